@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/config"
+	"github.com/hannasdev/kanban-reports/internal/demo"
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/terminal"
+)
+
+// runDemo walks through each report type and kind of metrics on the sample
+// dataset with "demo", explaining each, so the tool can be tried before
+// exporting real data
+func runDemo(args []string) int {
+	fs := flag.NewFlagSet("demo", flag.ContinueOnError)
+	noPause := fs.Bool("no-pause", false, "Show all steps without waiting for Enter between them")
+	ascii := fs.Bool("ascii", false, "Use plain ASCII markers instead of emoji")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s demo [--no-pause] [--ascii]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	terminal.SetASCII(terminal.ASCIIMode(fs, *ascii))
+	stdout = terminal.Stdout()
+
+	dir, err := os.MkdirTemp("", "kanban-reports-demo-*")
+	if err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+	if err := demo.WriteFiles(dir, time.Now()); err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+
+	// Running in the directory of the files keeps the commands shown, and
+	// the item locations in the output, as short as on a real export
+	if wd, err := os.Getwd(); err == nil && os.Chdir(dir) == nil {
+		defer os.Chdir(wd)
+		dir = "."
+	}
+
+	// Pause between steps only when someone is reading along
+	var next func() bool
+	if !*noPause && terminal.StdinIsTerminal() && terminal.Height() > 0 {
+		scanner := bufio.NewScanner(os.Stdin)
+		next = func() bool {
+			fmt.Fprint(stdout, "\nPress Enter for the next report, or q to quit: ")
+			return scanner.Scan() && !strings.EqualFold(strings.TrimSpace(scanner.Text()), "q")
+		}
+	}
+
+	if err := demoTour(stdout, dir, next); err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// demoTour runs the steps of the demo on the files in dir, asking next before
+// each step after the first; a nil next doesn't wait
+func demoTour(w io.Writer, dir string, next func() bool) error {
+	fmt.Fprintln(w, "🎬 Kanban Reports - Demo")
+	fmt.Fprintln(w, "========================")
+	fmt.Fprintln(w, "A tour of the reports and metrics on a sample export of two teams, six")
+	fmt.Fprintln(w, "epics and 31 items. Each step shows the command that produces it; replace")
+	fmt.Fprintln(w, "sample.csv with your own export to try it on your data.")
+
+	// The dataset is read once; the steps differ only in what they generate
+	loadCfg, err := config.ParseArgs(demoArgs(demo.Step{Args: []string{"--type", "category"}, Categories: true}, dir))
+	if err != nil {
+		return err
+	}
+	items, warnings, err := loadItems(context.Background(), loadCfg, io.Discard)
+	if err != nil {
+		return fmt.Errorf("loading the demo dataset: %v", err)
+	}
+	issues := parseIssues(warnings)
+
+	for i, step := range demo.Steps {
+		if i > 0 && next != nil && !next() {
+			fmt.Fprintln(w, "\n👋 Demo stopped. Run it again at any time.")
+			return nil
+		}
+
+		fmt.Fprintf(w, "\n%s\n", strings.Repeat("=", 60))
+		fmt.Fprintf(w, "%d/%d  %s\n", i+1, len(demo.Steps), step.Title)
+		fmt.Fprintf(w, "%s\n", strings.Repeat("=", 60))
+		fmt.Fprintln(w, step.Explanation)
+		fmt.Fprintf(w, "\n$ %s %s\n\n", os.Args[0], strings.Join(step.Command(dir), " "))
+
+		cfg, err := config.ParseArgs(demoArgs(step, dir))
+		if err != nil {
+			return fmt.Errorf("%s: %v", step.Title, err)
+		}
+		output, err := generateOutput(context.Background(), cfg, items, issues)
+		if err != nil {
+			fmt.Fprintf(w, "❌ Error %v\n", err)
+			continue
+		}
+		fmt.Fprintln(w, output)
+	}
+
+	fmt.Fprintln(w, "\n🎉 That was every report and kind of metrics. Next steps:")
+	fmt.Fprintf(w, "   • Describe your own export once: %s setup\n", os.Args[0])
+	fmt.Fprintf(w, "   • Build a report step by step: %s --interactive\n", os.Args[0])
+	fmt.Fprintf(w, "   • See all options: %s --help\n", os.Args[0])
+	return nil
+}
+
+// demoArgs returns the options of a step's run on the files in dir. The
+// delimiter is given so detection doesn't announce itself.
+func demoArgs(step demo.Step, dir string) []string {
+	return append(step.Command(dir), "--delimiter", models.DelimiterComma.Name)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/demo"
+)
+
+func TestDemoTour(t *testing.T) {
+	dir := t.TempDir()
+	if err := demo.WriteFiles(dir, time.Now()); err != nil {
+		t.Fatalf("WriteFiles() error = %v", err)
+	}
+
+	var out strings.Builder
+	if err := demoTour(&out, dir, nil); err != nil {
+		t.Fatalf("demoTour() error = %v", err)
+	}
+	for _, step := range demo.Steps {
+		if !strings.Contains(out.String(), step.Title) {
+			t.Errorf("demo output is missing the step %q", step.Title)
+		}
+	}
+	if strings.Contains(out.String(), "❌") {
+		t.Errorf("demo output has errors:\n%s", out.String())
+	}
+
+	// Declining to go on stops after the first step
+	out.Reset()
+	if err := demoTour(&out, dir, func() bool { return false }); err != nil {
+		t.Fatalf("demoTour() error = %v", err)
+	}
+	if !strings.Contains(out.String(), demo.Steps[0].Title) || strings.Contains(out.String(), demo.Steps[1].Title) || !strings.Contains(out.String(), "Demo stopped") {
+		t.Errorf("demoTour() didn't stop after the first step:\n%s", out.String())
+	}
+}
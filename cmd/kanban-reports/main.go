@@ -1,41 +1,108 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/hannasdev/kanban-reports/internal/charts"
+	"github.com/hannasdev/kanban-reports/internal/compare"
 	"github.com/hannasdev/kanban-reports/internal/config"
+	"github.com/hannasdev/kanban-reports/internal/lockfile"
 	"github.com/hannasdev/kanban-reports/internal/menu"
 	"github.com/hannasdev/kanban-reports/internal/metrics"
+	"github.com/hannasdev/kanban-reports/internal/models"
 	"github.com/hannasdev/kanban-reports/internal/parser"
+	"github.com/hannasdev/kanban-reports/internal/quality"
+	"github.com/hannasdev/kanban-reports/internal/render"
 	"github.com/hannasdev/kanban-reports/internal/reports"
+	"github.com/hannasdev/kanban-reports/internal/retention"
+	"github.com/hannasdev/kanban-reports/internal/schema"
+	"github.com/hannasdev/kanban-reports/internal/signing"
+	"github.com/hannasdev/kanban-reports/internal/snapshot"
+	"github.com/hannasdev/kanban-reports/pkg/filtering"
+	"github.com/hannasdev/kanban-reports/pkg/terminal"
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
+// stdout receives console output; it uses plain ASCII markers when requested
+var stdout io.Writer = os.Stdout
+
 func main() {
+	// Subcommands take their own arguments and skip the report flags
+	if len(os.Args) > 1 && os.Args[1] == "compare" {
+		os.Exit(runCompare(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		os.Exit(runSchema(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		os.Exit(runExplain(os.Args[2:], os.Stdout))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		os.Exit(runServe(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfig(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "demo" {
+		os.Exit(runDemo(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "setup" {
+		os.Exit(runSetup(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "install-service" {
+		os.Exit(runInstallService(os.Args[2:]))
+	}
+	if firstRun() {
+		os.Exit(runFirstRun())
+	}
+
 	var cfg *config.Config
 	var err error
+	var menuSystem *menu.Menu
 	
 	// Parse initial configuration
 	cfg, err = config.ParseFlags()
+	stdout = terminal.Stdout()
 	if err != nil {
 		// Enhanced error output with helpful suggestions
-		fmt.Printf("❌ Error: %v\n", err)
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
 		os.Exit(1)
 	}
 	
 	// Check if interactive mode was requested
 	if cfg.Interactive {
-		fmt.Println("🎯 Starting Interactive Mode...")
-		menuSystem := menu.NewMenu()
+		fmt.Fprintln(stdout, "🎯 Starting Interactive Mode...")
+		menuSystem = menu.NewMenu()
+		if cfg.AnswersPath != "" {
+			answers, err := openAnswers(cfg.AnswersPath)
+			if err != nil {
+				fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			defer answers.Close()
+			menuSystem = menu.NewScriptedMenu(answers, stdout)
+		}
+		menuSystem.WithPreview(previewOutput())
 		cfg, err = menuSystem.Run()
 		if err != nil {
 			// Check if it's a quit error
 			if quitErr, ok := err.(menu.QuitError); ok {
-				fmt.Printf("\n👋 %s. Goodbye!\n", quitErr.Message)
+				fmt.Fprintf(stdout, "\n👋 %s. Goodbye!\n", quitErr.Message)
 				os.Exit(0)
 			}
-			fmt.Printf("❌ Error: %v\n", err)
+			fmt.Fprintf(stdout, "❌ Error: %v\n", err)
 			os.Exit(1)
 		}
 		
@@ -43,126 +110,1223 @@ func main() {
 		menuSystem.ShowSummary(cfg)
 	} else {
 		// CLI mode - show what we're doing
-		fmt.Printf("🔄 Kanban Reports - CLI Mode\n")
-		fmt.Printf("============================\n")
+		fmt.Fprintf(stdout, "🔄 Kanban Reports - CLI Mode\n")
+		fmt.Fprintf(stdout, "============================\n")
 		showConfigSummary(cfg)
 	}
 
+	// Ctrl+C and --timeout stop loading and generating promptly. The prompts
+	// before and after keep the default handling.
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := withTimeout(ctx, cfg)
+
 	// Parse CSV file
-	fmt.Printf("\n📁 Loading kanban data from: %s\n", cfg.CSVPath)
-	csvParser := parser.NewCSVParser(cfg.CSVPath)
-	
-	// Set delimiter from config
-	csvParser.WithDelimiter(cfg.Delimiter)
-	
-	items, err := csvParser.Parse()
-	if err != nil {
-		fmt.Printf("❌ Error parsing CSV: %v\n", err)
-		fmt.Printf("\n💡 Troubleshooting tips:\n")
-		fmt.Printf("   • Check that the file exists and is readable\n")
-		fmt.Printf("   • Ensure required columns are present: id, name, estimate, is_completed, completed_at\n")
-		fmt.Printf("   • Try different delimiter with --delimiter option\n")
-		fmt.Printf("   • For help: %s --help\n", os.Args[0])
+	items, warnings, err := loadItems(ctx, cfg, stdout)
+	if err != nil {
+		exitIfStopped(err, cfg)
+		fmt.Fprintf(stdout, "❌ Error parsing CSV: %v\n", err)
+		if errors.Is(err, parser.ErrTooManyRowErrors) {
+			fmt.Fprintf(stdout, "\n💡 The report was not generated because too much of the data is invalid.\n")
+			fmt.Fprintf(stdout, "   • Fix the rows listed in the warnings above\n")
+			fmt.Fprintf(stdout, "   • Or raise the limit with --max-errors (-1 for no limit)\n")
+			os.Exit(1)
+		}
+		fmt.Fprintf(stdout, "\n💡 Troubleshooting tips:\n")
+		fmt.Fprintf(stdout, "   • Check that the file exists and is readable\n")
+		fmt.Fprintf(stdout, "   • Ensure required columns are present: id, name, estimate, is_completed, completed_at\n")
+		fmt.Fprintf(stdout, "   • Try different delimiter with --delimiter option\n")
+		fmt.Fprintf(stdout, "   • For help: %s --help\n", os.Args[0])
 		os.Exit(1)
 	}
 
-	fmt.Printf("✅ Loaded %d kanban items\n", len(items))
-
-	// Generate report or metrics
-	fmt.Printf("\n⚙️  Generating output...\n")
-	
-	var outputContent string
-	
-	if cfg.IsMetricsReport() {
-		// Generate metrics using the metrics package
-		metricsGenerator := metrics.NewGenerator(items)
-		metricsGenerator.WithAdHocFilter(cfg.AdHocFilter)
-
-		startDate, endDate := cfg.GetDateRange()
-		outputContent, err = metricsGenerator.Generate(cfg.MetricsType, cfg.PeriodType, startDate, endDate, cfg.FilterField)
-		if err != nil {
-			fmt.Printf("❌ Error generating metrics: %v\n", err)
+	// Write machine-readable warnings for automation
+	if cfg.WarningsFile != "" {
+		if err := writeWarnings(cfg.WarningsFile, warnings); err != nil {
+			fmt.Fprintf(stdout, "❌ Error writing warnings: %v\n", err)
 			os.Exit(1)
 		}
-	} else {
-		// Generate regular report using the reports package
-		reporter := reports.NewReporter(items)
-		reporter.WithAdHocFilter(cfg.AdHocFilter)
+	}
 
-		startDate, endDate := cfg.GetDateRange()
-		outputContent, err = reporter.GenerateReport(cfg.ReportType, startDate, endDate, cfg.FilterField)
+	// Hold the lock of the output file while generating it, so a slow run
+	// and the next scheduled one can't write the same file at once
+	var outputLock *lockfile.Lock
+	if cfg.OutputPath != "" {
+		outputLock, err = lockfile.Acquire(cfg.OutputPath)
 		if err != nil {
-			fmt.Printf("❌ Error generating report: %v\n", err)
+			fmt.Fprintf(stdout, "❌ Error: %v\n", err)
 			os.Exit(1)
 		}
 	}
 
+	// Generate report or metrics
+	fmt.Fprintf(stdout, "\n⚙️  Generating output...\n")
+	
+	outputContent, err := generateOutput(ctx, cfg, items, parseIssues(warnings))
+	var chartPaths []string
+	if err == nil && cfg.ChartsDir != "" {
+		chartPaths, err = writeCharts(ctx, cfg, items)
+	}
+	cancel()
+	stopSignals()
+	if err != nil {
+		exitIfStopped(err, cfg)
+		fmt.Fprintf(stdout, "❌ Error %v\n", err)
+		os.Exit(1)
+	}
+	if len(chartPaths) > 0 {
+		fmt.Fprintf(stdout, "🖼️  Charts saved to: %s\n", strings.Join(chartPaths, ", "))
+	}
+
+	// Console output too long for the screen is shown a screen at a time. In
+	// interactive mode it may be saved to a file instead; scripted answers
+	// don't include that question, so they are left out.
+	pager := consolePager(cfg)
+	if cfg.OutputPath == "" && pager != nil && !pager.Fits(outputContent) && menuSystem != nil && cfg.AnswersPath == "" {
+		if path, err := menuSystem.AskSaveLongOutput(pager.Lines(outputContent)); err == nil && path != "" {
+			cfg.OutputPath = path
+		}
+	}
+
 	// Output report
 	if cfg.OutputPath != "" {
 		// Save to file
-		err = os.WriteFile(cfg.OutputPath, []byte(outputContent), 0644)
+		saved := "saved"
+		if cfg.Append {
+			err = appendOutput(cfg, outputContent, time.Now())
+			saved = "appended"
+		} else {
+			err = writeOutput(cfg.OutputPath, outputContent, cfg.NoOverwrite)
+		}
+		outputLock.Release()
 		if err != nil {
-			fmt.Printf("❌ Error writing output to file: %v\n", err)
+			fmt.Fprintf(stdout, "❌ Error writing output to file: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Printf("✅ Output saved to: %s\n", cfg.OutputPath)
+		fmt.Fprintf(stdout, "✅ Output %s to: %s\n", saved, cfg.OutputPath)
+
+		if cfg.Sign {
+			if err := signOutput(cfg.OutputPath, cfg.MinisignKey); err != nil {
+				fmt.Fprintf(stdout, "❌ Error signing output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Remove earlier outputs of the template outside the retention policy.
+		// The new output is saved, so a failure here is only a warning.
+		removed, err := retention.Prune(cfg.OutputGlob, cfg.OutputPath, cfg.Retention, time.Now())
+		if err != nil {
+			fmt.Fprintf(stdout, "⚠️  Error removing old outputs: %v\n", err)
+		}
+		if len(removed) > 0 {
+			fmt.Fprintf(stdout, "🧹 Removed %d old outputs: %s\n", len(removed), strings.Join(removed, ", "))
+		}
 		
 		// Also show a preview in console
-		fmt.Printf("\n📋 Preview (first 500 characters):\n")
-		fmt.Printf("%s\n", strings.Repeat("=", 50))
-		preview := outputContent
-		if len(preview) > 500 {
-			preview = preview[:500] + "...\n\n[Full report saved to file]"
+		if !cfg.NonInteractive {
+			fmt.Fprintf(stdout, "\n📋 Preview (first 500 characters):\n")
+			fmt.Fprintf(stdout, "%s\n", strings.Repeat("=", 50))
+			preview := outputContent
+			if len(preview) > 500 {
+				preview = preview[:500] + "...\n\n[Full report saved to file]"
+			}
+			fmt.Fprintf(stdout, "%s\n", preview)
 		}
-		fmt.Printf("%s\n", preview)
 	} else {
 		// Print to console
-		fmt.Printf("\n%s\n", strings.Repeat("=", 60))
-		fmt.Printf("📊 RESULTS\n")
-		fmt.Printf("%s\n", strings.Repeat("=", 60))
-		fmt.Printf("%s\n", outputContent)
+		fmt.Fprintf(stdout, "\n%s\n", strings.Repeat("=", 60))
+		fmt.Fprintf(stdout, "📊 RESULTS\n")
+		fmt.Fprintf(stdout, "%s\n", strings.Repeat("=", 60))
+		if pager != nil && !pager.Fits(outputContent) {
+			pager.Page(outputContent)
+		} else {
+			fmt.Fprintf(stdout, "%s\n", outputContent)
+		}
 		
 		// Show helpful next steps
-		fmt.Printf("\n💡 Next steps:\n")
-		fmt.Printf("   • Save to file: add --output filename.txt\n")
-		fmt.Printf("   • Try different time periods: --last 7, --last 30, --last 90\n")
-		fmt.Printf("   • Explore other report types: %s --examples\n", os.Args[0])
+		if !cfg.NonInteractive {
+			fmt.Fprintf(stdout, "\n💡 Next steps:\n")
+			fmt.Fprintf(stdout, "   • Save to file: add --output filename.txt\n")
+			fmt.Fprintf(stdout, "   • Try different time periods: --last 7, --last 30, --last 90\n")
+			fmt.Fprintf(stdout, "   • Explore other report types: %s --examples\n", os.Args[0])
+		}
 	}
 	
-	fmt.Printf("\n🎉 Report generation complete!\n")
+	fmt.Fprintf(stdout, "\n🎉 Report generation complete!\n")
+}
+
+// loadItems parses the CSV file and applies the state history, reopened item
+// policy, categories and --filter, reporting progress on out. The warnings are
+// the data-quality findings of parsing, followed by the reopened items.
+func loadItems(ctx context.Context, cfg *config.Config, out io.Writer) ([]models.KanbanItem, []quality.Issue, error) {
+	paths := cfg.CSVFiles()
+	if len(paths) > 1 {
+		fmt.Fprintf(out, "\n📁 Loading kanban data from %d files: %s\n", len(paths), strings.Join(paths, ", "))
+	} else {
+		fmt.Fprintf(out, "\n📁 Loading kanban data from: %s\n", cfg.CSVPath)
+	}
+	csvParser := parser.NewCSVParser(cfg.CSVPath)
+	
+	// Set delimiter from config
+	csvParser.WithDelimiter(cfg.Delimiter)
+	csvParser.WithColumnMap(cfg.ColumnMap)
+	csvParser.WithEstimateMapping(cfg.EstimateMapping)
+	csvParser.WithDecimalSeparator(cfg.DecimalSeparator)
+	csvParser.WithDateFormat(cfg.DateFormat)
+	csvParser.WithMaxErrors(cfg.MaxErrors)
+	csvParser.WithOutput(out)
+	if !cfg.BoolTokens.IsZero() {
+		csvParser.WithBoolTokens(cfg.BoolTokens)
+	}
+	
+	items, err := loadFiles(ctx, csvParser, cfg.CSVSources(), out)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fmt.Fprintf(out, "✅ Loaded %d kanban items\n", len(items))
+
+	if len(cfg.History) > 0 {
+		var withHistory int
+		items, withHistory = cfg.History.Apply(items)
+		fmt.Fprintf(out, "✅ Found state history for %d of %d items\n", withHistory, len(items))
+	}
+
+	// Apply the reopened item policy before any completion-based filtering,
+	// after the history that shows when items were completed again
+	reopenedPolicy := cfg.Reopened
+	if reopenedPolicy == "" {
+		reopenedPolicy = types.ReopenedExclude
+	}
+	items, reopenedCount := filtering.ApplyReopenedPolicy(items, reopenedPolicy)
+	if len(cfg.Categories) > 0 {
+		items = cfg.Categories.Apply(items)
+	}
+	if cfg.Filter != nil {
+		loaded := len(items)
+		items = cfg.Filter.Filter(items)
+		fmt.Fprintf(out, "🔎 Filter kept %d of %d items\n", len(items), loaded)
+	}
+	warnings := csvParser.Issues()
+	if reopenedCount > 0 {
+		fmt.Fprintf(out, "⚠️  Found %d reopened items (completed_at set but not completed); policy: %s\n", reopenedCount, reopenedPolicy)
+		warnings = append(warnings, quality.Issue{
+			Source:  quality.SourceFilter,
+			Value:   string(reopenedPolicy),
+			Message: fmt.Sprintf("%d reopened items (completed_at set but not completed)", reopenedCount),
+		})
+	}
+	return items, warnings, nil
+}
+
+// previewOutput returns the preview of the interactive format step, which
+// generates the output of the configuration chosen so far. The items are
+// loaded quietly, once.
+func previewOutput() menu.Previewer {
+	var items []models.KanbanItem
+	var issues []quality.Issue
+	loaded := false
+	return func(cfg *config.Config) (string, error) {
+		if !loaded {
+			loadedItems, warnings, err := loadItems(context.Background(), cfg, io.Discard)
+			if err != nil {
+				return "", err
+			}
+			items, issues, loaded = loadedItems, parseIssues(warnings), true
+		}
+		return generateOutput(context.Background(), cfg, items, issues)
+	}
+}
+
+// loadFiles parses the CSV files, each with its own delimiter and column
+// renames if given. Items of several files are merged: one per ID, the most
+// recently updated, since teams export each board separately and a story can
+// be on more than one.
+func loadFiles(ctx context.Context, csvParser *parser.CSVParser, sources []parser.FileSource, out io.Writer) ([]models.KanbanItem, error) {
+	if len(sources) == 1 && sources[0].Delimiter.Name == "" && len(sources[0].ColumnMap) == 0 {
+		return csvParser.ParseContext(ctx)
+	}
+
+	items, err := csvParser.ParseSourcesContext(ctx, sources)
+	if err != nil || len(sources) == 1 {
+		return items, err
+	}
+
+	items, duplicates := parser.MergeDuplicates(items)
+	if duplicates > 0 {
+		fmt.Fprintf(out, "🔁 Merged %d duplicate items found in several files, keeping the most recently updated\n", duplicates)
+	}
+	return items, nil
+}
+
+// withTimeout bounds a run by --timeout, if given
+func withTimeout(ctx context.Context, cfg *config.Config) (context.Context, context.CancelFunc) {
+	if cfg.Timeout > 0 {
+		return context.WithTimeout(ctx, cfg.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// stoppedMessage describes why a run was stopped early, or returns "" for
+// other errors
+func stoppedMessage(err error, cfg *config.Config) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Sprintf("the run took longer than --timeout %s", cfg.Timeout)
+	case errors.Is(err, context.Canceled):
+		return "the run was cancelled"
+	}
+	return ""
+}
+
+// exitIfStopped ends a run stopped by Ctrl+C or --timeout, without the
+// troubleshooting tips meant for other errors
+func exitIfStopped(err error, cfg *config.Config) {
+	message := stoppedMessage(err, cfg)
+	if message == "" {
+		return
+	}
+	fmt.Fprintf(stdout, "\n❌ Error: %s\n", message)
+	if errors.Is(err, context.Canceled) {
+		os.Exit(130) // Like a shell reports an interrupted command
+	}
+	os.Exit(1)
+}
+
+// parseIssues returns the warnings found while parsing, leaving out those
+// from filtering
+func parseIssues(warnings []quality.Issue) []quality.Issue {
+	var issues []quality.Issue
+	for _, issue := range warnings {
+		if issue.Source == quality.SourceParser {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// generateOutput generates the requested report and metrics in the output format
+func generateOutput(ctx context.Context, cfg *config.Config, items []models.KanbanItem, issues []quality.Issue) (string, error) {
+	combine := combineText
+	switch cfg.Format {
+	case types.FormatJSON:
+		combine = combineJSON
+	case types.FormatMarkdown, types.FormatHTML:
+		combine = combineDocument
+	case types.FormatProblems:
+		combine = combineProblems
+	case types.FormatSVG:
+		combine = combineSVG
+	}
+	if cfg.CompareWith != "" {
+		combine = combineComparison
+	}
+	if cfg.Export == types.ExportItemsJSON {
+		combine = combineItems
+	}
+	if cfg.BaselinePath != "" {
+		combine = combineDiff
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return combine(ctx, cfg, items, issues)
+}
+
+// consolePager returns a pager for console output, or nil when stdout is not
+// a terminal, prompts are not allowed or paging is turned off
+func consolePager(cfg *config.Config) *terminal.Pager {
+	height := terminal.Height()
+	if height == 0 || cfg.NoPager || cfg.NonInteractive {
+		return nil
+	}
+	return terminal.NewPager(os.Stdin, stdout, height)
+}
+
+// newReporter creates a reporter configured from the command line
+func newReporter(cfg *config.Config, items []models.KanbanItem) *reports.Reporter {
+	reporter := reports.NewReporter(items)
+	reporter.WithAdHocFilter(cfg.AdHocFilter)
+	reporter.WithAdHocRules(cfg.AdHocRules)
+	reporter.WithHierarchy(cfg.Hierarchy)
+	reporter.WithUnit(cfg.Unit)
+	reporter.WithProductAreaMode(cfg.ProductAreaMode)
+	reporter.WithSeparator(cfg.Separator)
+	reporter.WithMinEpics(cfg.MinEpics)
+	reporter.WithEpic(cfg.Epic)
+	reporter.WithLabelPrefix(cfg.LabelPrefix)
+	reporter.WithGroupBy(cfg.GroupBy)
+	reporter.WithPivot(cfg.PivotRows, cfg.PivotCols)
+	reporter.WithAllocateBy(cfg.AllocateBy)
+	reporter.WithDetail(cfg.Detail, cfg.Top)
+	reporter.WithFormat(cfg.Format)
+	return reporter
+}
+
+// generateReport generates a regular report using the reports package
+func generateReport(cfg *config.Config, items []models.KanbanItem) (string, error) {
+	reporter := newReporter(cfg, items)
+	startDate, endDate := cfg.GetDateRange()
+	if len(cfg.ReportTypes) > 1 {
+		return reporter.GenerateReports(cfg.ReportTypes, startDate, endDate, cfg.FilterField)
+	}
+	return reporter.GenerateReport(cfg.ReportType, startDate, endDate, cfg.FilterField)
+}
+
+// newGenerator creates a metrics generator configured from the command line
+func newGenerator(ctx context.Context, cfg *config.Config, items []models.KanbanItem) *metrics.Generator {
+	metricsGenerator := metrics.NewGenerator(items)
+	metricsGenerator.WithAdHocFilter(cfg.AdHocFilter)
+	metricsGenerator.WithAdHocRules(cfg.AdHocRules)
+	metricsGenerator.WithStats(cfg.Stats)
+	metricsGenerator.WithUnit(cfg.Unit)
+	metricsGenerator.WithWeekNumbering(cfg.WeekNumbering)
+	metricsGenerator.WithPeriodLabels(cfg.PeriodLabels)
+	metricsGenerator.WithHistogramBuckets(cfg.HistogramBuckets)
+	metricsGenerator.WithHolidays(cfg.Holidays)
+	metricsGenerator.WithAbsences(cfg.Absences)
+	metricsGenerator.WithAnnotations(cfg.Annotations)
+	metricsGenerator.WithAgeThresholds(cfg.AgeThresholds)
+	metricsGenerator.WithWIPLimits(cfg.WIPLimits)
+	metricsGenerator.WithSections(cfg.MetricsSections)
+	metricsGenerator.WithSplitBy(cfg.SplitBy)
+	metricsGenerator.WithSeparator(cfg.Separator)
+	metricsGenerator.WithWidth(tableWidth(cfg))
+	metricsGenerator.WithExplanations(!cfg.NoExplanations)
+	metricsGenerator.WithDigestSettings(cfg.DigestSettings)
+	metricsGenerator.WithAgeMode(cfg.AgeMode)
+	metricsGenerator.WithIterations(cfg.Iterations)
+	metricsGenerator.WithForecast(cfg.Forecast)
+	metricsGenerator.WithFormat(cfg.Format)
+	metricsGenerator.WithContext(ctx)
+	return metricsGenerator
+}
+
+// generateMetrics generates metrics using the metrics package
+func generateMetrics(ctx context.Context, cfg *config.Config, items []models.KanbanItem) (string, error) {
+	startDate, endDate := cfg.GetDateRange()
+	return newGenerator(ctx, cfg, items).Generate(cfg.MetricsType, cfg.PeriodType, startDate, endDate, cfg.FilterField)
+}
+
+// combineText generates the requested report and metrics as text, followed by
+// the data-quality findings when requested
+func combineText(ctx context.Context, cfg *config.Config, items []models.KanbanItem, issues []quality.Issue) (string, error) {
+	var outputContent string
+
+	if cfg.Both || !cfg.IsMetricsReport() {
+		report, err := generateReport(cfg, items)
+		if err != nil {
+			return "", fmt.Errorf("generating report: %v", err)
+		}
+		outputContent = report
+	}
+
+	if cfg.IsMetricsReport() {
+		metricsContent, err := generateMetrics(ctx, cfg, items)
+		if err != nil {
+			return "", fmt.Errorf("generating metrics: %v", err)
+		}
+		if outputContent != "" {
+			outputContent += "\n\n" + sectionSeparator(cfg) + "\n\n"
+		}
+		outputContent += metricsContent
+	}
+
+	// Append the data-quality findings from parsing
+	if cfg.DataQuality {
+		outputContent += "\n\n" + sectionSeparator(cfg) + "\n\n" + quality.FormatReport(issues)
+	}
+
+	if cfg.ASCII {
+		outputContent = terminal.Plain(outputContent)
+	}
+	return outputContent, nil
+}
+
+// jsonOutput is the JSON document written when a report, metrics or the
+// data-quality findings are requested together
+type jsonOutput struct {
+	Report      json.RawMessage `json:"report,omitempty"`
+	Metrics     json.RawMessage `json:"metrics,omitempty"`
+	DataQuality json.RawMessage `json:"data_quality,omitempty"`
+}
+
+// combineJSON generates the requested parts as JSON. A single report or
+// metrics document is written as is; several parts are combined into one
+// object. ASCII markers don't apply, since JSON holds no emoji markers.
+func combineJSON(ctx context.Context, cfg *config.Config, items []models.KanbanItem, issues []quality.Issue) (string, error) {
+	var output jsonOutput
+	parts := 0
+
+	if cfg.Both || !cfg.IsMetricsReport() {
+		report, err := generateReport(cfg, items)
+		if err != nil {
+			return "", fmt.Errorf("generating report: %v", err)
+		}
+		output.Report = json.RawMessage(report)
+		parts++
+	}
+
+	if cfg.IsMetricsReport() {
+		metricsContent, err := generateMetrics(ctx, cfg, items)
+		if err != nil {
+			return "", fmt.Errorf("generating metrics: %v", err)
+		}
+		output.Metrics = json.RawMessage(metricsContent)
+		parts++
+	}
+
+	if cfg.DataQuality {
+		var buf bytes.Buffer
+		if err := quality.WriteJSON(&buf, issues); err != nil {
+			return "", err
+		}
+		output.DataQuality = buf.Bytes()
+		parts++
+	}
+
+	if parts == 1 && !cfg.DataQuality {
+		return string(output.Report) + string(output.Metrics), nil
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// combineDocument generates the requested parts as one document rendered as
+// Markdown or HTML, followed by the data-quality findings when requested
+func combineDocument(ctx context.Context, cfg *config.Config, items []models.KanbanItem, issues []quality.Issue) (string, error) {
+	renderer, err := render.ForFormat(cfg.Format)
+	if err != nil {
+		return "", err
+	}
+
+	var doc render.ReportDocument
+	startDate, endDate := cfg.GetDateRange()
+
+	if cfg.Both || !cfg.IsMetricsReport() {
+		reportTypes := cfg.ReportTypes
+		if len(reportTypes) <= 1 {
+			reportTypes = []reports.ReportType{cfg.ReportType}
+		}
+		doc, err = newReporter(cfg, items).Document(reportTypes, startDate, endDate, cfg.FilterField)
+		if err != nil {
+			return "", fmt.Errorf("generating report: %v", err)
+		}
+	}
+
+	if cfg.IsMetricsReport() {
+		metricsDoc, err := newGenerator(ctx, cfg, items).Document(cfg.MetricsType, cfg.PeriodType, startDate, endDate, cfg.FilterField)
+		if err != nil {
+			return "", fmt.Errorf("generating metrics: %v", err)
+		}
+		if doc.Title == "" {
+			doc = metricsDoc
+		} else {
+			doc.Append(metricsDoc)
+		}
+	}
+
+	if cfg.DataQuality {
+		doc.Sections = append(doc.Sections, render.Section{Name: "data-quality", Blocks: render.Parse(quality.FormatReport(issues))})
+	}
+	if cfg.ChartsDir != "" {
+		section, err := chartsSection(ctx, cfg, items)
+		if err != nil {
+			return "", err
+		}
+		doc.Sections = append(doc.Sections, section)
+	}
+	if cfg.HTMLFilters {
+		// The filters come first, so readers find them without scrolling
+		explorer := newReporter(cfg, items).ExplorerSection(startDate, endDate, cfg.FilterField)
+		doc.Sections = append([]render.Section{explorer}, doc.Sections...)
+	}
+
+	outputContent := renderer.Render(doc)
+	if cfg.ASCII {
+		outputContent = terminal.Plain(outputContent)
+	}
+	return outputContent, nil
+}
+
+// chartsSection shows the charts saved by writeCharts, linked relative to the
+// output file so the document and the charts directory can be moved together
+func chartsSection(ctx context.Context, cfg *config.Config, items []models.KanbanItem) (render.Section, error) {
+	startDate, endDate := cfg.GetDateRange()
+	drawn, err := newGenerator(ctx, cfg, items).Charts(cfg.PeriodType, startDate, endDate, cfg.FilterField)
+	if err != nil {
+		return render.Section{}, fmt.Errorf("generating charts: %v", err)
+	}
+
+	section := render.Section{Name: "charts", Blocks: []render.Block{render.Heading{Level: 1, Text: "Charts"}}}
+	for _, chart := range drawn {
+		path := filepath.Join(cfg.ChartsDir, chart.FileName())
+		if relative, err := filepath.Rel(filepath.Dir(cfg.OutputPath), path); err == nil {
+			path = relative
+		}
+		section.Blocks = append(section.Blocks, render.Image{Src: filepath.ToSlash(path), Alt: chart.Title})
+	}
+	return section, nil
+}
+
+// writeCharts saves the throughput, cumulative flow and scatterplot charts in
+// the charts directory and returns their paths
+func writeCharts(ctx context.Context, cfg *config.Config, items []models.KanbanItem) ([]string, error) {
+	startDate, endDate := cfg.GetDateRange()
+	drawn, err := newGenerator(ctx, cfg, items).Charts(cfg.PeriodType, startDate, endDate, cfg.FilterField)
+	if err != nil {
+		return nil, fmt.Errorf("generating charts: %v", err)
+	}
+	paths, err := charts.Write(cfg.ChartsDir, drawn)
+	if err != nil {
+		return nil, fmt.Errorf("saving charts: %v", err)
+	}
+	return paths, nil
+}
+
+// combineComparison generates the requested report and metrics for the
+// baseline range of --compare-with and for the date range, and shows the
+// change of every value found in both. The runs are compared as JSON, like the
+// compare command does.
+func combineComparison(ctx context.Context, cfg *config.Config, items []models.KanbanItem, issues []quality.Issue) (string, error) {
+	baseline := comparisonRun(cfg)
+	baseline.StartDate, baseline.EndDate = cfg.CompareStart, cfg.CompareEnd
+	before, err := comparisonValues(ctx, baseline, items)
+	if err != nil {
+		return "", fmt.Errorf("baseline range: %v", err)
+	}
+	after, err := comparisonValues(ctx, comparisonRun(cfg), items)
+	if err != nil {
+		return "", err
+	}
+
+	deltas := compare.Compare(before, after)
+	matched := compare.Matched(deltas)
+	baselineRange := render.DateRangeField(cfg.CompareStart, cfg.CompareEnd).Value
+	primaryRange := render.DateRangeField(cfg.StartDate, cfg.EndDate).Value
+	var report string
+	if cfg.Format == types.FormatText {
+		report = compare.FormatReport(matched, baselineRange+" (baseline)", primaryRange, compare.DefaultThreshold)
+	} else {
+		// Documents name the ranges in their fields
+		report = "# Comparison\n\n" + compare.FormatDeltas(matched, compare.DefaultThreshold)
+	}
+	if left := len(deltas) - len(matched); left > 0 {
+		report += fmt.Sprintf("\n%d values found in one range only, such as its periods, are left out.\n", left)
+	}
+	if cfg.DataQuality {
+		report += "\n" + sectionSeparator(cfg) + "\n\n" + quality.FormatReport(issues)
+	}
+
+	if cfg.Format != types.FormatText {
+		renderer, err := render.ForFormat(cfg.Format)
+		if err != nil {
+			return "", err
+		}
+		doc := render.ReportDocument{
+			Title:    "Kanban Comparison",
+			Fields:   []render.Field{{Label: "Baseline", Value: baselineRange}, {Label: "Date Range", Value: primaryRange}},
+			Sections: []render.Section{{Name: "comparison", Blocks: render.Parse(report)}},
+		}
+		report = renderer.Render(doc)
+	}
+	if cfg.ASCII {
+		report = terminal.Plain(report)
+	}
+	return report, nil
+}
+
+// comparisonRun copies the configuration to generate one side of a
+// comparison as JSON, without explanations or data-quality findings
+func comparisonRun(cfg *config.Config) *config.Config {
+	run := *cfg
+	run.Format = types.FormatJSON
+	run.NoExplanations = true
+	run.DataQuality = false
+	return &run
+}
+
+// comparisonValues generates the run as JSON and collects its numeric values
+func comparisonValues(ctx context.Context, run *config.Config, items []models.KanbanItem) (compare.Values, error) {
+	output, err := combineJSON(ctx, run, items, nil)
+	if err != nil {
+		return nil, err
+	}
+	return compare.Parse(strings.NewReader(output))
+}
+
+// combineDiff lists the changes to the items since the --baseline export,
+// which is read with the same settings as the CSV. Reports and metrics are
+// left out.
+func combineDiff(ctx context.Context, cfg *config.Config, items []models.KanbanItem, issues []quality.Issue) (string, error) {
+	baselineRun := *cfg
+	baselineRun.CSVPath, baselineRun.CSVPaths = cfg.BaselinePath, nil
+	baseline, _, err := loadItems(ctx, &baselineRun, io.Discard)
+	if err != nil {
+		return "", fmt.Errorf("parsing baseline: %v", err)
+	}
+	result := snapshot.Diff(baseline, items)
+
+	if cfg.Format == types.FormatJSON {
+		var buf strings.Builder
+		if err := snapshot.WriteJSON(&buf, result); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
+	renderer, err := render.ForFormat(cfg.Format)
+	if err != nil {
+		return "", err
+	}
+	doc := render.ReportDocument{
+		Title:    "Kanban Changes",
+		Fields:   []render.Field{{Label: "Baseline", Value: cfg.BaselinePath}, {Label: "Current", Value: strings.Join(cfg.CSVFiles(), ", ")}},
+		Sections: []render.Section{{Name: "changes", Blocks: snapshot.Blocks(result)}},
+	}
+	if cfg.DataQuality {
+		doc.Sections = append(doc.Sections, render.Section{Name: "data-quality", Blocks: render.Parse(quality.FormatReport(issues))})
+	}
+	output := renderer.Render(doc)
+	if cfg.ASCII {
+		output = terminal.Plain(output)
+	}
+	return output, nil
+}
+
+// combineProblems lists the data-quality findings for editors, pointing at the
+// lines of the CSV file. Reports and metrics are left out.
+func combineProblems(ctx context.Context, cfg *config.Config, items []models.KanbanItem, issues []quality.Issue) (string, error) {
+	return quality.FormatProblems(cfg.CSVPath, issues), nil
+}
+
+// combineSVG draws the cycle time scatterplot as an SVG image. The
+// data-quality findings are left out, since an image can't hold them.
+func combineSVG(ctx context.Context, cfg *config.Config, items []models.KanbanItem, issues []quality.Issue) (string, error) {
+	startDate, endDate := cfg.GetDateRange()
+	svg, err := newGenerator(ctx, cfg, items).ScatterplotSVG(startDate, endDate, cfg.FilterField)
+	if err != nil {
+		return "", fmt.Errorf("generating metrics: %v", err)
+	}
+	return svg, nil
+}
+
+// combineItems writes one JSON object per item with its computed measures,
+// as JSON Lines for data warehouses. Reports and metrics are left out.
+func combineItems(ctx context.Context, cfg *config.Config, items []models.KanbanItem, issues []quality.Issue) (string, error) {
+	startDate, endDate := cfg.GetDateRange()
+	var buf strings.Builder
+	if err := newGenerator(ctx, cfg, items).ExportItems(&buf, startDate, endDate, cfg.FilterField, time.Now()); err != nil {
+		return "", fmt.Errorf("exporting items: %v", err)
+	}
+	return buf.String(), nil
+}
+
+// sectionSeparator returns the line placed between sections of combined output
+func sectionSeparator(cfg *config.Config) string {
+	if cfg.Separator == "" {
+		return metrics.DefaultSeparator
+	}
+	return cfg.Separator
+}
+
+// runCompare prints the deltas between two JSON outputs and returns the exit code
+func runCompare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	threshold := fs.Float64("threshold", compare.DefaultThreshold, "Highlight changes of at least this many percent")
+	ascii := fs.Bool("ascii", false, "Use plain ASCII markers instead of emoji")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s compare [--threshold PCT] [--ascii] BEFORE.json AFTER.json\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	terminal.SetASCII(terminal.ASCIIMode(fs, *ascii))
+	stdout = terminal.Stdout()
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+	if *threshold < 0 {
+		fmt.Fprintf(stdout, "❌ Error: threshold must be 0 or more, got: %v\n", *threshold)
+		return 1
+	}
+
+	before, err := compare.Load(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+	after, err := compare.Load(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprint(stdout, compare.FormatReport(compare.Compare(before, after), fs.Arg(0), fs.Arg(1), *threshold))
+	return 0
+}
+
+// jsonSchemas are the JSON Schemas printed by the schema command, by output
+var jsonSchemas = map[string]func() (schema.Schema, error){
+	"report":       reports.JSONSchema,
+	"metrics":      metrics.JSONSchema,
+	"data-quality": quality.JSONSchema,
+	"changes":      snapshot.JSONSchema,
+	"items":        metrics.ItemsJSONSchema,
+}
+
+// runSchema prints the JSON Schema of one JSON output and returns the exit code
+func runSchema(args []string) int {
+	if len(args) != 1 || jsonSchemas[args[0]] == nil {
+		fmt.Fprintf(os.Stderr, "Usage: %s schema report|metrics|data-quality|items|changes\n", os.Args[0])
+		return 2
+	}
+
+	jsonSchema, err := jsonSchemas[args[0]]()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+		return 1
+	}
+	data, err := json.MarshalIndent(jsonSchema, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stdout, "%s\n", data)
+	return 0
+}
+
+// runExplain prints what a metric measures, its formulas and caveats with
+// "explain TERM", or lists the terms without one. Reports run with
+// --no-explanations leave this text out.
+func runExplain(args []string, w io.Writer) int {
+	if len(args) > 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s explain [%s]\n", os.Args[0], strings.Join(metrics.TermNames(), "|"))
+		return 2
+	}
+
+	if len(args) == 0 {
+		fmt.Fprintf(w, "Terms explained by %s explain TERM:\n\n", os.Args[0])
+		for _, term := range metrics.Glossary {
+			fmt.Fprintf(w, "  %-12s %s\n", term.Name, term.Title)
+		}
+		return 0
+	}
+
+	term, ok := metrics.LookupTerm(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "❌ Error: unknown term %q (available: %s)\n", args[0], strings.Join(metrics.TermNames(), ", "))
+		return 2
+	}
+	fmt.Fprint(w, term.Format())
+	return 0
+}
+
+// runConfig checks the profiles of a config file with "config validate",
+// explaining each wrong option, so mistakes surface before a run
+func runConfig(args []string) int {
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	configPath := fs.String("config", "", "YAML or TOML file of profiles to check")
+	profileName := fs.String("profile", "", "Check only this profile")
+	ascii := fs.Bool("ascii", false, "Use plain ASCII markers instead of emoji")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s config validate --config FILE [--profile NAME] [--ascii]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 || args[0] != "validate" {
+		fs.Usage()
+		return 2
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *configPath == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	terminal.SetASCII(terminal.ASCIIMode(fs, *ascii))
+	stdout = terminal.Stdout()
+
+	profiles, err := config.LoadProfiles(*configPath)
+	if err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+	if *profileName != "" {
+		profile, ok := profiles.Find(*profileName)
+		if !ok {
+			fmt.Fprintf(stdout, "❌ Error: unknown profile %q in %s (available: %s)\n", *profileName, *configPath, strings.Join(profiles.Names(), ", "))
+			return 1
+		}
+		profiles = config.Profiles{profile}
+	}
+	if len(profiles) == 0 {
+		fmt.Fprintf(stdout, "❌ Error: %s defines no profiles\n", *configPath)
+		return 1
+	}
+
+	report, valid := validateProfiles(*configPath, profiles)
+	fmt.Fprint(stdout, report)
+	if !valid {
+		return 1
+	}
+	return 0
+}
+
+// validateProfiles lists each profile with its problems as file:line: key:
+// message, and reports whether all of them are valid
+func validateProfiles(path string, profiles config.Profiles) (string, bool) {
+	var report strings.Builder
+	count := 0
+	for _, profile := range profiles {
+		problems := config.ValidateProfile(profile)
+		if len(problems) == 0 {
+			fmt.Fprintf(&report, "✅ %s\n", profile.Name)
+			continue
+		}
+		fmt.Fprintf(&report, "❌ %s\n", profile.Name)
+		for _, problem := range problems {
+			if problem.Key == "" {
+				fmt.Fprintf(&report, "   %s:%d: %s\n", path, problem.Line, problem.Message)
+			} else {
+				fmt.Fprintf(&report, "   %s:%d: %s: %s\n", path, problem.Line, problem.Key, problem.Message)
+			}
+		}
+		count += len(problems)
+	}
+
+	switch {
+	case count == 1:
+		fmt.Fprintf(&report, "\n❌ 1 problem in %s\n", path)
+	case count > 1:
+		fmt.Fprintf(&report, "\n❌ %d problems in %s\n", count, path)
+	case len(profiles) == 1:
+		fmt.Fprintf(&report, "\n✅ %s: the profile is valid\n", path)
+	default:
+		fmt.Fprintf(&report, "\n✅ %s: all %d profiles are valid\n", path, len(profiles))
+	}
+	return report.String(), count == 0
+}
+
+// openAnswers opens the scripted answers for the interactive menu ("-" for stdin)
+func openAnswers(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening answers file: %v", err)
+	}
+	return file, nil
+}
+
+// tableWidth returns the maximum width of wide tables: the --width value, or
+// the terminal width when printing to the console (no limit for files)
+func tableWidth(cfg *config.Config) int {
+	if cfg.Width > 0 {
+		return cfg.Width
+	}
+	if cfg.OutputPath != "" {
+		return 0
+	}
+	return terminal.Width()
+}
+
+// reportTypeNames returns the names of the given report types
+func reportTypeNames(reportTypes []reports.ReportType) []string {
+	names := make([]string, len(reportTypes))
+	for i, rt := range reportTypes {
+		names[i] = string(rt)
+	}
+	return names
+}
+
+// metricsSectionNames returns the metrics sections as a comma-separated list
+func metricsSectionNames(sections []metrics.MetricsType) string {
+	names := make([]string, len(sections))
+	for i, mt := range sections {
+		names[i] = string(mt)
+	}
+	return strings.Join(names, ", ")
+}
+
+// writeOutput saves the output to a file. With noOverwrite an existing file is
+// left untouched and an error is returned instead.
+func writeOutput(path, content string, noOverwrite bool) error {
+	if !noOverwrite {
+		return os.WriteFile(path, []byte(content), 0644)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%s already exists and --no-overwrite is set", path)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// journalEntry puts a header dated now above the run. After earlier runs in
+// the output file, a blank line and in Markdown a rule separate it from them.
+func journalEntry(cfg *config.Config, content string, now time.Time, earlier bool) string {
+	content = strings.TrimRight(content, "\n") + "\n"
+	header := "Run of " + now.Format("2006-01-02 15:04")
+	if cfg.Format == types.FormatMarkdown {
+		entry := "# " + header + "\n\n" + content
+		if earlier {
+			entry = "\n***\n\n" + entry
+		}
+		return entry
+	}
+	separator := sectionSeparator(cfg)
+	entry := separator + "\n" + header + "\n" + separator + "\n\n" + content
+	if earlier {
+		entry = "\n" + entry
+	}
+	return entry
+}
+
+// appendOutput adds the run to the end of the output file with a dated
+// header, creating the file for the first run
+func appendOutput(cfg *config.Config, content string, now time.Time) error {
+	info, err := os.Stat(cfg.OutputPath)
+	earlier := err == nil && info.Size() > 0
+
+	file, err := os.OpenFile(cfg.OutputPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(journalEntry(cfg, content, now, earlier)); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// signOutput writes the checksum of the output file and, given a key, its
+// minisign signature
+func signOutput(path, minisignKey string) error {
+	checksumPath, err := signing.WriteChecksum(path)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "🔏 Checksum saved to: %s\n", checksumPath)
+
+	if minisignKey == "" {
+		return nil
+	}
+	signaturePath, err := signing.Sign(path, minisignKey)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "🔏 Signature saved to: %s\n", signaturePath)
+	return nil
+}
+
+// retentionSummary describes which earlier outputs are kept
+func retentionSummary(policy retention.Policy) string {
+	var limits []string
+	if policy.KeepLast > 0 {
+		limits = append(limits, fmt.Sprintf("newest %d files", policy.KeepLast))
+	}
+	if policy.KeepDays > 0 {
+		limits = append(limits, fmt.Sprintf("files from the last %d days", policy.KeepDays))
+	}
+	return "keep " + strings.Join(limits, " and ")
+}
+
+// writeWarnings writes the warnings as JSON to the given file, or to stderr for "-"
+func writeWarnings(path string, warnings []quality.Issue) error {
+	if path == "-" {
+		return quality.WriteJSON(os.Stderr, warnings)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return quality.WriteJSON(file, warnings)
 }
 
 // showConfigSummary displays the current configuration in CLI mode
 func showConfigSummary(cfg *config.Config) {
-	fmt.Printf("📋 Configuration:\n")
-	fmt.Printf("   📁 CSV File: %s\n", cfg.CSVPath)
+	fmt.Fprintf(stdout, "📋 Configuration:\n")
+	if paths := cfg.CSVFiles(); len(paths) > 1 {
+		fmt.Fprintf(stdout, "   📁 CSV Files: %s\n", strings.Join(paths, ", "))
+	} else {
+		fmt.Fprintf(stdout, "   📁 CSV File: %s\n", cfg.CSVPath)
+	}
+	if cfg.Profile != "" {
+		fmt.Fprintf(stdout, "   🗂️  Profile: %s (%s)\n", cfg.Profile, cfg.ConfigPath)
+	}
 	
+	if cfg.Format == types.FormatProblems {
+		fmt.Fprintf(stdout, "   🩺 Mode: Data-quality problems (file:line: message)\n")
+	} else if cfg.Export != "" {
+		fmt.Fprintf(stdout, "   📦 Mode: Export %s (one JSON object per item)\n", cfg.Export)
+	} else if cfg.BaselinePath != "" {
+		fmt.Fprintf(stdout, "   🔀 Mode: Changes since %s\n", cfg.BaselinePath)
+	} else if cfg.Both || !cfg.IsMetricsReport() {
+		if len(cfg.ReportTypes) > 1 {
+			fmt.Fprintf(stdout, "   📊 Mode: Reports (%s)\n", strings.Join(reportTypeNames(cfg.ReportTypes), ", "))
+		} else {
+			fmt.Fprintf(stdout, "   📊 Mode: Report (%s)\n", cfg.ReportType)
+		}
+		if cfg.Hierarchy {
+			fmt.Fprintf(stdout, "   🌳 Hierarchy: Project → Epic → Item\n")
+		}
+		if cfg.Epic != "" {
+			fmt.Fprintf(stdout, "   🎯 Epic: %s\n", cfg.Epic)
+		}
+		if cfg.LabelPrefix != "" {
+			fmt.Fprintf(stdout, "   🏷️ Label Prefix: %s\n", cfg.LabelPrefix)
+		}
+		if cfg.GroupBy != "" {
+			fmt.Fprintf(stdout, "   🗃️  Group By: %s\n", cfg.GroupBy)
+		}
+		if cfg.PivotRows != "" {
+			fmt.Fprintf(stdout, "   🧮 Pivot: %s × %s\n", cfg.PivotRows, cfg.PivotCols)
+		}
+		if cfg.AllocateBy != "" {
+			fmt.Fprintf(stdout, "   🧭 Allocate By: %s\n", cfg.AllocateBy)
+		}
+		if cfg.Detail {
+			if cfg.Top > 0 {
+				fmt.Fprintf(stdout, "   📝 Detail: top %d items per contributor\n", cfg.Top)
+			} else {
+				fmt.Fprintf(stdout, "   📝 Detail: all items per contributor\n")
+			}
+		}
+	}
 	if cfg.IsMetricsReport() {
-		fmt.Printf("   📈 Mode: Metrics (%s)\n", cfg.MetricsType)
-		if cfg.MetricsType == metrics.MetricsTypeThroughput || cfg.MetricsType == metrics.MetricsTypeAll {
-			fmt.Printf("   ⏰ Period: %s\n", cfg.PeriodType)
+		fmt.Fprintf(stdout, "   📈 Mode: Metrics (%s)\n", cfg.MetricsType)
+		if cfg.MetricsType == metrics.MetricsTypeThroughput || cfg.MetricsType == metrics.MetricsTypeWorkflow || cfg.MetricsType == metrics.MetricsTypeAll {
+			if cfg.PeriodType == metrics.PeriodTypeWeek {
+				fmt.Fprintf(stdout, "   ⏰ Period: %s (%s weeks)\n", cfg.PeriodType, cfg.WeekNumbering)
+			} else {
+				fmt.Fprintf(stdout, "   ⏰ Period: %s\n", cfg.PeriodType)
+			}
 		}
-	} else {
-		fmt.Printf("   📊 Mode: Report (%s)\n", cfg.ReportType)
+		if cfg.MetricsType == metrics.MetricsTypeBenchmark {
+			fmt.Fprintf(stdout, "   🏁 Split By: %s\n", cfg.SplitBy)
+		}
+		if cfg.MetricsType == metrics.MetricsTypeForecast {
+			fmt.Fprintf(stdout, "   🎲 Simulations: %d (seed %d)\n", cfg.Forecast.Simulations, cfg.Forecast.Seed)
+		}
+		if len(cfg.MetricsSections) > 0 {
+			fmt.Fprintf(stdout, "   🧩 Sections: %s\n", metricsSectionNames(cfg.MetricsSections))
+		}
+		if cfg.AgeMode == metrics.AgeModeAgingWIP {
+			fmt.Fprintf(stdout, "   📍 Age Mode: %s\n", cfg.AgeMode)
+		}
+		if len(cfg.AgeThresholds) > 0 {
+			fmt.Fprintf(stdout, "   🚦 Age SLA: %s\n", cfg.AgeThresholds)
+		}
+		if len(cfg.WIPLimits) > 0 {
+			fmt.Fprintf(stdout, "   🚧 WIP Limits: %s\n", cfg.WIPLimits)
+		}
+		if len(cfg.Annotations) > 0 {
+			fmt.Fprintf(stdout, "   📝 Annotations: %d events\n", len(cfg.Annotations))
+		}
+		if len(cfg.Absences) > 0 {
+			fmt.Fprintf(stdout, "   🌴 Absences: %d periods adjust capacity\n", len(cfg.Absences))
+		}
+		if len(cfg.Holidays) > 0 {
+			fmt.Fprintf(stdout, "   🏖️  Holidays: %d dates excluded from working days\n", len(cfg.Holidays))
+		}
+		if len(cfg.Iterations) > 0 {
+			fmt.Fprintf(stdout, "   🏃 Iterations: %d with dates\n", len(cfg.Iterations))
+		}
+	}
+	if cfg.CompareWith != "" {
+		fmt.Fprintf(stdout, "   ⚖️  Compared with: %s\n", render.DateRangeField(cfg.CompareStart, cfg.CompareEnd).Value)
+	}
+	if cfg.HTMLFilters {
+		fmt.Fprintf(stdout, "   🎛️  Filters: team, epic and dates in the HTML page\n")
+	}
+	if cfg.ChartsDir != "" {
+		fmt.Fprintf(stdout, "   🖼️  Charts: saved as SVG in %s\n", cfg.ChartsDir)
+	}
+	if len(cfg.Categories) > 0 {
+		fmt.Fprintf(stdout, "   🗂️  Categories: %d rules\n", len(cfg.Categories))
+	}
+	if len(cfg.History) > 0 {
+		fmt.Fprintf(stdout, "   🕓 State history: %d items\n", len(cfg.History))
 	}
 	
 	// Date range
 	if cfg.LastNDays > 0 {
-		fmt.Printf("   📅 Date Range: Last %d days\n", cfg.LastNDays)
+		fmt.Fprintf(stdout, "   📅 Date Range: Last %d days\n", cfg.LastNDays)
+	} else if cfg.Range != "" {
+		fmt.Fprintf(stdout, "   📅 Date Range: %s (%s to %s)\n", cfg.Range,
+			cfg.StartDate.Format("2006-01-02"),
+			cfg.EndDate.Format("2006-01-02"))
 	} else if !cfg.StartDate.IsZero() && !cfg.EndDate.IsZero() {
-		fmt.Printf("   📅 Date Range: %s to %s\n", 
+		fmt.Fprintf(stdout, "   📅 Date Range: %s to %s\n", 
 			cfg.StartDate.Format("2006-01-02"), 
 			cfg.EndDate.Format("2006-01-02"))
 	} else {
-		fmt.Printf("   📅 Date Range: All time\n")
+		fmt.Fprintf(stdout, "   📅 Date Range: All time\n")
+	}
+	
+	if cfg.Unit != "" && cfg.Unit != types.UnitPoints {
+		fmt.Fprintf(stdout, "   📏 Unit: %s\n", cfg.Unit)
+	}
+	fmt.Fprintf(stdout, "   🔍 Ad-hoc Filter: %s\n", cfg.AdHocFilter)
+	if cfg.Filter != nil {
+		fmt.Fprintf(stdout, "   🔎 Filter: %s\n", cfg.Filter)
+	}
+	if cfg.AdHocRules.String() != filtering.DefaultAdHocRules().String() {
+		fmt.Fprintf(stdout, "   🏷️  Ad-hoc Rules: %s\n", cfg.AdHocRules)
+	}
+	fmt.Fprintf(stdout, "   🔗 CSV Delimiter: %s\n", cfg.Delimiter.Name)
+	if len(cfg.EstimateMapping) > 0 {
+		fmt.Fprintf(stdout, "   👕 Estimate Mapping: %s\n", cfg.EstimateMapping)
+	}
+	
+	if cfg.DataQuality {
+		fmt.Fprintf(stdout, "   🩺 Data Quality: report appended\n")
+	}
+	if cfg.WarningsFile != "" {
+		fmt.Fprintf(stdout, "   🧾 Warnings: %s\n", cfg.WarningsFile)
 	}
 	
-	fmt.Printf("   🔍 Ad-hoc Filter: %s\n", cfg.AdHocFilter)
-	fmt.Printf("   🔗 CSV Delimiter: %s\n", cfg.Delimiter.Name)
+	if cfg.Width > 0 {
+		fmt.Fprintf(stdout, "   📏 Width: %d columns\n", cfg.Width)
+	}
 	
 	if cfg.OutputPath != "" {
-		fmt.Printf("   💾 Output: %s\n", cfg.OutputPath)
+		fmt.Fprintf(stdout, "   💾 Output: %s\n", cfg.OutputPath)
+		if cfg.NoOverwrite {
+			fmt.Fprintf(stdout, "   🔒 No overwrite: existing files are kept\n")
+		}
+		if cfg.Append {
+			fmt.Fprintf(stdout, "   📓 Append: runs are added to the end of the file\n")
+		}
+		if cfg.MinisignKey != "" {
+			fmt.Fprintf(stdout, "   🔏 Signing: SHA-256 checksum and minisign signature\n")
+		} else if cfg.Sign {
+			fmt.Fprintf(stdout, "   🔏 Signing: SHA-256 checksum\n")
+		}
+		if cfg.Retention.IsSet() {
+			fmt.Fprintf(stdout, "   🧹 Retention: %s\n", retentionSummary(cfg.Retention))
+		}
 	} else {
-		fmt.Printf("   💾 Output: Console\n")
+		fmt.Fprintf(stdout, "   💾 Output: Console\n")
 	}
 }
\ No newline at end of file
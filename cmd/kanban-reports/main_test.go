@@ -3,18 +3,12 @@ package main
 
 import (
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 )
 
 func TestEndToEnd(t *testing.T) {
-	// Skip if running in CI environment without the necessary setup
-	if os.Getenv("CI") != "" {
-		t.Skip("Skipping integration test in CI environment")
-	}
-
 	// Create a test CSV file
 	tempDir, err := os.MkdirTemp("", "kanban-test-*")
 	if err != nil {
@@ -36,13 +30,19 @@ func TestEndToEnd(t *testing.T) {
 		t.Fatalf("Failed to write test CSV: %v", err)
 	}
 
-	// Get the path to the compiled binary
-	// Note: You need to build the binary first with `go build -o bin/kanban-reports ./cmd/kanban-reports`
-	binaryPath := filepath.Join("..", "..", "bin", "kanban-reports")
-	if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
-		t.Skip("Binary not found at " + binaryPath + ". Run 'go build -o bin/kanban-reports ./cmd/kanban-reports' first")
+	// An earlier export, before task 3 was completed and task 4 re-estimated
+	baselinePath := filepath.Join(tempDir, "baseline.csv")
+	baselineCSV := `id,name,type,estimate,is_completed,completed_at,owners,epic,team,product_area,created_at,started_at
+1,Task 1,Feature,3,TRUE,2024/05/07 10:30:00,john@example.com,Epic 1,Team A,Backend,2024/05/01 09:00:00,2024/05/03 11:00:00
+2,Task 2,Bug,1,TRUE,2024/05/08 15:45:00,jane@example.com,Epic 1,Team A,Frontend,2024/05/02 14:00:00,2024/05/05 10:00:00
+3,Task 3,Feature,5,FALSE,,john@example.com;jane@example.com,Epic 2,Team B,Backend,2024/05/03 08:00:00,2024/05/06 09:00:00
+4,Task 4,Task,1,FALSE,,bob@example.com,Epic 2,Team B,Backend,2024/05/04 11:00:00,2024/05/08 14:00:00
+`
+	if err := os.WriteFile(baselinePath, []byte(baselineCSV), 0644); err != nil {
+		t.Fatalf("Failed to write baseline CSV: %v", err)
 	}
 
+
 	// Test cases for different report types and options
 	testCases := []struct {
 		name    string
@@ -85,15 +85,64 @@ func TestEndToEnd(t *testing.T) {
 				"Story Points",
 			},
 		},
+		{
+			name: "Metrics as JSON",
+			args: []string{"--csv", csvPath, "--metrics", "lead-time", "--format", "json", "--output", outputPath},
+			checks: []string{
+				`"metrics_type": "lead-time"`,
+				`"type": "lead-time"`,
+				`"cycle_time_histogram"`,
+			},
+		},
+		{
+			name: "Report and metrics as HTML",
+			args: []string{"--csv", csvPath, "--type", "team", "--metrics", "throughput", "--both", "--format", "html", "--output", outputPath},
+			checks: []string{
+				"<!DOCTYPE html>",
+				`<section id="team">`,
+				`<section id="throughput">`,
+				"<table>",
+			},
+		},
+		{
+			name: "Team report compared with a baseline range",
+			args: []string{"--csv", csvPath, "--type", "team", "--start", "2024-05-08", "--end", "2024-05-10", "--compare-with", "2024-05-01..2024-05-07", "--output", outputPath},
+			checks: []string{
+				"# Comparison",
+				"Before: 2024-05-01 to 2024-05-07 (baseline)",
+				"After:  2024-05-08 to 2024-05-10",
+				"sections[team].data.groups[Team A].amount",
+			},
+		},
+		{
+			name: "Metrics as Markdown with charts",
+			args: []string{"--csv", csvPath, "--metrics", "lead-time", "--format", "markdown", "--charts-dir", filepath.Join(tempDir, "charts"), "--output", outputPath},
+			checks: []string{
+				"![Monthly Throughput](<charts/throughput.svg>)",
+				"![Cumulative Flow](<charts/cfd.svg>)",
+				"![Cycle Time Scatterplot](<charts/scatterplot.svg>)",
+			},
+		},
+		{
+			name: "Changes since a baseline export",
+			args: []string{"--csv", csvPath, "--baseline", baselinePath, "--format", "markdown", "--output", outputPath},
+			checks: []string{
+				"# Changes Since Baseline",
+				"4 items in the baseline, 4 now; 2 items changed.",
+				"## Completed (1)",
+				"| 3 | Task 3 | Team B | - | 2024-05-10 |",
+				"## Re-estimated (1)",
+				"| 4 | Task 4 | Team B | 1 | 2 |",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Run the command
-			cmd := exec.Command(binaryPath, tc.args...)
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+			output, code := runBinary(t, tc.args...)
+			if code != 0 {
+				t.Fatalf("Command failed with exit code %d\nOutput: %s", code, output)
 			}
 
 			// Read the output file
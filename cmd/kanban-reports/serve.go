@@ -0,0 +1,311 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/hannasdev/kanban-reports/internal/config"
+	"github.com/hannasdev/kanban-reports/internal/quality"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcServerError    = -32000 // The run itself failed, e.g. the CSV file is missing
+)
+
+// maxRequestSize is the longest request line the server reads
+const maxRequestSize = 1 << 20
+
+// defaultSocketPath is where the server listens unless --socket is given
+func defaultSocketPath() string {
+	return filepath.Join(os.TempDir(), "kanban-reports.sock")
+}
+
+// rpcRequest is a JSON-RPC 2.0 request; notifications have no ID
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is the reply to a request, holding either a result or an error
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError describes why a request failed
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcNotification is a message from the server that expects no reply
+type rpcNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// generateParams are the command-line arguments of one run, e.g.
+// {"args": ["--csv", "data.csv", "--metrics", "lead-time"]}
+type generateParams struct {
+	Args []string `json:"args"`
+}
+
+// generateResult is the output of a run with the data-quality findings
+type generateResult struct {
+	Output   string          `json:"output"`
+	Warnings []quality.Issue `json:"warnings"`
+}
+
+// schemaParams name the output whose JSON Schema is requested
+type schemaParams struct {
+	Output string `json:"output"` // report, metrics or data-quality
+}
+
+// progressParams report how far a generate request has come
+type progressParams struct {
+	ID      json.RawMessage `json:"id"` // Of the generate request
+	Stage   string          `json:"stage"`
+	Message string          `json:"message,omitempty"`
+}
+
+// Progress stages of a generate request, in order
+const (
+	stageLoading    = "loading"
+	stageLoaded     = "loaded"
+	stageGenerating = "generating"
+	stageDone       = "done"
+)
+
+// runServe serves the report engine to editors and other tools. Each
+// connection sends newline-delimited JSON-RPC 2.0 requests and gets one
+// response line per request, preceded by progress notifications.
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	ipc := fs.Bool("ipc", false, "Serve JSON-RPC 2.0 on a local socket")
+	socketPath := fs.String("socket", defaultSocketPath(), "Path of the socket to listen on")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s serve --ipc [--socket PATH]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if !*ipc || fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	listener, err := listenSocket(*socketPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+		return 1
+	}
+
+	// Stop on Ctrl+C or a termination signal, cancelling the runs in
+	// progress and removing the socket
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintf(os.Stderr, "🔌 Listening on %s (JSON-RPC 2.0, one message per line)\n", *socketPath)
+	if err := serveIPC(ctx, listener); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// listenSocket listens on a Unix socket that only the current user can
+// connect to, replacing a socket left behind by an earlier server
+func listenSocket(path string) (net.Listener, error) {
+	if info, err := os.Stat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another server is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// serveIPC handles connections until the context is done or the listener is
+// closed. Closing a Unix listener also removes its socket file.
+func serveIPC(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go serveConn(ctx, conn)
+	}
+}
+
+// serveConn answers the requests of one connection in order
+func serveConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	encoder := json.NewEncoder(conn)
+	encoder.SetEscapeHTML(false)
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRequestSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		response := handleRequest(ctx, []byte(line), func(n rpcNotification) {
+			encoder.Encode(n)
+		})
+		if response != nil {
+			if err := encoder.Encode(response); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// handleRequest runs one request, sending progress through notify. It
+// returns nil for notifications, which get no response.
+func handleRequest(ctx context.Context, line []byte, notify func(rpcNotification)) *rpcResponse {
+	var request rpcRequest
+	if err := json.Unmarshal(line, &request); err != nil {
+		return errorResponse(nil, rpcParseError, fmt.Sprintf("invalid JSON: %v", err))
+	}
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		return errorResponse(request.ID, rpcInvalidRequest, `expected a JSON-RPC 2.0 request with "jsonrpc": "2.0" and a method`)
+	}
+
+	var result interface{}
+	var err *rpcError
+	switch request.Method {
+	case "generate":
+		var params generateParams
+		if e := json.Unmarshal(paramsOrEmpty(request.Params), &params); e != nil {
+			err = &rpcError{rpcInvalidParams, fmt.Sprintf("invalid params: %v", e)}
+			break
+		}
+		result, err = generate(ctx, params, func(stage, message string) {
+			if request.ID != nil {
+				notify(rpcNotification{"2.0", "progress", progressParams{request.ID, stage, message}})
+			}
+		})
+	case "schema":
+		var params schemaParams
+		if e := json.Unmarshal(paramsOrEmpty(request.Params), &params); e != nil || jsonSchemas[params.Output] == nil {
+			err = &rpcError{rpcInvalidParams, `expected params {"output": "report|metrics|data-quality|items|changes"}`}
+			break
+		}
+		jsonSchema, e := jsonSchemas[params.Output]()
+		if e != nil {
+			err = &rpcError{rpcServerError, e.Error()}
+			break
+		}
+		result = jsonSchema
+	default:
+		err = &rpcError{rpcMethodNotFound, fmt.Sprintf("unknown method %q (available: generate, schema)", request.Method)}
+	}
+
+	if request.ID == nil {
+		return nil
+	}
+	if err != nil {
+		return errorResponse(request.ID, err.Code, err.Message)
+	}
+	return &rpcResponse{JSONRPC: "2.0", ID: request.ID, Result: result}
+}
+
+// generate runs the report engine with the given arguments, as the command
+// line would, reporting each stage through progress. The run stops when the
+// server shuts down or after its --timeout.
+func generate(ctx context.Context, params generateParams, progress func(stage, message string)) (*generateResult, *rpcError) {
+	cfg, err := config.ParseArgs(params.Args)
+	if err != nil {
+		return nil, &rpcError{rpcInvalidParams, err.Error()}
+	}
+	ctx, cancel := withTimeout(ctx, cfg)
+	defer cancel()
+
+	progress(stageLoading, strings.Join(cfg.CSVFiles(), ", "))
+	items, warnings, err := loadItems(ctx, cfg, io.Discard)
+	if message := stoppedMessage(err, cfg); message != "" {
+		return nil, &rpcError{rpcServerError, message}
+	}
+	if err != nil {
+		return nil, &rpcError{rpcServerError, fmt.Sprintf("error parsing CSV: %v", err)}
+	}
+	progress(stageLoaded, fmt.Sprintf("%d kanban items", len(items)))
+
+	progress(stageGenerating, "")
+	output, err := generateOutput(ctx, cfg, items, parseIssues(warnings))
+	if message := stoppedMessage(err, cfg); message != "" {
+		return nil, &rpcError{rpcServerError, message}
+	}
+	if err != nil {
+		return nil, &rpcError{rpcServerError, err.Error()}
+	}
+	progress(stageDone, "")
+
+	if warnings == nil {
+		warnings = []quality.Issue{}
+	}
+	return &generateResult{Output: output, Warnings: warnings}, nil
+}
+
+// paramsOrEmpty treats missing params as an empty object
+func paramsOrEmpty(params json.RawMessage) json.RawMessage {
+	if len(params) == 0 {
+		return json.RawMessage("{}")
+	}
+	return params
+}
+
+// errorResponse replies to a request that failed; the ID is null when the
+// request couldn't be read
+func errorResponse(id json.RawMessage, code int, message string) *rpcResponse {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return &rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{code, message}}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/config"
+)
+
+// startServer serves IPC on a socket in a new temporary directory. Socket
+// paths are limited in length, so the directory name is kept short.
+func startServer(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "kr")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, "kr.sock")
+	listener, err := listenSocket(socketPath)
+	if err != nil {
+		t.Fatalf("listenSocket() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go serveIPC(ctx, listener)
+	return socketPath
+}
+
+// rpcMessage is any message the server sends
+type rpcMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params progressParams  `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// call sends one request line and reads messages up to its response
+func call(t *testing.T, conn net.Conn, reader *bufio.Reader, request string) (rpcMessage, []progressParams) {
+	t.Helper()
+	if _, err := conn.Write([]byte(request + "\n")); err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	var progress []progressParams
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("Failed to read response: %v", err)
+		}
+		var message rpcMessage
+		if err := json.Unmarshal(line, &message); err != nil {
+			t.Fatalf("Invalid response %q: %v", line, err)
+		}
+		if message.Method == "progress" {
+			progress = append(progress, message.Params)
+			continue
+		}
+		return message, progress
+	}
+}
+
+func TestServeIPC_Generate(t *testing.T) {
+	csvPath := filepath.Join(t.TempDir(), "data.csv")
+	csv := `id,name,type,estimate,is_completed,completed_at,owners,epic,team,created_at,started_at
+1,Task 1,Feature,3,TRUE,2024/05/07 10:30:00,john@example.com,Epic 1,Team A,2024/05/01 09:00:00,2024/05/03 11:00:00
+2,Task 2,Bug,1,TRUE,2024/05/08 15:45:00,jane@example.com,Epic 1,Team A,2024/05/02 14:00:00,2024/05/05 10:00:00
+`
+	if err := os.WriteFile(csvPath, []byte(csv), 0644); err != nil {
+		t.Fatalf("Failed to write test CSV: %v", err)
+	}
+
+	conn, err := net.Dial("unix", startServer(t))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	args, _ := json.Marshal([]string{"--csv", csvPath, "--type", "contributor", "--start", "2024-05-01", "--end", "2024-05-31"})
+	response, progress := call(t, conn, reader, `{"jsonrpc": "2.0", "id": 7, "method": "generate", "params": {"args": `+string(args)+`}}`)
+	if response.Error != nil {
+		t.Fatalf("generate error = %+v", response.Error)
+	}
+	if string(response.ID) != "7" {
+		t.Errorf("response id = %s, want 7", response.ID)
+	}
+
+	var result generateResult
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		t.Fatalf("Invalid result: %v", err)
+	}
+	if !strings.Contains(result.Output, "Story Points by Contributor") || !strings.Contains(result.Output, "john@example.com") {
+		t.Errorf("output doesn't contain the contributor report\nGot:\n%s", result.Output)
+	}
+
+	var stages []string
+	for _, p := range progress {
+		if string(p.ID) != "7" {
+			t.Errorf("progress id = %s, want 7", p.ID)
+		}
+		stages = append(stages, p.Stage)
+	}
+	if got := strings.Join(stages, ","); got != "loading,loaded,generating,done" {
+		t.Errorf("progress stages = %s, want loading,loaded,generating,done", got)
+	}
+	if progress[1].Message != "2 kanban items" {
+		t.Errorf("loaded message = %q, want %q", progress[1].Message, "2 kanban items")
+	}
+
+	// The connection stays open for further requests
+	response, _ = call(t, conn, reader, `{"jsonrpc": "2.0", "id": "s", "method": "schema", "params": {"output": "metrics"}}`)
+	if response.Error != nil || !strings.Contains(string(response.Result), `"$schema"`) {
+		t.Errorf("schema response = %+v, want the metrics JSON Schema", response)
+	}
+}
+
+func TestServeIPC_Errors(t *testing.T) {
+	invalidCSV := filepath.Join(t.TempDir(), "invalid.csv")
+	if err := os.WriteFile(invalidCSV, []byte("key,title\n1,Task 1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test CSV: %v", err)
+	}
+
+	conn, err := net.Dial("unix", startServer(t))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	tests := []struct {
+		name    string
+		request string
+		code    int
+		message string
+	}{
+		{"Invalid JSON", `{"jsonrpc": "2.0",`, rpcParseError, "invalid JSON"},
+		{"Not JSON-RPC 2.0", `{"id": 1, "method": "generate"}`, rpcInvalidRequest, "JSON-RPC 2.0"},
+		{"Unknown method", `{"jsonrpc": "2.0", "id": 1, "method": "render"}`, rpcMethodNotFound, "unknown method"},
+		{"Invalid args", `{"jsonrpc": "2.0", "id": 1, "method": "generate", "params": {"args": ["--type", "contributor"]}}`, rpcInvalidParams, "--csv"},
+		{"Output file", `{"jsonrpc": "2.0", "id": 1, "method": "generate", "params": {"args": ["--csv", "a.csv", "--type", "team", "--output", "out.txt"]}}`, rpcInvalidParams, "the output is returned instead"},
+		{"Interactive", `{"jsonrpc": "2.0", "id": 1, "method": "generate", "params": {"args": ["--interactive"]}}`, rpcInvalidParams, "only available on the command line"},
+		{"Missing CSV", `{"jsonrpc": "2.0", "id": 1, "method": "generate", "params": {"args": ["--csv", "/nonexistent/data.csv", "--type", "team"]}}`, rpcInvalidParams, "does not exist"},
+		{"Invalid CSV", `{"jsonrpc": "2.0", "id": 1, "method": "generate", "params": {"args": ["--csv", "` + invalidCSV + `", "--type", "team"]}}`, rpcServerError, "error parsing CSV"},
+		{"Unknown schema", `{"jsonrpc": "2.0", "id": 1, "method": "schema", "params": {"output": "flow"}}`, rpcInvalidParams, "report|metrics|data-quality"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, _ := call(t, conn, reader, tt.request)
+			if response.Error == nil {
+				t.Fatalf("response = %+v, want error %d", response, tt.code)
+			}
+			if response.Error.Code != tt.code || !strings.Contains(response.Error.Message, tt.message) {
+				t.Errorf("error = %+v, want code %d containing %q", response.Error, tt.code, tt.message)
+			}
+		})
+	}
+}
+
+func TestHandleRequest_Notification(t *testing.T) {
+	notified := false
+	response := handleRequest(context.Background(), []byte(`{"jsonrpc": "2.0", "method": "schema", "params": {"output": "report"}}`), func(rpcNotification) {
+		notified = true
+	})
+	if response != nil || notified {
+		t.Errorf("notification got response %+v (notified %v), want none", response, notified)
+	}
+}
+
+func TestGenerate_Stopped(t *testing.T) {
+	csvPath := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(csvPath, []byte("id,name,estimate,is_completed,completed_at\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test CSV: %v", err)
+	}
+
+	// Runs in progress stop when the server shuts down
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var stages []string
+	_, err := generate(ctx, generateParams{Args: []string{"--csv", csvPath, "--type", "team"}}, func(stage, message string) {
+		stages = append(stages, stage)
+	})
+	if err == nil || err.Code != rpcServerError || err.Message != "the run was cancelled" {
+		t.Errorf("generate() error = %+v, want the run was cancelled", err)
+	}
+	if len(stages) != 1 || stages[0] != stageLoading {
+		t.Errorf("progress stages = %v, want only loading", stages)
+	}
+
+	cfg := &config.Config{Timeout: 30 * time.Second}
+	if got := stoppedMessage(fmt.Errorf("loading: %w", context.DeadlineExceeded), cfg); got != "the run took longer than --timeout 30s" {
+		t.Errorf("stoppedMessage() = %q, want the timeout", got)
+	}
+	if got := stoppedMessage(errors.New("missing column"), cfg); got != "" {
+		t.Errorf("stoppedMessage() = %q, want none for other errors", got)
+	}
+}
+
+func TestListenSocket_InUse(t *testing.T) {
+	socketPath := startServer(t)
+	if _, err := listenSocket(socketPath); err == nil || !strings.Contains(err.Error(), "already listening") {
+		t.Errorf("listenSocket() error = %v, want already listening", err)
+	}
+}
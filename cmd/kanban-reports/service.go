@@ -0,0 +1,318 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/config"
+	"github.com/hannasdev/kanban-reports/pkg/terminal"
+)
+
+// serviceSchedules are how often an installed service runs its profile
+var serviceSchedules = []string{"daily", "weekly", "monthly"}
+
+// serviceSpec is what an installed service runs, and when
+type serviceSpec struct {
+	Name       string // Unit or job name, from the profile
+	Profile    string
+	Executable string
+	Args       []string // Options of each run, naming the config file and profile
+	WorkDir    string   // Relative paths of the profile resolve here
+	Schedule   string   // One of serviceSchedules
+	Hour       int
+	Minute     int
+}
+
+// runInstallService writes and enables a systemd timer (Linux) or launchd
+// agent (macOS) that runs a profile on a schedule with "install-service", for
+// users who would rather not maintain a crontab
+func runInstallService(args []string) int {
+	fs := flag.NewFlagSet("install-service", flag.ContinueOnError)
+	profileName := fs.String("profile", "", "Profile to run on the schedule (required)")
+	configPath := fs.String("config", "", "YAML or TOML file of profiles (default: the saved config file)")
+	schedule := fs.String("schedule", "weekly", "How often to run: "+strings.Join(serviceSchedules, ", "))
+	at := fs.String("at", "07:00", "Time of day to run, as HH:MM (weekly runs are on Mondays, monthly on the 1st)")
+	dryRun := fs.Bool("dry-run", false, "Print the files that would be written instead of installing them")
+	ascii := fs.Bool("ascii", false, "Use plain ASCII markers instead of emoji")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s install-service --profile NAME [--config FILE] [--schedule daily|weekly|monthly] [--at HH:MM] [--dry-run] [--ascii]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *profileName == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	terminal.SetASCII(terminal.ASCIIMode(fs, *ascii))
+	stdout = terminal.Stdout()
+
+	spec, err := newServiceSpec(*configPath, *profileName, *schedule, *at)
+	if err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+
+	files, enable, err := serviceFiles(runtime.GOOS, spec)
+	if err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+
+	if *dryRun {
+		for _, file := range files {
+			fmt.Fprintf(stdout, "# %s\n%s\n", file.path, file.content)
+		}
+		for _, command := range enable {
+			fmt.Fprintf(stdout, "# then: %s\n", strings.Join(command, " "))
+		}
+		return 0
+	}
+
+	for _, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+			fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+			return 1
+		}
+		if err := os.WriteFile(file.path, []byte(file.content), 0644); err != nil {
+			fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "✅ Wrote %s\n", file.path)
+	}
+	for _, command := range enable {
+		if output, err := exec.Command(command[0], command[1:]...).CombinedOutput(); err != nil {
+			fmt.Fprintf(stdout, "❌ Error: %s: %v\n%s", strings.Join(command, " "), err, output)
+			return 1
+		}
+	}
+	fmt.Fprintf(stdout, "🕓 %s runs profile %s %s at %02d:%02d\n", spec.Name, spec.Profile, spec.Schedule, spec.Hour, spec.Minute)
+	return 0
+}
+
+// newServiceSpec checks that the profile exists, is valid and writes its
+// output to a file, since nobody reads the console of a scheduled run
+func newServiceSpec(configPath, profileName, schedule, at string) (serviceSpec, error) {
+	valid := false
+	for _, s := range serviceSchedules {
+		valid = valid || s == schedule
+	}
+	if !valid {
+		return serviceSpec{}, fmt.Errorf("invalid schedule: %s (must be one of: %s)", schedule, strings.Join(serviceSchedules, ", "))
+	}
+	var hour, minute int
+	if n, err := fmt.Sscanf(at, "%d:%d", &hour, &minute); err != nil || n != 2 || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return serviceSpec{}, fmt.Errorf("invalid time: %s (expected HH:MM, e.g. 07:00)", at)
+	}
+
+	if configPath == "" {
+		path, err := config.DefaultConfigPath()
+		if err != nil {
+			return serviceSpec{}, err
+		}
+		configPath = path
+	}
+	configPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return serviceSpec{}, err
+	}
+	profiles, err := config.LoadProfiles(configPath)
+	if err != nil {
+		return serviceSpec{}, err
+	}
+	profile, ok := profiles.Find(profileName)
+	if !ok {
+		return serviceSpec{}, fmt.Errorf("unknown profile %q in %s (available: %s)", profileName, configPath, strings.Join(profiles.Names(), ", "))
+	}
+	if problems := config.ValidateProfile(profile); len(problems) > 0 {
+		problem := problems[0].Message
+		if problems[0].Key != "" {
+			problem = problems[0].Key + ": " + problem
+		}
+		return serviceSpec{}, fmt.Errorf("profile %s is invalid (%s); check it with %s config validate --config %s --profile %s",
+			profileName, problem, os.Args[0], configPath, profileName)
+	}
+	writesFile := false
+	for _, setting := range profile.Settings {
+		writesFile = writesFile || setting.Key == "output" || setting.Key == "output-template"
+	}
+	if !writesFile {
+		return serviceSpec{}, fmt.Errorf("profile %s sets no output or output-template, so its scheduled runs would be lost", profileName)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return serviceSpec{}, err
+	}
+	workDir, err := os.Getwd()
+	if err != nil {
+		return serviceSpec{}, err
+	}
+
+	return serviceSpec{
+		Name:       "kanban-reports-" + serviceName(profileName),
+		Profile:    profileName,
+		Executable: executable,
+		Args:       []string{"--config", configPath, "--profile", profileName},
+		WorkDir:    workDir,
+		Schedule:   schedule,
+		Hour:       hour,
+		Minute:     minute,
+	}, nil
+}
+
+// serviceName turns a profile name into one that is safe in unit and job
+// names, replacing anything but letters, digits, '-' and '_' with '-'. A name
+// that had to change gets a hash of the original, so that "team a" and
+// "team/a" don't install over each other.
+func serviceName(profile string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, profile)
+	if name == profile {
+		return name
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(profile))
+	return fmt.Sprintf("%s-%08x", name, hash.Sum32())
+}
+
+// serviceFile is a file an installed service is made of
+type serviceFile struct {
+	path    string
+	content string
+}
+
+// serviceFiles returns the files of the service for the operating system and
+// the commands that enable it
+func serviceFiles(goos string, spec serviceSpec) ([]serviceFile, [][]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch goos {
+	case "linux":
+		dir := filepath.Join(home, ".config", "systemd", "user")
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			dir = filepath.Join(xdg, "systemd", "user")
+		}
+		service, timer := systemdUnits(spec)
+		files := []serviceFile{
+			{filepath.Join(dir, spec.Name+".service"), service},
+			{filepath.Join(dir, spec.Name+".timer"), timer},
+		}
+		enable := [][]string{
+			{"systemctl", "--user", "daemon-reload"},
+			{"systemctl", "--user", "enable", "--now", spec.Name + ".timer"},
+		}
+		return files, enable, nil
+	case "darwin":
+		path := filepath.Join(home, "Library", "LaunchAgents", "com.github.hannasdev."+spec.Name+".plist")
+		return []serviceFile{{path, launchdPlist(spec)}}, [][]string{{"launchctl", "load", "-w", path}}, nil
+	}
+	return nil, nil, fmt.Errorf("install-service supports systemd on Linux and launchd on macOS, not %s; schedule %s %s with your system's scheduler instead",
+		goos, spec.Executable, strings.Join(spec.Args, " "))
+}
+
+// systemdUnits returns the user service that runs the profile once and the
+// timer that starts it on the schedule, catching up on runs missed while the
+// machine was off
+func systemdUnits(spec serviceSpec) (service, timer string) {
+	calendar := map[string]string{
+		"daily":   "*-*-*",
+		"weekly":  "Mon *-*-*",
+		"monthly": "*-*-01",
+	}[spec.Schedule]
+
+	command := []string{systemdQuote(spec.Executable)}
+	for _, arg := range spec.Args {
+		command = append(command, systemdQuote(arg))
+	}
+
+	service = fmt.Sprintf(`[Unit]
+Description=kanban-reports profile %s
+
+[Service]
+Type=oneshot
+WorkingDirectory=%s
+ExecStart=%s
+`, systemdEscape(spec.Profile), systemdQuote(spec.WorkDir), strings.Join(command, " "))
+
+	timer = fmt.Sprintf(`[Unit]
+Description=Run kanban-reports profile %s %s
+
+[Timer]
+OnCalendar=%s %02d:%02d:00
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`, systemdEscape(spec.Profile), spec.Schedule, calendar, spec.Hour, spec.Minute)
+	return service, timer
+}
+
+// systemdQuote quotes a word of a unit file when it has spaces or quotes
+func systemdQuote(s string) string {
+	s = systemdEscape(s)
+	if !strings.ContainsAny(s, " \t\"'\\") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// systemdEscape doubles '%', which would otherwise start a specifier such as
+// %h when systemd reads the unit file
+func systemdEscape(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
+
+// launchdPlist returns the launch agent that runs the profile on the schedule
+func launchdPlist(spec serviceSpec) string {
+	interval := fmt.Sprintf("\t\t<key>Hour</key>\n\t\t<integer>%d</integer>\n\t\t<key>Minute</key>\n\t\t<integer>%d</integer>\n", spec.Hour, spec.Minute)
+	switch spec.Schedule {
+	case "weekly":
+		interval = "\t\t<key>Weekday</key>\n\t\t<integer>1</integer>\n" + interval
+	case "monthly":
+		interval = "\t\t<key>Day</key>\n\t\t<integer>1</integer>\n" + interval
+	}
+
+	var arguments strings.Builder
+	for _, argument := range append([]string{spec.Executable}, spec.Args...) {
+		fmt.Fprintf(&arguments, "\t\t<string>%s</string>\n", xmlEscape(argument))
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>com.github.hannasdev.%s</string>
+	<key>ProgramArguments</key>
+	<array>
+%s	</array>
+	<key>WorkingDirectory</key>
+	<string>%s</string>
+	<key>StartCalendarInterval</key>
+	<dict>
+%s	</dict>
+</dict>
+</plist>
+`, xmlEscape(spec.Name), arguments.String(), xmlEscape(spec.WorkDir), interval)
+}
+
+// xmlEscape escapes the characters with a meaning in XML text
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
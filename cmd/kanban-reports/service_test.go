@@ -0,0 +1,135 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServiceUnits(t *testing.T) {
+	spec := serviceSpec{
+		Name:       "kanban-reports-weekly-team",
+		Profile:    "weekly-team",
+		Executable: "/usr/local/bin/kanban-reports",
+		Args:       []string{"--config", "/home/ana/My Reports/kanban.yaml", "--profile", "weekly-team"},
+		WorkDir:    "/home/ana/My Reports",
+		Schedule:   "weekly",
+		Hour:       7,
+		Minute:     30,
+	}
+
+	service, timer := systemdUnits(spec)
+	for _, str := range []string{
+		`WorkingDirectory="/home/ana/My Reports"`,
+		`ExecStart=/usr/local/bin/kanban-reports --config "/home/ana/My Reports/kanban.yaml" --profile weekly-team`,
+	} {
+		if !strings.Contains(service, str) {
+			t.Errorf("service unit doesn't contain %q:\n%s", str, service)
+		}
+	}
+	if !strings.Contains(timer, "OnCalendar=Mon *-*-* 07:30:00") || !strings.Contains(timer, "Persistent=true") {
+		t.Errorf("timer doesn't run on Mondays at 07:30:\n%s", timer)
+	}
+
+	spec.Schedule = "monthly"
+	plist := launchdPlist(spec)
+	for _, str := range []string{
+		"<string>com.github.hannasdev.kanban-reports-weekly-team</string>",
+		"<string>/home/ana/My Reports/kanban.yaml</string>",
+		"<key>Day</key>\n\t\t<integer>1</integer>\n\t\t<key>Hour</key>\n\t\t<integer>7</integer>\n\t\t<key>Minute</key>\n\t\t<integer>30</integer>",
+	} {
+		if !strings.Contains(plist, str) {
+			t.Errorf("launchd plist doesn't contain %q:\n%s", str, plist)
+		}
+	}
+
+	if _, _, err := serviceFiles("windows", spec); err == nil || !strings.Contains(err.Error(), "systemd on Linux and launchd on macOS") {
+		t.Errorf("serviceFiles(windows) error = %v, want unsupported", err)
+	}
+	if got := serviceName("weekly-team"); got != "weekly-team" {
+		t.Errorf("serviceName() = %q, want weekly-team", got)
+	}
+	if got := serviceName("team a/b"); !strings.HasPrefix(got, "team-a-b-") {
+		t.Errorf("serviceName() = %q, want team-a-b and a hash", got)
+	}
+	if serviceName("team a") == serviceName("team/a") || serviceName("team a") == serviceName("team-a") {
+		t.Errorf("serviceName() of team a, team/a and team-a = %q, %q, %q; want them to differ",
+			serviceName("team a"), serviceName("team/a"), serviceName("team-a"))
+	}
+}
+
+func TestServiceUnits_Percent(t *testing.T) {
+	spec := serviceSpec{
+		Name:       serviceName("100% done"),
+		Profile:    "100% done",
+		Executable: "/usr/local/bin/kanban-reports",
+		Args:       []string{"--config", "/home/ana/50%/kanban.yaml", "--profile", "100% done"},
+		WorkDir:    "/home/ana/50%",
+		Schedule:   "daily",
+	}
+
+	service, timer := systemdUnits(spec)
+	for _, str := range []string{
+		"Description=kanban-reports profile 100%% done",
+		"WorkingDirectory=/home/ana/50%%\n",
+		`ExecStart=/usr/local/bin/kanban-reports --config /home/ana/50%%/kanban.yaml --profile "100%% done"`,
+	} {
+		if !strings.Contains(service, str) {
+			t.Errorf("service unit doesn't contain %q:\n%s", str, service)
+		}
+	}
+	if !strings.Contains(timer, "Description=Run kanban-reports profile 100%% done daily") {
+		t.Errorf("timer doesn't escape the profile:\n%s", timer)
+	}
+}
+
+func TestNewServiceSpec(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "kanban.csv")
+	if err := os.WriteFile(csvPath, []byte("id,name,estimate,is_completed,completed_at\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, "kanban.yaml")
+	content := "profiles:\n" +
+		"  weekly-team:\n    csv: " + csvPath + "\n    type: team\n    output: " + filepath.Join(dir, "weekly.md") + "\n" +
+		"  monthly:\n    csv: " + csvPath + "\n    type: team\n    output-template: monthly-{date}.md\n" +
+		"  console:\n    csv: " + csvPath + "\n    type: team\n" +
+		"  broken:\n    csv: " + csvPath + "\n    ad-hoc: sometimes\n    output: out.md\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := newServiceSpec(configPath, "weekly-team", "daily", "18:05")
+	if err != nil {
+		t.Fatalf("newServiceSpec() error = %v", err)
+	}
+	if spec.Name != "kanban-reports-weekly-team" || strings.Join(spec.Args, " ") != "--config "+configPath+" --profile weekly-team" || spec.Hour != 18 || spec.Minute != 5 {
+		t.Errorf("newServiceSpec() = %+v", spec)
+	}
+	if _, err := newServiceSpec(configPath, "monthly", "monthly", "07:00"); err != nil {
+		t.Errorf("newServiceSpec() with output-template error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		profile  string
+		schedule string
+		at       string
+		errorMsg string
+	}{
+		{"Unknown profile", "nightly", "daily", "07:00", `unknown profile "nightly"`},
+		{"No output", "console", "daily", "07:00", "sets no output or output-template"},
+		{"Invalid profile", "broken", "daily", "07:00", "profile broken is invalid (ad-hoc: invalid ad-hoc filter type: sometimes"},
+		{"Invalid schedule", "weekly-team", "hourly", "07:00", "invalid schedule: hourly"},
+		{"Invalid time", "weekly-team", "daily", "25:00", "invalid time: 25:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newServiceSpec(configPath, tt.profile, tt.schedule, tt.at)
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("newServiceSpec() error = %v, want it to contain %q", err, tt.errorMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hannasdev/kanban-reports/internal/config"
+	"github.com/hannasdev/kanban-reports/internal/menu"
+	"github.com/hannasdev/kanban-reports/pkg/terminal"
+)
+
+// firstRun reports whether this is the first launch on a terminal: no
+// arguments and no saved config file yet
+func firstRun() bool {
+	if len(os.Args) > 1 || !terminal.StdinIsTerminal() || terminal.Height() == 0 {
+		return false
+	}
+	path, err := config.DefaultConfigPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return errors.Is(err, os.ErrNotExist)
+}
+
+// runFirstRun offers the setup on the first launch instead of failing for
+// the missing --csv
+func runFirstRun() int {
+	terminal.SetASCII(terminal.ASCIIPreferred())
+	stdout = terminal.Stdout()
+	path, err := config.DefaultConfigPath()
+	if err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+
+	m := menu.NewMenuWithIO(os.Stdin, stdout)
+	start, err := m.OfferSetup(path)
+	if err != nil || !start {
+		fmt.Fprintf(stdout, "\n💡 Run %s setup at any time, or %s --help to see the options.\n", os.Args[0], os.Args[0])
+		return 0
+	}
+	fmt.Fprintln(stdout)
+	return setup(m, path)
+}
+
+// runSetup asks how the team's exports look with "setup" and saves the
+// answers as the default profile of the saved config file
+func runSetup(args []string) int {
+	fs := flag.NewFlagSet("setup", flag.ContinueOnError)
+	ascii := fs.Bool("ascii", false, "Use plain ASCII markers instead of emoji")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s setup [--ascii]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	terminal.SetASCII(terminal.ASCIIMode(fs, *ascii))
+	stdout = terminal.Stdout()
+
+	path, err := config.DefaultConfigPath()
+	if err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+	return setup(menu.NewMenuWithIO(os.Stdin, stdout), path)
+}
+
+// setup runs the setup with m and saves its profile to path, replacing the
+// default profile of an existing file and keeping the others
+func setup(m *menu.Menu, path string) int {
+	var profiles config.Profiles
+	if _, err := os.Stat(path); err == nil {
+		if profiles, err = config.LoadProfiles(path); err != nil {
+			fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+			return 1
+		}
+	}
+
+	profile, err := m.RunSetup()
+	if err == nil {
+		var save bool
+		if save, err = m.ConfirmSave(path, profile); err == nil && !save {
+			fmt.Fprintln(stdout, "\n👋 Nothing was saved.")
+			return 0
+		}
+	}
+	if err != nil {
+		if _, ok := err.(menu.QuitError); ok {
+			fmt.Fprintln(stdout, "\n👋 Setup cancelled; nothing was saved.")
+			return 0
+		}
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+
+	if err := config.SaveProfiles(path, withDefaultProfile(profiles, profile)); err != nil {
+		fmt.Fprintf(stdout, "❌ Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "\n✅ Saved to %s\n\n", path)
+	csv := "--csv FILE "
+	if _, ok := findSetting(profile, "csv"); ok {
+		csv = ""
+	}
+	fmt.Fprintln(stdout, "Next steps:")
+	for _, step := range [][2]string{
+		{csv + "--type contributor", "story points by contributor"},
+		{csv + "--metrics all", "all flow metrics"},
+		{"setup", "change the answers"},
+	} {
+		fmt.Fprintf(stdout, "   %s %-29s %s\n", os.Args[0], step[0], step[1])
+	}
+	return 0
+}
+
+// withDefaultProfile replaces the default profile of profiles, or adds it first
+func withDefaultProfile(profiles config.Profiles, profile config.Profile) config.Profiles {
+	for i := range profiles {
+		if profiles[i].Name == config.DefaultProfile {
+			profiles[i] = profile
+			return profiles
+		}
+	}
+	return append(config.Profiles{profile}, profiles...)
+}
+
+// findSetting returns the value of an option of profile
+func findSetting(profile config.Profile, key string) (string, bool) {
+	for _, setting := range profile.Settings {
+		if setting.Key == key {
+			return setting.Value, true
+		}
+	}
+	return "", false
+}
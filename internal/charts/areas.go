@@ -0,0 +1,92 @@
+package charts
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Series is one band of a stacked area chart, with a value for each label
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// StackedAreas draws the series as bands stacked on each other, the first at
+// the bottom, across the labels in order. Cumulative flow diagrams stack the
+// states this way.
+func StackedAreas(title, valueLabel string, labels []string, series []Series) string {
+	totals := make([]float64, len(labels))
+	for _, s := range series {
+		for i := range totals {
+			if i < len(s.Values) {
+				totals[i] += s.Values[i]
+			}
+		}
+	}
+	largest := 0.0
+	for _, total := range totals {
+		largest = max(largest, total)
+	}
+	f := newFrame(title, valueLabel, largest)
+	if len(labels) == 0 {
+		return f.String()
+	}
+
+	// Each label is a point along the plot; a single label spans it as a flat band
+	type column struct {
+		x     float64
+		label int
+	}
+	var columns []column
+	for i := range labels {
+		columns = append(columns, column{f.x(float64(i) / float64(max(len(labels)-1, 1))), i})
+	}
+	if len(labels) == 1 {
+		columns = append(columns, column{f.right(), 0})
+	}
+
+	below := make([]float64, len(labels))
+	for n, s := range series {
+		above := make([]float64, len(labels))
+		for i := range labels {
+			above[i] = below[i]
+			if i < len(s.Values) {
+				above[i] += s.Values[i]
+			}
+		}
+
+		var points []string
+		for _, c := range columns {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", c.x, f.y(above[c.label])))
+		}
+		for i := len(columns) - 1; i >= 0; i-- {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", columns[i].x, f.y(below[columns[i].label])))
+		}
+		fmt.Fprintf(&f.b, `<polygon points="%s" fill="%s" fill-opacity="0.8"><title>%s</title></polygon>`+"\n",
+			strings.Join(points, " "), color(n), html.EscapeString(s.Name))
+		below = above
+	}
+
+	step := labelStep(len(labels))
+	for i, label := range labels {
+		if i%step != 0 {
+			continue
+		}
+		anchor := "middle"
+		switch {
+		case i == 0:
+			anchor = "start"
+		case i == len(labels)-1:
+			anchor = "end"
+		}
+		f.label(columns[i].x, anchor, label)
+	}
+
+	var names []string
+	for _, s := range series {
+		names = append(names, s.Name)
+	}
+	f.legend(names)
+	return f.String()
+}
@@ -0,0 +1,40 @@
+package charts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStackedAreas(t *testing.T) {
+	series := []Series{
+		{Name: "Done", Values: []float64{0, 2, 4}},
+		{Name: "In Progress", Values: []float64{2, 2, 0}},
+	}
+	svg := StackedAreas("Cumulative Flow", "Items", []string{"Jan", "Feb", "Mar"}, series)
+	assertWellFormed(t, svg)
+
+	for _, want := range []string{
+		// Done at the bottom, In Progress stacked on it up to the total of 4
+		`<polygon points="56.0,320.0 328.0,168.0 600.0,16.0 600.0,320.0 328.0,320.0 56.0,320.0" fill="#0969da"`,
+		`<polygon points="56.0,168.0 328.0,16.0 600.0,16.0 600.0,16.0 328.0,168.0 56.0,320.0" fill="#1a7f37"`,
+		`<text x="56.0" y="344" text-anchor="start">Jan</text>`,
+		`<text x="600.0" y="344" text-anchor="end">Mar</text>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("StackedAreas() doesn't contain %q:\n%s", want, svg)
+		}
+	}
+	// The legend lists the top band first
+	if strings.Index(svg, ">In Progress</text>") > strings.Index(svg, ">Done</text>") {
+		t.Errorf("legend doesn't list In Progress above Done:\n%s", svg)
+	}
+}
+
+func TestStackedAreas_SingleLabel(t *testing.T) {
+	svg := StackedAreas("Cumulative Flow", "Items", []string{"Jan"}, []Series{{Name: "Done", Values: []float64{3}}})
+	assertWellFormed(t, svg)
+
+	if !strings.Contains(svg, `<polygon points="56.0,16.0 600.0,16.0 600.0,320.0 56.0,320.0"`) {
+		t.Errorf("StackedAreas() with one label isn't a band across the plot:\n%s", svg)
+	}
+}
@@ -0,0 +1,38 @@
+package charts
+
+import (
+	"fmt"
+	"html"
+)
+
+// Bar is one bar of a bar chart, such as the items completed in a period
+type Bar struct {
+	Label string
+	Value float64
+}
+
+// Bars draws a bar chart of the values in order, labelling the bars below
+// the axis. Every bar has its label and value as its tooltip.
+func Bars(title, valueLabel string, bars []Bar) string {
+	largest := 0.0
+	for _, bar := range bars {
+		largest = max(largest, bar.Value)
+	}
+	f := newFrame(title, valueLabel, largest)
+	if len(bars) == 0 {
+		return f.String()
+	}
+
+	slot := plotWidth() / float64(len(bars))
+	width := max(slot*0.8, 1)
+	step := labelStep(len(bars))
+	for i, bar := range bars {
+		x := f.x(float64(i)/float64(len(bars))) + (slot-width)/2
+		fmt.Fprintf(&f.b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %s</title></rect>`+"\n",
+			x, f.y(bar.Value), width, f.bottom()-f.y(bar.Value), color(0), html.EscapeString(bar.Label), formatValue(bar.Value))
+		if i%step == 0 {
+			f.label(x+width/2, "middle", bar.Label)
+		}
+	}
+	return f.String()
+}
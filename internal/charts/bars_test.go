@@ -0,0 +1,37 @@
+package charts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBars(t *testing.T) {
+	svg := Bars("Weekly <Throughput>", "Items completed", []Bar{{"2024-W01", 2}, {"2024-W02", 4}, {"2024-W03", 0}})
+	assertWellFormed(t, svg)
+
+	for _, want := range []string{
+		`aria-label="Weekly &lt;Throughput&gt;"`,
+		">Items completed</text>",
+		`<text x="50" y="16.0" text-anchor="end" dominant-baseline="middle">4</text>`,
+		// The tallest bar reaches the top of the plot, the empty week stays flat
+		`y="16.0" width="145.1" height="304.0" fill="#0969da"><title>2024-W02: 4</title>`,
+		`height="0.0" fill="#0969da"><title>2024-W03: 0</title>`,
+		`text-anchor="middle">2024-W01</text>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Bars() doesn't contain %q:\n%s", want, svg)
+		}
+	}
+}
+
+func TestBars_LabelsEveryFewBars(t *testing.T) {
+	var bars []Bar
+	for i := 0; i < 20; i++ {
+		bars = append(bars, Bar{Label: string(rune('a' + i)), Value: 1})
+	}
+	svg := Bars("Throughput", "Items", bars)
+
+	if got := strings.Count(svg, `y="344" text-anchor="middle"`); got != 7 {
+		t.Errorf("Bars() labelled %d bars, want every third of 20", got)
+	}
+}
@@ -0,0 +1,138 @@
+package charts
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Size of every chart, and the margins around its plot area. The right margin
+// holds the guide labels and legends.
+const (
+	Width        = 720
+	Height       = 360
+	marginLeft   = 56
+	marginRight  = 120
+	marginTop    = 16
+	marginBottom = 40
+)
+
+// palette colors the bars, areas and points, in the order of the series
+var palette = []string{"#0969da", "#1a7f37", "#bf8700", "#8250df", "#cf222e", "#57606a", "#bc4c00", "#1b7c83"}
+
+// Chart is a chart drawn as a standalone SVG image
+type Chart struct {
+	Name  string // File name without the extension, e.g. throughput
+	Title string
+	SVG   string
+}
+
+// FileName returns the name the chart is saved under
+func (c Chart) FileName() string {
+	return c.Name + ".svg"
+}
+
+// Write saves the charts in dir, creating it when needed, and returns the
+// paths of the files written
+func Write(dir string, charts []Chart) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating charts directory: %w", err)
+	}
+	var paths []string
+	for _, chart := range charts {
+		path := filepath.Join(dir, chart.FileName())
+		if err := os.WriteFile(path, []byte(chart.SVG+"\n"), 0644); err != nil {
+			return paths, fmt.Errorf("writing chart: %w", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// frame is a chart being drawn: an SVG element with a value axis from zero
+// to the top value at the left of the plot area
+type frame struct {
+	b   strings.Builder
+	top float64
+}
+
+// newFrame starts a chart with the title and a value axis up to the largest value
+func newFrame(title, valueLabel string, largest float64) *frame {
+	f := &frame{top: largest}
+	if f.top <= 0 {
+		f.top = 1
+	}
+	escaped := html.EscapeString(title)
+	fmt.Fprintf(&f.b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" font-family="sans-serif" font-size="11" role="img" aria-label="%s">`+"\n",
+		Width, Height, Width, Height, escaped)
+	fmt.Fprintf(&f.b, "<title>%s</title>\n", escaped)
+
+	fmt.Fprintf(&f.b, `<path d="M%d %d V%.1f H%.1f" fill="none" stroke="#57606a"/>`+"\n", marginLeft, marginTop, f.bottom(), f.right())
+	for _, value := range []float64{0, f.top / 2, f.top} {
+		fmt.Fprintf(&f.b, `<text x="%d" y="%.1f" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n", marginLeft-6, f.y(value), formatValue(value))
+	}
+	middle := marginTop + plotHeight()/2
+	fmt.Fprintf(&f.b, `<text x="14" y="%.1f" transform="rotate(-90 14 %.1f)" text-anchor="middle">%s</text>`+"\n", middle, middle, html.EscapeString(valueLabel))
+	return f
+}
+
+func plotWidth() float64  { return Width - marginLeft - marginRight }
+func plotHeight() float64 { return Height - marginTop - marginBottom }
+
+// right and bottom are the edges of the plot area
+func (f *frame) right() float64  { return marginLeft + plotWidth() }
+func (f *frame) bottom() float64 { return marginTop + plotHeight() }
+
+// x returns the position of a fraction of the way along the plot area
+func (f *frame) x(fraction float64) float64 {
+	return marginLeft + fraction*plotWidth()
+}
+
+// y returns the position of a value on the value axis
+func (f *frame) y(value float64) float64 {
+	return f.bottom() - value/f.top*plotHeight()
+}
+
+// label writes text below the plot area, centered on x unless anchored otherwise
+func (f *frame) label(x float64, anchor, text string) {
+	fmt.Fprintf(&f.b, `<text x="%.1f" y="%d" text-anchor="%s">%s</text>`+"\n", x, Height-16, anchor, html.EscapeString(text))
+}
+
+// legend lists the names of the series with their colors in the right
+// margin, last series first, as they are stacked
+func (f *frame) legend(names []string) {
+	for row := range names {
+		series := len(names) - 1 - row
+		y := float64(marginTop + 16*row)
+		fmt.Fprintf(&f.b, `<rect x="%.1f" y="%.1f" width="10" height="10" fill="%s"/>`+"\n", f.right()+8, y, color(series))
+		fmt.Fprintf(&f.b, `<text x="%.1f" y="%.1f" dominant-baseline="middle">%s</text>`+"\n", f.right()+22, y+5, html.EscapeString(names[series]))
+	}
+}
+
+// String closes the SVG element and returns the chart
+func (f *frame) String() string {
+	return f.b.String() + "</svg>"
+}
+
+// color returns the palette color of the i-th series
+func color(i int) string {
+	return palette[i%len(palette)]
+}
+
+// formatValue formats an axis value without needless decimals
+func formatValue(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.1f", value)
+}
+
+// labelStep returns how many bars or periods share each label below the
+// axis, so that at most eight are shown
+func labelStep(count int) int {
+	const most = 8
+	return (count + most - 1) / most
+}
@@ -0,0 +1,62 @@
+package charts
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// assertWellFormed fails the test when the chart isn't well-formed XML
+func assertWellFormed(t *testing.T, svg string) {
+	t.Helper()
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("chart isn't well-formed XML: %v\n%s", err, svg)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "charts")
+	drawn := []Chart{
+		{Name: "throughput", Title: "Weekly Throughput", SVG: Bars("Weekly Throughput", "Items", nil)},
+		{Name: "cfd", Title: "Cumulative Flow", SVG: "<svg/>"},
+	}
+
+	paths, err := Write(dir, drawn)
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	want := []string{filepath.Join(dir, "throughput.svg"), filepath.Join(dir, "cfd.svg")}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("Write() = %v, want %v", paths, want)
+	}
+	data, err := os.ReadFile(paths[1])
+	if err != nil || string(data) != "<svg/>\n" {
+		t.Errorf("cfd.svg = %q, %v, want the chart", data, err)
+	}
+}
+
+func TestLabelStep(t *testing.T) {
+	tests := []struct {
+		count, want int
+	}{
+		{1, 1},
+		{8, 1},
+		{9, 2},
+		{52, 7},
+	}
+	for _, tt := range tests {
+		if got := labelStep(tt.count); got != tt.want {
+			t.Errorf("labelStep(%d) = %d, want %d", tt.count, got, tt.want)
+		}
+	}
+}
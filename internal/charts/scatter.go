@@ -0,0 +1,69 @@
+package charts
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"time"
+)
+
+// Point is one dot of a scatterplot, placed by its date and value
+type Point struct {
+	Date    time.Time
+	Value   float64
+	Tooltip string
+}
+
+// Guide is a dashed line across a scatterplot at a value, such as a percentile
+type Guide struct {
+	Label string
+	Value float64
+}
+
+// Scatter draws the points by date, from the first to the last, with the
+// guides as labelled dashed lines
+func Scatter(title, valueLabel string, points []Point, guides []Guide) string {
+	largest := 0.0
+	var first, last time.Time
+	for i, point := range points {
+		largest = max(largest, point.Value)
+		if i == 0 || point.Date.Before(first) {
+			first = point.Date
+		}
+		if point.Date.After(last) {
+			last = point.Date
+		}
+	}
+	f := newFrame(title, valueLabel, largest)
+	if len(points) == 0 {
+		return f.String()
+	}
+
+	// Points on a single date are drawn in the middle
+	span := last.Sub(first).Hours()
+	x := func(date time.Time) float64 {
+		if span <= 0 {
+			return f.x(0.5)
+		}
+		return f.x(date.Sub(first).Hours() / span)
+	}
+	f.label(marginLeft, "start", first.Format("2006-01-02"))
+	if span > 0 {
+		f.label(f.right(), "end", last.Format("2006-01-02"))
+	}
+
+	// Labels of lines close together are moved up, so they don't overlap
+	labelY := math.Inf(1)
+	for _, guide := range guides {
+		y := f.y(guide.Value)
+		labelY = math.Min(y, labelY-12)
+		fmt.Fprintf(&f.b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#cf222e" stroke-dasharray="4 3"/>`+"\n", marginLeft, y, f.right(), y)
+		fmt.Fprintf(&f.b, `<text x="%.1f" y="%.1f" fill="#cf222e" dominant-baseline="middle">%s</text>`+"\n", f.right()+6, labelY, html.EscapeString(guide.Label))
+	}
+
+	for _, point := range points {
+		fmt.Fprintf(&f.b, `<circle cx="%.1f" cy="%.1f" r="3.5" fill="%s" fill-opacity="0.6"><title>%s</title></circle>`+"\n",
+			x(point.Date), f.y(point.Value), color(0), html.EscapeString(point.Tooltip))
+	}
+	return f.String()
+}
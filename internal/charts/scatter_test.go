@@ -0,0 +1,42 @@
+package charts
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScatter(t *testing.T) {
+	first := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	points := []Point{
+		{Date: first.AddDate(0, 0, 10), Value: 8, Tooltip: "#2 <b>"},
+		{Date: first, Value: 2, Tooltip: "#1 Login"},
+	}
+	guides := []Guide{{"p50 5.0 days", 5}, {"p85 5.5 days", 5.5}}
+	svg := Scatter("Cycle Time Scatterplot", "Cycle time (days)", points, guides)
+	assertWellFormed(t, svg)
+
+	for _, want := range []string{
+		`<circle cx="56.0" cy="244.0" r="3.5" fill="#0969da" fill-opacity="0.6"><title>#1 Login</title></circle>`,
+		`<circle cx="600.0" cy="16.0" r="3.5" fill="#0969da" fill-opacity="0.6"><title>#2 &lt;b&gt;</title></circle>`,
+		`<text x="56.0" y="344" text-anchor="start">2024-05-01</text>`,
+		`<text x="600.0" y="344" text-anchor="end">2024-05-11</text>`,
+		`<line x1="56" y1="130.0" x2="600.0" y2="130.0" stroke="#cf222e" stroke-dasharray="4 3"/>`,
+		// The second label would overlap the first, so it is moved up
+		`<text x="606.0" y="130.0" fill="#cf222e" dominant-baseline="middle">p50 5.0 days</text>`,
+		`<text x="606.0" y="111.0" fill="#cf222e" dominant-baseline="middle">p85 5.5 days</text>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Scatter() doesn't contain %q:\n%s", want, svg)
+		}
+	}
+}
+
+func TestScatter_SingleDate(t *testing.T) {
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	svg := Scatter("Cycle Time Scatterplot", "Cycle time (days)", []Point{{Date: date, Value: 1}}, nil)
+
+	if !strings.Contains(svg, `<circle cx="328.0"`) || strings.Contains(svg, `text-anchor="end">2024-05-01`) {
+		t.Errorf("Scatter() on a single date isn't centered with one date label:\n%s", svg)
+	}
+}
@@ -0,0 +1,174 @@
+// Package classify tags items with custom categories, such as "KTLO",
+// "Roadmap" or "Support", using rules read from a file
+package classify
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// Uncategorized is the category of items that no rule matches
+const Uncategorized = "Uncategorized"
+
+// Any matches every non-empty value of a field
+const Any = "*"
+
+// fields returns the values of each field that conditions can test
+var fields = map[string]func(item models.KanbanItem) []string{
+	"label":        func(item models.KanbanItem) []string { return item.Labels },
+	"epic-label":   func(item models.KanbanItem) []string { return item.EpicLabels },
+	"type":         func(item models.KanbanItem) []string { return []string{item.Type} },
+	"epic":         func(item models.KanbanItem) []string { return []string{item.Epic} },
+	"team":         func(item models.KanbanItem) []string { return []string{item.Team} },
+	"project":      func(item models.KanbanItem) []string { return []string{item.Project} },
+	"state":        func(item models.KanbanItem) []string { return []string{item.State} },
+	"workflow":     func(item models.KanbanItem) []string { return []string{item.Workflow} },
+	"owner":        func(item models.KanbanItem) []string { return item.Owners },
+	"priority":     func(item models.KanbanItem) []string { return []string{item.Priority} },
+	"milestone":    func(item models.KanbanItem) []string { return []string{item.Milestone} },
+	"product-area": models.KanbanItem.GetProductAreas,
+}
+
+// Condition requires a field of the item to have a value
+type Condition struct {
+	Field string
+	Value string // Compared case-insensitively; Any matches every non-empty value
+}
+
+// Matches reports whether the item satisfies the condition
+func (c Condition) Matches(item models.KanbanItem) bool {
+	for _, value := range fields[c.Field](item) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if c.Value == Any || strings.EqualFold(value, c.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Rule assigns a category to items that satisfy all of its conditions
+type Rule struct {
+	Category   string
+	Conditions []Condition
+}
+
+// Matches reports whether the item satisfies every condition of the rule
+func (r Rule) Matches(item models.KanbanItem) bool {
+	for _, condition := range r.Conditions {
+		if !condition.Matches(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Rules are tried in order; the first matching rule decides the category
+type Rules []Rule
+
+// Categorize returns the category of the first rule the item matches, or
+// Uncategorized
+func (rules Rules) Categorize(item models.KanbanItem) string {
+	for _, rule := range rules {
+		if rule.Matches(item) {
+			return rule.Category
+		}
+	}
+	return Uncategorized
+}
+
+// Apply returns a copy of the items with their Category set
+func (rules Rules) Apply(items []models.KanbanItem) []models.KanbanItem {
+	result := make([]models.KanbanItem, len(items))
+	for i, item := range items {
+		item.Category = rules.Categorize(item)
+		result[i] = item
+	}
+	return result
+}
+
+// ParseRules reads one "Category: field=value, field=value" rule per line.
+// All conditions of a rule must match. Blank lines and lines starting with
+// # are ignored.
+func ParseRules(r io.Reader) (Rules, error) {
+	var rules Rules
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		category, conditions, ok := strings.Cut(line, ":")
+		category = strings.TrimSpace(category)
+		if !ok || category == "" {
+			return nil, fmt.Errorf("line %d: expected \"Category: field=value\", got %q", lineNumber, line)
+		}
+
+		rule := Rule{Category: category}
+		for _, part := range strings.Split(conditions, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			field, value, ok := strings.Cut(part, "=")
+			field = strings.ToLower(strings.TrimSpace(field))
+			value = strings.TrimSpace(value)
+			if !ok || value == "" {
+				return nil, fmt.Errorf("line %d: invalid condition %q (must be field=value)", lineNumber, part)
+			}
+			if _, known := fields[field]; !known {
+				return nil, fmt.Errorf("line %d: unknown field %q (must be one of: %s)", lineNumber, field, strings.Join(FieldNames(), ", "))
+			}
+
+			rule.Conditions = append(rule.Conditions, Condition{Field: field, Value: value})
+		}
+
+		if len(rule.Conditions) == 0 {
+			return nil, fmt.Errorf("line %d: rule for %q has no conditions", lineNumber, category)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// LoadRules reads a classification rules file from disk
+func LoadRules(path string) (Rules, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening categories file: %w", err)
+	}
+	defer file.Close()
+
+	rules, err := ParseRules(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading categories file '%s': %w", path, err)
+	}
+	return rules, nil
+}
+
+// FieldNames returns the fields conditions can test, in alphabetical order
+func FieldNames() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
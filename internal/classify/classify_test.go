@@ -0,0 +1,119 @@
+package classify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestParseRules(t *testing.T) {
+	input := `# Work categories
+Support: epic-label=support
+KTLO: type=chore, team=Platform
+
+Roadmap: epic=*`
+
+	rules, err := ParseRules(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+
+	if len(rules) != 3 {
+		t.Fatalf("ParseRules() returned %d rules, want 3", len(rules))
+	}
+	if rules[1].Category != "KTLO" || len(rules[1].Conditions) != 2 {
+		t.Errorf("rules[1] = %+v, want KTLO with two conditions", rules[1])
+	}
+	if rules[1].Conditions[1] != (Condition{Field: "team", Value: "Platform"}) {
+		t.Errorf("rules[1].Conditions[1] = %+v", rules[1].Conditions[1])
+	}
+}
+
+func TestParseRules_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		errorMsg string
+	}{
+		{"Missing colon", "Support type=bug", "line 1: expected \"Category: field=value\""},
+		{"Missing category", ": type=bug", "line 1: expected"},
+		{"No conditions", "Support:", "has no conditions"},
+		{"Condition without value", "Support: type=", "invalid condition"},
+		{"Unknown field", "# rules\nSupport: color=red", "line 2: unknown field \"color\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("ParseRules() error = %v, want it to contain %q", err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestRules_Categorize(t *testing.T) {
+	rules, err := ParseRules(strings.NewReader(`Support: epic-label=support
+KTLO: type=chore, team=Platform
+Roadmap: epic=*
+Design: product-area=design`))
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		item models.KanbanItem
+		want string
+	}{
+		{"Epic label, case-insensitive", models.KanbanItem{EpicLabels: []string{"Support"}, Epic: "Helpdesk"}, "Support"},
+		{"All conditions match", models.KanbanItem{Type: "Chore", Team: "Platform"}, "KTLO"},
+		{"Only some conditions match", models.KanbanItem{Type: "chore", Team: "Mobile"}, Uncategorized},
+		{"Wildcard matches any epic", models.KanbanItem{Epic: "Checkout v2"}, "Roadmap"},
+		{"First matching rule wins", models.KanbanItem{EpicLabels: []string{"support"}, Type: "chore", Team: "Platform"}, "Support"},
+		{"One of several product areas", models.KanbanItem{ProductAreas: []string{"Web", "Design"}}, "Design"},
+		{"Product areas not yet split", models.KanbanItem{ProductArea: "Web; Design"}, "Design"},
+		{"No rule matches", models.KanbanItem{Type: "feature"}, Uncategorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.Categorize(tt.item); got != tt.want {
+				t.Errorf("Categorize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRules_Apply(t *testing.T) {
+	rules := Rules{{Category: "Bugs", Conditions: []Condition{{Field: "type", Value: "bug"}}}}
+	items := []models.KanbanItem{{ID: "1", Type: "bug"}, {ID: "2", Type: "feature"}}
+
+	result := rules.Apply(items)
+
+	if result[0].Category != "Bugs" || result[1].Category != Uncategorized {
+		t.Errorf("Apply() categories = %q, %q; want Bugs, %s", result[0].Category, result[1].Category, Uncategorized)
+	}
+	if items[0].Category != "" {
+		t.Errorf("Apply() should not modify the input items")
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "categories.txt")
+	if err := os.WriteFile(path, []byte("Support: type=bug\n"), 0644); err != nil {
+		t.Fatalf("Failed to write rules file: %v", err)
+	}
+
+	rules, err := LoadRules(path)
+	if err != nil || len(rules) != 1 {
+		t.Errorf("LoadRules() = %v, %v; want one rule", rules, err)
+	}
+
+	if _, err := LoadRules(filepath.Join(t.TempDir(), "missing.txt")); err == nil || !strings.Contains(err.Error(), "error opening categories file") {
+		t.Errorf("LoadRules() of a missing file error = %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+// Package compare computes metric-by-metric deltas between two JSON outputs
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultThreshold is the relative change, in percent, highlighted by default
+const DefaultThreshold = 10.0
+
+// identityKeys are the fields used, in order, to label array elements so
+// entries are matched by name rather than position
+var identityKeys = []string{"name", "id", "type", "period", "label", "key"}
+
+// Values maps the dotted path of each numeric value in a JSON document to the value
+type Values map[string]float64
+
+// Delta is the change of a single metric between two runs
+type Delta struct {
+	Path      string
+	Before    float64
+	After     float64
+	HasBefore bool
+	HasAfter  bool
+}
+
+// Change returns the absolute change; it is only meaningful when both values exist
+func (d Delta) Change() float64 {
+	return d.After - d.Before
+}
+
+// Percent returns the change relative to the earlier value, or false when
+// there is no earlier value to compare against
+func (d Delta) Percent() (float64, bool) {
+	if !d.HasBefore || !d.HasAfter || d.Before == 0 {
+		return 0, false
+	}
+	return d.Change() / math.Abs(d.Before) * 100, true
+}
+
+// Load reads a JSON file and collects its numeric values
+func Load(path string) (Values, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %v", path, err)
+	}
+	defer file.Close()
+
+	values, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	return values, nil
+}
+
+// Parse decodes a JSON document and collects its numeric values. Objects
+// contribute their keys to the path; array elements are labeled by an
+// identifying field such as "name" when they have one, otherwise by index.
+func Parse(r io.Reader) (Values, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	values := make(Values)
+	collect(values, "", doc)
+	return values, nil
+}
+
+// collect walks node, adding every number below it to values
+func collect(values Values, path string, node interface{}) {
+	switch v := node.(type) {
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			values[path] = f
+		}
+	case map[string]interface{}:
+		for key, child := range v {
+			collect(values, joinPath(path, key), child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			collect(values, path+"["+elementLabel(child, i)+"]", child)
+		}
+	}
+}
+
+// elementLabel identifies an array element by its identifying field or index
+func elementLabel(node interface{}, index int) string {
+	if obj, ok := node.(map[string]interface{}); ok {
+		for _, key := range identityKeys {
+			if label, ok := obj[key].(string); ok && label != "" {
+				return label
+			}
+		}
+	}
+	return fmt.Sprintf("%d", index)
+}
+
+// joinPath appends key to a dotted path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// Compare returns the deltas of all metrics found in either run, sorted by path
+func Compare(before, after Values) []Delta {
+	paths := make(map[string]bool)
+	for path := range before {
+		paths[path] = true
+	}
+	for path := range after {
+		paths[path] = true
+	}
+
+	deltas := make([]Delta, 0, len(paths))
+	for path := range paths {
+		d := Delta{Path: path}
+		d.Before, d.HasBefore = before[path]
+		d.After, d.HasAfter = after[path]
+		deltas = append(deltas, d)
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].Path < deltas[j].Path
+	})
+	return deltas
+}
+
+// Matched returns the deltas of the values found in both runs. Values of one
+// run only, such as the periods of different date ranges, are left out.
+func Matched(deltas []Delta) []Delta {
+	var matched []Delta
+	for _, d := range deltas {
+		if d.HasBefore && d.HasAfter {
+			matched = append(matched, d)
+		}
+	}
+	return matched
+}
+
+// FormatReport renders the deltas as a table. Changes of at least threshold
+// percent are highlighted and listed again at the end.
+func FormatReport(deltas []Delta, beforeName, afterName string, threshold float64) string {
+	report := "# Comparison\n\n"
+	report += fmt.Sprintf("Before: %s\n", beforeName)
+	report += fmt.Sprintf("After:  %s\n\n", afterName)
+	return report + FormatDeltas(deltas, threshold)
+}
+
+// FormatDeltas renders the table of deltas and the changes of at least
+// threshold percent, without naming the runs
+func FormatDeltas(deltas []Delta, threshold float64) string {
+	if len(deltas) == 0 {
+		return "No numeric values found to compare.\n"
+	}
+
+	report := ""
+	pathWidth := len("Metric")
+	for _, d := range deltas {
+		if len(d.Path) > pathWidth {
+			pathWidth = len(d.Path)
+		}
+	}
+
+	report += fmt.Sprintf("%-*s | %10s | %10s | %10s | %8s\n", pathWidth, "Metric", "Before", "After", "Change", "Change %")
+	report += strings.Repeat("-", pathWidth+1) + "|------------|------------|------------|---------\n"
+
+	var highlights []string
+	unchanged := 0
+	for _, d := range deltas {
+		if d.HasBefore && d.HasAfter && d.Change() == 0 {
+			unchanged++
+		}
+
+		marker := ""
+		if pct, ok := d.Percent(); ok && math.Abs(pct) >= threshold {
+			marker = " 🔻"
+			if pct > 0 {
+				marker = " 🔺"
+			}
+			highlights = append(highlights, fmt.Sprintf("- %s: %s → %s (%+.1f%%)",
+				d.Path, formatValue(d.Before), formatValue(d.After), pct))
+		}
+
+		report += fmt.Sprintf("%-*s | %10s | %10s | %10s | %8s%s\n", pathWidth, d.Path,
+			valueOrNA(d.Before, d.HasBefore), valueOrNA(d.After, d.HasAfter),
+			formatChange(d), formatPercent(d), marker)
+	}
+
+	report += fmt.Sprintf("\n%d metrics compared, %d unchanged\n", len(deltas), unchanged)
+
+	report += fmt.Sprintf("\n## Changes of %s%% or more\n\n", formatValue(threshold))
+	if len(highlights) == 0 {
+		report += "None.\n"
+	} else {
+		report += strings.Join(highlights, "\n") + "\n"
+	}
+
+	report += "\nWhether an increase is an improvement depends on the metric: higher\n"
+	report += "throughput is better, while higher lead time or age is worse.\n"
+
+	return report
+}
+
+// formatValue prints whole numbers without decimals and others with up to two
+func formatValue(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
+
+// valueOrNA formats v, or "n/a" when the run does not have the metric
+func valueOrNA(v float64, ok bool) string {
+	if !ok {
+		return "n/a"
+	}
+	return formatValue(v)
+}
+
+// formatChange formats the signed change, noting added and removed metrics
+func formatChange(d Delta) string {
+	switch {
+	case !d.HasBefore:
+		return "added"
+	case !d.HasAfter:
+		return "removed"
+	case d.Change() > 0:
+		return "+" + formatValue(d.Change())
+	}
+	return formatValue(d.Change())
+}
+
+// formatPercent formats the relative change, or "-" when it is undefined
+func formatPercent(d Delta) string {
+	pct, ok := d.Percent()
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", pct)
+}
@@ -0,0 +1,132 @@
+package compare
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := `{
+		"lead_time": {"p85": 10, "median": 4.5, "unit": "days"},
+		"throughput": [{"period": "2024-05", "items": 10}, {"items": 8}],
+		"total": 18
+	}`
+
+	values, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	expected := Values{
+		"lead_time.p85":             10,
+		"lead_time.median":          4.5,
+		"throughput[2024-05].items": 10,
+		"throughput[1].items":       8,
+		"total":                     18,
+	}
+	if len(values) != len(expected) {
+		t.Errorf("Parse() returned %d values, want %d: %v", len(values), len(expected), values)
+	}
+	for path, want := range expected {
+		if got, ok := values[path]; !ok || got != want {
+			t.Errorf("values[%q] = %v (found %v), want %v", path, got, ok, want)
+		}
+	}
+}
+
+func TestParse_InvalidJSON(t *testing.T) {
+	if _, err := Parse(strings.NewReader("Lead Time: 5 days")); err == nil {
+		t.Errorf("Parse() of text output: expected error, got nil")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	before := Values{"a": 10, "b": 4, "gone": 1}
+	after := Values{"a": 12, "b": 4, "new": 3}
+
+	deltas := Compare(before, after)
+	if len(deltas) != 4 {
+		t.Fatalf("Compare() returned %d deltas, want 4", len(deltas))
+	}
+
+	paths := []string{"a", "b", "gone", "new"}
+	for i, path := range paths {
+		if deltas[i].Path != path {
+			t.Errorf("deltas[%d].Path = %q, want %q", i, deltas[i].Path, path)
+		}
+	}
+
+	if pct, ok := deltas[0].Percent(); !ok || pct != 20 {
+		t.Errorf("Percent() of a = %v, %v; want 20, true", pct, ok)
+	}
+	if deltas[2].HasAfter || !deltas[2].HasBefore {
+		t.Errorf("gone should only exist before: %+v", deltas[2])
+	}
+	if _, ok := deltas[3].Percent(); ok {
+		t.Errorf("Percent() of an added metric should be undefined")
+	}
+}
+
+func TestMatched(t *testing.T) {
+	before := Values{"a": 10, "periods[2024-01].items": 4}
+	after := Values{"a": 12, "periods[2024-04].items": 3}
+
+	matched := Matched(Compare(before, after))
+	if len(matched) != 1 || matched[0].Path != "a" {
+		t.Errorf("Matched() = %+v, want only a", matched)
+	}
+}
+
+func TestFormatReport(t *testing.T) {
+	deltas := Compare(
+		Values{"lead_time.p85": 10, "throughput.items": 8, "wip": 5},
+		Values{"lead_time.p85": 8, "throughput.items": 12, "wip": 5.2},
+	)
+
+	report := FormatReport(deltas, "before.json", "after.json", 10)
+
+	expected := []string{
+		"Before: before.json",
+		"After:  after.json",
+		"Metric           |     Before |      After |     Change | Change %",
+		"lead_time.p85    |         10 |          8 |         -2 |   -20.0% 🔻",
+		"throughput.items |          8 |         12 |         +4 |   +50.0% 🔺",
+		"wip              |          5 |        5.2 |       +0.2 |    +4.0%\n",
+		"3 metrics compared, 0 unchanged",
+		"## Changes of 10% or more",
+		"- lead_time.p85: 10 → 8 (-20.0%)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report doesn't contain %q\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "- wip:") {
+		t.Errorf("Changes below the threshold should not be highlighted")
+	}
+}
+
+func TestFormatReport_Empty(t *testing.T) {
+	report := FormatReport(nil, "a.json", "b.json", DefaultThreshold)
+	if !strings.Contains(report, "No numeric values found to compare.") {
+		t.Errorf("Report doesn't explain that nothing was compared:\n%s", report)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.json")
+	if err := os.WriteFile(path, []byte(`{"items": 3}`), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	values, err := Load(path)
+	if err != nil || values["items"] != 3 {
+		t.Errorf("Load() = %v, %v; want items = 3", values, err)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("Load() of a missing file: expected error, got nil")
+	}
+}
@@ -3,13 +3,23 @@ package config
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/hannasdev/kanban-reports/internal/classify"
 	"github.com/hannasdev/kanban-reports/internal/metrics"
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/parser"
+	"github.com/hannasdev/kanban-reports/internal/query"
 	"github.com/hannasdev/kanban-reports/internal/reports"
+	"github.com/hannasdev/kanban-reports/internal/retention"
 	"github.com/hannasdev/kanban-reports/internal/validation"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+	"github.com/hannasdev/kanban-reports/pkg/filtering"
+	"github.com/hannasdev/kanban-reports/pkg/terminal"
 	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
@@ -17,69 +27,255 @@ import (
 type Config struct {
 	// Input file configuration
 	CSVPath     string
+	CSVPaths    []string // Every file to read when several are given; CSVPath is the first
+	BaselinePath string  // Earlier export the CSV is compared with, listing the changes instead of reports
 	Delimiter   models.DelimiterType
 	AutoDetect  bool
+	EstimateMapping models.EstimateMapping
+	ColumnMap   models.ColumnMap
+	FileDelimiters map[string]models.DelimiterType // Delimiter of single CSV files, by path
+	FileColumnMaps map[string]models.ColumnMap     // Column renames of single CSV files, by path
+	BoolTokens  models.BoolTokens
+	DecimalSeparator models.DecimalSeparator
+	DateFormat  models.DateFormat // Layouts of the timestamps in the CSV
+	MaxErrors   int
 
 	// Report/metrics type configuration
 	ReportType  reports.ReportType
+	ReportTypes []reports.ReportType // All requested report types; ReportType is the first
 	MetricsType metrics.MetricsType
+	MetricsSections []metrics.MetricsType // Sections of --metrics all, in order
+	SplitBy     metrics.SplitField
+	Separator   string // Line placed between sections of combined output
+	Width       int    // Maximum width of wide tables (0 = terminal width, or no limit for files)
 	PeriodType  metrics.PeriodType
+	WeekNumbering types.WeekNumbering // Where weeks start and how they are labeled
+	PeriodLabels types.PeriodLabels // How weeks and months are labeled in trend tables
+	Unit        types.EstimateUnit
+	Stats       []metrics.StatType
+	HistogramBuckets []float64
+	Holidays    dateutil.Holidays
+	Absences    dateutil.Absences
+	Annotations metrics.Annotations
+	Iterations  metrics.Iterations // Iteration dates for the commitment report
+	Categories  classify.Rules // Rules that tag items with custom categories
+	History     models.StateHistory // State transitions per item from --history
+	DigestSettings metrics.DigestSettings // Thresholds behind the digest highlights
+	Forecast    metrics.ForecastSettings // Simulations and targets of the forecast
+	AgeThresholds metrics.AgeThresholds
+	WIPLimits   metrics.WIPLimits // Items each team may have in a state at once
+	AgeMode     metrics.AgeMode // Age summary or aging work in progress chart
+	Both        bool // Generate both the report and the metrics
 
 	// Date range configuration
 	StartDate   time.Time
 	EndDate     time.Time
 	LastNDays   int
+	Range       types.DateRangePreset // Calendar preset the dates were resolved from
+	CompareWith  string    // Baseline range the date range is compared with, as given
+	CompareStart time.Time // First moment of the baseline range
+	CompareEnd   time.Time // Last moment of the baseline range
 
 	// Output configuration
 	OutputPath  string
+	NoOverwrite bool // Fail instead of replacing an existing output file
+	Append      bool // Add the run with a dated header to the end of the output file
+	OutputGlob  string           // Pattern matching earlier outputs of --output-template
+	Retention   retention.Policy // Which earlier outputs to keep
+	Sign        bool             // Write a SHA-256 checksum next to the output file
+	MinisignKey string           // Secret key to also sign the output with minisign
+	Format      types.OutputFormat // Text tables or a JSON document
+	Export      types.ExportType   // Raw data written instead of reports and metrics
+	HTMLFilters bool               // Team, epic and date filters recomputing totals in the HTML page
+	ChartsDir   string             // Directory the charts are saved in as SVG images
+	ASCII       bool // Plain ASCII markers instead of emoji
+	NoPager     bool // Print long console output in full instead of a screen at a time
+	DataQuality bool
+	WarningsFile string
+	Timeout     time.Duration // Longest a run may take (0 for no limit)
 
 	// Filtering configuration
 	AdHocFilter types.AdHocFilterType
+	AdHocRules  filtering.AdHocRules
+	Reopened    types.ReopenedPolicy
 	FilterField models.FilterField
+	Filter      *query.Query // Expression items must satisfy, or nil for every item
+
+	// Report layout configuration
+	Hierarchy   bool
+	NoExplanations bool // Leave out the sections explaining each metric
+	ProductAreaMode reports.ProductAreaMode
+	MinEpics    int // Concurrent epics at which the contention report lists a contributor
+	Epic        string // Epic the epic-contributor report is limited to, or all epics
+	LabelPrefix string // Prefix the labels of the label report start with, or all labels
+	GroupBy     reports.GroupField // Field the group report totals by
+	PivotRows   reports.GroupField // Field the rows of the pivot report total by
+	PivotCols   reports.GroupField // Field the columns of the pivot report total by
+	AllocateBy  reports.GroupField // Field the allocation report divides each owner's work by
+	Detail      bool // List each contributor's completed items in the contributor report
+	Top         int  // Items listed per contributor with Detail, or all when 0
 	
 	// CLI mode flags
 	Interactive bool
+	NonInteractive bool // Never prompt and skip console previews, for pipelines
+	AnswersPath string // Answers for the interactive menu, one per line ("-" for stdin)
 	ShowHelp    bool
+	ConfigPath  string // Config file the profile was read from
+	Profile     string // Profile whose options were used, if any
 }
 
 // flagSet holds all parsed command-line flags
 type flagSet struct {
-	csvPath      *string
-	reportType   *string
+	csvPath      *listFlag
+	reportType   *listFlag
 	metricsType  *string
+	both         *bool
+	digest       *bool
+	digestSettingsPath *string
+	simulations  *int
+	forecastItems *int
+	forecastDate *string
+	forecastMinWeeks *int
+	seed         *int64
+	onlyMetrics  *string
+	splitBy      *string
+	excludeMetrics *string
+	sectionOrder *string
+	separator    *string
 	periodType   *string
+	weekNumbering *string
+	periodLabels *string
+	unit         *string
+	stats        *string
+	histogramBuckets *string
+	holidaysPath *string
+	absencesPath *string
+	annotationsPath *string
+	iterationsPath *string
+	categoriesPath  *string
+	historyPath  *string
+	ageSLA       *string
+	wipLimits    *string
+	ageMode      *string
 	startDateStr *string
 	endDateStr   *string
 	lastNDays    *int
+	dateRange    *string
+	compareWith  *string
 	outputPath   *string
+	outputTemplate *string
+	noOverwrite  *bool
+	appendOutput *bool
+	keepLast     *int
+	keepDays     *int
+	sign         *bool
+	minisignKey  *string
+	format       *string
+	export       *string
+	htmlFilters  *bool
+	chartsDir    *string
+	baseline     *string
+	ascii        *bool
+	noPager      *bool
+	timeout      *string
+	width        *int
+	dataQuality  *bool
+	warningsFile *string
+	maxErrors    *int
 	delimiterStr *string
+	estimateMap  *string
+	columnMap    *string
+	fileDelimiter *listFlag
+	fileColumnMap *listFlag
+	truthy       *string
+	decimalSep   *string
+	dateFormat   *string
+	falsy        *string
 	adHocFilter  *string
+	adHocRules   *string
+	reopened     *string
 	filterField  *string
+	filter       *string
+	hierarchy    *bool
+	noExplanations *bool
+	minEpics     *int
+	epic         *string
+	labelPrefix  *string
+	groupBy      *string
+	pivotRows    *string
+	pivotCols    *string
+	allocateBy   *string
+	detail       *bool
+	top          *int
+	productAreaMode *string
+	
+	// Config file flags
+	configPath   *string
+	profile      *string
 	
 	// Control flags
 	help         *bool
 	helpShort    *bool
 	interactive  *bool
 	interactiveShort *bool
+	answersPath      *string
+	nonInteractive   *bool
 	version      *bool
 	examples     *bool
 }
 
+// listFlag is a string flag that may be repeated; the values are joined with commas
+type listFlag []string
+
+// newListFlag registers a repeatable string flag
+func newListFlag(fs *flag.FlagSet, name, usage string) *listFlag {
+	l := &listFlag{}
+	fs.Var(l, name, usage)
+	return l
+}
+
+// String returns the values joined with commas
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set appends a value each time the flag is given
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
 // ParseFlags parses command-line flags and returns a populated Config
 func ParseFlags() (*Config, error) {
-	flags := defineFlags()
+	flags := defineFlags(flag.CommandLine)
 	
 	flag.Usage = showUsage
 	flag.Parse()
 
+	// Fill in the options of the chosen profile before anything reads them
+	configPath, profileName := *flags.configPath, *flags.profile
+	if configPath == "" {
+		configPath, profileName = savedProfile(profileName)
+	}
+	profile, err := applyProfile(flag.CommandLine, configPath, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("%v\n\nFor help: %s --help", err, os.Args[0])
+	}
+
+	// Apply the output mode before anything is printed
+	terminal.SetASCII(terminal.ASCIIMode(flag.CommandLine, *flags.ascii))
+
 	// Handle special control flags first
 	if err := handleControlFlags(flags); err != nil {
 		return nil, err
 	}
 
 	// Check for interactive mode
-	if *flags.interactive || *flags.interactiveShort {
-		return &Config{Interactive: true}, nil
+	if *flags.interactive || *flags.interactiveShort || *flags.answersPath != "" {
+		if *flags.nonInteractive {
+			return nil, fmt.Errorf("--non-interactive cannot be combined with --interactive or --answers")
+		}
+		return &Config{Interactive: true, AnswersPath: *flags.answersPath, ASCII: terminal.ASCII()}, nil
 	}
 
 	// Parse and validate configuration
@@ -87,31 +283,148 @@ func ParseFlags() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("%v\n\nFor help: %s --help", err, os.Args[0])
 	}
+	if profile != "" {
+		config.ConfigPath, config.Profile = configPath, profile
+	}
+
+	return config, nil
+}
+
+// ParseArgs parses the arguments of one run requested by another program,
+// such as over the IPC server, without touching the process's flags. Help,
+// interactive mode and writing the output to a file are not available,
+// since the output is returned to the caller.
+func ParseArgs(args []string) (*Config, error) {
+	fs := flag.NewFlagSet("kanban-reports", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	flags := defineFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument: %s", fs.Arg(0))
+	}
+	profile, err := applyProfile(fs, *flags.configPath, *flags.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if *flags.help || *flags.helpShort || *flags.version || *flags.examples {
+		return nil, fmt.Errorf("--help, --version and --examples are only available on the command line")
+	}
+	if *flags.interactive || *flags.interactiveShort || *flags.answersPath != "" || *flags.nonInteractive {
+		return nil, fmt.Errorf("--interactive, --answers and --non-interactive are only available on the command line")
+	}
+	if *flags.outputPath != "" || *flags.outputTemplate != "" || *flags.warningsFile != "" || *flags.chartsDir != "" {
+		return nil, fmt.Errorf("--output, --output-template, --warnings-file and --charts-dir are not available here; the output is returned instead")
+	}
 
+	config, err := buildConfig(flags)
+	if err != nil {
+		return nil, err
+	}
+	// The process's own ASCII setting doesn't apply to the caller's output
+	config.ASCII = *flags.ascii
+	config.ConfigPath, config.Profile = *flags.configPath, profile
 	return config, nil
 }
 
-// defineFlags sets up all command-line flags
-func defineFlags() *flagSet {
+// defineFlags sets up all command-line flags on fs
+func defineFlags(fs *flag.FlagSet) *flagSet {
 	return &flagSet{
-		csvPath:      flag.String("csv", "", "Path to the kanban CSV file"),
-		reportType:   flag.String("type", "", "Type of report: contributor, epic, product-area, team"),
-		metricsType:  flag.String("metrics", "", "Type of metrics: lead-time, throughput, flow, estimation, age, improvement, all"),
-		periodType:   flag.String("period", DefaultPeriodType, "Time period for reports: week, month"),
-		startDateStr: flag.String("start", "", "Start date (YYYY-MM-DD)"),
-		endDateStr:   flag.String("end", "", "End date (YYYY-MM-DD)"),
-		lastNDays:    flag.Int("last", 0, "Generate report for the last N days"),
-		outputPath:   flag.String("output", "", "Path to save the report (optional)"),
-		delimiterStr: flag.String("delimiter", DefaultDelimiter, "CSV delimiter: comma, tab, semicolon, or auto for automatic detection"),
-		adHocFilter:  flag.String("ad-hoc", DefaultAdHocFilter, "How to handle ad-hoc requests: include, exclude, only"),
-		filterField:  flag.String("filter-field", DefaultFilterField, "Date field to filter by: completed_at, created_at, started_at"),
+		csvPath:      newListFlag(fs, "csv", "Path to the kanban CSV file; several files or a glob such as \"exports/*.csv\" are merged (comma-separated or repeated)"),
+		reportType:   newListFlag(fs, "type", "Type of report: contributor, epic, product-area, team, category, workload, contention, epic-contributor, team-month, label, milestone, iteration, state, group, pivot, epic-progress, allocation (comma-separated or repeated for several)"),
+		metricsType:  fs.String("metrics", "", "Type of metrics: lead-time, throughput, flow, estimation, age, improvement, workflow, benchmark, review, cfd, priority, digest, weekday, forecast, commitment, scatterplot, all"),
+		splitBy:      fs.String("split-by", DefaultSplitBy, "Field to group by in the benchmark and review: team, product-area, epic, workflow, category"),
+		onlyMetrics:  fs.String("only-metrics", "", "Comma-separated metrics to include in --metrics all, e.g. \"lead-time,throughput\""),
+		excludeMetrics: fs.String("exclude-metrics", "", "Comma-separated metrics to leave out of --metrics all, e.g. \"age,estimation\""),
+		sectionOrder: fs.String("section-order", "", "Comma-separated metrics shown first in --metrics all, e.g. \"age,throughput\""),
+		separator:    fs.String("section-separator", "", "Line placed between sections of combined output (supports \\n and \\t; default: 80 '=')"),
+		both:         fs.Bool("both", false, "Generate both the --type report and the --metrics output"),
+		digest:       fs.Bool("digest", false, "Summarize the latest week in 5-10 plain-language highlights (same as --metrics digest)"),
+		digestSettingsPath: fs.String("digest-settings", "", "File of \"key = value\" digest thresholds: baseline-weeks, cycle-weeks, aging-days, steady-percent, min-sample, max-bullets"),
+		simulations:  fs.Int("simulations", DefaultSimulations, "Number of Monte Carlo runs behind --metrics forecast"),
+		forecastItems: fs.Int("forecast-items", 0, "Forecast when this many items will be done (default: the items not yet completed)"),
+		forecastDate: fs.String("forecast-date", "", "Forecast how many items will be done by this date (YYYY-MM-DD)"),
+		forecastMinWeeks: fs.Int("forecast-min-weeks", DefaultForecastMinWeeks, "Fewest weeks of throughput history --metrics forecast needs; shorter histories are refused"),
+		seed:         fs.Int64("seed", DefaultSeed, "Seed of the Monte Carlo runs; the same seed and data give the same forecast"),
+		periodType:   fs.String("period", DefaultPeriodType, "Time period for reports: week, month"),
+		periodLabels: fs.String("period-labels", DefaultPeriodLabels, "Labels of weeks and months in throughput and improvement tables: iso (2024-05, 2024-W20), friendly (May 2024, Week 20, 2024)"),
+		weekNumbering: fs.String("week-numbering", DefaultWeekNumbering, "Week convention for --period week: iso (Monday start, ISO week numbers), us (Sunday start, week 1 holds January 1)"),
+		unit:         fs.String("unit", DefaultUnit, "What estimates measure: points, hours, items (count items and ignore estimates)"),
+		stats:        fs.String("stats", DefaultStats, "Statistics shown in metrics tables: min, max, avg, median, p85, p95, stddev, count"),
+		histogramBuckets: fs.String("histogram-buckets", DefaultHistogramBuckets, "Upper bounds in days for cycle time histogram buckets"),
+		holidaysPath: fs.String("holidays", "", "File of holiday dates (YYYY-MM-DD per line) excluded from working-day ages"),
+		categoriesPath: fs.String("categories", "", "File of classification rules (\"Category: field=value, ...\" per line) for --type category and --split-by category"),
+		historyPath:  fs.String("history", "", "State history CSV (item_id, from, to, timestamp) for real per-state durations in flow and cfd metrics"),
+		annotationsPath: fs.String("annotations", "", "File of dated events (\"YYYY-MM-DD text\" per line) marked in throughput and improvement trends"),
+		iterationsPath: fs.String("iterations", "", "File of iteration dates (\"START..END name\" per line) for --metrics commitment"),
+		absencesPath: fs.String("absences", "", "File of team absences (\"START..END PERCENT\" per line) used to adjust throughput trends"),
+		ageMode:      fs.String("age-mode", DefaultAgeMode, "How --metrics age shows open work: summary, aging-wip (open items by state against completed cycle time percentiles)"),
+		ageSLA:       fs.String("age-sla", "", "Per-state age thresholds in days, e.g. \"In Progress=5:10,*=10:20\" (warning:critical)"),
+		wipLimits:    fs.String("wip-limits", "", "Items each team may have in a state at once, e.g. \"In Progress=5,Payments/Review=2\"; flagged by the age, cfd and digest metrics"),
+		startDateStr: fs.String("start", "", "Start date (YYYY-MM-DD)"),
+		endDateStr:   fs.String("end", "", "End date (YYYY-MM-DD)"),
+		lastNDays:    fs.Int("last", 0, "Generate report for the last N days"),
+		dateRange:    fs.String("range", "", "Calendar date range: this-week, last-week, this-month, last-month, this-quarter, last-quarter, ytd"),
+		compareWith:  fs.String("compare-with", "", "Compare the date range with a baseline range and show the change of every value: previous (the same length just before), a --range preset, or START..END"),
+		outputPath:   fs.String("output", "", "Path to save the report (optional)"),
+		outputTemplate: fs.String("output-template", "", "Path to save the report with {date} and {type} expanded, e.g. \"report-{date}-{type}.md\""),
+		noOverwrite:  fs.Bool("no-overwrite", false, "Fail instead of replacing an existing output file"),
+		appendOutput: fs.Bool("append", false, "Add the run with a dated header to the end of the output file instead of replacing it, keeping a journal"),
+		keepLast:     fs.Int("keep-last", 0, "Keep only the newest N files written by --output-template (0 keeps all)"),
+		keepDays:     fs.Int("keep-days", 0, "Remove files written by --output-template more than N days ago (0 keeps all)"),
+		sign:         fs.Bool("sign", false, "Write a SHA-256 checksum (.sha256) next to the output file"),
+		minisignKey:  fs.String("minisign-key", "", "Secret key to also sign the output with minisign (.minisig), with --sign"),
+		format:       fs.String("format", DefaultFormat, "Output format: text, json (structured results for scripts and dashboards), markdown, html (documents to share), problems (data-quality findings as file:line: message for editors), svg (the --metrics scatterplot as an image)"),
+		export:       fs.String("export", "", "Write raw data instead of reports: items-json (one JSON object per item with lead, cycle, age and queue times, for data warehouses)"),
+		htmlFilters:  fs.Bool("html-filters", false, "Add team, epic and date filters to --format html output that total the matching items in the browser"),
+		baseline:     fs.String("baseline", "", "Earlier CSV export of the same board: list the items added, removed, completed, re-opened, re-estimated or moved between epics and teams since, instead of reports"),
+		chartsDir:    fs.String("charts-dir", "", "Save throughput, cumulative flow and cycle time scatterplot charts as SVG images in this directory, shown in --format html and markdown output"),
+		ascii:        fs.Bool("ascii", false, "Use plain ASCII markers instead of emoji (also enabled by NO_COLOR or TERM=dumb)"),
+		noPager:      fs.Bool("no-pager", false, "Print console output longer than the terminal in full instead of a screen at a time"),
+		timeout:      fs.String("timeout", "", "Stop a run that takes longer than this, e.g. 30s or 2m (default: no limit)"),
+		width:        fs.Int("width", 0, "Maximum width of wide tables (default: terminal width, no limit when writing to --output)"),
+		maxErrors:    fs.Int("max-errors", DefaultMaxErrors, "Abort if more than N rows fail to parse (-1 for no limit)"),
+		warningsFile: fs.String("warnings-file", "", "Write parser and filter warnings as a JSON array to this file (\"-\" for stderr)"),
+		dataQuality:  fs.Bool("data-quality", false, "Append a data-quality report listing values that could not be parsed"),
+		delimiterStr: fs.String("delimiter", DefaultDelimiter, "CSV delimiter: comma, tab, semicolon, or auto for automatic detection"),
+		columnMap:    fs.String("column-map", "", "Rename export columns to expected names, e.g. \"Story Points=estimate,Title=name\""),
+		fileDelimiter: newListFlag(fs, "file-delimiter", "Delimiter of one of the --csv files, as PATH=DELIMITER, e.g. \"exports/jira.csv=semicolon\" (comma-separated or repeated)"),
+		fileColumnMap: newListFlag(fs, "file-column-map", "Column rename for one of the --csv files, as PATH:EXPORT_COLUMN=column, e.g. \"exports/jira.csv:Summary=name\" (comma-separated or repeated)"),
+		decimalSep:   fs.String("decimal-separator", DefaultDecimalSeparator, "Decimal separator in estimates: auto, dot, comma"),
+		dateFormat:   fs.String("date-format", DefaultDateFormat, "Format of timestamps in the CSV: shortcut (2024/05/07 15:04:05), iso, jira, eu, us, or a Go time layout such as \"2006-01-02 15:04\""),
+		truthy:       fs.String("truthy", "", "Comma-separated values treated as true in boolean columns (replaces the defaults)"),
+		falsy:        fs.String("falsy", "", "Comma-separated values treated as false in boolean columns (replaces the defaults)"),
+		estimateMap:  fs.String("estimate-map", "", "Point values for non-numeric estimates, e.g. \"XS=1,S=2,M=3,L=5,XL=8\""),
+		adHocFilter:  fs.String("ad-hoc", DefaultAdHocFilter, "How to handle ad-hoc requests: include, exclude, only"),
+		adHocRules:   fs.String("ad-hoc-rules", "", "What marks ad-hoc requests, e.g. \"epic-label=support,type=chore\" (label=, epic-label=, type=; default: label=ad-hoc-request)"),
+		reopened:     fs.String("reopened", DefaultReopenedPolicy, "How to count reopened items (completed_at set, is_completed false): exclude, count-first-completion, count-last"),
+		filterField:  fs.String("filter-field", DefaultFilterField, "Date field to filter by: completed_at, created_at, started_at"),
+		filter:       fs.String("filter", "", "Only use items matching an expression, e.g. 'team == \"Payments\" && estimate >= 3 && has(labels, \"backend\")'"),
+		productAreaMode: fs.String("product-area-mode", DefaultProductAreaMode, "How items in several product areas (separated by ';') are credited: split, duplicate"),
+		minEpics:     fs.Int("min-epics", DefaultMinEpics, "Concurrent epics at which the contention report lists a contributor"),
+		epic:         fs.String("epic", "", "Epic the epic-contributor report is limited to (default: all epics)"),
+		labelPrefix:  fs.String("label-prefix", "", "Prefix the labels of the label report start with, e.g. \"component:\" (default: all labels)"),
+		groupBy:      fs.String("group-by", "", "Column or custom field (custom:KEY) to total story points by, e.g. priority or custom:domain; implies --type group"),
+		pivotRows:    fs.String("rows", "", "Column or custom field (custom:KEY) of the rows of the pivot report, e.g. team or epic"),
+		pivotCols:    fs.String("cols", "", "Column or custom field (custom:KEY) of the columns of the pivot report, e.g. type or month"),
+		allocateBy:   fs.String("allocate-by", "", "Column or custom field (custom:KEY) the allocation report divides each owner's work by, e.g. product-area (default: epic)"),
+		detail:       fs.Bool("detail", false, "List each contributor's completed items beneath their summary line in the contributor report"),
+		top:          fs.Int("top", 0, "Items listed per contributor with --detail, largest first (0 lists all)"),
+		hierarchy:    fs.Bool("hierarchy", false, "Add a project → epic → item breakdown with subtotals to reports"),
+		noExplanations: fs.Bool("no-explanations", false, "Leave out the sections explaining each metric (see \"explain\"); always on for --format json"),
 		
-		help:             flag.Bool("help", false, "Show help information and usage examples"),
-		helpShort:        flag.Bool("h", false, "Show help information and usage examples"),
-		interactive:      flag.Bool("interactive", false, "Run in interactive menu mode"),
-		interactiveShort: flag.Bool("i", false, "Run in interactive menu mode"),
-		version:          flag.Bool("version", false, "Show version information"),
-		examples:         flag.Bool("examples", false, "Show usage examples"),
+		configPath:       fs.String("config", "", "YAML or TOML file of named profiles holding options, e.g. kanban.yaml"),
+		profile:          fs.String("profile", "", "Profile of --config to use (default: the only profile); options given on the command line win"),
+		help:             fs.Bool("help", false, "Show help information and usage examples"),
+		helpShort:        fs.Bool("h", false, "Show help information and usage examples"),
+		interactive:      fs.Bool("interactive", false, "Run in interactive menu mode"),
+		interactiveShort: fs.Bool("i", false, "Run in interactive menu mode"),
+		answersPath:      fs.String("answers", "", "Run interactive mode with answers read from this file, one per line (\"-\" for stdin)"),
+		nonInteractive:   fs.Bool("non-interactive", false, "Never prompt (fail instead), skip previews and use $OUTPUT as --output; for containers and pipelines"),
+		version:          fs.Bool("version", false, "Show version information"),
+		examples:         fs.Bool("examples", false, "Show usage examples"),
 	}
 }
 
@@ -140,7 +453,7 @@ func buildConfig(flags *flagSet) (*Config, error) {
 	config := &Config{}
 
 	// Validate and set required fields
-	if err := setCSVPath(config, *flags.csvPath); err != nil {
+	if err := setCSVPath(config, flags.csvPath.String()); err != nil {
 		return nil, err
 	}
 
@@ -148,38 +461,433 @@ func buildConfig(flags *flagSet) (*Config, error) {
 		return nil, err
 	}
 
-	if err := setReportAndMetricsTypes(config, *flags.reportType, *flags.metricsType); err != nil {
+	if err := setColumnMap(config, *flags.columnMap); err != nil {
+		return nil, err
+	}
+
+	if err := setFileOptions(config, flags.fileDelimiter.String(), flags.fileColumnMap.String()); err != nil {
+		return nil, err
+	}
+
+	if err := setDecimalSeparator(config, *flags.decimalSep); err != nil {
+		return nil, err
+	}
+
+	if err := setDateFormat(config, *flags.dateFormat); err != nil {
+		return nil, err
+	}
+
+	if err := setBoolTokens(config, *flags.truthy, *flags.falsy); err != nil {
+		return nil, err
+	}
+
+	if err := setEstimateMapping(config, *flags.estimateMap); err != nil {
+		return nil, err
+	}
+
+	metricsType, err := digestMetricsType(*flags.metricsType, flags.reportType.String(), *flags.digest)
+	if err != nil {
+		return nil, err
+	}
+	reportType := groupByReportType(flags.reportType.String(), metricsType, *flags.groupBy)
+
+	if err := setExport(config, *flags.export, *flags.format); err != nil {
+		return nil, err
+	}
+
+	if *flags.format == string(types.FormatProblems) {
+		// The data-quality findings are the whole output
+		if metricsType != "" || reportType != "" {
+			return nil, fmt.Errorf("--format problems lists the data-quality findings only; leave out --type and --metrics")
+		}
+	} else if config.Export != "" {
+		// The exported items are the whole output
+		if metricsType != "" || reportType != "" {
+			return nil, fmt.Errorf("--export writes the items instead of reports; leave out --type and --metrics")
+		}
+	} else if strings.TrimSpace(*flags.baseline) != "" {
+		// The changes since the baseline are the whole output
+		if metricsType != "" || reportType != "" {
+			return nil, fmt.Errorf("--baseline lists the changes since the earlier export instead of reports; leave out --type and --metrics")
+		}
+	} else if err := setReportAndMetricsTypes(config, reportType, metricsType, *flags.both); err != nil {
+		return nil, err
+	} else if err := setGroupBy(config, *flags.groupBy); err != nil {
+		return nil, err
+	} else if err := setPivot(config, *flags.pivotRows, *flags.pivotCols); err != nil {
+		return nil, err
+	} else if err := setAllocateBy(config, *flags.allocateBy); err != nil {
+		return nil, err
+	} else if err := setDetail(config, *flags.detail, *flags.top); err != nil {
+		return nil, err
+	}
+
+	if err := setSplitBy(config, *flags.splitBy); err != nil {
+		return nil, err
+	}
+
+	if err := setMetricsSections(config, *flags.onlyMetrics, *flags.excludeMetrics, *flags.sectionOrder); err != nil {
 		return nil, err
 	}
 
+	setSeparator(config, *flags.separator)
+
 	if err := setPeriodType(config, *flags.periodType); err != nil {
 		return nil, err
 	}
 
+	if err := setWeekNumbering(config, *flags.weekNumbering); err != nil {
+		return nil, err
+	}
+
+	if err := setPeriodLabels(config, *flags.periodLabels); err != nil {
+		return nil, err
+	}
+
+	if err := setUnit(config, *flags.unit); err != nil {
+		return nil, err
+	}
+
+	if err := setStats(config, *flags.stats); err != nil {
+		return nil, err
+	}
+
+	if err := setHistogramBuckets(config, *flags.histogramBuckets); err != nil {
+		return nil, err
+	}
+
+	if err := setHolidays(config, *flags.holidaysPath); err != nil {
+		return nil, err
+	}
+
+	if err := setAbsences(config, *flags.absencesPath); err != nil {
+		return nil, err
+	}
+
+	if err := setAnnotations(config, *flags.annotationsPath); err != nil {
+		return nil, err
+	}
+
+	if err := setIterations(config, *flags.iterationsPath); err != nil {
+		return nil, err
+	}
+
+	if err := setCategories(config, *flags.categoriesPath); err != nil {
+		return nil, err
+	}
+
+	if err := setHistory(config, *flags.historyPath); err != nil {
+		return nil, err
+	}
+
+	if err := setDigestSettings(config, *flags.digestSettingsPath); err != nil {
+		return nil, err
+	}
+
+	if err := setForecast(config, *flags.simulations, *flags.forecastItems, *flags.forecastDate); err != nil {
+		return nil, err
+	}
+	if err := setForecastMinWeeks(config, *flags.forecastMinWeeks); err != nil {
+		return nil, err
+	}
+	config.Forecast.Seed = *flags.seed
+
+	if err := setAgeThresholds(config, *flags.ageSLA); err != nil {
+		return nil, err
+	}
+
+	if err := setWIPLimits(config, *flags.wipLimits); err != nil {
+		return nil, err
+	}
+
+	if err := setAgeMode(config, *flags.ageMode); err != nil {
+		return nil, err
+	}
+
 	if err := setFilterOptions(config, *flags.adHocFilter, *flags.filterField); err != nil {
 		return nil, err
 	}
 
-	if err := setDateRange(config, *flags.startDateStr, *flags.endDateStr, *flags.lastNDays); err != nil {
+	if err := setAdHocRules(config, *flags.adHocRules); err != nil {
+		return nil, err
+	}
+
+	if err := setFilter(config, *flags.filter); err != nil {
 		return nil, err
 	}
 
-	config.OutputPath = *flags.outputPath
+	if err := setProductAreaMode(config, *flags.productAreaMode); err != nil {
+		return nil, err
+	}
+
+	if err := setReopenedPolicy(config, *flags.reopened); err != nil {
+		return nil, err
+	}
+
+	if err := setDateRange(config, *flags.startDateStr, *flags.endDateStr, *flags.lastNDays, *flags.dateRange); err != nil {
+		return nil, err
+	}
+
+	if err := setMaxErrors(config, *flags.maxErrors); err != nil {
+		return nil, err
+	}
+
+	if err := setTimeout(config, *flags.timeout); err != nil {
+		return nil, err
+	}
+
+	if err := setWidth(config, *flags.width); err != nil {
+		return nil, err
+	}
+
+	if err := setMinEpics(config, *flags.minEpics); err != nil {
+		return nil, err
+	}
+
+	if err := setFormat(config, *flags.format); err != nil {
+		return nil, err
+	}
+	if err := setHTMLFilters(config, *flags.htmlFilters); err != nil {
+		return nil, err
+	}
+	if config.Format == types.FormatSVG && (config.MetricsType != metrics.MetricsTypeScatterplot || config.Both) {
+		return nil, fmt.Errorf("--format svg draws the cycle time scatterplot; use it with --metrics scatterplot only")
+	}
+	if err := setCompareWith(config, *flags.compareWith); err != nil {
+		return nil, err
+	}
+	if err := setBaseline(config, *flags.baseline); err != nil {
+		return nil, err
+	}
+
+	if err := setOutputPath(config, *flags.outputPath, *flags.outputTemplate, *flags.nonInteractive); err != nil {
+		return nil, err
+	}
+	config.ChartsDir = strings.TrimSpace(*flags.chartsDir)
+	config.NoOverwrite = *flags.noOverwrite
+	if err := setAppend(config, *flags.appendOutput); err != nil {
+		return nil, err
+	}
+	if err := setRetention(config, *flags.keepLast, *flags.keepDays, *flags.outputTemplate); err != nil {
+		return nil, err
+	}
+	if err := setSigning(config, *flags.sign, *flags.minisignKey); err != nil {
+		return nil, err
+	}
+	config.Hierarchy = *flags.hierarchy
+	// JSON is read by tools rather than people, so it never explains the metrics
+	config.NoExplanations = *flags.noExplanations || config.Format == types.FormatJSON
+	config.Epic = strings.TrimSpace(*flags.epic)
+	config.LabelPrefix = strings.TrimSpace(*flags.labelPrefix)
+	config.DataQuality = *flags.dataQuality
+	config.ASCII = terminal.ASCII()
+	config.NoPager = *flags.noPager
+	config.WarningsFile = *flags.warningsFile
 
 	return config, nil
 }
 
-// setCSVPath validates and sets the CSV file path
+// setOutputPath sets the output path from --output or the expanded
+// --output-template; in non-interactive mode $OUTPUT is used when neither is given
+func setOutputPath(config *Config, outputPath, outputTemplate string, nonInteractive bool) error {
+	config.NonInteractive = nonInteractive
+	if outputTemplate != "" {
+		if outputPath != "" {
+			return fmt.Errorf("--output and --output-template cannot be used together")
+		}
+		path, err := expandOutputTemplate(outputTemplate, time.Now(), outputTypeName(config))
+		if err != nil {
+			return err
+		}
+		outputPath = path
+	}
+	if outputPath == "" && nonInteractive {
+		outputPath = os.Getenv(OutputEnv)
+	}
+	config.OutputPath = outputPath
+	return nil
+}
+
+// setAppend validates and sets whether runs are added to the end of the
+// output file. Only text and Markdown stay readable when runs are joined.
+func setAppend(config *Config, appendOutput bool) error {
+	if !appendOutput {
+		return nil
+	}
+	if config.OutputPath == "" {
+		return fmt.Errorf("--append requires --output or --output-template")
+	}
+	if config.NoOverwrite {
+		return fmt.Errorf("--append and --no-overwrite cannot be used together")
+	}
+	if config.Export != "" {
+		return fmt.Errorf("--append adds runs to text and markdown files, not to --export %s", config.Export)
+	}
+	if config.Format != types.FormatText && config.Format != types.FormatMarkdown {
+		return fmt.Errorf("--append adds runs to text and markdown files, not to --format %s", config.Format)
+	}
+	config.Append = true
+	return nil
+}
+
+// setRetention validates and sets which earlier outputs of --output-template are kept
+func setRetention(config *Config, keepLast, keepDays int, outputTemplate string) error {
+	if keepLast < 0 {
+		return fmt.Errorf("keep last must be 0 or more, got: %d", keepLast)
+	}
+	if keepDays < 0 {
+		return fmt.Errorf("keep days must be 0 or more, got: %d", keepDays)
+	}
+	config.Retention = retention.Policy{KeepLast: keepLast, KeepDays: keepDays}
+	if !config.Retention.IsSet() {
+		return nil
+	}
+	if outputTemplate == "" {
+		return fmt.Errorf("--keep-last and --keep-days require --output-template")
+	}
+
+	glob, err := outputTemplateGlob(outputTemplate, outputTypeName(config))
+	if err != nil {
+		return err
+	}
+	config.OutputGlob = glob
+	return nil
+}
+
+// setSigning validates and sets whether the output file is checksummed and signed
+func setSigning(config *Config, sign bool, minisignKey string) error {
+	if minisignKey != "" && !sign {
+		return fmt.Errorf("--minisign-key requires --sign")
+	}
+	if sign && config.OutputPath == "" {
+		return fmt.Errorf("--sign requires --output or --output-template")
+	}
+	if minisignKey != "" {
+		if _, err := os.Stat(minisignKey); err != nil {
+			return fmt.Errorf("minisign key not found: %s", minisignKey)
+		}
+	}
+	config.Sign = sign
+	config.MinisignKey = minisignKey
+	return nil
+}
+
+// outputTypeName names the generated output for output templates, joining
+// several report types and the metrics type with "-"
+func outputTypeName(config *Config) string {
+	var names []string
+	if config.Both || !config.IsMetricsReport() {
+		for _, rt := range config.ReportTypes {
+			names = append(names, string(rt))
+		}
+	}
+	if config.IsMetricsReport() {
+		names = append(names, string(config.MetricsType))
+	}
+	return strings.Join(names, "-")
+}
+
+// expandOutputTemplate replaces the {date} and {type} tokens of an output
+// path template, so scheduled runs write a new file each time
+func expandOutputTemplate(template string, now time.Time, outputType string) (string, error) {
+	values := map[string]string{
+		"date": now.Format(DateFormat),
+		"type": outputType,
+	}
+	return replaceTemplateTokens(template, values, func(text string) string { return text })
+}
+
+// outputTemplateGlob returns a pattern matching the files an output template
+// writes for the output type on any date, for --keep-last and --keep-days
+func outputTemplateGlob(template, outputType string) (string, error) {
+	values := map[string]string{
+		"date": "[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]",
+		"type": globEscape(outputType),
+	}
+	return replaceTemplateTokens(template, values, globEscape)
+}
+
+// globEscape escapes the pattern characters of a literal path part
+func globEscape(text string) string {
+	return strings.NewReplacer("[", "[[]", "*", "[*]", "?", "[?]").Replace(text)
+}
+
+// replaceTemplateTokens replaces the tokens of an output template with their
+// values, passing the text between tokens through literal
+func replaceTemplateTokens(template string, values map[string]string, literal func(string) string) (string, error) {
+	var path strings.Builder
+	rest := template
+	for {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("unclosed token in output template: %s", template)
+		}
+		token := rest[start+1 : start+end]
+		value, known := values[token]
+		if !known {
+			return "", fmt.Errorf("unknown token {%s} in output template (must be one of: {date}, {type})", token)
+		}
+		path.WriteString(literal(rest[:start]))
+		path.WriteString(value)
+		rest = rest[start+end+1:]
+	}
+	path.WriteString(literal(rest))
+
+	return path.String(), nil
+}
+
+// setCSVPath validates and sets the CSV file paths: a comma-separated list of
+// files and glob patterns
 func setCSVPath(config *Config, csvPath string) error {
-	if csvPath == "" {
+	if strings.TrimSpace(csvPath) == "" {
 		return fmt.Errorf("CSV file path is required. Use --csv to specify the file path")
 	}
 
-	if err := validation.ValidateCSVPath(csvPath); err != nil {
-		return formatCSVValidationError(err, csvPath)
+	var paths []string
+	seen := make(map[string]bool)
+	for _, pattern := range strings.Split(csvPath, ",") {
+		pattern = validation.CleanPathInput(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		// A file named like a pattern, such as "export [1].csv" from a
+		// browser download, is taken as it is
+		matches := []string{pattern}
+		if _, err := os.Stat(pattern); err != nil && strings.ContainsAny(pattern, "*?[") {
+			var err error
+			if matches, err = filepath.Glob(pattern); err != nil {
+				return fmt.Errorf("invalid CSV file pattern '%s': %v", pattern, err)
+			}
+			if len(matches) == 0 {
+				return fmt.Errorf("no CSV files match '%s'", pattern)
+			}
+		}
+
+		for _, path := range matches {
+			if seen[path] {
+				continue
+			}
+			if err := validation.ValidateCSVPath(path); err != nil {
+				return formatCSVValidationError(err, path)
+			}
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("CSV file path is required. Use --csv to specify the file path")
 	}
 
-	config.CSVPath = csvPath
+	config.CSVPath = paths[0]
+	config.CSVPaths = nil
+	if len(paths) > 1 {
+		config.CSVPaths = paths
+	}
 	return nil
 }
 
@@ -222,47 +930,674 @@ func setDelimiter(config *Config, delimiterStr string) error {
 	return nil
 }
 
-// setReportAndMetricsTypes validates and sets report/metrics types with proper precedence
-func setReportAndMetricsTypes(config *Config, reportType, metricsType string) error {
+// setReportAndMetricsTypes validates and sets report/metrics types.
+// Using --type and --metrics together requires --both.
+func setReportAndMetricsTypes(config *Config, reportType, metricsType string, both bool) error {
 	if metricsType == "" && reportType == "" {
 		return fmt.Errorf("either --type or --metrics must be specified")
 	}
 
-	// Metrics type takes precedence when both are specified (original behavior)
+	if both && (metricsType == "" || reportType == "") {
+		return fmt.Errorf("--both requires both --type and --metrics")
+	}
+	if !both && metricsType != "" && reportType != "" {
+		return fmt.Errorf("--type and --metrics cannot be used together; add --both to generate both")
+	}
+	config.Both = both
+
 	if metricsType != "" {
 		mt, err := metrics.ParseMetricsType(metricsType)
 		if err != nil {
-			return fmt.Errorf("%v\n\nAvailable metrics types: lead-time, throughput, flow, estimation, age, improvement, all", err)
+			return fmt.Errorf("%v\n\nAvailable metrics types: lead-time, throughput, flow, estimation, age, improvement, workflow, benchmark, review, cfd, priority, digest, weekday, forecast, commitment, scatterplot, all", err)
 		}
 		config.MetricsType = mt
-		return nil
 	}
 
-	// Only parse report type if metrics type is not specified
 	if reportType != "" {
-		rt, err := reports.ParseReportType(reportType)
+		rts, err := reports.ParseReportTypes(reportType)
 		if err != nil {
-			return fmt.Errorf("%v\n\nAvailable report types: contributor, epic, product-area, team", err)
+			return fmt.Errorf("%v\n\nAvailable report types: contributor, epic, product-area, team, category, workload, contention, epic-contributor, team-month, label, milestone, iteration, state, group, pivot, epic-progress, allocation", err)
 		}
-		config.ReportType = rt
-		return nil
+		config.ReportType = rts[0]
+		config.ReportTypes = rts
 	}
 
 	return nil
 }
 
-// setPeriodType parses and validates the period type
-func setPeriodType(config *Config, periodType string) error {
-	pt, err := metrics.ParsePeriodType(periodType)
+// digestMetricsType returns the metrics type to use, which is the digest when
+// --digest is given. The digest is a complete output on its own.
+func digestMetricsType(metricsType, reportType string, digest bool) (string, error) {
+	if !digest {
+		return metricsType, nil
+	}
+	if metricsType != "" || reportType != "" {
+		return "", fmt.Errorf("--digest cannot be combined with --type or --metrics")
+	}
+	return string(metrics.MetricsTypeDigest), nil
+}
+
+// groupByReportType returns the report type to use, which is the group report
+// when --group-by is given on its own
+func groupByReportType(reportType, metricsType, groupBy string) string {
+	if strings.TrimSpace(groupBy) != "" && reportType == "" && metricsType == "" {
+		return string(reports.ReportTypeGroup)
+	}
+	return reportType
+}
+
+// setGroupBy parses and sets the field the group report totals by, which the
+// group report needs and no other report uses
+func setGroupBy(config *Config, groupBy string) error {
+	grouped := hasReportType(config, reports.ReportTypeGroup)
+
+	if strings.TrimSpace(groupBy) == "" {
+		if grouped {
+			return fmt.Errorf("--type group requires --group-by FIELD, e.g. --group-by priority or --group-by custom:domain")
+		}
+		return nil
+	}
+	if !grouped {
+		return fmt.Errorf("--group-by applies to --type group")
+	}
+
+	field, err := reports.ParseGroupField(groupBy)
 	if err != nil {
 		return err
 	}
-	config.PeriodType = pt
+	config.GroupBy = field
 	return nil
 }
 
-// setFilterOptions parses and sets filtering configuration
-func setFilterOptions(config *Config, adHocFilter, filterField string) error {
+// setPivot parses and sets the fields of the rows and columns of the pivot
+// report, which the pivot report needs and no other report uses
+func setPivot(config *Config, rows, cols string) error {
+	pivot := hasReportType(config, reports.ReportTypePivot)
+
+	if strings.TrimSpace(rows) == "" && strings.TrimSpace(cols) == "" && !pivot {
+		return nil
+	}
+	if !pivot {
+		return fmt.Errorf("--rows and --cols apply to --type pivot")
+	}
+	if strings.TrimSpace(rows) == "" || strings.TrimSpace(cols) == "" {
+		return fmt.Errorf("--type pivot requires --rows FIELD and --cols FIELD, e.g. --rows team --cols type")
+	}
+
+	rowField, err := reports.ParseGroupField(rows)
+	if err != nil {
+		return err
+	}
+	colField, err := reports.ParseGroupField(cols)
+	if err != nil {
+		return err
+	}
+	if rowField == colField {
+		return fmt.Errorf("--rows and --cols must be different fields, both are %s", rowField)
+	}
+	config.PivotRows = rowField
+	config.PivotCols = colField
+	return nil
+}
+
+// setAllocateBy parses and sets the field the allocation report divides each
+// owner's work by, which no other report uses. Without one it uses epics.
+func setAllocateBy(config *Config, allocateBy string) error {
+	if strings.TrimSpace(allocateBy) == "" {
+		return nil
+	}
+	if !hasReportType(config, reports.ReportTypeAllocation) {
+		return fmt.Errorf("--allocate-by applies to --type allocation")
+	}
+
+	field, err := reports.ParseGroupField(allocateBy)
+	if err != nil {
+		return err
+	}
+	if field == "month" {
+		return fmt.Errorf("--allocate-by month is not supported; the allocation report already has a column per month")
+	}
+	config.AllocateBy = field
+	return nil
+}
+
+// setDetail validates and sets the contributor report's item drill-down and
+// the number of items it lists per contributor
+func setDetail(config *Config, detail bool, top int) error {
+	if top < 0 {
+		return fmt.Errorf("top must be 0 or more, got: %d", top)
+	}
+	if top > 0 && !detail {
+		return fmt.Errorf("--top applies to --detail")
+	}
+	if detail && !hasReportType(config, reports.ReportTypeContributor) {
+		return fmt.Errorf("--detail applies to --type contributor")
+	}
+	config.Detail = detail
+	config.Top = top
+	return nil
+}
+
+// hasReportType reports whether the report type is one of the configured ones
+func hasReportType(config *Config, reportType reports.ReportType) bool {
+	for _, rt := range config.ReportTypes {
+		if rt == reportType {
+			return true
+		}
+	}
+	return false
+}
+
+// setSplitBy parses and sets the field used to group items in the benchmark
+func setSplitBy(config *Config, splitBy string) error {
+	field, err := metrics.ParseSplitField(splitBy)
+	if err != nil {
+		return err
+	}
+	config.SplitBy = field
+	return nil
+}
+
+// setMetricsSections parses and sets which sections --metrics all generates, and in which order
+func setMetricsSections(config *Config, only, exclude, order string) error {
+	if only == "" && exclude == "" && order == "" {
+		return nil
+	}
+	if only != "" && exclude != "" {
+		return fmt.Errorf("--only-metrics and --exclude-metrics cannot be used together")
+	}
+	if config.MetricsType != metrics.MetricsTypeAll {
+		return fmt.Errorf("--only-metrics, --exclude-metrics and --section-order can only be used with --metrics all")
+	}
+
+	onlyTypes, err := metrics.ParseMetricsTypes(only)
+	if err != nil {
+		return err
+	}
+	excludeTypes, err := metrics.ParseMetricsTypes(exclude)
+	if err != nil {
+		return err
+	}
+
+	orderTypes, err := metrics.ParseMetricsTypes(order)
+	if err != nil {
+		return err
+	}
+
+	sections := metrics.SelectSections(onlyTypes, excludeTypes)
+	if len(sections) == 0 {
+		return fmt.Errorf("no metrics left to generate after excluding: %s", exclude)
+	}
+	config.MetricsSections = metrics.OrderSections(sections, orderTypes)
+	return nil
+}
+
+// setSeparator sets the section separator, expanding \n and \t escapes
+func setSeparator(config *Config, separator string) {
+	config.Separator = strings.NewReplacer(`\n`, "\n", `\t`, "\t").Replace(separator)
+}
+
+// setPeriodType parses and validates the period type
+func setPeriodType(config *Config, periodType string) error {
+	pt, err := metrics.ParsePeriodType(periodType)
+	if err != nil {
+		return err
+	}
+	config.PeriodType = pt
+	return nil
+}
+
+// setColumnMap parses and sets the export column renames
+func setColumnMap(config *Config, columnMap string) error {
+	m, err := models.ParseColumnMap(columnMap)
+	if err != nil {
+		return err
+	}
+	config.ColumnMap = m
+	return nil
+}
+
+// parseFileDelimiters parses a list like "jira.csv=semicolon,linear.csv=tab".
+// Paths end at the last "=", so they may contain one.
+func parseFileDelimiters(s string) (map[string]models.DelimiterType, error) {
+	delimiters := make(map[string]models.DelimiterType)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		i := strings.LastIndex(part, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid file delimiter: %s (expected PATH=DELIMITER, e.g. exports/jira.csv=semicolon)", part)
+		}
+		delimiter, err := models.ParseDelimiter(strings.TrimSpace(part[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid file delimiter: %s: %v", part, err)
+		}
+		delimiters[filepath.Clean(strings.TrimSpace(part[:i]))] = delimiter
+	}
+	return delimiters, nil
+}
+
+// parseFileColumnMaps parses a list like "jira.csv:Summary=name,jira.csv:Story
+// Points=estimate". The path ends at the last ":" before the "=", so Windows
+// paths such as C:\exports\jira.csv keep their drive.
+func parseFileColumnMaps(s string) (map[string]models.ColumnMap, error) {
+	columnMaps := make(map[string]models.ColumnMap)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		equals := strings.Index(part, "=")
+		colon := -1
+		if equals > 0 {
+			colon = strings.LastIndex(part[:equals], ":")
+		}
+		if colon <= 0 {
+			return nil, fmt.Errorf("invalid file column mapping: %s (expected PATH:EXPORT_COLUMN=column, e.g. exports/jira.csv:Summary=name)", part)
+		}
+		m, err := models.ParseColumnMap(part[colon+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid file column mapping: %s: %v", part, err)
+		}
+		path := filepath.Clean(strings.TrimSpace(part[:colon]))
+		if columnMaps[path] == nil {
+			columnMaps[path] = make(models.ColumnMap)
+		}
+		for source, target := range m {
+			columnMaps[path][source] = target
+		}
+	}
+	return columnMaps, nil
+}
+
+// setFileOptions parses and sets the delimiters and column renames of single
+// CSV files, which must be among the --csv files
+func setFileOptions(config *Config, delimiters, columnMaps string) error {
+	fileDelimiters, err := parseFileDelimiters(delimiters)
+	if err != nil {
+		return err
+	}
+	fileColumnMaps, err := parseFileColumnMaps(columnMaps)
+	if err != nil {
+		return err
+	}
+
+	files := make(map[string]bool)
+	for _, path := range config.CSVFiles() {
+		files[filepath.Clean(path)] = true
+	}
+	for path := range fileDelimiters {
+		if !files[path] {
+			return fmt.Errorf("--file-delimiter names %s, which is not one of the --csv files", path)
+		}
+	}
+	for path := range fileColumnMaps {
+		if !files[path] {
+			return fmt.Errorf("--file-column-map names %s, which is not one of the --csv files", path)
+		}
+	}
+
+	if len(fileDelimiters) > 0 {
+		config.FileDelimiters = fileDelimiters
+	}
+	if len(fileColumnMaps) > 0 {
+		config.FileColumnMaps = fileColumnMaps
+	}
+	return nil
+}
+
+// setDecimalSeparator parses and sets the decimal separator used in estimates
+func setDecimalSeparator(config *Config, sep string) error {
+	ds, err := models.ParseDecimalSeparator(sep)
+	if err != nil {
+		return err
+	}
+	config.DecimalSeparator = ds
+	return nil
+}
+
+// setDateFormat parses and sets the layouts timestamps are read with
+func setDateFormat(config *Config, format string) error {
+	f, err := models.ParseDateFormat(format)
+	if err != nil {
+		return err
+	}
+	config.DateFormat = f
+	return nil
+}
+
+// setBoolTokens parses and sets the values recognised in boolean columns
+func setBoolTokens(config *Config, truthy, falsy string) error {
+	tokens, err := models.ParseBoolTokens(truthy, falsy)
+	if err != nil {
+		return err
+	}
+	config.BoolTokens = tokens
+	return nil
+}
+
+// setEstimateMapping parses and sets the point values for non-numeric estimates
+func setEstimateMapping(config *Config, mapping string) error {
+	m, err := models.ParseEstimateMapping(mapping)
+	if err != nil {
+		return err
+	}
+	config.EstimateMapping = m
+	return nil
+}
+
+// setUnit parses and sets the estimate unit
+func setUnit(config *Config, unit string) error {
+	u, err := types.ParseEstimateUnit(unit)
+	if err != nil {
+		return err
+	}
+	config.Unit = u
+	return nil
+}
+
+// setFormat parses and sets the output format
+func setFormat(config *Config, format string) error {
+	f, err := types.ParseOutputFormat(format)
+	if err != nil {
+		return err
+	}
+	config.Format = f
+	return nil
+}
+
+// setHTMLFilters sets whether HTML output embeds the items to filter in the browser
+func setHTMLFilters(config *Config, htmlFilters bool) error {
+	if htmlFilters && config.Format != types.FormatHTML {
+		return fmt.Errorf("--html-filters applies to --format html")
+	}
+	config.HTMLFilters = htmlFilters
+	return nil
+}
+
+// setExport parses and sets the raw data export, which has a format of its own
+func setExport(config *Config, export, format string) error {
+	if export == "" {
+		return nil
+	}
+	e, err := types.ParseExportType(export)
+	if err != nil {
+		return err
+	}
+	if format != string(types.FormatText) {
+		return fmt.Errorf("--export %s writes JSON Lines; leave out --format", e)
+	}
+	config.Export = e
+	return nil
+}
+
+// setWeekNumbering parses and sets the week convention
+func setWeekNumbering(config *Config, numbering string) error {
+	n, err := types.ParseWeekNumbering(numbering)
+	if err != nil {
+		return err
+	}
+	config.WeekNumbering = n
+	return nil
+}
+
+// setPeriodLabels parses and sets how weeks and months are labeled
+func setPeriodLabels(config *Config, labels string) error {
+	l, err := types.ParsePeriodLabels(labels)
+	if err != nil {
+		return err
+	}
+	config.PeriodLabels = l
+	return nil
+}
+
+// setStats parses and sets the statistics shown in metrics tables
+func setStats(config *Config, stats string) error {
+	st, err := metrics.ParseStats(stats)
+	if err != nil {
+		return err
+	}
+	config.Stats = st
+	return nil
+}
+
+// setHistogramBuckets parses and sets the cycle time histogram bucket bounds
+func setHistogramBuckets(config *Config, buckets string) error {
+	bounds, err := metrics.ParseHistogramBuckets(buckets)
+	if err != nil {
+		return err
+	}
+	config.HistogramBuckets = bounds
+	return nil
+}
+
+// setAnnotations loads the annotations file, if one is given
+func setAnnotations(config *Config, path string) error {
+	if path == "" {
+		return nil
+	}
+	annotations, err := metrics.LoadAnnotations(path)
+	if err != nil {
+		return err
+	}
+	config.Annotations = annotations
+	return nil
+}
+
+// setCategories loads the classification rules file, if one is given. Grouping
+// by category needs the rules, since without them every item is uncategorized.
+func setCategories(config *Config, path string) error {
+	if path != "" {
+		rules, err := classify.LoadRules(path)
+		if err != nil {
+			return err
+		}
+		config.Categories = rules
+		return nil
+	}
+
+	for _, rt := range config.ReportTypes {
+		if rt == reports.ReportTypeCategory {
+			return fmt.Errorf("--type category requires --categories FILE with the classification rules")
+		}
+	}
+	if (config.MetricsType == metrics.MetricsTypeBenchmark || config.MetricsType == metrics.MetricsTypeReview) && config.SplitBy == metrics.SplitByCategory {
+		return fmt.Errorf("--split-by category requires --categories FILE with the classification rules")
+	}
+	return nil
+}
+
+// setHistory loads the state history file, if one is given
+func setHistory(config *Config, path string) error {
+	if path == "" {
+		return nil
+	}
+	history, err := parser.LoadHistory(path)
+	if err != nil {
+		return err
+	}
+	config.History = history
+	return nil
+}
+
+// setForecast validates and sets the forecast simulations and targets
+func setForecast(config *Config, simulations, items int, dateStr string) error {
+	if simulations < 1 {
+		return fmt.Errorf("simulations must be 1 or more, got: %d", simulations)
+	}
+	if items < 0 {
+		return fmt.Errorf("forecast items must be 0 or more, got: %d", items)
+	}
+
+	config.Forecast = metrics.DefaultForecastSettings()
+	config.Forecast.Simulations = simulations
+	config.Forecast.Items = items
+	if dateStr != "" {
+		date, err := time.Parse(DateFormat, dateStr)
+		if err != nil {
+			return fmt.Errorf("error parsing forecast date: %v\nExpected format: YYYY-MM-DD", err)
+		}
+		config.Forecast.Date = date
+	}
+	return nil
+}
+
+// setForecastMinWeeks validates and sets the fewest weeks of history to forecast from
+func setForecastMinWeeks(config *Config, weeks int) error {
+	if weeks < 1 {
+		return fmt.Errorf("forecast minimum weeks must be 1 or more, got: %d", weeks)
+	}
+	config.Forecast.MinWeeks = weeks
+	return nil
+}
+
+// setDigestSettings loads the digest settings file, if one is given
+func setDigestSettings(config *Config, path string) error {
+	config.DigestSettings = metrics.DefaultDigestSettings()
+	if path == "" {
+		return nil
+	}
+	settings, err := metrics.LoadDigestSettings(path)
+	if err != nil {
+		return err
+	}
+	config.DigestSettings = settings
+	return nil
+}
+
+// setIterations loads the iterations file, if one is given
+func setIterations(config *Config, path string) error {
+	if path == "" {
+		return nil
+	}
+	iterations, err := metrics.LoadIterations(path)
+	if err != nil {
+		return err
+	}
+	config.Iterations = iterations
+	return nil
+}
+
+// setAbsences loads the absences file, if one is given
+func setAbsences(config *Config, path string) error {
+	if path == "" {
+		return nil
+	}
+	absences, err := dateutil.LoadAbsences(path)
+	if err != nil {
+		return err
+	}
+	config.Absences = absences
+	return nil
+}
+
+// setHolidays loads the holidays file, if one is given
+func setHolidays(config *Config, path string) error {
+	if path == "" {
+		return nil
+	}
+	holidays, err := dateutil.LoadHolidays(path)
+	if err != nil {
+		return err
+	}
+	config.Holidays = holidays
+	return nil
+}
+
+// setAgeMode parses and sets how the age metric shows open work
+func setAgeMode(config *Config, mode string) error {
+	m, err := metrics.ParseAgeMode(mode)
+	if err != nil {
+		return err
+	}
+	config.AgeMode = m
+	return nil
+}
+
+// setAgeThresholds parses and sets the per-state age SLA thresholds
+func setAgeThresholds(config *Config, thresholds string) error {
+	t, err := metrics.ParseAgeThresholds(thresholds)
+	if err != nil {
+		return err
+	}
+	config.AgeThresholds = t
+	return nil
+}
+
+// setWIPLimits parses and sets the per-team and per-state WIP limits
+func setWIPLimits(config *Config, limits string) error {
+	l, err := metrics.ParseWIPLimits(limits)
+	if err != nil {
+		return err
+	}
+	config.WIPLimits = l
+	return nil
+}
+
+// setProductAreaMode parses and sets how multi-area items are attributed
+func setProductAreaMode(config *Config, mode string) error {
+	m, err := reports.ParseProductAreaMode(mode)
+	if err != nil {
+		return err
+	}
+	config.ProductAreaMode = m
+	return nil
+}
+
+// setReopenedPolicy parses and sets how reopened items are counted
+func setReopenedPolicy(config *Config, policy string) error {
+	rp, err := types.ParseReopenedPolicy(policy)
+	if err != nil {
+		return err
+	}
+	config.Reopened = rp
+	return nil
+}
+
+// setMaxErrors validates and sets how many rows may fail to parse
+func setMaxErrors(config *Config, maxErrors int) error {
+	if maxErrors < -1 {
+		return fmt.Errorf("max errors must be 0 or more (or -1 for no limit), got: %d", maxErrors)
+	}
+	config.MaxErrors = maxErrors
+	return nil
+}
+
+// setTimeout parses and sets how long a run may take
+func setTimeout(config *Config, timeout string) error {
+	if timeout == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid timeout: %s (expected a positive duration such as 30s or 2m)", timeout)
+	}
+	config.Timeout = d
+	return nil
+}
+
+// setMinEpics validates and sets the contention report threshold
+func setMinEpics(config *Config, minEpics int) error {
+	if minEpics < 2 {
+		return fmt.Errorf("min epics must be 2 or more, got: %d", minEpics)
+	}
+	config.MinEpics = minEpics
+	return nil
+}
+
+// setWidth validates and sets the maximum table width
+func setWidth(config *Config, width int) error {
+	if width < 0 {
+		return fmt.Errorf("width must be 0 or more (0 to detect the terminal width), got: %d", width)
+	}
+	config.Width = width
+	return nil
+}
+
+// setFilterOptions parses and sets filtering configuration
+func setFilterOptions(config *Config, adHocFilter, filterField string) error {
 	af, err := types.ParseAdHocFilterType(adHocFilter)
 	if err != nil {
 		return err
@@ -278,12 +1613,39 @@ func setFilterOptions(config *Config, adHocFilter, filterField string) error {
 	return nil
 }
 
+// setAdHocRules parses and sets the rules that decide which items are ad-hoc requests
+func setAdHocRules(config *Config, rules string) error {
+	parsed, err := filtering.ParseAdHocRules(rules)
+	if err != nil {
+		return err
+	}
+	config.AdHocRules = parsed
+	return nil
+}
+
+// setFilter parses and sets the expression items must satisfy
+func setFilter(config *Config, expression string) error {
+	if strings.TrimSpace(expression) == "" {
+		return nil
+	}
+	q, err := query.Parse(expression)
+	if err != nil {
+		return fmt.Errorf("invalid --filter: %w", err)
+	}
+	config.Filter = q
+	return nil
+}
+
 // setDateRange validates and sets the date range configuration
-func setDateRange(config *Config, startDateStr, endDateStr string, lastNDays int) error {
+func setDateRange(config *Config, startDateStr, endDateStr string, lastNDays int, rangePreset string) error {
 	if lastNDays < 0 {
 		return fmt.Errorf("last N days must be a positive number, got: %d", lastNDays)
 	}
 
+	if rangePreset != "" {
+		return setRangePreset(config, rangePreset, startDateStr != "" || endDateStr != "" || lastNDays > 0)
+	}
+
 	// Last N days takes precedence
 	if lastNDays > 0 {
 		config.LastNDays = lastNDays
@@ -306,6 +1668,106 @@ func setDateRange(config *Config, startDateStr, endDateStr string, lastNDays int
 	return nil
 }
 
+// setRangePreset resolves a calendar date range preset into the date range
+func setRangePreset(config *Config, rangePreset string, hasDates bool) error {
+	if hasDates {
+		return fmt.Errorf("--range cannot be combined with --start, --end or --last")
+	}
+	preset, err := types.ParseDateRangePreset(rangePreset)
+	if err != nil {
+		return err
+	}
+
+	config.Range = preset
+	config.StartDate, config.EndDate = presetDates(preset, config.WeekNumbering)
+	return nil
+}
+
+// presetDates returns the first and last moment of a calendar date range preset
+func presetDates(preset types.DateRangePreset, numbering types.WeekNumbering) (time.Time, time.Time) {
+	// Today is taken from the local clock; dates are compared like --start and --end
+	now := time.Now()
+	first, last := preset.Resolve(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), numbering)
+	return first, last.Add(HoursPerDay*time.Hour + MinutesPerHour*time.Minute + SecondsPerMinute*time.Second)
+}
+
+// setBaseline validates and sets the earlier export the CSV is compared
+// with. The whole exports are compared, so no date range applies.
+func setBaseline(config *Config, baselinePath string) error {
+	baselinePath = validation.CleanPathInput(baselinePath)
+	if baselinePath == "" {
+		return nil
+	}
+	if err := validation.ValidateCSVPath(baselinePath); err != nil {
+		return formatCSVValidationError(err, baselinePath)
+	}
+	if config.Export != "" {
+		return fmt.Errorf("--baseline and --export cannot be used together")
+	}
+	if config.CompareWith != "" {
+		return fmt.Errorf("--baseline and --compare-with cannot be used together")
+	}
+	if config.HTMLFilters {
+		return fmt.Errorf("--baseline and --html-filters cannot be used together")
+	}
+	switch config.Format {
+	case types.FormatText, types.FormatMarkdown, types.FormatHTML, types.FormatJSON:
+	default:
+		return fmt.Errorf("--baseline lists the changes as text, json, markdown or html, not --format %s", config.Format)
+	}
+	if !config.StartDate.IsZero() || !config.EndDate.IsZero() {
+		return fmt.Errorf("--baseline compares the whole exports; leave out the date range")
+	}
+	config.BaselinePath = baselinePath
+	return nil
+}
+
+// setCompareWith resolves the baseline range of --compare-with: previous for
+// the same length just before the date range, a calendar preset, or
+// START..END dates. The changes are shown as text or documents only.
+func setCompareWith(config *Config, compareWith string) error {
+	compareWith = strings.TrimSpace(compareWith)
+	if compareWith == "" {
+		return nil
+	}
+	if config.StartDate.IsZero() || config.EndDate.IsZero() {
+		return fmt.Errorf("--compare-with needs a date range to compare: --start and --end, --last or --range")
+	}
+	if config.Export != "" || (config.Format != types.FormatText && config.Format != types.FormatMarkdown && config.Format != types.FormatHTML) {
+		return fmt.Errorf("--compare-with shows the changes as text, markdown or html")
+	}
+	if config.HTMLFilters {
+		return fmt.Errorf("--compare-with and --html-filters cannot be used together")
+	}
+
+	switch {
+	case compareWith == ComparePrevious:
+		config.CompareEnd = config.StartDate.Add(-time.Second)
+		config.CompareStart = config.CompareEnd.Add(-config.EndDate.Sub(config.StartDate))
+	case strings.Contains(compareWith, ".."):
+		startStr, endStr, _ := strings.Cut(compareWith, "..")
+		if startStr == "" || endStr == "" {
+			return fmt.Errorf("invalid --compare-with range: %s (expected START..END, e.g. 2024-01-01..2024-03-31)", compareWith)
+		}
+		baseline := &Config{}
+		if err := parseExplicitDates(baseline, startStr, endStr); err != nil {
+			return err
+		}
+		if baseline.EndDate.Before(baseline.StartDate) {
+			return fmt.Errorf("invalid --compare-with range: end date (%s) is before start date (%s)", endStr, startStr)
+		}
+		config.CompareStart, config.CompareEnd = baseline.StartDate, baseline.EndDate
+	default:
+		preset, err := types.ParseDateRangePreset(compareWith)
+		if err != nil {
+			return fmt.Errorf("invalid --compare-with: %s (must be %s, START..END or a --range preset)", compareWith, ComparePrevious)
+		}
+		config.CompareStart, config.CompareEnd = presetDates(preset, config.WeekNumbering)
+	}
+	config.CompareWith = compareWith
+	return nil
+}
+
 // parseExplicitDates parses start and end date strings
 func parseExplicitDates(config *Config, startDateStr, endDateStr string) error {
 	if startDateStr != "" {
@@ -333,6 +1795,29 @@ func (c *Config) IsMetricsReport() bool {
 	return c.MetricsType != ""
 }
 
+// CSVFiles returns every CSV file to read
+func (c *Config) CSVFiles() []string {
+	if len(c.CSVPaths) > 0 {
+		return c.CSVPaths
+	}
+	return []string{c.CSVPath}
+}
+
+// CSVSources returns the CSV files with the delimiter and column renames
+// given for each with --file-delimiter and --file-column-map
+func (c *Config) CSVSources() []parser.FileSource {
+	paths := c.CSVFiles()
+	sources := make([]parser.FileSource, len(paths))
+	for i, path := range paths {
+		sources[i] = parser.FileSource{
+			Path:      path,
+			Delimiter: c.FileDelimiters[filepath.Clean(path)],
+			ColumnMap: c.FileColumnMaps[filepath.Clean(path)],
+		}
+	}
+	return sources
+}
+
 // GetDateRange returns the configured date range
 func (c *Config) GetDateRange() (time.Time, time.Time) {
 	return c.StartDate, c.EndDate
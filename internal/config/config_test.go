@@ -3,11 +3,18 @@ package config
 import (
 	"flag"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/parser"
 	"github.com/hannasdev/kanban-reports/internal/reports"
+	"github.com/hannasdev/kanban-reports/internal/retention"
+	"github.com/hannasdev/kanban-reports/pkg/terminal"
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
 func TestParseFlags(t *testing.T) {
@@ -71,6 +78,46 @@ func TestParseFlags(t *testing.T) {
 				return startDiff > 6.9 && startDiff < 7.1 // ~7 days
 			},
 		},
+		{
+			name:      "ASCII output",
+			args:      []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--ascii"},
+			expectErr: false,
+			validate: func(cfg *Config) bool {
+				return cfg.ASCII && terminal.ASCII()
+			},
+		},
+		{
+			name:      "Metrics without explanations",
+			args:      []string{"cmd", "--csv", tempFile.Name(), "--metrics", "flow", "--no-explanations"},
+			expectErr: false,
+			validate: func(cfg *Config) bool {
+				return cfg.NoExplanations
+			},
+		},
+		{
+			name:      "JSON output never explains the metrics",
+			args:      []string{"cmd", "--csv", tempFile.Name(), "--metrics", "flow", "--format", "json"},
+			expectErr: false,
+			validate: func(cfg *Config) bool {
+				return cfg.NoExplanations
+			},
+		},
+		{
+			name:      "Text output explains the metrics by default",
+			args:      []string{"cmd", "--csv", tempFile.Name(), "--metrics", "flow"},
+			expectErr: false,
+			validate: func(cfg *Config) bool {
+				return !cfg.NoExplanations
+			},
+		},
+		{
+			name:      "Answers file starts interactive mode",
+			args:      []string{"cmd", "--answers", "answers.txt"},
+			expectErr: false,
+			validate: func(cfg *Config) bool {
+				return cfg.Interactive && cfg.AnswersPath == "answers.txt"
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -148,6 +195,152 @@ func TestGetDateRange(t *testing.T) {
 	}
 }
 
+func TestExpandOutputTemplate(t *testing.T) {
+	now := time.Date(2024, 5, 6, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{"No tokens", "report.md", "report.md", false},
+		{"Date and type", "reports/{date}/{type}.md", "reports/2024-05-06/contributor.md", false},
+		{"Repeated token", "{type}-{type}.txt", "contributor-contributor.txt", false},
+		{"Unknown token", "report-{time}.md", "", true},
+		{"Unclosed token", "report-{date.md", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandOutputTemplate(tt.template, now, "contributor")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandOutputTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("expandOutputTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputTemplateGlob(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"Date and type", "out/{date}-{type}.txt", "out/[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]-team.txt"},
+		{"Pattern characters are literal", "report[*]?-{type}.md", "report[[][*]][?]-team.md"},
+		{"No tokens", "report.md", "report.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := outputTemplateGlob(tt.template, "team")
+			if err != nil {
+				t.Fatalf("outputTemplateGlob() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("outputTemplateGlob() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFlags_SeveralCSVFiles(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range []string{"board-a.csv", "board-b.csv", "board-c.csv"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("id,name\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	// Browsers number repeated downloads like a glob character class
+	download := filepath.Join(t.TempDir(), "export [1].csv")
+	if err := os.WriteFile(download, []byte("id,name\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		csv  []string
+		want []string
+	}{
+		{"Single file", []string{paths[0]}, paths[:1]},
+		{"Repeated flag", []string{paths[0], paths[1]}, paths[:2]},
+		{"Comma-separated", []string{paths[1] + "," + paths[2]}, paths[1:]},
+		{"Glob", []string{filepath.Join(dir, "*.csv")}, paths},
+		{"Duplicates are read once", []string{paths[0], filepath.Join(dir, "board-*.csv")}, paths},
+		{"File named like a pattern", []string{download}, []string{download}},
+		{"Path in quotes", []string{`"` + paths[0] + `"`}, paths[:1]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := []string{"--type", "team"}
+			for _, csv := range tt.csv {
+				args = append(args, "--csv", csv)
+			}
+			cfg, err := ParseArgs(args)
+			if err != nil {
+				t.Fatalf("ParseArgs() error = %v", err)
+			}
+			if got := cfg.CSVFiles(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CSVFiles() = %v, want %v", got, tt.want)
+			}
+			if cfg.CSVPath != tt.want[0] {
+				t.Errorf("CSVPath = %q, want %q", cfg.CSVPath, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestParseFlags_FileOptions(t *testing.T) {
+	dir := t.TempDir()
+	comma, semicolon := filepath.Join(dir, "board.csv"), filepath.Join(dir, "jira.csv")
+	for _, path := range []string{comma, semicolon} {
+		if err := os.WriteFile(path, []byte("id,name\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := ParseArgs([]string{"--type", "team", "--csv", comma, "--csv", semicolon,
+		"--file-delimiter", semicolon + "=semicolon",
+		"--file-column-map", semicolon + ":Summary=name," + semicolon + ":Story Points=estimate"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	want := []parser.FileSource{
+		{Path: comma},
+		{Path: semicolon, Delimiter: models.DelimiterSemicolon, ColumnMap: models.ColumnMap{"Summary": "name", "Story Points": "estimate"}},
+	}
+	if got := cfg.CSVSources(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CSVSources() = %+v, want %+v", got, want)
+	}
+
+	errorTests := []struct {
+		name     string
+		args     []string
+		errorMsg string
+	}{
+		{"Delimiter without path", []string{"--file-delimiter", "semicolon"}, "expected PATH=DELIMITER"},
+		{"Invalid delimiter", []string{"--file-delimiter", semicolon + "=pipe"}, "invalid delimiter type: pipe"},
+		{"Column map without path", []string{"--file-column-map", "Summary=name"}, "expected PATH:EXPORT_COLUMN=column"},
+		{"File not loaded", []string{"--file-delimiter", filepath.Join(dir, "other.csv") + "=tab"}, "not one of the --csv files"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseArgs(append([]string{"--type", "team", "--csv", comma, "--csv", semicolon}, tt.args...))
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("ParseArgs() error = %v, want it to contain %q", err, tt.errorMsg)
+			}
+		})
+	}
+}
+
 func TestParseFlags_ErrorHandling(t *testing.T) {
 	// Save original command line arguments and restore after test
 	origArgs := os.Args
@@ -178,58 +371,502 @@ func TestParseFlags_ErrorHandling(t *testing.T) {
 		errorMsg  string
 	}{
 		{
-			name:      "Invalid report type",
-			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "invalid-type"},
+			name:      "Invalid report type",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "invalid-type"},
+			expectErr: true,
+			errorMsg:  "invalid report type",
+		},
+		{
+			name:      "Invalid metrics type", 
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "invalid-metrics"},
+			expectErr: true,
+			errorMsg:  "invalid report type",
+		},
+		{
+			name:      "Invalid period type",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "lead-time", "--period", "invalid"},
+			expectErr: true,
+			errorMsg:  "invalid period type",
+		},
+		{
+			name:      "Invalid ad-hoc filter",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--ad-hoc", "invalid"},
+			expectErr: true,
+			errorMsg:  "invalid ad-hoc filter type",
+		},
+		{
+			name:      "Invalid filter field",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--filter-field", "invalid"},
+			expectErr: true,
+			errorMsg:  "invalid filter field",
+		},
+		{
+			name:      "Malformed start date",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--start", "not-a-date"},
+			expectErr: true,
+			errorMsg:  "error parsing start date",
+		},
+		{
+			name:      "Malformed end date",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--end", "2024-13-45"},
+			expectErr: true,
+			errorMsg:  "error parsing end date",
+		},
+		{
+			name:      "End date before start date",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--start", "2024-05-31", "--end", "2024-05-01"},
+			expectErr: true,
+			errorMsg:  "invalid date range",
+		},
+		{
+			name:      "Invalid statistic",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "lead-time", "--stats", "median,p99"},
+			expectErr: true,
+			errorMsg:  "invalid statistic",
+		},
+		{
+			name:      "Invalid histogram bucket",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "lead-time", "--histogram-buckets", "2,abc"},
+			expectErr: true,
+			errorMsg:  "invalid histogram bucket",
+		},
+		{
+			name:      "Missing holidays file",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "age", "--holidays", "/nonexistent/holidays.txt"},
+			expectErr: true,
+			errorMsg:  "error opening holidays file",
+		},
+		{
+			name:      "Missing absences file",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "throughput", "--absences", "/nonexistent/absences.txt"},
+			expectErr: true,
+			errorMsg:  "error opening absences file",
+		},
+		{
+			name:      "Missing annotations file",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "improvement", "--annotations", "/nonexistent/events.txt"},
+			expectErr: true,
+			errorMsg:  "error opening annotations file",
+		},
+		{
+			name:      "Invalid age threshold",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "age", "--age-sla", "Review=4:2"},
+			expectErr: true,
+			errorMsg:  "invalid age threshold",
+		},
+		{
+			name:      "Invalid WIP limit",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "age", "--wip-limits", "Payments/Review=0"},
+			expectErr: true,
+			errorMsg:  "invalid WIP limit: Payments/Review=0",
+		},
+		{
+			name:      "Allocate by without the allocation report",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--allocate-by", "product-area"},
+			expectErr: true,
+			errorMsg:  "--allocate-by applies to --type allocation",
+		},
+		{
+			name:      "Allocate by month",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "allocation", "--allocate-by", "month"},
+			expectErr: true,
+			errorMsg:  "--allocate-by month is not supported",
+		},
+		{
+			name:      "Invalid filter",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--filter", "team = \"Payments\""},
+			expectErr: true,
+			errorMsg:  "invalid --filter: column 6: unexpected \"=\" (use == to compare)",
+		},
+		{
+			name:      "Invalid reopened policy",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--reopened", "include"},
+			expectErr: true,
+			errorMsg:  "invalid reopened policy",
+		},
+		{
+			name:      "Invalid product area mode",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "product-area", "--product-area-mode", "share"},
+			expectErr: true,
+			errorMsg:  "invalid product area mode",
+		},
+		{
+			name:      "Invalid unit",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--unit", "days"},
+			expectErr: true,
+			errorMsg:  "invalid unit",
+		},
+		{
+			name:      "Invalid column map",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--column-map", "Story Points"},
+			expectErr: true,
+			errorMsg:  "invalid column mapping",
+		},
+		{
+			name:      "Conflicting boolean values",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--truthy", "done", "--falsy", "done"},
+			expectErr: true,
+			errorMsg:  "invalid boolean values",
+		},
+		{
+			name:      "Invalid decimal separator",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--decimal-separator", "period"},
+			expectErr: true,
+			errorMsg:  "invalid decimal separator",
+		},
+		{
+			name:      "Invalid date format",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--date-format", "DD.MM.YYYY"},
+			expectErr: true,
+			errorMsg:  "invalid date format",
+		},
+		{
+			name:      "Invalid estimate mapping",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--estimate-map", "M=medium"},
+			expectErr: true,
+			errorMsg:  "invalid estimate mapping",
+		},
+		{
+			name:      "Negative last N days",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--last", "-5"},
+			expectErr: true,
+			errorMsg:  "last N days must be a positive number",
+		},
+		{
+			name:      "Type and metrics without --both",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--metrics", "lead-time"},
+			expectErr: true,
+			errorMsg:  "--type and --metrics cannot be used together",
+		},
+		{
+			name:      "Both without metrics",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--both"},
+			expectErr: true,
+			errorMsg:  "--both requires both --type and --metrics",
+		},
+		{
+			name:      "Invalid excluded metrics section",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "all", "--exclude-metrics", "velocity"},
+			expectErr: true,
+			errorMsg:  "invalid metrics section",
+		},
+		{
+			name:      "Metrics selection without all",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "lead-time", "--exclude-metrics", "age"},
+			expectErr: true,
+			errorMsg:  "can only be used with --metrics all",
+		},
+		{
+			name:      "Invalid section order",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "all", "--section-order", "age,all"},
+			expectErr: true,
+			errorMsg:  "invalid metrics section",
+		},
+		{
+			name:      "Only and exclude metrics together",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "all", "--only-metrics", "flow", "--exclude-metrics", "age"},
+			expectErr: true,
+			errorMsg:  "cannot be used together",
+		},
+		{
+			name:      "Invalid split field",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "benchmark", "--split-by", "owner"},
+			expectErr: true,
+			errorMsg:  "invalid split field",
+		},
+		{
+			name:      "Category report without rules",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team,category"},
+			expectErr: true,
+			errorMsg:  "--type category requires --categories FILE",
+		},
+		{
+			name:      "Category benchmark without rules",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "benchmark", "--split-by", "category"},
+			expectErr: true,
+			errorMsg:  "--split-by category requires --categories FILE",
+		},
+		{
+			name:      "Missing categories file",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "category", "--categories", "/nonexistent/categories.txt"},
+			expectErr: true,
+			errorMsg:  "error opening categories file",
+		},
+		{
+			name:      "Invalid ad-hoc rule",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--ad-hoc-rules", "owner=alice"},
+			expectErr: true,
+			errorMsg:  "invalid ad-hoc rule",
+		},
+		{
+			name:      "Digest with metrics",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--digest", "--metrics", "flow"},
+			expectErr: true,
+			errorMsg:  "--digest cannot be combined with --type or --metrics",
+		},
+		{
+			name:      "Invalid timeout",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--timeout", "10"},
+			expectErr: true,
+			errorMsg:  "invalid timeout: 10",
+		},
+		{
+			name:      "Problems format with report",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--format", "problems"},
+			expectErr: true,
+			errorMsg:  "--format problems lists the data-quality findings only",
+		},
+		{
+			name:      "HTML filters without HTML",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--format", "markdown", "--html-filters"},
+			expectErr: true,
+			errorMsg:  "--html-filters applies to --format html",
+		},
+		{
+			name:      "SVG format without scatterplot",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "lead-time", "--format", "svg"},
+			expectErr: true,
+			errorMsg:  "--format svg draws the cycle time scatterplot",
+		},
+		{
+			name:      "Append without output file",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--append"},
+			expectErr: true,
+			errorMsg:  "--append requires --output or --output-template",
+		},
+		{
+			name:      "Append with no overwrite",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--output", "a.txt", "--append", "--no-overwrite"},
+			expectErr: true,
+			errorMsg:  "--append and --no-overwrite cannot be used together",
+		},
+		{
+			name:      "Append to JSON",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--output", "a.json", "--format", "json", "--append"},
+			expectErr: true,
+			errorMsg:  "--append adds runs to text and markdown files, not to --format json",
+		},
+		{
+			name:      "Compare without date range",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--compare-with", "previous"},
+			expectErr: true,
+			errorMsg:  "--compare-with needs a date range to compare",
+		},
+		{
+			name:      "Compare as JSON",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--last", "30", "--format", "json", "--compare-with", "previous"},
+			expectErr: true,
+			errorMsg:  "--compare-with shows the changes as text, markdown or html",
+		},
+		{
+			name:      "Compare with unknown range",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--last", "30", "--compare-with", "yesteryear"},
+			expectErr: true,
+			errorMsg:  "invalid --compare-with: yesteryear",
+		},
+		{
+			name:      "Compare with reversed dates",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--last", "30", "--compare-with", "2024-03-31..2024-01-01"},
+			expectErr: true,
+			errorMsg:  "end date (2024-01-01) is before start date (2024-03-31)",
+		},
+		{
+			name:      "Items export with report",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--export", "items-json"},
+			expectErr: true,
+			errorMsg:  "--export writes the items instead of reports",
+		},
+		{
+			name:      "Items export with format",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--export", "items-json", "--format", "json"},
+			expectErr: true,
+			errorMsg:  "--export items-json writes JSON Lines; leave out --format",
+		},
+		{
+			name:      "Invalid export",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--export", "items-csv"},
+			expectErr: true,
+			errorMsg:  "invalid export: items-csv",
+		},
+		{
+			name:      "Baseline with report",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--baseline", validFile.Name(), "--type", "team"},
+			expectErr: true,
+			errorMsg:  "--baseline lists the changes since the earlier export instead of reports",
+		},
+		{
+			name:      "Baseline with date range",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--baseline", validFile.Name(), "--last", "30"},
+			expectErr: true,
+			errorMsg:  "--baseline compares the whole exports; leave out the date range",
+		},
+		{
+			name:      "Baseline as problems",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--baseline", validFile.Name(), "--format", "problems"},
+			expectErr: true,
+			errorMsg:  "--baseline lists the changes as text, json, markdown or html, not --format problems",
+		},
+		{
+			name:      "Missing baseline file",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--baseline", "/nonexistent/old.csv"},
+			expectErr: true,
+			errorMsg:  "Make sure the file path is correct",
+		},
+		{
+			name:      "Missing digest settings file",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--digest", "--digest-settings", "/nonexistent/digest.conf"},
+			expectErr: true,
+			errorMsg:  "error opening digest settings file",
+		},
+		{
+			name:      "Missing history file",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "cfd", "--history", "/nonexistent/history.csv"},
+			expectErr: true,
+			errorMsg:  "error opening history file",
+		},
+		{
+			name:      "Detail without the contributor report",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--detail"},
+			expectErr: true,
+			errorMsg:  "--detail applies to --type contributor",
+		},
+		{
+			name:      "Top without detail",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--top", "5"},
+			expectErr: true,
+			errorMsg:  "--top applies to --detail",
+		},
+		{
+			name:      "Min epics too low",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contention", "--min-epics", "1"},
+			expectErr: true,
+			errorMsg:  "min epics must be 2 or more",
+		},
+		{
+			name:      "Non-interactive with interactive",
+			args:      []string{"cmd", "--non-interactive", "--interactive"},
+			expectErr: true,
+			errorMsg:  "--non-interactive cannot be combined with --interactive or --answers",
+		},
+		{
+			name:      "Invalid output format",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--format", "yaml"},
+			expectErr: true,
+			errorMsg:  "invalid output format: yaml",
+		},
+		{
+			name:      "Output with output template",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--output", "a.txt", "--output-template", "report-{date}.txt"},
+			expectErr: true,
+			errorMsg:  "--output and --output-template cannot be used together",
+		},
+		{
+			name:      "Unknown output template token",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--output-template", "report-{week}.txt"},
+			expectErr: true,
+			errorMsg:  "unknown token {week} in output template",
+		},
+		{
+			name:      "Negative keep last",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--output-template", "report-{date}.txt", "--keep-last", "-1"},
+			expectErr: true,
+			errorMsg:  "keep last must be 0 or more",
+		},
+		{
+			name:      "Keep days without output template",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--output", "a.txt", "--keep-days", "30"},
 			expectErr: true,
-			errorMsg:  "invalid report type",
+			errorMsg:  "--keep-last and --keep-days require --output-template",
 		},
 		{
-			name:      "Invalid metrics type", 
-			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "invalid-metrics"},
+			name:      "Sign without output file",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--sign"},
 			expectErr: true,
-			errorMsg:  "invalid report type",
+			errorMsg:  "--sign requires --output or --output-template",
 		},
 		{
-			name:      "Invalid period type",
-			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "lead-time", "--period", "invalid"},
+			name:      "Minisign key without sign",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--output", "a.txt", "--minisign-key", validFile.Name()},
 			expectErr: true,
-			errorMsg:  "invalid period type",
+			errorMsg:  "--minisign-key requires --sign",
 		},
 		{
-			name:      "Invalid ad-hoc filter",
-			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--ad-hoc", "invalid"},
+			name:      "Missing minisign key",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "team", "--output", "a.txt", "--sign", "--minisign-key", "nonexistent.key"},
 			expectErr: true,
-			errorMsg:  "invalid ad-hoc filter type",
+			errorMsg:  "minisign key not found: nonexistent.key",
 		},
 		{
-			name:      "Invalid filter field",
-			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--filter-field", "invalid"},
+			name:      "Invalid age mode",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "age", "--age-mode", "chart"},
 			expectErr: true,
-			errorMsg:  "invalid filter field",
+			errorMsg:  "invalid age mode",
 		},
 		{
-			name:      "Malformed start date",
-			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--start", "not-a-date"},
+			name:      "Missing iterations file",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "commitment", "--iterations", "/nonexistent/sprints.txt"},
 			expectErr: true,
-			errorMsg:  "error parsing start date",
+			errorMsg:  "error opening iterations file",
 		},
 		{
-			name:      "Malformed end date",
-			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--end", "2024-13-45"},
+			name:      "No CSV files match a glob",
+			args:      []string{"cmd", "--csv", "/nonexistent/exports/*.csv", "--type", "team"},
 			expectErr: true,
-			errorMsg:  "error parsing end date",
+			errorMsg:  "no CSV files match '/nonexistent/exports/*.csv'",
 		},
 		{
-			name:      "End date before start date",
-			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--start", "2024-05-31", "--end", "2024-05-01"},
+			name:      "Invalid date range preset",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--range", "last-year"},
 			expectErr: true,
-			errorMsg:  "invalid date range",
+			errorMsg:  "invalid date range: last-year",
 		},
 		{
-			name:      "Negative last N days",
-			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--last", "-5"},
+			name:      "Date range preset with explicit dates",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--range", "ytd", "--last", "30"},
 			expectErr: true,
-			errorMsg:  "last N days must be a positive number",
+			errorMsg:  "--range cannot be combined with --start, --end or --last",
+		},
+		{
+			name:      "Invalid simulations",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "forecast", "--simulations", "0"},
+			expectErr: true,
+			errorMsg:  "simulations must be 1 or more",
+		},
+		{
+			name:      "Negative forecast items",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "forecast", "--forecast-items", "-5"},
+			expectErr: true,
+			errorMsg:  "forecast items must be 0 or more",
+		},
+		{
+			name:      "Forecast minimum weeks below one",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "forecast", "--forecast-min-weeks", "0"},
+			expectErr: true,
+			errorMsg:  "forecast minimum weeks must be 1 or more",
+		},
+		{
+			name:      "Invalid forecast date",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "forecast", "--forecast-date", "2024/09/30"},
+			expectErr: true,
+			errorMsg:  "error parsing forecast date",
+		},
+		{
+			name:      "Invalid week numbering",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "throughput", "--week-numbering", "uk"},
+			expectErr: true,
+			errorMsg:  "invalid week numbering",
+		},
+		{
+			name:      "Negative width",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--metrics", "throughput", "--width", "-1"},
+			expectErr: true,
+			errorMsg:  "width must be 0 or more",
+		},
+		{
+			name:      "Invalid max errors",
+			args:      []string{"cmd", "--csv", validFile.Name(), "--type", "contributor", "--max-errors", "-2"},
+			expectErr: true,
+			errorMsg:  "max errors must be 0 or more",
 		},
 	}
 
@@ -316,6 +953,224 @@ func TestParseFlags_DefaultBehavior(t *testing.T) {
 				return cfg.PeriodType == "month"
 			},
 		},
+		{
+			name: "Default week numbering should be iso",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "throughput", "--period", "week"},
+			validate: func(cfg *Config) bool {
+				return cfg.WeekNumbering == "iso"
+			},
+		},
+		{
+			name: "Epic contributor report for one epic",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "epic-contributor", "--epic", " Checkout "},
+			validate: func(cfg *Config) bool {
+				return cfg.ReportType == reports.ReportTypeEpicContributor && cfg.Epic == "Checkout"
+			},
+		},
+		{
+			name: "Label report for one prefix",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "label", "--label-prefix", "component:"},
+			validate: func(cfg *Config) bool {
+				return cfg.ReportType == reports.ReportTypeLabel && cfg.LabelPrefix == "component:"
+			},
+		},
+		{
+			name: "Milestone and iteration reports",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "milestone,iteration"},
+			validate: func(cfg *Config) bool {
+				return reflect.DeepEqual(cfg.ReportTypes, []reports.ReportType{reports.ReportTypeMilestone, reports.ReportTypeIteration})
+			},
+		},
+		{
+			name: "State report",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "state"},
+			validate: func(cfg *Config) bool {
+				return cfg.ReportType == reports.ReportTypeState
+			},
+		},
+		{
+			name: "Group-by on its own implies the group report",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--group-by", "custom:domain"},
+			validate: func(cfg *Config) bool {
+				return cfg.ReportType == reports.ReportTypeGroup && cfg.GroupBy == "custom:domain"
+			},
+		},
+		{
+			name: "Friendly period labels",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "throughput", "--period-labels", "friendly"},
+			validate: func(cfg *Config) bool {
+				return cfg.PeriodLabels == types.PeriodLabelsFriendly
+			},
+		},
+		{
+			name: "Epic progress report",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "epic-progress"},
+			validate: func(cfg *Config) bool {
+				return cfg.ReportType == reports.ReportTypeEpicProgress
+			},
+		},
+		{
+			name: "Pivot report of two fields",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "pivot", "--rows", "team", "--cols", "month"},
+			validate: func(cfg *Config) bool {
+				return cfg.ReportType == reports.ReportTypePivot && cfg.PivotRows == "team" && cfg.PivotCols == "month"
+			},
+		},
+		{
+			name: "Allocation report by product area",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "allocation", "--allocate-by", "product-area"},
+			validate: func(cfg *Config) bool {
+				return cfg.ReportType == reports.ReportTypeAllocation && cfg.AllocateBy == "product_area"
+			},
+		},
+		{
+			name: "Contributor drill-down limited to the top items",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "contributor", "--detail", "--top", "5"},
+			validate: func(cfg *Config) bool {
+				return cfg.Detail && cfg.Top == 5
+			},
+		},
+		{
+			name: "Pager turned off",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--no-pager"},
+			validate: func(cfg *Config) bool {
+				return cfg.NoPager
+			},
+		},
+		{
+			name: "Problems format without report or metrics",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--format", "problems"},
+			validate: func(cfg *Config) bool {
+				return cfg.Format == types.FormatProblems && cfg.ReportType == "" && cfg.MetricsType == ""
+			},
+		},
+		{
+			name: "HTML filters",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--format", "html", "--html-filters"},
+			validate: func(cfg *Config) bool {
+				return cfg.HTMLFilters && cfg.Format == types.FormatHTML
+			},
+		},
+		{
+			name: "SVG scatterplot",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "scatterplot", "--format", "svg"},
+			validate: func(cfg *Config) bool {
+				return cfg.Format == types.FormatSVG && cfg.MetricsType == "scatterplot"
+			},
+		},
+		{
+			name: "Charts directory",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "throughput", "--format", "html", "--charts-dir", " charts "},
+			validate: func(cfg *Config) bool {
+				return cfg.ChartsDir == "charts"
+			},
+		},
+		{
+			name: "Append to a Markdown journal",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "throughput", "--format", "markdown", "--output", "journal.md", "--append"},
+			validate: func(cfg *Config) bool {
+				return cfg.Append && cfg.OutputPath == "journal.md"
+			},
+		},
+		{
+			name: "Compare with the previous range",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "throughput", "--start", "2024-04-01", "--end", "2024-04-30", "--compare-with", "previous"},
+			validate: func(cfg *Config) bool {
+				// 30 days just before April: March 2 to March 31
+				return cfg.CompareStart.Format(DateFormat) == "2024-03-02" && cfg.CompareEnd.Format(DateFormat) == "2024-03-31" && cfg.CompareWith == "previous"
+			},
+		},
+		{
+			name: "Compare with explicit dates",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--range", "this-quarter", "--compare-with", "2024-01-01..2024-03-31"},
+			validate: func(cfg *Config) bool {
+				return cfg.CompareStart.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) && cfg.CompareEnd.Format("2006-01-02 15:04:05") == "2024-03-31 23:59:59"
+			},
+		},
+		{
+			name: "Compare with a preset",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--range", "this-quarter", "--compare-with", "last-quarter"},
+			validate: func(cfg *Config) bool {
+				return cfg.CompareEnd.Add(time.Second).Equal(cfg.StartDate)
+			},
+		},
+		{
+			name: "Items export without report or metrics",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--export", "items-json", "--last", "30"},
+			validate: func(cfg *Config) bool {
+				return cfg.Export == types.ExportItemsJSON && cfg.ReportType == "" && cfg.MetricsType == ""
+			},
+		},
+		{
+			name: "Baseline without report or metrics",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--baseline", tempFile.Name(), "--format", "markdown"},
+			validate: func(cfg *Config) bool {
+				return cfg.BaselinePath == tempFile.Name() && cfg.ReportType == "" && cfg.MetricsType == ""
+			},
+		},
+		{
+			name: "WIP limits",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "cfd", "--wip-limits", "In Progress=5,Payments/Review=2"},
+			validate: func(cfg *Config) bool {
+				return cfg.WIPLimits.String() == "In Progress=5,Payments/Review=2"
+			},
+		},
+		{
+			name: "Filter",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--filter", "team == \"Payments\" && has(labels, \"backend\")"},
+			validate: func(cfg *Config) bool {
+				return cfg.Filter != nil && cfg.Filter.String() == "team == \"Payments\" && has(labels, \"backend\")"
+			},
+		},
+		{
+			name: "Timeout",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--timeout", "2m"},
+			validate: func(cfg *Config) bool {
+				return cfg.Timeout == 2*time.Minute
+			},
+		},
+		{
+			name: "Aging WIP age mode",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "age", "--age-mode", "aging-wip"},
+			validate: func(cfg *Config) bool {
+				return cfg.AgeMode == "aging-wip"
+			},
+		},
+		{
+			name: "Default forecast settings",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "forecast"},
+			validate: func(cfg *Config) bool {
+				return cfg.MetricsType == "forecast" && cfg.Forecast.Simulations == 10000 && cfg.Forecast.Items == 0 && cfg.Forecast.Date.IsZero() && cfg.Forecast.Seed == 1
+			},
+		},
+		{
+			name: "Forecast seed",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "forecast", "--seed", "42"},
+			validate: func(cfg *Config) bool {
+				return cfg.Forecast.Seed == 42
+			},
+		},
+		{
+			name: "Forecast targets",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "forecast", "--forecast-items", "40", "--forecast-date", "2024-09-30", "--simulations", "500"},
+			validate: func(cfg *Config) bool {
+				return cfg.Forecast.Items == 40 && cfg.Forecast.Date.Format("2006-01-02") == "2024-09-30" && cfg.Forecast.Simulations == 500
+			},
+		},
+		{
+			name: "US week numbering",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "throughput", "--period", "week", "--week-numbering", "us"},
+			validate: func(cfg *Config) bool {
+				return cfg.WeekNumbering == "us"
+			},
+		},
+		{
+			name: "Default stats should be count,min,max,avg,median",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "lead-time"},
+			validate: func(cfg *Config) bool {
+				return len(cfg.Stats) == 5 && cfg.Stats[0] == "count" && cfg.Stats[4] == "median"
+			},
+		},
 		{
 			name: "Invalid delimiter falls back to auto",
 			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "contributor", "--delimiter", "invalid"}, // Use tempFile.Name() instead of "test.csv"
@@ -370,16 +1225,121 @@ func TestParseFlags_EdgeCaseBehavior(t *testing.T) {
 	}
 	tempFile.Close()
 
+	// Only non-interactive runs read the output path from the environment
+	t.Setenv(OutputEnv, "from-env.txt")
+
 	testCases := []struct {
 		name     string
 		args     []string
 		validate func(*Config) bool
 	}{
 		{
-			name: "Both type and metrics specified - metrics should win",
-			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "contributor", "--metrics", "lead-time"}, // Use tempFile.Name()
+			name: "Both type and metrics specified with --both",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "contributor", "--metrics", "lead-time", "--both"},
+			validate: func(cfg *Config) bool {
+				return cfg.Both && cfg.MetricsType == "lead-time" && cfg.ReportType == reports.ReportTypeContributor
+			},
+		},
+		{
+			name: "Digest selects the digest metrics",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--digest"},
+			validate: func(cfg *Config) bool {
+				return cfg.MetricsType == "digest" && cfg.IsMetricsReport()
+			},
+		},
+		{
+			name: "Non-interactive saves to $OUTPUT",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--non-interactive"},
+			validate: func(cfg *Config) bool {
+				return cfg.NonInteractive && cfg.OutputPath == "from-env.txt"
+			},
+		},
+		{
+			name: "Non-interactive keeps --output over $OUTPUT",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--non-interactive", "--output", "report.txt"},
+			validate: func(cfg *Config) bool {
+				return cfg.OutputPath == "report.txt"
+			},
+		},
+		{
+			name: "$OUTPUT is ignored without --non-interactive",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team"},
+			validate: func(cfg *Config) bool {
+				return !cfg.NonInteractive && cfg.OutputPath == ""
+			},
+		},
+		{
+			name: "Output template expands date and types",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--metrics", "lead-time", "--both", "--output-template", "report-{date}-{type}.md", "--no-overwrite"},
+			validate: func(cfg *Config) bool {
+				return cfg.OutputPath == "report-"+time.Now().Format(DateFormat)+"-team-lead-time.md" && cfg.NoOverwrite
+			},
+		},
+		{
+			name: "Retention matches earlier outputs of the template",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--output-template", "reports/{type}-{date}.md", "--keep-last", "4", "--keep-days", "90"},
+			validate: func(cfg *Config) bool {
+				return cfg.Retention == retention.Policy{KeepLast: 4, KeepDays: 90} &&
+					cfg.OutputGlob == "reports/team-[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9].md"
+			},
+		},
+		{
+			name: "Sign with minisign key",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--output", "a.txt", "--sign", "--minisign-key", tempFile.Name()},
+			validate: func(cfg *Config) bool {
+				return cfg.Sign && cfg.MinisignKey == tempFile.Name()
+			},
+		},
+		{
+			name: "Non-interactive prefers the output template over $OUTPUT",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "digest", "--non-interactive", "--output-template", "{type}.txt"},
+			validate: func(cfg *Config) bool {
+				return cfg.OutputPath == "digest.txt"
+			},
+		},
+		{
+			name: "JSON output format",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--format", "json"},
+			validate: func(cfg *Config) bool {
+				return cfg.Format == types.FormatJSON
+			},
+		},
+		{
+			name: "HTML output format",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--format", "html"},
+			validate: func(cfg *Config) bool {
+				return cfg.Format == types.FormatHTML
+			},
+		},
+		{
+			name: "Text output format by default",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team"},
+			validate: func(cfg *Config) bool {
+				return cfg.Format == types.FormatText
+			},
+		},
+		{
+			name: "Several report types as a list",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "contributor,epic"},
+			validate: func(cfg *Config) bool {
+				return len(cfg.ReportTypes) == 2 && cfg.ReportType == reports.ReportTypeContributor &&
+					cfg.ReportTypes[1] == reports.ReportTypeEpic
+			},
+		},
+		{
+			name: "Several report types as repeated flags",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "team", "--type", "epic"},
+			validate: func(cfg *Config) bool {
+				return len(cfg.ReportTypes) == 2 && cfg.ReportType == reports.ReportTypeTeam &&
+					cfg.ReportTypes[1] == reports.ReportTypeEpic
+			},
+		},
+		{
+			name: "Section order and separator",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--metrics", "all", "--exclude-metrics", "flow", "--section-order", "age", "--section-separator", `---\n`},
 			validate: func(cfg *Config) bool {
-				return cfg.IsMetricsReport() && cfg.MetricsType == "lead-time"
+				return len(cfg.MetricsSections) == 5 && cfg.MetricsSections[0] == "age" &&
+					cfg.Separator == "---\n"
 			},
 		},
 		{
@@ -389,6 +1349,22 @@ func TestParseFlags_EdgeCaseBehavior(t *testing.T) {
 				return cfg.LastNDays == 7 && !cfg.StartDate.IsZero() && !cfg.EndDate.IsZero()
 			},
 		},
+		{
+			name: "Year to date range ends today",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "contributor", "--range", "ytd"},
+			validate: func(cfg *Config) bool {
+				now := time.Now()
+				return cfg.Range == "ytd" && cfg.StartDate.Format(DateFormat) == now.Format("2006") + "-01-01" &&
+					cfg.EndDate.Format(DateFormat) == now.Format(DateFormat) && cfg.EndDate.Hour() == 23
+			},
+		},
+		{
+			name: "Last week range follows the week numbering",
+			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "contributor", "--range", "last-week", "--week-numbering", "us"},
+			validate: func(cfg *Config) bool {
+				return cfg.StartDate.Weekday() == time.Sunday && cfg.EndDate.Weekday() == time.Saturday
+			},
+		},
 		{
 			name: "Zero last N days should not override explicit dates",
 			args: []string{"cmd", "--csv", tempFile.Name(), "--type", "contributor", "--start", "2024-05-01", "--last", "0"}, // Use tempFile.Name()
@@ -426,3 +1402,40 @@ func TestParseFlags_EdgeCaseBehavior(t *testing.T) {
 		})
 	}
 }
+
+func TestParseArgs(t *testing.T) {
+	csvPath := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(csvPath, []byte("id,name,estimate,is_completed,completed_at\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test CSV: %v", err)
+	}
+
+	cfg, err := ParseArgs([]string{"--csv", csvPath, "--metrics", "lead-time", "--format", "json"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if cfg.CSVPath != csvPath || cfg.MetricsType != "lead-time" || cfg.Format != types.FormatJSON {
+		t.Errorf("ParseArgs() = %+v, want lead-time JSON metrics of %s", cfg, csvPath)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"Unknown flag", []string{"--csv", csvPath, "--colour"}, "flag provided but not defined"},
+		{"Stray argument", []string{"--csv", csvPath, "--type", "team", "extra"}, "unexpected argument: extra"},
+		{"Help", []string{"--help"}, "only available on the command line"},
+		{"Interactive", []string{"-i"}, "only available on the command line"},
+		{"Output file", []string{"--csv", csvPath, "--type", "team", "--output", "out.txt"}, "the output is returned instead"},
+		{"Warnings file", []string{"--csv", csvPath, "--type", "team", "--warnings-file", "-"}, "the output is returned instead"},
+		{"Charts directory", []string{"--csv", csvPath, "--metrics", "throughput", "--charts-dir", "charts"}, "the output is returned instead"},
+		{"Invalid config", []string{"--csv", csvPath, "--type", "nonsense"}, "invalid report type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseArgs(tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseArgs() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -6,16 +6,73 @@ const (
 	
 	// DefaultPeriodType is the default time period for metrics grouping
 	DefaultPeriodType = "month"
+
+	// DefaultAgeMode is the default presentation of the age metric
+	DefaultAgeMode = "summary"
+
+	// DefaultSimulations is the default number of Monte Carlo forecast runs
+	DefaultSimulations = 10000
+
+	// DefaultForecastMinWeeks is the default fewest weeks of history to forecast from
+	DefaultForecastMinWeeks = 4
+
+	// DefaultSeed is the default seed of the Monte Carlo forecast runs
+	DefaultSeed = 1
+
+	// DefaultWeekNumbering is the default week convention for weekly periods
+	DefaultWeekNumbering = "iso"
+
+	// DefaultPeriodLabels is the default labeling of weeks and months in trend tables
+	DefaultPeriodLabels = "iso"
 	
 	// DefaultAdHocFilter is the default ad-hoc request filtering behavior
 	DefaultAdHocFilter = "include"
 	
+	// DefaultStats is the default set of statistics shown in metrics tables
+	DefaultStats = "count,min,max,avg,median"
+	
+	// DefaultReopenedPolicy is the default handling of reopened items
+	DefaultReopenedPolicy = "exclude"
+	
+	// DefaultProductAreaMode is the default attribution of items in several product areas
+	DefaultProductAreaMode = "split"
+	
+	// DefaultDecimalSeparator is the default decimal separator handling for estimates
+	DefaultDecimalSeparator = "auto"
+	
+	// DefaultDateFormat is the default layout of timestamps in the CSV
+	DefaultDateFormat = "shortcut"
+	
+	// DefaultUnit is the default estimate unit
+	DefaultUnit = "points"
+	
+	// DefaultHistogramBuckets are the default cycle time histogram bucket bounds in days
+	DefaultHistogramBuckets = "2,5,10,20"
+	
+	// DefaultMinEpics is the default number of concurrent epics flagged by the contention report
+	DefaultMinEpics = 3
+	
+	// DefaultSplitBy is the default field used to group items in the benchmark
+	DefaultSplitBy = "team"
+	
 	// DefaultFilterField is the default date field used for filtering
 	DefaultFilterField = "completed_at"
 	
 	// DefaultDelimiter is the default CSV delimiter setting
 	DefaultDelimiter = "auto"
 	
+	// DefaultMaxErrors is the default number of rows allowed to fail parsing (-1 = no limit)
+	DefaultMaxErrors = -1
+	
+	// DefaultFormat is the default output format
+	DefaultFormat = "text"
+	
+	// OutputEnv names the environment variable used as --output in non-interactive mode
+	OutputEnv = "OUTPUT"
+	
+	// ComparePrevious compares the date range with the range of the same length just before it
+	ComparePrevious = "previous"
+	
 	// DateFormat is the expected date format for command-line date inputs
 	DateFormat = "2006-01-02"
 	
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+
+	"github.com/hannasdev/kanban-reports/pkg/terminal"
 )
 
 // showUsage displays basic usage information
@@ -23,7 +25,7 @@ For detailed help and examples, use:
 
 // showVersion displays version information
 func showVersion() {
-	fmt.Printf(`Kanban Reports v1.0.0
+	fmt.Fprintf(terminal.Stdout(), `Kanban Reports v1.0.0
 A tool for generating productivity reports from Kanban board CSV exports.
 
 Build Information:
@@ -35,7 +37,7 @@ Build Information:
 
 // showHelp displays comprehensive help information
 func showHelp() {
-	fmt.Printf(`🔄 Kanban Reports - Help & Usage Guide
+	fmt.Fprintf(terminal.Stdout(), `🔄 Kanban Reports - Help & Usage Guide
 =====================================
 
 DESCRIPTION:
@@ -57,68 +59,444 @@ MODES:
         
         Guided step-by-step menu to configure your report.
 
+        %s --answers answers.txt
+        
+        Replays a session with the menu answers read from a file,
+        one per line ("-" reads them from stdin).
+
     ⚡ Command-line Mode (Great for automation):
         %s --csv data.csv --type contributor --last 7
 
+COMMANDS:
+    %s compare BEFORE.json AFTER.json
+                                  Metric-by-metric deltas between two JSON
+                                  outputs; changes of --threshold percent
+                                  (default 10) or more are highlighted
+    %s schema report|metrics|data-quality|items|changes
+                                  Print the JSON Schema of the --format json
+                                  output of reports, metrics or the
+                                  data-quality findings, of the items
+                                  written by --export items-json, or of the
+                                  changes listed with --baseline
+    %s explain [TERM]
+                                  Explain a metric: what it measures, its
+                                  formulas and caveats (lead-time, flow, sle,
+                                  ...; without TERM, list the terms)
+    %s serve --ipc [--socket PATH]
+                                  Serve reports and metrics to editors and
+                                  tools as JSON-RPC 2.0 on a local socket,
+                                  with progress notifications
+    %s config validate --config FILE [--profile NAME]
+                                  Check the profiles of a config file and
+                                  explain each wrong option with its line
+    %s demo [--no-pause]
+                                  Tour every report and kind of metrics on a
+                                  built-in sample export, with a short
+                                  explanation of each
+    %s setup
+                                  Answer a few questions about your team's
+                                  exports and save them as the defaults
+                                  (offered on the first run without options)
+    %s install-service --profile NAME [--schedule daily|weekly|monthly] [--at HH:MM]
+                                  Run a profile of the saved config file on
+                                  a schedule with a systemd user timer
+                                  (Linux) or launchd agent (macOS); add
+                                  --dry-run to print the files instead
+
 REQUIRED OPTIONS:
-    --csv FILE                      Path to your kanban CSV file
+    --csv FILE                      Path to your kanban CSV file; repeat it or
+                                    use a glob ("exports/*.csv") to merge several,
+                                    keeping the latest update of each item
     
     Choose ONE of:
     --type TYPE                     Generate a report (see REPORT TYPES)
     --metrics TYPE                  Generate metrics (see METRICS TYPES)
+    --digest                        Summarize the latest week in 5-10 plain-
+                                    language highlights (throughput vs the
+                                    prior 4 weeks, cycle time, WIP, aging work)
+    --digest-settings FILE          Tune the digest with "key = value" lines:
+                                    baseline-weeks (4), cycle-weeks (4),
+                                    aging-days (30), steady-percent (5),
+                                    min-sample (1), max-bullets (10)
+
+    --both                          Allow --type and --metrics together and
+                                    output the report followed by the metrics
 
 REPORT TYPES (--type):
     contributor                     Story points by person who completed work
+    --detail                       List each contributor's completed items
+                                  with points, epic and cycle time
+    --top N                        Items listed per contributor with
+                                  --detail, largest first (default: all)
     epic                           Story points by epic/initiative
     product-area                   Story points by product area
     team                           Story points by team
+    category                       Story points by custom category (needs
+                                  --categories)
+    workload                       Open items by owner with points and oldest
+                                  age, flagging overloaded people
+    contention                     Contributors working on several epics at
+                                  once and the epics sharing the most people
+    --min-epics N                  Concurrent epics at which contention lists
+                                  a contributor (default: 3)
+    epic-contributor               Contributors and their story points within
+                                  each epic
+    --epic NAME                    Limit epic-contributor to one epic
+                                  (default: all epics)
+    team-month                     Story points by team and month of
+                                  completion, as a heatmap in HTML output
+    label                          Story points by label; items count in
+                                  full toward each of their labels
+    --label-prefix PREFIX          Limit label to labels starting with
+                                  PREFIX, e.g. "component:"
+    milestone                      Story points by milestone, with its due
+                                  date against its last completion
+    iteration                      Story points by iteration; both count the
+                                  items completed after their due_date
+    state                          Board summary: open items by workflow
+                                  state now, and states at completion
+    group                          Story points by any column or custom field
+    --group-by FIELD               Field the group report totals by, e.g.
+                                  priority or custom:domain; on its own it
+                                  implies --type group
+    pivot                          Story points as a cross-tab of two columns
+                                  or custom fields, e.g. teams by item type
+    --rows FIELD --cols FIELD      Fields of the rows and columns of pivot,
+                                  such as --rows epic --cols month
+    epic-progress                  Each epic's completed against total points,
+                                  with its projected finish at the velocity
+                                  of the last 4 weeks, against its due date,
+                                  and by the cycle times of its open sizes
+    allocation                     Each owner's share of the points they
+                                  completed per month by epic, estimating
+                                  where their time went
+    --allocate-by FIELD            Field allocation divides the work by,
+                                  e.g. product-area or custom:domain
+                                  (default: epic)
+
+    Several types can be combined into one document with sections:
+    --type contributor,epic,team   or   --type epic --type team
+
+    --hierarchy                    Add a project → epic → item breakdown
+                                  with subtotals at each level
+    --product-area-mode MODE       Items in several product areas ("A;B"):
+                                  split (default) divides the estimate,
+                                  duplicate credits each area in full
 
 METRICS TYPES (--metrics):
     lead-time                      How long items take from creation to completion
     throughput                     Completion rates over time (items & points)
+                                  and how much the items per week vary
     flow                          Flow efficiency (active vs waiting time)
     estimation                    Estimation accuracy (estimates vs actual time)
     age                           Age analysis of current incomplete work
     improvement                   Month-over-month improvement trends
-    all                           Generate all metrics above
+    workflow                      Lead time and throughput compared per workflow
+    benchmark                     Rank teams on p85 cycle time, throughput
+                                  stability, flow efficiency and WIP age
+    review                        Time in the final review/QA state per team,
+                                  estimated from moved_at and completed_at
+    cfd                           Cumulative flow: items per state at the end
+                                  of each period (real states with --history)
+    priority                      Share of completed points by priority per
+                                  period, flagging high-priority crowding
+    digest                        Weekly highlights in plain language (same
+                                  as --digest)
+    weekday                       Completions by day of week (and hour),
+                                  flagging Friday-evening and weekend crunch
+    forecast                      Monte Carlo forecast from weekly throughput:
+                                  when items will be done and how many by a
+                                  date, at 50/70/85/95%% confidence
+    commitment                    Points committed at each iteration's start
+                                  vs completed by its end (say/do ratio)
+    scatterplot                   Cycle time of each item against its
+                                  completion date, with p50/p70/p85/p95 lines
+                                  (drawn as SVG in --format html and svg)
+    all                           Generate all metrics above (except workflow,
+                                  benchmark, review, cfd, priority, digest,
+                                  weekday, forecast, commitment and scatterplot)
+
+    --split-by FIELD               Group compared in the benchmark and review: team
+                                  (default), product-area, epic, workflow,
+                                  category (needs --categories)
+
+    --exclude-metrics LIST         Leave sections out of "all", e.g. age,estimation
+    --only-metrics LIST            Generate only these sections of "all", in the
+                                  given order (may include workflow)
+    --section-order LIST           Show these sections of "all" first, in the
+                                  given order, followed by the rest
+
+OUTPUT LAYOUT:
+    --section-separator TEXT       Line between sections of combined output
+                                  (default: a row of 80 '='; \n and \t allowed)
+    --no-explanations              Leave out the sections explaining each
+                                  metric; read them with "explain" (always
+                                  on for --format json)
 
 DATE FILTERING:
     --last N                       Include only last N days
     --start YYYY-MM-DD             Start date (inclusive)
     --end YYYY-MM-DD               End date (inclusive)
+    --range PRESET                 Calendar range up to today: this-week,
+                                  last-week, this-month, last-month,
+                                  this-quarter, last-quarter, ytd (weeks follow
+                                  --week-numbering)
+    --compare-with RANGE           Also run for a baseline range and show the
+                                  change of every value: previous (the same
+                                  length just before), a --range preset, or
+                                  START..END (text, markdown or html)
     
     Examples:
     --last 7                       Last week
     --last 30                      Last month
     --last 90                      Last quarter
     --start 2024-01-01 --end 2024-03-31    Q1 2024
+    --range last-month             The whole previous calendar month
+    --range this-quarter --compare-with last-quarter    Quarter over quarter
 
 AD-HOC REQUEST FILTERING:
     --ad-hoc include               Include all items (default)
     --ad-hoc exclude               Exclude items labeled 'ad-hoc-request'
     --ad-hoc only                  Only items labeled 'ad-hoc-request'
+    --ad-hoc-rules LIST            What marks ad-hoc requests, as label=,
+                                  epic-label= and type= rules, e.g.
+                                  "epic-label=support,type=chore"; the
+                                  'ad-hoc-request' label still applies unless
+                                  a label= rule is given
+
+FILTER EXPRESSIONS:
+    --filter EXPR                  Only use items matching the expression, e.g.
+                                  'team == "Payments" && estimate >= 3 &&
+                                  has(labels, "backend")'
+                                  Fields are named like the CSV columns; compare
+                                  with == != < <= > >= (text ignores case, dates
+                                  are "YYYY-MM-DD"), test lists with
+                                  has(labels, "x") and text with
+                                  contains(name, "x"), combine with && || ! ( )
 
 TIME PERIODS (for metrics):
-    --period week                  Group by week (for throughput metrics)
+    --period week                  Group by week (for throughput and workflow metrics)
     --period month                 Group by month (default)
+    --week-numbering iso           Weeks start on Monday with ISO week numbers (default)
+    --week-numbering us            Weeks start on Sunday; week 1 holds January 1
+    --period-labels iso            Label periods 2024-05 and 2024-W20 (default)
+    --period-labels friendly       Label periods May 2024 and Week 20, 2024 in
+                                  throughput and improvement tables
+
+ESTIMATE UNITS:
+    --unit points                  Estimates are story points (default)
+    --unit hours                   Estimates are hours; totals and labels use hours
+    --unit items                   Ignore estimates and count items
+
+STATISTICS (for metrics tables):
+    --stats LIST                   Comma-separated columns to show in statistical
+                                  tables: count, min, max, avg, median, p85, p95,
+                                  stddev (default: count,min,max,avg,median)
+    --histogram-buckets LIST       Upper bounds in days for the cycle time
+                                  histogram (default: 2,5,10,20 giving
+                                  0-2d, 3-5d, 6-10d, 11-20d, >20d)
+
+CATEGORIES:
+    --categories FILE              Classification rules, one per line as
+                                  "Category: field=value, field=value"; all
+                                  conditions must match and the first matching
+                                  rule wins, e.g. "KTLO: type=chore" or
+                                  "Support: epic-label=support". Fields: label,
+                                  epic-label, type, epic, team, project, state,
+                                  workflow, owner, priority, milestone,
+                                  product-area; "*" matches any value.
+                                  Unmatched items are "Uncategorized"
+
+STATE HISTORY:
+    --history FILE                 CSV of state transitions with the columns
+                                  item_id, from, to, timestamp (YYYY/MM/DD
+                                  HH:MM:SS). Flow metrics then use the real
+                                  time per state instead of the created,
+                                  started and completed dates; states named
+                                  like backlog, ready or blocked count as
+                                  waiting. Also gives cfd the real states
+
+FORECASTING (--metrics forecast):
+    --forecast-items N             When will N items be done? (default: the
+                                  items not yet completed)
+    --forecast-date DATE           How many items will be done by DATE
+                                  (YYYY-MM-DD)?
+    --simulations N                Monte Carlo runs per forecast (default: 10000)
+    --forecast-min-weeks N         Refuse to forecast from fewer weeks of
+                                  history (default: 4)
+    --seed N                       Seed of the simulations (default: 1); the
+                                  same seed and data give the same forecast,
+                                  and the seed is shown in the output header
+
+                                  History is the weekly throughput up to the
+                                  last completion by the end date (or today),
+                                  so quiet days since don't count as empty
+                                  weeks; narrow it with --start or --last
+
+ITERATIONS (--metrics commitment):
+    --iterations FILE              Iteration dates, one per line as
+                                  "START..END name", e.g. "2024-05-06..2024-05-17
+                                  Sprint 12"; names match the iteration column.
+                                  Work created before the start counts as
+                                  committed. Without the file, dates are
+                                  inferred from each iteration's items
+
+CAPACITY & CONTEXT:
+    --absences FILE                Team absences, one per line as
+                                  START[..END] PERCENT, e.g.
+                                  "2024-07-01..2024-07-31 50%%"; throughput and
+                                  improvement add capacity-adjusted trends so
+                                  vacation months aren't read as declines
+    --annotations FILE             Dated events, one per line as
+                                  "YYYY-MM-DD text" (e.g. "2024-05-06 New
+                                  hire"); marked as footnotes in throughput
+                                  and improvement trends
+
+AGE METRICS:
+    --holidays FILE                File with one holiday date (YYYY-MM-DD) per
+                                  line; weekends and these dates are excluded
+                                  from working-day ages
+    --age-sla LIST                 Per-state age thresholds as
+                                  state=warning:critical in days, e.g.
+                                  "In Progress=5:10,Review=2:4,*=10:20";
+                                  items are marked green/yellow/red and the
+                                  red count is reported ("*" = other states)
+    --wip-limits LIST              Items each team may have in a state at
+                                  once, as state=limit for every team or
+                                  team/state=limit for one team, e.g.
+                                  "In Progress=5,Payments/Review=2" (the team
+                                  ends at the first "/"; */Review/QA=3 limits
+                                  a state with a "/" for every team); teams
+                                  over a limit are flagged by --metrics age
+                                  (now), cfd (at the end of every period)
+                                  and digest (as a highlight)
+    --age-mode summary             Age statistics and oldest items per state
+                                  (default)
+    --age-mode aging-wip           Chart open items by state against the
+                                  P50/P70/P85/P95 cycle times of completed
+                                  items; items past P85 are at risk
 
 OUTPUT OPTIONS:
-    --output FILE                  Save report to file
+    --output FILE                  Save report to file; other runs can't
+                                  write it until it is saved
                                   (default: display in console)
+    --output-template TEMPLATE     Save to a path with {date} and {type}
+                                  expanded, e.g. "report-{date}-{type}.md"
+    --no-overwrite                 Fail instead of replacing an existing
+                                  output file, e.g. in scheduled runs
+    --append                       Add the run under a dated header to the
+                                  end of the output file instead of
+                                  replacing it, keeping a text or markdown
+                                  journal
+    --keep-last N                  Keep only the newest N files written by
+                                  --output-template for the same type
+    --keep-days N                  Remove files written by --output-template
+                                  more than N days ago
+    --sign                         Write a SHA-256 checksum (.sha256) next to
+                                  the output file
+    --minisign-key FILE            Also sign the output with minisign
+                                  (.minisig), with --sign
+    --format FORMAT                Output format: text (default), json with
+                                  structured results for scripts, dashboards
+                                  and the compare command, markdown or
+                                  html documents with tables to share, or
+                                  problems: data-quality findings as
+                                  file:line: message for editors (without
+                                  --type or --metrics), or svg: the
+                                  --metrics scatterplot as an image
+    --html-filters                 Add team and epic dropdowns and a date
+                                  chart to drag across to --format html
+                                  output, totalling the matching items by
+                                  team, epic and contributor in the browser
+    --charts-dir DIR               Save throughput, cumulative flow and cycle
+                                  time scatterplot charts as SVG images in
+                                  DIR, shown in --format html and markdown
+                                  output
+    --export items-json            Write one JSON object per item (JSON
+                                  Lines) with its lead, cycle, age and queue
+                                  times and flags, for data warehouses;
+                                  replaces reports and metrics (without
+                                  --type, --metrics or --format)
+    --baseline OLD.csv             Compare the --csv export with an earlier
+                                  export of the same board and list the
+                                  items added, removed, completed,
+                                  re-opened, re-estimated or moved between
+                                  epics and teams since; replaces reports
+                                  and metrics (without --type, --metrics or
+                                  a date range), e.g. for sprint reviews
+    --ascii                        Plain ASCII markers instead of emoji, for
+                                  screen readers and limited terminals (also
+                                  enabled by NO_COLOR, TERM=dumb or the
+                                  classic Windows console; --ascii=false
+                                  keeps emoji)
+    --no-pager                     Print console output longer than the
+                                  terminal in full; by default it is shown a
+                                  screen at a time (interactive mode offers
+                                  to save it to a file first)
+    --width N                      Maximum width of wide tables; less frequent
+                                  item types are combined into "Other"
+                                  (default: terminal width, no limit in files)
+    --data-quality                 Append a data-quality report listing rows
+                                  and values that could not be parsed
+    --warnings-file FILE           Write parser and filter warnings as a JSON
+                                  array for automation ("-" for stderr)
 
 CSV OPTIONS:
     --delimiter auto               Auto-detect delimiter (default)
     --delimiter comma              Comma-separated values
     --delimiter semicolon          Semicolon-separated values
     --delimiter tab                Tab-separated values
+    --column-map LIST              Rename export columns to the expected names,
+                                  e.g. "Story Points=estimate,Title=name"
+    --file-delimiter PATH=DELIM    Delimiter of one of several --csv files,
+                                  e.g. "exports/jira.csv=semicolon"
+    --file-column-map PATH:COL=col Column rename for one of several --csv
+                                  files, e.g. "exports/jira.csv:Summary=name"
+    --decimal-separator SEP        Decimal separator in estimates: auto (default,
+                                  accepts "2.5" and "2,5" but leaves out
+                                  "1,000" and "1.000", which could be either),
+                                  dot, comma (the other separator may only
+                                  group thousands, as in "1.000,5")
+    --date-format FORMAT           Format of the timestamps: shortcut (default,
+                                  2024/05/07 15:04:05), iso, jira (07/May/24
+                                  3:04 PM), eu (07.05.2024), us (05/07/2024),
+                                  or a Go layout such as "2006-01-02 15:04"
+    --truthy LIST                  Values treated as true in boolean columns
+                                  (default: true,t,1,yes,y,x,✓,✔,on,done)
+    --falsy LIST                   Values treated as false in boolean columns
+                                  (default: false,f,0,no,n,-,✗,✘,off)
+                                  Unrecognised values count as false and are
+                                  reported after loading
+    --estimate-map LIST            Point values for non-numeric estimates such
+                                  as t-shirt sizes, e.g. "XS=1,S=2,M=3,L=5,XL=8"
+                                  (unmapped values count as 0 and are reported)
+    --max-errors N                 Abort if more than N rows fail to parse
+                                  instead of reporting on partial data
+                                  (default: -1, no limit)
 
 OTHER OPTIONS:
     --filter-field FIELD           Date field to filter by:
                                   completed_at (default), created_at, started_at
+    --reopened POLICY              How to count reopened items (completed_at set
+                                  but is_completed false):
+                                  exclude (default), count-first-completion
+                                  (use completed_at), count-last (use the
+                                  last completion in the --history file)
     --help, -h                     Show this help
     --examples                     Show usage examples
     --version                      Show version information
     --interactive, -i              Run interactive mode
+    --answers FILE                 Run interactive mode with answers from FILE
+    --non-interactive              Never prompt (fail instead) and skip console
+                                  previews and tips; uses $OUTPUT when --output
+                                  is not given. For containers and pipelines
+    --timeout DURATION             Stop a run that takes longer than this,
+                                  e.g. 30s or 2m (default: no limit); Ctrl+C
+                                  also stops loading and generating promptly
+    --config FILE                  YAML or TOML file of named profiles, each a
+                                  set of options such as csv, type and last
+    --profile NAME                 Profile of --config to use (default: the
+                                  only one); options on the command line win
 
 CSV FILE FORMAT:
     Your CSV must include these columns:
@@ -135,12 +513,12 @@ GETTING STARTED:
 
 For more examples: %s --examples
 
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 }
 
 // showExamples displays practical usage examples
 func showExamples() {
-	fmt.Printf(`🚀 Kanban Reports - Usage Examples
+	fmt.Fprintf(terminal.Stdout(), `🚀 Kanban Reports - Usage Examples
 =================================
 
 BASIC REPORTS:
@@ -160,9 +538,18 @@ METRICS ANALYSIS:
     # Weekly throughput trends
     %s --csv kanban-data.csv --metrics throughput --period week --last 180
 
+    # When will the remaining work be done, and how much by the end of the quarter?
+    %s --csv kanban-data.csv --metrics forecast --last 90 --forecast-date 2024-09-30
+
+    # Spot outliers against the cycle time percentiles, as an image
+    %s --csv kanban-data.csv --metrics scatterplot --last 90 --format svg --output scatterplot.svg
+
     # Complete metrics analysis
     %s --csv kanban-data.csv --metrics all --last 90 --output full-analysis.txt
 
+    # Lead times with percentiles for service level expectations
+    %s --csv kanban-data.csv --metrics lead-time --stats count,median,p85,p95
+
 FILTERING EXAMPLES:
     # Exclude ad-hoc work to see planned work only
     %s --csv kanban-data.csv --type team --last 30 --ad-hoc exclude
@@ -173,6 +560,9 @@ FILTERING EXAMPLES:
     # Filter by creation date instead of completion date
     %s --csv kanban-data.csv --type contributor --last 30 --filter-field created_at
 
+    # Only one team's larger backend items
+    %s --csv kanban-data.csv --metrics lead-time --filter 'team == "Payments" && estimate >= 3 && has(labels, "backend")'
+
 ADVANCED WORKFLOWS:
     # Generate monthly reports for stakeholders
     %s --csv kanban-data.csv --type epic --last 30 --output monthly-epic-report.txt
@@ -197,8 +587,21 @@ AUTOMATION EXAMPLES:
     DATE=$(date +%%Y-%%m-%%d)
     %s --csv latest-export.csv --type team --last 7 --output "weekly-report-$DATE.txt"
     
+    # Same without the shell date, keeping last week's file if the job reruns
+    # and only the 12 newest reports
+    %s --csv latest-export.csv --type team --last 7 --output-template "weekly-{type}-{date}.txt" --no-overwrite --keep-last 12
+    
     # Monthly metrics dashboard
     %s --csv kanban-export.csv --metrics all --last 30 --output monthly-metrics.txt
+    
+    # Structured results for a dashboard, or to diff two runs with compare
+    %s --csv kanban-export.csv --metrics all --last 30 --format json --output metrics.json
+    
+    # Styled HTML page with tables to share with stakeholders
+    %s --csv kanban-export.csv --type team --metrics throughput --both --last 30 --format html --output report.html
+    
+    # Container or CI job: never prompts, saves to $OUTPUT
+    OUTPUT=/reports/weekly.txt %s --non-interactive --csv /data/export.csv --type team --last 7
 
 CSV FILE TIPS:
     • Export from Jira, Azure DevOps, Linear, or any kanban tool
@@ -222,11 +625,13 @@ COMMON WORKFLOWS:
 Need help? Run: %s --help
 
 `, 
-		// Provide all 24 arguments for the format placeholders
+		// Provide all 32 arguments for the format placeholders
 		os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], 
 		os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], 
 		os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], 
-		os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+		os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0],
+		os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0],
+		os.Args[0], os.Args[0])
 }
 
 // getGoVersion returns the Go version for version display
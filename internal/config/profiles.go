@@ -0,0 +1,492 @@
+package config
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Profile is a named set of command-line options from a config file, such as
+// the weekly team report with its CSV path, filters and output
+type Profile struct {
+	Name     string
+	Line     int
+	Settings []Setting
+}
+
+// Setting is one option of a profile, named like its command-line flag
+// without the dashes, e.g. csv or ad-hoc
+type Setting struct {
+	Key   string
+	Value string // Lists are joined with commas, as on the command line
+	Line  int
+}
+
+// Profiles are the profiles of a config file, in file order
+type Profiles []Profile
+
+// Find returns the profile with the given name
+func (p Profiles) Find(name string) (Profile, bool) {
+	for _, profile := range p {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return Profile{}, false
+}
+
+// Names returns the profile names, in file order
+func (p Profiles) Names() []string {
+	names := make([]string, len(p))
+	for i, profile := range p {
+		names[i] = profile.Name
+	}
+	return names
+}
+
+// commandLineOnly are the flags a profile can't set, because they choose the
+// profile or run something other than a report
+var commandLineOnly = map[string]bool{
+	"config": true, "profile": true,
+	"help": true, "h": true, "version": true, "examples": true,
+	"interactive": true, "i": true, "answers": true,
+}
+
+// modeOptions choose what a run generates. They combine into one choice, so
+// a mode given on the command line replaces all of the profile's.
+var modeOptions = map[string]bool{"type": true, "metrics": true, "both": true, "digest": true}
+
+// DefaultProfile is the profile of the saved config file that applies when
+// no --profile is given
+const DefaultProfile = "default"
+
+// DefaultConfigPath is where the setup saves the config file, e.g.
+// ~/.config/kanban-reports/config.yaml on Linux. It is read when no --config
+// is given.
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kanban-reports", "config.yaml"), nil
+}
+
+// savedProfile returns the config file and profile to use when --config isn't
+// given: the profile named with --profile, or the default profile, of the
+// saved config file. Without a saved file, or a default profile in it, no
+// profile applies.
+func savedProfile(profileName string) (string, string) {
+	path, err := DefaultConfigPath()
+	if err != nil {
+		return "", profileName
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", profileName
+	}
+	if profileName != "" {
+		return path, profileName
+	}
+
+	profiles, err := LoadProfiles(path)
+	if err != nil {
+		return path, "" // Reported when the profile is applied
+	}
+	if _, ok := profiles.Find(DefaultProfile); ok {
+		return path, DefaultProfile
+	}
+	return "", ""
+}
+
+// LoadProfiles reads the profiles of a YAML (.yaml, .yml) or TOML (.toml)
+// config file
+func LoadProfiles(path string) (Profiles, error) {
+	var parse func(io.Reader) (Profiles, error)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		parse = ParseYAMLProfiles
+	case ".toml":
+		parse = ParseTOMLProfiles
+	default:
+		return nil, fmt.Errorf("unsupported config file '%s' (expected a .yaml, .yml or .toml file)", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening config file: %w", err)
+	}
+	defer file.Close()
+
+	profiles, err := parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file '%s': %w", path, err)
+	}
+	return profiles, nil
+}
+
+// SaveProfiles writes profiles to a YAML config file, creating its directory
+func SaveProfiles(path string, profiles Profiles) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(FormatYAMLProfiles(profiles)), 0644); err != nil {
+		return fmt.Errorf("error writing config file: %w", err)
+	}
+	return nil
+}
+
+// FormatYAMLProfiles writes profiles in the YAML form ParseYAMLProfiles reads
+func FormatYAMLProfiles(profiles Profiles) string {
+	var b strings.Builder
+	b.WriteString("# kanban-reports profiles; options are named like the command-line flags\n")
+	b.WriteString("profiles:\n")
+	for _, profile := range profiles {
+		fmt.Fprintf(&b, "  %s:\n", yamlValue(profile.Name))
+		for _, setting := range profile.Settings {
+			fmt.Fprintf(&b, "    %s: %s\n", setting.Key, yamlValue(setting.Value))
+		}
+	}
+	return b.String()
+}
+
+// yamlValue quotes a value that would otherwise read differently
+func yamlValue(value string) string {
+	if value == "" || value != strings.TrimSpace(value) || strings.ContainsAny(value, ":#[]{},'\"\\") || strings.HasPrefix(value, "-") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// ParseYAMLProfiles reads profiles from the YAML form of a config file:
+//
+//	profiles:
+//	  weekly-team:
+//	    csv: data/kanban.csv
+//	    type: [team, contributor]
+//	    last: 7
+//
+// Lists may also be written one "- value" per line below their key. Only this
+// layout is understood, not YAML in general.
+func ParseYAMLProfiles(r io.Reader) (Profiles, error) {
+	var profiles Profiles
+	var list *Setting // Setting whose "- value" lines are being read
+	inProfiles := false
+	profileIndent, settingIndent := -1, -1
+
+	// endList checks that a list started with "key:" got at least one value
+	endList := func() error {
+		if list != nil && list.Value == "" {
+			return fmt.Errorf("line %d: %s has no value", list.Line, list.Key)
+		}
+		if list != nil {
+			last := &profiles[len(profiles)-1]
+			last.Settings = append(last.Settings, *list)
+		}
+		list = nil
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		raw := stripComment(scanner.Text())
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(raw, "\t") {
+			return nil, fmt.Errorf("line %d: indent with spaces, not tabs", lineNumber)
+		}
+		indent := len(raw) - len(strings.TrimLeft(raw, " "))
+
+		if item, isItem := strings.CutPrefix(line, "-"); isItem && (item == "" || item[0] == ' ') {
+			if list == nil || indent <= settingIndent {
+				return nil, fmt.Errorf("line %d: unexpected list item %q", lineNumber, line)
+			}
+			value, err := parseValue(strings.TrimSpace(item))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			if list.Value != "" {
+				value = list.Value + "," + value
+			}
+			list.Value = value
+			continue
+		}
+		if err := endList(); err != nil {
+			return nil, err
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || (value != "" && value[0] != ' ') {
+			return nil, fmt.Errorf("line %d: expected key: value, got %q", lineNumber, line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if unquoted, err := parseValue(key); err == nil {
+			key = unquoted
+		}
+
+		switch {
+		case indent == 0:
+			if key != "profiles" || value != "" {
+				return nil, fmt.Errorf("line %d: unexpected %q (a config file holds profiles: with the profiles indented below)", lineNumber, line)
+			}
+			inProfiles = true
+		case !inProfiles:
+			return nil, fmt.Errorf("line %d: expected profiles: before %q", lineNumber, line)
+		case len(profiles) == 0 || indent <= profileIndent:
+			if profileIndent == -1 {
+				profileIndent = indent
+			}
+			if indent != profileIndent {
+				return nil, fmt.Errorf("line %d: profile %q is indented differently from the profiles before it", lineNumber, key)
+			}
+			if value != "" {
+				return nil, fmt.Errorf("line %d: expected the options of profile %q indented on the lines below it", lineNumber, key)
+			}
+			if _, exists := profiles.Find(key); exists {
+				return nil, fmt.Errorf("line %d: profile %q is defined twice", lineNumber, key)
+			}
+			profiles = append(profiles, Profile{Name: key, Line: lineNumber})
+			settingIndent = -1
+		default:
+			if settingIndent == -1 {
+				settingIndent = indent
+			}
+			if indent != settingIndent {
+				return nil, fmt.Errorf("line %d: %s is indented differently from the options before it", lineNumber, key)
+			}
+			current := &profiles[len(profiles)-1]
+			if err := checkDuplicate(*current, key, lineNumber); err != nil {
+				return nil, err
+			}
+			if value == "" {
+				list = &Setting{Key: key, Line: lineNumber}
+				continue
+			}
+			parsed, err := parseValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			current.Settings = append(current.Settings, Setting{key, parsed, lineNumber})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := endList(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// ParseTOMLProfiles reads profiles from the TOML form of a config file:
+//
+//	[profiles.weekly-team]
+//	csv = "data/kanban.csv"
+//	type = ["team", "contributor"]
+//	last = 7
+//
+// Only this layout is understood, not TOML in general.
+func ParseTOMLProfiles(r io.Reader) (Profiles, error) {
+	var profiles Profiles
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			table, ok := strings.CutSuffix(strings.TrimPrefix(line, "["), "]")
+			name, isProfile := strings.CutPrefix(strings.TrimSpace(table), "profiles.")
+			if !ok || !isProfile || name == "" {
+				return nil, fmt.Errorf("line %d: unexpected table %s (expected [profiles.NAME])", lineNumber, line)
+			}
+			name, err := parseValue(name)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			if _, exists := profiles.Find(name); exists {
+				return nil, fmt.Errorf("line %d: profile %q is defined twice", lineNumber, name)
+			}
+			profiles = append(profiles, Profile{Name: name, Line: lineNumber})
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value, got %q", lineNumber, line)
+		}
+		if len(profiles) == 0 {
+			return nil, fmt.Errorf("line %d: expected a [profiles.NAME] table before %q", lineNumber, line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		current := &profiles[len(profiles)-1]
+		if err := checkDuplicate(*current, key, lineNumber); err != nil {
+			return nil, err
+		}
+		parsed, err := parseValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if parsed == "" {
+			return nil, fmt.Errorf("line %d: %s has no value", lineNumber, key)
+		}
+		current.Settings = append(current.Settings, Setting{key, parsed, lineNumber})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// checkDuplicate rejects an option set twice in one profile
+func checkDuplicate(profile Profile, key string, lineNumber int) error {
+	for _, setting := range profile.Settings {
+		if setting.Key == key {
+			return fmt.Errorf("line %d: %s is set twice in profile %q (first on line %d)", lineNumber, key, profile.Name, setting.Line)
+		}
+	}
+	return nil
+}
+
+// parseValue reads a bare, quoted or list value; list items are joined with
+// commas, as the command line takes them
+func parseValue(value string) (string, error) {
+	if inner, ok := strings.CutPrefix(value, "["); ok {
+		inner, ok = strings.CutSuffix(inner, "]")
+		if !ok {
+			return "", fmt.Errorf("list %s is missing its closing ]", value)
+		}
+		var items []string
+		for _, item := range splitOutsideQuotes(inner, ',') {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			parsed, err := parseValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, parsed)
+		}
+		return strings.Join(items, ","), nil
+	}
+
+	switch {
+	case strings.HasPrefix(value, `"`):
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted value %s", value)
+		}
+		return unquoted, nil
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("invalid quoted value %s", value)
+		}
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	}
+	return value, nil
+}
+
+// stripComment removes a # comment that isn't inside quotes
+func stripComment(line string) string {
+	for i, part := range splitOutsideQuotes(line, '#') {
+		if i == 0 {
+			return part
+		}
+	}
+	return line
+}
+
+// splitOutsideQuotes splits text at each sep that isn't inside single or
+// double quotes
+func splitOutsideQuotes(text string, sep rune) []string {
+	var parts []string
+	var quote rune
+	escaped := false // The previous rune was a backslash in double quotes
+	start := 0
+	for i, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == sep:
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// applyProfile sets the options of the profile chosen with --config and
+// --profile on fs. Options given on the command line win over the profile.
+// It returns the name of the profile used, if any.
+func applyProfile(fs *flag.FlagSet, configPath, profileName string) (string, error) {
+	if configPath == "" {
+		if profileName != "" {
+			return "", fmt.Errorf("--profile needs --config with the file that defines the profile")
+		}
+		return "", nil
+	}
+
+	profiles, err := LoadProfiles(configPath)
+	if err != nil {
+		return "", err
+	}
+	profile, err := chooseProfile(profiles, configPath, profileName)
+	if err != nil {
+		return "", err
+	}
+
+	given := make(map[string]bool)
+	modeGiven := false
+	fs.Visit(func(f *flag.Flag) {
+		given[f.Name] = true
+		modeGiven = modeGiven || modeOptions[f.Name]
+	})
+
+	for _, setting := range profile.Settings {
+		if (given[setting.Key] || modeGiven && modeOptions[setting.Key]) && !commandLineOnly[setting.Key] {
+			continue
+		}
+		if err := setOption(fs, setting); err != nil {
+			return "", fmt.Errorf("%s:%d: %s in profile %q: %v", configPath, setting.Line, setting.Key, profile.Name, err)
+		}
+	}
+	return profile.Name, nil
+}
+
+// chooseProfile returns the named profile, or the only one when no name is given
+func chooseProfile(profiles Profiles, configPath, name string) (Profile, error) {
+	available := strings.Join(profiles.Names(), ", ")
+	switch {
+	case len(profiles) == 0:
+		return Profile{}, fmt.Errorf("%s defines no profiles", configPath)
+	case name == "" && len(profiles) == 1:
+		return profiles[0], nil
+	case name == "":
+		return Profile{}, fmt.Errorf("%s defines several profiles; choose one with --profile (available: %s)", configPath, available)
+	}
+
+	profile, ok := profiles.Find(name)
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown profile %q in %s (available: %s)", name, configPath, available)
+	}
+	return profile, nil
+}
@@ -0,0 +1,296 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hannasdev/kanban-reports/internal/metrics"
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// TestMain keeps a config file saved by the setup out of the tests
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "kanban-reports-config-*")
+	if err != nil {
+		panic(err)
+	}
+	for _, env := range []string{"XDG_CONFIG_HOME", "HOME", "AppData"} {
+		os.Setenv(env, dir)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// writeConfigFile writes a config file with the given name to a temporary directory
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestParseYAMLProfiles(t *testing.T) {
+	input := `# Team presets
+profiles:
+  weekly-team:
+    csv: data/kanban.csv
+    type: [team, contributor]
+    last: 7
+    ad-hoc: exclude  # planned work only
+
+  "quarterly":
+    metrics: all
+    only-metrics:
+      - lead-time
+      - 'throughput'
+    section-separator: "--- # ---"
+`
+	profiles, err := ParseYAMLProfiles(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseYAMLProfiles() error = %v", err)
+	}
+	if got := strings.Join(profiles.Names(), ","); got != "weekly-team,quarterly" {
+		t.Fatalf("profiles = %s, want weekly-team,quarterly", got)
+	}
+
+	weekly := profiles[0]
+	want := []Setting{
+		{"csv", "data/kanban.csv", 4},
+		{"type", "team,contributor", 5},
+		{"last", "7", 6},
+		{"ad-hoc", "exclude", 7},
+	}
+	if len(weekly.Settings) != len(want) {
+		t.Fatalf("weekly-team settings = %+v, want %+v", weekly.Settings, want)
+	}
+	for i, setting := range weekly.Settings {
+		if setting != want[i] {
+			t.Errorf("setting %d = %+v, want %+v", i, setting, want[i])
+		}
+	}
+
+	quarterly, ok := profiles.Find("quarterly")
+	if !ok || quarterly.Line != 9 || len(quarterly.Settings) != 3 {
+		t.Fatalf("quarterly = %+v, want 3 settings from line 9", quarterly)
+	}
+	if got := quarterly.Settings[1]; got.Value != "lead-time,throughput" || got.Line != 11 {
+		t.Errorf("only-metrics = %+v, want the list items joined", got)
+	}
+	if got := quarterly.Settings[2].Value; got != "--- # ---" {
+		t.Errorf("section-separator = %q, want the # inside quotes kept", got)
+	}
+}
+
+func TestParseTOMLProfiles(t *testing.T) {
+	input := `# Team presets
+[profiles.weekly-team]
+csv = "data/kanban.csv"
+type = ["team", "contributor"]
+last = 7
+hierarchy = true
+
+[profiles."release notes"]
+column-map = 'Story Points=estimate'
+`
+	profiles, err := ParseTOMLProfiles(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseTOMLProfiles() error = %v", err)
+	}
+	if got := strings.Join(profiles.Names(), ","); got != "weekly-team,release notes" {
+		t.Fatalf("profiles = %s, want weekly-team,release notes", got)
+	}
+	if got := profiles[0].Settings[1]; got != (Setting{"type", "team,contributor", 4}) {
+		t.Errorf("type = %+v, want the list joined with commas", got)
+	}
+	if got := profiles[1].Settings[0].Value; got != "Story Points=estimate" {
+		t.Errorf("column-map = %q, want Story Points=estimate", got)
+	}
+}
+
+func TestParseProfiles_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string) error
+		input string
+		want  string
+	}{
+		{"YAML without profiles", parseYAML, "csv: data.csv", "line 1: unexpected"},
+		{"YAML option outside a profile", parseYAML, "profiles:\n  weekly: data.csv", "expected the options of profile"},
+		{"YAML set twice", parseYAML, "profiles:\n  weekly:\n    last: 7\n    last: 30", "line 4: last is set twice in profile \"weekly\" (first on line 3)"},
+		{"YAML profile twice", parseYAML, "profiles:\n  weekly:\n    last: 7\n  weekly:\n    last: 30", "line 4: profile \"weekly\" is defined twice"},
+		{"YAML misaligned", parseYAML, "profiles:\n  weekly:\n    last: 7\n      ad-hoc: exclude", "line 4: ad-hoc is indented differently"},
+		{"YAML empty list", parseYAML, "profiles:\n  weekly:\n    type:\n    last: 7", "line 3: type has no value"},
+		{"YAML tabs", parseYAML, "profiles:\n\tweekly:", "line 2: indent with spaces"},
+		{"YAML unclosed quote", parseYAML, "profiles:\n  weekly:\n    csv: \"data.csv", "line 3: invalid quoted value"},
+		{"TOML other table", parseTOML, "[settings]\ncsv = \"data.csv\"", "line 1: unexpected table [settings]"},
+		{"TOML option outside a profile", parseTOML, "csv = \"data.csv\"", "line 1: expected a [profiles.NAME] table"},
+		{"TOML unclosed list", parseTOML, "[profiles.weekly]\ntype = [\"team\"", "line 2: list [\"team\" is missing its closing ]"},
+		{"TOML no value", parseTOML, "[profiles.weekly]\ncsv =", "line 2: csv has no value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.parse(tt.input); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatYAMLProfiles(t *testing.T) {
+	profiles := Profiles{
+		{Name: DefaultProfile, Settings: []Setting{
+			{Key: "csv", Value: "exports/my team.csv"},
+			{Key: "column-map", Value: "Issue key=id,Summary=name"},
+			{Key: "delimiter", Value: "auto"},
+			{Key: "section-separator", Value: "--- # ---"},
+		}},
+		{Name: "weekly team", Settings: []Setting{{Key: "last", Value: "7"}}},
+	}
+
+	parsed, err := ParseYAMLProfiles(strings.NewReader(FormatYAMLProfiles(profiles)))
+	if err != nil {
+		t.Fatalf("ParseYAMLProfiles() error = %v\n%s", err, FormatYAMLProfiles(profiles))
+	}
+	if got := strings.Join(parsed.Names(), ","); got != "default,weekly team" {
+		t.Fatalf("profiles = %s, want default,weekly team", got)
+	}
+	for i, setting := range parsed[0].Settings {
+		if want := profiles[0].Settings[i]; setting.Key != want.Key || setting.Value != want.Value {
+			t.Errorf("setting %d = %+v, want %+v", i, setting, want)
+		}
+	}
+}
+
+func TestSaveProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kanban-reports", "config.yaml")
+	profiles := Profiles{{Name: DefaultProfile, Settings: []Setting{{Key: "date-format", Value: "jira"}}}}
+	if err := SaveProfiles(path, profiles); err != nil {
+		t.Fatalf("SaveProfiles() error = %v", err)
+	}
+	loaded, err := LoadProfiles(path)
+	if err != nil || len(loaded) != 1 || loaded[0].Settings[0].Value != "jira" {
+		t.Errorf("LoadProfiles() = %+v, %v, want the saved profile", loaded, err)
+	}
+}
+
+func parseYAML(input string) error {
+	_, err := ParseYAMLProfiles(strings.NewReader(input))
+	return err
+}
+
+func parseTOML(input string) error {
+	_, err := ParseTOMLProfiles(strings.NewReader(input))
+	return err
+}
+
+func TestLoadProfiles_Errors(t *testing.T) {
+	if _, err := LoadProfiles(writeConfigFile(t, "kanban.json", "{}")); err == nil || !strings.Contains(err.Error(), "expected a .yaml, .yml or .toml file") {
+		t.Errorf("LoadProfiles() error = %v, want unsupported file", err)
+	}
+	if _, err := LoadProfiles("/nonexistent/kanban.yaml"); err == nil {
+		t.Error("LoadProfiles() should fail for a missing file")
+	}
+}
+
+func TestParseArgs_Profile(t *testing.T) {
+	csvPath := createTestCSVFile(t)
+	configPath := writeConfigFile(t, "kanban.yaml", `profiles:
+  weekly-team:
+    csv: `+csvPath+`
+    type: [team, contributor]
+    last: 7
+    ad-hoc: exclude
+  unknown-option:
+    colour: red
+  command-line-only:
+    interactive: true
+  invalid-value:
+    last: seven
+`)
+
+	cfg, err := ParseArgs([]string{"--config", configPath, "--profile", "weekly-team", "--last", "30"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if cfg.CSVPath != csvPath || len(cfg.ReportTypes) != 2 || cfg.AdHocFilter != types.AdHocFilterExclude {
+		t.Errorf("ParseArgs() = %+v, want the options of weekly-team", cfg)
+	}
+	if cfg.LastNDays != 30 {
+		t.Errorf("LastNDays = %d, want 30 from the command line over the profile's 7", cfg.LastNDays)
+	}
+	if cfg.Profile != "weekly-team" || cfg.ConfigPath != configPath {
+		t.Errorf("Profile = %q from %q, want weekly-team from %q", cfg.Profile, cfg.ConfigPath, configPath)
+	}
+
+	// A mode on the command line replaces the profile's, rather than adding to it
+	cfg, err = ParseArgs([]string{"--config", configPath, "--profile", "weekly-team", "--metrics", "lead-time"})
+	if err != nil {
+		t.Fatalf("ParseArgs() with --metrics error = %v", err)
+	}
+	if cfg.MetricsType != metrics.MetricsTypeLeadTime || len(cfg.ReportTypes) != 0 || cfg.Both {
+		t.Errorf("ParseArgs() = metrics %q, types %v, want only lead-time from the command line", cfg.MetricsType, cfg.ReportTypes)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"No profile chosen", []string{"--config", configPath}, "defines several profiles; choose one with --profile (available: weekly-team, unknown-option, command-line-only, invalid-value)"},
+		{"Unknown profile", []string{"--config", configPath, "--profile", "monthly"}, "unknown profile \"monthly\""},
+		{"Unknown option", []string{"--config", configPath, "--profile", "unknown-option"}, ":8: colour in profile \"unknown-option\": unknown option"},
+		{"Command-line only", []string{"--config", configPath, "--profile", "command-line-only"}, ":10: interactive in profile \"command-line-only\": can only be given on the command line"},
+		{"Invalid value", []string{"--config", configPath, "--profile", "invalid-value"}, ":12: last in profile \"invalid-value\": invalid value \"seven\" (expected a whole number)"},
+		{"Profile without config", []string{"--csv", csvPath, "--profile", "weekly-team"}, "--profile needs --config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseArgs(tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseArgs() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	// A file with one profile needs no --profile
+	single := writeConfigFile(t, "single.toml", "[profiles.team]\ncsv = \""+csvPath+"\"\ntype = \"team\"\n")
+	if cfg, err := ParseArgs([]string{"--config", single}); err != nil || cfg.Profile != "team" {
+		t.Errorf("ParseArgs() = %+v, %v, want the only profile", cfg, err)
+	}
+}
+
+func TestParseFlags_SavedConfig(t *testing.T) {
+	origArgs, origCommandLine := os.Args, flag.CommandLine
+	defer func() { os.Args, flag.CommandLine = origArgs, origCommandLine }()
+
+	path, err := DefaultConfigPath()
+	if err != nil {
+		t.Fatalf("DefaultConfigPath() error = %v", err)
+	}
+	csvPath := createTestCSVFile(t)
+	profiles := Profiles{{Name: DefaultProfile, Settings: []Setting{{Key: "csv", Value: csvPath}, {Key: "date-format", Value: "iso"}}}}
+	if err := SaveProfiles(path, profiles); err != nil {
+		t.Fatalf("SaveProfiles() error = %v", err)
+	}
+	defer os.Remove(path)
+
+	flag.CommandLine = flag.NewFlagSet("cmd", flag.ContinueOnError)
+	os.Args = []string{"cmd", "--type", "team"}
+	cfg, err := ParseFlags()
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if cfg.CSVPath != csvPath || cfg.Profile != DefaultProfile || cfg.ConfigPath != path {
+		t.Errorf("ParseFlags() = %+v, want the default profile of the saved config", cfg)
+	}
+	if cfg.DateFormat.String() != models.DateFormatPresets["iso"].String() {
+		t.Errorf("DateFormat = %s, want the iso preset from the saved config", cfg.DateFormat)
+	}
+}
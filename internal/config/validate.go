@@ -0,0 +1,216 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/metrics"
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/reports"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// Problem is a mistake in a profile, pointing at the line and option to fix
+type Problem struct {
+	Line    int
+	Key     string // Empty when the options don't work together
+	Message string
+}
+
+// optionChecks validate the value of single options the way a run parses
+// them, so the errors list the allowed values. Options without a check only
+// need to parse as their flag's type.
+var optionChecks = map[string]func(value string) error{
+	"csv":                func(v string) error { return setCSVPath(&Config{}, v) },
+	"baseline":           func(v string) error { return setBaseline(&Config{Format: types.FormatText}, v) },
+	"type":               func(v string) error { return setReportAndMetricsTypes(&Config{}, v, "", false) },
+	"metrics":            func(v string) error { return setReportAndMetricsTypes(&Config{}, "", v, false) },
+	"split-by":           func(v string) error { return setSplitBy(&Config{}, v) },
+	"group-by":           func(v string) error { _, err := reports.ParseGroupField(v); return err },
+	"rows":               func(v string) error { _, err := reports.ParseGroupField(v); return err },
+	"cols":               func(v string) error { _, err := reports.ParseGroupField(v); return err },
+	"allocate-by":        func(v string) error { return setAllocateBy(&Config{ReportTypes: []reports.ReportType{reports.ReportTypeAllocation}}, v) },
+	"top":                checkInt(func(n int) error { return setDetail(&Config{ReportTypes: []reports.ReportType{reports.ReportTypeContributor}}, true, n) }),
+	"only-metrics":       checkMetricsSections,
+	"exclude-metrics":    checkMetricsSections,
+	"section-order":      checkMetricsSections,
+	"period":             func(v string) error { return setPeriodType(&Config{}, v) },
+	"week-numbering":     func(v string) error { return setWeekNumbering(&Config{}, v) },
+	"period-labels":      func(v string) error { return setPeriodLabels(&Config{}, v) },
+	"unit":               func(v string) error { return setUnit(&Config{}, v) },
+	"stats":              func(v string) error { return setStats(&Config{}, v) },
+	"histogram-buckets":  func(v string) error { return setHistogramBuckets(&Config{}, v) },
+	"holidays":           func(v string) error { return setHolidays(&Config{}, v) },
+	"absences":           func(v string) error { return setAbsences(&Config{}, v) },
+	"annotations":        func(v string) error { return setAnnotations(&Config{}, v) },
+	"iterations":         func(v string) error { return setIterations(&Config{}, v) },
+	"categories":         func(v string) error { return setCategories(&Config{}, v) },
+	"history":            func(v string) error { return setHistory(&Config{}, v) },
+	"digest-settings":    func(v string) error { return setDigestSettings(&Config{}, v) },
+	"simulations":        checkInt(func(n int) error { return setForecast(&Config{}, n, 0, "") }),
+	"forecast-items":     checkInt(func(n int) error { return setForecast(&Config{}, DefaultSimulations, n, "") }),
+	"forecast-date":      func(v string) error { return setForecast(&Config{}, DefaultSimulations, 0, v) },
+	"forecast-min-weeks": checkInt(func(n int) error { return setForecastMinWeeks(&Config{}, n) }),
+	"age-sla":            func(v string) error { return setAgeThresholds(&Config{}, v) },
+	"wip-limits":         func(v string) error { return setWIPLimits(&Config{}, v) },
+	"age-mode":           func(v string) error { return setAgeMode(&Config{}, v) },
+	"start":              checkDate,
+	"end":                checkDate,
+	"last":               checkInt(func(n int) error { return setDateRange(&Config{}, "", "", n, "") }),
+	"range":              func(v string) error { _, err := types.ParseDateRangePreset(v); return err },
+	"format":             func(v string) error { return setFormat(&Config{}, v) },
+	"export":             func(v string) error { return setExport(&Config{}, v, DefaultFormat) },
+	"timeout":            func(v string) error { return setTimeout(&Config{}, v) },
+	"width":              checkInt(func(n int) error { return setWidth(&Config{}, n) }),
+	"max-errors":         checkInt(func(n int) error { return setMaxErrors(&Config{}, n) }),
+	"delimiter":          func(v string) error { _, err := models.ParseDelimiter(v); return err },
+	"column-map":         func(v string) error { return setColumnMap(&Config{}, v) },
+	"file-delimiter":     func(v string) error { _, err := parseFileDelimiters(v); return err },
+	"file-column-map":    func(v string) error { _, err := parseFileColumnMaps(v); return err },
+	"decimal-separator":  func(v string) error { return setDecimalSeparator(&Config{}, v) },
+	"date-format":        func(v string) error { return setDateFormat(&Config{}, v) },
+	"truthy":             func(v string) error { return setBoolTokens(&Config{}, v, "") },
+	"falsy":              func(v string) error { return setBoolTokens(&Config{}, "", v) },
+	"estimate-map":       func(v string) error { return setEstimateMapping(&Config{}, v) },
+	"ad-hoc":             func(v string) error { return setFilterOptions(&Config{}, v, DefaultFilterField) },
+	"ad-hoc-rules":       func(v string) error { return setAdHocRules(&Config{}, v) },
+	"reopened":           func(v string) error { return setReopenedPolicy(&Config{}, v) },
+	"filter":             func(v string) error { return setFilter(&Config{}, v) },
+	"filter-field":       func(v string) error { return setFilterOptions(&Config{}, DefaultAdHocFilter, v) },
+	"product-area-mode":  func(v string) error { return setProductAreaMode(&Config{}, v) },
+	"min-epics":          checkInt(func(n int) error { return setMinEpics(&Config{}, n) }),
+}
+
+// checkMetricsSections checks a list of --metrics all sections
+func checkMetricsSections(value string) error {
+	_, err := metrics.ParseMetricsTypes(value)
+	return err
+}
+
+// checkDate checks a YYYY-MM-DD date
+func checkDate(value string) error {
+	if _, err := time.Parse(DateFormat, value); err != nil {
+		return fmt.Errorf("invalid date: %s (expected YYYY-MM-DD)", value)
+	}
+	return nil
+}
+
+// checkInt checks a number option once its flag has parsed it
+func checkInt(check func(int) error) func(string) error {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil
+		}
+		return check(n)
+	}
+}
+
+// ValidateProfile checks the options of a profile without running anything:
+// each option on its own, then, if those are all valid, whether they work
+// together. Files the options name must exist and parse. The default profile
+// is only checked option by option, as every run adds its own options to it.
+func ValidateProfile(profile Profile) []Problem {
+	fs := flag.NewFlagSet("kanban-reports", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	flags := defineFlags(fs)
+
+	var problems []Problem
+	for _, setting := range profile.Settings {
+		if err := setOption(fs, setting); err != nil {
+			problems = append(problems, Problem{setting.Line, setting.Key, err.Error()})
+			continue
+		}
+		if check := optionChecks[setting.Key]; check != nil {
+			if err := check(setting.Value); err != nil {
+				problems = append(problems, Problem{setting.Line, setting.Key, oneLine(err)})
+			}
+		}
+	}
+	if len(problems) > 0 || profile.Name == DefaultProfile {
+		return problems
+	}
+
+	if _, err := buildConfig(flags); err != nil {
+		return []Problem{{Line: profile.Line, Message: oneLine(err)}}
+	}
+	return nil
+}
+
+// setOption sets one profile option on fs, explaining options that don't
+// exist or can't be set from a profile
+func setOption(fs *flag.FlagSet, setting Setting) error {
+	if commandLineOnly[setting.Key] {
+		return fmt.Errorf("can only be given on the command line, not in a profile")
+	}
+	f := fs.Lookup(setting.Key)
+	if f == nil {
+		if suggestion := closestOption(fs, setting.Key); suggestion != "" {
+			return fmt.Errorf("unknown option (did you mean %s?)", suggestion)
+		}
+		return fmt.Errorf("unknown option (options are named like the command-line flags, e.g. csv or ad-hoc)")
+	}
+	if err := fs.Set(setting.Key, setting.Value); err != nil {
+		if getter, ok := f.Value.(flag.Getter); ok {
+			switch getter.Get().(type) {
+			case bool:
+				return fmt.Errorf("invalid value %q (expected true or false)", setting.Value)
+			case int:
+				return fmt.Errorf("invalid value %q (expected a whole number)", setting.Value)
+			}
+		}
+		return fmt.Errorf("invalid value %q: %v", setting.Value, err)
+	}
+	return nil
+}
+
+// closestOption returns the option most like an unknown one, e.g. a typo, or
+// "" if none is close
+func closestOption(fs *flag.FlagSet, key string) string {
+	best, bestDistance := "", 3
+	fs.VisitAll(func(f *flag.Flag) {
+		if commandLineOnly[f.Name] || len(f.Name) < 3 {
+			return
+		}
+		if distance := editDistance(key, f.Name); distance < bestDistance {
+			best, bestDistance = f.Name, distance
+		}
+	})
+	return best
+}
+
+// editDistance is the number of single-character edits between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// oneLine joins the lines of a multi-line error message
+func oneLine(err error) string {
+	var lines []string
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateProfile(t *testing.T) {
+	csvPath := createTestCSVFile(t)
+	setting := func(key, value string, line int) Setting { return Setting{key, value, line} }
+
+	tests := []struct {
+		name     string
+		profile  string
+		settings []Setting
+		want     []Problem
+	}{
+		{
+			name:     "Valid",
+			settings: []Setting{setting("csv", csvPath, 2), setting("metrics", "all", 3), setting("only-metrics", "lead-time,throughput", 4)},
+		},
+		{
+			name: "Wrong options",
+			settings: []Setting{
+				setting("csv", csvPath, 2),
+				setting("type", "team", 3),
+				setting("ad-hoc", "sometimes", 4),
+				setting("colum-map", "Story Points=estimate", 5),
+				setting("hierarchy", "maybe", 6),
+				setting("last", "seven", 7),
+				setting("answers", "answers.txt", 8),
+				setting("frobnicate", "yes", 9),
+			},
+			want: []Problem{
+				{4, "ad-hoc", "invalid ad-hoc filter type: sometimes (must be one of: include, exclude, only)"},
+				{5, "colum-map", "unknown option (did you mean column-map?)"},
+				{6, "hierarchy", `invalid value "maybe" (expected true or false)`},
+				{7, "last", `invalid value "seven" (expected a whole number)`},
+				{8, "answers", "can only be given on the command line, not in a profile"},
+				{9, "frobnicate", "unknown option (options are named like the command-line flags, e.g. csv or ad-hoc)"},
+			},
+		},
+		{
+			name:     "Thresholds and column map",
+			settings: []Setting{setting("csv", csvPath, 2), setting("type", "team", 3), setting("age-sla", "In Progress=10:5", 4), setting("column-map", "Story Points", 5)},
+			want: []Problem{
+				{4, "age-sla", "invalid age threshold"},
+				{5, "column-map", "Story Points"},
+			},
+		},
+		{
+			name:     "Options that don't work together",
+			settings: []Setting{setting("csv", csvPath, 2), setting("type", "team", 3), setting("metrics", "lead-time", 4)},
+			want:     []Problem{{1, "", "--type and --metrics cannot be used together"}},
+		},
+		{
+			name:     "Default profile without a report type",
+			profile:  DefaultProfile,
+			settings: []Setting{setting("date-format", "jira", 2), setting("delimiter", "pipe", 3)},
+			want:     []Problem{{3, "delimiter", "invalid delimiter"}},
+		},
+		{
+			name:     "Multi-line errors",
+			settings: []Setting{setting("csv", csvPath, 2), setting("metrics", "speed", 3)},
+			want:     []Problem{{3, "metrics", "speed Available metrics types: lead-time"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := tt.profile
+			if name == "" {
+				name = "weekly"
+			}
+			problems := ValidateProfile(Profile{Name: name, Line: 1, Settings: tt.settings})
+			if len(problems) != len(tt.want) {
+				t.Fatalf("ValidateProfile() = %+v, want %d problems", problems, len(tt.want))
+			}
+			for i, problem := range problems {
+				want := tt.want[i]
+				if problem.Line != want.Line || problem.Key != want.Key || !strings.Contains(problem.Message, want.Message) {
+					t.Errorf("problem %d = %+v, want %+v", i, problem, want)
+				}
+				if strings.Contains(problem.Message, "\n") {
+					t.Errorf("problem %d message %q should be on one line", i, problem.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"column-map", "column-map", 0},
+		{"colum-map", "column-map", 1},
+		{"ad_hoc", "ad-hoc", 1},
+		{"", "csv", 3},
+		{"period", "perido", 2},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
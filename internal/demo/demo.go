@@ -0,0 +1,250 @@
+// Package demo holds a sample dataset and a tour of the reports and metrics
+// run on it, so the tool can be tried before exporting real data
+package demo
+
+import (
+	"bytes"
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+//go:embed sample.csv categories.txt
+var files embed.FS
+
+// File names of the dataset and its classification rules
+const (
+	DatasetFile    = "sample.csv"
+	CategoriesFile = "categories.txt"
+)
+
+// Step is one stop of the tour: a report or metrics run and what it shows
+type Step struct {
+	Title       string
+	Explanation string
+	Args        []string // Options of the run, without --csv
+	Categories  bool     // Whether the run needs the classification rules
+}
+
+// Command returns the options of the step's run on the dataset written to dir
+func (s Step) Command(dir string) []string {
+	args := []string{"--csv", filepath.Join(dir, DatasetFile)}
+	if s.Categories {
+		args = append(args, "--categories", filepath.Join(dir, CategoriesFile))
+	}
+	return append(args, s.Args...)
+}
+
+// Steps walk through each report type, then each kind of metrics
+var Steps = []Step{
+	{
+		Title:       "Story points by contributor",
+		Explanation: "Completed story points and items per person. Items with several owners count for each of them.",
+		Args:        []string{"--type", "contributor"},
+	},
+	{
+		Title:       "Story points by epic",
+		Explanation: "Where the delivered work went, one line per epic.",
+		Args:        []string{"--type", "epic"},
+	},
+	{
+		Title:       "Story points by product area",
+		Explanation: "Delivered work per product area. Items in several areas (\"Frontend;Mobile\") split their points between them.",
+		Args:        []string{"--type", "product-area"},
+	},
+	{
+		Title:       "Story points by team",
+		Explanation: "Delivered work per team, the usual start of a team review.",
+		Args:        []string{"--type", "team"},
+	},
+	{
+		Title:       "Story points by category",
+		Explanation: "Items sorted into categories by rules such as \"Unplanned: label=ad-hoc-request\", given with --categories.",
+		Args:        []string{"--type", "category"},
+		Categories:  true,
+	},
+	{
+		Title:       "Open work by owner",
+		Explanation: "What each person has in progress right now and how old their oldest item is.",
+		Args:        []string{"--type", "workload"},
+	},
+	{
+		Title:       "Cross-epic contention",
+		Explanation: "People spread over several epics at once and the epics that share the most people.",
+		Args:        []string{"--type", "contention"},
+	},
+	{
+		Title:       "Contributors within each epic",
+		Explanation: "Each epic broken down by who delivered it.",
+		Args:        []string{"--type", "epic-contributor"},
+	},
+	{
+		Title:       "Team throughput by month",
+		Explanation: "Story points per team and month; in --format html this is a heatmap.",
+		Args:        []string{"--type", "team-month"},
+	},
+	{
+		Title:       "Lead time",
+		Explanation: "How long items take from creation to completion, and from start to completion (cycle time).",
+		Args:        []string{"--metrics", "lead-time"},
+	},
+	{
+		Title:       "Throughput",
+		Explanation: "Items and points completed per period; choose the period with --period.",
+		Args:        []string{"--metrics", "throughput", "--period", "week"},
+	},
+	{
+		Title:       "Flow efficiency",
+		Explanation: "The share of an item's lead time spent actively worked on rather than waiting.",
+		Args:        []string{"--metrics", "flow"},
+	},
+	{
+		Title:       "Estimation accuracy",
+		Explanation: "How well estimates predict the time items take, per estimate size.",
+		Args:        []string{"--metrics", "estimation"},
+	},
+	{
+		Title:       "Aging work in progress",
+		Explanation: "How old the open items are against the cycle times of completed ones; items past P85 are the ones to look at first.",
+		Args:        []string{"--metrics", "age", "--age-mode", "aging-wip"},
+	},
+	{
+		Title:       "Improvement trends",
+		Explanation: "Lead time and throughput over time, to see whether changes to the process paid off.",
+		Args:        []string{"--metrics", "improvement"},
+	},
+	{
+		Title:       "Workflow comparison",
+		Explanation: "Kanban and Scrum boards side by side.",
+		Args:        []string{"--metrics", "workflow"},
+	},
+	{
+		Title:       "Team benchmark",
+		Explanation: "Teams compared on the same metrics; --split-by groups by another field.",
+		Args:        []string{"--metrics", "benchmark"},
+	},
+	{
+		Title:       "Time in review",
+		Explanation: "How long items wait in review or QA before they are done.",
+		Args:        []string{"--metrics", "review"},
+	},
+	{
+		Title:       "Cumulative flow",
+		Explanation: "Items per state over time; widening bands show where work piles up.",
+		Args:        []string{"--metrics", "cfd"},
+	},
+	{
+		Title:       "Lead time by priority",
+		Explanation: "Whether high-priority items really move faster.",
+		Args:        []string{"--metrics", "priority"},
+	},
+	{
+		Title:       "Weekly digest",
+		Explanation: "A few lines on the past week, for a stand-up or a chat channel.",
+		Args:        []string{"--metrics", "digest"},
+	},
+	{
+		Title:       "Completions by weekday",
+		Explanation: "On which days of the week work gets finished.",
+		Args:        []string{"--metrics", "weekday"},
+	},
+	{
+		Title:       "Forecast",
+		Explanation: "A Monte Carlo simulation of past throughput: how many items are likely done in the coming weeks.",
+		Args:        []string{"--metrics", "forecast"},
+	},
+	{
+		Title:       "Iteration commitment",
+		Explanation: "Items planned into each iteration against those completed in it.",
+		Args:        []string{"--metrics", "commitment"},
+	},
+}
+
+// timestampColumns are the dataset's columns that hold dates
+var timestampColumns = map[string]bool{
+	"completed_at": true,
+	"created_at":   true,
+	"started_at":   true,
+	"moved_at":     true,
+	"updated_at":   true,
+}
+
+// WriteFiles writes the dataset and its classification rules to dir. The
+// dates are moved forward by whole weeks so the latest falls in the week
+// before now, keeping the data recent and its weekdays unchanged.
+func WriteFiles(dir string, now time.Time) error {
+	dataset, err := files.ReadFile(DatasetFile)
+	if err != nil {
+		return err
+	}
+	dataset, err = shiftDates(dataset, now)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, DatasetFile), dataset, 0644); err != nil {
+		return fmt.Errorf("error writing the demo dataset: %w", err)
+	}
+
+	categories, err := files.ReadFile(CategoriesFile)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, CategoriesFile), categories, 0644); err != nil {
+		return fmt.Errorf("error writing the demo categories: %w", err)
+	}
+	return nil
+}
+
+// shiftDates moves the timestamps of a dataset so the latest is less than a
+// week before now
+func shiftDates(dataset []byte, now time.Time) ([]byte, error) {
+	rows, err := csv.NewReader(bytes.NewReader(dataset)).ReadAll()
+	if err != nil || len(rows) == 0 {
+		return nil, fmt.Errorf("invalid demo dataset: %v", err)
+	}
+
+	var latest time.Time
+	forEachDate(rows, func(t time.Time) time.Time {
+		if t.After(latest) {
+			latest = t
+		}
+		return t
+	})
+
+	week := 7 * 24 * time.Hour
+	shift := now.Sub(latest) / week * week
+	if err := forEachDate(rows, func(t time.Time) time.Time { return t.Add(shift) }); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	writer := csv.NewWriter(&out)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// forEachDate replaces each timestamp of rows with the result of change
+func forEachDate(rows [][]string, change func(time.Time) time.Time) error {
+	header := rows[0]
+	for _, row := range rows[1:] {
+		for i, value := range row {
+			if i >= len(header) || !timestampColumns[strings.TrimSpace(header[i])] || value == "" {
+				continue
+			}
+			t, err := models.DefaultDateFormat.Parse(value)
+			if err != nil {
+				return fmt.Errorf("invalid demo dataset: %w", err)
+			}
+			row[i] = change(t).Format(models.DefaultDateFormat[0])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package demo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/config"
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestShiftDates(t *testing.T) {
+	dataset := []byte("id,name,created_at,completed_at\n" +
+		"1,First,2024/05/01 09:00:00,2024/05/07 10:30:00\n" +
+		"2,Second,2024/05/20 09:00:00,\n")
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	shifted, err := shiftDates(dataset, now)
+	if err != nil {
+		t.Fatalf("shiftDates() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(shifted)), "\n")
+	if len(lines) != 3 || lines[0] != "id,name,created_at,completed_at" {
+		t.Fatalf("shiftDates() = %q, want the header and both rows", shifted)
+	}
+	if !strings.HasSuffix(lines[2], ",") {
+		t.Errorf("row 2 = %q, want the empty completed_at kept", lines[2])
+	}
+
+	latest, err := models.DefaultDateFormat.Parse(strings.Split(lines[2], ",")[2])
+	if err != nil {
+		t.Fatalf("shifted date: %v", err)
+	}
+	if latest.After(now) || now.Sub(latest) >= 7*24*time.Hour {
+		t.Errorf("latest date = %v, want within the week before %v", latest, now)
+	}
+	if latest.Weekday() != time.Monday {
+		t.Errorf("latest date is a %s, want the Monday it was", latest.Weekday())
+	}
+}
+
+func TestSteps(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteFiles(dir, time.Now()); err != nil {
+		t.Fatalf("WriteFiles() error = %v", err)
+	}
+	for _, name := range []string{DatasetFile, CategoriesFile} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("WriteFiles() didn't write %s: %v", name, err)
+		}
+	}
+
+	for _, step := range Steps {
+		if _, err := config.ParseArgs(step.Command(dir)); err != nil {
+			t.Errorf("step %q: ParseArgs() error = %v", step.Title, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package forecast
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Levels are the confidence levels, in percent, that forecasts are reported at
+var Levels = []int{50, 70, 85, 95}
+
+// IntervalConfidence is the share of runs, in percent, that the confidence
+// intervals of forecasts hold
+const IntervalConfidence = 90
+
+// MaxWeeks caps a simulated run that has not finished, so that long spells of
+// empty weeks in the history cannot keep a simulation going forever
+const MaxWeeks = 520
+
+// Simulation runs Monte Carlo trials that draw each future week's throughput
+// at random from the weekly throughput seen in the past
+type Simulation struct {
+	samples []int
+	runs    int
+	rng     *rand.Rand
+}
+
+// NewSimulation creates a simulation of runs trials over the weekly throughput
+// samples. The seed makes the results repeatable.
+func NewSimulation(samples []int, runs int, seed int64) (*Simulation, error) {
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("no weekly throughput history to forecast from")
+	}
+	total := 0
+	for _, sample := range samples {
+		if sample < 0 {
+			return nil, fmt.Errorf("invalid weekly throughput: %d", sample)
+		}
+		total += sample
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("no items were completed in the %d weeks of history", len(samples))
+	}
+	if runs < 1 {
+		return nil, fmt.Errorf("simulations must be 1 or more, got: %d", runs)
+	}
+	return &Simulation{samples: samples, runs: runs, rng: rand.New(rand.NewSource(seed))}, nil
+}
+
+// week draws the throughput of one simulated week
+func (s *Simulation) week() int {
+	return s.samples[s.rng.Intn(len(s.samples))]
+}
+
+// WeeksToComplete simulates how many weeks it takes to complete items,
+// returning one outcome per run in ascending order
+func (s *Simulation) WeeksToComplete(items int) []int {
+	outcomes := make([]int, s.runs)
+	for run := range outcomes {
+		done, weeks := 0, 0
+		for done < items && weeks < MaxWeeks {
+			done += s.week()
+			weeks++
+		}
+		outcomes[run] = weeks
+	}
+	sort.Ints(outcomes)
+	return outcomes
+}
+
+// ItemsInWeeks simulates how many items are completed in the given number of
+// weeks, returning one outcome per run in ascending order
+func (s *Simulation) ItemsInWeeks(weeks int) []int {
+	outcomes := make([]int, s.runs)
+	for run := range outcomes {
+		for week := 0; week < weeks; week++ {
+			outcomes[run] += s.week()
+		}
+	}
+	sort.Ints(outcomes)
+	return outcomes
+}
+
+// rank returns the index of the outcome that confidence percent of the runs
+// reach, in outcomes sorted from best to worst
+func rank(count, confidence int) int {
+	index := (confidence*count+99)/100 - 1
+	return max(0, min(count-1, index))
+}
+
+// WeeksAt returns the number of weeks within which confidence percent of the
+// runs finished, from the ascending outcomes of WeeksToComplete
+func WeeksAt(outcomes []int, confidence int) int {
+	if len(outcomes) == 0 {
+		return 0
+	}
+	return outcomes[rank(len(outcomes), confidence)]
+}
+
+// Interval returns the range that the middle confidence percent of the runs
+// fall in, from ascending outcomes, leaving out as many runs below as above
+func Interval(outcomes []int, confidence int) (low, high int) {
+	if len(outcomes) == 0 {
+		return 0, 0
+	}
+	tail := (100 - confidence) / 2
+	return outcomes[rank(len(outcomes), tail)], outcomes[rank(len(outcomes), 100-tail)]
+}
+
+// ItemsAt returns the number of items that confidence percent of the runs
+// completed at least, from the ascending outcomes of ItemsInWeeks
+func ItemsAt(outcomes []int, confidence int) int {
+	if len(outcomes) == 0 {
+		return 0
+	}
+	return outcomes[len(outcomes)-1-rank(len(outcomes), confidence)]
+}
@@ -0,0 +1,126 @@
+package forecast
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestNewSimulation_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []int
+		runs    int
+	}{
+		{"No history", nil, 100},
+		{"Only empty weeks", []int{0, 0, 0}, 100},
+		{"Negative sample", []int{2, -1}, 100},
+		{"No runs", []int{2, 3}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSimulation(tt.samples, tt.runs, 1); err == nil {
+				t.Error("NewSimulation() should fail")
+			}
+		})
+	}
+}
+
+func TestWeeksToComplete_ConstantThroughput(t *testing.T) {
+	sim, err := NewSimulation([]int{5, 5, 5}, 200, 1)
+	if err != nil {
+		t.Fatalf("NewSimulation() error = %v", err)
+	}
+
+	// Five items a week always needs four weeks for 20 items, and five for 21
+	for _, confidence := range Levels {
+		if got := WeeksAt(sim.WeeksToComplete(20), confidence); got != 4 {
+			t.Errorf("WeeksAt(20 items, %d%%) = %d, want 4", confidence, got)
+		}
+		if got := WeeksAt(sim.WeeksToComplete(21), confidence); got != 5 {
+			t.Errorf("WeeksAt(21 items, %d%%) = %d, want 5", confidence, got)
+		}
+	}
+}
+
+func TestItemsInWeeks_ConstantThroughput(t *testing.T) {
+	sim, err := NewSimulation([]int{3}, 50, 1)
+	if err != nil {
+		t.Fatalf("NewSimulation() error = %v", err)
+	}
+	for _, confidence := range Levels {
+		if got := ItemsAt(sim.ItemsInWeeks(6), confidence); got != 18 {
+			t.Errorf("ItemsAt(6 weeks, %d%%) = %d, want 18", confidence, got)
+		}
+	}
+}
+
+func TestSimulation_HigherConfidenceIsMoreCautious(t *testing.T) {
+	sim, err := NewSimulation([]int{0, 2, 4, 6, 8, 10}, 5000, 42)
+	if err != nil {
+		t.Fatalf("NewSimulation() error = %v", err)
+	}
+
+	weeks := sim.WeeksToComplete(50)
+	items := sim.ItemsInWeeks(8)
+	if !sort.IntsAreSorted(weeks) || !sort.IntsAreSorted(items) {
+		t.Fatal("outcomes should be sorted in ascending order")
+	}
+
+	for i := 1; i < len(Levels); i++ {
+		if WeeksAt(weeks, Levels[i]) < WeeksAt(weeks, Levels[i-1]) {
+			t.Errorf("weeks at %d%% should not be fewer than at %d%%", Levels[i], Levels[i-1])
+		}
+		if ItemsAt(items, Levels[i]) > ItemsAt(items, Levels[i-1]) {
+			t.Errorf("items at %d%% should not be more than at %d%%", Levels[i], Levels[i-1])
+		}
+	}
+
+	// Averaging 5 items a week, 8 weeks give about 40 items at 50%
+	if got := ItemsAt(items, 50); got < 34 || got > 46 {
+		t.Errorf("ItemsAt(8 weeks, 50%%) = %d, want about 40", got)
+	}
+}
+
+func TestSimulation_SameSeedSameResults(t *testing.T) {
+	first, _ := NewSimulation([]int{1, 4, 7}, 100, 7)
+	second, _ := NewSimulation([]int{1, 4, 7}, 100, 7)
+	a, b := first.WeeksToComplete(30), second.WeeksToComplete(30)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("runs with the same seed differ at %d: %d vs %d", i, a[i], b[i])
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	outcomes := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if got := WeeksAt(outcomes, 50); got != 5 {
+		t.Errorf("WeeksAt(50%%) = %d, want 5", got)
+	}
+	if got := WeeksAt(outcomes, 95); got != 10 {
+		t.Errorf("WeeksAt(95%%) = %d, want 10", got)
+	}
+	if got := ItemsAt(outcomes, 85); got != 2 {
+		t.Errorf("ItemsAt(85%%) = %d, want 2", got)
+	}
+	if got := WeeksAt(nil, 50); got != 0 {
+		t.Errorf("WeeksAt(no outcomes) = %d, want 0", got)
+	}
+}
+
+func TestInterval(t *testing.T) {
+	var outcomes []int
+	for i := 1; i <= 100; i++ {
+		outcomes = append(outcomes, i)
+	}
+	if low, high := Interval(outcomes, 90); low != 5 || high != 95 {
+		t.Errorf("Interval(90%%) = %d-%d, want 5-95", low, high)
+	}
+	if low, high := Interval([]int{4, 4, 4}, 90); low != 4 || high != 4 {
+		t.Errorf("Interval(constant) = %d-%d, want 4-4", low, high)
+	}
+	if low, high := Interval(nil, 90); low != 0 || high != 0 {
+		t.Errorf("Interval(no outcomes) = %d-%d, want 0-0", low, high)
+	}
+}
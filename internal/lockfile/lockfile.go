@@ -0,0 +1,74 @@
+// Package lockfile keeps two runs from writing the same output file at once,
+// such as a slow scheduled run and the next one
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Extension is added to the path of an output to name its lock file
+const Extension = ".lock"
+
+// ErrLocked is returned when another run holds the lock of an output
+var ErrLocked = errors.New("output is locked by another run")
+
+// Lock is a held lock file
+type Lock struct {
+	path string
+}
+
+// Acquire creates the lock file of the output at path, holding the ID of
+// this process. A lock left behind by a process that is no longer running,
+// e.g. one that failed or was killed, is taken over.
+func Acquire(path string) (*Lock, error) {
+	lockPath := path + Extension
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = fmt.Fprintf(file, "%d\n", os.Getpid())
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(lockPath)
+				return nil, fmt.Errorf("error writing lock file %s: %v", lockPath, err)
+			}
+			return &Lock{path: lockPath}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("error creating lock file %s: %v", lockPath, err)
+		}
+
+		content, err := os.ReadFile(lockPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("error reading lock file %s: %v", lockPath, err)
+		}
+		if err == nil {
+			pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+			if err != nil || pid <= 0 {
+				return nil, fmt.Errorf("%w: %s doesn't name a process; remove it if no run is writing %s", ErrLocked, lockPath, path)
+			}
+			if running(pid) {
+				return nil, fmt.Errorf("%w: process %d is writing %s; remove %s if that run has stopped", ErrLocked, pid, path, lockPath)
+			}
+			// The run that left the lock has stopped
+			if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("error removing stale lock file %s: %v", lockPath, err)
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: another run took %s first", ErrLocked, lockPath)
+}
+
+// Release removes the lock file. A lock file that can't be removed is taken
+// over by the next run once this process has exited; releasing a nil lock
+// does nothing.
+func (l *Lock) Release() {
+	if l != nil {
+		os.Remove(l.path)
+	}
+}
@@ -0,0 +1,65 @@
+package lockfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAcquire(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "report.txt")
+
+	lock, err := Acquire(output)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	content, err := os.ReadFile(output + Extension)
+	if err != nil || strings.TrimSpace(string(content)) != strconv.Itoa(os.Getpid()) {
+		t.Errorf("lock file = %q, %v; want this process ID", content, err)
+	}
+
+	// A second run is refused while this one holds the lock
+	_, err = Acquire(output)
+	if !errors.Is(err, ErrLocked) || !strings.Contains(err.Error(), "process "+strconv.Itoa(os.Getpid())+" is writing") {
+		t.Errorf("second Acquire() error = %v, want ErrLocked naming this process", err)
+	}
+
+	lock.Release()
+	if _, err := os.Stat(output + Extension); !os.IsNotExist(err) {
+		t.Errorf("Release() left the lock file: %v", err)
+	}
+	lock, err = Acquire(output)
+	if err != nil {
+		t.Fatalf("Acquire() after Release() error = %v", err)
+	}
+	lock.Release()
+
+	var none *Lock
+	none.Release()
+}
+
+func TestAcquire_LeftBehind(t *testing.T) {
+	dir := t.TempDir()
+
+	// Larger than any process ID, so the run that left the lock has stopped
+	stale := filepath.Join(dir, "stale.txt")
+	if err := os.WriteFile(stale+Extension, []byte("1073741824\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := Acquire(stale)
+	if err != nil {
+		t.Fatalf("Acquire() of a stale lock error = %v", err)
+	}
+	lock.Release()
+
+	garbled := filepath.Join(dir, "garbled.txt")
+	if err := os.WriteFile(garbled+Extension, []byte("not a process\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Acquire(garbled); !errors.Is(err, ErrLocked) || !strings.Contains(err.Error(), "doesn't name a process") {
+		t.Errorf("Acquire() of a garbled lock error = %v, want ErrLocked", err)
+	}
+}
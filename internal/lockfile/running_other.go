@@ -0,0 +1,17 @@
+//go:build !unix
+
+package lockfile
+
+import "os"
+
+// running reports whether a process with the ID exists. On Windows finding a
+// process opens it, which fails once it has exited; elsewhere the lock is
+// kept until it is removed by hand.
+func running(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	process.Release()
+	return true
+}
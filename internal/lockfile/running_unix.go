@@ -0,0 +1,11 @@
+//go:build unix
+
+package lockfile
+
+import "syscall"
+
+// running reports whether a process with the ID exists; signal 0 only checks
+func running(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
@@ -1,92 +1,87 @@
 package menu
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/config"
 	"github.com/hannasdev/kanban-reports/internal/metrics"
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/prompt"
 	"github.com/hannasdev/kanban-reports/internal/reports"
 	"github.com/hannasdev/kanban-reports/internal/validation"
+	"github.com/hannasdev/kanban-reports/pkg/terminal"
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
-// MenuInterface defines the interface for input/output operations
-type MenuInterface interface {
-	ReadInput(prompt string) (string, error)
-	Print(msg string)
-	Printf(format string, args ...interface{})
-	Println(msg string)
-}
-
 // Menu handles interactive menu functionality
 type Menu struct {
-	scanner *bufio.Scanner
+	prompt  *prompt.Prompter
 	writer  io.Writer
-	reader  io.Reader
+	preview Previewer
 }
 
+// Previewer generates the output of a configuration, for the preview shown
+// when choosing the output format
+type Previewer func(cfg *config.Config) (string, error)
+
+// previewLines is how many lines of the output the format preview shows
+const previewLines = 12
+
 // NewMenu creates a new interactive menu
 func NewMenu() *Menu {
-	return &Menu{
-		scanner: bufio.NewScanner(os.Stdin),
-		writer:  os.Stdout,
-		reader:  os.Stdin,
-	}
+	return NewMenuWithIO(os.Stdin, terminal.Stdout())
 }
 
 // NewMenuWithIO creates a new menu with custom input/output for testing
 func NewMenuWithIO(reader io.Reader, writer io.Writer) *Menu {
 	return &Menu{
-		scanner: bufio.NewScanner(reader),
-		writer:  writer,
-		reader:  reader,
+		prompt: prompt.New(reader, writer).WithCheck(HandleQuit),
+		writer: writer,
 	}
 }
 
-func (m *Menu) print(msg string) {
-	fmt.Fprint(m.writer, msg)
+// NewScriptedMenu creates a menu that reads its answers, one per line, from
+// answers and echoes each one so the output reads like an interactive session
+func NewScriptedMenu(answers io.Reader, writer io.Writer) *Menu {
+	m := NewMenuWithIO(answers, writer)
+	m.prompt.WithEcho(true)
+	return m
+}
+
+// WithPreview sets how the output format step previews the output; without
+// it the format is chosen without a preview
+func (m *Menu) WithPreview(preview Previewer) *Menu {
+	m.preview = preview
+	return m
 }
 
 // printf outputs formatted text to the configured writer
 func (m *Menu) printf(format string, args ...interface{}) {
-	fmt.Fprintf(m.writer, format, args...)
+	m.prompt.Printf(format, args...)
 }
 
 // println outputs a line to the configured writer
 func (m *Menu) println(msg string) {
-	fmt.Fprintln(m.writer, msg)
+	m.prompt.Println(msg)
 }
 
 // readInput reads input from user and checks for quit commands
 func (m *Menu) readInput(prompt string) (string, error) {
-	m.print(prompt)
-	if !m.scanner.Scan() {
-		return "", fmt.Errorf("failed to read input")
-	}
-	
-	input := m.scanner.Text()
-	
-	// Check for quit command
-	if err := HandleQuit(input); err != nil {
-		return "", err
-	}
-	
-	return strings.TrimSpace(input), nil
+	return m.prompt.Input(prompt)
 }
 
 // Run starts the interactive menu system
 func (m *Menu) Run() (*config.Config, error) {
 	m.println("🔄 Kanban Reports - Interactive Mode")
 	m.println("=====================================")
-	ShowQuitHelp()
+	ShowQuitHelp(m.writer)
 	
-	cfg := &config.Config{}
+	cfg := &config.Config{MaxErrors: config.DefaultMaxErrors, ASCII: terminal.ASCII()}
 	
 	// Step 1: Get CSV file path
 	csvPath, err := m.getCSVPath()
@@ -133,253 +128,222 @@ func (m *Menu) Run() (*config.Config, error) {
 		return nil, err
 	}
 	
+	// Step 8: Choose the output format, previewed with the settings above
+	if err := m.configureFormat(cfg); err != nil {
+		return nil, err
+	}
+	
 	return cfg, nil
 }
 
 func (m *Menu) getCSVPath() (string, error) {
-	m.println("\n📁 CSV File Selection")
-	m.println("--------------------")
+	m.prompt.Heading("📁 CSV File Selection")
 	
-	for {
-		path, err := m.readInput("Enter the path to your CSV file: ")
-		if err != nil {
-			// This already handles quit commands from readInput
-			return "", err
-		}
-		
-		if path == "" {
-			m.println("❌ Please enter a valid file path")
-			continue
+	path, err := m.prompt.Text("Enter the path to your CSV file: ", "", validateCSVPath)
+	if err != nil {
+		return "", err
+	}
+	path = validation.CleanPathInput(path)
+	
+	m.printf("✅ File validated: %s\n", path)
+	return path, nil
+}
+
+// validateCSVPath checks the CSV path, adding a hint on how to fix each problem
+func validateCSVPath(path string) error {
+	path = validation.CleanPathInput(path)
+	if path == "" {
+		return fmt.Errorf("Please enter a valid file path")
+	}
+	
+	err := validation.ValidateCSVPath(path)
+	if err == nil {
+		return nil
+	}
+	
+	csvErr, ok := err.(validation.CSVPathError)
+	if !ok {
+		return fmt.Errorf("Error: %v", err)
+	}
+	
+	switch csvErr.Type {
+	case "is_directory":
+		// Suggest CSV files in the directory
+		suggestions := validation.SuggestCSVFiles(path)
+		if len(suggestions) == 0 {
+			return fmt.Errorf("%s\n\n💡 Try: %s", csvErr.Message, filepath.Join(path, "your-file.csv"))
 		}
 		
-		// Perform comprehensive validation
-		if err := validation.ValidateCSVPath(path); err != nil {
-			csvErr, ok := err.(validation.CSVPathError)
-			if !ok {
-				m.printf("❌ Error: %v\n", err)
-				continue
-			}
-			
-			// Handle different error types with helpful suggestions
-			switch csvErr.Type {
-			case "is_directory":
-				m.printf("❌ %s\n", csvErr.Message)
-				
-				// Suggest CSV files in the directory
-				suggestions := validation.SuggestCSVFiles(path)
-				if len(suggestions) > 0 {
-					m.println("\n💡 Found these CSV files in that directory:")
-					for i, suggestion := range suggestions {
-						if i >= 5 { // Limit suggestions
-							m.printf("   • %s\n", suggestion)
-						} else {
-							m.printf("   ... and %d more\n", len(suggestions)-5)
-							break
-						}
-					}
-					m.println("\nPlease enter the full path to one of these files.")
-				} else {
-					m.printf("\n💡 Try: %s/your-file.csv\n", path)
-				}
-				
-			case "not_found":
-				m.printf("❌ %s\n", csvErr.Message)
-				m.println("💡 Make sure the file path is correct and the file exists.")
-				
-			case "not_readable":
-				m.printf("❌ %s\n", csvErr.Message)
-				m.println("💡 Check file permissions or if the file is open in another program.")
-				
-			case "empty_file":
-				m.printf("❌ %s\n", csvErr.Message)
-				m.println("💡 Make sure your CSV file contains data.")
-				
-			case "invalid_format":
-				m.printf("❌ %s\n", csvErr.Message)
-				m.println("💡 Make sure the file is a text-based CSV file, not binary.")
-				
-			default:
-				m.printf("❌ %s\n", csvErr.Message)
+		hint := "\n\n💡 Found these CSV files in that directory:"
+		for i, suggestion := range suggestions {
+			if i >= 5 { // Limit suggestions
+				hint += fmt.Sprintf("\n   ... and %d more", len(suggestions)-5)
+				break
 			}
-			
-			// The continue here will loop back to readInput, which handles quit
-			continue
+			hint += fmt.Sprintf("\n   • %s", suggestion)
 		}
+		return fmt.Errorf("%s%s\n\nPlease enter the full path to one of these files.", csvErr.Message, hint)
+		
+	case "not_found":
+		return fmt.Errorf("%s\n💡 Make sure the file path is correct and the file exists.", csvErr.Message)
+		
+	case "not_readable":
+		return fmt.Errorf("%s\n💡 Check file permissions or if the file is open in another program.", csvErr.Message)
+		
+	case "empty_file":
+		return fmt.Errorf("%s\n💡 Make sure your CSV file contains data.", csvErr.Message)
 		
-		m.printf("✅ File validated: %s\n", path)
-		return path, nil
+	case "invalid_format":
+		return fmt.Errorf("%s\n💡 Make sure the file is a text-based CSV file, not binary.", csvErr.Message)
 	}
+	
+	return fmt.Errorf("%s", csvErr.Message)
 }
 
 func (m *Menu) chooseMode() (bool, error) {
-	m.println("\n🎯 Mode Selection")
-	m.println("----------------")
+	m.prompt.Heading("🎯 Mode Selection")
 	m.println("Choose what you want to generate:")
-	m.println("1. 📊 Reports (story points by contributor, epic, team, or product area)")
-	m.println("2. 📈 Metrics (lead time, throughput, flow efficiency, etc.)")
 	
-	for {
-		choice, err := m.readInput("\nEnter your choice (1 or 2): ")
-		if err != nil {
-			return false, err
-		}
-		
-		switch choice {
-		case "1":
-			return false, nil // Reports mode
-		case "2":
-			return true, nil // Metrics mode
-		default:
-			m.println("❌ Please enter 1 or 2")
-		}
+	choice, err := m.prompt.Select([]string{
+		"📊 Reports (story points by contributor, epic, team, or product area)",
+		"📈 Metrics (lead time, throughput, flow efficiency, etc.)",
+	}, -1)
+	if err != nil {
+		return false, err
 	}
+	
+	return choice == 1, nil
 }
 
 func (m *Menu) configureReports(cfg *config.Config) error {
-	m.println("\n📊 Report Type Selection")
-	m.println("------------------------")
+	m.prompt.Heading("📊 Report Type Selection")
 	m.println("Available report types:")
-	m.println("1. 👤 Contributor - Story points by person")
-	m.println("2. 🎯 Epic - Story points by epic/initiative")
-	m.println("3. 🏢 Product Area - Story points by product area")
-	m.println("4. 👥 Team - Story points by team")
 	
-	for {
-		choice, err := m.readInput("\nEnter your choice (1-4): ")
-		if err != nil {
-			return err
-		}
-		
-		var reportType reports.ReportType
-		
-		switch choice {
-		case "1":
-			reportType = reports.ReportTypeContributor
-		case "2":
-			reportType = reports.ReportTypeEpic
-		case "3":
-			reportType = reports.ReportTypeProductArea
-		case "4":
-			reportType = reports.ReportTypeTeam
-		default:
-			m.println("❌ Please enter a number between 1 and 4")
-			continue
-		}
-		
-		cfg.ReportType = reportType
-		m.printf("✅ Selected: %s report\n", reportType)
-		return nil
+	reportTypes := []reports.ReportType{
+		reports.ReportTypeContributor,
+		reports.ReportTypeEpic,
+		reports.ReportTypeProductArea,
+		reports.ReportTypeTeam,
+	}
+	choice, err := m.prompt.Select([]string{
+		"👤 Contributor - Story points by person",
+		"🎯 Epic - Story points by epic/initiative",
+		"🏢 Product Area - Story points by product area",
+		"👥 Team - Story points by team",
+	}, -1)
+	if err != nil {
+		return err
 	}
+	
+	cfg.ReportType = reportTypes[choice]
+	m.printf("✅ Selected: %s report\n", cfg.ReportType)
+	return nil
 }
 
 func (m *Menu) configureMetrics(cfg *config.Config) error {
-	m.println("\n📈 Metrics Type Selection")
-	m.println("-------------------------")
+	m.prompt.Heading("📈 Metrics Type Selection")
 	m.println("Available metrics:")
-	m.println("1. ⏱️  Lead Time - How long items take to complete")
-	m.println("2. 🚀 Throughput - Completion rates over time")
-	m.println("3. 🌊 Flow Efficiency - Active vs waiting time")
-	m.println("4. 🎯 Estimation Accuracy - Estimate vs actual time correlation")
-	m.println("5. 📅 Work Item Age - Age of current incomplete items")
-	m.println("6. 📊 Team Improvement - Month-over-month trends")
-	m.println("7. 🔄 All Metrics - Generate all of the above")
 	
-	for {
-		choice, err := m.readInput("\nEnter your choice (1-7): ")
-		if err != nil {
-			return err
-		}
-		
-		var metricsType metrics.MetricsType
-		
-		switch choice {
-		case "1":
-			metricsType = metrics.MetricsTypeLeadTime
-		case "2":
-			metricsType = metrics.MetricsTypeThroughput
-		case "3":
-			metricsType = metrics.MetricsTypeFlow
-		case "4":
-			metricsType = metrics.MetricsTypeEstimation
-		case "5":
-			metricsType = metrics.MetricsTypeAge
-		case "6":
-			metricsType = metrics.MetricsTypeImprovement
-		case "7":
-			metricsType = metrics.MetricsTypeAll
-		default:
-			fmt.Println("❌ Please enter a number between 1 and 7")
-			continue
-		}
-		
-		cfg.MetricsType = metricsType
-		m.printf("✅ Selected: %s metrics\n", metricsType)
-		
-		// For throughput metrics, ask about period
-		if metricsType == metrics.MetricsTypeThroughput || metricsType == metrics.MetricsTypeAll {
-			return m.configurePeriod(cfg)
-		}
-		
-		// Set default period for other metrics
-		cfg.PeriodType = metrics.PeriodTypeMonth
-		return nil
+	metricsTypes := []metrics.MetricsType{
+		metrics.MetricsTypeLeadTime,
+		metrics.MetricsTypeThroughput,
+		metrics.MetricsTypeFlow,
+		metrics.MetricsTypeEstimation,
+		metrics.MetricsTypeAge,
+		metrics.MetricsTypeImprovement,
+		metrics.MetricsTypeWorkflow,
+		metrics.MetricsTypeBenchmark,
+		metrics.MetricsTypeReview,
+		metrics.MetricsTypeCFD,
+		metrics.MetricsTypePriority,
+		metrics.MetricsTypeDigest,
+		metrics.MetricsTypeWeekday,
+		metrics.MetricsTypeForecast,
+		metrics.MetricsTypeCommitment,
+		metrics.MetricsTypeScatterplot,
+		metrics.MetricsTypeAll,
 	}
+	choice, err := m.prompt.Select([]string{
+		"⏱️  Lead Time - How long items take to complete",
+		"🚀 Throughput - Completion rates over time",
+		"🌊 Flow Efficiency - Active vs waiting time",
+		"🎯 Estimation Accuracy - Estimate vs actual time correlation",
+		"📅 Work Item Age - Age of current incomplete items",
+		"📊 Team Improvement - Month-over-month trends",
+		"🔀 Workflow Comparison - Lead time and throughput per workflow",
+		"🏁 Team Benchmark - Rank teams on flow measures",
+		"🔍 Time in Review - Time spent in the final review/QA state",
+		"📶 Cumulative Flow - Items per state over time",
+		"🚨 Effort by Priority - Share of points per priority over time",
+		"📰 Weekly Digest - Plain-language highlights of the latest week",
+		"🗓️  Completions by Weekday - When in the week work gets finished",
+		"🎲 Forecast - When the remaining work will be done",
+		"🤝 Commitment - Points committed vs completed per iteration",
+		"✴️  Cycle Time Scatterplot - Each item's cycle time by completion date",
+		"🔄 All Metrics - Generate metrics 1-6",
+	}, -1)
+	if err != nil {
+		return err
+	}
+	
+	metricsType := metricsTypes[choice]
+	cfg.MetricsType = metricsType
+	m.printf("✅ Selected: %s metrics\n", metricsType)
+	
+	// For period-based metrics, ask about period
+	if metricsType == metrics.MetricsTypeThroughput || metricsType == metrics.MetricsTypeWorkflow || metricsType == metrics.MetricsTypeCFD || metricsType == metrics.MetricsTypePriority || metricsType == metrics.MetricsTypeAll {
+		return m.configurePeriod(cfg)
+	}
+	
+	// Set default period for other metrics
+	cfg.PeriodType = metrics.PeriodTypeMonth
+	return nil
 }
 
 func (m *Menu) configurePeriod(cfg *config.Config) error {
-	m.println("\n⏰ Time Period Selection")
-	m.println("-----------------------")
+	m.prompt.Heading("⏰ Time Period Selection")
 	m.println("Choose time period for grouping:")
-	m.println("1. 📅 Week - Group by week")
-	m.println("2. 🗓️  Month - Group by month")
 	
-	for {
-		choice, err := m.readInput("\nEnter your choice (1 or 2): ")
-		if err != nil {
-			return err
-		}
-		
-		switch choice {
-		case "1":
-			cfg.PeriodType = metrics.PeriodTypeWeek
-			m.println("✅ Selected: Weekly grouping")
-			return nil
-		case "2":
-			cfg.PeriodType = metrics.PeriodTypeMonth
-			m.println("✅ Selected: Monthly grouping")
-			return nil
-		default:
-			m.println("❌ Please enter 1 or 2")
-		}
+	choice, err := m.prompt.Select([]string{
+		"📅 Week - Group by week",
+		"🗓️  Month - Group by month",
+	}, -1)
+	if err != nil {
+		return err
+	}
+	
+	if choice == 0 {
+		cfg.PeriodType = metrics.PeriodTypeWeek
+		m.println("✅ Selected: Weekly grouping")
+	} else {
+		cfg.PeriodType = metrics.PeriodTypeMonth
+		m.println("✅ Selected: Monthly grouping")
 	}
+	return nil
 }
 
 func (m *Menu) configureDateRange(cfg *config.Config) error {
-	m.println("\n📅 Date Range Selection")
-	m.println("----------------------")
+	m.prompt.Heading("📅 Date Range Selection")
 	m.println("Choose date range:")
-	m.println("1. 🔄 All time - Include all data")
-	m.println("2. 📊 Last N days - Recent data only")
-	m.println("3. 📆 Specific range - Custom start and end dates")
 	
-	for {
-		choice, err := m.readInput("\nEnter your choice (1-3): ")
-		if err != nil {
-			return err
-		}
-		
-		switch choice {
-		case "1":
-			m.println("✅ Selected: All time")
-			return nil
-		case "2":
-			return m.configureLastNDays(cfg)
-		case "3":
-			return m.configureSpecificRange(cfg)
-		default:
-			m.println("❌ Please enter a number between 1 and 3")
-		}
+	choice, err := m.prompt.Select([]string{
+		"🔄 All time - Include all data",
+		"📊 Last N days - Recent data only",
+		"📆 Specific range - Custom start and end dates",
+	}, -1)
+	if err != nil {
+		return err
+	}
+	
+	switch choice {
+	case 1:
+		return m.configureLastNDays(cfg)
+	case 2:
+		return m.configureSpecificRange(cfg)
 	}
+	
+	m.println("✅ Selected: All time")
+	return nil
 }
 
 func (m *Menu) configureLastNDays(cfg *config.Config) error {
@@ -388,70 +352,39 @@ func (m *Menu) configureLastNDays(cfg *config.Config) error {
 	m.println("- Last 30 days (1 month)")
 	m.println("- Last 90 days (1 quarter)")
 	
-	for {
-		input, err := m.readInput("\nEnter number of days: ")
-		if err != nil {
-			return err
-		}
-		
-		days, err := strconv.Atoi(input)
-		if err != nil || days <= 0 {
-			m.println("❌ Please enter a valid positive number")
-			continue
-		}
-		
-		cfg.LastNDays = days
-		cfg.EndDate = time.Now()
-		cfg.StartDate = cfg.EndDate.AddDate(0, 0, -days)
-		
-		m.printf("✅ Selected: Last %d days\n", days)
-		return nil
+	days, err := m.prompt.Int("\nEnter number of days: ", 1)
+	if err != nil {
+		return err
 	}
+	
+	cfg.LastNDays = days
+	cfg.EndDate = time.Now()
+	cfg.StartDate = cfg.EndDate.AddDate(0, 0, -days)
+	
+	m.printf("✅ Selected: Last %d days\n", days)
+	return nil
 }
 
 func (m *Menu) configureSpecificRange(cfg *config.Config) error {
-	// Get start date
-	for {
-		input, err := m.readInput("\nEnter start date (YYYY-MM-DD): ")
-		if err != nil {
-			return err
-		}
-		
-		startDate, err := time.Parse("2006-01-02", input)
-		if err != nil {
-			m.println("❌ Invalid date format. Please use YYYY-MM-DD")
-			continue
-		}
-		
-		cfg.StartDate = startDate
-		break
+	startDate, err := m.prompt.Date("\nEnter start date (YYYY-MM-DD): ", nil)
+	if err != nil {
+		return err
 	}
 	
-	// Get end date
-	for {
-		input, err := m.readInput("Enter end date (YYYY-MM-DD): ")
-		if err != nil {
-			return err
-		}
-		
-		endDate, err := time.Parse("2006-01-02", input)
-		if err != nil {
-			m.println("❌ Invalid date format. Please use YYYY-MM-DD")
-			continue
-		}
-		
-		// Add end of day to end date
-		endDate = endDate.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
-		
-		if endDate.Before(cfg.StartDate) {
-			m.println("❌ End date cannot be before start date")
-			continue
+	endDate, err := m.prompt.Date("Enter end date (YYYY-MM-DD): ", func(date time.Time) error {
+		if date.Before(startDate) {
+			return fmt.Errorf("End date cannot be before start date")
 		}
-		
-		cfg.EndDate = endDate
-		break
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 	
+	cfg.StartDate = startDate
+	// Add end of day to end date
+	cfg.EndDate = endDate.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+	
 	m.printf("✅ Selected: %s to %s\n", 
 		cfg.StartDate.Format("2006-01-02"), 
 		cfg.EndDate.Format("2006-01-02"))
@@ -459,117 +392,147 @@ func (m *Menu) configureSpecificRange(cfg *config.Config) error {
 }
 
 func (m *Menu) configureFilters(cfg *config.Config) error {
-	m.println("\n🔍 Ad-hoc Request Filtering")
-	m.println("--------------------------")
+	m.prompt.Heading("🔍 Ad-hoc Request Filtering")
 	m.println("How should ad-hoc requests be handled?")
-	m.println("1. ✅ Include all items (default)")
-	m.println("2. ❌ Exclude ad-hoc requests")
-	m.println("3. 🎯 Only ad-hoc requests")
 	
-	for {
-		choice, err := m.readInput("\nEnter your choice (1-3): ")
-		if err != nil {
-			return err
-		}
-		
-		switch choice {
-		case "1", "":
-			cfg.AdHocFilter = "include"
-			m.println("✅ Selected: Include all items")
-		case "2":
-			cfg.AdHocFilter = "exclude"
-			m.println("✅ Selected: Exclude ad-hoc requests")
-		case "3":
-			cfg.AdHocFilter = "only"
-			m.println("✅ Selected: Only ad-hoc requests")
-		default:
-			m.println("❌ Please enter a number between 1 and 3")
-			continue
-		}
-		
-		// Configure filter field
-		cfg.FilterField = models.FilterFieldCompletedAt // Default
-		return nil
+	filters := []types.AdHocFilterType{types.AdHocFilterInclude, types.AdHocFilterExclude, types.AdHocFilterOnly}
+	selected := []string{"Include all items", "Exclude ad-hoc requests", "Only ad-hoc requests"}
+	choice, err := m.prompt.Select([]string{
+		"✅ Include all items (default)",
+		"❌ Exclude ad-hoc requests",
+		"🎯 Only ad-hoc requests",
+	}, 0)
+	if err != nil {
+		return err
 	}
+	
+	cfg.AdHocFilter = filters[choice]
+	m.printf("✅ Selected: %s\n", selected[choice])
+	
+	// Configure filter field
+	cfg.FilterField = models.FilterFieldCompletedAt // Default
+	return nil
 }
 
 func (m *Menu) configureOutput(cfg *config.Config) error {
-	m.println("\n💾 Output Configuration")
-	m.println("----------------------")
+	m.prompt.Heading("💾 Output Configuration")
 	m.println("Where should the report be displayed?")
-	m.println("1. 🖥️  Console only (display on screen)")
-	m.println("2. 📄 Save to file")
 	
-	for {
-		choice, err := m.readInput("\nEnter your choice (1 or 2): ")
-		if err != nil {
-			return err
-		}
-		
-		switch choice {
-		case "1":
-			m.println("✅ Selected: Console output")
-			return nil
-		case "2":
-			return m.configureOutputFile(cfg)
-		default:
-			m.println("❌ Please enter 1 or 2")
-		}
+	choice, err := m.prompt.Select([]string{
+		"🖥️  Console only (display on screen)",
+		"📄 Save to file",
+	}, -1)
+	if err != nil {
+		return err
 	}
+	
+	if choice == 1 {
+		return m.configureOutputFile(cfg)
+	}
+	
+	m.println("✅ Selected: Console output")
+	return nil
 }
 
 func (m *Menu) configureOutputFile(cfg *config.Config) error {
-	for {
-		filename, err := m.readInput("\nEnter output filename (e.g., report.txt): ")
-		if err != nil {
-			return err
+	filename, err := m.prompt.Text("\nEnter output filename (e.g., report.txt): ", "", func(answer string) error {
+		if answer == "" {
+			return fmt.Errorf("Please enter a valid filename")
 		}
-		
-		if filename == "" {
-			m.println("❌ Please enter a valid filename")
-			continue
-		}
-		
-		cfg.OutputPath = filename
-		m.printf("✅ Selected: Save to %s\n", filename)
 		return nil
+	})
+	if err != nil {
+		return err
 	}
+	
+	cfg.OutputPath = filename
+	m.printf("✅ Selected: Save to %s\n", filename)
+	return nil
 }
 
 func (m *Menu) configureDelimiter(cfg *config.Config) error {
-	m.println("\n🔗 CSV Delimiter Configuration")
-	m.println("-----------------------------")
+	m.prompt.Heading("🔗 CSV Delimiter Configuration")
 	m.println("Choose CSV delimiter (auto-detection recommended):")
-	m.println("1. 🤖 Auto-detect (recommended)")
-	m.println("2. , Comma")
-	m.println("3. ; Semicolon")
-	m.println("4. ⭾ Tab")
+	
+	delimiters := []models.DelimiterType{
+		models.DelimiterAuto,
+		models.DelimiterComma,
+		models.DelimiterSemicolon,
+		models.DelimiterTab,
+	}
+	selected := []string{"Auto-detection", "Comma delimiter", "Semicolon delimiter", "Tab delimiter"}
+	choice, err := m.prompt.Select([]string{
+		"🤖 Auto-detect (recommended)",
+		", Comma",
+		"; Semicolon",
+		"⭾ Tab",
+	}, 0)
+	if err != nil {
+		return err
+	}
+	
+	cfg.Delimiter = delimiters[choice]
+	m.printf("✅ Selected: %s\n", selected[choice])
+	return nil
+}
+
+func (m *Menu) configureFormat(cfg *config.Config) error {
+	formats := []types.OutputFormat{types.FormatText, types.FormatMarkdown, types.FormatHTML, types.FormatJSON}
+	selected := []string{"Console table", "Markdown", "HTML", "JSON"}
 	
 	for {
-		choice, err := m.readInput("\nEnter your choice (1-4): ")
+		m.prompt.Heading("🎨 Output Format")
+		m.println("Choose the output format:")
+		
+		choice, err := m.prompt.Select([]string{
+			"🖥️  Console table (default)",
+			"📝 Markdown - Tables for wikis and pull requests",
+			"🌐 HTML - A styled page to share",
+			"🧩 JSON - Structured results for scripts and dashboards",
+		}, 0)
 		if err != nil {
 			return err
 		}
 		
-		switch choice {
-		case "1", "":
-			cfg.Delimiter = models.DelimiterAuto
-			m.println("✅ Selected: Auto-detection")
-		case "2":
-			cfg.Delimiter = models.DelimiterComma
-			m.println("✅ Selected: Comma delimiter")
-		case "3":
-			cfg.Delimiter = models.DelimiterSemicolon
-			m.println("✅ Selected: Semicolon delimiter")
-		case "4":
-			cfg.Delimiter = models.DelimiterTab
-			m.println("✅ Selected: Tab delimiter")
-		default:
-			m.println("❌ Please enter a number between 1 and 4")
-			continue
+		cfg.Format = formats[choice]
+		m.printf("✅ Selected: %s\n", selected[choice])
+		if m.preview == nil {
+			return nil
 		}
-		return nil
+		
+		m.showPreview(cfg)
+		keep, err := m.prompt.Confirm("Generate the output in this format?", true)
+		if err != nil || keep {
+			return err
+		}
+	}
+}
+
+// showPreview shows the first lines of the output in the chosen format
+func (m *Menu) showPreview(cfg *config.Config) {
+	output, err := m.preview(cfg)
+	if err != nil {
+		m.printf("⚠️  No preview: %v\n", err)
+		return
 	}
+	
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	m.printf("\n👀 Preview (first %d of %d lines):\n", min(previewLines, len(lines)), len(lines))
+	m.println(strings.Repeat("-", 50))
+	for _, line := range lines[:min(previewLines, len(lines))] {
+		m.println(line)
+	}
+	if len(lines) > previewLines {
+		m.printf("... %d more lines\n", len(lines)-previewLines)
+	}
+	m.println(strings.Repeat("-", 50))
+}
+
+// AskSaveLongOutput offers to save console output that is too long for the
+// screen. It returns the filename, or "" to page through the output instead.
+func (m *Menu) AskSaveLongOutput(lines int) (string, error) {
+	m.printf("\n📜 The output has %d lines, more than fit on the screen.\n", lines)
+	return m.prompt.Text("Enter a filename to save it to, or press Enter to page through it: ", "", nil)
 }
 
 // ShowSummary displays a summary of the selected configuration
@@ -580,7 +543,7 @@ func (m *Menu) ShowSummary(cfg *config.Config) {
 	
 	if cfg.IsMetricsReport() {
 		m.printf("📈 Metrics Type: %s\n", cfg.MetricsType)
-		if cfg.MetricsType == metrics.MetricsTypeThroughput || cfg.MetricsType == metrics.MetricsTypeAll {
+		if cfg.MetricsType == metrics.MetricsTypeThroughput || cfg.MetricsType == metrics.MetricsTypeWorkflow || cfg.MetricsType == metrics.MetricsTypeAll {
 			m.printf("⏰ Period: %s\n", cfg.PeriodType)
 		}
 	} else {
@@ -600,6 +563,9 @@ func (m *Menu) ShowSummary(cfg *config.Config) {
 	
 	m.printf("🔍 Ad-hoc Filter: %s\n", cfg.AdHocFilter)
 	m.printf("🔗 Delimiter: %s\n", cfg.Delimiter.Name)
+	if cfg.Format != "" {
+		m.printf("🎨 Format: %s\n", cfg.Format)
+	}
 	
 	if cfg.OutputPath != "" {
 		m.printf("💾 Output: %s\n", cfg.OutputPath)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hannasdev/kanban-reports/internal/config"
 	"github.com/hannasdev/kanban-reports/internal/reports"
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
 // TestInput simulates user input for testing
@@ -226,6 +227,30 @@ func TestConfigureSpecificRange(t *testing.T) {
 	}
 }
 
+func TestAskSaveLongOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantPath string
+	}{
+		{"Save to file", "report.txt\n", "report.txt"},
+		{"Page through", "\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			menu := createTestMenu(tt.input)
+			path, err := menu.AskSaveLongOutput(120)
+			if err != nil {
+				t.Fatalf("AskSaveLongOutput() error = %v", err)
+			}
+			if path != tt.wantPath {
+				t.Errorf("AskSaveLongOutput() = %q, want %q", path, tt.wantPath)
+			}
+		})
+	}
+}
+
 func TestIsQuitCommand(t *testing.T) {
 	tests := []struct {
 		input string
@@ -285,4 +310,72 @@ func TestQuitHandling_Integration(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestScriptedMenu(t *testing.T) {
+	helper := NewTestHelper()
+	defer helper.Cleanup()
+
+	tmpFile := helper.CreateTempCSV(t, "")
+
+	t.Run("Complete session", func(t *testing.T) {
+		answers := strings.Join([]string{tmpFile, "2", "0", "17", "1", "2", "30", "1", "1", "1", "3"}, "\n") + "\n"
+		writer := &strings.Builder{}
+		menu := NewScriptedMenu(strings.NewReader(answers), writer)
+
+		cfg, err := menu.Run()
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+
+		if cfg.MetricsType != "all" || cfg.LastNDays != 30 || cfg.Format != types.FormatHTML {
+			t.Errorf("Run() config = metrics %q, last %d days, format %q; want all, 30, html", cfg.MetricsType, cfg.LastNDays, cfg.Format)
+		}
+
+		output := writer.String()
+		expected := []string{
+			"Enter the path to your CSV file: " + tmpFile + "\n",
+			"Enter your choice (1-17): 0\n❌ Please enter a number between 1 and 17",
+			"Tip: Type 'q'",
+		}
+		for _, want := range expected {
+			if !strings.Contains(output, want) {
+				t.Errorf("Output doesn't contain %q", want)
+			}
+		}
+	})
+
+	t.Run("Format preview", func(t *testing.T) {
+		// Markdown is previewed and declined, then JSON is kept
+		answers := strings.Join([]string{tmpFile, "1", "4", "1", "1", "1", "1", "2", "n", "4", ""}, "\n") + "\n"
+		writer := &strings.Builder{}
+		var previewed []types.OutputFormat
+		menu := NewScriptedMenu(strings.NewReader(answers), writer).WithPreview(func(cfg *config.Config) (string, error) {
+			previewed = append(previewed, cfg.Format)
+			return strings.Repeat(string(cfg.Format)+" line\n", 20), nil
+		})
+
+		cfg, err := menu.Run()
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if cfg.Format != types.FormatJSON || len(previewed) != 2 || previewed[0] != types.FormatMarkdown {
+			t.Errorf("Run() format = %q after previews %v; want json after markdown, json", cfg.Format, previewed)
+		}
+
+		output := writer.String()
+		for _, want := range []string{"Preview (first 12 of 20 lines)", "markdown line\n", "... 8 more lines"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Output doesn't contain %q", want)
+			}
+		}
+	})
+
+	t.Run("Answers run out", func(t *testing.T) {
+		menu := NewScriptedMenu(strings.NewReader(tmpFile+"\n"), &strings.Builder{})
+
+		_, err := menu.Run()
+		if err == nil || !strings.Contains(err.Error(), "answers ended before the prompt") {
+			t.Errorf("Run() error = %v, want answers ended error", err)
+		}
+	})
+}
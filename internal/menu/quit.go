@@ -2,6 +2,7 @@ package menu
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -35,7 +36,7 @@ func HandleQuit(input string) error {
 	return nil
 }
 
-// ShowQuitHelp displays quit help information
-func ShowQuitHelp() {
-	fmt.Println("\n💡 Tip: Type 'q', 'quit', 'exit', or 'bye' at any time to exit")
+// ShowQuitHelp writes quit help information to w
+func ShowQuitHelp(w io.Writer) {
+	fmt.Fprintln(w, "\n💡 Tip: Type 'q', 'quit', 'exit', or 'bye' at any time to exit")
 }
\ No newline at end of file
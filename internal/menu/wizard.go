@@ -0,0 +1,275 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/config"
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// jiraColumns rename the columns of a Jira issue export
+var jiraColumns = []string{
+	"Issue key=id",
+	"Summary=name",
+	"Custom field (Story Points)=estimate",
+	"Status=state",
+	"Status Category=is_completed",
+	"Resolved=completed_at",
+	"Created=created_at",
+	"Updated=updated_at",
+	"Assignee=owners",
+	"Issue Type=type",
+	"Labels=labels",
+	"Parent summary=epic",
+}
+
+// setupAnswers collects the answers of the setup
+type setupAnswers struct {
+	columnMap  []string
+	truthy     string
+	falsy      string
+	delimiter  string
+	dateFormat string
+	teamColumn string
+	adHocRules string
+	csvPath    string
+}
+
+// profile returns the answers as the options of the default profile
+func (s *setupAnswers) profile() config.Profile {
+	profile := config.Profile{Name: config.DefaultProfile}
+	add := func(key, value string) {
+		if value != "" {
+			profile.Settings = append(profile.Settings, config.Setting{Key: key, Value: value})
+		}
+	}
+	add("csv", s.csvPath)
+	add("delimiter", s.delimiter)
+	add("date-format", s.dateFormat)
+	add("column-map", strings.Join(s.columnMap, ","))
+	add("truthy", s.truthy)
+	add("falsy", s.falsy)
+	add("ad-hoc-rules", s.adHocRules)
+	return profile
+}
+
+// OfferSetup asks whether to run the setup on the first launch
+func (m *Menu) OfferSetup(path string) (bool, error) {
+	m.println("👋 Welcome to Kanban Reports!")
+	m.printf("No config file was found at %s.\n", path)
+	return m.prompt.Confirm("Set up your team's export format now", true)
+}
+
+// RunSetup asks how the team's exports look and which requests count as
+// ad-hoc, and returns the answers as the default profile of a config file
+func (m *Menu) RunSetup() (config.Profile, error) {
+	m.println("🧭 Kanban Reports - Setup")
+	m.println("=========================")
+	m.println("A few questions about your team's exports. The answers are saved and used")
+	m.println("by every run; options given on the command line still take precedence.")
+	ShowQuitHelp(m.writer)
+
+	s := &setupAnswers{teamColumn: "team"}
+	steps := []func(*setupAnswers) error{
+		m.askTool,
+		m.askDelimiter,
+		m.askDateFormat,
+		m.askTeamColumn,
+		m.askAdHocConvention,
+		m.askUsualCSV,
+	}
+	for _, step := range steps {
+		if err := step(s); err != nil {
+			return config.Profile{}, err
+		}
+	}
+	return s.profile(), nil
+}
+
+// ConfirmSave shows the options of profile and asks whether to save them
+func (m *Menu) ConfirmSave(path string, profile config.Profile) (bool, error) {
+	m.prompt.Heading("💾 Save Settings")
+	if len(profile.Settings) == 0 {
+		m.println("Your exports work with the defaults, so the profile sets no options.")
+	}
+	for _, setting := range profile.Settings {
+		m.printf("   %s: %s\n", setting.Key, setting.Value)
+	}
+	return m.prompt.Confirm(fmt.Sprintf("\nSave to %s?", path), true)
+}
+
+func (m *Menu) askTool(s *setupAnswers) error {
+	m.prompt.Heading("🧰 Export Format")
+	m.println("Which tool do your CSV exports come from?")
+
+	choice, err := m.prompt.Select([]string{
+		"Shortcut (the columns are read as they are)",
+		"Jira (issue export with all fields)",
+		"Another tool",
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	switch choice {
+	case 1:
+		s.columnMap = append(s.columnMap, jiraColumns...)
+		s.truthy, s.falsy = "Done", "To Do,In Progress"
+		s.dateFormat = "jira"
+		s.teamColumn = "Team"
+		m.println("✅ Selected: Jira; its columns, statuses and dates will be mapped")
+	case 2:
+		m.println("\nColumns are read by their Shortcut names: id, name, estimate, is_completed,")
+		m.println("completed_at, created_at, owners, labels, epic, team, ...")
+		columns, err := m.prompt.Text("Rename columns, e.g. \"Story Points=estimate,Title=name\" (Enter if none): ", "", func(answer string) error {
+			_, err := models.ParseColumnMap(answer)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if columns != "" {
+			s.columnMap = append(s.columnMap, columns)
+		}
+	default:
+		m.println("✅ Selected: Shortcut")
+	}
+	return nil
+}
+
+func (m *Menu) askDelimiter(s *setupAnswers) error {
+	m.prompt.Heading("🔗 CSV Delimiter")
+	m.println("Which character separates the columns?")
+
+	delimiters := []string{"auto", "comma", "semicolon", "tab"}
+	choice, err := m.prompt.Select([]string{
+		"🤖 Detect it for each file (recommended)",
+		", Comma",
+		"; Semicolon",
+		"⭾ Tab",
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	// Detection is the default, so it needs no option
+	if choice > 0 {
+		s.delimiter = delimiters[choice]
+	}
+	return nil
+}
+
+func (m *Menu) askDateFormat(s *setupAnswers) error {
+	m.prompt.Heading("📅 Date Format")
+	m.println("How are the timestamps in your exports written?")
+
+	example := time.Date(2024, 5, 7, 15, 49, 34, 0, time.UTC)
+	presets := []string{"shortcut", "iso", "jira", "eu", "us"}
+	options := make([]string, 0, len(presets)+1)
+	def := 0
+	for i, preset := range presets {
+		options = append(options, fmt.Sprintf("%-9s e.g. %s", preset, example.Format(models.DateFormatPresets[preset][0])))
+		if preset == s.dateFormat {
+			def = i
+		}
+	}
+	options = append(options, "Another format, written as a Go time layout")
+
+	choice, err := m.prompt.Select(options, def)
+	if err != nil {
+		return err
+	}
+
+	if choice < len(presets) {
+		s.dateFormat = presets[choice]
+		if s.dateFormat == config.DefaultDateFormat {
+			s.dateFormat = ""
+		}
+		return nil
+	}
+
+	m.println("\nWrite the layout with Go's reference time, Mon Jan 2 15:04:05 2006,")
+	m.println("e.g. \"2006-01-02 15:04\" for 2024-05-07 15:49.")
+	s.dateFormat, err = m.prompt.Text("Layout: ", "", func(answer string) error {
+		if answer == "" {
+			return errors.New("Please enter a layout")
+		}
+		_, err := models.ParseDateFormat(answer)
+		return err
+	})
+	return err
+}
+
+func (m *Menu) askTeamColumn(s *setupAnswers) error {
+	m.prompt.Heading("👥 Teams")
+
+	column, err := m.prompt.Text(fmt.Sprintf("Which column holds the team name? [%s]: ", s.teamColumn), s.teamColumn, func(answer string) error {
+		if strings.ContainsAny(answer, ",=") {
+			return errors.New("Column names can't contain , or =")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if column != "team" {
+		s.columnMap = append(s.columnMap, column+"=team")
+	}
+	return nil
+}
+
+func (m *Menu) askAdHocConvention(s *setupAnswers) error {
+	m.prompt.Heading("🔍 Ad-hoc Requests")
+	m.println("How does your team mark ad-hoc (unplanned) requests?")
+
+	choice, err := m.prompt.Select([]string{
+		"With the label ad-hoc-request (the default)",
+		"With another label",
+		"With a label on their epic",
+		"With an item type, e.g. a Jira issue type",
+	}, 0)
+	if err != nil {
+		return err
+	}
+	if choice == 0 {
+		return nil
+	}
+
+	rules := []string{"", "label", "epic-label", "type"}
+	questions := []string{"", "Label: ", "Epic label: ", "Item type: "}
+	value, err := m.prompt.Text(questions[choice], "", func(answer string) error {
+		if answer == "" || strings.Contains(answer, ",") {
+			return errors.New("Please enter one value without commas")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.adHocRules = rules[choice] + "=" + value
+	return nil
+}
+
+func (m *Menu) askUsualCSV(s *setupAnswers) error {
+	m.prompt.Heading("📁 Usual CSV File")
+
+	path, err := m.prompt.Text("Path of the export you usually report on (Enter to give --csv each time): ", "", func(answer string) error {
+		if answer == "" {
+			return nil
+		}
+		return validateCSVPath(answer)
+	})
+	if err != nil || path == "" {
+		return err
+	}
+
+	// Runs start in any directory, so relative paths are resolved now
+	s.csvPath, err = filepath.Abs(path)
+	return err
+}
@@ -0,0 +1,93 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hannasdev/kanban-reports/internal/config"
+)
+
+// settings returns the options of a profile as key=value lines
+func settings(profile config.Profile) string {
+	lines := make([]string, 0, len(profile.Settings))
+	for _, setting := range profile.Settings {
+		lines = append(lines, setting.Key+"="+setting.Value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestRunSetup(t *testing.T) {
+	helper := NewTestHelper()
+	defer helper.Cleanup()
+	csvPath := helper.CreateTempCSV(t, "")
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "Shortcut defaults",
+			input: "\n\n\n\n\n\n",
+			want:  nil,
+		},
+		{
+			name:  "Jira with an epic label",
+			input: "2\n\n\nSquad\n3\nsupport\n" + csvPath + "\n",
+			want: []string{
+				"csv=" + csvPath,
+				"date-format=jira",
+				"column-map=Issue key=id,Summary=name,Custom field (Story Points)=estimate,Status=state,Status Category=is_completed,Resolved=completed_at,Created=created_at,Updated=updated_at,Assignee=owners,Issue Type=type,Labels=labels,Parent summary=epic,Squad=team",
+				"truthy=Done",
+				"falsy=To Do,In Progress",
+				"ad-hoc-rules=epic-label=support",
+			},
+		},
+		{
+			name:  "Another tool with a custom layout",
+			input: "3\nPoints\nPoints=estimate\n3\n6\nDD.MM.YYYY\n02.01.2006\nGroup=x\nGroup\n4\nchore\n\n",
+			want: []string{
+				"delimiter=semicolon",
+				"date-format=02.01.2006",
+				"column-map=Points=estimate,Group=team",
+				"ad-hoc-rules=type=chore",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := createTestMenu(tt.input).RunSetup()
+			if err != nil {
+				t.Fatalf("RunSetup() error = %v", err)
+			}
+			if profile.Name != config.DefaultProfile {
+				t.Errorf("profile name = %q, want %q", profile.Name, config.DefaultProfile)
+			}
+			if got, want := settings(profile), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("settings =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestRunSetup_Quit(t *testing.T) {
+	_, err := createTestMenu("2\nq\n").RunSetup()
+	if _, ok := err.(QuitError); !ok {
+		t.Errorf("RunSetup() error = %v, want QuitError", err)
+	}
+}
+
+func TestConfirmSave(t *testing.T) {
+	writer := &strings.Builder{}
+	m := NewMenuWithIO(strings.NewReader("n\n"), writer)
+	profile := config.Profile{Name: config.DefaultProfile, Settings: []config.Setting{{Key: "date-format", Value: "eu"}}}
+
+	save, err := m.ConfirmSave("/tmp/config.yaml", profile)
+	if err != nil || save {
+		t.Errorf("ConfirmSave() = %v, %v, want false", save, err)
+	}
+	if !strings.Contains(writer.String(), "date-format: eu") {
+		t.Errorf("ConfirmSave() output = %q, want the options listed", writer.String())
+	}
+}
@@ -6,41 +6,85 @@ import (
 	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
 )
 
 // WorkItemAgeReport shows how long current items have been in each state
 func WorkItemAgeReport(items []models.KanbanItem, asOf time.Time) (string, error) {
+	return workItemAgeReport(items, asOf, DefaultOptions())
+}
+
+// AgedItem is an open item and how long it has been in progress
+type AgedItem struct {
+	Name       string         `json:"name"`
+	State      string         `json:"state"`
+	Age        float64        `json:"age_days"`
+	WorkingAge float64        `json:"working_age_days"`
+	Status     SLAStatus      `json:"sla_status,omitempty"`
+	Source     *models.Source `json:"source,omitempty"`
+}
+
+// AgeState is the age of the open items in one state
+type AgeState struct {
+	Name      string        `json:"name"`
+	Items     int           `json:"items"`
+	Calendar  StatsSummary  `json:"calendar_days"`
+	Working   StatsSummary  `json:"working_days"`
+	Threshold *AgeThreshold `json:"sla,omitempty"`
+	Oldest    []AgedItem    `json:"oldest"`
+}
+
+// AgeResult holds the age of open items by state
+type AgeResult struct {
+	AsOf         string            `json:"as_of"`
+	StatusCounts map[SLAStatus]int `json:"sla_status_counts,omitempty"` // Only set when SLA thresholds are configured
+	States       []AgeState        `json:"states"`
+	OverSLA      []AgedItem        `json:"over_sla"`
+	OverWIPLimit []WIPViolation    `json:"over_wip_limits,omitempty"` // Only set when WIP limits are configured
+}
+
+// maxOldestItems is the number of oldest items listed per state
+const maxOldestItems = 5
+
+// sourceNote names the CSV line a listed item was read from, if known
+func sourceNote(source *models.Source) string {
+	if source == nil {
+		return ""
+	}
+	return ", " + source.String()
+}
+
+// workItemAgeResult measures the age of open items as of the given time
+func workItemAgeResult(items []models.KanbanItem, asOf time.Time, opts Options) AgeResult {
 	if asOf.IsZero() {
 		asOf = time.Now()
 	}
 	
 	// Group items by state
-	stateItems := make(map[string][]struct{
-		Name string
-		Age float64
-	})
+	stateItems := make(map[string][]AgedItem)
+	statusCounts := make(map[SLAStatus]int)
 	
 	for _, item := range items {
 		if item.IsCompleted {
 			continue // Skip completed items
 		}
 		
-		var age float64
+		start := item.CreatedAt
 		if !item.StartedAt.IsZero() {
-			age = asOf.Sub(item.StartedAt).Hours() / 24
-		} else {
-			age = asOf.Sub(item.CreatedAt).Hours() / 24
+			start = item.StartedAt
 		}
+		age := asOf.Sub(start).Hours() / 24
+		workingAge := dateutil.WorkingDaysBetween(start, asOf, opts.Holidays)
 		
 		state := item.State
 		if state == "" {
 			state = "Unknown"
 		}
 		
-		stateItems[state] = append(stateItems[state], struct{
-			Name string
-			Age float64
-		}{item.Name, age})
+		status := opts.AgeThresholds.Status(state, age)
+		statusCounts[status]++
+		
+		stateItems[state] = append(stateItems[state], AgedItem{item.Name, state, age, workingAge, status, item.SourceRef()})
 	}
 	
 	// Sort states
@@ -50,17 +94,17 @@ func WorkItemAgeReport(items []models.KanbanItem, asOf time.Time) (string, error
 	}
 	sort.Strings(states)
 	
-	// Generate report
-	report := "# Current Work Item Age Analysis\n\n"
-	report += "Age of incomplete items by state (in days):\n\n"
+	result := AgeResult{AsOf: asOf.Format("2006-01-02"), States: []AgeState{}, OverSLA: []AgedItem{}}
+	if len(opts.AgeThresholds) > 0 {
+		result.StatusCounts = map[SLAStatus]int{
+			SLAGreen:  statusCounts[SLAGreen],
+			SLAYellow: statusCounts[SLAYellow],
+			SLARed:    statusCounts[SLARed],
+		}
+	}
 	
 	for _, state := range states {
 		items := stateItems[state]
-		if len(items) == 0 {
-			continue
-		}
-		
-		report += fmt.Sprintf("## %s (%d items)\n\n", state, len(items))
 		
 		// Sort by age (descending)
 		sort.Slice(items, func(i, j int) bool {
@@ -68,25 +112,98 @@ func WorkItemAgeReport(items []models.KanbanItem, asOf time.Time) (string, error
 		})
 		
 		// Calculate statistics
-		var ages []float64
+		var ages, workingAges []float64
 		for _, item := range items {
 			ages = append(ages, item.Age)
+			workingAges = append(workingAges, item.WorkingAge)
+			if item.Status == SLARed {
+				result.OverSLA = append(result.OverSLA, item)
+			}
+		}
+		
+		ageState := AgeState{
+			Name:     state,
+			Items:    len(items),
+			Calendar: summarize(ages),
+			Working:  summarize(workingAges),
+			Oldest:   items[:min(len(items), maxOldestItems)],
 		}
-		min, max, avg, median := calculateStats(ages)
+		if threshold, ok := opts.AgeThresholds.lookup(state); ok {
+			ageState.Threshold = &threshold
+		}
+		result.States = append(result.States, ageState)
+	}
+	if len(opts.WIPLimits) > 0 {
+		result.OverWIPLimit = append([]WIPViolation{}, opts.WIPLimits.currentViolations(items)...)
+	}
+	
+	return result
+}
+
+// workItemAgeReport builds the work item age report using the given options
+func workItemAgeReport(items []models.KanbanItem, asOf time.Time, opts Options) (string, error) {
+	result := workItemAgeResult(items, asOf, opts)
+	
+	// Generate report
+	report := "# Current Work Item Age Analysis\n\n"
+	report += "Age of incomplete items by state (in days):\n\n"
+	report += "Working days exclude weekends"
+	if len(opts.Holidays) > 0 {
+		report += fmt.Sprintf(" and %d configured holidays", len(opts.Holidays))
+	}
+	report += ".\n\n"
+	
+	if result.StatusCounts != nil {
+		report += fmt.Sprintf("SLA status: %s %d green, %s %d yellow, %s %d red\n",
+			SLAGreen.Marker(), result.StatusCounts[SLAGreen],
+			SLAYellow.Marker(), result.StatusCounts[SLAYellow],
+			SLARed.Marker(), result.StatusCounts[SLARed])
+		report += fmt.Sprintf("Red items: %d\n\n", result.StatusCounts[SLARed])
+	}
+	
+	for _, state := range result.States {
+		report += fmt.Sprintf("## %s (%d items)\n\n", state.Name, state.Items)
 		
-		report += fmt.Sprintf("Min: %.1f, Max: %.1f, Avg: %.1f, Median: %.1f days\n\n", 
-			min, max, avg, median)
+		report += fmt.Sprintf("Calendar: %s days\n", formatStatsInline(state.Calendar, ageStats(opts.Stats)))
+		report += fmt.Sprintf("Working:  %s days\n", formatStatsInline(state.Working, ageStats(opts.Stats)))
+		if state.Threshold != nil {
+			report += fmt.Sprintf("SLA:      %s after %s days, %s after %s days\n",
+				SLAYellow.Marker(), formatDays(state.Threshold.Warning), SLARed.Marker(), formatDays(state.Threshold.Critical))
+		}
+		report += "\n"
 		
-		// Show oldest 5 items
+		// Show the oldest items
 		report += "Oldest Items:\n\n"
-		for i, item := range items {
-			if i >= 5 {
-				break
+		for _, item := range state.Oldest {
+			marker := ""
+			if item.Status != "" {
+				marker = item.Status.Marker() + " "
 			}
-			report += fmt.Sprintf("- %s (%.1f days)\n", item.Name, item.Age)
+			report += fmt.Sprintf("- %s%s (%.1f days, %.1f working days%s)\n", marker, item.Name, item.Age, item.WorkingAge, sourceNote(item.Source))
+		}
+		report += "\n"
+	}
+	
+	// List every item past its critical threshold, not just the oldest per state
+	if len(result.OverSLA) > 0 {
+		report += "## Items Over SLA\n\n"
+		for _, item := range result.OverSLA {
+			report += fmt.Sprintf("- %s %s [%s] (%.1f days%s)\n", SLARed.Marker(), item.Name, item.State, item.Age, sourceNote(item.Source))
 		}
 		report += "\n"
 	}
+	report += formatWIPViolations(result.OverWIPLimit)
 	
 	return report, nil
-}
\ No newline at end of file
+}
+
+// ageStats drops the count from the inline statistics since it is already in the heading
+func ageStats(stats []StatType) []StatType {
+	var filtered []StatType
+	for _, st := range stats {
+		if st != StatCount {
+			filtered = append(filtered, st)
+		}
+	}
+	return filtered
+}
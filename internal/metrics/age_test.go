@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
 )
 
 func TestWorkItemAgeReport(t *testing.T) {
@@ -408,4 +409,111 @@ func TestWorkItemAgeReport_LimitOldestItems(t *testing.T) {
 	if taskCount > 5 {
 		t.Errorf("Oldest Items section should show at most 5 items, but shows %d", taskCount)
 	}
-}
\ No newline at end of file
+}
+func TestWorkItemAgeReport_WorkingDays(t *testing.T) {
+	// Wednesday 2024-05-15 noon; item started the previous Wednesday noon
+	baseTime := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+
+	items := []models.KanbanItem{
+		{
+			ID:          "1",
+			Name:        "Week Old Task",
+			State:       "In Progress",
+			IsCompleted: false,
+			CreatedAt:   baseTime.AddDate(0, 0, -10),
+			StartedAt:   baseTime.AddDate(0, 0, -7),
+		},
+	}
+
+	report, err := workItemAgeReport(items, baseTime, DefaultOptions())
+	if err != nil {
+		t.Fatalf("workItemAgeReport() error = %v", err)
+	}
+	if !strings.Contains(report, "Week Old Task (7.0 days, 5.0 working days)") {
+		t.Errorf("Expected calendar and working-day ages, got:\n%s", report)
+	}
+
+	opts := DefaultOptions()
+	opts.Holidays = dateutil.Holidays{"2024-05-13": true}
+	report, err = workItemAgeReport(items, baseTime, opts)
+	if err != nil {
+		t.Fatalf("workItemAgeReport() error = %v", err)
+	}
+	if !strings.Contains(report, "Week Old Task (7.0 days, 4.0 working days)") {
+		t.Errorf("Expected holiday to be excluded from working days, got:\n%s", report)
+	}
+	if !strings.Contains(report, "1 configured holidays") {
+		t.Errorf("Expected report to mention configured holidays")
+	}
+}
+
+func TestWorkItemAgeReport_AgeThresholds(t *testing.T) {
+	baseTime := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Stuck Review", State: "Review", StartedAt: baseTime.AddDate(0, 0, -6)},
+		{ID: "2", Name: "Slow Review", State: "Review", StartedAt: baseTime.AddDate(0, 0, -3)},
+		{ID: "3", Name: "Fresh Task", State: "In Progress", StartedAt: baseTime.AddDate(0, 0, -1)},
+	}
+
+	opts := DefaultOptions()
+	opts.AgeThresholds = AgeThresholds{"Review": {Warning: 2, Critical: 4}, "*": {Warning: 5, Critical: 10}}
+
+	report, err := workItemAgeReport(items, baseTime, opts)
+	if err != nil {
+		t.Fatalf("workItemAgeReport() error = %v", err)
+	}
+
+	expected := []string{
+		"SLA status: 🟢 1 green, 🟡 1 yellow, 🔴 1 red",
+		"Red items: 1",
+		"- 🔴 Stuck Review (6.0 days",
+		"- 🟡 Slow Review (3.0 days",
+		"- 🟢 Fresh Task (1.0 days",
+		"## Items Over SLA",
+		"- 🔴 Stuck Review [Review] (6.0 days)",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	// Without thresholds no SLA output is shown
+	report, err = workItemAgeReport(items, baseTime, DefaultOptions())
+	if err != nil {
+		t.Fatalf("workItemAgeReport() error = %v", err)
+	}
+	if strings.Contains(report, "SLA") || strings.Contains(report, "🔴") {
+		t.Errorf("Report should not contain SLA output without thresholds")
+	}
+}
+
+func TestWorkItemAgeReport_Source(t *testing.T) {
+	baseTime := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Traced Task", State: "Review", StartedAt: baseTime.AddDate(0, 0, -6),
+			Source: models.Source{File: "export.csv", Row: 11, Line: 12}},
+		{ID: "2", Name: "Built Task", State: "Review", StartedAt: baseTime.AddDate(0, 0, -2)},
+	}
+
+	report, err := workItemAgeReport(items, baseTime, DefaultOptions())
+	if err != nil {
+		t.Fatalf("workItemAgeReport() error = %v", err)
+	}
+	for _, str := range []string{
+		"- Traced Task (6.0 days, 4.0 working days, export.csv:12)",
+		"- Built Task (2.0 days, 2.0 working days)",
+	} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	result := workItemAgeResult(items, baseTime, DefaultOptions())
+	oldest := result.States[0].Oldest
+	if oldest[0].Source == nil || *oldest[0].Source != items[0].Source || oldest[1].Source != nil {
+		t.Errorf("Oldest sources = %v, %v; want %v and none", oldest[0].Source, oldest[1].Source, items[0].Source)
+	}
+}
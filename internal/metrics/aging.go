@@ -0,0 +1,213 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+)
+
+// agingPercentiles are the cycle time percentiles open items are charted against
+var agingPercentiles = []int{50, 70, 85, 95}
+
+// AtRiskPercentile is the cycle time percentile past which an open item is at risk
+const AtRiskPercentile = 85
+
+// AgingWIPReport charts open items by state against the cycle times of completed items
+func AgingWIPReport(items []models.KanbanItem, asOf time.Time) (string, error) {
+	return agingWIPReport(items, asOf, DefaultOptions())
+}
+
+// CycleTimePercentile is a percentile of the cycle times of completed items
+type CycleTimePercentile struct {
+	Percentile int     `json:"percentile"`
+	Days       float64 `json:"days"`
+}
+
+// AgingBand counts the open items per state whose age falls between two
+// cycle time percentiles
+type AgingBand struct {
+	Label  string         `json:"label"`
+	From   float64        `json:"from_days"`
+	To     *float64       `json:"to_days,omitempty"` // Open-ended for the oldest band
+	AtRisk bool           `json:"at_risk"`
+	Counts map[string]int `json:"counts"` // By state
+}
+
+// AgingWIPResult holds open items charted against completed cycle times
+type AgingWIPResult struct {
+	AsOf        string                `json:"as_of"`
+	Completed   int                   `json:"completed_items"` // Items behind the percentiles
+	Percentiles []CycleTimePercentile `json:"cycle_time_percentiles"`
+	States      []string              `json:"states"`
+	Bands       []AgingBand           `json:"bands"` // Oldest first
+	AtRisk      []AgedItem            `json:"at_risk"`
+	OverWIPLimit []WIPViolation       `json:"over_wip_limits,omitempty"` // Only set when WIP limits are configured
+}
+
+// agingWIPResult places each open item in a band between the cycle time
+// percentiles of the completed items, and lists the items older than the
+// at-risk percentile
+func agingWIPResult(items []models.KanbanItem, asOf time.Time, opts Options) (AgingWIPResult, error) {
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	var cycleTimes []float64
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		start := item.StartedAt
+		if start.IsZero() {
+			start = item.CreatedAt
+		}
+		if !start.IsZero() {
+			cycleTimes = append(cycleTimes, item.CompletedAt.Sub(start).Hours()/24)
+		}
+	}
+	if len(cycleTimes) == 0 {
+		return AgingWIPResult{}, fmt.Errorf("no completed items with start dates to compare open items with")
+	}
+
+	result := AgingWIPResult{AsOf: asOf.Format("2006-01-02"), Completed: len(cycleTimes), States: []string{}, AtRisk: []AgedItem{}}
+	var atRiskDays float64
+	for _, p := range agingPercentiles {
+		days := calculatePercentile(cycleTimes, float64(p))
+		result.Percentiles = append(result.Percentiles, CycleTimePercentile{p, days})
+		if p == AtRiskPercentile {
+			atRiskDays = days
+		}
+	}
+
+	// Bands run from the oldest, past the highest percentile, to the youngest
+	for i := len(result.Percentiles); i >= 0; i-- {
+		band := AgingBand{Counts: make(map[string]int)}
+		switch {
+		case i == len(result.Percentiles):
+			last := result.Percentiles[i-1]
+			band.Label = fmt.Sprintf("Over P%d", last.Percentile)
+			band.From = last.Days
+		case i == 0:
+			band.Label = fmt.Sprintf("Under P%d", result.Percentiles[0].Percentile)
+			band.To = &result.Percentiles[0].Days
+		default:
+			band.Label = fmt.Sprintf("P%d-P%d", result.Percentiles[i-1].Percentile, result.Percentiles[i].Percentile)
+			band.From = result.Percentiles[i-1].Days
+			band.To = &result.Percentiles[i].Days
+		}
+		band.AtRisk = band.From >= atRiskDays && i > 0
+		result.Bands = append(result.Bands, band)
+	}
+
+	seenStates := make(map[string]bool)
+	for _, item := range items {
+		if item.IsCompleted {
+			continue
+		}
+		start := item.CreatedAt
+		if !item.StartedAt.IsZero() {
+			start = item.StartedAt
+		}
+		age := asOf.Sub(start).Hours() / 24
+		state := item.State
+		if state == "" {
+			state = "Unknown"
+		}
+		if !seenStates[state] {
+			seenStates[state] = true
+			result.States = append(result.States, state)
+		}
+
+		// Bands are oldest first, so the first one the item reaches is its own
+		for i, band := range result.Bands {
+			if age >= band.From || i == len(result.Bands)-1 {
+				band.Counts[state]++
+				break
+			}
+		}
+		if age > atRiskDays {
+			workingAge := dateutil.WorkingDaysBetween(start, asOf, opts.Holidays)
+			result.AtRisk = append(result.AtRisk, AgedItem{item.Name, state, age, workingAge, opts.AgeThresholds.Status(state, age), item.SourceRef()})
+		}
+	}
+
+	sort.Strings(result.States)
+	if len(opts.WIPLimits) > 0 {
+		result.OverWIPLimit = append([]WIPViolation{}, opts.WIPLimits.currentViolations(items)...)
+	}
+	sort.Slice(result.AtRisk, func(i, j int) bool {
+		return result.AtRisk[i].Age > result.AtRisk[j].Age
+	})
+	return result, nil
+}
+
+// agingWIPReport builds the aging work in progress chart using the given options
+func agingWIPReport(items []models.KanbanItem, asOf time.Time, opts Options) (string, error) {
+	result, err := agingWIPResult(items, asOf, opts)
+	if err != nil {
+		return "", err
+	}
+
+	report := "# Aging Work in Progress\n\n"
+	report += fmt.Sprintf("Age of open items by state against the cycle times of %d completed items:\n", result.Completed)
+	var percentiles []string
+	for _, p := range result.Percentiles {
+		percentiles = append(percentiles, fmt.Sprintf("P%d %.1f days", p.Percentile, p.Days))
+	}
+	report += strings.Join(percentiles, ", ") + "\n\n"
+
+	if len(result.States) == 0 {
+		return report + "No open items.\n", nil
+	}
+
+	// One row per age band, oldest at the top, with a column per state
+	labels := make([]string, len(result.Bands))
+	labelWidth := len("Age")
+	for i, band := range result.Bands {
+		if band.To == nil {
+			labels[i] = fmt.Sprintf("%s (%.1f+ days)", band.Label, band.From)
+		} else {
+			labels[i] = fmt.Sprintf("%s (%.1f-%.1f days)", band.Label, band.From, *band.To)
+		}
+		labelWidth = max(labelWidth, len(labels[i]))
+	}
+
+	header := fmt.Sprintf("%-*s", labelWidth, "Age")
+	divider := strings.Repeat("-", labelWidth+1)
+	for _, state := range result.States {
+		header += " | " + state
+		divider += "|" + strings.Repeat("-", len([]rune(state))+2)
+	}
+	report += header + "\n" + strings.TrimSuffix(divider, "-") + "\n"
+
+	for i, band := range result.Bands {
+		row := fmt.Sprintf("%-*s", labelWidth, labels[i])
+		for _, state := range result.States {
+			cell := ""
+			if count := band.Counts[state]; count > 0 {
+				cell = fmt.Sprintf("%d", count)
+			}
+			row += fmt.Sprintf(" | %*s", len([]rune(state)), cell)
+		}
+		report += strings.TrimRight(row, " ") + "\n"
+	}
+
+	if len(result.OverWIPLimit) > 0 {
+		report += "\n" + strings.TrimSuffix(formatWIPViolations(result.OverWIPLimit), "\n")
+	}
+
+	report += fmt.Sprintf("\n## At Risk (older than P%d)\n\n", AtRiskPercentile)
+	if len(result.AtRisk) == 0 {
+		report += fmt.Sprintf("No open items are older than P%d.\n", AtRiskPercentile)
+		return report, nil
+	}
+	for _, item := range result.AtRisk {
+		report += fmt.Sprintf("- ⚠️  %s [%s] (%.1f days%s)\n", item.Name, item.State, item.Age, sourceNote(item.Source))
+	}
+
+	return report, nil
+}
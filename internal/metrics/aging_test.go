@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// agingItems are five items completed in 1 to 5 days (P50 3, P70 3.8, P85 4.4,
+// P95 4.8) and three open items aged 10, 4 and 2 days on asOf
+func agingItems(asOf time.Time) []models.KanbanItem {
+	daysAgo := func(d int) time.Time { return asOf.AddDate(0, 0, -d) }
+	items := []models.KanbanItem{
+		{Name: "Stuck", State: "In Progress", StartedAt: daysAgo(10)},
+		{Name: "Slow", State: "In Progress", StartedAt: daysAgo(4)},
+		{Name: "Fresh", State: "In Review", CreatedAt: daysAgo(2)}, // Never started
+	}
+	for d := 1; d <= 5; d++ {
+		items = append(items, models.KanbanItem{
+			Name: "Done", State: "Done", StartedAt: daysAgo(20), IsCompleted: true, CompletedAt: daysAgo(20 - d),
+		})
+	}
+	return items
+}
+
+func TestAgingWIPResult(t *testing.T) {
+	asOf := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	result, err := agingWIPResult(agingItems(asOf), asOf, DefaultOptions())
+	if err != nil {
+		t.Fatalf("agingWIPResult() error = %v", err)
+	}
+
+	if result.Completed != 5 || len(result.Percentiles) != 4 || result.Percentiles[2].Days != 4.4 {
+		t.Errorf("percentiles = %+v from %d items, want P85 4.4 from 5", result.Percentiles, result.Completed)
+	}
+	if len(result.Bands) != 5 {
+		t.Fatalf("Expected 5 bands, got %d", len(result.Bands))
+	}
+
+	// Oldest band first
+	want := []struct {
+		label  string
+		atRisk bool
+		counts map[string]int
+	}{
+		{"Over P95", true, map[string]int{"In Progress": 1}},
+		{"P85-P95", true, map[string]int{}},
+		{"P70-P85", false, map[string]int{"In Progress": 1}},
+		{"P50-P70", false, map[string]int{}},
+		{"Under P50", false, map[string]int{"In Review": 1}},
+	}
+	for i, w := range want {
+		band := result.Bands[i]
+		if band.Label != w.label || band.AtRisk != w.atRisk || len(band.Counts) != len(w.counts) {
+			t.Errorf("band %d = %+v, want %s (at risk %v) with %v", i, band, w.label, w.atRisk, w.counts)
+			continue
+		}
+		for state, count := range w.counts {
+			if band.Counts[state] != count {
+				t.Errorf("band %s has %d %s items, want %d", band.Label, band.Counts[state], state, count)
+			}
+		}
+	}
+
+	if len(result.AtRisk) != 1 || result.AtRisk[0].Name != "Stuck" || result.AtRisk[0].Age != 10 {
+		t.Errorf("at risk = %+v, want only Stuck at 10 days", result.AtRisk)
+	}
+}
+
+func TestAgingWIPResult_NoCompletedItems(t *testing.T) {
+	items := []models.KanbanItem{{Name: "Open", State: "In Progress", StartedAt: time.Now()}}
+	if _, err := agingWIPResult(items, time.Now(), DefaultOptions()); err == nil || !strings.Contains(err.Error(), "no completed items") {
+		t.Errorf("agingWIPResult() error = %v, want no completed items error", err)
+	}
+}
+
+func TestAgingWIPReport(t *testing.T) {
+	asOf := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	report, err := AgingWIPReport(agingItems(asOf), asOf)
+	if err != nil {
+		t.Fatalf("AgingWIPReport() error = %v", err)
+	}
+
+	for _, str := range []string{
+		"# Aging Work in Progress",
+		"P50 3.0 days, P70 3.8 days, P85 4.4 days, P95 4.8 days",
+		"Age                      | In Progress | In Review",
+		"Over P95 (4.8+ days)     |           1 |",
+		"Under P50 (0.0-3.0 days) |             |         1",
+		"## At Risk (older than P85)",
+		"- ⚠️  Stuck [In Progress] (10.0 days)",
+	} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// Annotation is a dated note about an event that gives context to trends,
+// such as a new hire or an incident week
+type Annotation struct {
+	Date time.Time
+	Text string
+}
+
+// Annotations is a list of annotations in date order
+type Annotations []Annotation
+
+// ParseAnnotations reads one "YYYY-MM-DD text" annotation per line.
+// Blank lines and lines starting with # are ignored.
+func ParseAnnotations(r io.Reader) (Annotations, error) {
+	var annotations Annotations
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		dateStr, text, _ := strings.Cut(line, " ")
+		date, err := time.Parse("2006-01-02", strings.TrimSuffix(dateStr, ","))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid annotation date %q (expected YYYY-MM-DD)", lineNumber, dateStr)
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return nil, fmt.Errorf("line %d: annotation for %s has no text", lineNumber, dateStr)
+		}
+
+		annotations = append(annotations, Annotation{Date: date, Text: text})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].Date.Before(annotations[j].Date)
+	})
+	return annotations, nil
+}
+
+// LoadAnnotations reads an annotations file from disk
+func LoadAnnotations(path string) (Annotations, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening annotations file: %w", err)
+	}
+	defer file.Close()
+
+	annotations, err := ParseAnnotations(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading annotations file '%s': %w", path, err)
+	}
+	return annotations, nil
+}
+
+// PeriodAnnotation is a numbered annotation falling within a reported period
+type PeriodAnnotation struct {
+	Number int    `json:"number"`
+	Date   string `json:"date"`
+	Period string `json:"period"`
+	Text   string `json:"text"`
+}
+
+// periodAnnotations numbers the annotations that fall within the given periods
+func periodAnnotations(periods []string, periodType string, opts Options) []PeriodAnnotation {
+	annotations := opts.Annotations
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	reported := make(map[string]bool)
+	for _, period := range periods {
+		reported[period] = true
+	}
+
+	var notes []PeriodAnnotation
+	for _, annotation := range annotations {
+		period := dateutil.FormatPeriodWith(annotation.Date, periodType, opts.WeekNumbering)
+		if !reported[period] {
+			continue
+		}
+		notes = append(notes, PeriodAnnotation{len(notes) + 1, annotation.Date.Format("2006-01-02"), period, annotation.Text})
+	}
+	return notes
+}
+
+// annotatePeriods returns a marker such as " [1] [2]" for each annotated
+// period and the footnotes section listing the annotations, with periods
+// labeled the given way
+func annotatePeriods(notes []PeriodAnnotation, labels types.PeriodLabels) (map[string]string, string) {
+	markers := make(map[string]string)
+	if len(notes) == 0 {
+		return markers, ""
+	}
+
+	footnotes := ""
+	for _, note := range notes {
+		markers[note.Period] += fmt.Sprintf(" [%d]", note.Number)
+		footnotes += fmt.Sprintf("[%d] %s (%s): %s\n", note.Number, note.Date, labels.Label(note.Period), note.Text)
+	}
+	return markers, "\n## Annotations\n\n" + footnotes
+}
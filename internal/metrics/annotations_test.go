@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestParseAnnotations(t *testing.T) {
+	input := `# Team events
+2024-06-17 Prod incident week
+2024-05-06 New hire: Alex
+
+2024-06-03, Offsite`
+
+	annotations, err := ParseAnnotations(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseAnnotations() error = %v", err)
+	}
+	if len(annotations) != 3 {
+		t.Fatalf("Expected 3 annotations, got %d", len(annotations))
+	}
+	if annotations[0].Text != "New hire: Alex" || annotations[2].Text != "Prod incident week" {
+		t.Errorf("Annotations not sorted by date: %+v", annotations)
+	}
+	if annotations[1].Text != "Offsite" {
+		t.Errorf("Comma after date not handled: %+v", annotations[1])
+	}
+
+	for _, bad := range []string{"June 1 Offsite", "2024-06-01"} {
+		if _, err := ParseAnnotations(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseAnnotations(%q) should fail", bad)
+		}
+	}
+}
+
+func TestAnnotatedTrends(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", IsCompleted: true, Estimate: 3, CompletedAt: time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)},
+		{ID: "2", IsCompleted: true, Estimate: 2, CompletedAt: time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)},
+	}
+	opts := DefaultOptions()
+	opts.Annotations = Annotations{
+		{Date: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), Text: "New hire"},
+		{Date: time.Date(2024, 6, 17, 0, 0, 0, 0, time.UTC), Text: "Prod incident week"},
+		{Date: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), Text: "Outside the report"},
+	}
+
+	throughput, err := throughputReport(items, "month", opts)
+	if err != nil {
+		t.Fatalf("throughputReport() error = %v", err)
+	}
+	improvement, err := teamImprovementReport(items, opts)
+	if err != nil {
+		t.Fatalf("teamImprovementReport() error = %v", err)
+	}
+
+	for name, report := range map[string]string{"throughput": throughput, "improvement": improvement} {
+		expected := []string{
+			"## Annotations",
+			"[1] 2024-05-06 (2024-05): New hire",
+			"[2] 2024-06-17 (2024-06): Prod incident week",
+		}
+		for _, str := range expected {
+			if !strings.Contains(report, str) {
+				t.Errorf("%s report doesn't contain %q", name, str)
+			}
+		}
+		if strings.Contains(report, "Outside the report") {
+			t.Errorf("%s report should skip annotations outside the reported periods", name)
+		}
+	}
+
+	if !strings.Contains(throughput, "2024-06 |               1 |         2.0 |            2.0 [2]") {
+		t.Errorf("Throughput row should carry the annotation marker:\n%s", throughput)
+	}
+}
@@ -0,0 +1,303 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// BenchmarkGroup holds one group's benchmark measures. A NaN measure means no data.
+type BenchmarkGroup struct {
+	Name           string
+	ItemCount      int
+	P85CycleTime   float64
+	ThroughputCV   float64
+	FlowEfficiency float64
+	WIPAge         float64
+	WIPCount       int
+	Score          float64
+}
+
+// benchmarkGroupJSON is the JSON form of a BenchmarkGroup
+type benchmarkGroupJSON struct {
+	Name           string   `json:"name"`
+	ItemCount      int      `json:"items"`
+	P85CycleTime   *float64 `json:"p85_cycle_time"`
+	ThroughputCV   *float64 `json:"throughput_cv"`
+	FlowEfficiency *float64 `json:"flow_efficiency_percent"`
+	WIPAge         *float64 `json:"wip_age"`
+	WIPCount       int      `json:"wip_items"`
+	Score          *float64 `json:"score"`
+}
+
+// MarshalJSON writes measures without data as null, since JSON has no NaN
+func (g BenchmarkGroup) MarshalJSON() ([]byte, error) {
+	measure := func(value float64) *float64 {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil
+		}
+		return &value
+	}
+	return json.Marshal(benchmarkGroupJSON{g.Name, g.ItemCount, measure(g.P85CycleTime), measure(g.ThroughputCV),
+		measure(g.FlowEfficiency), measure(g.WIPAge), g.WIPCount, measure(g.Score)})
+}
+
+// JSONShape returns the type a BenchmarkGroup is written as, for the JSON schema
+func (BenchmarkGroup) JSONShape() interface{} {
+	return benchmarkGroupJSON{}
+}
+
+// BenchmarkResult holds the groups ranked from best to worst
+type BenchmarkResult struct {
+	GroupBy string            `json:"group_by"`
+	Groups  []*BenchmarkGroup `json:"groups"`
+}
+
+// BenchmarkReport ranks teams against each other on cycle time, throughput
+// stability, flow efficiency and the age of their work in progress
+func BenchmarkReport(items []models.KanbanItem, asOf time.Time) (string, error) {
+	return benchmarkReport(items, items, asOf, DefaultOptions())
+}
+
+// benchmarkResult measures and ranks groups from completed items and the
+// current work in progress, grouping by opts.SplitBy
+func benchmarkResult(items, wip []models.KanbanItem, asOf time.Time, opts Options) (BenchmarkResult, error) {
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	splitBy := opts.SplitBy
+	if splitBy == "" {
+		splitBy = SplitByTeam
+	}
+
+	type groupData struct {
+		CycleTimes []float64
+		ByMonth    map[string]int
+		Waiting    float64
+		Active     float64
+		Ages       []float64
+	}
+
+	dataByGroup := make(map[string]*groupData)
+	getGroup := func(name string) *groupData {
+		data, exists := dataByGroup[name]
+		if !exists {
+			data = &groupData{ByMonth: make(map[string]int)}
+			dataByGroup[name] = data
+		}
+		return data
+	}
+
+	var firstMonth, lastMonth time.Time
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		data := getGroup(splitBy.GroupOf(item))
+
+		start := item.StartedAt
+		if start.IsZero() {
+			start = item.CreatedAt
+		}
+		if !start.IsZero() {
+			data.CycleTimes = append(data.CycleTimes, item.CompletedAt.Sub(start).Hours()/24)
+		}
+
+		// Same simplified flow as the flow efficiency report: created → started → completed
+		if !item.CreatedAt.IsZero() {
+			if !item.StartedAt.IsZero() {
+				data.Waiting += item.StartedAt.Sub(item.CreatedAt).Hours() / 24
+				data.Active += item.CompletedAt.Sub(item.StartedAt).Hours() / 24
+			} else {
+				data.Active += item.CompletedAt.Sub(item.CreatedAt).Hours() / 24
+			}
+		}
+
+		month := time.Date(item.CompletedAt.Year(), item.CompletedAt.Month(), 1, 0, 0, 0, 0, time.UTC)
+		data.ByMonth[month.Format("2006-01")]++
+		if firstMonth.IsZero() || month.Before(firstMonth) {
+			firstMonth = month
+		}
+		if month.After(lastMonth) {
+			lastMonth = month
+		}
+	}
+
+	for _, item := range wip {
+		if item.IsCompleted {
+			continue
+		}
+		start := item.StartedAt
+		if start.IsZero() {
+			start = item.CreatedAt
+		}
+		if start.IsZero() {
+			continue
+		}
+		data := getGroup(splitBy.GroupOf(item))
+		data.Ages = append(data.Ages, asOf.Sub(start).Hours()/24)
+	}
+
+	if len(dataByGroup) == 0 {
+		return BenchmarkResult{}, fmt.Errorf("no items to benchmark")
+	}
+
+	// Every group is measured over the same months, counting months without completions
+	var months []string
+	for month := firstMonth; !firstMonth.IsZero() && !month.After(lastMonth); month = month.AddDate(0, 1, 0) {
+		months = append(months, month.Format("2006-01"))
+	}
+
+	var groups []*BenchmarkGroup
+	for name, data := range dataByGroup {
+		group := &BenchmarkGroup{
+			Name:           name,
+			ItemCount:      len(data.CycleTimes),
+			P85CycleTime:   math.NaN(),
+			ThroughputCV:   math.NaN(),
+			FlowEfficiency: math.NaN(),
+			WIPAge:         math.NaN(),
+			WIPCount:       len(data.Ages),
+		}
+		if len(data.CycleTimes) > 0 {
+			group.P85CycleTime = calculatePercentile(data.CycleTimes, 85)
+		}
+		if len(months) > 1 {
+			var counts []float64
+			for _, month := range months {
+				counts = append(counts, float64(data.ByMonth[month]))
+			}
+			_, _, avg, _, stddev := calculateStats(counts)
+			if avg > 0 {
+				group.ThroughputCV = stddev / avg
+			}
+		}
+		if total := data.Waiting + data.Active; total > 0 {
+			group.FlowEfficiency = data.Active / total * 100
+		}
+		if len(data.Ages) > 0 {
+			_, _, _, median, _ := calculateStats(data.Ages)
+			group.WIPAge = median
+		}
+		groups = append(groups, group)
+	}
+
+	// Sort by name first so ties are ranked the same way on every run
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	rankGroups(groups)
+
+	return BenchmarkResult{GroupBy: string(splitBy), Groups: groups}, nil
+}
+
+// benchmarkReport builds the benchmark report from completed items and the
+// current work in progress, grouping by opts.SplitBy
+func benchmarkReport(items, wip []models.KanbanItem, asOf time.Time, opts Options) (string, error) {
+	result, err := benchmarkResult(items, wip, asOf, opts)
+	if err != nil {
+		return "", err
+	}
+	splitBy := opts.SplitBy
+	if splitBy == "" {
+		splitBy = SplitByTeam
+	}
+	groups := result.Groups
+
+	report := fmt.Sprintf("# %s Benchmark\n\n", splitBy.Title())
+	report += fmt.Sprintf("Ranks each %s on four flow measures. Lower is better except for flow efficiency.\n\n", strings.ToLower(splitBy.Title()))
+	report += "- **P85 Cycle Time**: 85% of items finished within this many days of starting\n"
+	report += "- **Throughput CV**: Variation of monthly completions (stddev / mean); lower is more predictable\n"
+	report += "- **Flow Efficiency**: Share of lead time spent actively working\n"
+	report += "- **WIP Age**: Median age in days of items still in progress\n\n"
+
+	report += "## ⚠️ Read this before comparing\n\n"
+	report += "- Teams differ in work type, item size, estimation habits and board conventions; a rank is a prompt for a conversation, not a verdict\n"
+	report += "- Small groups produce noisy numbers; treat groups with few items with extra care\n"
+	report += "- Measures depend on how consistently states and dates are recorded in each board\n"
+	report += "- Never use this table to rate individuals or set targets; that reliably makes the numbers worse and less honest\n\n"
+
+	nameWidth := len(splitBy.Title())
+	for _, group := range groups {
+		if len(group.Name) > nameWidth {
+			nameWidth = len(group.Name)
+		}
+	}
+
+	report += fmt.Sprintf("Rank | %-*s | Items | P85 Cycle Time | Throughput CV | Flow Efficiency | WIP Age (items)\n", nameWidth, splitBy.Title())
+	report += fmt.Sprintf("-----|%s|-------|----------------|---------------|-----------------|----------------\n", strings.Repeat("-", nameWidth+2))
+	for i, group := range groups {
+		report += fmt.Sprintf("%4d | %-*s | %5d | %14s | %13s | %15s | %15s\n",
+			i+1,
+			nameWidth, group.Name,
+			group.ItemCount,
+			formatMeasure(group.P85CycleTime, "%.1f days"),
+			formatMeasure(group.ThroughputCV, "%.2f"),
+			formatMeasure(group.FlowEfficiency, "%.1f%%"),
+			fmt.Sprintf("%s (%d)", formatMeasure(group.WIPAge, "%.1f days"), group.WIPCount))
+	}
+	report += "\nRank is the average of each measure's rank; measures without data are not counted.\n"
+
+	return report, nil
+}
+
+// rankGroups scores each group by its average rank across the measures and sorts by score
+func rankGroups(groups []*BenchmarkGroup) {
+	measures := []struct {
+		value       func(*BenchmarkGroup) float64
+		higherFirst bool
+	}{
+		{func(g *BenchmarkGroup) float64 { return g.P85CycleTime }, false},
+		{func(g *BenchmarkGroup) float64 { return g.ThroughputCV }, false},
+		{func(g *BenchmarkGroup) float64 { return g.FlowEfficiency }, true},
+		{func(g *BenchmarkGroup) float64 { return g.WIPAge }, false},
+	}
+
+	rankSums := make(map[*BenchmarkGroup]float64)
+	rankCounts := make(map[*BenchmarkGroup]int)
+	for _, measure := range measures {
+		var ranked []*BenchmarkGroup
+		for _, group := range groups {
+			if !math.IsNaN(measure.value(group)) {
+				ranked = append(ranked, group)
+			}
+		}
+		sort.SliceStable(ranked, func(i, j int) bool {
+			if measure.higherFirst {
+				return measure.value(ranked[i]) > measure.value(ranked[j])
+			}
+			return measure.value(ranked[i]) < measure.value(ranked[j])
+		})
+		for i, group := range ranked {
+			rankSums[group] += float64(i + 1)
+			rankCounts[group]++
+		}
+	}
+
+	for _, group := range groups {
+		group.Score = math.Inf(1)
+		if rankCounts[group] > 0 {
+			group.Score = rankSums[group] / float64(rankCounts[group])
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Score != groups[j].Score {
+			return groups[i].Score < groups[j].Score
+		}
+		return groups[i].Name < groups[j].Name
+	})
+}
+
+// formatMeasure formats a benchmark measure, showing "n/a" when there is no data
+func formatMeasure(value float64, format string) string {
+	if math.IsNaN(value) {
+		return "n/a"
+	}
+	return fmt.Sprintf(format, value)
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestBenchmarkReport(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	items := []models.KanbanItem{
+		// Fast, steady team: 2-day cycle times every month, no waiting
+		{ID: "1", Team: "Fast", IsCompleted: true, CreatedAt: day(5, 1), StartedAt: day(5, 1), CompletedAt: day(5, 3)},
+		{ID: "2", Team: "Fast", IsCompleted: true, CreatedAt: day(6, 1), StartedAt: day(6, 1), CompletedAt: day(6, 3)},
+		{ID: "3", Team: "Fast", IsCompleted: false, CreatedAt: day(6, 20), StartedAt: day(6, 28)},
+		// Slow, bursty team: long waits and cycle times, everything in one month
+		{ID: "4", Team: "Slow", IsCompleted: true, CreatedAt: day(4, 1), StartedAt: day(5, 1), CompletedAt: day(6, 1)},
+		{ID: "5", Team: "Slow", IsCompleted: true, CreatedAt: day(4, 1), StartedAt: day(5, 1), CompletedAt: day(6, 5)},
+		{ID: "6", Team: "Slow", IsCompleted: false, CreatedAt: day(3, 1), StartedAt: day(4, 1)},
+	}
+
+	report, err := benchmarkReport(items, items, day(7, 1), DefaultOptions())
+	if err != nil {
+		t.Fatalf("benchmarkReport() error = %v", err)
+	}
+
+	expected := []string{
+		"# Team Benchmark",
+		"Read this before comparing",
+		"Rank | Team | Items | P85 Cycle Time | Throughput CV | Flow Efficiency | WIP Age (items)",
+		"   1 | Fast |     2 |       2.0 days |          0.00 |          100.0% |    3.0 days (1)",
+		"   2 | Slow |     2 |",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
+
+func TestBenchmarkReport_SplitBy(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", ProductArea: "Billing", IsCompleted: true, CreatedAt: time.Now().AddDate(0, 0, -5), CompletedAt: time.Now()},
+		{ID: "2", IsCompleted: true, CreatedAt: time.Now().AddDate(0, 0, -5), CompletedAt: time.Now()},
+	}
+
+	opts := DefaultOptions()
+	opts.SplitBy = SplitByProductArea
+	report, err := benchmarkReport(items, items, time.Now(), opts)
+	if err != nil {
+		t.Fatalf("benchmarkReport() error = %v", err)
+	}
+
+	for _, str := range []string{"# Product Area Benchmark", "Billing", "Unspecified", "n/a"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q", str)
+		}
+	}
+}
+
+func TestBenchmarkReport_NoItems(t *testing.T) {
+	if _, err := BenchmarkReport(nil, time.Now()); err == nil {
+		t.Errorf("BenchmarkReport() with no items should return an error")
+	}
+}
@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// otherColumn is the column that collects types dropped to fit the width
+const otherColumn = "Other"
+
+// typeBreakdownTable renders per-period counts by item type. When width is
+// positive and the table would be wider, the least frequent types are folded
+// into an "Other" column so lines are not wrapped by the terminal.
+func typeBreakdownTable(periodName string, periods []string, counts map[string]map[string]int, width int) string {
+	totals := make(map[string]int)
+	for _, period := range periods {
+		for itemType, count := range counts[period] {
+			totals[itemType] += count
+		}
+	}
+
+	var typesList []string
+	for itemType := range totals {
+		typesList = append(typesList, itemType)
+	}
+	sort.Strings(typesList)
+
+	labelWidth := len(periodName)
+	for _, period := range periods {
+		if len(period) > labelWidth {
+			labelWidth = len(period)
+		}
+	}
+
+	shown, folded := fitTypeColumns(typesList, totals, labelWidth, width)
+
+	columns := shown
+	if len(folded) > 0 {
+		columns = append(append([]string{}, shown...), otherColumn)
+	}
+	columns = append(columns, "Total")
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-*s", labelWidth, periodName))
+	for _, column := range columns {
+		sb.WriteString(fmt.Sprintf(" | %*s", columnWidth(column), column))
+	}
+	sb.WriteString("\n")
+
+	separator := strings.Repeat("-", labelWidth+1)
+	for _, column := range columns {
+		separator += "|" + strings.Repeat("-", columnWidth(column)+2)
+	}
+	sb.WriteString(strings.TrimSuffix(separator, "-") + "\n")
+
+	for _, period := range periods {
+		sb.WriteString(fmt.Sprintf("%-*s", labelWidth, period))
+
+		periodTotal := 0
+		for _, itemType := range shown {
+			count := counts[period][itemType]
+			sb.WriteString(fmt.Sprintf(" | %*d", columnWidth(itemType), count))
+			periodTotal += count
+		}
+		if len(folded) > 0 {
+			other := 0
+			for _, itemType := range folded {
+				other += counts[period][itemType]
+			}
+			sb.WriteString(fmt.Sprintf(" | %*d", columnWidth(otherColumn), other))
+			periodTotal += other
+		}
+
+		sb.WriteString(fmt.Sprintf(" | %*d\n", columnWidth("Total"), periodTotal))
+	}
+
+	if len(folded) > 0 {
+		sb.WriteString(fmt.Sprintf("\n%s combines %d less frequent %s to fit %d columns: %s\n",
+			otherColumn, len(folded), pluralize("type", len(folded)), width, strings.Join(folded, ", ")))
+	}
+
+	return sb.String()
+}
+
+// fitTypeColumns chooses which types get their own column within width,
+// keeping the most frequent ones. The remaining types are returned as folded,
+// both lists in alphabetical order.
+func fitTypeColumns(typesList []string, totals map[string]int, labelWidth, width int) ([]string, []string) {
+	lineWidth := labelWidth + len(" | ") + columnWidth("Total")
+	for _, itemType := range typesList {
+		lineWidth += len(" | ") + columnWidth(itemType)
+	}
+	if width <= 0 || lineWidth <= width || len(typesList) < 2 {
+		return typesList, nil
+	}
+
+	byTotal := append([]string{}, typesList...)
+	sort.SliceStable(byTotal, func(i, j int) bool {
+		return totals[byTotal[i]] > totals[byTotal[j]]
+	})
+
+	// Reserve room for the period label, the Other column and the total
+	used := labelWidth + 2*len(" | ") + columnWidth(otherColumn) + columnWidth("Total")
+	keep := make(map[string]bool)
+	for _, itemType := range byTotal {
+		needed := len(" | ") + columnWidth(itemType)
+		if used+needed > width {
+			break
+		}
+		used += needed
+		keep[itemType] = true
+	}
+
+	var shown, folded []string
+	for _, itemType := range typesList {
+		if keep[itemType] {
+			shown = append(shown, itemType)
+		} else {
+			folded = append(folded, itemType)
+		}
+	}
+	return shown, folded
+}
+
+// columnWidth returns the width of a count column with the given header
+func columnWidth(header string) int {
+	if len(header) < 5 {
+		return 5
+	}
+	return len(header)
+}
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// capacityPeriod holds the completed work of one period for capacity adjustment
+type capacityPeriod struct {
+	Label  string
+	Date   time.Time // Any date within the period
+	Count  int
+	Amount float64
+}
+
+// periodBounds returns the first and last day of the week or month containing t
+func periodBounds(t time.Time, periodType string, numbering types.WeekNumbering) (time.Time, time.Time) {
+	if periodType == "week" {
+		start := numbering.StartOfWeek(t)
+		return start, start.AddDate(0, 0, 6)
+	}
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 1, -1)
+}
+
+// CapacityAdjustment is one period's throughput divided by the share of team
+// capacity available in it
+type CapacityAdjustment struct {
+	Period         string   `json:"period"`
+	Capacity       float64  `json:"capacity"` // Share of full capacity, 0 to 1
+	Items          int      `json:"items"`
+	AdjustedItems  float64  `json:"adjusted_items"`
+	AdjustedAmount float64  `json:"adjusted_amount"`
+	AdjustedChange *float64 `json:"adjusted_change_percent,omitempty"`
+}
+
+// capacityAdjustments divides each period's throughput by the share of team
+// capacity available in it, so periods with absences can be compared with full
+// ones. It returns nil when no absences calendar is configured.
+func capacityAdjustments(periodType string, periods []capacityPeriod, opts Options) []CapacityAdjustment {
+	if len(opts.Absences) == 0 || len(periods) == 0 {
+		return nil
+	}
+
+	var adjustments []CapacityAdjustment
+	prevAdjusted := 0.0
+	for i, period := range periods {
+		start, end := periodBounds(period.Date, periodType, opts.WeekNumbering)
+		capacity := opts.Absences.Availability(start, end, opts.Holidays)
+
+		// A period with no capacity at all can't be adjusted meaningfully
+		adjustment := CapacityAdjustment{
+			Period:         period.Label,
+			Capacity:       capacity,
+			Items:          period.Count,
+			AdjustedItems:  float64(period.Count),
+			AdjustedAmount: period.Amount,
+		}
+		if capacity > 0 {
+			adjustment.AdjustedItems /= capacity
+			adjustment.AdjustedAmount /= capacity
+		}
+
+		if i > 0 && prevAdjusted > 0 {
+			change := (adjustment.AdjustedItems - prevAdjusted) / prevAdjusted * 100
+			adjustment.AdjustedChange = &change
+		}
+		prevAdjusted = adjustment.AdjustedItems
+
+		adjustments = append(adjustments, adjustment)
+	}
+	return adjustments
+}
+
+// capacityAdjustedSection formats the capacity-adjusted throughput of each period
+func capacityAdjustedSection(title, periodName string, adjustments []CapacityAdjustment, opts Options) string {
+	if len(adjustments) == 0 {
+		return ""
+	}
+
+	report := fmt.Sprintf("\n## %s\n\n", title)
+	report += "Throughput divided by the share of team capacity available (working days less absences), "
+	report += "so a dip in a period when the team was away is not mistaken for a decline.\n\n"
+
+	amountTitle := "Adj. " + opts.Unit.ColumnTitle()
+	if opts.Unit.CountsItems() {
+		report += fmt.Sprintf("%s | Capacity | Items | Adj. Items | Adj. Δ\n", periodName)
+		report += fmt.Sprintf("%s|----------|-------|------------|--------\n", strings.Repeat("-", len(periodName)+1))
+	} else {
+		report += fmt.Sprintf("%s | Capacity | Items | Adj. Items | %s | Adj. Δ\n", periodName, amountTitle)
+		report += fmt.Sprintf("%s|----------|-------|------------|%s|--------\n",
+			strings.Repeat("-", len(periodName)+1), strings.Repeat("-", len(amountTitle)+2))
+	}
+
+	reduced := 0
+	for _, adjustment := range adjustments {
+		marker := " "
+		if adjustment.Capacity < 1 {
+			marker = "*"
+			reduced++
+		}
+
+		change := ""
+		if adjustment.AdjustedChange != nil {
+			change = fmt.Sprintf("%+.0f%%", *adjustment.AdjustedChange)
+		}
+
+		report += fmt.Sprintf("%-*s | %6.0f%%%s | %5d | %10.1f", len(periodName), opts.PeriodLabels.Label(adjustment.Period), adjustment.Capacity*100, marker, adjustment.Items, adjustment.AdjustedItems)
+		if !opts.Unit.CountsItems() {
+			report += fmt.Sprintf(" | %*.1f", len(amountTitle), adjustment.AdjustedAmount)
+		}
+		report += fmt.Sprintf(" | %6s\n", change)
+	}
+
+	if reduced > 0 {
+		report += fmt.Sprintf("\n* %d %s with reduced capacity from the absences calendar\n", reduced, pluralize(strings.ToLower(periodName), reduced))
+	}
+
+	return report
+}
+
+// pluralize adds an "s" to word unless count is 1
+func pluralize(word string, count int) string {
+	if count == 1 {
+		return word
+	}
+	return word + "s"
+}
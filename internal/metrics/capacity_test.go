@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestPeriodBounds(t *testing.T) {
+	date := time.Date(2024, 7, 17, 15, 0, 0, 0, time.UTC) // Wednesday
+
+	start, end := periodBounds(date, "week", types.WeekNumberingISO)
+	if start.Format("2006-01-02") != "2024-07-15" || end.Format("2006-01-02") != "2024-07-21" {
+		t.Errorf("Week bounds = %s..%s, want 2024-07-15..2024-07-21", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+
+	start, end = periodBounds(date, "week", types.WeekNumberingUS)
+	if start.Format("2006-01-02") != "2024-07-14" || end.Format("2006-01-02") != "2024-07-20" {
+		t.Errorf("US week bounds = %s..%s, want 2024-07-14..2024-07-20", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+
+	start, end = periodBounds(date, "month", types.WeekNumberingISO)
+	if start.Format("2006-01-02") != "2024-07-01" || end.Format("2006-01-02") != "2024-07-31" {
+		t.Errorf("Month bounds = %s..%s, want 2024-07-01..2024-07-31", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+}
+
+func TestCapacityAdjustedThroughput(t *testing.T) {
+	var items []models.KanbanItem
+	for i := 0; i < 4; i++ {
+		items = append(items, models.KanbanItem{ID: "j", IsCompleted: true, Estimate: 2, CompletedAt: time.Date(2024, 6, 10+i, 12, 0, 0, 0, time.UTC)})
+	}
+	for i := 0; i < 2; i++ {
+		items = append(items, models.KanbanItem{ID: "k", IsCompleted: true, Estimate: 2, CompletedAt: time.Date(2024, 7, 10+i, 12, 0, 0, 0, time.UTC)})
+	}
+
+	opts := DefaultOptions()
+	report, err := throughputReport(items, "month", opts)
+	if err != nil {
+		t.Fatalf("throughputReport() error = %v", err)
+	}
+	if strings.Contains(report, "Capacity-Adjusted") {
+		t.Errorf("Capacity section should only be shown with an absences calendar")
+	}
+
+	// Half the team away for all of July
+	opts.Absences = dateutil.Absences{{
+		Start:    time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC),
+		Fraction: 0.5,
+	}}
+	report, err = throughputReport(items, "month", opts)
+	if err != nil {
+		t.Fatalf("throughputReport() error = %v", err)
+	}
+
+	expected := []string{
+		"## Capacity-Adjusted Throughput",
+		"2024-06 |    100%  |     4 |        4.0 |         8.0 |",
+		"2024-07 |     50%* |     2 |        4.0 |         8.0 |    +0%",
+		"* 1 month with reduced capacity",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	improvement, err := teamImprovementReport(items, opts)
+	if err != nil {
+		t.Fatalf("teamImprovementReport() error = %v", err)
+	}
+	if !strings.Contains(improvement, "## Capacity-Adjusted Trends") {
+		t.Errorf("Improvement report should include capacity-adjusted trends")
+	}
+}
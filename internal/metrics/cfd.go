@@ -0,0 +1,224 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+)
+
+// Approximate states used for the cumulative flow when there is no state history
+const (
+	cfdNotStarted = "Not Started"
+	cfdInProgress = "In Progress"
+	cfdDone       = "Done"
+)
+
+// CumulativeFlowReport counts the items in each state at the end of every period
+func CumulativeFlowReport(items []models.KanbanItem, periodType string) (string, error) {
+	return cumulativeFlowReport(items, periodType, DefaultOptions())
+}
+
+// CFDSnapshot is the number of items in each state at the end of a period
+type CFDSnapshot struct {
+	Period string         `json:"period"`
+	Counts map[string]int `json:"counts"`
+	Total  int            `json:"total"`
+}
+
+// CFDResult holds the cumulative flow snapshots in workflow state order
+type CFDResult struct {
+	Source    string        `json:"source"` // FlowSourceHistory or FlowSourceDates
+	Tracked   int           `json:"tracked_items"`
+	States    []string      `json:"states"`
+	Snapshots []CFDSnapshot `json:"snapshots"`
+	OverWIPLimit []WIPViolation `json:"over_wip_limits,omitempty"` // Teams over their WIP limits at the end of a period; only set when WIP limits are configured
+}
+
+// cumulativeFlowResult counts the items in each state at the end of every
+// period. With a state history the real states are used; otherwise items are
+// placed in Not Started, In Progress or Done from their created, started and
+// completed dates.
+func cumulativeFlowResult(items []models.KanbanItem, periodType string, opts Options) (CFDResult, error) {
+	useHistory := false
+	for _, item := range items {
+		if item.HasHistory() {
+			useHistory = true
+			break
+		}
+	}
+
+	var tracked []models.KanbanItem
+	var first, last time.Time
+	widen := func(t time.Time) {
+		if t.IsZero() {
+			return
+		}
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	for _, item := range items {
+		if useHistory {
+			if !item.HasHistory() {
+				continue
+			}
+			widen(item.History[0].At)
+			widen(item.History[len(item.History)-1].At)
+		} else {
+			if item.CreatedAt.IsZero() {
+				continue
+			}
+			widen(item.CreatedAt)
+			widen(item.StartedAt)
+			if item.IsCompleted {
+				widen(item.CompletedAt)
+			}
+		}
+		tracked = append(tracked, item)
+	}
+	if len(tracked) == 0 {
+		return CFDResult{}, fmt.Errorf("no items with dates for a cumulative flow")
+	}
+
+	stateOf := approximateState
+	states := []string{cfdNotStarted, cfdInProgress, cfdDone}
+	if useHistory {
+		stateOf = func(item models.KanbanItem, t time.Time) string { return item.StateAt(t) }
+		states = historyStateOrder(tracked)
+	}
+
+	result := CFDResult{Source: FlowSourceDates, Tracked: len(tracked), States: states}
+	if useHistory {
+		result.Source = FlowSourceHistory
+	}
+	start := dateutil.GetStartOfPeriod(first, periodType)
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	if periodType == "week" {
+		start = opts.WeekNumbering.StartOfWeek(first)
+	}
+	for !start.After(last) {
+		next := start.AddDate(0, 1, 0)
+		if periodType == "week" {
+			next = start.AddDate(0, 0, 7)
+		}
+		end := next.Add(-time.Nanosecond)
+
+		snapshot := CFDSnapshot{Period: dateutil.FormatPeriodWith(end, periodType, opts.WeekNumbering), Counts: make(map[string]int)}
+		for _, item := range tracked {
+			if state := stateOf(item, end); state != "" {
+				snapshot.Counts[state]++
+				snapshot.Total++
+			}
+		}
+		result.Snapshots = append(result.Snapshots, snapshot)
+		if len(opts.WIPLimits) > 0 {
+			result.OverWIPLimit = append(result.OverWIPLimit, opts.WIPLimits.violations(tracked, func(item models.KanbanItem) string { return stateOf(item, end) }, snapshot.Period)...)
+		}
+		start = next
+	}
+
+	return result, nil
+}
+
+// cumulativeFlowReport builds the cumulative flow table
+func cumulativeFlowReport(items []models.KanbanItem, periodType string, opts Options) (string, error) {
+	result, err := cumulativeFlowResult(items, periodType, opts)
+	if err != nil {
+		return "", err
+	}
+
+	periodName := "Month"
+	if periodType == "week" {
+		periodName = "Week"
+	}
+
+	report := "# Cumulative Flow\n\n"
+	if result.Source == FlowSourceHistory {
+		report += fmt.Sprintf("Items in each state at the end of every %s, from the state history of %d items.\n\n", strings.ToLower(periodName), result.Tracked)
+	} else {
+		report += fmt.Sprintf("Items in each state at the end of every %s, approximated from created_at, started_at and completed_at. Supply a state history file for the real workflow states.\n\n", strings.ToLower(periodName))
+	}
+
+	labelWidth := len(periodName)
+	for _, s := range result.Snapshots {
+		if len(s.Period) > labelWidth {
+			labelWidth = len(s.Period)
+		}
+	}
+	columns := append(append([]string{}, result.States...), "Total")
+
+	report += fmt.Sprintf("%-*s", labelWidth, periodName)
+	separator := strings.Repeat("-", labelWidth+1)
+	for _, column := range columns {
+		report += fmt.Sprintf(" | %*s", columnWidth(column), column)
+		separator += "|" + strings.Repeat("-", columnWidth(column)+2)
+	}
+	report += "\n" + strings.TrimSuffix(separator, "-") + "\n"
+
+	for _, s := range result.Snapshots {
+		report += fmt.Sprintf("%-*s", labelWidth, s.Period)
+		for _, state := range result.States {
+			report += fmt.Sprintf(" | %*d", columnWidth(state), s.Counts[state])
+		}
+		report += fmt.Sprintf(" | %*d\n", columnWidth("Total"), s.Total)
+	}
+
+	report += "\nA widening band means items pile up in that state; parallel bands mean steady flow.\n"
+	if len(result.OverWIPLimit) > 0 {
+		report += "\n" + strings.TrimSuffix(formatWIPViolations(result.OverWIPLimit), "\n")
+	}
+	return report, nil
+}
+
+// approximateState places an item in Not Started, In Progress or Done at t
+// from its dates, or returns "" before it was created
+func approximateState(item models.KanbanItem, t time.Time) string {
+	switch {
+	case item.CreatedAt.After(t):
+		return ""
+	case item.IsCompleted && !item.CompletedAt.IsZero() && !item.CompletedAt.After(t):
+		return cfdDone
+	case !item.StartedAt.IsZero() && !item.StartedAt.After(t):
+		return cfdInProgress
+	}
+	return cfdNotStarted
+}
+
+// historyStateOrder orders the states found in the items' histories by their
+// average position in an item's path through the workflow
+func historyStateOrder(items []models.KanbanItem) []string {
+	positions := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, item := range items {
+		seen := make(map[string]bool)
+		for i, transition := range item.History {
+			if seen[transition.To] {
+				continue
+			}
+			seen[transition.To] = true
+			positions[transition.To] += float64(i)
+			counts[transition.To]++
+		}
+	}
+
+	var states []string
+	for state := range positions {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		pi := positions[states[i]] / float64(counts[states[i]])
+		pj := positions[states[j]] / float64(counts[states[j]])
+		if pi != pj {
+			return pi < pj
+		}
+		return states[i] < states[j]
+	})
+	return states
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestCumulativeFlowReport_Approximate(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	items := []models.KanbanItem{
+		{ID: "1", CreatedAt: day(5, 2), StartedAt: day(5, 10), CompletedAt: day(6, 3), IsCompleted: true},
+		{ID: "2", CreatedAt: day(5, 20)},
+		{ID: "3", CreatedAt: day(6, 1), StartedAt: day(6, 15)},
+	}
+
+	report, err := cumulativeFlowReport(items, "month", DefaultOptions())
+	if err != nil {
+		t.Fatalf("cumulativeFlowReport() error = %v", err)
+	}
+
+	expected := []string{
+		"# Cumulative Flow",
+		"approximated from created_at, started_at and completed_at",
+		"Month   | Not Started | In Progress |  Done | Total",
+		"2024-05 |           1 |           1 |     0 |     2",
+		"2024-06 |           1 |           1 |     1 |     3",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
+
+func TestCumulativeFlowReport_History(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 6, d, 12, 0, 0, 0, time.UTC)
+	}
+	history := func(states ...string) []models.StateTransition {
+		var transitions []models.StateTransition
+		for i, state := range states {
+			transitions = append(transitions, models.StateTransition{To: state, At: day(3 + i*7)})
+		}
+		return transitions
+	}
+
+	items := []models.KanbanItem{
+		{ID: "1", History: history("Ready", "Doing", "Review", "Done")},
+		{ID: "2", History: history("Ready", "Doing")},
+		// No history: left out once any item has one
+		{ID: "3", CreatedAt: day(1)},
+	}
+
+	report, err := cumulativeFlowReport(items, "month", DefaultOptions())
+	if err != nil {
+		t.Fatalf("cumulativeFlowReport() error = %v", err)
+	}
+
+	expected := []string{
+		"from the state history of 2 items",
+		"Month   | Ready | Doing | Review |  Done | Total",
+		"2024-06 |     0 |     1 |      0 |     1 |     2",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
+
+func TestCumulativeFlowReport_NoItems(t *testing.T) {
+	if _, err := cumulativeFlowReport([]models.KanbanItem{{ID: "1"}}, "week", DefaultOptions()); err == nil {
+		t.Error("cumulativeFlowReport() expected error for items without dates")
+	}
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/charts"
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// Charts draws the throughput, the cumulative flow and the cycle time
+// scatterplot of the items in the date range as images. A chart without data
+// to draw, such as a scatterplot without completed items, is left out.
+func (g *Generator) Charts(periodType PeriodType, startDate, endDate time.Time, filterField models.FilterField) ([]charts.Chart, error) {
+	if err := g.ctx.Err(); err != nil {
+		return nil, err
+	}
+	items := g.filterItemsByDateRange(startDate, endDate, filterField)
+
+	var drawn []charts.Chart
+	if throughput := throughputResult(items, string(periodType), g.opts); len(throughput.Periods) > 0 {
+		drawn = append(drawn, throughputChart(throughput, periodType))
+	}
+	// Work in progress has no completion date, so it is taken from all items
+	if cfd, err := cumulativeFlowResult(g.withWorkInProgress(items), string(periodType), g.opts); err == nil {
+		drawn = append(drawn, cfdChart(cfd))
+	}
+	if scatterplot, err := scatterplotResult(items); err == nil {
+		drawn = append(drawn, charts.Chart{Name: "scatterplot", Title: "Cycle Time Scatterplot", SVG: scatterplotSVG(scatterplot)})
+	}
+	return drawn, nil
+}
+
+// throughputChart draws the items completed per period as bars
+func throughputChart(result ThroughputResult, periodType PeriodType) charts.Chart {
+	title := "Monthly Throughput"
+	if periodType == PeriodTypeWeek {
+		title = "Weekly Throughput"
+	}
+	var bars []charts.Bar
+	for _, period := range result.Periods {
+		bars = append(bars, charts.Bar{Label: period.Period, Value: float64(period.Items)})
+	}
+	return charts.Chart{Name: "throughput", Title: title, SVG: charts.Bars(title, "Items completed", bars)}
+}
+
+// cfdChart draws the items per state at the end of each period as stacked
+// areas, with the last state (done) at the bottom
+func cfdChart(result CFDResult) charts.Chart {
+	const title = "Cumulative Flow"
+	var labels []string
+	for _, snapshot := range result.Snapshots {
+		labels = append(labels, snapshot.Period)
+	}
+	var series []charts.Series
+	for i := len(result.States) - 1; i >= 0; i-- {
+		s := charts.Series{Name: result.States[i]}
+		for _, snapshot := range result.Snapshots {
+			s.Values = append(s.Values, float64(snapshot.Counts[result.States[i]]))
+		}
+		series = append(series, s)
+	}
+	return charts.Chart{Name: "cfd", Title: title, SVG: charts.StackedAreas(title, "Items", labels, series)}
+}
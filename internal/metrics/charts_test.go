@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestGeneratorCharts(t *testing.T) {
+	drawn, err := NewGenerator(scatterplotItems()).Charts(PeriodTypeWeek, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Charts() error = %v", err)
+	}
+
+	var names []string
+	for _, chart := range drawn {
+		names = append(names, chart.FileName())
+	}
+	if strings.Join(names, ",") != "throughput.svg,scatterplot.svg" {
+		t.Fatalf("Charts() = %v, want throughput and scatterplot (no item has a created date for the cumulative flow)", names)
+	}
+	if drawn[0].Title != "Weekly Throughput" || !strings.Contains(drawn[0].SVG, "<rect") {
+		t.Errorf("throughput chart = %q, want weekly bars", drawn[0].Title)
+	}
+}
+
+func TestCFDChart_DoneAtTheBottom(t *testing.T) {
+	result := CFDResult{
+		States: []string{cfdNotStarted, cfdInProgress, cfdDone},
+		Snapshots: []CFDSnapshot{
+			{Period: "2024-05", Counts: map[string]int{cfdNotStarted: 3, cfdInProgress: 1}},
+			{Period: "2024-06", Counts: map[string]int{cfdInProgress: 2, cfdDone: 2}},
+		},
+	}
+
+	chart := cfdChart(result)
+	done := strings.Index(chart.SVG, "<title>"+cfdDone+"</title></polygon>")
+	notStarted := strings.Index(chart.SVG, "<title>"+cfdNotStarted+"</title></polygon>")
+	if done < 0 || notStarted < done {
+		t.Errorf("cfdChart() doesn't stack %s first:\n%s", cfdDone, chart.SVG)
+	}
+}
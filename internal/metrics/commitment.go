@@ -0,0 +1,201 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// CommitmentReport compares the work committed to each iteration with the work completed
+func CommitmentReport(items []models.KanbanItem) (string, error) {
+	return commitmentReport(items, DefaultOptions())
+}
+
+// IterationCommitment is the committed, added and completed work of one iteration
+type IterationCommitment struct {
+	Name           string  `json:"name"`
+	Start          string  `json:"start"`
+	End            string  `json:"end,omitempty"`
+	Inferred       bool    `json:"dates_inferred"` // Dates taken from the items, not --iterations
+	Committed      float64 `json:"committed"`
+	CommittedItems int     `json:"committed_items"`
+	Added          float64 `json:"added"`
+	AddedItems     int     `json:"added_items"`
+	Done           float64 `json:"done_of_committed"`
+	DoneItems      int     `json:"done_of_committed_items"`
+	DoneTotal      float64 `json:"done_total"`
+	SayDo          float64 `json:"say_do_percent"` // Share of committed work completed
+}
+
+// CommitmentResult holds the commitment of each iteration, oldest first
+type CommitmentResult struct {
+	Iterations []IterationCommitment `json:"iterations"`
+	SayDo      float64               `json:"say_do_percent"` // Over all iterations
+}
+
+// iterationName names the iteration an item belongs to, if any
+func iterationName(item models.KanbanItem) string {
+	if item.Iteration != "" {
+		return item.Iteration
+	}
+	return item.IterationID
+}
+
+// inferIterationDates takes an iteration's start from its first started item
+// and its end from its last completion, for iterations missing from the calendar
+func inferIterationDates(name string, items []models.KanbanItem) IterationDates {
+	dates := IterationDates{Name: name}
+	for _, item := range items {
+		start := item.StartedAt
+		if start.IsZero() {
+			start = item.CompletedAt
+		}
+		if !start.IsZero() && (dates.Start.IsZero() || start.Before(dates.Start)) {
+			dates.Start = start
+		}
+		if item.IsCompleted && item.CompletedAt.After(dates.End) {
+			dates.End = item.CompletedAt
+		}
+	}
+	dates.Start = time.Date(dates.Start.Year(), dates.Start.Month(), dates.Start.Day(), 0, 0, 0, 0, dates.Start.Location())
+	return dates
+}
+
+// commitmentResult counts, for each iteration, the work created before it
+// started (committed), the work added later, and how much of the committed
+// work was completed by its last day
+func commitmentResult(items []models.KanbanItem, opts Options) (CommitmentResult, error) {
+	byIteration := make(map[string][]models.KanbanItem)
+	for _, item := range items {
+		if name := iterationName(item); name != "" {
+			byIteration[name] = append(byIteration[name], item)
+		}
+	}
+	if len(byIteration) == 0 {
+		return CommitmentResult{}, fmt.Errorf("no items have an iteration")
+	}
+
+	result := CommitmentResult{Iterations: []IterationCommitment{}}
+	committedTotal, doneTotal := 0.0, 0.0
+	for name, iterationItems := range byIteration {
+		dates, known := opts.Iterations.find(name)
+		if !known {
+			dates = inferIterationDates(name, iterationItems)
+		}
+		if dates.Start.IsZero() {
+			continue // Nothing started yet, so there is no commitment to compare
+		}
+
+		commitment := IterationCommitment{Name: name, Start: dates.Start.Format("2006-01-02"), Inferred: !known}
+		var end time.Time
+		if !dates.End.IsZero() {
+			commitment.End = dates.End.Format("2006-01-02")
+			end = time.Date(dates.End.Year(), dates.End.Month(), dates.End.Day(), 0, 0, 0, 0, dates.End.Location()).AddDate(0, 0, 1)
+		}
+
+		for _, item := range iterationItems {
+			value := opts.Unit.Value(item.Estimate)
+			// Items without a creation date are taken to be planned
+			committed := item.CreatedAt.IsZero() || item.CreatedAt.Before(dates.Start)
+			done := item.IsCompleted && !item.CompletedAt.IsZero() && (end.IsZero() || item.CompletedAt.Before(end))
+
+			if committed {
+				commitment.Committed += value
+				commitment.CommittedItems++
+				if done {
+					commitment.Done += value
+					commitment.DoneItems++
+				}
+			} else {
+				commitment.Added += value
+				commitment.AddedItems++
+			}
+			if done {
+				commitment.DoneTotal += value
+			}
+		}
+
+		if commitment.Committed > 0 {
+			commitment.SayDo = commitment.Done / commitment.Committed * 100
+		}
+		committedTotal += commitment.Committed
+		doneTotal += commitment.Done
+		result.Iterations = append(result.Iterations, commitment)
+	}
+
+	if len(result.Iterations) == 0 {
+		return CommitmentResult{}, fmt.Errorf("no iteration has started")
+	}
+
+	// Oldest iteration first; start dates sort as text, names break ties
+	sort.Slice(result.Iterations, func(i, j int) bool {
+		a, b := result.Iterations[i], result.Iterations[j]
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return a.Name < b.Name
+	})
+
+	if committedTotal > 0 {
+		result.SayDo = doneTotal / committedTotal * 100
+	}
+	return result, nil
+}
+
+// commitmentReport builds the commitment report using the given options
+func commitmentReport(items []models.KanbanItem, opts Options) (string, error) {
+	result, err := commitmentResult(items, opts)
+	if err != nil {
+		return "", err
+	}
+
+	unit := opts.Unit.Label()
+	report := "# Commitment vs Completion by Iteration\n\n"
+	report += fmt.Sprintf("Committed %s were created before the iteration started; added %s came in later.\n", unit, unit)
+	report += fmt.Sprintf("Say/Do is the share of committed %s completed by the iteration's last day.\n\n", unit)
+
+	// Iterations with inferred dates are marked with an asterisk
+	names := make([]string, len(result.Iterations))
+	nameWidth := len("Iteration")
+	inferred := false
+	for i, it := range result.Iterations {
+		names[i] = it.Name
+		if it.Inferred {
+			names[i] += "*"
+			inferred = true
+		}
+		nameWidth = max(nameWidth, len([]rune(names[i])))
+	}
+
+	amount := func(value float64) string {
+		if opts.Unit.CountsItems() {
+			return fmt.Sprintf("%.0f", value)
+		}
+		return fmt.Sprintf("%.1f", value)
+	}
+
+	report += fmt.Sprintf("%-*s | Start      | End        | Committed | Added | Done of Committed | Done in Total | Say/Do\n", nameWidth, "Iteration")
+	report += strings.Repeat("-", nameWidth+1) + "|------------|------------|-----------|-------|-------------------|---------------|-------\n"
+	for i, it := range result.Iterations {
+		end := it.End
+		if end == "" {
+			end = "-"
+		}
+		sayDo := "-"
+		if it.Committed > 0 {
+			sayDo = fmt.Sprintf("%.0f%%", it.SayDo)
+		}
+		report += fmt.Sprintf("%-*s | %s | %-10s | %9s | %5s | %17s | %13s | %6s\n",
+			nameWidth, names[i], it.Start, end, amount(it.Committed), amount(it.Added), amount(it.Done), amount(it.DoneTotal), sayDo)
+	}
+
+	if inferred {
+		report += "\n* Dates inferred from the first start and last completion; give --iterations for exact dates.\n"
+	}
+	report += fmt.Sprintf("\nOverall Say/Do: %.0f%% of committed %s completed.\n", result.SayDo, unit)
+
+	return report, nil
+}
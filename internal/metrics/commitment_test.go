@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// sprintItems are two sprints: in Sprint 12, 8 of 10 committed points are done
+// and 2 points were added; Sprint 13 has no calendar entry
+func sprintItems() []models.KanbanItem {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 10, 0, 0, 0, time.UTC) }
+	return []models.KanbanItem{
+		{ID: "1", Iteration: "Sprint 12", Estimate: 5, CreatedAt: day(1), StartedAt: day(6), IsCompleted: true, CompletedAt: day(10)},
+		{ID: "2", Iteration: "Sprint 12", Estimate: 3, CreatedAt: day(2), StartedAt: day(7), IsCompleted: true, CompletedAt: day(17)},
+		{ID: "3", Iteration: "Sprint 12", Estimate: 2, CreatedAt: day(3), StartedAt: day(8)}, // Not finished
+		{ID: "4", Iteration: "Sprint 12", Estimate: 2, CreatedAt: day(9), StartedAt: day(9), IsCompleted: true, CompletedAt: day(15)},
+		{ID: "5", Iteration: "Sprint 13", Estimate: 4, CreatedAt: day(15), StartedAt: day(20), IsCompleted: true, CompletedAt: day(24)},
+		{ID: "6", Iteration: "Sprint 13", Estimate: 1, CreatedAt: day(22), StartedAt: day(22), IsCompleted: true, CompletedAt: day(23)},
+		{ID: "7", Estimate: 8, IsCompleted: true, CompletedAt: day(12)}, // No iteration
+	}
+}
+
+func TestCommitmentResult(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Iterations = Iterations{{
+		Name:  "Sprint 12",
+		Start: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC),
+	}}
+
+	result, err := commitmentResult(sprintItems(), opts)
+	if err != nil {
+		t.Fatalf("commitmentResult() error = %v", err)
+	}
+	if len(result.Iterations) != 2 {
+		t.Fatalf("Expected 2 iterations, got %d", len(result.Iterations))
+	}
+
+	sprint12 := result.Iterations[0]
+	if sprint12.Name != "Sprint 12" || sprint12.Inferred {
+		t.Errorf("first iteration = %+v, want Sprint 12 from the calendar", sprint12)
+	}
+	if sprint12.Committed != 10 || sprint12.CommittedItems != 3 || sprint12.Added != 2 ||
+		sprint12.Done != 8 || sprint12.DoneTotal != 10 || sprint12.SayDo != 80 {
+		t.Errorf("Sprint 12 = %+v, want 10 committed, 2 added, 8 done of committed, 10 done, 80%%", sprint12)
+	}
+
+	// Sprint 13 starts with its first started item on May 20, after item 5 was created
+	sprint13 := result.Iterations[1]
+	if !sprint13.Inferred || sprint13.Start != "2024-05-20" || sprint13.End != "2024-05-24" {
+		t.Errorf("Sprint 13 = %+v, want inferred dates 2024-05-20..2024-05-24", sprint13)
+	}
+	if sprint13.Committed != 4 || sprint13.Added != 1 || sprint13.SayDo != 100 {
+		t.Errorf("Sprint 13 = %+v, want 4 committed, 1 added, 100%%", sprint13)
+	}
+
+	if result.SayDo != 12.0/14*100 {
+		t.Errorf("overall SayDo = %.2f, want %.2f", result.SayDo, 12.0/14*100)
+	}
+}
+
+func TestCommitmentResult_NoIterations(t *testing.T) {
+	items := []models.KanbanItem{{ID: "1", IsCompleted: true, CompletedAt: time.Now()}}
+	if _, err := commitmentResult(items, DefaultOptions()); err == nil || !strings.Contains(err.Error(), "no items have an iteration") {
+		t.Errorf("commitmentResult() error = %v, want no iteration error", err)
+	}
+}
+
+func TestCommitmentReport(t *testing.T) {
+	report, err := commitmentReport(sprintItems(), DefaultOptions())
+	if err != nil {
+		t.Fatalf("commitmentReport() error = %v", err)
+	}
+
+	for _, str := range []string{
+		"# Commitment vs Completion by Iteration",
+		"Iteration  | Start      | End        | Committed | Added | Done of Committed | Done in Total | Say/Do",
+		"Sprint 13* | 2024-05-20 | 2024-05-24 |       4.0 |   1.0 |               4.0 |           5.0 |   100%",
+		"* Dates inferred from the first start and last completion",
+		"Overall Say/Do:",
+	} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	opts := DefaultOptions()
+	opts.Unit = types.UnitItems
+	report, err = commitmentReport(sprintItems(), opts)
+	if err != nil {
+		t.Fatalf("commitmentReport() error = %v", err)
+	}
+	if !strings.Contains(report, "Sprint 13* | 2024-05-20 | 2024-05-24 |         1 |     1 |                 1 |             2 |   100%") {
+		t.Errorf("Items report should count items\nGot:\n%s", report)
+	}
+}
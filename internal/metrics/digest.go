@@ -0,0 +1,382 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// DigestSettings tune the rules behind the digest highlights
+type DigestSettings struct {
+	// BaselineWeeks is the number of weeks before the digest week it is compared with
+	BaselineWeeks int
+	// CycleWeeks is the length of the two windows whose median cycle times are compared
+	CycleWeeks int
+	// AgingDays is the age after which work in progress is called out
+	AgingDays int
+	// SteadyPercent is the change below which a trend is called steady
+	SteadyPercent float64
+	// MinSample is the fewest items a window needs before it is compared
+	MinSample int
+	// MaxBullets caps the number of highlights
+	MaxBullets int
+}
+
+// DefaultDigestSettings returns the settings used when none are configured
+func DefaultDigestSettings() DigestSettings {
+	return DigestSettings{
+		BaselineWeeks: 4,
+		CycleWeeks:    4,
+		AgingDays:     30,
+		SteadyPercent: 5,
+		MinSample:     1,
+		MaxBullets:    10,
+	}
+}
+
+// ParseDigestSettings reads "key = value" lines overriding the default digest
+// settings. Keys are baseline-weeks, cycle-weeks, aging-days, steady-percent,
+// min-sample and max-bullets. Blank lines and lines starting with # are ignored.
+func ParseDigestSettings(r io.Reader) (DigestSettings, error) {
+	settings := DefaultDigestSettings()
+	ints := map[string]*int{
+		"baseline-weeks": &settings.BaselineWeeks,
+		"cycle-weeks":    &settings.CycleWeeks,
+		"aging-days":     &settings.AgingDays,
+		"min-sample":     &settings.MinSample,
+		"max-bullets":    &settings.MaxBullets,
+	}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return DigestSettings{}, fmt.Errorf("line %d: expected key = value, got %q", lineNumber, line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		if key == "steady-percent" {
+			percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+			if err != nil || percent < 0 {
+				return DigestSettings{}, fmt.Errorf("line %d: invalid steady-percent %q (expected 0 or more)", lineNumber, value)
+			}
+			settings.SteadyPercent = percent
+			continue
+		}
+
+		target, known := ints[key]
+		if !known {
+			return DigestSettings{}, fmt.Errorf("line %d: unknown digest setting %q (must be one of: baseline-weeks, cycle-weeks, aging-days, steady-percent, min-sample, max-bullets)", lineNumber, key)
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 1 {
+			return DigestSettings{}, fmt.Errorf("line %d: invalid %s %q (expected 1 or more)", lineNumber, key, value)
+		}
+		*target = number
+	}
+
+	if err := scanner.Err(); err != nil {
+		return DigestSettings{}, err
+	}
+	return settings, nil
+}
+
+// LoadDigestSettings reads a digest settings file from disk
+func LoadDigestSettings(path string) (DigestSettings, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return DigestSettings{}, fmt.Errorf("error opening digest settings file: %w", err)
+	}
+	defer file.Close()
+
+	settings, err := ParseDigestSettings(file)
+	if err != nil {
+		return DigestSettings{}, fmt.Errorf("error reading digest settings file '%s': %w", path, err)
+	}
+	return settings, nil
+}
+
+// DigestReport summarizes the week ending at asOf in plain-language bullet points
+func DigestReport(items []models.KanbanItem, asOf time.Time) (string, error) {
+	return digestReport(items, nil, asOf, DefaultOptions())
+}
+
+// DigestResult holds the highlights of the week ending at WeekEnding
+type DigestResult struct {
+	WeekEnding     string   `json:"week_ending"`
+	Completed      int      `json:"completed_items"`
+	WorkInProgress int      `json:"wip_items"`
+	Blocked        int      `json:"blocked_items"`
+	Highlights     []string `json:"highlights"`
+}
+
+// digestResult builds the weekly digest from completed items and the current
+// work in progress. Each highlight comes from a simple rule over the computed
+// metrics; rules without data are skipped. isAdHoc may be nil to leave out the
+// ad-hoc share.
+func digestResult(items []models.KanbanItem, isAdHoc func(models.KanbanItem) bool, asOf time.Time, opts Options) DigestResult {
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	splitBy := opts.SplitBy
+	if splitBy == "" {
+		splitBy = SplitByTeam
+	}
+	settings := opts.Digest
+	if settings == (DigestSettings{}) {
+		settings = DefaultDigestSettings()
+	}
+
+	weekStart := asOf.AddDate(0, 0, -7)
+	baselineStart := weekStart.AddDate(0, 0, -7*settings.BaselineWeeks)
+	cycleStart := asOf.AddDate(0, 0, -7*settings.CycleWeeks)
+	previousCycleStart := cycleStart.AddDate(0, 0, -7*settings.CycleWeeks)
+	within := func(t, start, end time.Time) bool {
+		return !t.IsZero() && t.After(start) && !t.After(end)
+	}
+
+	var thisWeek []models.KanbanItem
+	baselineCount, baselineValue := 0, 0.0
+	var cycleTimes, previousCycleTimes []float64
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		switch {
+		case within(item.CompletedAt, weekStart, asOf):
+			thisWeek = append(thisWeek, item)
+		case within(item.CompletedAt, baselineStart, weekStart):
+			baselineCount++
+			baselineValue += opts.Unit.Value(item.Estimate)
+		}
+		if item.StartedAt.IsZero() {
+			continue
+		}
+		cycleTime := item.CompletedAt.Sub(item.StartedAt).Hours() / 24
+		if within(item.CompletedAt, cycleStart, asOf) {
+			cycleTimes = append(cycleTimes, cycleTime)
+		} else if within(item.CompletedAt, previousCycleStart, cycleStart) {
+			previousCycleTimes = append(previousCycleTimes, cycleTime)
+		}
+	}
+
+	var bullets []string
+
+	// Throughput against the weekly average of the baseline weeks
+	if baselineCount == 0 {
+		bullets = append(bullets, fmt.Sprintf("%d %s completed this week, with none in the %d weeks before",
+			len(thisWeek), pluralize("item", len(thisWeek)), settings.BaselineWeeks))
+	} else if baselineCount < settings.MinSample {
+		bullets = append(bullets, fmt.Sprintf("%d %s completed this week; too few items in the %d weeks before to compare",
+			len(thisWeek), pluralize("item", len(thisWeek)), settings.BaselineWeeks))
+	} else {
+		average := float64(baselineCount) / float64(settings.BaselineWeeks)
+		bullets = append(bullets, fmt.Sprintf("Throughput %s vs prior %d-week average (%d %s vs %.1f)",
+			describeChange(float64(len(thisWeek)), average, settings.SteadyPercent), settings.BaselineWeeks, len(thisWeek), pluralize("item", len(thisWeek)), average))
+	}
+
+	if !opts.Unit.CountsItems() && baselineValue > 0 && baselineCount >= settings.MinSample {
+		weekValue := 0.0
+		for _, item := range thisWeek {
+			weekValue += opts.Unit.Value(item.Estimate)
+		}
+		average := baselineValue / float64(settings.BaselineWeeks)
+		bullets = append(bullets, fmt.Sprintf("%.1f %s completed, %s vs prior %d-week average (%.1f)",
+			weekValue, opts.Unit.Label(), describeChange(weekValue, average, settings.SteadyPercent), settings.BaselineWeeks, average))
+	}
+
+	// Median cycle time of the latest window against the one before
+	if len(cycleTimes) >= settings.MinSample && len(cycleTimes) > 0 {
+		median := calculatePercentile(cycleTimes, 50)
+		if len(previousCycleTimes) >= settings.MinSample && len(previousCycleTimes) > 0 {
+			previous := calculatePercentile(previousCycleTimes, 50)
+			direction := describeChange(median, previous, settings.SteadyPercent)
+			bullets = append(bullets, fmt.Sprintf("Median cycle time %.1f days over the last %d weeks, %s vs %.1f days in the %d weeks before",
+				median, settings.CycleWeeks, direction, previous, settings.CycleWeeks))
+		} else {
+			bullets = append(bullets, fmt.Sprintf("Median cycle time %.1f days over the last %d weeks", median, settings.CycleWeeks))
+		}
+	}
+
+	// Work in progress now and a week ago, from the started and completed dates
+	inProgressAt := func(t time.Time) int {
+		count := 0
+		for _, item := range items {
+			if item.StartedAt.IsZero() || item.StartedAt.After(t) {
+				continue
+			}
+			if item.IsCompleted && !item.CompletedAt.IsZero() && !item.CompletedAt.After(t) {
+				continue
+			}
+			count++
+		}
+		return count
+	}
+	wipNow, wipBefore := inProgressAt(asOf), inProgressAt(weekStart)
+	if wipNow > 0 || wipBefore > 0 {
+		change := "unchanged from a week ago"
+		if wipNow > wipBefore {
+			change = fmt.Sprintf("up from %d a week ago", wipBefore)
+		} else if wipNow < wipBefore {
+			change = fmt.Sprintf("down from %d a week ago", wipBefore)
+		}
+		bullets = append(bullets, fmt.Sprintf("%d %s in progress, %s", wipNow, pluralize("item", wipNow), change))
+	}
+
+	// Teams over their WIP limits now
+	for _, violation := range opts.WIPLimits.currentViolations(items) {
+		bullets = append(bullets, fmt.Sprintf("%s is over its WIP limit in %s (%d %s, limit %d)",
+			violation.Team, violation.State, violation.Items, pluralize("item", violation.Items), violation.Limit))
+	}
+
+	// Aging and blocked work in progress
+	agingByState := make(map[string]int)
+	blocked := 0
+	for _, item := range items {
+		if item.IsCompleted {
+			continue
+		}
+		if item.IsBlocked {
+			blocked++
+		}
+		start := item.CreatedAt
+		if !item.StartedAt.IsZero() {
+			start = item.StartedAt
+		}
+		if start.IsZero() || asOf.Sub(start).Hours()/24 <= float64(settings.AgingDays) {
+			continue
+		}
+		state := item.State
+		if state == "" {
+			state = "Unknown"
+		}
+		agingByState[state]++
+	}
+	var agingStates []string
+	for state := range agingByState {
+		agingStates = append(agingStates, state)
+	}
+	sort.Slice(agingStates, func(i, j int) bool {
+		if agingByState[agingStates[i]] != agingByState[agingStates[j]] {
+			return agingByState[agingStates[i]] > agingByState[agingStates[j]]
+		}
+		return agingStates[i] < agingStates[j]
+	})
+	for i, state := range agingStates {
+		if i >= 3 {
+			break
+		}
+		count := agingByState[state]
+		bullets = append(bullets, fmt.Sprintf("%d %s older than %d days in %s", count, pluralize("item", count), settings.AgingDays, state))
+	}
+	if blocked == 1 {
+		bullets = append(bullets, "1 item is blocked")
+	} else if blocked > 1 {
+		bullets = append(bullets, fmt.Sprintf("%d items are blocked", blocked))
+	}
+
+	if len(thisWeek) > 0 {
+		// Ad-hoc share of the week's completions
+		if isAdHoc != nil {
+			adHoc := 0
+			for _, item := range thisWeek {
+				if isAdHoc(item) {
+					adHoc++
+				}
+			}
+			if adHoc > 0 {
+				bullets = append(bullets, fmt.Sprintf("Ad-hoc requests were %.0f%% of items completed this week (%d of %d)",
+					float64(adHoc)/float64(len(thisWeek))*100, adHoc, len(thisWeek)))
+			}
+		}
+
+		// The group that finished the most
+		byGroup := make(map[string]int)
+		for _, item := range thisWeek {
+			byGroup[splitBy.GroupOf(item)]++
+		}
+		if len(byGroup) > 1 {
+			var groups []string
+			for group := range byGroup {
+				groups = append(groups, group)
+			}
+			sort.Slice(groups, func(i, j int) bool {
+				if byGroup[groups[i]] != byGroup[groups[j]] {
+					return byGroup[groups[i]] > byGroup[groups[j]]
+				}
+				return groups[i] < groups[j]
+			})
+			bullets = append(bullets, fmt.Sprintf("%s %s completed the most items this week (%d of %d)",
+				splitBy.Title(), groups[0], byGroup[groups[0]], len(thisWeek)))
+		}
+
+		// The longest wait among the week's completions
+		var longest models.KanbanItem
+		longestDays := 0.0
+		for _, item := range thisWeek {
+			if item.CreatedAt.IsZero() {
+				continue
+			}
+			if days := item.CompletedAt.Sub(item.CreatedAt).Hours() / 24; days > longestDays {
+				longest, longestDays = item, days
+			}
+		}
+		if longestDays > 0 {
+			bullets = append(bullets, fmt.Sprintf("Longest lead time this week: %q took %.0f days from creation to done", longest.Name, longestDays))
+		}
+	}
+
+	if len(bullets) > settings.MaxBullets {
+		bullets = bullets[:settings.MaxBullets]
+	}
+
+	return DigestResult{
+		WeekEnding:     asOf.Format("2006-01-02"),
+		Completed:      len(thisWeek),
+		WorkInProgress: wipNow,
+		Blocked:        blocked,
+		Highlights:     bullets,
+	}
+}
+
+// digestReport formats the weekly digest as bullet points
+func digestReport(items []models.KanbanItem, isAdHoc func(models.KanbanItem) bool, asOf time.Time, opts Options) (string, error) {
+	result := digestResult(items, isAdHoc, asOf, opts)
+
+	report := fmt.Sprintf("# Weekly Digest (week ending %s)\n\n", result.WeekEnding)
+	for _, bullet := range result.Highlights {
+		report += "- " + bullet + "\n"
+	}
+	return report, nil
+}
+
+// describeChange describes current against baseline as "up 18%", "down 5%" or
+// "steady" when the change is below steadyPercent
+func describeChange(current, baseline, steadyPercent float64) string {
+	if baseline == 0 {
+		return "steady"
+	}
+	change := (current - baseline) / baseline * 100
+	switch {
+	case math.Abs(change) < steadyPercent || change == 0:
+		return "steady"
+	case change > 0:
+		return fmt.Sprintf("up %.0f%%", change)
+	}
+	return fmt.Sprintf("down %.0f%%", -change)
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestDigestReport(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return asOf.AddDate(0, 0, -days)
+	}
+
+	var items []models.KanbanItem
+	// Four items in the four weeks before: one per week on average
+	for i, days := range []int{10, 16, 24, 30} {
+		items = append(items, models.KanbanItem{
+			ID: string(rune('a' + i)), Team: "Core", Estimate: 2, IsCompleted: true,
+			CreatedAt: daysAgo(days + 5), StartedAt: daysAgo(days + 4), CompletedAt: daysAgo(days),
+		})
+	}
+	// Two items this week, one of them ad-hoc
+	items = append(items,
+		models.KanbanItem{ID: "1", Name: "Fix login", Team: "Core", Estimate: 3, IsCompleted: true, Labels: []string{"ad-hoc-request"},
+			CreatedAt: daysAgo(40), StartedAt: daysAgo(3), CompletedAt: daysAgo(1)},
+		models.KanbanItem{ID: "2", Name: "Add search", Team: "Web", Estimate: 5, IsCompleted: true,
+			CreatedAt: daysAgo(6), StartedAt: daysAgo(5), CompletedAt: daysAgo(2)},
+		// Old work in progress
+		models.KanbanItem{ID: "3", Team: "Web", State: "In Review", IsBlocked: true, CreatedAt: daysAgo(60), StartedAt: daysAgo(45)},
+	)
+
+	isAdHoc := func(item models.KanbanItem) bool {
+		return len(item.Labels) > 0
+	}
+	report, err := digestReport(items, isAdHoc, asOf, DefaultOptions())
+	if err != nil {
+		t.Fatalf("digestReport() error = %v", err)
+	}
+
+	expected := []string{
+		"# Weekly Digest (week ending 2024-06-30)",
+		"- Throughput up 100% vs prior 4-week average (2 items vs 1.0)",
+		"- 8.0 points completed, up 300% vs prior 4-week average (2.0)",
+		"- Median cycle time",
+		"- 1 item in progress, unchanged from a week ago",
+		"- 1 item older than 30 days in In Review",
+		"- 1 item is blocked",
+		"- Ad-hoc requests were 50% of items completed this week (1 of 2)",
+		"- Team Core completed the most items this week (1 of 2)",
+		"- Longest lead time this week: \"Fix login\" took 39 days from creation to done",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	if bullets := strings.Count(report, "\n- "); bullets > DefaultDigestSettings().MaxBullets {
+		t.Errorf("digestReport() produced %d bullets, want at most %d", bullets, DefaultDigestSettings().MaxBullets)
+	}
+
+	// Tuned settings: a larger sample is needed and fewer bullets are kept
+	opts := DefaultOptions()
+	opts.Digest.MinSample = 5
+	opts.Digest.MaxBullets = 2
+	report, err = digestReport(items, isAdHoc, asOf, opts)
+	if err != nil {
+		t.Fatalf("digestReport() error = %v", err)
+	}
+	if !strings.Contains(report, "- 2 items completed this week; too few items in the 4 weeks before to compare") {
+		t.Errorf("Report doesn't skip the comparison below the minimum sample:\n%s", report)
+	}
+	if bullets := strings.Count(report, "\n- "); bullets != 2 {
+		t.Errorf("digestReport() produced %d bullets, want 2", bullets)
+	}
+}
+
+func TestParseDigestSettings(t *testing.T) {
+	input := "# Quieter digest\nsteady-percent = 15%\naging-days=45\n\nmax-bullets = 6\n"
+	settings, err := ParseDigestSettings(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDigestSettings() error = %v", err)
+	}
+	want := DefaultDigestSettings()
+	want.SteadyPercent, want.AgingDays, want.MaxBullets = 15, 45, 6
+	if settings != want {
+		t.Errorf("ParseDigestSettings() = %+v, want %+v", settings, want)
+	}
+
+	errorTests := map[string]string{
+		"aging-days = 0\n":    "line 1: invalid aging-days",
+		"loud = yes\n":        "unknown digest setting \"loud\"",
+		"max-bullets 5\n":     "expected key = value",
+		"steady-percent=-1\n": "invalid steady-percent",
+	}
+	for input, errorMsg := range errorTests {
+		if _, err := ParseDigestSettings(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), errorMsg) {
+			t.Errorf("ParseDigestSettings(%q) error = %v, want %q", input, err, errorMsg)
+		}
+	}
+}
+
+func TestDescribeChange(t *testing.T) {
+	tests := []struct {
+		current, baseline float64
+		want              string
+	}{
+		{11.8, 10, "up 18%"},
+		{9, 10, "down 10%"},
+		{10.2, 10, "steady"},
+		{3, 0, "steady"},
+	}
+	for _, tt := range tests {
+		if got := describeChange(tt.current, tt.baseline, 5); got != tt.want {
+			t.Errorf("describeChange(%v, %v) = %q, want %q", tt.current, tt.baseline, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+)
+
+// Document builds the structured document behind Generate, with one section
+// per type of metrics, for rendering as Markdown or HTML
+func (g *Generator) Document(metricsType MetricsType, periodType PeriodType, startDate, endDate time.Time, filterField models.FilterField) (render.ReportDocument, error) {
+	if err := g.ctx.Err(); err != nil {
+		return render.ReportDocument{}, err
+	}
+	// Like Generate, the digest compares the latest week with the weeks before it
+	filterStart := startDate
+	if metricsType == MetricsTypeDigest {
+		filterStart = time.Time{}
+	}
+	return g.document(metricsType, periodType, startDate, endDate, g.filterItemsByDateRange(filterStart, endDate, filterField))
+}
+
+// document builds the document for Generate from the filtered items
+func (g *Generator) document(metricsType MetricsType, periodType PeriodType, startDate, endDate time.Time, items []models.KanbanItem) (render.ReportDocument, error) {
+	doc := render.ReportDocument{
+		Title: "Kanban Metrics",
+		Fields: []render.Field{
+			{Label: "Metrics Type", Value: string(metricsType)},
+			{Label: "Period Type", Value: string(periodType)},
+			render.DateRangeField(startDate, endDate),
+		},
+	}
+	doc.Fields = append(doc.Fields, render.FilterFields(g.adHocFilter)...)
+	if g.simulates(metricsType) {
+		doc.Fields = append(doc.Fields, render.Field{Label: "Seed", Value: strconv.FormatInt(g.opts.Forecast.Seed, 10)})
+	}
+
+	if len(items) == 0 {
+		doc.Sections = []render.Section{{
+			Name:   "metrics",
+			Blocks: []render.Block{render.Paragraph{Lines: []string{"No items completed in the specified date range."}}},
+		}}
+		return doc, nil
+	}
+
+	if metricsType == MetricsTypeScatterplot {
+		blocks, err := scatterplotBlocks(items)
+		if err != nil {
+			return render.ReportDocument{}, err
+		}
+		doc.Sections = []render.Section{{Name: string(metricsType), Blocks: blocks}}
+		return doc, nil
+	}
+	if metricsType != MetricsTypeAll {
+		content, err := g.generateReport(metricsType, periodType, endDate, items)
+		if err != nil {
+			return render.ReportDocument{}, err
+		}
+		doc.Sections = []render.Section{{Name: string(metricsType), Blocks: render.Parse(content)}}
+		return doc, nil
+	}
+
+	sections := g.opts.Sections
+	if len(sections) == 0 {
+		sections = AllSections
+	}
+	// Like the combined text report, sections that fail are listed at the end
+	contents, errs, err := generateSections(g.ctx, sections, func(section MetricsType) (string, error) {
+		return generateSection(section, items, string(periodType), g.opts)
+	})
+	if err != nil {
+		return render.ReportDocument{}, err
+	}
+	var failed []FailedSection
+	for i, section := range sections {
+		if errs[i] != nil {
+			failed = append(failed, newFailedSection(section, errs[i]))
+			continue
+		}
+		doc.Sections = append(doc.Sections, render.Section{Name: string(section), Blocks: render.Parse(contents[i])})
+	}
+	if len(failed) > 0 {
+		doc.Sections = append(doc.Sections, render.Section{Name: "failed-sections", Blocks: render.Parse(failedSectionsReport(failed))})
+	}
+	return doc, nil
+}
+
+// renderDocument renders the document for Generate in the generator's format
+func (g *Generator) renderDocument(metricsType MetricsType, periodType PeriodType, startDate, endDate time.Time, items []models.KanbanItem) (string, error) {
+	renderer, err := render.ForFormat(g.format)
+	if err != nil {
+		return "", err
+	}
+	doc, err := g.document(metricsType, periodType, startDate, endDate, items)
+	if err != nil {
+		return "", err
+	}
+	return renderer.Render(doc), nil
+}
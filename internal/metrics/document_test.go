@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// documentTestItems returns two completed items and one in progress
+func documentTestItems() []models.KanbanItem {
+	now := time.Now()
+	return []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Estimate: 3, IsCompleted: true, CreatedAt: now.AddDate(0, 0, -10), StartedAt: now.AddDate(0, 0, -7), CompletedAt: now.AddDate(0, 0, -5)},
+		{ID: "2", Name: "Task 2", Estimate: 1, IsCompleted: true, CreatedAt: now.AddDate(0, 0, -8), StartedAt: now.AddDate(0, 0, -5), CompletedAt: now.AddDate(0, 0, -3)},
+		{ID: "3", Name: "Task 3", Estimate: 2, IsCompleted: false, CreatedAt: now.AddDate(0, 0, -4), StartedAt: now.AddDate(0, 0, -2)},
+	}
+}
+
+func TestDocument(t *testing.T) {
+	items := documentTestItems()
+
+	doc, err := NewGenerator(items).WithSections([]MetricsType{MetricsTypeThroughput, MetricsTypeFlow}).
+		Document(MetricsTypeAll, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Document() error = %v", err)
+	}
+
+	if doc.Title != "Kanban Metrics" || doc.Fields[0] != (render.Field{Label: "Metrics Type", Value: "all"}) {
+		t.Errorf("Title = %q, Fields = %v", doc.Title, doc.Fields)
+	}
+	if len(doc.Sections) != 2 || doc.Sections[0].Name != "throughput" || doc.Sections[1].Name != "flow" {
+		t.Fatalf("Sections = %+v, want throughput and flow", doc.Sections)
+	}
+
+	hasTable := false
+	for _, block := range doc.Sections[0].Blocks {
+		if table, ok := block.(render.Table); ok && table.Headers[0] == "Month" {
+			hasTable = true
+		}
+	}
+	if !hasTable {
+		t.Errorf("throughput section has no table by month: %+v", doc.Sections[0].Blocks)
+	}
+}
+
+func TestGenerateHTML(t *testing.T) {
+	output, err := NewGenerator(documentTestItems()).WithFormat(types.FormatHTML).
+		Generate(MetricsTypeFlow, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	expected := []string{
+		"<title>Kanban Metrics</title>",
+		`<section id="flow">`,
+		"<h2>Flow Efficiency Analysis</h2>",
+		"<table>",
+	}
+	for _, str := range expected {
+		if !strings.Contains(output, str) {
+			t.Errorf("Output doesn't contain expected string: %q\nGot:\n%s", str, output)
+		}
+	}
+}
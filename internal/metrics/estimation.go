@@ -8,6 +8,24 @@ import (
 
 // EstimationAccuracyReport compares story point sizes to actual completion times
 func EstimationAccuracyReport(items []models.KanbanItem) (string, error) {
+	return estimationAccuracyReport(items, DefaultOptions())
+}
+
+// EstimationResult holds the time spent per estimated size and how well
+// estimates correlate with cycle time
+type EstimationResult struct {
+	Available   bool         `json:"available"` // false when counting items, which have no size
+	DaysPerSize []GroupStats `json:"days_per_size"`
+	CycleTime   []GroupStats `json:"cycle_time"`
+	Correlation *float64     `json:"correlation"` // nil without sized items
+}
+
+// estimationResult groups the cycle times of completed items by the closest standard size
+func estimationResult(items []models.KanbanItem, opts Options) EstimationResult {
+	if opts.Unit.CountsItems() {
+		return EstimationResult{}
+	}
+	
 	// Map story points to actual cycle times
 	cycleTimesByPoints := make(map[float64][]float64)
 	
@@ -21,29 +39,15 @@ func EstimationAccuracyReport(items []models.KanbanItem) (string, error) {
 		}
 	}
 	
-	// Calculate cycle time per story point for each size
-	report := "# Estimation Accuracy Analysis\n\n"
-	
-	// Add explanatory text
-	report += "## What is Estimation Accuracy?\n\n"
-	report += "Estimation accuracy measures how well your story point estimates correlate with the actual time spent completing work. Ideally, there should be a consistent relationship between story points and completion time.\n\n"
-	report += "This analysis shows:\n"
-	report += "- How much time is spent per story point for different sized items\n"
-	report += "- Whether your story points consistently scale (e.g., do 3-point stories take about 3× as long as 1-point stories?)\n"
-	report += "- The correlation between estimates and actual completion times\n\n"
-	report += "## How to use this data:\n"
-	report += "- Look for consistency in the days/SP metric across different sizes\n"
-	report += "- Identify if certain sized items are consistently under or overestimated\n"
-	report += "- Use the correlation value to assess your estimation system's reliability\n"
-	report += "- Consider calibrating story point values based on actual completion times\n\n"
-	
-	report += "## Time Spent per Story Point Size\n\n"
-	report += "Story points | Count | Min Days/SP | Max Days/SP | Avg Days/SP | Median Days/SP\n"
-	report += "-------------|-------|------------|------------|-------------|---------------\n"
-	
+	result := EstimationResult{Available: true}
 	for _, size := range standardPointSizes {
 		times := cycleTimesByPoints[size]
-		if len(times) == 0 || size == 0 {
+		if len(times) == 0 {
+			continue
+		}
+		label := fmt.Sprintf("%.0f", size)
+		result.CycleTime = append(result.CycleTime, GroupStats{label, summarize(times)})
+		if size == 0 {
 			continue
 		}
 		
@@ -52,26 +56,7 @@ func EstimationAccuracyReport(items []models.KanbanItem) (string, error) {
 		for i, t := range times {
 			daysPerSP[i] = t / size
 		}
-		
-		min, max, avg, median := calculateStats(daysPerSP)
-		report += fmt.Sprintf("%12.0f | %5d | %10.1f | %10.1f | %11.1f | %15.1f\n", 
-			size, len(times), min, max, avg, median)
-	}
-	
-	// Add raw cycle time data for comparison
-	report += "\n## Raw Cycle Time by Story Point Size\n\n"
-	report += "Story points | Count | Min | Max | Avg | Median\n"
-	report += "-------------|-------|-----|-----|-----|-------\n"
-	
-	for _, size := range standardPointSizes {
-		times := cycleTimesByPoints[size]
-		if len(times) == 0 {
-			continue
-		}
-		
-		min, max, avg, median := calculateStats(times)
-		report += fmt.Sprintf("%12.0f | %5d | %3.1f | %3.1f | %3.1f | %6.1f\n", 
-			size, len(times), min, max, avg, median)
+		result.DaysPerSize = append(result.DaysPerSize, GroupStats{label, summarize(daysPerSP)})
 	}
 	
 	// Calculate overall correlation between story points and cycle time
@@ -91,7 +76,40 @@ func EstimationAccuracyReport(items []models.KanbanItem) (string, error) {
 	
 	if len(allPoints) > 0 {
 		correlation := calculateCorrelation(allPoints, allTimes)
-		report += fmt.Sprintf("\nCorrelation between story points and cycle time: %.2f\n", correlation)
+		result.Correlation = &correlation
+	}
+	
+	return result
+}
+
+// estimationAccuracyReport builds the estimation accuracy report using the given options
+func estimationAccuracyReport(items []models.KanbanItem, opts Options) (string, error) {
+	result := estimationResult(items, opts)
+	
+	// Calculate cycle time per story point for each size
+	report := "# Estimation Accuracy Analysis\n\n"
+	
+	if !result.Available {
+		report += "Estimation accuracy compares estimates to actual completion times, so it is not available when counting items (--unit items).\n"
+		return report, nil
+	}
+	
+	rateSuffix := " Days/" + opts.Unit.Abbrev()
+	
+	// Add explanatory text
+	if !opts.NoExplanations {
+		report += estimationExplanation
+	}
+	
+	report += fmt.Sprintf("## Time Spent per %s\n\n", sizeTitle(opts.Unit))
+	report += formatStatsTable(opts.Unit.SizeLabel(), result.DaysPerSize, opts.Stats, rateSuffix)
+	
+	// Add raw cycle time data for comparison
+	report += fmt.Sprintf("\n## Raw Cycle Time by %s\n\n", sizeTitle(opts.Unit))
+	report += formatStatsTable(opts.Unit.SizeLabel(), result.CycleTime, opts.Stats, "")
+	
+	if result.Correlation != nil {
+		report += fmt.Sprintf("\nCorrelation between story points and cycle time: %.2f\n", *result.Correlation)
 		report += "\nInterpretation of correlation:\n"
 		report += "- **0.7-1.0**: Strong positive correlation. Excellent estimation system.\n"
 		report += "- **0.4-0.7**: Moderate correlation. Reasonably good estimates.\n"
@@ -100,4 +118,4 @@ func EstimationAccuracyReport(items []models.KanbanItem) (string, error) {
 	}
 	
 	return report, nil
-}
\ No newline at end of file
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
 func TestEstimationAccuracyReport(t *testing.T) {
@@ -363,4 +364,37 @@ func TestEstimationAccuracyReport_ExplanatoryText(t *testing.T) {
 			t.Errorf("Report doesn't contain expected explanation: %s", explanation)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestEstimationAccuracyReport_Units(t *testing.T) {
+	items := []models.KanbanItem{
+		{
+			ID:          "1",
+			Name:        "Task 1",
+			IsCompleted: true,
+			StartedAt:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			CompletedAt: time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+			Estimate:    2,
+		},
+	}
+
+	opts := DefaultOptions()
+	opts.Unit = types.UnitHours
+	report, err := estimationAccuracyReport(items, opts)
+	if err != nil {
+		t.Fatalf("estimationAccuracyReport() error = %v", err)
+	}
+	for _, str := range []string{"Time Spent per Hour Estimate", "Days/h"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q", str)
+		}
+	}
+
+	opts.Unit = types.UnitItems
+	report, err = estimationAccuracyReport(items, opts)
+	if err != nil {
+		t.Fatalf("estimationAccuracyReport() error = %v", err)
+	}
+	if !strings.Contains(report, "not available when counting items") {
+		t.Errorf("Expected estimation accuracy to be unavailable for item counts\nGot:\n%s", report)
+	}
+}
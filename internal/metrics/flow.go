@@ -2,12 +2,59 @@ package metrics
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
 )
 
-// FlowEfficiencyReport analyzes time spent in each state
-func FlowEfficiencyReport(items []models.KanbanItem) (string, error) {
+// WaitingStateKeywords identify queue states in a state history; a state
+// counts as waiting when its name contains one of them, ignoring case
+var WaitingStateKeywords = []string{"backlog", "ready", "to do", "todo", "unstarted", "waiting", "blocked", "queue", "hold"}
+
+// isWaitingState reports whether a state name looks like a queue state
+func isWaitingState(state string) bool {
+	state = strings.ToLower(state)
+	for _, keyword := range WaitingStateKeywords {
+		if strings.Contains(state, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sources of the time in each state used by the flow efficiency analysis
+const (
+	FlowSourceDates   = "dates"
+	FlowSourceHistory = "history"
+)
+
+// FlowState is the time completed items spent in one state
+type FlowState struct {
+	Name    string  `json:"name"`
+	Kind    string  `json:"kind"` // Active or Waiting
+	AvgDays float64 `json:"avg_days"`
+	Percent float64 `json:"percent"` // Share of the total time of all states
+}
+
+// FlowResult holds the time spent in each state and the resulting flow efficiency
+type FlowResult struct {
+	Source     string      `json:"source"`
+	Measured   int         `json:"measured_items"`
+	Skipped    int         `json:"skipped_items,omitempty"` // Completed items without a state history
+	States     []FlowState `json:"states"`
+	Efficiency *float64    `json:"flow_efficiency_percent"` // nil when there is no time to measure
+}
+
+// flowResult measures the time completed items spent waiting and active. A
+// state history gives the real time per state, so it is preferred when present.
+func flowResult(items []models.KanbanItem) FlowResult {
+	for _, item := range items {
+		if item.IsCompleted && !item.CompletedAt.IsZero() && item.HasHistory() {
+			return flowFromHistory(items)
+		}
+	}
+
 	// Track time spent in each state
 	stateTimeTotal := make(map[string]float64) // in days
 	stateItemCount := make(map[string]int)
@@ -33,53 +80,142 @@ func FlowEfficiencyReport(items []models.KanbanItem) (string, error) {
 		}
 	}
 	
+	result := FlowResult{Source: FlowSourceDates, Measured: stateItemCount["Active"]}
+	totalTime := stateTimeTotal["Waiting"] + stateTimeTotal["Active"]
+	if totalTime <= 0 {
+		return result
+	}
+	
+	for _, state := range []string{"Waiting", "Active"} {
+		avg := 0.0
+		if stateItemCount[state] > 0 {
+			avg = stateTimeTotal[state] / float64(stateItemCount[state])
+		}
+		result.States = append(result.States, FlowState{state, state, avg, stateTimeTotal[state] / totalTime * 100})
+	}
+	efficiency := stateTimeTotal["Active"] / totalTime * 100
+	result.Efficiency = &efficiency
+	
+	return result
+}
+
+// FlowEfficiencyReport analyzes time spent in each state
+func FlowEfficiencyReport(items []models.KanbanItem) (string, error) {
+	return flowEfficiencyReport(items, DefaultOptions())
+}
+
+// flowEfficiencyReport builds the flow efficiency report using the given options
+func flowEfficiencyReport(items []models.KanbanItem, opts Options) (string, error) {
+	result := flowResult(items)
+	
 	report := "# Flow Efficiency Analysis\n\n"
 	
 	// Add explanatory text
-	report += "## What is Flow Efficiency?\n\n"
-	report += "Flow efficiency measures the percentage of time items spend being actively worked on versus waiting. It's a key metric in Lean and Kanban methodologies.\n\n"
-	report += "**Flow Efficiency = (Active Time / Total Time) × 100%**\n\n"
-	report += "- **Waiting time**: Time from creation until work begins (queue time)\n"
-	report += "- **Active time**: Time from when work begins until completion (processing time)\n"
-	report += "- **Total time**: Sum of waiting and active time (lead time)\n\n"
-	report += "## Interpretation:\n"
-	report += "- **Low efficiency (10-30%)**: Common in many organizations. Items spend most of their time waiting.\n"
-	report += "- **Medium efficiency (30-50%)**: Generally considered good for knowledge work.\n"
-	report += "- **High efficiency (>50%)**: Excellent! Your team has minimal waiting time.\n\n"
-	report += "## How to improve flow efficiency:\n"
-	report += "- Limit work in progress (WIP)\n"
-	report += "- Reduce handoffs between teams\n"
-	report += "- Eliminate bottlenecks\n"
-	report += "- Implement pull systems\n"
-	report += "- Reduce batch sizes\n\n"
+	if !opts.NoExplanations {
+		report += flowExplanation
+	}
+	
+	if result.Source == FlowSourceHistory {
+		return report + formatFlowFromHistory(result), nil
+	}
 	
 	report += "State | Avg Time (days) | % of Total Time\n"
 	report += "------|-----------------|---------------\n"
 	
-	totalTime := stateTimeTotal["Waiting"] + stateTimeTotal["Active"]
-	if totalTime > 0 {
-		waitAvg := 0.0
-		if stateItemCount["Waiting"] > 0 {
-			waitAvg = stateTimeTotal["Waiting"] / float64(stateItemCount["Waiting"])
-		}
-		
-		activeAvg := 0.0
-		if stateItemCount["Active"] > 0 {
-			activeAvg = stateTimeTotal["Active"] / float64(stateItemCount["Active"])
-		}
-		
-		waitPercent := (stateTimeTotal["Waiting"] / totalTime) * 100
-		activePercent := (stateTimeTotal["Active"] / totalTime) * 100
-		
-		report += fmt.Sprintf("Waiting | %15.1f | %13.1f%%\n", waitAvg, waitPercent)
-		report += fmt.Sprintf("Active  | %15.1f | %13.1f%%\n", activeAvg, activePercent)
-		report += fmt.Sprintf("\nFlow Efficiency: %.1f%%\n", activePercent)
-	} else {
+	if result.Efficiency == nil {
 		report += "No data available for flow efficiency calculation.\n"
+		return report, nil
 	}
 	
-	// Additional advanced flow analysis could go here
-	// For example, analyzing flow efficiency by story point size or type
+	for _, state := range result.States {
+		report += fmt.Sprintf("%-7s | %15.1f | %13.1f%%\n", state.Name, state.AvgDays, state.Percent)
+	}
+	report += fmt.Sprintf("\nFlow Efficiency: %.1f%%\n", *result.Efficiency)
 	
 	return report, nil
-}
\ No newline at end of file
+}
+
+// flowFromHistory measures the time spent in each state of completed items
+// with a state history, and the flow efficiency of the active states
+func flowFromHistory(items []models.KanbanItem) FlowResult {
+	stateTime := make(map[string]float64)
+	stateItems := make(map[string]int)
+	result := FlowResult{Source: FlowSourceHistory}
+
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		if !item.HasHistory() {
+			result.Skipped++
+			continue
+		}
+		result.Measured++
+		for state, days := range item.StateDurations(item.CompletedAt) {
+			stateTime[state] += days
+			stateItems[state]++
+		}
+	}
+
+	var states []string
+	totalTime, activeTime := 0.0, 0.0
+	for state, days := range stateTime {
+		states = append(states, state)
+		totalTime += days
+		if !isWaitingState(state) {
+			activeTime += days
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if stateTime[states[i]] != stateTime[states[j]] {
+			return stateTime[states[i]] > stateTime[states[j]]
+		}
+		return states[i] < states[j]
+	})
+
+	if totalTime <= 0 {
+		return result
+	}
+
+	for _, state := range states {
+		kind := "Active"
+		if isWaitingState(state) {
+			kind = "Waiting"
+		}
+		result.States = append(result.States, FlowState{state, kind, stateTime[state] / float64(stateItems[state]), stateTime[state] / totalTime * 100})
+	}
+	efficiency := activeTime / totalTime * 100
+	result.Efficiency = &efficiency
+
+	return result
+}
+
+// formatFlowFromHistory formats the time in each state measured from a state history
+func formatFlowFromHistory(result FlowResult) string {
+	report := fmt.Sprintf("Based on the state history of %d completed items", result.Measured)
+	if result.Skipped > 0 {
+		report += fmt.Sprintf(" (%d completed items without history are left out)", result.Skipped)
+	}
+	report += fmt.Sprintf(". States containing %s count as waiting; all others as active.\n\n", strings.Join(WaitingStateKeywords, ", "))
+
+	if result.Efficiency == nil {
+		return report + "No data available for flow efficiency calculation.\n"
+	}
+
+	stateWidth := len("State")
+	for _, state := range result.States {
+		if len(state.Name) > stateWidth {
+			stateWidth = len(state.Name)
+		}
+	}
+
+	report += fmt.Sprintf("%-*s | Kind    | Avg Time (days) | %% of Total Time\n", stateWidth, "State")
+	report += strings.Repeat("-", stateWidth+1) + "|---------|-----------------|----------------\n"
+	for _, state := range result.States {
+		report += fmt.Sprintf("%-*s | %-7s | %15.1f | %14.1f%%\n",
+			stateWidth, state.Name, state.Kind, state.AvgDays, state.Percent)
+	}
+	report += fmt.Sprintf("\nFlow Efficiency: %.1f%%\n", *result.Efficiency)
+
+	return report
+}
@@ -299,4 +299,45 @@ func TestFlowEfficiencyReport_TableFormat(t *testing.T) {
 	if !strings.Contains(report, "------|") {
 		t.Errorf("Report doesn't contain table separator")
 	}
-}
\ No newline at end of file
+}
+func TestFlowEfficiencyReport_History(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	items := []models.KanbanItem{
+		{
+			ID:          "1",
+			IsCompleted: true,
+			CreatedAt:   day(1),
+			CompletedAt: day(10),
+			History: []models.StateTransition{
+				{To: "Ready", At: day(1)},
+				{To: "In Progress", At: day(4)},
+				{To: "Blocked", At: day(5)},
+				{To: "In Progress", At: day(8)},
+				{To: "Done", At: day(10)},
+			},
+		},
+		{ID: "2", IsCompleted: true, CreatedAt: day(1), StartedAt: day(2), CompletedAt: day(3)},
+	}
+
+	report, err := FlowEfficiencyReport(items)
+	if err != nil {
+		t.Fatalf("FlowEfficiencyReport() error = %v", err)
+	}
+
+	expected := []string{
+		"Based on the state history of 1 completed items (1 completed items without history are left out)",
+		"State       | Kind    | Avg Time (days) | % of Total Time",
+		"Ready       | Waiting |             3.0 |           33.3%",
+		"Blocked     | Waiting |             3.0 |           33.3%",
+		"In Progress | Active  |             3.0 |           33.3%",
+		"Flow Efficiency: 33.3%",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/forecast"
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// ForecastSettings choose what the Monte Carlo forecast answers
+type ForecastSettings struct {
+	// Simulations is the number of Monte Carlo runs behind each forecast
+	Simulations int
+	// Items asks when this many items will be done (0 forecasts the work not yet completed)
+	Items int
+	// Date asks how many items will be done by this date (zero to skip)
+	Date time.Time
+	// Seed makes the simulated runs, and so the forecast, repeatable
+	Seed int64
+	// MinWeeks is the fewest weeks of throughput history to forecast from
+	MinWeeks int
+}
+
+// DefaultForecastSettings returns the settings used when none are configured
+func DefaultForecastSettings() ForecastSettings {
+	return ForecastSettings{Simulations: 10000, Seed: 1, MinWeeks: 4}
+}
+
+// ForecastReport forecasts from the weekly throughput of the weeks up to the
+// last completion before asOf
+func ForecastReport(items []models.KanbanItem, asOf time.Time) (string, error) {
+	return forecastReport(items, asOf, DefaultOptions())
+}
+
+// ForecastInterval is the range the middle runs of a forecast fall in
+type ForecastInterval struct {
+	Confidence int `json:"confidence_percent"`
+	Low        int `json:"low"`
+	High       int `json:"high"`
+}
+
+// CompletionForecast answers when a number of items will be done
+type CompletionForecast struct {
+	Items    int               `json:"items"`
+	Levels   []CompletionLevel `json:"levels"`
+	Interval ForecastInterval  `json:"interval_weeks"`
+}
+
+// CompletionLevel is the number of weeks, and the date, within which the
+// items are done with the given confidence
+type CompletionLevel struct {
+	Confidence int    `json:"confidence_percent"`
+	Weeks      int    `json:"weeks"`
+	Date       string `json:"date"`
+}
+
+// DateForecast answers how many items will be done by a date
+type DateForecast struct {
+	Date     string           `json:"date"`
+	Weeks    int              `json:"weeks"`
+	Levels   []DeliveryLevel  `json:"levels"`
+	Interval ForecastInterval `json:"interval_items"`
+}
+
+// DeliveryLevel is the number of items done at least with the given confidence
+type DeliveryLevel struct {
+	Confidence int `json:"confidence_percent"`
+	Items      int `json:"items"`
+}
+
+// ForecastResult holds the Monte Carlo forecasts and the history behind them
+type ForecastResult struct {
+	AsOf             string              `json:"as_of"`
+	HistoryEnd       string              `json:"history_end"` // Day of the last completion, where the history ends
+	Simulations      int                 `json:"simulations"`
+	SampleWeeks      int                 `json:"sample_weeks"`      // Weeks of history drawn from
+	SampleItems      int                 `json:"sample_items"`      // Items completed in those weeks
+	WeeklyThroughput []int               `json:"weekly_throughput"` // Oldest week first
+	Completion       *CompletionForecast `json:"completion,omitempty"`
+	ByDate           *DateForecast       `json:"by_date,omitempty"`
+}
+
+// weeklyThroughput counts the items completed in each 7-day window ending at
+// asOf, back to the window holding the first completion, oldest first
+func weeklyThroughput(items []models.KanbanItem, asOf time.Time) []int {
+	end := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location()).AddDate(0, 0, 1)
+
+	var first time.Time
+	for _, item := range items {
+		if item.IsCompleted && !item.CompletedAt.IsZero() && item.CompletedAt.Before(end) {
+			if first.IsZero() || item.CompletedAt.Before(first) {
+				first = item.CompletedAt
+			}
+		}
+	}
+	if first.IsZero() {
+		return nil
+	}
+
+	weeks := int(end.Sub(first).Hours()/(24*7)) + 1
+	counts := make([]int, weeks)
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() || !item.CompletedAt.Before(end) {
+			continue
+		}
+		// Windows are counted back from the end, so the newest is last
+		back := int(end.Sub(item.CompletedAt).Hours() / (24 * 7))
+		counts[weeks-1-back]++
+	}
+	return counts
+}
+
+// forecastResult simulates future weeks from the weekly throughput up to the
+// last completion on or before asOf, like the throughput distribution, so
+// that the days since, which may be a weekend or a holiday, don't count as
+// weeks without completions. It forecasts from asOf when the requested items
+// (or the items not yet completed) will be done and, when a date is set, how
+// many items will be done by then.
+func forecastResult(items []models.KanbanItem, asOf time.Time, opts Options) (ForecastResult, error) {
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	asOf = time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location())
+	settings := opts.Forecast
+	defaults := DefaultForecastSettings()
+	if settings.Simulations == 0 {
+		settings.Simulations = defaults.Simulations
+	}
+	if settings.MinWeeks == 0 {
+		settings.MinWeeks = defaults.MinWeeks
+	}
+
+	historyEnd := asOf
+	if last := lastCompletion(items, asOf.AddDate(0, 0, 1)); !last.IsZero() {
+		historyEnd = last
+	}
+	samples := weeklyThroughput(items, historyEnd)
+	sim, err := forecast.NewSimulation(samples, settings.Simulations, settings.Seed)
+	if err != nil {
+		return ForecastResult{}, err
+	}
+
+	result := ForecastResult{
+		AsOf:             asOf.Format("2006-01-02"),
+		HistoryEnd:       historyEnd.Format("2006-01-02"),
+		Simulations:      settings.Simulations,
+		SampleWeeks:      len(samples),
+		WeeklyThroughput: samples,
+	}
+	for _, count := range samples {
+		result.SampleItems += count
+	}
+
+	target := settings.Items
+	if target == 0 {
+		for _, item := range items {
+			if !item.IsCompleted {
+				target++
+			}
+		}
+	}
+
+	// Only whole weeks are simulated, so a partial last week is left out
+	dateWeeks := 0
+	if !settings.Date.IsZero() {
+		dateWeeks = int(settings.Date.Sub(asOf).Hours() / (24 * 7))
+		if dateWeeks < 1 {
+			return ForecastResult{}, fmt.Errorf("forecast date %s must be at least a week after %s",
+				settings.Date.Format("2006-01-02"), result.AsOf)
+		}
+	}
+
+	if target == 0 && dateWeeks == 0 {
+		return ForecastResult{}, fmt.Errorf("nothing to forecast: no items are left to complete and no forecast date is set")
+	}
+
+	// A few weeks say little about the weeks to come, however many runs
+	// are drawn from them
+	if len(samples) < settings.MinWeeks {
+		return ForecastResult{}, fmt.Errorf("not enough history to forecast: %d weeks of throughput since the first completion, at least %d needed (--forecast-min-weeks)",
+			len(samples), settings.MinWeeks)
+	}
+
+	if target > 0 {
+		outcomes := sim.WeeksToComplete(target)
+		result.Completion = &CompletionForecast{Items: target}
+		for _, confidence := range forecast.Levels {
+			weeks := forecast.WeeksAt(outcomes, confidence)
+			result.Completion.Levels = append(result.Completion.Levels, CompletionLevel{
+				Confidence: confidence,
+				Weeks:      weeks,
+				Date:       asOf.AddDate(0, 0, 7*weeks).Format("2006-01-02"),
+			})
+		}
+		low, high := forecast.Interval(outcomes, forecast.IntervalConfidence)
+		result.Completion.Interval = ForecastInterval{forecast.IntervalConfidence, low, high}
+	}
+
+	if dateWeeks > 0 {
+		outcomes := sim.ItemsInWeeks(dateWeeks)
+		result.ByDate = &DateForecast{Date: settings.Date.Format("2006-01-02"), Weeks: dateWeeks}
+		for _, confidence := range forecast.Levels {
+			result.ByDate.Levels = append(result.ByDate.Levels, DeliveryLevel{confidence, forecast.ItemsAt(outcomes, confidence)})
+		}
+		low, high := forecast.Interval(outcomes, forecast.IntervalConfidence)
+		result.ByDate.Interval = ForecastInterval{forecast.IntervalConfidence, low, high}
+	}
+
+	return result, nil
+}
+
+// lastCompletion returns when the last item completed before end was
+// completed, or the zero time when none was
+func lastCompletion(items []models.KanbanItem, end time.Time) time.Time {
+	var last time.Time
+	for _, item := range items {
+		if item.IsCompleted && item.CompletedAt.Before(end) && item.CompletedAt.After(last) {
+			last = item.CompletedAt
+		}
+	}
+	return last
+}
+
+// weeksAfter returns the date the given number of weeks after a YYYY-MM-DD date
+func weeksAfter(date string, weeks int) string {
+	start, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return start.AddDate(0, 0, 7*weeks).Format("2006-01-02")
+}
+
+// forecastReport builds the Monte Carlo forecast report using the given options
+func forecastReport(items []models.KanbanItem, asOf time.Time, opts Options) (string, error) {
+	result, err := forecastResult(items, asOf, opts)
+	if err != nil {
+		return "", err
+	}
+
+	total, low, high := 0, result.WeeklyThroughput[0], result.WeeklyThroughput[0]
+	for _, count := range result.WeeklyThroughput {
+		total += count
+		low = min(low, count)
+		high = max(high, count)
+	}
+
+	report := "# Monte Carlo Forecast\n\n"
+	report += fmt.Sprintf("Based on %d simulations drawing from the weekly throughput of the %d %s up to the last completion on %s\n",
+		result.Simulations, len(result.WeeklyThroughput), pluralize("week", len(result.WeeklyThroughput)), result.HistoryEnd)
+	report += fmt.Sprintf("(%.1f items per week on average, from %d to %d).\n", float64(total)/float64(len(result.WeeklyThroughput)), low, high)
+	report += fmt.Sprintf("Sample: %d %s, %d completed %s.\n", result.SampleWeeks, pluralize("week", result.SampleWeeks),
+		result.SampleItems, pluralize("item", result.SampleItems))
+
+	if result.Completion != nil {
+		report += fmt.Sprintf("\n## When will %d %s be done?\n\n", result.Completion.Items, pluralize("item", result.Completion.Items))
+		report += "Confidence | Weeks | Done By\n"
+		report += "-----------|-------|-----------\n"
+		for _, level := range result.Completion.Levels {
+			report += fmt.Sprintf("%9d%% | %5d | %s\n", level.Confidence, level.Weeks, level.Date)
+		}
+		interval := result.Completion.Interval
+		report += fmt.Sprintf("\n%d%% confidence interval: %d to %d %s (%s to %s)\n", interval.Confidence, interval.Low, interval.High,
+			pluralize("week", interval.High), weeksAfter(result.AsOf, interval.Low), weeksAfter(result.AsOf, interval.High))
+		if last := result.Completion.Levels[len(result.Completion.Levels)-1]; last.Weeks >= forecast.MaxWeeks {
+			report += fmt.Sprintf("\n⚠️  Some runs did not finish within %d weeks.\n", forecast.MaxWeeks)
+		}
+	}
+
+	if result.ByDate != nil {
+		report += fmt.Sprintf("\n## How many items by %s?\n\n", result.ByDate.Date)
+		report += fmt.Sprintf("In the %d full %s from %s:\n\n", result.ByDate.Weeks, pluralize("week", result.ByDate.Weeks), result.AsOf)
+		report += "Confidence | Items\n"
+		report += "-----------|------\n"
+		for _, level := range result.ByDate.Levels {
+			report += fmt.Sprintf("%9d%% | %5d\n", level.Confidence, level.Items)
+		}
+		interval := result.ByDate.Interval
+		report += fmt.Sprintf("\n%d%% confidence interval: %d to %d %s\n", interval.Confidence, interval.Low, interval.High, pluralize("item", interval.High))
+	}
+
+	return report, nil
+}
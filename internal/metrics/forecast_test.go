@@ -0,0 +1,241 @@
+package metrics
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// steadyItems completes perWeek items in each of the given number of weeks
+// before asOf, and leaves open items not yet completed
+func steadyItems(asOf time.Time, weeks, perWeek, open int) []models.KanbanItem {
+	var items []models.KanbanItem
+	for week := 0; week < weeks; week++ {
+		for i := 0; i < perWeek; i++ {
+			items = append(items, models.KanbanItem{
+				ID:          "done",
+				IsCompleted: true,
+				CompletedAt: asOf.AddDate(0, 0, -7*week-i%7),
+			})
+		}
+	}
+	for i := 0; i < open; i++ {
+		items = append(items, models.KanbanItem{ID: "open", CreatedAt: asOf.AddDate(0, 0, -10)})
+	}
+	return items
+}
+
+func TestWeeklyThroughput(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{ID: "1", IsCompleted: true, CompletedAt: time.Date(2024, 6, 30, 17, 0, 0, 0, time.UTC)}, // Last day counts
+		{ID: "2", IsCompleted: true, CompletedAt: time.Date(2024, 6, 24, 9, 0, 0, 0, time.UTC)},
+		{ID: "3", IsCompleted: true, CompletedAt: time.Date(2024, 6, 23, 9, 0, 0, 0, time.UTC)},
+		{ID: "4", IsCompleted: true, CompletedAt: time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)},
+		{ID: "5", IsCompleted: true, CompletedAt: time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC)}, // After asOf
+		{ID: "6", CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},                      // Not completed
+	}
+
+	// Windows: Jun 10-16, Jun 17-23, Jun 24-30
+	if got, want := weeklyThroughput(items, asOf), []int{1, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("weeklyThroughput() = %v, want %v", got, want)
+	}
+	if got := weeklyThroughput(nil, asOf); got != nil {
+		t.Errorf("weeklyThroughput(no items) = %v, want nil", got)
+	}
+}
+
+func TestForecastResult_RemainingWork(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	opts := DefaultOptions()
+	opts.Forecast.Simulations = 500
+
+	result, err := forecastResult(steadyItems(asOf, 8, 5, 20), asOf, opts)
+	if err != nil {
+		t.Fatalf("forecastResult() error = %v", err)
+	}
+	if result.Completion == nil || result.Completion.Items != 20 {
+		t.Fatalf("Completion = %+v, want a forecast for the 20 open items", result.Completion)
+	}
+	if result.ByDate != nil {
+		t.Errorf("ByDate = %+v, want nil without a forecast date", result.ByDate)
+	}
+
+	// Five items every week finish 20 items in exactly four weeks
+	for _, level := range result.Completion.Levels {
+		if level.Weeks != 4 || level.Date != "2024-07-28" {
+			t.Errorf("level %d%% = %d weeks (%s), want 4 weeks (2024-07-28)", level.Confidence, level.Weeks, level.Date)
+		}
+	}
+	if want := (ForecastInterval{90, 4, 4}); result.Completion.Interval != want {
+		t.Errorf("Interval = %+v, want %+v", result.Completion.Interval, want)
+	}
+	if result.SampleWeeks != 8 || result.SampleItems != 40 {
+		t.Errorf("sample = %d weeks, %d items, want 8 weeks, 40 items", result.SampleWeeks, result.SampleItems)
+	}
+}
+
+func TestForecastResult_HistoryEndsAtLastCompletion(t *testing.T) {
+	lastDone := time.Date(2024, 6, 9, 0, 0, 0, 0, time.UTC)
+	asOf := lastDone.AddDate(0, 0, 21) // Three weeks without completions, e.g. a holiday
+	opts := DefaultOptions()
+	opts.Forecast.Simulations = 200
+
+	result, err := forecastResult(steadyItems(lastDone, 4, 2, 4), asOf, opts)
+	if err != nil {
+		t.Fatalf("forecastResult() error = %v", err)
+	}
+	if result.HistoryEnd != "2024-06-09" || result.AsOf != "2024-06-30" {
+		t.Errorf("history end and as of = %s and %s, want 2024-06-09 and 2024-06-30", result.HistoryEnd, result.AsOf)
+	}
+	if want := []int{2, 2, 2, 2}; !reflect.DeepEqual(result.WeeklyThroughput, want) {
+		t.Errorf("WeeklyThroughput = %v, want %v without the empty weeks since", result.WeeklyThroughput, want)
+	}
+	// Dates are still counted from asOf
+	if level := result.Completion.Levels[0]; level.Weeks != 2 || level.Date != "2024-07-14" {
+		t.Errorf("level %d%% = %d weeks (%s), want 2 weeks (2024-07-14)", level.Confidence, level.Weeks, level.Date)
+	}
+}
+
+func TestForecastReport_OneItem(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	opts := DefaultOptions()
+	opts.Forecast.Simulations = 100
+	opts.Forecast.Items = 1
+
+	report, err := forecastReport(steadyItems(asOf, 4, 1, 0), asOf, opts)
+	if err != nil {
+		t.Fatalf("forecastReport() error = %v", err)
+	}
+	for _, str := range []string{"## When will 1 item be done?", "90% confidence interval: 1 to 1 week ("} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
+
+func TestForecastResult_Targets(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	opts := DefaultOptions()
+	opts.Forecast.Simulations = 500
+	opts.Forecast.Items = 12
+	opts.Forecast.Date = time.Date(2024, 8, 14, 0, 0, 0, 0, time.UTC) // 6 full weeks and 3 days
+
+	result, err := forecastResult(steadyItems(asOf, 4, 3, 0), asOf, opts)
+	if err != nil {
+		t.Fatalf("forecastResult() error = %v", err)
+	}
+	if result.Completion.Items != 12 || result.Completion.Levels[0].Weeks != 4 {
+		t.Errorf("Completion = %+v, want 12 items in 4 weeks", result.Completion)
+	}
+	if result.ByDate == nil || result.ByDate.Weeks != 6 {
+		t.Fatalf("ByDate = %+v, want a 6 week forecast", result.ByDate)
+	}
+	for _, level := range result.ByDate.Levels {
+		if level.Items != 18 {
+			t.Errorf("level %d%% = %d items, want 18", level.Confidence, level.Items)
+		}
+	}
+}
+
+func TestForecastResult_Errors(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		items  []models.KanbanItem
+		modify func(*Options)
+		want   string
+	}{
+		{"No history", nil, func(*Options) {}, "no weekly throughput history"},
+		{"Nothing left to do", steadyItems(asOf, 3, 2, 0), func(*Options) {}, "nothing to forecast"},
+		{"Date too soon", steadyItems(asOf, 3, 2, 0), func(o *Options) { o.Forecast.Date = asOf.AddDate(0, 0, 3) }, "at least a week after"},
+		{"Too little history", steadyItems(asOf, 3, 2, 5), func(*Options) {}, "3 weeks of throughput since the first completion, at least 4 needed"},
+		{"Configured minimum", steadyItems(asOf, 6, 2, 5), func(o *Options) { o.Forecast.MinWeeks = 8 }, "at least 8 needed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			tt.modify(&opts)
+			_, err := forecastResult(tt.items, asOf, opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("forecastResult() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestForecastReport(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	opts := DefaultOptions()
+	opts.Forecast.Simulations = 200
+	opts.Forecast.Date = time.Date(2024, 7, 28, 0, 0, 0, 0, time.UTC)
+
+	report, err := forecastReport(steadyItems(asOf, 4, 2, 6), asOf, opts)
+	if err != nil {
+		t.Fatalf("forecastReport() error = %v", err)
+	}
+
+	for _, str := range []string{
+		"# Monte Carlo Forecast",
+		"Based on 200 simulations drawing from the weekly throughput of the 4 weeks up to the last completion on 2024-06-30",
+		"(2.0 items per week on average, from 2 to 2)",
+		"## When will 6 items be done?",
+		"       85% |     3 | 2024-07-21",
+		"## How many items by 2024-07-28?",
+		"In the 4 full weeks from 2024-06-30",
+		"       95% |     8",
+		"Sample: 4 weeks, 8 completed items.",
+		"90% confidence interval: 3 to 3 weeks (2024-07-21 to 2024-07-21)",
+		"90% confidence interval: 8 to 8 items",
+	} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
+
+func TestGenerate_ForecastSeed(t *testing.T) {
+	now := time.Now()
+	items := steadyItems(now, 8, 2, 6)
+	for i := range items {
+		// Vary the weekly throughput so the seed matters
+		if items[i].IsCompleted && i%3 == 0 {
+			items[i].CompletedAt = items[i].CompletedAt.AddDate(0, 0, -7)
+		}
+	}
+
+	generate := func(seed int64, metricsType MetricsType, format types.OutputFormat) string {
+		settings := DefaultForecastSettings()
+		settings.Seed = seed
+		output, err := NewGenerator(items).WithForecast(settings).WithFormat(format).
+			Generate(metricsType, PeriodTypeWeek, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+		if err != nil {
+			t.Fatalf("Generate(%s) error = %v", metricsType, err)
+		}
+		return output
+	}
+
+	report := generate(42, MetricsTypeForecast, types.FormatText)
+	if !strings.Contains(report, "Seed: 42\n") {
+		t.Errorf("Forecast header should record the seed:\n%s", report)
+	}
+	if again := generate(42, MetricsTypeForecast, types.FormatText); again != report {
+		t.Errorf("The same seed should give the same forecast:\n%s\n---\n%s", report, again)
+	}
+	if strings.Contains(generate(42, MetricsTypeLeadTime, types.FormatText), "Seed:") {
+		t.Error("Only runs with a forecast should record the seed")
+	}
+
+	if output := generate(7, MetricsTypeForecast, types.FormatJSON); !strings.Contains(output, `"seed": 7`) {
+		t.Errorf("JSON output should record the seed:\n%s", output)
+	}
+	if output := generate(7, MetricsTypeForecast, types.FormatMarkdown); !strings.Contains(output, "Seed") {
+		t.Errorf("Markdown output should record the seed:\n%s", output)
+	}
+}
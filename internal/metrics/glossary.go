@@ -0,0 +1,244 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// Term is a metric explained by "explain": the explanation its report opens
+// with, how it is calculated and what to keep in mind when reading it
+type Term struct {
+	Name        string // Name given to explain, e.g. "lead-time"
+	Title       string
+	Explanation string // Markdown sections, left out of reports with NoExplanations
+	Formulas    []string
+	Caveats     []string
+}
+
+// Format returns the term as a markdown document
+func (t Term) Format() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", t.Title)
+	b.WriteString(t.Explanation)
+	if len(t.Formulas) > 0 {
+		b.WriteString("## Formulas\n\n")
+		for _, formula := range t.Formulas {
+			fmt.Fprintf(&b, "- %s\n", formula)
+		}
+		b.WriteString("\n")
+	}
+	if len(t.Caveats) > 0 {
+		b.WriteString("## Caveats\n\n")
+		for _, caveat := range t.Caveats {
+			fmt.Fprintf(&b, "- %s\n", caveat)
+		}
+	}
+	return b.String()
+}
+
+const leadTimeExplanation = "## What is Lead Time?\n\n" +
+	"Lead Time measures how long it takes for work to go from creation to completion. It's the total elapsed time that a customer waits for their request to be delivered.\n\n" +
+	"- **Lead Time**: Time from when an item is created to when it's completed (includes both waiting and active time)\n" +
+	"- **Cycle Time**: Time from when work actively starts on an item to when it's completed (active time only)\n\n" +
+	"Lower values indicate faster delivery. Higher story point items typically have longer lead and cycle times.\n\n" +
+	"## How to use this data:\n" +
+	"- Compare different sized items to validate your estimation system\n" +
+	"- Use these values to set realistic delivery expectations with stakeholders\n" +
+	"- Track these metrics over time to identify process improvements\n\n"
+
+const throughputExplanation = "## What is Throughput?\n\n" +
+	"Throughput measures how many items your team completes in a given time period. It represents your delivery capacity and is a key metric for planning and forecasting.\n\n" +
+	"- Higher numbers indicate greater delivery capacity\n" +
+	"- Consistent throughput suggests a stable, predictable process\n" +
+	"- Declining throughput may indicate impediments or reduced capacity\n" +
+	"- Rising throughput may indicate process improvements or increased capacity\n\n" +
+	"## How to use this data:\n" +
+	"- Use average throughput to forecast future delivery capabilities\n" +
+	"- Look for trends or patterns in delivery capacity\n" +
+	"- Compare throughput across different time periods to identify improvements or issues\n" +
+	"- Analyze the balance between different types of work (features, bugs, etc.)\n\n"
+
+const flowExplanation = "## What is Flow Efficiency?\n\n" +
+	"Flow efficiency measures the percentage of time items spend being actively worked on versus waiting. It's a key metric in Lean and Kanban methodologies.\n\n" +
+	"**Flow Efficiency = (Active Time / Total Time) × 100%**\n\n" +
+	"- **Waiting time**: Time from creation until work begins (queue time)\n" +
+	"- **Active time**: Time from when work begins until completion (processing time)\n" +
+	"- **Total time**: Sum of waiting and active time (lead time)\n\n" +
+	"## Interpretation:\n" +
+	"- **Low efficiency (10-30%)**: Common in many organizations. Items spend most of their time waiting.\n" +
+	"- **Medium efficiency (30-50%)**: Generally considered good for knowledge work.\n" +
+	"- **High efficiency (>50%)**: Excellent! Your team has minimal waiting time.\n\n" +
+	"## How to improve flow efficiency:\n" +
+	"- Limit work in progress (WIP)\n" +
+	"- Reduce handoffs between teams\n" +
+	"- Eliminate bottlenecks\n" +
+	"- Implement pull systems\n" +
+	"- Reduce batch sizes\n\n"
+
+const estimationExplanation = "## What is Estimation Accuracy?\n\n" +
+	"Estimation accuracy measures how well your story point estimates correlate with the actual time spent completing work. Ideally, there should be a consistent relationship between story points and completion time.\n\n" +
+	"This analysis shows:\n" +
+	"- How much time is spent per story point for different sized items\n" +
+	"- Whether your story points consistently scale (e.g., do 3-point stories take about 3× as long as 1-point stories?)\n" +
+	"- The correlation between estimates and actual completion times\n\n" +
+	"## How to use this data:\n" +
+	"- Look for consistency in the days/SP metric across different sizes\n" +
+	"- Identify if certain sized items are consistently under or overestimated\n" +
+	"- Use the correlation value to assess your estimation system's reliability\n" +
+	"- Consider calibrating story point values based on actual completion times\n\n"
+
+const workflowExplanation = "## Why compare workflows?\n\n" +
+	"Organizations often run several boards side by side (for example a Kanban workflow for support and a Scrum workflow for product work). Comparing them in one run shows how each way of working performs on the same measures.\n\n" +
+	"- **Lead Time**: Time from creation to completion\n" +
+	"- **Cycle Time**: Time from start to completion\n" +
+	"- **Throughput**: Items completed per period\n\n" +
+	"Differences are a prompt for conversation rather than a verdict: workflows often handle different kinds of work.\n\n"
+
+const sleExplanation = "## What is a Service Level Expectation?\n\n" +
+	"A service level expectation (SLE) tells stakeholders how long an item is likely to take once work on it starts, as a duration and a probability: \"85% of items are done within 9 days\". It comes from the cycle times of completed items rather than from estimates.\n\n" +
+	"## How to use this data:\n" +
+	"- Read it from the P85 column of the cycle time table: --metrics lead-time --stats count,median,p85,p95\n" +
+	"- Check open items against it with --metrics age --age-mode aging-wip; items past P85 are at risk of missing it\n" +
+	"- Quote the SLE when asked \"when will this be done?\" instead of re-estimating\n\n"
+
+// improvementExplanation returns the explanation of the improvement report,
+// which names the measure of capacity
+func improvementExplanation(unit types.EstimateUnit) string {
+	explanation := "## What are Team Improvement Metrics?\n\n"
+	explanation += "Team Improvement Metrics track how your team's performance changes over time across several key dimensions. This helps identify trends, improvements, and areas that need attention.\n\n"
+	explanation += "The metrics tracked month-over-month include:\n"
+	explanation += "- **Item Count**: Number of completed items\n"
+	if !unit.CountsItems() {
+		explanation += fmt.Sprintf("- **%s**: Total %s completed\n", unit.Title(), unit.Label())
+	}
+	explanation += "- **Lead Time**: Average time from creation to completion\n"
+	explanation += "- **Cycle Time**: Average time from start to completion\n\n"
+	explanation += "## How to use this data:\n"
+	explanation += fmt.Sprintf("- Look for trends in delivery capacity (%s)\n", capacityDescription(unit))
+	explanation += "- Track improvements in lead time and cycle time\n"
+	explanation += "- Use delta (Δ) values to see percentage improvements\n"
+	explanation += "- Celebrate improvements and investigate regressions\n"
+	explanation += "- Set team goals based on historical performance\n\n"
+	return explanation
+}
+
+// Glossary holds the terms explained by "explain", in the order they are listed
+var Glossary = []Term{
+	{
+		Name:        "lead-time",
+		Title:       "Lead Time and Cycle Time",
+		Explanation: leadTimeExplanation,
+		Formulas: []string{
+			"Lead Time = completed_at - created_at, in calendar days",
+			"Cycle Time = completed_at - started_at, in calendar days",
+			"Items are grouped by the standard size closest to their estimate (1, 2, 3, 5, 8, 13, 21)",
+		},
+		Caveats: []string{
+			"Only completed items with a creation date count; cycle time also needs a start date",
+			"Weekends and holidays are included, unlike the working-day ages of --metrics age",
+			"A few very old items raise the average a lot; the median and P85 are steadier",
+		},
+	},
+	{
+		Name:        "throughput",
+		Title:       "Throughput",
+		Explanation: throughputExplanation,
+		Formulas: []string{
+			"Throughput = completed items per period (--period week or month), by completed_at",
+			"Avg per item = total estimate / completed items",
+			"Coefficient of variation = standard deviation / mean of the items completed per week (below 0.5 steady, 1 or more unpredictable)",
+		},
+		Caveats: []string{
+			"The current period is still running, so it usually looks low",
+			"The weekly distribution counts 7-day windows back from the last completion, weeks without completions included, as the forecast does",
+			"Items split or merged differently change the count without changing the work done",
+		},
+	},
+	{
+		Name:        "flow",
+		Title:       "Flow Efficiency",
+		Explanation: flowExplanation,
+		Formulas: []string{
+			"Flow Efficiency = Active Time / Total Time × 100%",
+			"Without a state history: Waiting = started_at - created_at, Active = completed_at - started_at",
+			fmt.Sprintf("With a state history (--history): states containing %s count as waiting; all others as active", strings.Join(WaitingStateKeywords, ", ")),
+		},
+		Caveats: []string{
+			"Without a state history, waiting inside the started states (review queues, blocked work) counts as active, so the efficiency is overstated",
+			"Items without a start date count entirely as active time",
+			"Compare efficiency over time for one team rather than between teams with different boards",
+		},
+	},
+	{
+		Name:        "sle",
+		Title:       "Service Level Expectation",
+		Explanation: sleExplanation,
+		Formulas: []string{
+			"SLE = the 85th percentile (P85) of the cycle times of completed items",
+			"Percentiles interpolate linearly between the two nearest sorted values",
+		},
+		Caveats: []string{
+			"It describes the past; a change of process, team or kind of work makes it stale",
+			"With fewer than about 20 completed items the percentile moves a lot with each new one",
+			"It says nothing about when an item will start, only how long it takes once started",
+		},
+	},
+	{
+		Name:        "estimation",
+		Title:       "Estimation Accuracy",
+		Explanation: estimationExplanation,
+		Formulas: []string{
+			"Days per point = cycle time / size, where the size is the standard size closest to the estimate",
+			"Correlation = Pearson correlation of sizes and cycle times",
+		},
+		Caveats: []string{
+			"Not available with --unit items, since there is no estimate to compare",
+			"Unestimated items and items without a start date are left out",
+		},
+	},
+	{
+		Name:        "improvement",
+		Title:       "Team Improvement Metrics",
+		Explanation: improvementExplanation(types.UnitPoints),
+		Formulas: []string{
+			"Δ = (this month - previous month) / previous month × 100%",
+		},
+		Caveats: []string{
+			"Months with few completed items swing a lot; look at several months together",
+		},
+	},
+	{
+		Name:        "workflow",
+		Title:       "Workflow Comparison",
+		Explanation: workflowExplanation,
+		Formulas: []string{
+			"Lead Time, Cycle Time and Throughput as in explain lead-time and explain throughput, per workflow column",
+		},
+		Caveats: []string{
+			"Items without a workflow are grouped as Unspecified and may mix several ways of working",
+		},
+	},
+}
+
+// LookupTerm returns the glossary term with the given name
+func LookupTerm(name string) (Term, bool) {
+	for _, term := range Glossary {
+		if term.Name == strings.ToLower(strings.TrimSpace(name)) {
+			return term, true
+		}
+	}
+	return Term{}, false
+}
+
+// TermNames returns the names of the glossary terms, sorted
+func TermNames() []string {
+	names := make([]string, len(Glossary))
+	for i, term := range Glossary {
+		names[i] = term.Name
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestLookupTerm(t *testing.T) {
+	for _, name := range []string{"lead-time", "flow", "sle", " SLE "} {
+		term, ok := LookupTerm(name)
+		if !ok {
+			t.Errorf("LookupTerm(%q) found nothing", name)
+			continue
+		}
+		if term.Explanation == "" || len(term.Formulas) == 0 || len(term.Caveats) == 0 {
+			t.Errorf("LookupTerm(%q) = %+v, want an explanation, formulas and caveats", name, term)
+		}
+	}
+
+	if _, ok := LookupTerm("velocity"); ok {
+		t.Error("LookupTerm(\"velocity\") found a term")
+	}
+}
+
+func TestTermFormat(t *testing.T) {
+	term, _ := LookupTerm("flow")
+	text := term.Format()
+
+	for _, want := range []string{
+		"# Flow Efficiency\n\n",
+		"What is Flow Efficiency?",
+		"## Formulas\n\n- Flow Efficiency = Active Time / Total Time × 100%\n",
+		"## Caveats\n\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Format() missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestGenerate_NoExplanations(t *testing.T) {
+	items := documentTestItems()
+
+	tests := []struct {
+		metricsType MetricsType
+		explanation string
+	}{
+		{MetricsTypeLeadTime, "What is Lead Time?"},
+		{MetricsTypeThroughput, "What is Throughput?"},
+		{MetricsTypeFlow, "What is Flow Efficiency?"},
+		{MetricsTypeEstimation, "What is Estimation Accuracy?"},
+		{MetricsTypeImprovement, "What are Team Improvement Metrics?"},
+		{MetricsTypeWorkflow, "Why compare workflows?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.metricsType), func(t *testing.T) {
+			for _, show := range []bool{true, false} {
+				report, err := NewGenerator(items).WithExplanations(show).
+					Generate(tt.metricsType, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+				if err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+				if got := strings.Contains(report, tt.explanation); got != show {
+					t.Errorf("WithExplanations(%v): report contains %q = %v", show, tt.explanation, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultHistogramBuckets are the upper bounds (in days) of the cycle time histogram buckets
+var DefaultHistogramBuckets = []float64{2, 5, 10, 20}
+
+// histogramBarWidth is the width of the bar drawn for a bucket holding every item
+const histogramBarWidth = 30
+
+// ParseHistogramBuckets converts a comma-separated list of upper bounds (in days) into buckets
+func ParseHistogramBuckets(s string) ([]float64, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultHistogramBuckets, nil
+	}
+
+	var bounds []float64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bound, err := strconv.ParseFloat(part, 64)
+		if err != nil || bound <= 0 {
+			return nil, fmt.Errorf("invalid histogram bucket: %s (must be a positive number of days)", part)
+		}
+		bounds = append(bounds, bound)
+	}
+
+	if len(bounds) == 0 {
+		return DefaultHistogramBuckets, nil
+	}
+
+	sort.Float64s(bounds)
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i] == bounds[i-1] {
+			return nil, fmt.Errorf("duplicate histogram bucket: %s", formatDays(bounds[i]))
+		}
+	}
+
+	return bounds, nil
+}
+
+// HistogramBucket is a single range in a histogram
+type HistogramBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// bucketValues counts values into buckets bounded by the given upper bounds.
+// Each bucket covers (previous bound, bound]; a final bucket holds values above the last bound.
+func bucketValues(values []float64, bounds []float64) []HistogramBucket {
+	buckets := make([]HistogramBucket, len(bounds)+1)
+
+	lower := 0.0
+	for i, bound := range bounds {
+		if i == 0 {
+			buckets[i].Label = fmt.Sprintf("0–%sd", formatDays(bound))
+		} else {
+			buckets[i].Label = fmt.Sprintf("%s–%sd", formatDays(nextBucketStart(lower)), formatDays(bound))
+		}
+		lower = bound
+	}
+	buckets[len(bounds)].Label = fmt.Sprintf(">%sd", formatDays(lower))
+
+	for _, v := range values {
+		idx := sort.SearchFloat64s(bounds, v)
+		buckets[idx].Count++
+	}
+
+	return buckets
+}
+
+// nextBucketStart returns the label start for a bucket following the given bound
+func nextBucketStart(bound float64) float64 {
+	if bound == float64(int(bound)) {
+		return bound + 1
+	}
+	return bound
+}
+
+// formatDays formats a number of days without a trailing ".0" for whole numbers
+func formatDays(days float64) string {
+	return strconv.FormatFloat(days, 'f', -1, 64)
+}
+
+// formatHistogram renders a histogram table with counts, percentages and bars
+func formatHistogram(title, keyLabel string, buckets []HistogramBucket) string {
+	report := fmt.Sprintf("## %s\n\n", title)
+
+	total := 0
+	for _, b := range buckets {
+		total += b.Count
+	}
+	if total == 0 {
+		report += "No data available.\n"
+		return report
+	}
+
+	return report + formatDistribution(keyLabel, buckets, total)
+}
+
+// formatDistribution renders rows of item counts with their share of total and a bar
+func formatDistribution(keyLabel string, buckets []HistogramBucket, total int) string {
+	return formatCountDistribution(keyLabel, "Items", buckets, total)
+}
+
+// formatCountDistribution renders rows of counts of what countLabel names
+// (a five-letter plural such as Items or Weeks) with their share of total and a bar
+func formatCountDistribution(keyLabel, countLabel string, buckets []HistogramBucket, total int) string {
+	keyWidth := len(keyLabel)
+	for _, b := range buckets {
+		if n := len([]rune(b.Label)); n > keyWidth {
+			keyWidth = n
+		}
+	}
+
+	table := fmt.Sprintf("%-*s | %s | %% of %s | Distribution\n", keyWidth, keyLabel, countLabel, countLabel)
+	table += strings.Repeat("-", keyWidth+1) + "|-------|------------|-------------\n"
+
+	for _, b := range buckets {
+		percent := 0.0
+		if total > 0 {
+			percent = float64(b.Count) / float64(total) * 100
+		}
+		bar := strings.Repeat("#", int(percent/100*histogramBarWidth+0.5))
+		line := fmt.Sprintf("%-*s | %5d | %9.1f%% | %s", keyWidth, b.Label, b.Count, percent, bar)
+		table += strings.TrimRight(line, " ") + "\n"
+	}
+
+	return table
+}
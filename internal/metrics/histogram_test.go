@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestParseHistogramBuckets(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  []float64
+		expectErr bool
+	}{
+		{"Empty uses defaults", "", DefaultHistogramBuckets, false},
+		{"Custom bounds", "1,3,7", []float64{1, 3, 7}, false},
+		{"Unsorted bounds are sorted", "7, 1, 3", []float64{1, 3, 7}, false},
+		{"Fractional bounds", "0.5,1.5", []float64{0.5, 1.5}, false},
+		{"Negative bound", "-1,3", nil, true},
+		{"Zero bound", "0,3", nil, true},
+		{"Not a number", "abc", nil, true},
+		{"Duplicate bound", "3,3", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHistogramBuckets(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseHistogramBuckets() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("ParseHistogramBuckets() = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("ParseHistogramBuckets()[%d] = %v, want %v", i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBucketValues(t *testing.T) {
+	values := []float64{0.5, 2, 2.5, 5, 9, 15, 20, 21, 40}
+
+	buckets := bucketValues(values, DefaultHistogramBuckets)
+
+	expected := []HistogramBucket{
+		{"0–2d", 2},
+		{"3–5d", 2},
+		{"6–10d", 1},
+		{"11–20d", 2},
+		{">20d", 2},
+	}
+
+	if len(buckets) != len(expected) {
+		t.Fatalf("bucketValues() returned %d buckets, want %d", len(buckets), len(expected))
+	}
+	for i := range expected {
+		if buckets[i] != expected[i] {
+			t.Errorf("bucket %d = %+v, want %+v", i, buckets[i], expected[i])
+		}
+	}
+}
+
+func TestFormatHistogram(t *testing.T) {
+	report := formatHistogram("Cycle Time Distribution", "Cycle time", bucketValues([]float64{1, 1, 4, 30}, DefaultHistogramBuckets))
+
+	expected := []string{
+		"## Cycle Time Distribution",
+		"Cycle time | Items | % of Items",
+		"0–2d       |     2 |      50.0% | ###############",
+		">20d       |     1 |      25.0% |",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Histogram doesn't contain %q\nGot:\n%s", str, report)
+		}
+	}
+
+	empty := formatHistogram("Cycle Time Distribution", "Cycle time", bucketValues(nil, DefaultHistogramBuckets))
+	if !strings.Contains(empty, "No data available") {
+		t.Errorf("Empty histogram should report no data, got:\n%s", empty)
+	}
+}
+
+func TestLeadTimeReport_IncludesHistogram(t *testing.T) {
+	baseTime := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{
+			ID:          "1",
+			IsCompleted: true,
+			CreatedAt:   baseTime.AddDate(0, 0, -10),
+			StartedAt:   baseTime.AddDate(0, 0, -4),
+			CompletedAt: baseTime,
+			Estimate:    3,
+		},
+	}
+
+	report, err := NewGenerator(items).
+		WithHistogramBuckets([]float64{3, 7}).
+		Generate(MetricsTypeLeadTime, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, str := range []string{"## Cycle Time Distribution", "0–3d", "4–7d       |     1 |     100.0%", ">7d"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain %q\nGot:\n%s", str, report)
+		}
+	}
+}
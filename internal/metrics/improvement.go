@@ -2,153 +2,218 @@ package metrics
 
 import (
 	"fmt"
-	"sort"
+	"strings"
+	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
+// ImprovementMonth holds one month's delivery measures and the change in
+// average lead and cycle time from the month before
+type ImprovementMonth struct {
+	Period                 string   `json:"period"`
+	Items                  int      `json:"items"`
+	Amount                 float64  `json:"amount"`
+	AvgLeadTime            float64  `json:"avg_lead_time"`
+	AvgCycleTime           float64  `json:"avg_cycle_time"`
+	MedianLeadTime         float64  `json:"median_lead_time"`
+	MedianCycleTime        float64  `json:"median_cycle_time"`
+	LeadTimeChange         *float64 `json:"lead_time_change,omitempty"`
+	LeadTimeChangePercent  *float64 `json:"lead_time_change_percent,omitempty"`
+	CycleTimeChange        *float64 `json:"cycle_time_change,omitempty"`
+	CycleTimeChangePercent *float64 `json:"cycle_time_change_percent,omitempty"`
+}
+
+// ImprovementResult holds the month-over-month improvement measures
+type ImprovementResult struct {
+	Months           []ImprovementMonth   `json:"months"`
+	CapacityAdjusted []CapacityAdjustment `json:"capacity_adjusted,omitempty"`
+	Annotations      []PeriodAnnotation   `json:"annotations,omitempty"`
+}
+
 // TeamImprovementReport shows how metrics change month over month
 func TeamImprovementReport(items []models.KanbanItem) (string, error) {
-	// Group items by month
-	itemsByMonth := make(map[string][]models.KanbanItem)
+	return teamImprovementReport(items, DefaultOptions())
+}
+
+// improvementMonthWork collects the completed work of one month
+type improvementMonthWork struct {
+	items      int
+	amount     float64
+	leadTimes  []float64
+	cycleTimes []float64
+	date       time.Time // Any completion date within the month
+}
+
+// improvementResult calculates the delivery measures of each month and their changes
+func improvementResult(items []models.KanbanItem, opts Options) ImprovementResult {
+	// Collect the measures of each month in one pass over the items
+	workByMonth := make(map[dateutil.Period]*improvementMonthWork)
 	
 	for _, item := range items {
-		if item.IsCompleted && !item.CompletedAt.IsZero() {
-			month := item.CompletedAt.Format("2006-01")
-			itemsByMonth[month] = append(itemsByMonth[month], item)
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		month := dateutil.PeriodOf(item.CompletedAt, "month", opts.WeekNumbering)
+		work, exists := workByMonth[month]
+		if !exists {
+			work = &improvementMonthWork{date: item.CompletedAt}
+			workByMonth[month] = work
+		}
+		
+		work.items++
+		work.amount += opts.Unit.Value(item.Estimate)
+		if !item.CreatedAt.IsZero() {
+			work.leadTimes = append(work.leadTimes, item.CompletedAt.Sub(item.CreatedAt).Hours()/24)
+		}
+		if !item.StartedAt.IsZero() {
+			work.cycleTimes = append(work.cycleTimes, item.CompletedAt.Sub(item.StartedAt).Hours()/24)
 		}
 	}
 	
-	// Sort months
-	var months []string
-	for month := range itemsByMonth {
-		months = append(months, month)
-	}
-	sort.Strings(months)
-	
 	// Calculate metrics for each month
-	type monthlyMetrics struct {
-		ItemCount int
-		StoryPoints float64
-		AvgLeadTime float64
-		AvgCycleTime float64
-		LeadTimeMedian float64
-		CycleTimeMedian float64
-	}
-	
-	metricsByMonth := make(map[string]monthlyMetrics)
-	
-	for _, month := range months {
-		monthItems := itemsByMonth[month]
-		metrics := monthlyMetrics{
-			ItemCount: len(monthItems),
-		}
-		
-		var leadTimes, cycleTimes []float64
-		
-		for _, item := range monthItems {
-			metrics.StoryPoints += item.Estimate
-			
-			if !item.CreatedAt.IsZero() && !item.CompletedAt.IsZero() {
-				leadTime := item.CompletedAt.Sub(item.CreatedAt).Hours() / 24
-				leadTimes = append(leadTimes, leadTime)
-			}
-			
-			if !item.StartedAt.IsZero() && !item.CompletedAt.IsZero() {
-				cycleTime := item.CompletedAt.Sub(item.StartedAt).Hours() / 24
-				cycleTimes = append(cycleTimes, cycleTime)
-			}
+	var result ImprovementResult
+	var months []string
+	var capacityPeriods []capacityPeriod
+	for i, month := range sortedPeriods(workByMonth) {
+		work := workByMonth[month]
+		metrics := ImprovementMonth{
+			Period: month.Format("month"),
+			Items:  work.items,
+			Amount: work.amount,
 		}
+		leadTimes, cycleTimes := work.leadTimes, work.cycleTimes
 		
 		// Calculate lead time statistics
 		if len(leadTimes) > 0 {
-			_, _, avg, median := calculateStats(leadTimes)
+			_, _, avg, median, _ := calculateStats(leadTimes)
 			metrics.AvgLeadTime = avg
-			metrics.LeadTimeMedian = median
+			metrics.MedianLeadTime = median
 		}
 		
 		// Calculate cycle time statistics
 		if len(cycleTimes) > 0 {
-			_, _, avg, median := calculateStats(cycleTimes)
+			_, _, avg, median, _ := calculateStats(cycleTimes)
 			metrics.AvgCycleTime = avg
-			metrics.CycleTimeMedian = median
+			metrics.MedianCycleTime = median
 		}
 		
-		metricsByMonth[month] = metrics
+		// Month-over-month changes
+		if i > 0 {
+			prevMetrics := result.Months[i-1]
+			metrics.LeadTimeChange, metrics.LeadTimeChangePercent = monthChange(metrics.AvgLeadTime, prevMetrics.AvgLeadTime)
+			metrics.CycleTimeChange, metrics.CycleTimeChangePercent = monthChange(metrics.AvgCycleTime, prevMetrics.AvgCycleTime)
+		}
+		
+		months = append(months, metrics.Period)
+		result.Months = append(result.Months, metrics)
+		capacityPeriods = append(capacityPeriods, capacityPeriod{metrics.Period, work.date, metrics.Items, metrics.Amount})
 	}
 	
+	// Normalize for absences when a calendar is configured
+	result.CapacityAdjusted = capacityAdjustments("month", capacityPeriods, opts)
+	result.Annotations = periodAnnotations(months, "month", opts)
+	
+	return result
+}
+
+// monthChange returns the difference from the previous value and its
+// percentage, or nils when the previous value is not positive
+func monthChange(current, previous float64) (*float64, *float64) {
+	if previous <= 0 {
+		return nil, nil
+	}
+	diff := current - previous
+	percent := diff / previous * 100
+	return &diff, &percent
+}
+
+// teamImprovementReport builds the team improvement report using the given options
+func teamImprovementReport(items []models.KanbanItem, opts Options) (string, error) {
+	result := improvementResult(items, opts)
+	
 	// Generate report with month-over-month changes
 	report := "# Team Improvement Metrics\n\n"
 	
 	// Add explanatory text
-	report += "## What are Team Improvement Metrics?\n\n"
-	report += "Team Improvement Metrics track how your team's performance changes over time across several key dimensions. This helps identify trends, improvements, and areas that need attention.\n\n"
-	report += "The metrics tracked month-over-month include:\n"
-	report += "- **Item Count**: Number of completed items\n"
-	report += "- **Story Points**: Total points completed\n"
-	report += "- **Lead Time**: Average time from creation to completion\n"
-	report += "- **Cycle Time**: Average time from start to completion\n\n"
-	report += "## How to use this data:\n"
-	report += "- Look for trends in delivery capacity (items and points)\n"
-	report += "- Track improvements in lead time and cycle time\n"
-	report += "- Use delta (Δ) values to see percentage improvements\n"
-	report += "- Celebrate improvements and investigate regressions\n"
-	report += "- Set team goals based on historical performance\n\n"
+	if !opts.NoExplanations {
+		report += improvementExplanation(opts.Unit)
+	}
 	
-	report += "Month | Items | Points | Avg Lead Time | Avg Cycle Time | Lead Time Δ | Cycle Time Δ\n"
-	report += "------|-------|--------|---------------|----------------|------------|-------------\n"
+	// Estimate totals are left out when counting items, since they equal the item count
+	amountTitle := opts.Unit.ColumnTitle()
+	if opts.Unit.CountsItems() {
+		report += "Month | Items | Avg Lead Time | Avg Cycle Time | Lead Time Δ | Cycle Time Δ\n"
+		report += "------|-------|---------------|----------------|------------|-------------\n"
+	} else {
+		report += fmt.Sprintf("Month | Items | %s | Avg Lead Time | Avg Cycle Time | Lead Time Δ | Cycle Time Δ\n", amountTitle)
+		report += fmt.Sprintf("------|-------|%s|---------------|----------------|------------|-------------\n",
+			strings.Repeat("-", len(amountTitle)+2))
+	}
 	
-	var prevMonth string
-	for _, month := range months {
-		metrics := metricsByMonth[month]
-		
+	markers, footnotes := annotatePeriods(result.Annotations, opts.PeriodLabels)
+	for _, metrics := range result.Months {
 		leadTimeChange := ""
+		if metrics.LeadTimeChange != nil {
+			leadTimeChange = fmt.Sprintf("%+.1f (%+.1f%%)", *metrics.LeadTimeChange, *metrics.LeadTimeChangePercent)
+		}
+		
 		cycleTimeChange := ""
+		if metrics.CycleTimeChange != nil {
+			cycleTimeChange = fmt.Sprintf("%+.1f (%+.1f%%)", *metrics.CycleTimeChange, *metrics.CycleTimeChangePercent)
+		}
 		
-		if prevMonth != "" {
-			prevMetrics := metricsByMonth[prevMonth]
-			
-			leadTimeDiff := metrics.AvgLeadTime - prevMetrics.AvgLeadTime
-			if prevMetrics.AvgLeadTime > 0 {
-				leadTimeChange = fmt.Sprintf("%+.1f (%+.1f%%)", 
-					leadTimeDiff, 
-					(leadTimeDiff/prevMetrics.AvgLeadTime)*100)
-			}
-			
-			cycleTimeDiff := metrics.AvgCycleTime - prevMetrics.AvgCycleTime
-			if prevMetrics.AvgCycleTime > 0 {
-				cycleTimeChange = fmt.Sprintf("%+.1f (%+.1f%%)", 
-					cycleTimeDiff, 
-					(cycleTimeDiff/prevMetrics.AvgCycleTime)*100)
-			}
+		amount := ""
+		if !opts.Unit.CountsItems() {
+			amount = fmt.Sprintf(" | %*.1f", len(amountTitle), metrics.Amount)
 		}
 		
-		report += fmt.Sprintf("%s | %5d | %6.1f | %13.1f | %14.1f | %10s | %11s\n",
-			month, 
-			metrics.ItemCount, 
-			metrics.StoryPoints, 
+		report += fmt.Sprintf("%s | %5d%s | %13.1f | %14.1f | %10s | %11s%s\n",
+			opts.PeriodLabels.Label(metrics.Period), 
+			metrics.Items, 
+			amount, 
 			metrics.AvgLeadTime, 
 			metrics.AvgCycleTime,
 			leadTimeChange,
-			cycleTimeChange)
-		
-		prevMonth = month
+			cycleTimeChange,
+			markers[metrics.Period])
 	}
 	
 	// Add statistical analysis section
 	report += "\n## Statistical Trends\n\n"
-	report += "Month | Lead Time (Median) | Cycle Time (Median) | Items/Month | Points/Month\n"
-	report += "------|-------------------|-------------------|------------|-------------\n"
+	perMonthTitle := amountTitle + "/Month"
+	if opts.Unit.CountsItems() {
+		report += "Month | Lead Time (Median) | Cycle Time (Median) | Items/Month\n"
+		report += "------|-------------------|-------------------|------------\n"
+	} else {
+		report += fmt.Sprintf("Month | Lead Time (Median) | Cycle Time (Median) | Items/Month | %s\n", perMonthTitle)
+		report += fmt.Sprintf("------|-------------------|-------------------|------------|%s\n",
+			strings.Repeat("-", len(perMonthTitle)+1))
+	}
 	
-	for _, month := range months {
-		metrics := metricsByMonth[month]
-		report += fmt.Sprintf("%s | %17.1f | %19.1f | %10d | %11.1f\n",
-			month,
-			metrics.LeadTimeMedian,
-			metrics.CycleTimeMedian,
-			metrics.ItemCount,
-			metrics.StoryPoints)
+	for _, metrics := range result.Months {
+		report += fmt.Sprintf("%s | %17.1f | %19.1f | %10d",
+			opts.PeriodLabels.Label(metrics.Period),
+			metrics.MedianLeadTime,
+			metrics.MedianCycleTime,
+			metrics.Items)
+		if !opts.Unit.CountsItems() {
+			report += fmt.Sprintf(" | %*.1f", len(perMonthTitle)-1, metrics.Amount)
+		}
+		report += "\n"
 	}
 	
+	report += capacityAdjustedSection("Capacity-Adjusted Trends", "Month", result.CapacityAdjusted, opts)
+	report += footnotes
+	
 	return report, nil
-}
\ No newline at end of file
+}
+// capacityDescription names the capacity measures tracked for a unit, e.g. "items and points"
+func capacityDescription(unit types.EstimateUnit) string {
+	if unit.CountsItems() {
+		return "items"
+	}
+	return "items and " + unit.Label()
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
 func TestTeamImprovementReport(t *testing.T) {
@@ -475,4 +476,34 @@ func TestTeamImprovementReport_ZeroValues(t *testing.T) {
 	if !strings.Contains(report, "2") { // 2 items
 		t.Errorf("Report should show correct item count")
 	}
-}
\ No newline at end of file
+}
+func BenchmarkImprovementResult(b *testing.B) {
+	items := benchmarkItems(50000)
+	opts := DefaultOptions()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		improvementResult(items, opts)
+	}
+}
+
+func TestTeamImprovementReport_PeriodLabels(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", IsCompleted: true, CreatedAt: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), CompletedAt: time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), Estimate: 3},
+		{ID: "2", IsCompleted: true, CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), CompletedAt: time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC), Estimate: 2},
+	}
+
+	opts := DefaultOptions()
+	opts.PeriodLabels = types.PeriodLabelsFriendly
+	report, err := teamImprovementReport(items, opts)
+	if err != nil {
+		t.Fatalf("teamImprovementReport() error = %v", err)
+	}
+
+	if strings.Count(report, "April 2024 |") != 2 || strings.Count(report, "May 2024 |") != 2 {
+		t.Errorf("both tables should label the months April 2024 and May 2024:\n%s", report)
+	}
+	if strings.Contains(report, "2024-04") {
+		t.Errorf("report still contains the ISO label 2024-04:\n%s", report)
+	}
+}
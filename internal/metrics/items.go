@@ -0,0 +1,183 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/schema"
+	"github.com/hannasdev/kanban-reports/pkg/filtering"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// ItemMetricsVersion is the version of the exported item records. Fields are
+// only added within a version; renaming or removing one starts a new version.
+const ItemMetricsVersion = 1
+
+// ItemMetrics is one item with the measures computed from its dates and state
+// history, so data warehouses don't have to derive them from the raw CSV.
+// Every field is written for every item, with null for measures that don't
+// apply, so the records load as a table with fixed columns.
+type ItemMetrics struct {
+	Version     int        `json:"version"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	State       string     `json:"state"`
+	Team        string     `json:"team"`
+	Epic        string     `json:"epic"`
+	Owners      []string   `json:"owners"`
+	Estimate    float64    `json:"estimate"`
+	CreatedAt   *time.Time `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+
+	LeadTimeDays   *float64 `json:"lead_time_days"`   // Created to completed, for completed items
+	CycleTimeDays  *float64 `json:"cycle_time_days"`  // Started to completed, for completed items
+	AgeDays        *float64 `json:"age_days"`         // Since started, or else created, for open items
+	QueueTimeDays  *float64 `json:"queue_time_days"`  // Time in waiting states, or else created to started
+	ActiveTimeDays *float64 `json:"active_time_days"` // Time in the other states, or else started to completed or now
+	TimeSource     string   `json:"time_source"`      // Where queue and active time come from: history, dates or none
+	AgeStatus      string   `json:"age_status"`       // green, yellow or red against --age-thresholds; "" without one
+
+	Completed bool   `json:"completed"`
+	AdHoc     bool   `json:"ad_hoc"`
+	Blocked   bool   `json:"blocked"`
+	Blocker   bool   `json:"blocker"`
+	Reopened  bool   `json:"reopened"` // Completed once, then reopened
+	Archived  bool   `json:"archived"`
+	Waiting   bool   `json:"waiting"`  // Open in a queue state, such as Ready or Blocked
+	Category  string `json:"category"` // From the classification rules
+
+	Source *models.Source `json:"source,omitempty"` // Only set for items read from a CSV file
+}
+
+// itemMetrics computes the measures of one item as of a date
+func itemMetrics(item models.KanbanItem, asOf time.Time, rules filtering.AdHocRules, opts Options) ItemMetrics {
+	record := ItemMetrics{
+		Version:   ItemMetricsVersion,
+		ID:        item.ID,
+		Name:      item.Name,
+		Type:      item.Type,
+		State:     item.State,
+		Team:      item.Team,
+		Epic:      item.Epic,
+		Owners:    item.Owners,
+		Estimate:  item.Estimate,
+		Completed: item.IsCompleted,
+		AdHoc:     rules.Matches(item),
+		Blocked:   item.IsBlocked,
+		Blocker:   item.IsABlocker,
+		Reopened:  filtering.IsReopened(item),
+		Archived:  item.IsArchived,
+		Waiting:   !item.IsCompleted && item.State != "" && isWaitingState(item.State),
+		Category:  item.Category,
+		Source:    item.SourceRef(),
+	}
+	if record.Owners == nil {
+		record.Owners = []string{}
+	}
+	record.CreatedAt = timeOrNil(item.CreatedAt)
+	record.StartedAt = timeOrNil(item.StartedAt)
+	record.CompletedAt = timeOrNil(item.CompletedAt)
+
+	end := asOf
+	if item.IsCompleted && !item.CompletedAt.IsZero() {
+		end = item.CompletedAt
+		record.LeadTimeDays = daysOrNil(item.CreatedAt, end)
+		record.CycleTimeDays = daysOrNil(item.StartedAt, end)
+	} else if !item.IsCompleted {
+		start := item.StartedAt
+		if start.IsZero() {
+			start = item.CreatedAt
+		}
+		record.AgeDays = daysOrNil(start, asOf)
+		if age := record.AgeDays; age != nil {
+			state := item.State
+			if state == "" {
+				state = "Unknown"
+			}
+			record.AgeStatus = string(opts.AgeThresholds.Status(state, *age))
+		}
+	}
+
+	// A state history gives the real time per state, so it is preferred
+	record.TimeSource = FlowSourceDates
+	switch {
+	case item.HasHistory():
+		record.TimeSource = FlowSourceHistory
+		queue, active := 0.0, 0.0
+		for state, days := range item.StateDurations(end) {
+			if isWaitingState(state) {
+				queue += days
+			} else {
+				active += days
+			}
+		}
+		record.QueueTimeDays, record.ActiveTimeDays = &queue, &active
+	case !item.CreatedAt.IsZero() && !item.StartedAt.IsZero():
+		record.QueueTimeDays = daysOrNil(item.CreatedAt, item.StartedAt)
+		record.ActiveTimeDays = daysOrNil(item.StartedAt, end)
+	case !item.CreatedAt.IsZero() && !item.IsCompleted:
+		// Not started yet, so all of its time has been spent waiting
+		record.QueueTimeDays = daysOrNil(item.CreatedAt, asOf)
+	default:
+		record.TimeSource = "none"
+	}
+
+	return record
+}
+
+// timeOrNil returns the time, or nil when it isn't set
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// daysOrNil returns the days from one time to another, or nil when either is
+// missing or they are out of order
+func daysOrNil(from, to time.Time) *float64 {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return nil
+	}
+	days := to.Sub(from).Hours() / 24
+	return &days
+}
+
+// ExportItems writes the computed measures of the items in the date range as
+// JSON Lines, one ItemMetrics object per line. Without a date range every
+// item is exported, open ones included.
+func (g *Generator) ExportItems(w io.Writer, startDate, endDate time.Time, filterField models.FilterField, asOf time.Time) error {
+	var items []models.KanbanItem
+	if !startDate.IsZero() || !endDate.IsZero() {
+		items = g.filterItemsByDateRange(startDate, endDate, filterField)
+	} else {
+		for _, item := range g.items {
+			isAdHoc := g.isAdHocRequest(item)
+			if (g.adHocFilter == types.AdHocFilterExclude && isAdHoc) || (g.adHocFilter == types.AdHocFilterOnly && !isAdHoc) {
+				continue
+			}
+			items = append(items, item)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, item := range items {
+		if err := g.ctx.Err(); err != nil {
+			return err
+		}
+		if err := encoder.Encode(itemMetrics(item, asOf, g.adHocRules, g.opts)); err != nil {
+			return fmt.Errorf("error encoding item %s: %w", item.ID, err)
+		}
+	}
+	return nil
+}
+
+// ItemsJSONSchema describes the objects written by ExportItems
+func ItemsJSONSchema() (schema.Schema, error) {
+	return schema.For("Kanban item metrics", ItemMetrics{})
+}
@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestItemMetrics_CompletedWithHistory(t *testing.T) {
+	created := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	item := models.KanbanItem{
+		ID: "1", Name: "Checkout", Type: "feature", State: "Done", Estimate: 3,
+		CreatedAt: created, StartedAt: created.AddDate(0, 0, 2), CompletedAt: created.AddDate(0, 0, 10), IsCompleted: true,
+		Labels: []string{"ad-hoc-request"},
+		History: []models.StateTransition{
+			{To: "Ready", At: created},
+			{From: "Ready", To: "In Progress", At: created.AddDate(0, 0, 2)},
+			{From: "In Progress", To: "Blocked", At: created.AddDate(0, 0, 5)},
+			{From: "Blocked", To: "In Progress", At: created.AddDate(0, 0, 6)},
+			{From: "In Progress", To: "Done", At: created.AddDate(0, 0, 10)},
+		},
+	}
+
+	record := itemMetrics(item, created.AddDate(0, 1, 0), NewGenerator(nil).adHocRules, DefaultOptions())
+
+	if record.Version != ItemMetricsVersion || record.ID != "1" || record.TimeSource != FlowSourceHistory {
+		t.Errorf("record = %+v, want version %d, ID 1 and times from the history", record, ItemMetricsVersion)
+	}
+	if record.LeadTimeDays == nil || *record.LeadTimeDays != 10 || record.CycleTimeDays == nil || *record.CycleTimeDays != 8 {
+		t.Errorf("lead, cycle time = %v, %v, want 10, 8", record.LeadTimeDays, record.CycleTimeDays)
+	}
+	// 2 days Ready and 1 day Blocked, the rest in progress until completion
+	if record.QueueTimeDays == nil || *record.QueueTimeDays != 3 || record.ActiveTimeDays == nil || *record.ActiveTimeDays != 7 {
+		t.Errorf("queue, active time = %v, %v, want 3, 7", record.QueueTimeDays, record.ActiveTimeDays)
+	}
+	if record.AgeDays != nil || record.AgeStatus != "" {
+		t.Errorf("age = %v, %q, want none for a completed item", record.AgeDays, record.AgeStatus)
+	}
+	if !record.Completed || !record.AdHoc || record.Waiting || record.Reopened {
+		t.Errorf("flags = %+v, want completed and ad hoc only", record)
+	}
+}
+
+func TestItemMetrics_OpenFromDates(t *testing.T) {
+	asOf := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+	opts := DefaultOptions()
+	opts.AgeThresholds = AgeThresholds{"*": {Warning: 5, Critical: 10}}
+
+	unstarted := itemMetrics(models.KanbanItem{ID: "2", State: "Ready", CreatedAt: asOf.AddDate(0, 0, -6)}, asOf, NewGenerator(nil).adHocRules, opts)
+	if unstarted.AgeDays == nil || *unstarted.AgeDays != 6 || unstarted.AgeStatus != "yellow" {
+		t.Errorf("age = %v, %q, want 6 days, yellow", unstarted.AgeDays, unstarted.AgeStatus)
+	}
+	if unstarted.QueueTimeDays == nil || *unstarted.QueueTimeDays != 6 || unstarted.ActiveTimeDays != nil || unstarted.TimeSource != FlowSourceDates {
+		t.Errorf("queue, active time = %v, %v (%s), want 6 days waiting from the dates", unstarted.QueueTimeDays, unstarted.ActiveTimeDays, unstarted.TimeSource)
+	}
+	if !unstarted.Waiting || unstarted.LeadTimeDays != nil || unstarted.CycleTimeDays != nil || unstarted.StartedAt != nil {
+		t.Errorf("record = %+v, want waiting without lead or cycle time", unstarted)
+	}
+
+	undated := itemMetrics(models.KanbanItem{ID: "3", CompletedAt: asOf.AddDate(0, 0, -1)}, asOf, NewGenerator(nil).adHocRules, opts)
+	if undated.TimeSource != "none" || undated.QueueTimeDays != nil || !undated.Reopened {
+		t.Errorf("record = %+v, want reopened without queue time", undated)
+	}
+}
+
+func TestExportItems(t *testing.T) {
+	now := time.Now()
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Recent", IsCompleted: true, CreatedAt: now.AddDate(0, 0, -4), CompletedAt: now.AddDate(0, 0, -2)},
+		{ID: "2", Name: "Old", IsCompleted: true, CreatedAt: now.AddDate(0, -2, 0), CompletedAt: now.AddDate(0, -1, 0)},
+		{ID: "3", Name: "Open", CreatedAt: now.AddDate(0, 0, -1)},
+		{ID: "4", Name: "Ad hoc", CreatedAt: now.AddDate(0, 0, -1), Labels: []string{"ad-hoc-request"}},
+	}
+
+	tests := []struct {
+		name       string
+		adHoc      types.AdHocFilterType
+		start, end time.Time
+		want       []string
+	}{
+		{"All items without a date range", types.AdHocFilterInclude, time.Time{}, time.Time{}, []string{"1", "2", "3", "4"}},
+		{"Completed in the date range", types.AdHocFilterInclude, now.AddDate(0, 0, -7), now, []string{"1"}},
+		{"Ad hoc left out", types.AdHocFilterExclude, time.Time{}, time.Time{}, []string{"1", "2", "3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := NewGenerator(items).WithAdHocFilter(tt.adHoc).ExportItems(&buf, tt.start, tt.end, models.FilterFieldCompletedAt, now)
+			if err != nil {
+				t.Fatalf("ExportItems() error = %v", err)
+			}
+
+			var ids []string
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				var record map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &record); err != nil {
+					t.Fatalf("line %q isn't a JSON object: %v", line, err)
+				}
+				if _, ok := record["cycle_time_days"]; !ok {
+					t.Errorf("line %q leaves out cycle_time_days instead of writing null", line)
+				}
+				ids = append(ids, record["id"].(string))
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("exported %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// IterationDates are the first and last day of a named iteration
+type IterationDates struct {
+	Name  string
+	Start time.Time
+	End   time.Time // Inclusive
+}
+
+// Iterations is a list of iteration dates
+type Iterations []IterationDates
+
+// ParseIterations reads one iteration per line in the form "START..END name",
+// e.g. "2024-05-06..2024-05-17 Sprint 12". Names match the iteration column.
+// Blank lines and lines starting with # are ignored.
+func ParseIterations(r io.Reader) (Iterations, error) {
+	var iterations Iterations
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		dates, name, _ := strings.Cut(line, " ")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("line %d: iteration %s has no name", lineNumber, dates)
+		}
+		startStr, endStr, isRange := strings.Cut(dates, "..")
+		if !isRange {
+			return nil, fmt.Errorf("line %d: invalid iteration dates %q (expected YYYY-MM-DD..YYYY-MM-DD)", lineNumber, dates)
+		}
+		start, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid iteration date %q (expected YYYY-MM-DD)", lineNumber, startStr)
+		}
+		end, err := time.Parse("2006-01-02", endStr)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid iteration date %q (expected YYYY-MM-DD)", lineNumber, endStr)
+		}
+		if end.Before(start) {
+			return nil, fmt.Errorf("line %d: iteration ends before it starts: %s", lineNumber, dates)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("line %d: iteration %q is listed twice", lineNumber, name)
+		}
+		seen[name] = true
+
+		iterations = append(iterations, IterationDates{Name: name, Start: start, End: end})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return iterations, nil
+}
+
+// LoadIterations reads an iterations file from disk
+func LoadIterations(path string) (Iterations, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening iterations file: %w", err)
+	}
+	defer file.Close()
+
+	iterations, err := ParseIterations(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading iterations file '%s': %w", path, err)
+	}
+	return iterations, nil
+}
+
+// find returns the dates of the named iteration
+func (it Iterations) find(name string) (IterationDates, bool) {
+	for _, dates := range it {
+		if dates.Name == name {
+			return dates, true
+		}
+	}
+	return IterationDates{}, false
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIterations(t *testing.T) {
+	input := `# Sprint calendar
+2024-05-06..2024-05-17 Sprint 12
+
+2024-05-20..2024-05-31 Sprint 13
+`
+	iterations, err := ParseIterations(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseIterations() error = %v", err)
+	}
+	if len(iterations) != 2 {
+		t.Fatalf("Expected 2 iterations, got %d", len(iterations))
+	}
+
+	sprint, ok := iterations.find("Sprint 13")
+	if !ok {
+		t.Fatal("Sprint 13 not found")
+	}
+	if !sprint.Start.Equal(time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)) || !sprint.End.Equal(time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Sprint 13 = %v..%v, want 2024-05-20..2024-05-31", sprint.Start, sprint.End)
+	}
+	if _, ok := iterations.find("Sprint 14"); ok {
+		t.Error("Sprint 14 should not be found")
+	}
+}
+
+func TestParseIterations_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Missing name", "2024-05-06..2024-05-17", "has no name"},
+		{"Single date", "2024-05-06 Sprint 12", "invalid iteration dates"},
+		{"Invalid date", "2024-05-06..2024-13-01 Sprint 12", "invalid iteration date"},
+		{"Ends before start", "2024-05-17..2024-05-06 Sprint 12", "ends before it starts"},
+		{"Listed twice", "2024-05-06..2024-05-17 Sprint 12\n2024-05-20..2024-05-31 Sprint 12", "listed twice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseIterations(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseIterations() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadIterations_MissingFile(t *testing.T) {
+	if _, err := LoadIterations("/nonexistent/sprints.txt"); err == nil {
+		t.Error("LoadIterations() should fail for a missing file")
+	}
+}
@@ -0,0 +1,198 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/schema"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// Section is the result of one type of metrics in a JSON document
+type Section struct {
+	Type MetricsType `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// Document is the JSON form of a metrics run, with one section per metrics type
+type Document struct {
+	MetricsType MetricsType           `json:"metrics_type"`
+	PeriodType  PeriodType            `json:"period_type"`
+	Start       string                `json:"start,omitempty"`
+	End         string                `json:"end,omitempty"`
+	AdHocFilter types.AdHocFilterType `json:"ad_hoc_filter"`
+	Unit        types.EstimateUnit    `json:"unit"`
+	Seed        *int64                `json:"seed,omitempty"` // Seed of the forecast simulations, when the run has them
+	Message     string                `json:"message,omitempty"`
+	Sections    []Section             `json:"sections"`
+	Failed      []FailedSection       `json:"failed_sections,omitempty"` // Left out of "all"
+}
+
+// generateJSON builds the JSON document for Generate from the filtered items
+func (g *Generator) generateJSON(metricsType MetricsType, periodType PeriodType, startDate, endDate time.Time, items []models.KanbanItem) (string, error) {
+	doc := Document{
+		MetricsType: metricsType,
+		PeriodType:  periodType,
+		AdHocFilter: g.adHocFilter,
+		Unit:        g.opts.Unit,
+		Sections:    []Section{},
+	}
+	if !startDate.IsZero() {
+		doc.Start = startDate.Format("2006-01-02")
+	}
+	if !endDate.IsZero() {
+		doc.End = endDate.Format("2006-01-02")
+	}
+	if g.simulates(metricsType) {
+		seed := g.opts.Forecast.Seed
+		doc.Seed = &seed
+	}
+
+	if len(items) == 0 {
+		doc.Message = "No items completed in the specified date range."
+	} else {
+		sections, failed, err := g.results(metricsType, periodType, endDate, items)
+		if err != nil {
+			return "", err
+		}
+		doc.Sections = sections
+		doc.Failed = failed
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error encoding metrics as JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// results computes the sections behind Generate, taking work in progress and
+// the digest date from the generator the same way as the text reports, and
+// the sections of "all" that failed
+func (g *Generator) results(metricsType MetricsType, periodType PeriodType, endDate time.Time, items []models.KanbanItem) ([]Section, []FailedSection, error) {
+	var data interface{}
+	var err error
+
+	switch metricsType {
+	case MetricsTypeAll:
+		sections := g.opts.Sections
+		if len(sections) == 0 {
+			sections = AllSections
+		}
+		// Like the combined text report, sections that fail are listed apart
+		sectionData, errs, err := generateSections(g.ctx, sections, func(section MetricsType) (interface{}, error) {
+			return sectionResult(section, items, string(periodType), g.opts)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		results := []Section{}
+		var failed []FailedSection
+		for i, section := range sections {
+			if errs[i] != nil {
+				failed = append(failed, newFailedSection(section, errs[i]))
+				continue
+			}
+			results = append(results, Section{section, sectionData[i]})
+		}
+		return results, failed, nil
+	case MetricsTypeBenchmark:
+		data, err = benchmarkResult(items, g.incompleteItems(), time.Now(), g.opts)
+	case MetricsTypeReview:
+		data, err = timeInReviewResult(items, g.incompleteItems(), time.Now(), g.opts)
+	case MetricsTypeDigest:
+		asOf := endDate
+		if asOf.IsZero() {
+			asOf = time.Now()
+		}
+		data = digestResult(g.withWorkInProgress(items), g.isAdHocRequest, asOf, g.opts)
+	case MetricsTypeForecast:
+		data, err = forecastResult(g.withWorkInProgress(items), endDate, g.opts)
+	case MetricsTypeAge:
+		if g.opts.AgeMode == AgeModeAgingWIP {
+			data, err = agingWIPResult(g.withWorkInProgress(items), time.Now(), g.opts)
+		} else {
+			data, err = sectionResult(metricsType, items, string(periodType), g.opts)
+		}
+	case MetricsTypeCommitment:
+		data, err = commitmentResult(g.withWorkInProgress(items), g.opts)
+	case MetricsTypeCFD:
+		data, err = cumulativeFlowResult(g.withWorkInProgress(items), string(periodType), g.opts)
+	default:
+		data, err = sectionResult(metricsType, items, string(periodType), g.opts)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+	return []Section{{metricsType, data}}, nil, nil
+}
+
+// sectionResult computes the structured result of a single type of metrics
+func sectionResult(metricsType MetricsType, items []models.KanbanItem, periodType string, opts Options) (interface{}, error) {
+	switch metricsType {
+	case MetricsTypeLeadTime:
+		return leadTimeResult(items, opts), nil
+	case MetricsTypeThroughput:
+		return throughputResult(items, periodType, opts), nil
+	case MetricsTypeFlow:
+		return flowResult(items), nil
+	case MetricsTypeEstimation:
+		return estimationResult(items, opts), nil
+	case MetricsTypeAge:
+		if opts.AgeMode == AgeModeAgingWIP {
+			return agingWIPResult(items, time.Now(), opts)
+		}
+		return workItemAgeResult(items, time.Now(), opts), nil
+	case MetricsTypeImprovement:
+		return improvementResult(items, opts), nil
+	case MetricsTypeWorkflow:
+		return workflowResult(items, periodType, opts), nil
+	case MetricsTypeBenchmark:
+		return benchmarkResult(items, items, time.Now(), opts)
+	case MetricsTypeReview:
+		return timeInReviewResult(items, items, time.Now(), opts)
+	case MetricsTypeCFD:
+		return cumulativeFlowResult(items, periodType, opts)
+	case MetricsTypePriority:
+		return priorityDistributionResult(items, periodType, opts)
+	case MetricsTypeDigest:
+		return digestResult(items, nil, time.Now(), opts), nil
+	case MetricsTypeWeekday:
+		return completionWeekdayResult(items)
+	case MetricsTypeForecast:
+		return forecastResult(items, time.Now(), opts)
+	case MetricsTypeCommitment:
+		return commitmentResult(items, opts)
+	case MetricsTypeScatterplot:
+		return scatterplotResult(items)
+	default:
+		return nil, fmt.Errorf("unknown metrics type: %s", metricsType)
+	}
+}
+
+// JSONSchema describes the JSON document of metrics, with the result held by
+// the section of each metrics type. "all" has no section of its own; it has
+// one section per metrics type it includes.
+func JSONSchema() (schema.Schema, error) {
+	return schema.ForDocument("Kanban metrics", Document{}, Section{}, []schema.Section{
+		{Type: string(MetricsTypeLeadTime), Results: []interface{}{LeadTimeResult{}}},
+		{Type: string(MetricsTypeThroughput), Results: []interface{}{ThroughputResult{}}},
+		{Type: string(MetricsTypeFlow), Results: []interface{}{FlowResult{}}},
+		{Type: string(MetricsTypeEstimation), Results: []interface{}{EstimationResult{}}},
+		{Type: string(MetricsTypeAge), Results: []interface{}{AgeResult{}, AgingWIPResult{}}},
+		{Type: string(MetricsTypeImprovement), Results: []interface{}{ImprovementResult{}}},
+		{Type: string(MetricsTypeWorkflow), Results: []interface{}{WorkflowResult{}}},
+		{Type: string(MetricsTypeBenchmark), Results: []interface{}{BenchmarkResult{}}},
+		{Type: string(MetricsTypeReview), Results: []interface{}{ReviewResult{}}},
+		{Type: string(MetricsTypeCFD), Results: []interface{}{CFDResult{}}},
+		{Type: string(MetricsTypePriority), Results: []interface{}{PriorityResult{}}},
+		{Type: string(MetricsTypeDigest), Results: []interface{}{DigestResult{}}},
+		{Type: string(MetricsTypeWeekday), Results: []interface{}{WeekdayResult{}}},
+		{Type: string(MetricsTypeForecast), Results: []interface{}{ForecastResult{}}},
+		{Type: string(MetricsTypeCommitment), Results: []interface{}{CommitmentResult{}}},
+		{Type: string(MetricsTypeScatterplot), Results: []interface{}{ScatterplotResult{}}},
+	})
+}
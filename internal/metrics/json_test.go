@@ -0,0 +1,195 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/schema"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestGenerateJSON(t *testing.T) {
+	now := time.Now()
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Estimate: 3, IsCompleted: true, CreatedAt: now.AddDate(0, 0, -10), StartedAt: now.AddDate(0, 0, -7), CompletedAt: now.AddDate(0, 0, -5)},
+		{ID: "2", Name: "Task 2", Estimate: 1, IsCompleted: true, CreatedAt: now.AddDate(0, 0, -8), StartedAt: now.AddDate(0, 0, -5), CompletedAt: now.AddDate(0, 0, -3)},
+		{ID: "3", Name: "Task 3", Estimate: 2, IsCompleted: false, CreatedAt: now.AddDate(0, 0, -4), StartedAt: now.AddDate(0, 0, -2)},
+	}
+
+	tests := []struct {
+		name        string
+		metricsType MetricsType
+		sections    []MetricsType
+		wantTypes   []MetricsType
+	}{
+		{
+			name:        "Single metrics type",
+			metricsType: MetricsTypeLeadTime,
+			wantTypes:   []MetricsType{MetricsTypeLeadTime},
+		},
+		{
+			name:        "Benchmark includes work in progress",
+			metricsType: MetricsTypeBenchmark,
+			wantTypes:   []MetricsType{MetricsTypeBenchmark},
+		},
+		{
+			name:        "All with selected sections",
+			metricsType: MetricsTypeAll,
+			sections:    []MetricsType{MetricsTypeThroughput, MetricsTypeFlow, MetricsTypeWeekday},
+			wantTypes:   []MetricsType{MetricsTypeThroughput, MetricsTypeFlow, MetricsTypeWeekday},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := NewGenerator(items).WithFormat(types.FormatJSON).WithSections(tt.sections).
+				Generate(tt.metricsType, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			var doc struct {
+				MetricsType MetricsType `json:"metrics_type"`
+				Sections    []struct {
+					Type MetricsType     `json:"type"`
+					Data json.RawMessage `json:"data"`
+				} `json:"sections"`
+			}
+			if err := json.Unmarshal([]byte(output), &doc); err != nil {
+				t.Fatalf("Generate() returned invalid JSON: %v\n%s", err, output)
+			}
+			if doc.MetricsType != tt.metricsType {
+				t.Errorf("metrics_type = %s, want %s", doc.MetricsType, tt.metricsType)
+			}
+			if len(doc.Sections) != len(tt.wantTypes) {
+				t.Fatalf("got %d sections, want %d", len(doc.Sections), len(tt.wantTypes))
+			}
+			for i, section := range doc.Sections {
+				if section.Type != tt.wantTypes[i] {
+					t.Errorf("section %d type = %s, want %s", i, section.Type, tt.wantTypes[i])
+				}
+				if len(section.Data) == 0 || string(section.Data) == "null" {
+					t.Errorf("section %s has no data", section.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratorResult(t *testing.T) {
+	items := documentTestItems()
+
+	result, err := NewGenerator(items).Result(MetricsTypeLeadTime, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Result() error = %v", err)
+	}
+	leadTime, ok := result.(LeadTimeResult)
+	if !ok {
+		t.Fatalf("Result() = %T, want LeadTimeResult", result)
+	}
+	if len(leadTime.LeadTime) == 0 {
+		t.Errorf("Result() lead time groups are empty: %+v", leadTime)
+	}
+
+	if _, err := NewGenerator(items).Result(MetricsTypeAll, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt); err == nil {
+		t.Error("Result(all) should return an error")
+	}
+}
+
+func TestGenerateJSONLeadTimeValues(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	items := []models.KanbanItem{
+		{ID: "1", Estimate: 3, IsCompleted: true, CreatedAt: day(1), StartedAt: day(3), CompletedAt: day(5)},
+		{ID: "2", Estimate: 3, IsCompleted: true, CreatedAt: day(1), StartedAt: day(2), CompletedAt: day(9)},
+	}
+
+	output, err := NewGenerator(items).WithFormat(types.FormatJSON).
+		Generate(MetricsTypeLeadTime, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	var doc struct {
+		Sections []struct {
+			Data LeadTimeResult `json:"data"`
+		} `json:"sections"`
+	}
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	result := doc.Sections[0].Data
+	if len(result.LeadTime) != 1 || result.LeadTime[0].Stats.Avg != 6 {
+		t.Errorf("lead time = %+v, want one group averaging 6 days", result.LeadTime)
+	}
+	if len(result.CycleTime) != 1 || result.CycleTime[0].Stats.Max != 7 {
+		t.Errorf("cycle time = %+v, want one group with a maximum of 7 days", result.CycleTime)
+	}
+}
+
+func TestGenerateJSONNoItems(t *testing.T) {
+	output, err := NewGenerator(nil).WithFormat(types.FormatJSON).
+		Generate(MetricsTypeThroughput, PeriodTypeWeek, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !json.Valid([]byte(output)) {
+		t.Fatalf("Generate() returned invalid JSON:\n%s", output)
+	}
+	for _, want := range []string{`"message": "No items completed in the specified date range."`, `"sections": []`, `"period_type": "week"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output doesn't contain %s:\n%s", want, output)
+		}
+	}
+}
+
+func TestBenchmarkGroupMarshalJSON(t *testing.T) {
+	group := BenchmarkGroup{Name: "Team", ItemCount: 2, P85CycleTime: 3.5, ThroughputCV: math.NaN(), FlowEfficiency: math.NaN(), WIPAge: math.NaN(), Score: math.Inf(1)}
+
+	data, err := json.Marshal(group)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, want := range []string{`"p85_cycle_time":3.5`, `"throughput_cv":null`, `"score":null`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON doesn't contain %s: %s", want, data)
+		}
+	}
+}
+
+func TestJSONSchema_MatchesOutput(t *testing.T) {
+	jsonSchema, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+
+	now := time.Now()
+	daysAgo := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+	var items []models.KanbanItem
+	for i := 0; i < 12; i++ {
+		items = append(items, models.KanbanItem{
+			ID: fmt.Sprintf("%d", i), Name: fmt.Sprintf("Task %d", i), Estimate: float64(i%3 + 1), Team: "Platform",
+			State: "Done", Iteration: "Sprint 1", Priority: "High", Type: "feature",
+			CreatedAt: daysAgo(40 + i), StartedAt: daysAgo(30 + i), IsCompleted: true, CompletedAt: daysAgo(i * 2),
+		})
+	}
+	items = append(items, models.KanbanItem{ID: "open", Name: "Open", Estimate: 2, Team: "Platform", State: "In Progress", CreatedAt: daysAgo(20), StartedAt: daysAgo(15)})
+
+	for _, metricsType := range append(append([]MetricsType{}, AllSections...), MetricsTypeAll, MetricsTypeBenchmark, MetricsTypeReview, MetricsTypeDigest, MetricsTypeForecast, MetricsTypeCommitment) {
+		for _, ageMode := range []AgeMode{AgeModeSummary, AgeModeAgingWIP} {
+			output, err := NewGenerator(items).WithFormat(types.FormatJSON).WithAgeMode(ageMode).
+				Generate(metricsType, PeriodTypeWeek, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+			if err != nil {
+				t.Errorf("Generate(%s) error = %v", metricsType, err)
+				continue
+			}
+			if err := schema.Validate(jsonSchema, []byte(output)); err != nil {
+				t.Errorf("%s output doesn't match the schema: %v\n%s", metricsType, err, output)
+			}
+		}
+	}
+}
@@ -6,8 +6,20 @@ import (
 	"github.com/hannasdev/kanban-reports/internal/models"
 )
 
+// LeadTimeResult holds lead and cycle time statistics (in days) per estimate size
+type LeadTimeResult struct {
+	LeadTime           []GroupStats      `json:"lead_time"`
+	CycleTime          []GroupStats      `json:"cycle_time"`
+	CycleTimeHistogram []HistogramBucket `json:"cycle_time_histogram"`
+}
+
 // LeadTimeReport shows how long items take from creation to completion
 func LeadTimeReport(items []models.KanbanItem) (string, error) {
+	return leadTimeReport(items, DefaultOptions())
+}
+
+// leadTimeResult calculates lead and cycle times grouped by the closest standard size
+func leadTimeResult(items []models.KanbanItem, opts Options) LeadTimeResult {
 	// Group by story point size
 	leadTimesByPoints := make(map[float64][]float64)
 	cycleTimesByPoints := make(map[float64][]float64)
@@ -37,51 +49,48 @@ func LeadTimeReport(items []models.KanbanItem) (string, error) {
 		}
 	}
 	
+	// Process all standard point sizes, even if we don't have data for some
+	var result LeadTimeResult
+	for _, size := range standardPointSizes {
+		if times := leadTimesByPoints[size]; len(times) > 0 {
+			result.LeadTime = append(result.LeadTime, GroupStats{fmt.Sprintf("%.0f", size), summarize(times)})
+		}
+		if times := cycleTimesByPoints[size]; len(times) > 0 {
+			result.CycleTime = append(result.CycleTime, GroupStats{fmt.Sprintf("%.0f", size), summarize(times)})
+		}
+	}
+	
+	// Add cycle time distribution across all sizes
+	var allCycleTimes []float64
+	for _, size := range standardPointSizes {
+		allCycleTimes = append(allCycleTimes, cycleTimesByPoints[size]...)
+	}
+	allCycleTimes = append(allCycleTimes, cycleTimesByPoints[0]...)
+	result.CycleTimeHistogram = bucketValues(allCycleTimes, opts.HistogramBuckets)
+	
+	return result
+}
+
+// leadTimeReport builds the lead time report using the given options
+func leadTimeReport(items []models.KanbanItem, opts Options) (string, error) {
+	result := leadTimeResult(items, opts)
+	
 	// Calculate statistics for each point size
-	report := "# Lead Time Analysis by Story Point Size (in days)\n\n"
+	report := fmt.Sprintf("# Lead Time Analysis by %s (in days)\n\n", sizeTitle(opts.Unit))
 	
 	// Add explanatory text
-	report += "## What is Lead Time?\n\n"
-	report += "Lead Time measures how long it takes for work to go from creation to completion. It's the total elapsed time that a customer waits for their request to be delivered.\n\n"
-	report += "- **Lead Time**: Time from when an item is created to when it's completed (includes both waiting and active time)\n"
-	report += "- **Cycle Time**: Time from when work actively starts on an item to when it's completed (active time only)\n\n"
-	report += "Lower values indicate faster delivery. Higher story point items typically have longer lead and cycle times.\n\n"
-	report += "## How to use this data:\n"
-	report += "- Compare different sized items to validate your estimation system\n"
-	report += "- Use these values to set realistic delivery expectations with stakeholders\n"
-	report += "- Track these metrics over time to identify process improvements\n\n"
+	if !opts.NoExplanations {
+		report += leadTimeExplanation
+	}
 	
 	report += "## Lead Time (Creation to Completion)\n\n"
-	report += "Story points | Count | Min | Max | Avg | Median\n"
-	report += "-------------|-------|-----|-----|-----|-------\n"
-	
-	// Process all standard point sizes, even if we don't have data for some
-	for _, size := range standardPointSizes {
-		times := leadTimesByPoints[size]
-		if len(times) == 0 {
-			continue
-		}
-		
-		min, max, avg, median := calculateStats(times)
-		report += fmt.Sprintf("%12.0f | %5d | %3.1f | %3.1f | %3.1f | %6.1f\n", 
-			size, len(times), min, max, avg, median)
-	}
+	report += formatStatsTable(opts.Unit.SizeLabel(), result.LeadTime, opts.Stats, "")
 	
 	// Add cycle time statistics
 	report += "\n## Cycle Time (Start to Completion)\n\n"
-	report += "Story points | Count | Min | Max | Avg | Median\n"
-	report += "-------------|-------|-----|-----|-----|-------\n"
+	report += formatStatsTable(opts.Unit.SizeLabel(), result.CycleTime, opts.Stats, "")
 	
-	for _, size := range standardPointSizes {
-		times := cycleTimesByPoints[size]
-		if len(times) == 0 {
-			continue
-		}
-		
-		min, max, avg, median := calculateStats(times)
-		report += fmt.Sprintf("%12.0f | %5d | %3.1f | %3.1f | %3.1f | %6.1f\n", 
-			size, len(times), min, max, avg, median)
-	}
+	report += "\n" + formatHistogram("Cycle Time Distribution", "Cycle time", result.CycleTimeHistogram)
 	
 	return report, nil
-}
\ No newline at end of file
+}
@@ -1,11 +1,15 @@
 package metrics
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+	"github.com/hannasdev/kanban-reports/pkg/filtering"
 	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
@@ -13,6 +17,10 @@ import (
 type Generator struct {
 	items       []models.KanbanItem
 	adHocFilter types.AdHocFilterType
+	adHocRules  filtering.AdHocRules
+	format      types.OutputFormat
+	opts        Options
+	ctx         context.Context
 }
 
 // NewGenerator creates a new metrics generator
@@ -20,15 +28,188 @@ func NewGenerator(items []models.KanbanItem) *Generator {
 	return &Generator{
 		items:       items,
 		adHocFilter: types.AdHocFilterInclude,
+		adHocRules:  filtering.DefaultAdHocRules(),
+		format:      types.FormatText,
+		opts:        DefaultOptions(),
+		ctx:         context.Background(),
 	}
 }
 
+// WithContext sets the context that cancels generation between sections
+func (g *Generator) WithContext(ctx context.Context) *Generator {
+	g.ctx = ctx
+	return g
+}
+
 // WithAdHocFilter sets the ad-hoc request filter
 func (g *Generator) WithAdHocFilter(filter types.AdHocFilterType) *Generator {
 	g.adHocFilter = filter
 	return g
 }
 
+// WithAdHocRules sets the rules that decide which items are ad-hoc requests
+func (g *Generator) WithAdHocRules(rules filtering.AdHocRules) *Generator {
+	if rules.IsEmpty() {
+		rules = filtering.DefaultAdHocRules()
+	}
+	g.adHocRules = rules
+	return g
+}
+
+// WithStats sets the statistic columns shown in statistical tables
+func (g *Generator) WithStats(stats []StatType) *Generator {
+	if len(stats) == 0 {
+		stats = DefaultStats
+	}
+	g.opts.Stats = stats
+	return g
+}
+
+// WithHolidays sets the holidays excluded from working-day calculations
+func (g *Generator) WithHolidays(holidays dateutil.Holidays) *Generator {
+	g.opts.Holidays = holidays
+	return g
+}
+
+// WithAbsences sets the absence calendar used to adjust throughput for reduced capacity
+func (g *Generator) WithAbsences(absences dateutil.Absences) *Generator {
+	g.opts.Absences = absences
+	return g
+}
+
+// WithAnnotations sets the dated events marked in throughput and improvement trends
+func (g *Generator) WithAnnotations(annotations Annotations) *Generator {
+	g.opts.Annotations = annotations
+	return g
+}
+
+// WithWIPLimits sets the per-team and per-state WIP limits used to flag overloaded states
+func (g *Generator) WithWIPLimits(limits WIPLimits) *Generator {
+	g.opts.WIPLimits = limits
+	return g
+}
+
+// WithAgeThresholds sets the per-state age thresholds used to flag aging work
+func (g *Generator) WithAgeThresholds(thresholds AgeThresholds) *Generator {
+	g.opts.AgeThresholds = thresholds
+	return g
+}
+
+// WithUnit sets the unit estimates are aggregated and labeled in
+func (g *Generator) WithUnit(unit types.EstimateUnit) *Generator {
+	if unit == "" {
+		unit = types.UnitPoints
+	}
+	g.opts.Unit = unit
+	return g
+}
+
+// WithAgeMode sets whether the age metric shows the summary or the aging WIP chart
+func (g *Generator) WithAgeMode(mode AgeMode) *Generator {
+	if mode == "" {
+		mode = AgeModeSummary
+	}
+	g.opts.AgeMode = mode
+	return g
+}
+
+// WithIterations sets the iteration dates used by the commitment report
+func (g *Generator) WithIterations(iterations Iterations) *Generator {
+	g.opts.Iterations = iterations
+	return g
+}
+
+// WithForecast sets what the Monte Carlo forecast answers
+func (g *Generator) WithForecast(settings ForecastSettings) *Generator {
+	g.opts.Forecast = settings
+	return g
+}
+
+// WithWeekNumbering sets where weeks start and how they are labeled
+func (g *Generator) WithWeekNumbering(numbering types.WeekNumbering) *Generator {
+	if numbering == "" {
+		numbering = types.WeekNumberingISO
+	}
+	g.opts.WeekNumbering = numbering
+	return g
+}
+
+// WithPeriodLabels sets how the weeks and months of trend tables are labeled
+func (g *Generator) WithPeriodLabels(labels types.PeriodLabels) *Generator {
+	if labels == "" {
+		labels = types.PeriodLabelsISO
+	}
+	g.opts.PeriodLabels = labels
+	return g
+}
+
+// WithExplanations sets whether reports open with sections explaining each metric
+func (g *Generator) WithExplanations(show bool) *Generator {
+	g.opts.NoExplanations = !show
+	return g
+}
+
+// WithSplitBy sets the field used to group items in comparison reports
+func (g *Generator) WithSplitBy(field SplitField) *Generator {
+	if field == "" {
+		field = SplitByTeam
+	}
+	g.opts.SplitBy = field
+	return g
+}
+
+// WithSections sets the metrics generated, in order, for MetricsTypeAll
+func (g *Generator) WithSections(sections []MetricsType) *Generator {
+	if len(sections) == 0 {
+		sections = AllSections
+	}
+	g.opts.Sections = sections
+	return g
+}
+
+// WithSeparator sets the line placed between sections of combined reports
+func (g *Generator) WithSeparator(separator string) *Generator {
+	if separator == "" {
+		separator = DefaultSeparator
+	}
+	g.opts.Separator = separator
+	return g
+}
+
+// WithWidth limits the line width of wide tables (0 for no limit)
+func (g *Generator) WithWidth(width int) *Generator {
+	g.opts.Width = width
+	return g
+}
+
+// WithDigestSettings sets the thresholds used by the digest highlights
+func (g *Generator) WithDigestSettings(settings DigestSettings) *Generator {
+	if settings == (DigestSettings{}) {
+		settings = DefaultDigestSettings()
+	}
+	g.opts.Digest = settings
+	return g
+}
+
+// WithHistogramBuckets sets the upper bounds (in days) of the cycle time histogram buckets
+func (g *Generator) WithHistogramBuckets(bounds []float64) *Generator {
+	if len(bounds) == 0 {
+		bounds = DefaultHistogramBuckets
+	}
+	g.opts.HistogramBuckets = bounds
+	return g
+}
+
+// WithFormat sets whether Generate returns text tables, a JSON document, or a
+// Markdown or HTML document
+func (g *Generator) WithFormat(format types.OutputFormat) *Generator {
+	if format == "" {
+		format = types.FormatText
+	}
+	g.format = format
+	return g
+}
+
 // filterItemsByDateRange returns items completed within the given date range
 func (g *Generator) filterItemsByDateRange(startDate, endDate time.Time, filterField models.FilterField) []models.KanbanItem {
 	var filtered []models.KanbanItem
@@ -68,14 +249,41 @@ func (g *Generator) filterItemsByDateRange(startDate, endDate time.Time, filterF
 return filtered
 }
 
-// isAdHocRequest checks if an item is an ad-hoc request (has "ad-hoc-request" label)
-func (g *Generator) isAdHocRequest(item models.KanbanItem) bool {
-	for _, label := range item.Labels {
-		if strings.ToLower(label) == "ad-hoc-request" {
-			return true
+// incompleteItems returns the items still in progress, honoring the ad-hoc request filter
+func (g *Generator) incompleteItems() []models.KanbanItem {
+	var incomplete []models.KanbanItem
+	for _, item := range g.items {
+		if item.IsCompleted {
+			continue
+		}
+		isAdHoc := g.isAdHocRequest(item)
+		if (g.adHocFilter == types.AdHocFilterExclude && isAdHoc) || (g.adHocFilter == types.AdHocFilterOnly && !isAdHoc) {
+			continue
+		}
+		incomplete = append(incomplete, item)
+	}
+	return incomplete
+}
+
+// withWorkInProgress adds the incomplete items missing from items, for reports
+// that show work still on the board alongside completed work
+func (g *Generator) withWorkInProgress(items []models.KanbanItem) []models.KanbanItem {
+	present := make(map[string]bool)
+	for _, item := range items {
+		present[item.ID] = true
+	}
+	combined := append([]models.KanbanItem{}, items...)
+	for _, item := range g.incompleteItems() {
+		if !present[item.ID] {
+			combined = append(combined, item)
 		}
 	}
-	return false
+	return combined
+}
+
+// isAdHocRequest checks if an item is an ad-hoc request under the configured rules
+func (g *Generator) isAdHocRequest(item models.KanbanItem) bool {
+	return g.adHocRules.Matches(item)
 }
 
 // addDateRangeInfo adds date range information to the beginning of the metrics report
@@ -113,15 +321,42 @@ func (g *Generator) addDateRangeInfo(report string, metricsType MetricsType, per
 	case types.AdHocFilterOnly:
 		header += "Filter: Only ad-hoc requests\n\n"
 	}
+
+	if g.simulates(metricsType) {
+		header += fmt.Sprintf("Seed: %d\n\n", g.opts.Forecast.Seed)
+	}
 	
 	return header + report
 }
 
+// simulates reports whether a metrics run includes the Monte Carlo forecast,
+// whose seed is then recorded with the output so the run can be repeated
+func (g *Generator) simulates(metricsType MetricsType) bool {
+	if metricsType == MetricsTypeAll {
+		for _, section := range g.opts.Sections {
+			if section == MetricsTypeForecast {
+				return true
+			}
+		}
+	}
+	return metricsType == MetricsTypeForecast
+}
+
 // Generate generates metrics based on the specified type and time period
 func (g *Generator) Generate(metricsType MetricsType, periodType PeriodType, startDate, endDate time.Time, filterField models.FilterField) (string, error) {
-	// Filter items by date within range using the FilterField
-	filteredItems := g.filterItemsByDateRange(startDate, endDate, filterField)
+	if err := g.ctx.Err(); err != nil {
+		return "", err
+	}
+
+	filteredItems := g.itemsFor(metricsType, startDate, endDate, filterField)
  
+	if g.format == types.FormatMarkdown || g.format == types.FormatHTML {
+		return g.renderDocument(metricsType, periodType, startDate, endDate, filteredItems)
+	}
+	if g.format == types.FormatJSON {
+		return g.generateJSON(metricsType, periodType, startDate, endDate, filteredItems)
+	}
+	
 	if len(filteredItems) == 0 {
 		return "No items completed in the specified date range.", nil
 	}
@@ -130,76 +365,240 @@ func (g *Generator) Generate(metricsType MetricsType, periodType PeriodType, sta
 	var metricsContent string
 	var err error
 
+	if metricsType == MetricsTypeAll {
+		metricsContent, err = generateAllReports(g.ctx, filteredItems, string(periodType), g.opts)
+	} else {
+		metricsContent, err = g.generateReport(metricsType, periodType, endDate, filteredItems)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	// Add date range information to the metrics report
+	reportWithDateInfo := g.addDateRangeInfo(metricsContent, metricsType, periodType, startDate, endDate)
+	return reportWithDateInfo, nil
+}
+
+// itemsFor filters the items by date within range using the FilterField. The
+// digest compares the latest week with the weeks before it, so it ignores the start.
+func (g *Generator) itemsFor(metricsType MetricsType, startDate, endDate time.Time, filterField models.FilterField) []models.KanbanItem {
+	if metricsType == MetricsTypeDigest {
+		startDate = time.Time{}
+	}
+	return g.filterItemsByDateRange(startDate, endDate, filterField)
+}
+
+// Result computes the structured result of a single metrics type over the
+// items in the date range: the data of its section in the JSON output
+func (g *Generator) Result(metricsType MetricsType, periodType PeriodType, startDate, endDate time.Time, filterField models.FilterField) (interface{}, error) {
+	if err := g.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if metricsType == MetricsTypeAll {
+		return nil, fmt.Errorf("a result covers one metrics type; %s has one per section", MetricsTypeAll)
+	}
+
+	sections, _, err := g.results(metricsType, periodType, endDate, g.itemsFor(metricsType, startDate, endDate, filterField))
+	if err != nil {
+		return nil, err
+	}
+	return sections[0].Data, nil
+}
+
+// generateReport generates a single type of metrics report, taking work in
+// progress and the digest date from the generator
+func (g *Generator) generateReport(metricsType MetricsType, periodType PeriodType, endDate time.Time, items []models.KanbanItem) (string, error) {
+	switch metricsType {
+	case MetricsTypeBenchmark:
+		// Work in progress has no completion date, so it is taken from all items
+		return benchmarkReport(items, g.incompleteItems(), time.Now(), g.opts)
+	case MetricsTypeReview:
+		return timeInReviewReport(items, g.incompleteItems(), time.Now(), g.opts)
+	case MetricsTypeDigest:
+		asOf := endDate
+		if asOf.IsZero() {
+			asOf = time.Now()
+		}
+		return digestReport(g.withWorkInProgress(items), g.isAdHocRequest, asOf, g.opts)
+	case MetricsTypeForecast:
+		return forecastReport(g.withWorkInProgress(items), endDate, g.opts)
+	case MetricsTypeAge:
+		if g.opts.AgeMode != AgeModeAgingWIP {
+			return generateSection(metricsType, items, string(periodType), g.opts)
+		}
+		// Open items come from the whole board; completed items in range give the percentiles
+		return agingWIPReport(g.withWorkInProgress(items), time.Now(), g.opts)
+	case MetricsTypeCommitment:
+		// Committed work that was never finished still counts against the iteration
+		return commitmentReport(g.withWorkInProgress(items), g.opts)
+	case MetricsTypeCFD:
+		return cumulativeFlowReport(g.withWorkInProgress(items), string(periodType), g.opts)
+	default:
+		return generateSection(metricsType, items, string(periodType), g.opts)
+	}
+}
+
+// generateSection generates a single type of metrics report
+func generateSection(metricsType MetricsType, items []models.KanbanItem, periodType string, opts Options) (string, error) {
 	switch metricsType {
 	case MetricsTypeLeadTime:
-		metricsContent, err = LeadTimeReport(filteredItems)
+		return leadTimeReport(items, opts)
 	case MetricsTypeThroughput:
-		metricsContent, err = ThroughputReport(filteredItems, string(periodType))
+		return throughputReport(items, periodType, opts)
 	case MetricsTypeFlow:
-		metricsContent, err = FlowEfficiencyReport(filteredItems)
+		return flowEfficiencyReport(items, opts)
 	case MetricsTypeEstimation:
-		metricsContent, err = EstimationAccuracyReport(filteredItems)
+		return estimationAccuracyReport(items, opts)
 	case MetricsTypeAge:
-		metricsContent, err = WorkItemAgeReport(filteredItems, time.Now())
+		if opts.AgeMode == AgeModeAgingWIP {
+			return agingWIPReport(items, time.Now(), opts)
+		}
+		return workItemAgeReport(items, time.Now(), opts)
 	case MetricsTypeImprovement:
-		metricsContent, err = TeamImprovementReport(filteredItems)
-	case MetricsTypeAll:
-		metricsContent, err = GenerateAllReports(filteredItems, string(periodType))
+		return teamImprovementReport(items, opts)
+	case MetricsTypeWorkflow:
+		return workflowComparisonReport(items, periodType, opts)
+	case MetricsTypeBenchmark:
+		return benchmarkReport(items, items, time.Now(), opts)
+	case MetricsTypeReview:
+		return timeInReviewReport(items, items, time.Now(), opts)
+	case MetricsTypeCFD:
+		return cumulativeFlowReport(items, periodType, opts)
+	case MetricsTypePriority:
+		return priorityDistributionReport(items, periodType, opts)
+	case MetricsTypeDigest:
+		return digestReport(items, nil, time.Now(), opts)
+	case MetricsTypeWeekday:
+		return completionWeekdayReport(items, opts)
+	case MetricsTypeScatterplot:
+		return scatterplotReport(items)
+	case MetricsTypeForecast:
+		return forecastReport(items, time.Now(), opts)
+	case MetricsTypeCommitment:
+		return commitmentReport(items, opts)
 	default:
 		return "", fmt.Errorf("unknown metrics type: %s", metricsType)
 	}
-
-	if err != nil {
-		return "", err
-	}
-
-	// Add date range information to the metrics report
-	reportWithDateInfo := g.addDateRangeInfo(metricsContent, metricsType, periodType, startDate, endDate)
-	return reportWithDateInfo, nil
 }
 
 // GenerateAllReports generates all types of metrics reports
 func GenerateAllReports(items []models.KanbanItem, periodType string) (string, error) {
-	// Generate all reports and combine them
-	reports := []string{}
-	
-	leadTime, err := LeadTimeReport(items)
-	if err == nil {
-		reports = append(reports, leadTime)
+	return generateAllReports(context.Background(), items, periodType, DefaultOptions())
+}
+
+// generateAllReports generates all types of metrics reports using the given
+// options, stopping with the context's error when it is cancelled
+func generateAllReports(ctx context.Context, items []models.KanbanItem, periodType string, opts Options) (string, error) {
+	sections := opts.Sections
+	if len(sections) == 0 {
+		sections = AllSections
 	}
 	
-	throughput, err := ThroughputReport(items, periodType)
-	if err == nil {
-		reports = append(reports, throughput)
+	// Generate the selected reports and combine them. Sections that fail are
+	// listed at the end instead of stopping the others.
+	results, errs, err := generateSections(ctx, sections, func(section MetricsType) (string, error) {
+		return generateSection(section, items, periodType, opts)
+	})
+	if err != nil {
+		return "", err
 	}
-	
-	flow, err := FlowEfficiencyReport(items)
-	if err == nil {
-		reports = append(reports, flow)
+	reports := []string{}
+	var failed []FailedSection
+	for i, section := range sections {
+		if errs[i] != nil {
+			failed = append(failed, newFailedSection(section, errs[i]))
+			continue
+		}
+		reports = append(reports, results[i])
 	}
-	
-	estimation, err := EstimationAccuracyReport(items)
-	if err == nil {
-		reports = append(reports, estimation)
+	if len(failed) > 0 {
+		reports = append(reports, failedSectionsReport(failed))
 	}
 	
-	age, err := WorkItemAgeReport(items, time.Now())
-	if err == nil {
-		reports = append(reports, age)
+	return combineReports(reports, opts.Separator), nil
+}
+
+// generateSections calculates the sections of MetricsTypeAll concurrently,
+// isolating each, and returns their results and errors in section order.
+// Sections only read the items, so they can share them.
+func generateSections[T any](ctx context.Context, sections []MetricsType, calculate func(MetricsType) (T, error)) ([]T, []error, error) {
+	results := make([]T, len(sections))
+	errs := make([]error, len(sections))
+
+	var wg sync.WaitGroup
+	for i, section := range sections {
+		if err := ctx.Err(); err != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int, section MetricsType) {
+			defer wg.Done()
+			if errs[i] = ctx.Err(); errs[i] == nil {
+				results[i], errs[i] = isolateSection(section, func() (T, error) { return calculate(section) })
+			}
+		}(i, section)
 	}
-	
-	improvement, err := TeamImprovementReport(items)
-	if err == nil {
-		reports = append(reports, improvement)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
 	}
-	
-	return combineReports(reports), nil
+	return results, errs, nil
 }
 
-// combineReports combines multiple report strings with separators
-func combineReports(reports []string) string {
+// FailedSection is a section of MetricsTypeAll that could not be generated,
+// usually because the items lack the data it needs
+type FailedSection struct {
+	Type  MetricsType `json:"type"`
+	Error string      `json:"error"`
+	Panic bool        `json:"panic,omitempty"` // The calculation crashed on the data
+}
+
+// sectionPanic is the error of a section whose calculation panicked
+type sectionPanic struct {
+	section MetricsType
+	value   interface{}
+}
+
+func (p sectionPanic) Error() string {
+	return fmt.Sprintf("%s metrics crashed: %v", p.section, p.value)
+}
+
+// isolateSection runs one section's calculation, turning a panic into an
+// error so one section can't take down the combined report
+func isolateSection[T any](section MetricsType, calculate func() (T, error)) (result T, err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			var zero T
+			result, err = zero, sectionPanic{section, value}
+		}
+	}()
+	return calculate()
+}
+
+// newFailedSection records why a section could not be generated
+func newFailedSection(section MetricsType, err error) FailedSection {
+	var crashed sectionPanic
+	return FailedSection{Type: section, Error: err.Error(), Panic: errors.As(err, &crashed)}
+}
+
+// failedSectionsReport lists the sections left out of a combined report and why
+func failedSectionsReport(failed []FailedSection) string {
+	report := "# Sections Not Generated\n\n"
+	for _, section := range failed {
+		report += fmt.Sprintf("- %s: %s\n", section.Type, section.Error)
+	}
+	return report
+}
+
+// combineReports combines multiple report strings with the given separator line
+func combineReports(reports []string, separatorLine string) string {
+	if separatorLine == "" {
+		separatorLine = DefaultSeparator
+	}
 	combined := ""
-	separator := "\n\n" + strings.Repeat("=", 80) + "\n\n"
+	separator := "\n\n" + separatorLine + "\n\n"
 	
 	for i, report := range reports {
 		combined += report
@@ -2,6 +2,10 @@
 package metrics
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -211,6 +215,188 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestGenerateAllWithSections(t *testing.T) {
+	now := time.Now()
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Estimate: 3, IsCompleted: true, CreatedAt: now.AddDate(0, 0, -10), StartedAt: now.AddDate(0, 0, -7), CompletedAt: now.AddDate(0, 0, -5)},
+		{ID: "2", Name: "Task 2", Estimate: 1, IsCompleted: true, CreatedAt: now.AddDate(0, 0, -8), StartedAt: now.AddDate(0, 0, -5), CompletedAt: now.AddDate(0, 0, -3)},
+	}
+
+	tests := []struct {
+		name        string
+		sections    []MetricsType
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "Default sections",
+			sections: nil,
+			contains: []string{"Lead Time Analysis", "Throughput Analysis", "Current Work Item Age Analysis"},
+		},
+		{
+			name:        "Excluded sections",
+			sections:    SelectSections(nil, []MetricsType{MetricsTypeAge, MetricsTypeEstimation}),
+			contains:    []string{"Lead Time Analysis", "Throughput Analysis"},
+			notContains: []string{"Current Work Item Age Analysis", "Estimation Accuracy"},
+		},
+		{
+			name:        "Only sections",
+			sections:    SelectSections([]MetricsType{MetricsTypeThroughput, MetricsTypeWorkflow}, nil),
+			contains:    []string{"Throughput Analysis", "Workflow Comparison"},
+			notContains: []string{"Lead Time Analysis"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := NewGenerator(items).WithSections(tt.sections).Generate(MetricsTypeAll, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			for _, str := range tt.contains {
+				if !strings.Contains(report, str) {
+					t.Errorf("Report doesn't contain expected string: %s", str)
+				}
+			}
+			for _, str := range tt.notContains {
+				if strings.Contains(report, str) {
+					t.Errorf("Report should not contain: %s", str)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_Cancelled(t *testing.T) {
+	now := time.Now()
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Estimate: 3, IsCompleted: true, CreatedAt: now.AddDate(0, 0, -10), StartedAt: now.AddDate(0, 0, -7), CompletedAt: now.AddDate(0, 0, -5)},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, format := range []types.OutputFormat{types.FormatText, types.FormatJSON, types.FormatMarkdown} {
+		generator := NewGenerator(items).WithFormat(format).WithContext(ctx)
+		if _, err := generator.Generate(MetricsTypeAll, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: Generate() error = %v, want context.Canceled", format, err)
+		}
+	}
+
+	// Combined sections stop between sections
+	if _, err := generateAllReports(ctx, items, "month", DefaultOptions()); !errors.Is(err, context.Canceled) {
+		t.Errorf("generateAllReports() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestGenerateAll_FailedSections(t *testing.T) {
+	now := time.Now()
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Estimate: 3, IsCompleted: true, CreatedAt: now.AddDate(0, 0, -10), StartedAt: now.AddDate(0, 0, -7), CompletedAt: now.AddDate(0, 0, -5)},
+	}
+	// Without iterations the commitment section can't be generated
+	sections := SelectSections([]MetricsType{MetricsTypeThroughput, MetricsTypeCommitment}, nil)
+
+	report, err := NewGenerator(items).WithSections(sections).Generate(MetricsTypeAll, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, str := range []string{"Throughput Analysis", "# Sections Not Generated", "- commitment: no items have an iteration"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	output, err := NewGenerator(items).WithSections(sections).WithFormat(types.FormatJSON).Generate(MetricsTypeAll, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	var doc Document
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(doc.Sections) != 1 || len(doc.Failed) != 1 || doc.Failed[0].Type != MetricsTypeCommitment || doc.Failed[0].Panic {
+		t.Errorf("sections = %+v, failed = %+v, want throughput with commitment failed", doc.Sections, doc.Failed)
+	}
+}
+
+func TestIsolateSection(t *testing.T) {
+	_, err := isolateSection(MetricsTypeFlow, func() (string, error) {
+		var durations map[string][]float64
+		durations["active"] = append(durations["active"], 1) // Writing to a nil map panics
+		return "", nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "flow metrics crashed: assignment to entry in nil map") {
+		t.Fatalf("isolateSection() error = %v, want the panic as an error", err)
+	}
+	if failed := newFailedSection(MetricsTypeFlow, err); !failed.Panic || failed.Type != MetricsTypeFlow {
+		t.Errorf("newFailedSection() = %+v, want a crashed flow section", failed)
+	}
+
+	report, err := isolateSection(MetricsTypeFlow, func() (string, error) { return "# Flow", nil })
+	if err != nil || report != "# Flow" {
+		t.Errorf("isolateSection() = %q, %v, want the section's own result", report, err)
+	}
+}
+
+func TestGenerateSections(t *testing.T) {
+	sections := []MetricsType{MetricsTypeLeadTime, MetricsTypeThroughput, MetricsTypeFlow, MetricsTypeAge}
+
+	// Later sections finish first; the results still follow the sections
+	results, errs, err := generateSections(context.Background(), sections, func(section MetricsType) (string, error) {
+		for i, s := range sections {
+			if s == section {
+				time.Sleep(time.Duration(len(sections)-i) * time.Millisecond)
+			}
+		}
+		switch section {
+		case MetricsTypeFlow:
+			return "", errors.New("no state history")
+		case MetricsTypeAge:
+			var ages []float64
+			return fmt.Sprint(ages[1]), nil // Indexing past the end panics
+		}
+		return "# " + string(section), nil
+	})
+	if err != nil {
+		t.Fatalf("generateSections() error = %v", err)
+	}
+
+	if results[0] != "# lead-time" || results[1] != "# throughput" {
+		t.Errorf("results = %q, want lead-time then throughput", results)
+	}
+	if errs[0] != nil || errs[1] != nil || errs[2] == nil || errs[2].Error() != "no state history" {
+		t.Errorf("errs = %v, want only flow's own error and age's crash", errs)
+	}
+	if failed := newFailedSection(MetricsTypeAge, errs[3]); !failed.Panic {
+		t.Errorf("age section = %+v, want a crash", failed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := generateSections(ctx, sections, func(MetricsType) (string, error) { return "", nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("generateSections() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCombineReports(t *testing.T) {
+	tests := []struct {
+		name      string
+		separator string
+		expected  string
+	}{
+		{"Default separator", "", "A\n\n" + DefaultSeparator + "\n\nB"},
+		{"Custom separator", "---", "A\n\n---\n\nB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := combineReports([]string{"A", "B"}, tt.separator); got != tt.expected {
+				t.Errorf("combineReports() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestAddDateRangeInfo(t *testing.T) {
 	generator := NewGenerator(nil)
 	
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// DefaultSeparator is the line placed between sections of combined reports
+var DefaultSeparator = strings.Repeat("=", 80)
+
+// Options holds optional settings shared by the metrics calculators
+type Options struct {
+	// Stats selects the statistic columns shown in statistical tables
+	Stats []StatType
+
+	// HistogramBuckets are the upper bounds (in days) of the cycle time histogram buckets
+	HistogramBuckets []float64
+
+	// Holidays are excluded, along with weekends, from working-day calculations
+	Holidays dateutil.Holidays
+
+	// Absences reduce the team capacity used to normalize throughput trends
+	Absences dateutil.Absences
+
+	// Annotations are dated events marked in trend tables for context
+	Annotations Annotations
+
+	// AgeMode chooses between the age summary and the aging work in progress chart
+	AgeMode AgeMode
+
+	// AgeThresholds are per-state warning and critical ages used to flag aging work
+	AgeThresholds AgeThresholds
+
+	// WIPLimits cap the items each team may have in a state, flagged in the
+	// age, aging work in progress, cumulative flow and digest metrics
+	WIPLimits WIPLimits
+
+	// Unit controls how estimates are aggregated and labeled
+	Unit types.EstimateUnit
+
+	// SplitBy is the field used to group items in comparison reports
+	SplitBy SplitField
+
+	// Sections are the metrics generated, in order, for MetricsTypeAll
+	Sections []MetricsType
+
+	// Separator is the line placed between sections of combined reports
+	Separator string
+
+	// Width is the maximum line width of wide tables (0 for no limit)
+	Width int
+
+	// Digest tunes the rules behind the digest highlights
+	Digest DigestSettings
+
+	// Iterations are the dates of named iterations compared in the commitment report
+	Iterations Iterations
+
+	// Forecast chooses what the Monte Carlo forecast answers
+	Forecast ForecastSettings
+
+	// WeekNumbering decides where weeks start and how they are labeled
+	WeekNumbering types.WeekNumbering
+
+	// PeriodLabels decides how the weeks and months of the throughput and
+	// improvement tables are labeled
+	PeriodLabels types.PeriodLabels
+
+	// NoExplanations leaves out the sections explaining each metric, which
+	// "explain" prints instead
+	NoExplanations bool
+}
+
+// DefaultOptions returns the options used when nothing has been configured
+func DefaultOptions() Options {
+	return Options{
+		Stats:            DefaultStats,
+		HistogramBuckets: DefaultHistogramBuckets,
+		Unit:             types.UnitPoints,
+		SplitBy:          SplitByTeam,
+		AgeMode:          AgeModeSummary,
+		Sections:         AllSections,
+		Separator:        DefaultSeparator,
+		Digest:           DefaultDigestSettings(),
+		Forecast:         DefaultForecastSettings(),
+		WeekNumbering:    types.WeekNumberingISO,
+		PeriodLabels:     types.PeriodLabelsISO,
+	}
+}
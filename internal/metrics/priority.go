@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+)
+
+// priorityOrder ranks common priority names from most to least urgent; other
+// names follow in alphabetical order
+var priorityOrder = []string{"urgent", "critical", "highest", "high", "medium", "normal", "low", "lowest"}
+
+// HighPriorities are the priority names counted as high-priority work
+var HighPriorities = []string{"urgent", "critical", "highest", "high"}
+
+// CrowdingThreshold is the share of effort (in percent) above which
+// high-priority work is flagged as crowding out everything else
+const CrowdingThreshold = 70.0
+
+// isHighPriority reports whether a priority name is one of HighPriorities
+func isHighPriority(priority string) bool {
+	for _, name := range HighPriorities {
+		if strings.EqualFold(priority, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// priorityRank returns the position of a priority in priorityOrder, or its length if unknown
+func priorityRank(priority string) int {
+	for i, name := range priorityOrder {
+		if strings.EqualFold(priority, name) {
+			return i
+		}
+	}
+	return len(priorityOrder)
+}
+
+// PriorityDistributionReport trends the share of completed effort by priority
+func PriorityDistributionReport(items []models.KanbanItem, periodType string) (string, error) {
+	return priorityDistributionReport(items, periodType, DefaultOptions())
+}
+
+// PriorityPeriod is the share of completed effort by priority in one period
+type PriorityPeriod struct {
+	Period    string             `json:"period"`
+	Total     float64            `json:"total"`
+	Shares    map[string]float64 `json:"shares_percent"` // Empty when nothing was completed
+	HighShare float64            `json:"high_priority_share_percent"`
+}
+
+// PriorityResult holds the priority distribution of every period
+type PriorityResult struct {
+	Priorities []string         `json:"priorities"`
+	Periods    []PriorityPeriod `json:"periods"`
+}
+
+// priorityDistributionResult groups completed effort by period and priority
+func priorityDistributionResult(items []models.KanbanItem, periodType string, opts Options) (PriorityResult, error) {
+	effort := make(map[string]map[string]float64)
+	totals := make(map[string]float64)
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		priority := item.Priority
+		if priority == "" {
+			priority = "Unspecified"
+		}
+		period := dateutil.FormatPeriodWith(item.CompletedAt, periodType, opts.WeekNumbering)
+		if effort[period] == nil {
+			effort[period] = make(map[string]float64)
+		}
+		value := opts.Unit.Value(item.Estimate)
+		effort[period][priority] += value
+		totals[period] += value
+		seen[priority] = true
+	}
+	if len(effort) == 0 {
+		return PriorityResult{}, fmt.Errorf("no completed items to distribute by priority")
+	}
+
+	var periods []string
+	for period := range effort {
+		periods = append(periods, period)
+	}
+	sort.Strings(periods)
+
+	var result PriorityResult
+	for priority := range seen {
+		result.Priorities = append(result.Priorities, priority)
+	}
+	sort.Slice(result.Priorities, func(i, j int) bool {
+		ri, rj := priorityRank(result.Priorities[i]), priorityRank(result.Priorities[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return result.Priorities[i] < result.Priorities[j]
+	})
+
+	for _, period := range periods {
+		row := PriorityPeriod{Period: period, Total: totals[period], Shares: make(map[string]float64)}
+		if totals[period] > 0 {
+			for _, priority := range result.Priorities {
+				share := effort[period][priority] / totals[period] * 100
+				row.Shares[priority] = share
+				if isHighPriority(priority) {
+					row.HighShare += share
+				}
+			}
+		}
+		result.Periods = append(result.Periods, row)
+	}
+
+	return result, nil
+}
+
+// priorityDistributionReport builds the priority distribution report using the given options
+func priorityDistributionReport(items []models.KanbanItem, periodType string, opts Options) (string, error) {
+	result, err := priorityDistributionResult(items, periodType, opts)
+	if err != nil {
+		return "", err
+	}
+
+	periodName := "Month"
+	if periodType == "week" {
+		periodName = "Week"
+	}
+
+	totalTitle := "Total " + opts.Unit.ColumnTitle()
+	report := fmt.Sprintf("# Effort by Priority (share of completed %s per %s)\n\n", opts.Unit.Label(), strings.ToLower(periodName))
+	report += "Shows whether high-priority work is crowding out everything else. "
+	report += fmt.Sprintf("Priorities counted as high: %s.\n\n", strings.Join(HighPriorities, ", "))
+
+	labelWidth := max(len(periodName), len(result.Periods[0].Period))
+	report += fmt.Sprintf("%-*s", labelWidth, periodName)
+	separator := strings.Repeat("-", labelWidth+1)
+	for _, column := range append(append([]string{}, result.Priorities...), totalTitle) {
+		report += fmt.Sprintf(" | %*s", columnWidth(column), column)
+		separator += "|" + strings.Repeat("-", columnWidth(column)+2)
+	}
+	report += "\n" + strings.TrimSuffix(separator, "-") + "\n"
+
+	var highShares []float64
+	var sharePeriods []string
+	for _, period := range result.Periods {
+		report += fmt.Sprintf("%-*s", labelWidth, period.Period)
+		for _, priority := range result.Priorities {
+			if period.Total == 0 {
+				report += fmt.Sprintf(" | %*s", columnWidth(priority), "-")
+				continue
+			}
+			report += fmt.Sprintf(" | %*.0f%%", columnWidth(priority)-1, period.Shares[priority])
+		}
+		report += fmt.Sprintf(" | %*.1f\n", columnWidth(totalTitle), period.Total)
+		if period.Total > 0 {
+			highShares = append(highShares, period.HighShare)
+			sharePeriods = append(sharePeriods, period.Period)
+		}
+	}
+
+	report += "\n## High-Priority Share\n\n"
+	if len(highShares) == 0 {
+		report += fmt.Sprintf("No completed %s to compare.\n", opts.Unit.Label())
+		return report, nil
+	}
+	latest := highShares[len(highShares)-1]
+	report += fmt.Sprintf("High-priority work took %.0f%% of completed %s in %s", latest, opts.Unit.Label(), sharePeriods[len(sharePeriods)-1])
+	if len(highShares) > 1 {
+		earlier := 0.0
+		for _, share := range highShares[:len(highShares)-1] {
+			earlier += share
+		}
+		earlier /= float64(len(highShares) - 1)
+		report += fmt.Sprintf(", compared with an average of %.0f%% in the %d earlier %s", earlier, len(highShares)-1, pluralize(strings.ToLower(periodName), len(highShares)-1))
+	}
+	report += ".\n"
+	if latest >= CrowdingThreshold {
+		report += fmt.Sprintf("\n⚠️  High-priority work is above %.0f%% of effort, leaving little room for planned or improvement work.\n", CrowdingThreshold)
+	}
+
+	return report, nil
+}
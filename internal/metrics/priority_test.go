@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestPriorityDistributionReport(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	items := []models.KanbanItem{
+		{ID: "1", Priority: "High", Estimate: 2, IsCompleted: true, CompletedAt: day(5, 3)},
+		{ID: "2", Priority: "Low", Estimate: 2, IsCompleted: true, CompletedAt: day(5, 10)},
+		{ID: "3", Priority: "High", Estimate: 8, IsCompleted: true, CompletedAt: day(6, 3)},
+		{ID: "4", Estimate: 2, IsCompleted: true, CompletedAt: day(6, 12)},
+		{ID: "5", Priority: "Low", Estimate: 5},
+	}
+
+	report, err := priorityDistributionReport(items, "month", DefaultOptions())
+	if err != nil {
+		t.Fatalf("priorityDistributionReport() error = %v", err)
+	}
+
+	expected := []string{
+		"# Effort by Priority (share of completed points per month)",
+		"Month   |  High |   Low | Unspecified | Total Points",
+		"2024-05 |   50% |   50% |          0% |          4.0",
+		"2024-06 |   80% |    0% |         20% |         10.0",
+		"High-priority work took 80% of completed points in 2024-06, compared with an average of 50% in the 1 earlier month.",
+		"High-priority work is above 70% of effort",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
+
+func TestPriorityDistributionReport_NoItems(t *testing.T) {
+	items := []models.KanbanItem{{ID: "1", Priority: "High", Estimate: 3}}
+
+	if _, err := priorityDistributionReport(items, "month", DefaultOptions()); err == nil {
+		t.Error("priorityDistributionReport() expected error for no completed items")
+	}
+}
+
+func TestPriorityRank(t *testing.T) {
+	if priorityRank("HIGH") >= priorityRank("medium") || priorityRank("Low") >= priorityRank("Someday") {
+		t.Errorf("priorityRank() does not order high < medium < low < unknown")
+	}
+	if !isHighPriority("Urgent") || isHighPriority("Medium") {
+		t.Errorf("isHighPriority() = wrong result for Urgent or Medium")
+	}
+}
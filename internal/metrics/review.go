@@ -0,0 +1,190 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// ReviewStateKeywords identify review and QA states; a state matches when its
+// name contains one of them, ignoring case
+var ReviewStateKeywords = []string{"review", "qa", "test", "verif", "accept"}
+
+// isReviewState reports whether a state name looks like a review or QA state
+func isReviewState(state string) bool {
+	state = strings.ToLower(state)
+	for _, keyword := range ReviewStateKeywords {
+		if strings.Contains(state, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeInReviewReport estimates how long items spend in their final review or
+// QA state, per team
+func TimeInReviewReport(items []models.KanbanItem, asOf time.Time) (string, error) {
+	return timeInReviewReport(items, items, asOf, DefaultOptions())
+}
+
+// ReviewItem is an open item waiting in a review state
+type ReviewItem struct {
+	Name   string         `json:"name"`
+	Group  string         `json:"group"`
+	State  string         `json:"state"`
+	Days   float64        `json:"days"`
+	Source *models.Source `json:"source,omitempty"`
+}
+
+// ReviewResult holds the time spent in review per group
+type ReviewResult struct {
+	GroupBy        string       `json:"group_by"`
+	Completed      []GroupStats `json:"completed"`
+	CompletedItems int          `json:"completed_items"`
+	Unmeasured     int          `json:"unmeasured_items"` // Completed items whose last move was not before completion
+	InReview       []GroupStats `json:"in_review"`
+	Longest        []ReviewItem `json:"longest_waiting"`
+}
+
+// maxLongestWaiting is the number of longest waiting items listed
+const maxLongestWaiting = 5
+
+// timeInReviewResult measures the time in review from completed items and
+// the current work in progress, grouping by opts.SplitBy.
+//
+// The export only records the last state change (moved_at), so for completed
+// items the time between that move and completed_at is taken as the time spent
+// in the final state before done. Items still in a review state are measured
+// from moved_at to asOf.
+func timeInReviewResult(items, wip []models.KanbanItem, asOf time.Time, opts Options) (ReviewResult, error) {
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	splitBy := opts.SplitBy
+	if splitBy == "" {
+		splitBy = SplitByTeam
+	}
+
+	completedDays := make(map[string][]float64)
+	completedCount, unmeasured := 0, 0
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		completedCount++
+		// A move at or after completion is the move to done itself (or a later
+		// edit), which says nothing about the state before it
+		if item.MovedAt.IsZero() || !item.MovedAt.Before(item.CompletedAt) {
+			unmeasured++
+			continue
+		}
+		group := splitBy.GroupOf(item)
+		completedDays[group] = append(completedDays[group], item.CompletedAt.Sub(item.MovedAt).Hours()/24)
+	}
+
+	waitingDays := make(map[string][]float64)
+	var waiting []ReviewItem
+	for _, item := range wip {
+		if item.IsCompleted || item.MovedAt.IsZero() || !isReviewState(item.State) {
+			continue
+		}
+		group := splitBy.GroupOf(item)
+		days := asOf.Sub(item.MovedAt).Hours() / 24
+		waitingDays[group] = append(waitingDays[group], days)
+		waiting = append(waiting, ReviewItem{item.Name, group, item.State, days, item.SourceRef()})
+	}
+
+	if completedCount == 0 && len(waiting) == 0 {
+		return ReviewResult{}, fmt.Errorf("no completed or in-review items to measure")
+	}
+
+	sort.Slice(waiting, func(i, j int) bool {
+		return waiting[i].Days > waiting[j].Days
+	})
+
+	return ReviewResult{
+		GroupBy:        string(splitBy),
+		Completed:      reviewGroupStats(completedDays),
+		CompletedItems: completedCount,
+		Unmeasured:     unmeasured,
+		InReview:       reviewGroupStats(waitingDays),
+		Longest:        waiting[:min(len(waiting), maxLongestWaiting)],
+	}, nil
+}
+
+// timeInReviewReport builds the time in review report from completed items and
+// the current work in progress, grouping by opts.SplitBy
+func timeInReviewReport(items, wip []models.KanbanItem, asOf time.Time, opts Options) (string, error) {
+	result, err := timeInReviewResult(items, wip, asOf, opts)
+	if err != nil {
+		return "", err
+	}
+	splitBy := opts.SplitBy
+	if splitBy == "" {
+		splitBy = SplitByTeam
+	}
+
+	report := "# Time in Review\n\n"
+	report += "Estimated time spent in the final review or QA state, in days. The export only records the last state change (moved_at), so:\n\n"
+	report += "- **Completed items**: time from moved_at to completed_at, when the last move came before completion\n"
+	report += fmt.Sprintf("- **In review now**: time since moved_at for open items in a state containing %s\n\n", strings.Join(ReviewStateKeywords, ", "))
+
+	report += fmt.Sprintf("## Completed Items by %s\n\n", splitBy.Title())
+	if len(result.Completed) == 0 {
+		report += "No completed items were moved before their completion date.\n\n"
+	} else {
+		report += reviewStatsTable(splitBy.Title(), result.Completed, opts.Stats)
+		report += "\n"
+	}
+	if result.Unmeasured > 0 {
+		report += fmt.Sprintf("%d of %d completed items were skipped because their last move was not before completion.\n\n", result.Unmeasured, result.CompletedItems)
+	}
+
+	report += fmt.Sprintf("## In Review Now by %s\n\n", splitBy.Title())
+	if len(result.Longest) == 0 {
+		report += "No open items are in a review state.\n"
+		return report, nil
+	}
+	report += reviewStatsTable(splitBy.Title(), result.InReview, opts.Stats)
+
+	report += "\nLongest Waiting:\n\n"
+	for _, item := range result.Longest {
+		report += fmt.Sprintf("- %s [%s, %s] (%.1f days%s)\n", item.Name, item.Group, item.State, item.Days, sourceNote(item.Source))
+	}
+
+	return report, nil
+}
+
+// reviewGroupStats summarizes the days of each group, sorted by group name
+func reviewGroupStats(daysByGroup map[string][]float64) []GroupStats {
+	var groups []string
+	for group := range daysByGroup {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var rows []GroupStats
+	for _, group := range groups {
+		rows = append(rows, GroupStats{group, summarize(daysByGroup[group])})
+	}
+	return rows
+}
+
+// reviewStatsTable formats a statistics table with one row per group
+func reviewStatsTable(keyLabel string, rows []GroupStats, stats []StatType) string {
+	keyWidth := len(keyLabel)
+	for _, row := range rows {
+		if len(row.Label) > keyWidth {
+			keyWidth = len(row.Label)
+		}
+	}
+
+	table := formatStatsTableHeader(fmt.Sprintf("%-*s", keyWidth, keyLabel), stats, " Days")
+	for _, row := range rows {
+		table += formatStatsTableRow(fmt.Sprintf("%-*s", keyWidth, row.Label), keyWidth, row.Stats, stats, " Days")
+	}
+	return table
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestTimeInReviewReport(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 6, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	items := []models.KanbanItem{
+		// Moved into review two and four days before completion
+		{ID: "1", Name: "Login", Team: "Core", IsCompleted: true, MovedAt: day(1), CompletedAt: day(3)},
+		{ID: "2", Name: "Logout", Team: "Core", IsCompleted: true, MovedAt: day(1), CompletedAt: day(5)},
+		// Last move is the completion itself, so it cannot be measured
+		{ID: "3", Name: "Search", Team: "Web", IsCompleted: true, MovedAt: day(4), CompletedAt: day(4)},
+		// Waiting in review now, and an open item in another state
+		{ID: "4", Name: "Checkout", Team: "Web", State: "Code Review", MovedAt: day(10)},
+		{ID: "5", Name: "Cart", Team: "Web", State: "In Progress", MovedAt: day(2)},
+	}
+
+	report, err := timeInReviewReport(items, items, day(16), DefaultOptions())
+	if err != nil {
+		t.Fatalf("timeInReviewReport() error = %v", err)
+	}
+
+	expected := []string{
+		"# Time in Review",
+		"## Completed Items by Team",
+		"Team | Count | Min Days | Max Days | Avg Days | Median Days",
+		"Core |     2 |      2.0 |      4.0 |      3.0 |",
+		"1 of 3 completed items were skipped",
+		"## In Review Now by Team",
+		"Web  |     1 |      6.0 |",
+		"- Checkout [Web, Code Review] (6.0 days)",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+	if strings.Contains(report, "Cart") {
+		t.Errorf("Report includes an item outside a review state:\n%s", report)
+	}
+}
+
+func TestTimeInReviewReport_NoItems(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", State: "In Progress", MovedAt: time.Now()},
+	}
+
+	if _, err := timeInReviewReport(items, items, time.Now(), DefaultOptions()); err == nil {
+		t.Error("timeInReviewReport() expected error for no completed or in-review items")
+	}
+}
+
+func TestIsReviewState(t *testing.T) {
+	tests := map[string]bool{
+		"Code Review":       true,
+		"QA":                true,
+		"Ready for Testing": true,
+		"Verification":      true,
+		"In Progress":       false,
+		"Done":              false,
+	}
+	for state, want := range tests {
+		if got := isReviewState(state); got != want {
+			t.Errorf("isReviewState(%q) = %v, want %v", state, got, want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/charts"
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+)
+
+// scatterplotPercentiles are the percentiles drawn as guide lines
+var scatterplotPercentiles = []int{50, 70, 85, 95}
+
+const (
+	scatterplotColumns = 60 // Width of the text plot
+	scatterplotRows    = 15 // Height of the text plot
+)
+
+// ScatterPoint is one completed item of the scatterplot
+type ScatterPoint struct {
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	Completed     string         `json:"completed"`
+	CycleTimeDays float64        `json:"cycle_time_days"`
+	Source        *models.Source `json:"source,omitempty"`
+	date          time.Time
+}
+
+// PercentileLine is the cycle time that a share of the items finished within
+type PercentileLine struct {
+	Percentile int     `json:"percentile"`
+	Days       float64 `json:"days"`
+}
+
+// ScatterplotResult holds the cycle time of each completed item by completion date
+type ScatterplotResult struct {
+	Points      []ScatterPoint   `json:"points"` // Oldest completion first
+	Percentiles []PercentileLine `json:"percentiles"`
+}
+
+// scatterplotResult collects the cycle times of the completed items with a start date
+func scatterplotResult(items []models.KanbanItem) (ScatterplotResult, error) {
+	var result ScatterplotResult
+	var cycleTimes []float64
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() || item.StartedAt.IsZero() || item.CompletedAt.Before(item.StartedAt) {
+			continue
+		}
+		cycleTime := item.CompletedAt.Sub(item.StartedAt).Hours() / 24
+		result.Points = append(result.Points, ScatterPoint{
+			ID:            item.ID,
+			Name:          item.Name,
+			Completed:     item.CompletedAt.Format("2006-01-02"),
+			CycleTimeDays: cycleTime,
+			Source:        item.SourceRef(),
+			date:          item.CompletedAt,
+		})
+		cycleTimes = append(cycleTimes, cycleTime)
+	}
+	if len(result.Points) == 0 {
+		return ScatterplotResult{}, fmt.Errorf("no completed items with a start date to plot")
+	}
+
+	sort.SliceStable(result.Points, func(i, j int) bool {
+		return result.Points[i].date.Before(result.Points[j].date)
+	})
+	for _, percentile := range scatterplotPercentiles {
+		result.Percentiles = append(result.Percentiles, PercentileLine{percentile, calculatePercentile(cycleTimes, float64(percentile))})
+	}
+	return result, nil
+}
+
+// ScatterplotSVG draws the scatterplot of the items in the date range as a
+// standalone SVG image
+func (g *Generator) ScatterplotSVG(startDate, endDate time.Time, filterField models.FilterField) (string, error) {
+	if err := g.ctx.Err(); err != nil {
+		return "", err
+	}
+	result, err := scatterplotResult(g.filterItemsByDateRange(startDate, endDate, filterField))
+	if err != nil {
+		return "", err
+	}
+	return scatterplotSVG(result) + "\n", nil
+}
+
+// scatterplotReport builds the scatterplot report with the plot drawn in text
+func scatterplotReport(items []models.KanbanItem) (string, error) {
+	result, err := scatterplotResult(items)
+	if err != nil {
+		return "", err
+	}
+	return scatterplotIntro(result) + scatterplotText(result) + "\n\n" + scatterplotPercentileList(result), nil
+}
+
+// scatterplotBlocks builds the scatterplot report for documents, drawn as SVG in HTML
+func scatterplotBlocks(items []models.KanbanItem) ([]render.Block, error) {
+	result, err := scatterplotResult(items)
+	if err != nil {
+		return nil, err
+	}
+	blocks := render.Parse(scatterplotIntro(result))
+	blocks = append(blocks, render.Chart{SVG: scatterplotSVG(result), Text: scatterplotText(result)})
+	return append(blocks, render.Parse(scatterplotPercentileList(result))...), nil
+}
+
+// scatterplotIntro is the heading of the report and how to read the plot
+func scatterplotIntro(result ScatterplotResult) string {
+	report := "# Cycle Time Scatterplot\n\n"
+	report += fmt.Sprintf("Each point is one of the %d completed items, placed by its completion date and its cycle time (days from start to completion). ", len(result.Points))
+	report += "The dashed lines are percentiles: 85% of the items finished within the p85 line, so it is a fair promise for new work.\n\n"
+	return report
+}
+
+// scatterplotPercentileList lists the cycle times of the guide lines
+func scatterplotPercentileList(result ScatterplotResult) string {
+	report := "Percentiles:\n\n"
+	for _, line := range result.Percentiles {
+		report += fmt.Sprintf("- %d%% of items finished within %.1f days\n", line.Percentile, line.Days)
+	}
+	return report
+}
+
+// scatterplotScale returns the top of the cycle time axis and the first and
+// last completion dates
+func scatterplotScale(result ScatterplotResult) (top float64, first, last time.Time) {
+	for _, point := range result.Points {
+		top = math.Max(top, point.CycleTimeDays)
+	}
+	if top == 0 {
+		top = 1
+	}
+	return top, result.Points[0].date, result.Points[len(result.Points)-1].date
+}
+
+// position returns where a value falls on an axis of the given length,
+// from 0 to length-1
+func position(value, span float64, length int) int {
+	if span <= 0 {
+		return 0
+	}
+	return int(math.Round(value / span * float64(length-1)))
+}
+
+// scatterplotText draws the plot in text: * marks an item, digits count items
+// sharing a spot (# for ten or more) and dashed rows are the percentiles.
+// Every line is indented, so documents keep the layout.
+func scatterplotText(result ScatterplotResult) string {
+	top, first, last := scatterplotScale(result)
+	span := last.Sub(first).Hours()
+
+	counts := make([][]int, scatterplotRows)
+	for i := range counts {
+		counts[i] = make([]int, scatterplotColumns)
+	}
+	for _, point := range result.Points {
+		row := position(point.CycleTimeDays, top, scatterplotRows)
+		column := position(point.date.Sub(first).Hours(), span, scatterplotColumns)
+		counts[row][column]++
+	}
+	guides := make(map[int][]string)
+	for _, line := range result.Percentiles {
+		row := position(line.Days, top, scatterplotRows)
+		guides[row] = append(guides[row], fmt.Sprintf("p%d %.1f", line.Percentile, line.Days))
+	}
+
+	var b strings.Builder
+	b.WriteString("   days\n")
+	for row := scatterplotRows - 1; row >= 0; row-- {
+		label := ""
+		switch row {
+		case scatterplotRows - 1:
+			label = fmt.Sprintf("%.1f", top)
+		case 0:
+			label = "0.0"
+		}
+
+		cells := make([]rune, scatterplotColumns)
+		for column, count := range counts[row] {
+			switch {
+			case count == 1:
+				cells[column] = '*'
+			case count > 9:
+				cells[column] = '#'
+			case count > 1:
+				cells[column] = rune('0' + count)
+			case guides[row] != nil && column%2 == 0:
+				cells[column] = '-'
+			default:
+				cells[column] = ' '
+			}
+		}
+		line := fmt.Sprintf("%7s |%s", label, string(cells))
+		if guides[row] != nil {
+			line += "  " + strings.Join(guides[row], ", ")
+		}
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	b.WriteString("        +" + strings.Repeat("-", scatterplotColumns) + "\n")
+
+	from, to := first.Format("2006-01-02"), last.Format("2006-01-02")
+	if from == to {
+		b.WriteString("         " + from)
+	} else {
+		b.WriteString("         " + from + strings.Repeat(" ", scatterplotColumns-len(from)-len(to)) + to)
+	}
+	return b.String()
+}
+
+// scatterplotSVG draws the plot as a standalone SVG image, with each item's
+// name and cycle time as its tooltip
+func scatterplotSVG(result ScatterplotResult) string {
+	var points []charts.Point
+	for _, point := range result.Points {
+		points = append(points, charts.Point{
+			Date:    point.date,
+			Value:   point.CycleTimeDays,
+			Tooltip: fmt.Sprintf("#%s %s: %.1f days, completed %s", point.ID, point.Name, point.CycleTimeDays, point.Completed),
+		})
+	}
+	var guides []charts.Guide
+	for _, line := range result.Percentiles {
+		guides = append(guides, charts.Guide{Label: fmt.Sprintf("p%d %.1f days", line.Percentile, line.Days), Value: line.Days})
+	}
+	return charts.Scatter("Cycle Time Scatterplot", "Cycle time (days)", points, guides)
+}
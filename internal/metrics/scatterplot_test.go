@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+)
+
+func scatterplotItems() []models.KanbanItem {
+	first := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	var items []models.KanbanItem
+	// Cycle times of 1 to 10 days, one item completed every other day
+	for i := 1; i <= 10; i++ {
+		completed := first.AddDate(0, 0, 2*(i-1))
+		items = append(items, models.KanbanItem{
+			ID: string(rune('0' + i%10)), Name: "Item", IsCompleted: true,
+			StartedAt: completed.AddDate(0, 0, -i), CompletedAt: completed,
+		})
+	}
+	// Not plotted: open, or completed without a start date
+	items = append(items, models.KanbanItem{ID: "open", StartedAt: first})
+	items = append(items, models.KanbanItem{ID: "unstarted", IsCompleted: true, CompletedAt: first})
+	return items
+}
+
+func TestScatterplotResult(t *testing.T) {
+	result, err := scatterplotResult(scatterplotItems())
+	if err != nil {
+		t.Fatalf("scatterplotResult() error = %v", err)
+	}
+
+	if len(result.Points) != 10 || result.Points[0].Completed != "2024-05-01" || result.Points[9].CycleTimeDays != 10 {
+		t.Errorf("Points = %+v, want the 10 items with a start date, oldest first", result.Points)
+	}
+	want := []PercentileLine{{50, 5.5}, {70, 7.3}, {85, 8.65}, {95, 9.55}}
+	for i, line := range result.Percentiles {
+		if line.Percentile != want[i].Percentile || line.Days < want[i].Days-0.001 || line.Days > want[i].Days+0.001 {
+			t.Errorf("Percentiles[%d] = %+v, want %+v", i, line, want[i])
+		}
+	}
+
+	if _, err := scatterplotResult(scatterplotItems()[10:]); err == nil {
+		t.Error("scatterplotResult() without started and completed items should fail")
+	}
+}
+
+func TestScatterplotReport(t *testing.T) {
+	report, err := scatterplotReport(scatterplotItems())
+	if err != nil {
+		t.Fatalf("scatterplotReport() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"# Cycle Time Scatterplot",
+		"one of the 10 completed items",
+		"   10.0 |                                                           *\n",
+		"        |- - - - - - - - - - - - - - - - - - - - - - - - - - - - - -   p85 8.6\n",
+		"    0.0 |\n",
+		"        +------------------------------------------------------------\n",
+		"         2024-05-01                                        2024-05-19",
+		"- 85% of items finished within 8.6 days",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report doesn't contain %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestScatterplotBlocks(t *testing.T) {
+	blocks, err := scatterplotBlocks(scatterplotItems())
+	if err != nil {
+		t.Fatalf("scatterplotBlocks() error = %v", err)
+	}
+
+	var chart render.Chart
+	for _, block := range blocks {
+		if c, ok := block.(render.Chart); ok {
+			chart = c
+		}
+	}
+	if !strings.Contains(chart.Text, "p85 8.6") {
+		t.Errorf("chart text = %q, want the text plot", chart.Text)
+	}
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 720 360"`,
+		`stroke-dasharray="4 3"`,
+		">p85 8.6 days</text>",
+		"<title>#0 Item: 10.0 days, completed 2024-05-19</title>",
+	} {
+		if !strings.Contains(chart.SVG, want) {
+			t.Errorf("SVG doesn't contain %q:\n%s", want, chart.SVG)
+		}
+	}
+	if got := strings.Count(chart.SVG, "<circle"); got != 10 {
+		t.Errorf("SVG has %d points, want 10", got)
+	}
+}
+
+func TestScatterplotSVG_EscapesNames(t *testing.T) {
+	items := []models.KanbanItem{{ID: "1", Name: "<b>&", IsCompleted: true, StartedAt: time.Now().AddDate(0, 0, -1), CompletedAt: time.Now()}}
+
+	svg, err := NewGenerator(items).ScatterplotSVG(time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("ScatterplotSVG() error = %v", err)
+	}
+	if strings.Contains(svg, "<b>") || !strings.Contains(svg, "#1 &lt;b&gt;&amp;: 1.0 days") {
+		t.Errorf("ScatterplotSVG() didn't escape the item name:\n%s", svg)
+	}
+}
@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AllStates is the threshold key that applies to states without their own threshold
+const AllStates = "*"
+
+// SLAStatus classifies an item's age against its state's thresholds
+type SLAStatus string
+
+const (
+	// SLAGreen means the item is within its expected age
+	SLAGreen SLAStatus = "green"
+	// SLAYellow means the item has passed the warning threshold
+	SLAYellow SLAStatus = "yellow"
+	// SLARed means the item has passed the critical threshold
+	SLARed SLAStatus = "red"
+)
+
+// Marker returns the symbol used for the status in text output
+func (s SLAStatus) Marker() string {
+	switch s {
+	case SLAGreen:
+		return "🟢"
+	case SLAYellow:
+		return "🟡"
+	case SLARed:
+		return "🔴"
+	}
+	return ""
+}
+
+// AgeThreshold holds the warning and critical ages (in days) for a state
+type AgeThreshold struct {
+	Warning  float64 `json:"warning_days"`
+	Critical float64 `json:"critical_days"`
+}
+
+// AgeThresholds maps state names to age thresholds
+type AgeThresholds map[string]AgeThreshold
+
+// ParseAgeThresholds parses a list like "In Progress=5:10,Review=2:4,*=10:20".
+// Each entry is state=warning:critical in days; "*" applies to any other state.
+func ParseAgeThresholds(s string) (AgeThresholds, error) {
+	thresholds := make(AgeThresholds)
+	if strings.TrimSpace(s) == "" {
+		return thresholds, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		state, limits, ok := strings.Cut(part, "=")
+		state = strings.TrimSpace(state)
+		if !ok || state == "" {
+			return nil, fmt.Errorf("invalid age threshold: %s (expected state=warning:critical)", part)
+		}
+
+		warnStr, critStr, ok := strings.Cut(limits, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid age threshold: %s (expected state=warning:critical)", part)
+		}
+
+		warning, err := strconv.ParseFloat(strings.TrimSpace(warnStr), 64)
+		if err != nil || warning <= 0 {
+			return nil, fmt.Errorf("invalid age threshold: %s (warning must be a positive number of days)", part)
+		}
+		critical, err := strconv.ParseFloat(strings.TrimSpace(critStr), 64)
+		if err != nil || critical < warning {
+			return nil, fmt.Errorf("invalid age threshold: %s (critical must be a number of days no lower than warning)", part)
+		}
+
+		thresholds[state] = AgeThreshold{Warning: warning, Critical: critical}
+	}
+
+	return thresholds, nil
+}
+
+// lookup returns the threshold for a state, falling back to the "*" entry
+func (t AgeThresholds) lookup(state string) (AgeThreshold, bool) {
+	if threshold, ok := t[state]; ok {
+		return threshold, true
+	}
+	threshold, ok := t[AllStates]
+	return threshold, ok
+}
+
+// Status classifies an age in the given state; it returns "" when no threshold applies
+func (t AgeThresholds) Status(state string, age float64) SLAStatus {
+	threshold, ok := t.lookup(state)
+	if !ok {
+		return ""
+	}
+
+	switch {
+	case age >= threshold.Critical:
+		return SLARed
+	case age >= threshold.Warning:
+		return SLAYellow
+	default:
+		return SLAGreen
+	}
+}
+
+// String formats the thresholds in the form accepted by ParseAgeThresholds
+func (t AgeThresholds) String() string {
+	var states []string
+	for state := range t {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+
+	var parts []string
+	for _, state := range states {
+		threshold := t[state]
+		parts = append(parts, fmt.Sprintf("%s=%s:%s", state, formatDays(threshold.Warning), formatDays(threshold.Critical)))
+	}
+	return strings.Join(parts, ",")
+}
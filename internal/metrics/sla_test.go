@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"testing"
+)
+
+func TestParseAgeThresholds(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  AgeThresholds
+		expectErr bool
+	}{
+		{"Empty", "", AgeThresholds{}, false},
+		{"Single state", "In Progress=5:10", AgeThresholds{"In Progress": {5, 10}}, false},
+		{"Several states with wildcard", "Review=2:4, *=10:20", AgeThresholds{"Review": {2, 4}, "*": {10, 20}}, false},
+		{"Missing critical", "Review=2", nil, true},
+		{"Missing state", "=2:4", nil, true},
+		{"Critical below warning", "Review=4:2", nil, true},
+		{"Not a number", "Review=a:4", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAgeThresholds(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseAgeThresholds() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("ParseAgeThresholds() = %v, want %v", got, tt.expected)
+			}
+			for state, threshold := range tt.expected {
+				if got[state] != threshold {
+					t.Errorf("ParseAgeThresholds()[%q] = %v, want %v", state, got[state], threshold)
+				}
+			}
+		})
+	}
+}
+
+func TestAgeThresholdsStatus(t *testing.T) {
+	thresholds := AgeThresholds{
+		"Review": {Warning: 2, Critical: 4},
+		"*":      {Warning: 10, Critical: 20},
+	}
+
+	tests := []struct {
+		state    string
+		age      float64
+		expected SLAStatus
+	}{
+		{"Review", 1, SLAGreen},
+		{"Review", 2, SLAYellow},
+		{"Review", 5, SLARed},
+		{"In Progress", 5, SLAGreen},
+		{"In Progress", 25, SLARed},
+	}
+
+	for _, tt := range tests {
+		if got := thresholds.Status(tt.state, tt.age); got != tt.expected {
+			t.Errorf("Status(%q, %.1f) = %q, want %q", tt.state, tt.age, got, tt.expected)
+		}
+	}
+
+	if got := (AgeThresholds{"Review": {2, 4}}).Status("Done", 100); got != "" {
+		t.Errorf("Status() without matching threshold = %q, want empty", got)
+	}
+}
+
+func TestAgeThresholdsString(t *testing.T) {
+	thresholds := AgeThresholds{"Review": {2, 4}, "*": {10, 20.5}}
+	if got := thresholds.String(); got != "*=10:20.5,Review=2:4" {
+		t.Errorf("String() = %q", got)
+	}
+}
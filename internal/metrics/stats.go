@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatType identifies a statistic column shown in statistical tables
+type StatType string
+
+const (
+	// StatCount is the number of data points
+	StatCount StatType = "count"
+	// StatMin is the smallest value
+	StatMin StatType = "min"
+	// StatMax is the largest value
+	StatMax StatType = "max"
+	// StatAvg is the arithmetic mean
+	StatAvg StatType = "avg"
+	// StatMedian is the 50th percentile
+	StatMedian StatType = "median"
+	// StatP85 is the 85th percentile
+	StatP85 StatType = "p85"
+	// StatP95 is the 95th percentile
+	StatP95 StatType = "p95"
+	// StatStdDev is the population standard deviation
+	StatStdDev StatType = "stddev"
+)
+
+// DefaultStats is the statistics set shown when none is configured
+var DefaultStats = []StatType{StatCount, StatMin, StatMax, StatAvg, StatMedian}
+
+// IsValid checks if a StatType is supported
+func (st StatType) IsValid() bool {
+	switch st {
+	case StatCount, StatMin, StatMax, StatAvg, StatMedian, StatP85, StatP95, StatStdDev:
+		return true
+	}
+	return false
+}
+
+// Label returns the column heading used for the statistic
+func (st StatType) Label() string {
+	switch st {
+	case StatCount:
+		return "Count"
+	case StatMin:
+		return "Min"
+	case StatMax:
+		return "Max"
+	case StatAvg:
+		return "Avg"
+	case StatMedian:
+		return "Median"
+	case StatP85:
+		return "P85"
+	case StatP95:
+		return "P95"
+	case StatStdDev:
+		return "StdDev"
+	}
+	return string(st)
+}
+
+// ParseStats converts a comma-separated list into a set of statistics
+func ParseStats(s string) ([]StatType, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultStats, nil
+	}
+
+	var stats []StatType
+	seen := make(map[StatType]bool)
+	for _, part := range strings.Split(s, ",") {
+		st := StatType(strings.ToLower(strings.TrimSpace(part)))
+		if st == "" {
+			continue
+		}
+		if !st.IsValid() {
+			return nil, fmt.Errorf("invalid statistic: %s (must be one of: count, min, max, avg, median, p85, p95, stddev)", part)
+		}
+		if seen[st] {
+			continue
+		}
+		seen[st] = true
+		stats = append(stats, st)
+	}
+
+	if len(stats) == 0 {
+		return DefaultStats, nil
+	}
+	return stats, nil
+}
+
+// StatsSummary holds every supported statistic for a set of values
+type StatsSummary struct {
+	Count  int     `json:"count"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Avg    float64 `json:"avg"`
+	Median float64 `json:"median"`
+	P85    float64 `json:"p85"`
+	P95    float64 `json:"p95"`
+	StdDev float64 `json:"stddev"`
+}
+
+// GroupStats is the statistics of one row of a statistics table, such as an
+// estimate size or a team
+type GroupStats struct {
+	Label string       `json:"label"`
+	Stats StatsSummary `json:"stats"`
+}
+
+// summarize calculates all supported statistics for the values
+func summarize(values []float64) StatsSummary {
+	min, max, avg, median, stddev := calculateStats(values)
+	return StatsSummary{
+		Count:  len(values),
+		Min:    min,
+		Max:    max,
+		Avg:    avg,
+		Median: median,
+		P85:    calculatePercentile(values, 85),
+		P95:    calculatePercentile(values, 95),
+		StdDev: stddev,
+	}
+}
+
+// value returns the summary value for a statistic
+func (s StatsSummary) value(st StatType) float64 {
+	switch st {
+	case StatCount:
+		return float64(s.Count)
+	case StatMin:
+		return s.Min
+	case StatMax:
+		return s.Max
+	case StatAvg:
+		return s.Avg
+	case StatMedian:
+		return s.Median
+	case StatP85:
+		return s.P85
+	case StatP95:
+		return s.P95
+	case StatStdDev:
+		return s.StdDev
+	}
+	return 0
+}
+
+// statLabel returns the column heading for a statistic, with an optional unit suffix
+func statLabel(st StatType, suffix string) string {
+	if st == StatCount {
+		return st.Label()
+	}
+	return st.Label() + suffix
+}
+
+// formatStatsTableHeader builds the header and separator rows of a statistics table.
+// The suffix is appended to every value column label (e.g. " Days/SP").
+func formatStatsTableHeader(keyLabel string, stats []StatType, suffix string) string {
+	header := keyLabel
+	separator := strings.Repeat("-", len(keyLabel)+1)
+
+	for i, st := range stats {
+		label := statLabel(st, suffix)
+		header += " | " + label
+
+		width := len(label) + 2
+		if i == len(stats)-1 {
+			width = len(label) + 1
+		}
+		separator += "|" + strings.Repeat("-", width)
+	}
+
+	return header + "\n" + separator + "\n"
+}
+
+// formatStatsTableRow formats one statistics table row, aligning values to the header
+func formatStatsTableRow(key string, keyWidth int, summary StatsSummary, stats []StatType, suffix string) string {
+	row := fmt.Sprintf("%*s", keyWidth, key)
+
+	for _, st := range stats {
+		width := len(statLabel(st, suffix))
+		if st == StatCount {
+			row += fmt.Sprintf(" | %*d", width, summary.Count)
+		} else {
+			row += fmt.Sprintf(" | %*.1f", width, summary.value(st))
+		}
+	}
+
+	return row + "\n"
+}
+
+// formatStatsTable formats a statistics table with one right-aligned row per group
+func formatStatsTable(keyLabel string, rows []GroupStats, stats []StatType, suffix string) string {
+	table := formatStatsTableHeader(keyLabel, stats, suffix)
+	for _, row := range rows {
+		table += formatStatsTableRow(row.Label, len(keyLabel), row.Stats, stats, suffix)
+	}
+	return table
+}
+
+// formatStatsInline formats the statistics as a single "Min: 1.0, Max: 2.0" line
+func formatStatsInline(summary StatsSummary, stats []StatType) string {
+	var parts []string
+	for _, st := range stats {
+		if st == StatCount {
+			parts = append(parts, fmt.Sprintf("%s: %d", st.Label(), summary.Count))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %.1f", st.Label(), summary.value(st)))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestCalculateStats_StdDev(t *testing.T) {
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	min, max, avg, median, stddev := calculateStats(values)
+
+	if min != 2 || max != 9 {
+		t.Errorf("min/max = %.1f/%.1f, want 2.0/9.0", min, max)
+	}
+	if avg != 5 {
+		t.Errorf("avg = %.2f, want 5.00", avg)
+	}
+	if median != 4.5 {
+		t.Errorf("median = %.2f, want 4.50", median)
+	}
+	if math.Abs(stddev-2) > 0.0001 {
+		t.Errorf("stddev = %.4f, want 2.0000", stddev)
+	}
+}
+
+func TestCalculatePercentile(t *testing.T) {
+	values := []float64{10, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	tests := []struct {
+		p        float64
+		expected float64
+	}{
+		{0, 1},
+		{50, 5.5},
+		{85, 8.65},
+		{95, 9.55},
+		{100, 10},
+	}
+
+	for _, tt := range tests {
+		got := calculatePercentile(values, tt.p)
+		if math.Abs(got-tt.expected) > 0.0001 {
+			t.Errorf("calculatePercentile(%v) = %.4f, want %.4f", tt.p, got, tt.expected)
+		}
+	}
+
+	if got := calculatePercentile(nil, 85); got != 0 {
+		t.Errorf("calculatePercentile(nil) = %v, want 0", got)
+	}
+}
+
+func TestParseStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  []StatType
+		expectErr bool
+	}{
+		{"Empty uses defaults", "", DefaultStats, false},
+		{"Single stat", "p85", []StatType{StatP85}, false},
+		{"Multiple stats keep order", "median,p95,stddev", []StatType{StatMedian, StatP95, StatStdDev}, false},
+		{"Whitespace and case tolerated", " Min , MAX ", []StatType{StatMin, StatMax}, false},
+		{"Duplicates removed", "avg,avg,count", []StatType{StatAvg, StatCount}, false},
+		{"Invalid stat", "avg,p99", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStats(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseStats() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("ParseStats() = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("ParseStats()[%d] = %v, want %v", i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFormatStatsTable_DefaultLayout(t *testing.T) {
+	header := formatStatsTableHeader("Story points", DefaultStats, "")
+	expectedHeader := "Story points | Count | Min | Max | Avg | Median\n" +
+		"-------------|-------|-----|-----|-----|-------\n"
+	if header != expectedHeader {
+		t.Errorf("Header = %q, want %q", header, expectedHeader)
+	}
+
+	row := formatStatsTableRow("3", 12, summarize([]float64{1, 2, 3}), DefaultStats, "")
+	expectedRow := "           3 |     3 | 1.0 | 3.0 | 2.0 |    2.0\n"
+	if row != expectedRow {
+		t.Errorf("Row = %q, want %q", row, expectedRow)
+	}
+}
+
+func TestFormatStatsInline(t *testing.T) {
+	summary := summarize([]float64{1, 2, 3, 4})
+
+	got := formatStatsInline(summary, []StatType{StatCount, StatMedian, StatStdDev})
+	if got != "Count: 4, Median: 2.5, StdDev: 1.1" {
+		t.Errorf("formatStatsInline() = %q", got)
+	}
+}
+
+func TestGenerator_WithStats(t *testing.T) {
+	baseTime := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{
+			ID:          "1",
+			Name:        "Task 1",
+			IsCompleted: true,
+			CreatedAt:   baseTime.AddDate(0, 0, -3),
+			StartedAt:   baseTime.AddDate(0, 0, -1),
+			CompletedAt: baseTime,
+			Estimate:    3,
+		},
+	}
+
+	generator := NewGenerator(items).WithStats([]StatType{StatMedian, StatP85})
+
+	report, err := generator.Generate(MetricsTypeLeadTime, PeriodTypeMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if !strings.Contains(report, "Story points | Median | P85\n") {
+		t.Errorf("Report doesn't use selected statistics:\n%s", report)
+	}
+	if strings.Contains(report, "| Min |") {
+		t.Errorf("Report contains a statistic that wasn't selected")
+	}
+
+	// An empty selection falls back to the defaults
+	generator.WithStats(nil)
+	if len(generator.opts.Stats) != len(DefaultStats) {
+		t.Errorf("WithStats(nil) = %v, want defaults", generator.opts.Stats)
+	}
+}
@@ -2,39 +2,63 @@ package metrics
 
 import (
 	"fmt"
-	"sort"
+	"strings"
+	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
 )
 
+// ThroughputPeriod is the work completed in one week or month
+type ThroughputPeriod struct {
+	Period     string         `json:"period"`
+	Items      int            `json:"items"`
+	Amount     float64        `json:"amount"`
+	AvgPerItem float64        `json:"avg_per_item"`
+	Types      map[string]int `json:"types"`
+	date       time.Time      // Any completion date within the period
+}
+
+// throughputHistogramRows is the most rows of the weekly throughput histogram;
+// weeks with more items completed share rows covering several counts
+const throughputHistogramRows = 10
+
+// ThroughputDistribution describes how the items completed per week vary
+type ThroughputDistribution struct {
+	Weeks                  int               `json:"weeks"`                    // 7-day windows from the first to the last completion
+	Stats                  StatsSummary      `json:"stats"`                    // Of the items completed per week
+	CoefficientOfVariation float64           `json:"coefficient_of_variation"` // Standard deviation / mean
+	Histogram              []HistogramBucket `json:"histogram"`                // Weeks by the number of items completed
+}
+
+// ThroughputResult holds the completed work per period
+type ThroughputResult struct {
+	Periods          []ThroughputPeriod   `json:"periods"`
+	Distribution     *ThroughputDistribution `json:"distribution,omitempty"`
+	CapacityAdjusted []CapacityAdjustment `json:"capacity_adjusted,omitempty"`
+	Annotations      []PeriodAnnotation   `json:"annotations,omitempty"`
+}
+
 // ThroughputReport shows items and points completed per time period
 func ThroughputReport(items []models.KanbanItem, periodType string) (string, error) {
-	// Group items by time period (week or month)
-	periodFormat := "2006-01"
-	periodName := "Month"
-	if periodType == "week" {
-		periodFormat = "2006-W02" // ISO week format
-		periodName = "Week"
-	}
-	
-	throughputByPeriod := make(map[string]struct{
-		Count int
-		Points float64
-		Types map[string]int
-	})
-	
+	return throughputReport(items, periodType, DefaultOptions())
+}
+
+// throughputResult groups completed items by time period (week or month)
+func throughputResult(items []models.KanbanItem, periodType string, opts Options) ThroughputResult {
+	throughputByPeriod := make(map[dateutil.Period]*ThroughputPeriod)
 	for _, item := range items {
 		if item.IsCompleted && !item.CompletedAt.IsZero() {
-			period := item.CompletedAt.Format(periodFormat)
+			period := dateutil.PeriodOf(item.CompletedAt, periodType, opts.WeekNumbering)
 			
-			periodData := throughputByPeriod[period]
-			periodData.Count++
-			periodData.Points += item.Estimate
-			
-			// Initialize types map if needed
-			if periodData.Types == nil {
-				periodData.Types = make(map[string]int)
+			periodData, exists := throughputByPeriod[period]
+			if !exists {
+				periodData = &ThroughputPeriod{Period: period.Format(periodType), Types: make(map[string]int)}
+				throughputByPeriod[period] = periodData
 			}
+			periodData.Items++
+			periodData.Amount += opts.Unit.Value(item.Estimate)
+			periodData.date = item.CompletedAt
 			
 			// Count by type
 			itemType := item.Type
@@ -42,93 +66,167 @@ func ThroughputReport(items []models.KanbanItem, periodType string) (string, err
 				itemType = "Unspecified"
 			}
 			periodData.Types[itemType]++
-			
-			throughputByPeriod[period] = periodData
 		}
 	}
 	
 	// Sort periods chronologically
+	var result ThroughputResult
 	var periods []string
-	for period := range throughputByPeriod {
-		periods = append(periods, period)
+	var capacityPeriods []capacityPeriod
+	for _, period := range sortedPeriods(throughputByPeriod) {
+		data := throughputByPeriod[period]
+		if data.Items > 0 {
+			data.AvgPerItem = data.Amount / float64(data.Items)
+		}
+		periods = append(periods, data.Period)
+		result.Periods = append(result.Periods, *data)
+		capacityPeriods = append(capacityPeriods, capacityPeriod{data.Period, data.date, data.Items, data.Amount})
+	}
+	
+	result.Distribution = throughputDistribution(items)
+
+	// Normalize for absences when a calendar is configured
+	result.CapacityAdjusted = capacityAdjustments(periodType, capacityPeriods, opts)
+	result.Annotations = periodAnnotations(periods, periodType, opts)
+	
+	return result
+}
+
+// throughputReport builds the throughput report using the given options
+func throughputReport(items []models.KanbanItem, periodType string, opts Options) (string, error) {
+	result := throughputResult(items, periodType, opts)
+	periodName := "Month"
+	if periodType == "week" {
+		periodName = "Week"
 	}
-	sort.Strings(periods)
 	
 	report := fmt.Sprintf("# Throughput Analysis by %s\n\n", periodName)
 	
 	// Add explanatory text
-	report += "## What is Throughput?\n\n"
-	report += "Throughput measures how many items your team completes in a given time period. It represents your delivery capacity and is a key metric for planning and forecasting.\n\n"
-	report += "- Higher numbers indicate greater delivery capacity\n"
-	report += "- Consistent throughput suggests a stable, predictable process\n"
-	report += "- Declining throughput may indicate impediments or reduced capacity\n"
-	report += "- Rising throughput may indicate process improvements or increased capacity\n\n"
-	report += "## How to use this data:\n"
-	report += "- Use average throughput to forecast future delivery capabilities\n"
-	report += "- Look for trends or patterns in delivery capacity\n"
-	report += "- Compare throughput across different time periods to identify improvements or issues\n"
-	report += "- Analyze the balance between different types of work (features, bugs, etc.)\n\n"
+	if !opts.NoExplanations {
+		report += throughputExplanation
+	}
 	
-	report += fmt.Sprintf("%s | Items Completed | Story Points | Avg Points/Item\n", periodName)
-	report += "-------|----------------|-------------|---------------\n"
+	// Estimate totals are left out when counting items, since they equal the item count
+	amountTitle := opts.Unit.Title()
+	avgTitle := fmt.Sprintf("Avg %s/Item", opts.Unit.ColumnTitle())
+	if opts.Unit.CountsItems() {
+		report += fmt.Sprintf("%s | Items Completed\n", periodName)
+		report += "-------|----------------\n"
+	} else {
+		report += fmt.Sprintf("%s | Items Completed | %s | %s\n", periodName, amountTitle, avgTitle)
+		report += fmt.Sprintf("-------|----------------|%s|%s\n",
+			strings.Repeat("-", len(amountTitle)+1), strings.Repeat("-", len(avgTitle)))
+	}
 	
-	for _, period := range periods {
-		data := throughputByPeriod[period]
-		avgPointsPerItem := 0.0
-		if data.Count > 0 {
-			avgPointsPerItem = data.Points / float64(data.Count)
+	markers, footnotes := annotatePeriods(result.Annotations, opts.PeriodLabels)
+	var periods []string
+	typeCounts := make(map[string]map[string]int)
+	for _, data := range result.Periods {
+		label := opts.PeriodLabels.Label(data.Period)
+		periods = append(periods, label)
+		typeCounts[label] = data.Types
+		if opts.Unit.CountsItems() {
+			report += fmt.Sprintf("%s | %15d%s\n", label, data.Items, markers[data.Period])
+			continue
 		}
 		
-		report += fmt.Sprintf("%s | %15d | %11.1f | %14.1f\n", 
-			period, data.Count, data.Points, avgPointsPerItem)
+		report += fmt.Sprintf("%s | %15d | %*.1f | %*.1f%s\n", 
+			label, data.Items, len(amountTitle)-1, data.Amount, len(avgTitle)-1, data.AvgPerItem, markers[data.Period])
 	}
 	
 	// Add breakdown by type
 	report += "\n## Breakdown by Item Type\n\n"
+	report += typeBreakdownTable(periodName, periods, typeCounts, opts.Width)
 	
-	// Get all unique types across all periods
-	allTypes := make(map[string]bool)
-	for _, period := range periods {
-		for itemType := range throughputByPeriod[period].Types {
-			allTypes[itemType] = true
+	report += formatThroughputDistribution(result.Distribution)
+	report += capacityAdjustedSection("Capacity-Adjusted Throughput", periodName, result.CapacityAdjusted, opts)
+	report += footnotes
+	
+	return report, nil
+}
+
+// throughputDistribution summarizes the items completed in each week up to
+// the last completion, counting weeks without completions as zero the way
+// the forecast samples them. It returns nil when nothing was completed.
+func throughputDistribution(items []models.KanbanItem) *ThroughputDistribution {
+	var last time.Time
+	for _, item := range items {
+		if item.IsCompleted && item.CompletedAt.After(last) {
+			last = item.CompletedAt
 		}
 	}
-	
-	// Convert to sorted slice
-	var typesList []string
-	for itemType := range allTypes {
-		typesList = append(typesList, itemType)
+	if last.IsZero() {
+		return nil
 	}
-	sort.Strings(typesList)
-	
-	// Create header with all types
-	report += periodName
-	for _, itemType := range typesList {
-		report += fmt.Sprintf(" | %s", itemType)
+
+	counts := weeklyThroughput(items, last)
+	values := make([]float64, len(counts))
+	for i, count := range counts {
+		values[i] = float64(count)
 	}
-	report += " | Total\n"
-	
-	// Add separator
-	report += "-------"
-	for range typesList {
-		report += "|-------"
+	distribution := &ThroughputDistribution{
+		Weeks:     len(counts),
+		Stats:     summarize(values),
+		Histogram: countBuckets(counts),
 	}
-	report += "|-------\n"
-	
-	// Add rows for each period
-	for _, period := range periods {
-		data := throughputByPeriod[period]
-		report += period
-		
-		periodTotal := 0
-		for _, itemType := range typesList {
-			count := data.Types[itemType]
-			report += fmt.Sprintf(" | %5d", count)
-			periodTotal += count
+	if distribution.Stats.Avg > 0 {
+		distribution.CoefficientOfVariation = distribution.Stats.StdDev / distribution.Stats.Avg
+	}
+	return distribution
+}
+
+// countBuckets counts the weeks by the number of items completed, one row
+// per count up to throughputHistogramRows and ranges of counts beyond that
+func countBuckets(counts []int) []HistogramBucket {
+	most := 0
+	for _, count := range counts {
+		if count > most {
+			most = count
 		}
-		
-		report += fmt.Sprintf(" | %5d\n", periodTotal)
 	}
-	
-	return report, nil
-}
\ No newline at end of file
+
+	width := most/throughputHistogramRows + 1
+	var buckets []HistogramBucket
+	for low := 0; low <= most; low += width {
+		bucket := HistogramBucket{Label: fmt.Sprintf("%d", low)}
+		if width > 1 {
+			bucket.Label = fmt.Sprintf("%d–%d", low, low+width-1)
+		}
+		for _, count := range counts {
+			if count >= low && count < low+width {
+				bucket.Count++
+			}
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+// predictability describes how steady delivery is from the coefficient of variation
+func predictability(cv float64) string {
+	switch {
+	case cv < 0.5:
+		return "steady delivery, so forecasts are narrow"
+	case cv < 1:
+		return "variable delivery, so forecasts have wide ranges"
+	default:
+		return "unpredictable delivery, so forecasts are unreliable"
+	}
+}
+
+// formatThroughputDistribution renders the weekly throughput statistics and histogram
+func formatThroughputDistribution(distribution *ThroughputDistribution) string {
+	if distribution == nil {
+		return ""
+	}
+
+	stats := distribution.Stats
+	report := "\n## Weekly Throughput Distribution\n\n"
+	report += fmt.Sprintf("Items per week over %d weeks: avg %.1f, median %.1f, std dev %.1f, min %.0f, max %.0f\n",
+		distribution.Weeks, stats.Avg, stats.Median, stats.StdDev, stats.Min, stats.Max)
+	report += fmt.Sprintf("Coefficient of variation: %.2f (%s)\n\n",
+		distribution.CoefficientOfVariation, predictability(distribution.CoefficientOfVariation))
+	report += formatCountDistribution("Items/Week", "Weeks", distribution.Histogram, distribution.Weeks)
+	return report
+}
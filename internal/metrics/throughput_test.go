@@ -1,11 +1,13 @@
 package metrics
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
 func TestThroughputReport(t *testing.T) {
@@ -322,4 +324,209 @@ func TestThroughputReport_ChronologicalSorting(t *testing.T) {
 	if may > june {
 		t.Errorf("May should appear before June in chronological order")
 	}
-}
\ No newline at end of file
+}
+func TestThroughputResult_WeekNumbering(t *testing.T) {
+	items := []models.KanbanItem{
+		// Saturday and Sunday: the same ISO week, but the Sunday starts a US week
+		{ID: "1", Name: "Task 1", IsCompleted: true, CompletedAt: time.Date(2024, 5, 18, 12, 0, 0, 0, time.UTC), Estimate: 3},
+		{ID: "2", Name: "Task 2", IsCompleted: true, CompletedAt: time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC), Estimate: 2},
+	}
+
+	tests := []struct {
+		numbering types.WeekNumbering
+		want      []string
+	}{
+		{types.WeekNumberingISO, []string{"2024-W20"}},
+		{types.WeekNumberingUS, []string{"2024-W20", "2024-W21"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.numbering), func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.WeekNumbering = tt.numbering
+			result := throughputResult(items, "week", opts)
+
+			var got []string
+			for _, period := range result.Periods {
+				got = append(got, period.Period)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("periods = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThroughputReport_PeriodLabels(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Type: "Feature", IsCompleted: true, CompletedAt: time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC), Estimate: 3},
+	}
+
+	tests := []struct {
+		periodType string
+		labels     types.PeriodLabels
+		want       string
+		notWant    string
+	}{
+		{"month", types.PeriodLabelsFriendly, "May 2024 |", "2024-05"},
+		{"week", types.PeriodLabelsFriendly, "Week 20, 2024 |", "2024-W20"},
+		{"month", types.PeriodLabelsISO, "2024-05 |", "May 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.periodType+" "+string(tt.labels), func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.PeriodLabels = tt.labels
+			report, err := throughputReport(items, tt.periodType, opts)
+			if err != nil {
+				t.Fatalf("throughputReport() error = %v", err)
+			}
+			if !strings.Contains(report, tt.want) || strings.Contains(report, tt.notWant) {
+				t.Errorf("report should label the period %q, not %q:\n%s", tt.want, tt.notWant, report)
+			}
+
+			// The JSON result keeps the sortable labels
+			if period := throughputResult(items, tt.periodType, opts).Periods[0].Period; strings.Contains(period, " ") {
+				t.Errorf("result period = %q, want the ISO label", period)
+			}
+		})
+	}
+}
+
+func TestThroughputReport_Units(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", IsCompleted: true, CompletedAt: time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC), Estimate: 4},
+		{ID: "2", Name: "Task 2", IsCompleted: true, CompletedAt: time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), Estimate: 8},
+	}
+
+	opts := DefaultOptions()
+	opts.Unit = types.UnitHours
+	report, err := throughputReport(items, "month", opts)
+	if err != nil {
+		t.Fatalf("throughputReport() error = %v", err)
+	}
+	for _, str := range []string{"Items Completed | Hours | Avg Hours/Item", "2024-05 |               2 | 12.0 |           6.0"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Hours report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	opts.Unit = types.UnitItems
+	report, err = throughputReport(items, "month", opts)
+	if err != nil {
+		t.Fatalf("throughputReport() error = %v", err)
+	}
+	if strings.Contains(report, "Points") || strings.Contains(report, "Avg") {
+		t.Errorf("Items report should not contain estimate columns\nGot:\n%s", report)
+	}
+	if !strings.Contains(report, "2024-05 |               2\n") {
+		t.Errorf("Items report should list the item count per period\nGot:\n%s", report)
+	}
+}
+
+func TestTypeBreakdownTable(t *testing.T) {
+	periods := []string{"2024-04", "2024-05"}
+	counts := map[string]map[string]int{
+		"2024-04": {"Bug": 3, "Feature": 2, "Chore": 1, "Spike": 1},
+		"2024-05": {"Bug": 1, "Feature": 4, "Documentation": 1},
+	}
+
+	full := typeBreakdownTable("Month", periods, counts, 0)
+	expected := "Month   |   Bug | Chore | Documentation | Feature | Spike | Total\n" +
+		"--------|-------|-------|---------------|---------|-------|------\n" +
+		"2024-04 |     3 |     1 |             0 |       2 |     1 |     7\n" +
+		"2024-05 |     1 |     0 |             1 |       4 |     0 |     6\n"
+	if full != expected {
+		t.Errorf("typeBreakdownTable() without width =\n%s\nwant\n%s", full, expected)
+	}
+
+	condensed := typeBreakdownTable("Month", periods, counts, 41)
+	expected = "Month   |   Bug | Feature | Other | Total\n" +
+		"--------|-------|---------|-------|------\n" +
+		"2024-04 |     3 |       2 |     2 |     7\n" +
+		"2024-05 |     1 |       4 |     1 |     6\n" +
+		"\nOther combines 3 less frequent types to fit 41 columns: Chore, Documentation, Spike\n"
+	if condensed != expected {
+		t.Errorf("typeBreakdownTable() with width 41 =\n%s\nwant\n%s", condensed, expected)
+	}
+
+	for _, line := range strings.Split(strings.SplitN(condensed, "\n\n", 2)[0], "\n") {
+		if len(line) > 41 {
+			t.Errorf("line is wider than 41 columns: %q", line)
+		}
+	}
+}
+
+func BenchmarkThroughputResult(b *testing.B) {
+	items := benchmarkItems(50000)
+	opts := DefaultOptions()
+
+	for _, periodType := range []string{"week", "month"} {
+		b.Run(periodType, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				throughputResult(items, periodType, opts)
+			}
+		})
+	}
+}
+
+func TestThroughputDistribution(t *testing.T) {
+	last := time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)
+	var items []models.KanbanItem
+	// 4 weeks with 2, 0, 4 and 2 items completed, oldest first
+	for weeksBack, count := range []int{2, 4, 0, 2} {
+		for i := 0; i < count; i++ {
+			items = append(items, models.KanbanItem{IsCompleted: true, CompletedAt: last.AddDate(0, 0, -7*weeksBack)})
+		}
+	}
+	items = append(items, models.KanbanItem{Name: "Open"})
+
+	distribution := throughputDistribution(items)
+	if distribution == nil {
+		t.Fatal("throughputDistribution() = nil, want a distribution")
+	}
+	if distribution.Weeks != 4 || distribution.Stats.Avg != 2 || distribution.Stats.Median != 2 {
+		t.Errorf("weeks, avg, median = %d, %.1f, %.1f, want 4, 2.0, 2.0", distribution.Weeks, distribution.Stats.Avg, distribution.Stats.Median)
+	}
+	// Population standard deviation of 2, 0, 4, 2 is sqrt(2)
+	if cv := distribution.CoefficientOfVariation; cv < 0.707 || cv > 0.708 {
+		t.Errorf("CoefficientOfVariation = %.3f, want 0.707", cv)
+	}
+	want := []HistogramBucket{{"0", 1}, {"1", 0}, {"2", 2}, {"3", 0}, {"4", 1}}
+	if !reflect.DeepEqual(distribution.Histogram, want) {
+		t.Errorf("Histogram = %+v, want %+v", distribution.Histogram, want)
+	}
+
+	if throughputDistribution([]models.KanbanItem{{Name: "Open"}}) != nil {
+		t.Error("throughputDistribution() without completions should be nil")
+	}
+
+	report, err := ThroughputReport(items, "week")
+	if err != nil {
+		t.Fatalf("ThroughputReport() error = %v", err)
+	}
+	for _, want := range []string{
+		"## Weekly Throughput Distribution",
+		"Items per week over 4 weeks: avg 2.0, median 2.0, std dev 1.4, min 0, max 4",
+		"Coefficient of variation: 0.71 (variable delivery, so forecasts have wide ranges)",
+		"Items/Week | Weeks | % of Weeks | Distribution",
+		"2          |     2 |      50.0% | ###############",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report doesn't contain %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestCountBuckets_Ranges(t *testing.T) {
+	buckets := countBuckets([]int{0, 3, 12, 25})
+
+	// Up to 25 items a week fold into rows of 3 counts each
+	if len(buckets) != 9 || buckets[0].Label != "0–2" || buckets[8].Label != "24–26" {
+		t.Fatalf("buckets = %+v, want 9 rows of 3 counts from 0–2 to 24–26", buckets)
+	}
+	if buckets[0].Count != 1 || buckets[1].Count != 1 || buckets[4].Count != 1 || buckets[8].Count != 1 {
+		t.Errorf("buckets = %+v, want one week in 0–2, 3–5, 12–14 and 24–26", buckets)
+	}
+}
@@ -1,6 +1,11 @@
 package metrics
 
-import "fmt"
+import (
+    "fmt"
+    "strings"
+
+    "github.com/hannasdev/kanban-reports/internal/models"
+)
 
 // MetricsType defines the type of metrics to generate
 type MetricsType string
@@ -18,14 +23,44 @@ const (
     MetricsTypeAge MetricsType = "age"
     // MetricsTypeImprovement generates month-over-month improvement metrics
     MetricsTypeImprovement MetricsType = "improvement"
+    // MetricsTypeWorkflow compares lead time and throughput across workflows
+    MetricsTypeWorkflow MetricsType = "workflow"
+    // MetricsTypeBenchmark ranks teams (or other groups) against each other
+    MetricsTypeBenchmark MetricsType = "benchmark"
+    // MetricsTypeReview estimates time spent in the final review or QA state
+    MetricsTypeReview MetricsType = "review"
+    // MetricsTypeCFD counts items per state over time (cumulative flow)
+    MetricsTypeCFD MetricsType = "cfd"
+    // MetricsTypePriority trends the share of completed effort by priority
+    MetricsTypePriority MetricsType = "priority"
+    // MetricsTypeDigest summarizes the latest week in plain-language highlights
+    MetricsTypeDigest MetricsType = "digest"
+    // MetricsTypeWeekday shows completions by day of week and hour of day
+    MetricsTypeWeekday MetricsType = "weekday"
+    // MetricsTypeForecast forecasts completion with Monte Carlo simulations of weekly throughput
+    MetricsTypeForecast MetricsType = "forecast"
+    // MetricsTypeCommitment compares the work committed to each iteration with the work completed
+    MetricsTypeCommitment MetricsType = "commitment"
+    // MetricsTypeScatterplot plots the cycle time of each item against its completion date
+    MetricsTypeScatterplot MetricsType = "scatterplot"
     // MetricsTypeAll generates all metrics reports
     MetricsTypeAll MetricsType = "all"
 )
 
+// AllSections are the metrics included in MetricsTypeAll, in output order
+var AllSections = []MetricsType{
+    MetricsTypeLeadTime,
+    MetricsTypeThroughput,
+    MetricsTypeFlow,
+    MetricsTypeEstimation,
+    MetricsTypeAge,
+    MetricsTypeImprovement,
+}
+
 // Validate MetricsType
 func (mt MetricsType) IsValid() bool {
     switch mt {
-    case MetricsTypeLeadTime, MetricsTypeThroughput, MetricsTypeFlow, MetricsTypeEstimation, MetricsTypeAge, MetricsTypeImprovement, MetricsTypeAll:
+    case MetricsTypeLeadTime, MetricsTypeThroughput, MetricsTypeFlow, MetricsTypeEstimation, MetricsTypeAge, MetricsTypeImprovement, MetricsTypeWorkflow, MetricsTypeBenchmark, MetricsTypeReview, MetricsTypeCFD, MetricsTypePriority, MetricsTypeDigest, MetricsTypeWeekday, MetricsTypeForecast, MetricsTypeCommitment, MetricsTypeScatterplot, MetricsTypeAll:
         return true
     }
     return false
@@ -43,6 +78,146 @@ func ParseMetricsType(s string) (MetricsType, error) {
     return mt, nil
 }
 
+// ParseMetricsTypes parses a comma-separated list of metrics types for selecting
+// sections of the "all" report; "all" itself is not allowed in the list
+func ParseMetricsTypes(s string) ([]MetricsType, error) {
+    var result []MetricsType
+    seen := make(map[MetricsType]bool)
+
+    for _, part := range strings.Split(s, ",") {
+        part = strings.TrimSpace(part)
+        if part == "" {
+            continue
+        }
+        mt := MetricsType(part)
+        if !mt.IsValid() || mt == MetricsTypeAll {
+            return nil, fmt.Errorf("invalid metrics section: %s (must be one of: lead-time, throughput, flow, estimation, age, improvement, workflow, benchmark, review, cfd, priority, digest, weekday, forecast, commitment, scatterplot)", part)
+        }
+        if !seen[mt] {
+            seen[mt] = true
+            result = append(result, mt)
+        }
+    }
+    return result, nil
+}
+
+// SelectSections returns the sections of the "all" report to generate.
+// A non-empty only list is used as given; otherwise excluded sections are
+// removed from AllSections.
+func SelectSections(only, exclude []MetricsType) []MetricsType {
+    if len(only) > 0 {
+        return only
+    }
+
+    excluded := make(map[MetricsType]bool)
+    for _, mt := range exclude {
+        excluded[mt] = true
+    }
+
+    var sections []MetricsType
+    for _, mt := range AllSections {
+        if !excluded[mt] {
+            sections = append(sections, mt)
+        }
+    }
+    return sections
+}
+
+// OrderSections moves the sections named in order to the front, in that order,
+// keeping the remaining sections in their existing order. Names in order that
+// are not among the sections are ignored.
+func OrderSections(sections, order []MetricsType) []MetricsType {
+    present := make(map[MetricsType]bool)
+    for _, mt := range sections {
+        present[mt] = true
+    }
+
+    var ordered []MetricsType
+    placed := make(map[MetricsType]bool)
+    for _, mt := range order {
+        if present[mt] && !placed[mt] {
+            placed[mt] = true
+            ordered = append(ordered, mt)
+        }
+    }
+    for _, mt := range sections {
+        if !placed[mt] {
+            ordered = append(ordered, mt)
+        }
+    }
+    return ordered
+}
+
+// SplitField defines the item field used to split items into groups for comparison
+type SplitField string
+
+const (
+    // SplitByTeam groups items by team
+    SplitByTeam SplitField = "team"
+    // SplitByProductArea groups items by product area
+    SplitByProductArea SplitField = "product-area"
+    // SplitByEpic groups items by epic
+    SplitByEpic SplitField = "epic"
+    // SplitByWorkflow groups items by workflow
+    SplitByWorkflow SplitField = "workflow"
+    // SplitByCategory groups items by category from classification rules
+    SplitByCategory SplitField = "category"
+)
+
+// IsValid checks if a SplitField is valid
+func (f SplitField) IsValid() bool {
+    switch f {
+    case SplitByTeam, SplitByProductArea, SplitByEpic, SplitByWorkflow, SplitByCategory:
+        return true
+    }
+    return false
+}
+
+// ParseSplitField converts a string to a SplitField with validation
+func ParseSplitField(s string) (SplitField, error) {
+    f := SplitField(s)
+    if !f.IsValid() {
+        return "", fmt.Errorf("invalid split field: %s (must be one of: team, product-area, epic, workflow, category)", s)
+    }
+    return f, nil
+}
+
+// Title returns the column heading for the field
+func (f SplitField) Title() string {
+    switch f {
+    case SplitByProductArea:
+        return "Product Area"
+    case SplitByEpic:
+        return "Epic"
+    case SplitByWorkflow:
+        return "Workflow"
+    case SplitByCategory:
+        return "Category"
+    }
+    return "Team"
+}
+
+// GroupOf returns the group an item belongs to, or "Unspecified"
+func (f SplitField) GroupOf(item models.KanbanItem) string {
+    var group string
+    switch f {
+    case SplitByProductArea:
+        group = item.ProductArea
+    case SplitByEpic:
+        group = item.Epic
+    case SplitByWorkflow:
+        group = item.Workflow
+    case SplitByCategory:
+        group = item.Category
+    default:
+        group = item.Team
+    }
+    if group == "" {
+        return "Unspecified"
+    }
+    return group
+}
+
 // PeriodType defines the time period for grouping metrics
 type PeriodType string
 
@@ -68,3 +243,31 @@ func ParsePeriodType(s string) (PeriodType, error) {
     }
     return pt, nil
 }
+
+// AgeMode defines how the age metric presents open work
+type AgeMode string
+
+const (
+    // AgeModeSummary shows age statistics and the oldest items per state (default)
+    AgeModeSummary AgeMode = "summary"
+    // AgeModeAgingWIP charts open items by state against completed cycle time percentiles
+    AgeModeAgingWIP AgeMode = "aging-wip"
+)
+
+// IsValid checks if an AgeMode is valid
+func (m AgeMode) IsValid() bool {
+    switch m {
+    case AgeModeSummary, AgeModeAgingWIP:
+        return true
+    }
+    return false
+}
+
+// ParseAgeMode converts a string to an AgeMode with validation
+func ParseAgeMode(s string) (AgeMode, error) {
+    m := AgeMode(s)
+    if !m.IsValid() {
+        return "", fmt.Errorf("invalid age mode: %s (must be one of: summary, aging-wip)", s)
+    }
+    return m, nil
+}
@@ -1,7 +1,10 @@
 package metrics
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
 )
 
 func TestMetricsType_IsValid(t *testing.T) {
@@ -16,6 +19,7 @@ func TestMetricsType_IsValid(t *testing.T) {
 		{"Valid estimation", MetricsTypeEstimation, true},
 		{"Valid age", MetricsTypeAge, true},
 		{"Valid improvement", MetricsTypeImprovement, true},
+		{"Valid workflow", MetricsTypeWorkflow, true},
 		{"Valid all", MetricsTypeAll, true},
 		{"Invalid type", MetricsType("invalid"), false},
 		{"Empty type", MetricsType(""), false},
@@ -44,6 +48,7 @@ func TestParseMetricsType(t *testing.T) {
 		{"Valid estimation", "estimation", MetricsTypeEstimation, false},
 		{"Valid age", "age", MetricsTypeAge, false},
 		{"Valid improvement", "improvement", MetricsTypeImprovement, false},
+		{"Valid workflow", "workflow", MetricsTypeWorkflow, false},
 		{"Valid all", "all", MetricsTypeAll, false},
 		{"Empty string (valid)", "", MetricsType(""), false}, // Empty is valid for no metrics
 		{"Invalid type", "invalid", MetricsType(""), true},
@@ -78,6 +83,7 @@ func TestMetricsTypeConstants(t *testing.T) {
 		{"Estimation constant", MetricsTypeEstimation, "estimation"},
 		{"Age constant", MetricsTypeAge, "age"},
 		{"Improvement constant", MetricsTypeImprovement, "improvement"},
+		{"Workflow constant", MetricsTypeWorkflow, "workflow"},
 		{"All constant", MetricsTypeAll, "all"},
 	}
 
@@ -90,6 +96,53 @@ func TestMetricsTypeConstants(t *testing.T) {
 	}
 }
 
+func TestParseMetricsTypes(t *testing.T) {
+	got, err := ParseMetricsTypes("age, estimation,age")
+	if err != nil {
+		t.Fatalf("ParseMetricsTypes() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != MetricsTypeAge || got[1] != MetricsTypeEstimation {
+		t.Errorf("ParseMetricsTypes() = %v, want [age estimation]", got)
+	}
+
+	for _, input := range []string{"all", "age,velocity"} {
+		if _, err := ParseMetricsTypes(input); err == nil || !strings.Contains(err.Error(), "invalid metrics section") {
+			t.Errorf("ParseMetricsTypes(%q) error = %v, want invalid metrics section", input, err)
+		}
+	}
+}
+
+func TestSelectSections(t *testing.T) {
+	excluded := SelectSections(nil, []MetricsType{MetricsTypeAge})
+	if len(excluded) != len(AllSections)-1 {
+		t.Errorf("SelectSections() with exclude = %v", excluded)
+	}
+	for _, mt := range excluded {
+		if mt == MetricsTypeAge {
+			t.Errorf("SelectSections() should drop excluded section, got %v", excluded)
+		}
+	}
+
+	only := SelectSections([]MetricsType{MetricsTypeFlow}, []MetricsType{MetricsTypeAge})
+	if len(only) != 1 || only[0] != MetricsTypeFlow {
+		t.Errorf("SelectSections() with only = %v, want [flow]", only)
+	}
+}
+
+func TestOrderSections(t *testing.T) {
+	got := OrderSections(AllSections, []MetricsType{MetricsTypeAge, MetricsTypeWorkflow, MetricsTypeFlow})
+	want := []MetricsType{MetricsTypeAge, MetricsTypeFlow, MetricsTypeLeadTime, MetricsTypeThroughput, MetricsTypeEstimation, MetricsTypeImprovement}
+
+	if len(got) != len(want) {
+		t.Fatalf("OrderSections() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("OrderSections()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
 func TestPeriodType_IsValid(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -275,4 +328,37 @@ func TestPeriodType_RoundTrip(t *testing.T) {
 	if backToString != original {
 		t.Errorf("Round trip failed: %v -> %v -> %v", original, parsed, backToString)
 	}
-}
\ No newline at end of file
+}
+func TestSplitField_GroupOf(t *testing.T) {
+	item := models.KanbanItem{Team: "Platform", Epic: "Checkout", Workflow: "Kanban", ProductArea: "Web", Category: "KTLO"}
+
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{"team", "Platform"},
+		{"product-area", "Web"},
+		{"epic", "Checkout"},
+		{"workflow", "Kanban"},
+		{"category", "KTLO"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			field, err := ParseSplitField(tt.field)
+			if err != nil {
+				t.Fatalf("ParseSplitField(%q) error = %v", tt.field, err)
+			}
+			if got := field.GroupOf(item); got != tt.expected {
+				t.Errorf("GroupOf() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+
+	if got := SplitByCategory.GroupOf(models.KanbanItem{}); got != "Unspecified" {
+		t.Errorf("GroupOf() of an item without a category = %q, expected Unspecified", got)
+	}
+	if _, err := ParseSplitField("owner"); err == nil || !strings.Contains(err.Error(), "category") {
+		t.Errorf("ParseSplitField(\"owner\") error = %v, expected it to list category", err)
+	}
+}
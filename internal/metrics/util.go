@@ -3,15 +3,18 @@ package metrics
 import (
 	"math"
 	"sort"
+
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
 // Standard story point sizes for grouping
 var standardPointSizes = []float64{1, 2, 3, 5, 8, 13, 21}
 
 // calculateStats calculates statistical values from a set of data points
-func calculateStats(values []float64) (min, max, avg, median float64) {
+func calculateStats(values []float64) (min, max, avg, median, stddev float64) {
 	if len(values) == 0 {
-		return 0, 0, 0, 0
+		return 0, 0, 0, 0, 0
 	}
 	
 	// Sort for min, max, median
@@ -40,7 +43,39 @@ func calculateStats(values []float64) (min, max, avg, median float64) {
 		median = sorted[len(sorted)/2]
 	}
 	
-	return min, max, avg, median
+	// Calculate population standard deviation
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - avg) * (v - avg)
+	}
+	stddev = math.Sqrt(variance / float64(len(values)))
+	
+	return min, max, avg, median, stddev
+}
+
+// calculatePercentile returns the p-th percentile (0-100) using linear interpolation
+func calculatePercentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	
+	if p <= 0 {
+		return sorted[0]
+	}
+	if p >= 100 {
+		return sorted[len(sorted)-1]
+	}
+	
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	fraction := rank - float64(lower)
+	
+	return sorted[lower] + (sorted[upper]-sorted[lower])*fraction
 }
 
 // calculateCorrelation calculates the Pearson correlation coefficient between two sets of values
@@ -95,4 +130,24 @@ func findClosestPointSize(estimate float64, standardSizes []float64) float64 {
 	}
 	
 	return closest
-}
\ No newline at end of file
+}
+// sizeTitle names the estimate-size grouping used in section headings
+func sizeTitle(unit types.EstimateUnit) string {
+	switch unit {
+	case types.UnitHours:
+		return "Hour Estimate"
+	case types.UnitItems:
+		return "Estimate Size"
+	}
+	return "Story Point Size"
+}
+
+// sortedPeriods returns the periods that have data in chronological order
+func sortedPeriods[V any](byPeriod map[dateutil.Period]V) []dateutil.Period {
+	periods := make([]dateutil.Period, 0, len(byPeriod))
+	for period := range byPeriod {
+		periods = append(periods, period)
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i] < periods[j] })
+	return periods
+}
@@ -2,9 +2,33 @@
 package metrics
 
 import (
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
 )
 
+// benchmarkItems returns n completed items spread over about four years, as
+// in the export of a large organization
+func benchmarkItems(n int) []models.KanbanItem {
+	start := time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC)
+	items := make([]models.KanbanItem, n)
+	for i := range items {
+		completed := start.Add(time.Duration(i%1461)*24*time.Hour + time.Duration(i%8)*time.Hour)
+		items[i] = models.KanbanItem{
+			ID:          fmt.Sprintf("%d", i),
+			Type:        []string{"feature", "bug", "chore"}[i%3],
+			Estimate:    float64(i%8 + 1),
+			IsCompleted: true,
+			CreatedAt:   completed.AddDate(0, 0, -(i%30 + 5)),
+			StartedAt:   completed.AddDate(0, 0, -(i%10 + 1)),
+			CompletedAt: completed,
+		}
+	}
+	return items
+}
+
 func BenchmarkCalculateStats(b *testing.B) {
 	// Create sample data
 	data := make([]float64, 1000)
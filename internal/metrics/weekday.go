@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// CrunchHour is the hour of day from which a Friday completion counts as late
+const CrunchHour = 16
+
+// CrunchThreshold is the share of completions (in percent) landing on Friday
+// evenings or weekends above which a crunch pattern is flagged
+const CrunchThreshold = 20.0
+
+// weekdayOrder lists the days of the week starting on Monday
+var weekdayOrder = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+}
+
+// CompletionWeekdayReport shows when in the week (and day, if timestamps allow) items are completed
+func CompletionWeekdayReport(items []models.KanbanItem) (string, error) {
+	return completionWeekdayReport(items, DefaultOptions())
+}
+
+// WeekdayResult holds when in the week items were completed
+type WeekdayResult struct {
+	Completed     int               `json:"completed_items"`
+	Days          []HistogramBucket `json:"days"`
+	Hours         []HistogramBucket `json:"hours,omitempty"` // Only set when completions have times of day
+	FridayEvening *int              `json:"friday_evening,omitempty"`
+	Weekend       int               `json:"weekend"`
+	LateShare     float64           `json:"late_share_percent"` // Share of completions on Friday evenings or weekends
+}
+
+// completionWeekdayResult counts completions by day of week and, when
+// timestamps allow, by hour of day
+func completionWeekdayResult(items []models.KanbanItem) (WeekdayResult, error) {
+	var completed []time.Time
+	hasTimes := false
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		completed = append(completed, item.CompletedAt)
+		if item.CompletedAt.Hour() != 0 || item.CompletedAt.Minute() != 0 || item.CompletedAt.Second() != 0 {
+			hasTimes = true
+		}
+	}
+	if len(completed) == 0 {
+		return WeekdayResult{}, fmt.Errorf("no completed items to distribute by weekday")
+	}
+
+	dayCounts := make(map[time.Weekday]int)
+	hourCounts := make([]int, 24)
+	fridayEvening, weekend := 0, 0
+	for _, t := range completed {
+		dayCounts[t.Weekday()]++
+		hourCounts[t.Hour()]++
+		switch {
+		case t.Weekday() == time.Saturday || t.Weekday() == time.Sunday:
+			weekend++
+		case t.Weekday() == time.Friday && hasTimes && t.Hour() >= CrunchHour:
+			fridayEvening++
+		}
+	}
+
+	result := WeekdayResult{Completed: len(completed), Weekend: weekend}
+	for _, day := range weekdayOrder {
+		result.Days = append(result.Days, HistogramBucket{day.String(), dayCounts[day]})
+	}
+
+	late := weekend
+	if hasTimes {
+		// Only show the hours from the first to the last one with completions
+		first, last := 23, 0
+		for hour, count := range hourCounts {
+			if count > 0 {
+				first = min(first, hour)
+				last = max(last, hour)
+			}
+		}
+		for hour := first; hour <= last; hour++ {
+			result.Hours = append(result.Hours, HistogramBucket{fmt.Sprintf("%02d:00", hour), hourCounts[hour]})
+		}
+		result.FridayEvening = &fridayEvening
+		late += fridayEvening
+	}
+	result.LateShare = float64(late) / float64(len(completed)) * 100
+
+	return result, nil
+}
+
+// completionWeekdayReport builds the completion weekday report using the given options
+func completionWeekdayReport(items []models.KanbanItem, opts Options) (string, error) {
+	result, err := completionWeekdayResult(items)
+	if err != nil {
+		return "", err
+	}
+
+	report := "# Completions by Day of Week\n\n"
+	report += fmt.Sprintf("When in the week the %d completed items were finished.\n\n", result.Completed)
+	report += formatDistribution("Day", result.Days, result.Completed)
+
+	if result.Hours != nil {
+		report += "\n## Completions by Hour\n\n"
+		report += formatDistribution("Hour", result.Hours, result.Completed)
+	} else {
+		report += "\nCompletion times are all at midnight, so completions by hour are not shown.\n"
+	}
+
+	report += "\n## Crunch Check\n\n"
+	if result.FridayEvening != nil {
+		report += fmt.Sprintf("Friday from %02d:00: %d items (%.1f%%)\n", CrunchHour, *result.FridayEvening,
+			float64(*result.FridayEvening)/float64(result.Completed)*100)
+	}
+	report += fmt.Sprintf("Weekend: %d items (%.1f%%)\n", result.Weekend, float64(result.Weekend)/float64(result.Completed)*100)
+
+	if result.LateShare >= CrunchThreshold {
+		when := "on weekends"
+		if result.Hours != nil {
+			when = "on Friday evenings or weekends"
+		}
+		report += fmt.Sprintf("\n⚠️  %.0f%% of completions land %s, which may point to end-of-week crunch.\n", result.LateShare, when)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestCompletionWeekdayReport(t *testing.T) {
+	// 2024-06-03 is a Monday and 2024-06-07 a Friday
+	at := func(d, hour int) time.Time {
+		return time.Date(2024, 6, d, hour, 30, 0, 0, time.UTC)
+	}
+
+	items := []models.KanbanItem{
+		{ID: "1", IsCompleted: true, CompletedAt: at(3, 10)},
+		{ID: "2", IsCompleted: true, CompletedAt: at(4, 11)},
+		{ID: "3", IsCompleted: true, CompletedAt: at(7, 17)},
+		{ID: "4", IsCompleted: true, CompletedAt: at(7, 18)},
+		{ID: "5", IsCompleted: true, CompletedAt: at(8, 12)},
+		{ID: "6"},
+	}
+
+	report, err := completionWeekdayReport(items, DefaultOptions())
+	if err != nil {
+		t.Fatalf("completionWeekdayReport() error = %v", err)
+	}
+
+	expected := []string{
+		"When in the week the 5 completed items were finished.",
+		"Monday    |     1 |      20.0% | ######",
+		"Friday    |     2 |      40.0% | ############",
+		"Sunday    |     0 |       0.0% |\n",
+		"## Completions by Hour",
+		"10:00 |     1 |      20.0% | ######",
+		"18:00 |     1 |      20.0% | ######",
+		"Friday from 16:00: 2 items (40.0%)",
+		"Weekend: 1 items (20.0%)",
+		"60% of completions land on Friday evenings or weekends",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+	if strings.Contains(report, "09:00") || strings.Contains(report, "19:00") {
+		t.Errorf("Report shows hours outside the completed range:\n%s", report)
+	}
+}
+
+func TestCompletionWeekdayReport_DatesOnly(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", IsCompleted: true, CompletedAt: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{ID: "2", IsCompleted: true, CompletedAt: time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC)},
+	}
+
+	report, err := completionWeekdayReport(items, DefaultOptions())
+	if err != nil {
+		t.Fatalf("completionWeekdayReport() error = %v", err)
+	}
+	if strings.Contains(report, "## Completions by Hour") || strings.Contains(report, "Friday from") {
+		t.Errorf("Report shows hours for date-only completions:\n%s", report)
+	}
+	if strings.Contains(report, "⚠️") {
+		t.Errorf("Report flags crunch without late completions:\n%s", report)
+	}
+}
+
+func TestCompletionWeekdayReport_NoItems(t *testing.T) {
+	if _, err := completionWeekdayReport([]models.KanbanItem{{ID: "1"}}, DefaultOptions()); err == nil {
+		t.Error("completionWeekdayReport() expected error for no completed items")
+	}
+}
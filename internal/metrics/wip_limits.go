@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// WIPLimits caps the number of items each team may have in a state at once.
+// Keys are "State", which applies to every team, or "Team/State" for one
+// team, which wins over the state's limit for every team. A state containing
+// "/" that applies to every team is keyed "*/State", so it can't be mistaken
+// for a team's limit.
+type WIPLimits map[string]int
+
+// anyTeam stands for every team in a key, for states that contain "/"
+const anyTeam = "*"
+
+// ParseWIPLimits parses a list like "In Progress=5,Review=3,Payments/In Progress=2".
+// Each entry is state=limit or team/state=limit, with a limit of 1 or more.
+// The team ends at the first "/", so states may contain one, and the team
+// "*" applies to every team, e.g. "*/Review/QA=3".
+func ParseWIPLimits(s string) (WIPLimits, error) {
+	limits := make(WIPLimits)
+	if strings.TrimSpace(s) == "" {
+		return limits, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		scope, limitStr, ok := strings.Cut(part, "=")
+		team, state, hasTeam := strings.Cut(scope, "/")
+		if !hasTeam {
+			team, state = "", scope
+		}
+		team, state = strings.TrimSpace(team), strings.TrimSpace(state)
+		if !ok || state == "" || (hasTeam && team == "") {
+			return nil, fmt.Errorf("invalid WIP limit: %s (expected state=limit or team/state=limit)", part)
+		}
+		if team == anyTeam {
+			team = ""
+		}
+
+		limit, err := strconv.Atoi(strings.TrimSpace(limitStr))
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("invalid WIP limit: %s (limit must be a whole number of items, 1 or more)", part)
+		}
+		limits[wipKey(team, state)] = limit
+	}
+
+	return limits, nil
+}
+
+// wipKey returns the key of a team's limit in a state, or of the state's
+// limit for every team when team is empty
+func wipKey(team, state string) string {
+	if team == "" && strings.Contains(state, "/") {
+		team = anyTeam
+	}
+	if team == "" {
+		return state
+	}
+	return team + "/" + state
+}
+
+// lookup returns the limit of a team in a state, falling back to the state's
+// limit for every team
+func (l WIPLimits) lookup(team, state string) (int, bool) {
+	if limit, ok := l[wipKey(team, state)]; ok {
+		return limit, true
+	}
+	limit, ok := l[wipKey("", state)]
+	return limit, ok
+}
+
+// String formats the limits in the form accepted by ParseWIPLimits
+func (l WIPLimits) String() string {
+	var keys []string
+	for key := range l {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%d", key, l[key]))
+	}
+	return strings.Join(parts, ",")
+}
+
+// WIPViolation is a team with more items in a state than its limit allows
+type WIPViolation struct {
+	Period string `json:"period,omitempty"` // Period whose end the items were counted at; empty for the current work in progress
+	Team   string `json:"team"`
+	State  string `json:"state"`
+	Items  int    `json:"items"`
+	Limit  int    `json:"limit"`
+}
+
+// String describes the violation in one line
+func (v WIPViolation) String() string {
+	text := fmt.Sprintf("%s, %s: %d %s (limit %d)", v.Team, v.State, v.Items, pluralize("item", v.Items), v.Limit)
+	if v.Period != "" {
+		text = v.Period + " " + text
+	}
+	return text
+}
+
+// violations counts the items of each team in the state stateOf places them
+// in, skipping items it returns "" for, and returns the teams and states over
+// their limits, by team and state
+func (l WIPLimits) violations(items []models.KanbanItem, stateOf func(models.KanbanItem) string, period string) []WIPViolation {
+	if len(l) == 0 {
+		return nil
+	}
+
+	type teamState struct{ team, state string }
+	counts := make(map[teamState]int)
+	for _, item := range items {
+		if state := stateOf(item); state != "" {
+			counts[teamState{SplitByTeam.GroupOf(item), state}]++
+		}
+	}
+
+	var found []WIPViolation
+	for key, count := range counts {
+		if limit, ok := l.lookup(key.team, key.state); ok && count > limit {
+			found = append(found, WIPViolation{Period: period, Team: key.team, State: key.state, Items: count, Limit: limit})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Team != found[j].Team {
+			return found[i].Team < found[j].Team
+		}
+		return found[i].State < found[j].State
+	})
+	return found
+}
+
+// currentViolations checks the open items against the limits, by their
+// current state
+func (l WIPLimits) currentViolations(items []models.KanbanItem) []WIPViolation {
+	return l.violations(items, func(item models.KanbanItem) string {
+		if item.IsCompleted {
+			return ""
+		}
+		if item.State == "" {
+			return "Unknown"
+		}
+		return item.State
+	}, "")
+}
+
+// formatWIPViolations lists the violations under a heading, or returns ""
+// when there are none
+func formatWIPViolations(violations []WIPViolation) string {
+	if len(violations) == 0 {
+		return ""
+	}
+	report := "## Over WIP Limits\n\n"
+	for _, violation := range violations {
+		report += fmt.Sprintf("- %s %s\n", SLARed.Marker(), violation)
+	}
+	return report + "\n"
+}
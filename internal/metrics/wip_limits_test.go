@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestParseWIPLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  WIPLimits
+		expectErr bool
+	}{
+		{"Empty", "", WIPLimits{}, false},
+		{"Every team", "In Progress=5", WIPLimits{"In Progress": 5}, false},
+		{"One team", "In Progress=5, Payments / Review = 2", WIPLimits{"In Progress": 5, "Payments/Review": 2}, false},
+		{"State with a slash", "Payments/Review/QA=2, */Review/QA=3", WIPLimits{"Payments/Review/QA": 2, "*/Review/QA": 3}, false},
+		{"Every team spelled out", "*/In Progress=5", WIPLimits{"In Progress": 5}, false},
+		{"Missing limit", "Review", nil, true},
+		{"Missing state", "Payments/=2", nil, true},
+		{"Missing team", "/Review=2", nil, true},
+		{"Zero limit", "Review=0", nil, true},
+		{"Fractional limit", "Review=1.5", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWIPLimits(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseWIPLimits() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+			if got.String() != tt.expected.String() {
+				t.Errorf("ParseWIPLimits() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// wipItems has three items in progress for Payments, two for Core and one
+// completed item
+func wipItems() []models.KanbanItem {
+	started := time.Now().AddDate(0, 0, -3)
+	return []models.KanbanItem{
+		{ID: "1", Name: "A", Team: "Payments", State: "In Progress", StartedAt: started},
+		{ID: "2", Name: "B", Team: "Payments", State: "In Progress", StartedAt: started},
+		{ID: "3", Name: "C", Team: "Payments", State: "In Progress", StartedAt: started},
+		{ID: "4", Name: "D", Team: "Core", State: "In Progress", StartedAt: started},
+		{ID: "5", Name: "E", Team: "Core", State: "In Progress", StartedAt: started},
+		{ID: "6", Name: "F", Team: "Core", State: "Done", StartedAt: started, IsCompleted: true, CompletedAt: time.Now()},
+	}
+}
+
+func TestWIPLimits_CurrentViolations(t *testing.T) {
+	limits := WIPLimits{"In Progress": 2, "Core/In Progress": 1, "Done": 0}
+
+	got := limits.currentViolations(wipItems())
+	want := []WIPViolation{
+		{Team: "Core", State: "In Progress", Items: 2, Limit: 1},
+		{Team: "Payments", State: "In Progress", Items: 3, Limit: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("currentViolations() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("currentViolations()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if got := (WIPLimits{"In Progress": 3}).currentViolations(wipItems()); len(got) != 0 {
+		t.Errorf("Expected no violations at the limit, got %+v", got)
+	}
+}
+
+func TestWIPLimits_StateWithSlash(t *testing.T) {
+	limits, err := ParseWIPLimits("*/Review/QA=1,Core/Review/QA=2")
+	if err != nil {
+		t.Fatalf("ParseWIPLimits() error = %v", err)
+	}
+	items := []models.KanbanItem{
+		{ID: "1", Team: "Payments", State: "Review/QA"},
+		{ID: "2", Team: "Payments", State: "Review/QA"},
+		{ID: "3", Team: "Core", State: "Review/QA"},
+		{ID: "4", Team: "Core", State: "Review/QA"},
+		{ID: "5", Team: "Review", State: "QA"}, // Not the state limited for every team
+		{ID: "6", Team: "Review", State: "QA"},
+	}
+
+	got := limits.currentViolations(items)
+	want := WIPViolation{Team: "Payments", State: "Review/QA", Items: 2, Limit: 1}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("currentViolations() = %+v, want %+v", got, want)
+	}
+	if limits.String() != "*/Review/QA=1,Core/Review/QA=2" {
+		t.Errorf("String() = %q, want it to parse back to the same limits", limits.String())
+	}
+}
+
+func TestWIPViolation_String(t *testing.T) {
+	if got := (WIPViolation{Team: "Core", State: "Review", Items: 1, Limit: 0}).String(); got != "Core, Review: 1 item (limit 0)" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (WIPViolation{Period: "2024-05", Team: "Core", State: "Review", Items: 4, Limit: 2}).String(); got != "2024-05 Core, Review: 4 items (limit 2)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestWIPLimits_Reports(t *testing.T) {
+	opts := DefaultOptions()
+	opts.WIPLimits = WIPLimits{"In Progress": 2}
+	flagged := "- 🔴 Payments, In Progress: 3 items (limit 2)"
+
+	age, err := workItemAgeReport(wipItems(), time.Now(), opts)
+	if err != nil {
+		t.Fatalf("workItemAgeReport() error = %v", err)
+	}
+	if !strings.Contains(age, "## Over WIP Limits") || !strings.Contains(age, flagged) {
+		t.Errorf("Age report doesn't flag the limit:\n%s", age)
+	}
+
+	aging, err := agingWIPReport(wipItems(), time.Now(), opts)
+	if err != nil {
+		t.Fatalf("agingWIPReport() error = %v", err)
+	}
+	if !strings.Contains(aging, flagged) {
+		t.Errorf("Aging WIP report doesn't flag the limit:\n%s", aging)
+	}
+
+	digest := digestResult(wipItems(), nil, time.Now(), opts)
+	if !strings.Contains(strings.Join(digest.Highlights, "\n"), "Payments is over its WIP limit in In Progress (3 items, limit 2)") {
+		t.Errorf("Digest doesn't highlight the limit: %v", digest.Highlights)
+	}
+
+	if age, _ := workItemAgeReport(wipItems(), time.Now(), DefaultOptions()); strings.Contains(age, "Over WIP Limits") {
+		t.Errorf("Expected no WIP limits section without limits:\n%s", age)
+	}
+}
+
+func TestWIPLimits_CumulativeFlowPeriods(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC)
+	}
+	items := []models.KanbanItem{
+		{ID: "1", Team: "Core", CreatedAt: day(5, 2), StartedAt: day(5, 10), CompletedAt: day(6, 3), IsCompleted: true},
+		{ID: "2", Team: "Core", CreatedAt: day(5, 2), StartedAt: day(5, 12)},
+		{ID: "3", Team: "Core", CreatedAt: day(6, 1), StartedAt: day(6, 15)},
+	}
+	opts := DefaultOptions()
+	opts.WIPLimits = WIPLimits{"In Progress": 1}
+
+	result, err := cumulativeFlowResult(items, "month", opts)
+	if err != nil {
+		t.Fatalf("cumulativeFlowResult() error = %v", err)
+	}
+	want := WIPViolation{Period: "2024-05", Team: "Core", State: "In Progress", Items: 2, Limit: 1}
+	if len(result.OverWIPLimit) != 2 || result.OverWIPLimit[0] != want || result.OverWIPLimit[1].Period != "2024-06" {
+		t.Errorf("OverWIPLimit = %+v, want 2024-05 and 2024-06 over the limit", result.OverWIPLimit)
+	}
+
+	report, err := cumulativeFlowReport(items, "month", opts)
+	if err != nil {
+		t.Fatalf("cumulativeFlowReport() error = %v", err)
+	}
+	if !strings.Contains(report, "- 🔴 2024-05 Core, In Progress: 2 items (limit 1)") {
+		t.Errorf("Report doesn't flag the period:\n%s", report)
+	}
+}
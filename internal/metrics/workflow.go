@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/dateutil"
+)
+
+// WorkflowComparisonReport compares lead time, cycle time and throughput per workflow
+func WorkflowComparisonReport(items []models.KanbanItem, periodType string) (string, error) {
+	return workflowComparisonReport(items, periodType, DefaultOptions())
+}
+
+// WorkflowStats holds the lead time, cycle time and throughput of one workflow
+type WorkflowStats struct {
+	Name            string         `json:"name"`
+	Items           int            `json:"items"`
+	Amount          float64        `json:"amount"`
+	AvgLeadTime     float64        `json:"avg_lead_time"`
+	MedianLeadTime  float64        `json:"median_lead_time"`
+	AvgCycleTime    float64        `json:"avg_cycle_time"`
+	MedianCycleTime float64        `json:"median_cycle_time"`
+	ByPeriod        map[string]int `json:"items_by_period"`
+	AvgPerPeriod    float64        `json:"avg_items_per_period"`
+}
+
+// WorkflowResult holds the comparison of all workflows over the same periods
+type WorkflowResult struct {
+	Periods   []string        `json:"periods"`
+	Workflows []WorkflowStats `json:"workflows"`
+}
+
+// workflowResult groups completed items by workflow and period
+func workflowResult(items []models.KanbanItem, periodType string, opts Options) WorkflowResult {
+	type workflowData struct {
+		ItemCount  int
+		Points     float64
+		LeadTimes  []float64
+		CycleTimes []float64
+		ByPeriod   map[string]int
+	}
+
+	dataByWorkflow := make(map[string]*workflowData)
+	allPeriods := make(map[string]bool)
+
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+
+		workflow := item.Workflow
+		if workflow == "" {
+			workflow = "Unspecified"
+		}
+
+		data, exists := dataByWorkflow[workflow]
+		if !exists {
+			data = &workflowData{ByPeriod: make(map[string]int)}
+			dataByWorkflow[workflow] = data
+		}
+
+		data.ItemCount++
+		data.Points += opts.Unit.Value(item.Estimate)
+
+		if !item.CreatedAt.IsZero() {
+			data.LeadTimes = append(data.LeadTimes, item.CompletedAt.Sub(item.CreatedAt).Hours()/24)
+		}
+		if !item.StartedAt.IsZero() {
+			data.CycleTimes = append(data.CycleTimes, item.CompletedAt.Sub(item.StartedAt).Hours()/24)
+		}
+
+		period := dateutil.FormatPeriodWith(item.CompletedAt, periodType, opts.WeekNumbering)
+		data.ByPeriod[period]++
+		allPeriods[period] = true
+	}
+
+	// Sort workflows and periods
+	var workflows []string
+	for workflow := range dataByWorkflow {
+		workflows = append(workflows, workflow)
+	}
+	sort.Strings(workflows)
+
+	var result WorkflowResult
+	for period := range allPeriods {
+		result.Periods = append(result.Periods, period)
+	}
+	sort.Strings(result.Periods)
+
+	for _, workflow := range workflows {
+		data := dataByWorkflow[workflow]
+		stats := WorkflowStats{Name: workflow, Items: data.ItemCount, Amount: data.Points, ByPeriod: data.ByPeriod}
+		_, _, stats.AvgLeadTime, stats.MedianLeadTime, _ = calculateStats(data.LeadTimes)
+		_, _, stats.AvgCycleTime, stats.MedianCycleTime, _ = calculateStats(data.CycleTimes)
+		if len(result.Periods) > 0 {
+			stats.AvgPerPeriod = float64(data.ItemCount) / float64(len(result.Periods))
+		}
+		result.Workflows = append(result.Workflows, stats)
+	}
+
+	return result
+}
+
+// workflowComparisonReport builds the workflow comparison report using the given options
+func workflowComparisonReport(items []models.KanbanItem, periodType string, opts Options) (string, error) {
+	result := workflowResult(items, periodType, opts)
+
+	periodName := "Month"
+	if periodType == "week" {
+		periodName = "Week"
+	}
+
+	report := "# Workflow Comparison\n\n"
+
+	// Add explanatory text
+	if !opts.NoExplanations {
+		report += workflowExplanation
+	}
+
+	report += "## Lead and Cycle Time by Workflow (in days)\n\n"
+	// Estimate totals are left out when counting items, since they equal the item count
+	amountTitle := opts.Unit.ColumnTitle()
+	if opts.Unit.CountsItems() {
+		report += "Workflow | Items | Avg Lead Time | Median Lead Time | Avg Cycle Time | Median Cycle Time\n"
+		report += "---------|-------|---------------|------------------|----------------|------------------\n"
+	} else {
+		report += fmt.Sprintf("Workflow | Items | %s | Avg Lead Time | Median Lead Time | Avg Cycle Time | Median Cycle Time\n", amountTitle)
+		report += fmt.Sprintf("---------|-------|%s|---------------|------------------|----------------|------------------\n",
+			strings.Repeat("-", len(amountTitle)+2))
+	}
+
+	for _, workflow := range result.Workflows {
+		amount := ""
+		if !opts.Unit.CountsItems() {
+			amount = fmt.Sprintf(" | %*.1f", len(amountTitle), workflow.Amount)
+		}
+
+		report += fmt.Sprintf("%s | %5d%s | %13.1f | %16.1f | %14.1f | %17.1f\n",
+			workflow.Name, workflow.Items, amount, workflow.AvgLeadTime, workflow.MedianLeadTime, workflow.AvgCycleTime, workflow.MedianCycleTime)
+	}
+
+	report += fmt.Sprintf("\n## Throughput by Workflow per %s\n\n", periodName)
+
+	report += periodName
+	for _, workflow := range result.Workflows {
+		report += fmt.Sprintf(" | %s", workflow.Name)
+	}
+	report += " | Total\n"
+
+	report += "-------"
+	for range result.Workflows {
+		report += "|-------"
+	}
+	report += "|-------\n"
+
+	for _, period := range result.Periods {
+		report += period
+
+		periodTotal := 0
+		for _, workflow := range result.Workflows {
+			count := workflow.ByPeriod[period]
+			report += fmt.Sprintf(" | %5d", count)
+			periodTotal += count
+		}
+
+		report += fmt.Sprintf(" | %5d\n", periodTotal)
+	}
+
+	// Average throughput per period for each workflow
+	if len(result.Periods) > 0 {
+		report += fmt.Sprintf("\nAverage items per %s:\n\n", periodName)
+		for _, workflow := range result.Workflows {
+			report += fmt.Sprintf("- %s: %.1f\n", workflow.Name, workflow.AvgPerPeriod)
+		}
+	}
+
+	return report, nil
+}
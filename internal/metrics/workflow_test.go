@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestWorkflowComparisonReport(t *testing.T) {
+	baseTime := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+
+	items := []models.KanbanItem{
+		{
+			ID:          "1",
+			Name:        "Kanban Task",
+			Workflow:    "Kanban",
+			IsCompleted: true,
+			CreatedAt:   baseTime.AddDate(0, 0, -4),
+			StartedAt:   baseTime.AddDate(0, 0, -2),
+			CompletedAt: baseTime,
+			Estimate:    2,
+		},
+		{
+			ID:          "2",
+			Name:        "Scrum Task",
+			Workflow:    "Scrum",
+			IsCompleted: true,
+			CreatedAt:   baseTime.AddDate(0, 0, -10),
+			StartedAt:   baseTime.AddDate(0, 0, -5),
+			CompletedAt: baseTime,
+			Estimate:    5,
+		},
+		{
+			ID:          "3",
+			Name:        "Unassigned Workflow",
+			IsCompleted: true,
+			CreatedAt:   baseTime.AddDate(0, -1, -3),
+			CompletedAt: baseTime.AddDate(0, -1, 0),
+			Estimate:    1,
+		},
+		{
+			ID:          "4",
+			Name:        "Open Task",
+			Workflow:    "Kanban",
+			IsCompleted: false,
+			CreatedAt:   baseTime.AddDate(0, 0, -1),
+		},
+	}
+
+	report, err := WorkflowComparisonReport(items, "month")
+	if err != nil {
+		t.Fatalf("WorkflowComparisonReport() error = %v", err)
+	}
+
+	expectedStrings := []string{
+		"# Workflow Comparison",
+		"Lead and Cycle Time by Workflow",
+		"Throughput by Workflow per Month",
+		"Kanban | ",
+		"Scrum | ",
+		"Unspecified | ",
+		"2024-04",
+		"2024-05",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Report doesn't contain expected string: %s", expected)
+		}
+	}
+
+	// Kanban: lead 4 days, cycle 2 days; incomplete item must not be counted
+	if !strings.Contains(report, "Kanban |     1 |    2.0 |           4.0 |") {
+		t.Errorf("Kanban row has unexpected values:\n%s", report)
+	}
+
+	// May has one Kanban and one Scrum item, April only the unspecified one
+	if !strings.Contains(report, "2024-05 |     1 |     1 |     0 |     2") {
+		t.Errorf("May throughput row has unexpected values:\n%s", report)
+	}
+}
+
+func TestWorkflowComparisonReport_Weekly(t *testing.T) {
+	items := []models.KanbanItem{
+		{
+			ID:          "1",
+			Workflow:    "Kanban",
+			IsCompleted: true,
+			CompletedAt: time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	report, err := WorkflowComparisonReport(items, "week")
+	if err != nil {
+		t.Fatalf("WorkflowComparisonReport() error = %v", err)
+	}
+
+	if !strings.Contains(report, "Throughput by Workflow per Week") || !strings.Contains(report, "2024-W20") {
+		t.Errorf("Weekly report doesn't contain ISO week grouping:\n%s", report)
+	}
+}
+
+func TestWorkflowComparisonReport_Empty(t *testing.T) {
+	report, err := WorkflowComparisonReport([]models.KanbanItem{}, "month")
+	if err != nil {
+		t.Fatalf("WorkflowComparisonReport() error = %v", err)
+	}
+
+	if !strings.Contains(report, "# Workflow Comparison") {
+		t.Errorf("Empty report should still contain the header")
+	}
+}
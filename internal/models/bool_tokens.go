@@ -0,0 +1,99 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Default tokens recognised as true or false (compared case-insensitively)
+var (
+	DefaultTruthyValues = []string{"true", "t", "1", "yes", "y", "x", "✓", "✔", "on", "done"}
+	DefaultFalsyValues  = []string{"false", "f", "0", "no", "n", "-", "✗", "✘", "off"}
+)
+
+// defaultBoolTokens is shared by ParseBool to avoid rebuilding the token maps per value
+var defaultBoolTokens = DefaultBoolTokens()
+
+// BoolTokens holds the values recognised as true and false in boolean columns
+type BoolTokens struct {
+	truthy map[string]bool
+	falsy  map[string]bool
+}
+
+// NewBoolTokens creates a token set from truthy and falsy values
+func NewBoolTokens(truthy, falsy []string) BoolTokens {
+	tokens := BoolTokens{
+		truthy: make(map[string]bool),
+		falsy:  make(map[string]bool),
+	}
+	for _, value := range truthy {
+		tokens.truthy[normalizeBoolToken(value)] = true
+	}
+	for _, value := range falsy {
+		tokens.falsy[normalizeBoolToken(value)] = true
+	}
+	return tokens
+}
+
+// DefaultBoolTokens returns the built-in truthy and falsy values
+func DefaultBoolTokens() BoolTokens {
+	return NewBoolTokens(DefaultTruthyValues, DefaultFalsyValues)
+}
+
+// ParseBoolTokens builds a token set from comma-separated lists; a non-empty list replaces
+// the corresponding default list
+func ParseBoolTokens(truthy, falsy string) (BoolTokens, error) {
+	truthyValues := DefaultTruthyValues
+	if strings.TrimSpace(truthy) != "" {
+		truthyValues = splitBoolTokens(truthy)
+	}
+	falsyValues := DefaultFalsyValues
+	if strings.TrimSpace(falsy) != "" {
+		falsyValues = splitBoolTokens(falsy)
+	}
+
+	tokens := NewBoolTokens(truthyValues, falsyValues)
+	for value := range tokens.truthy {
+		if tokens.falsy[value] {
+			return BoolTokens{}, fmt.Errorf("invalid boolean values: %q is listed as both true and false", value)
+		}
+	}
+	return tokens, nil
+}
+
+// IsZero reports whether the token set was never initialised
+func (b BoolTokens) IsZero() bool {
+	return b.truthy == nil && b.falsy == nil
+}
+
+// Parse converts a value to a bool. Empty values are false; recognized reports whether
+// a non-empty value matched a known token.
+func (b BoolTokens) Parse(value string) (result bool, recognized bool) {
+	value = normalizeBoolToken(value)
+	if value == "" {
+		return false, true
+	}
+	if b.truthy[value] {
+		return true, true
+	}
+	if b.falsy[value] {
+		return false, true
+	}
+	return false, false
+}
+
+// splitBoolTokens splits a comma-separated token list, dropping empty entries
+func splitBoolTokens(s string) []string {
+	var values []string
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// normalizeBoolToken trims and lower-cases a token for comparison
+func normalizeBoolToken(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestBoolTokens_Parse(t *testing.T) {
+	tokens := DefaultBoolTokens()
+
+	tests := []struct {
+		value      string
+		expected   bool
+		recognized bool
+	}{
+		{"TRUE", true, true},
+		{" yes ", true, true},
+		{"✔", true, true},
+		{"FALSE", false, true},
+		{"n", false, true},
+		{"", false, true},
+		{"maybe", false, false},
+	}
+
+	for _, tt := range tests {
+		got, recognized := tokens.Parse(tt.value)
+		if got != tt.expected || recognized != tt.recognized {
+			t.Errorf("Parse(%q) = %v, %v; want %v, %v", tt.value, got, recognized, tt.expected, tt.recognized)
+		}
+	}
+}
+
+func TestParseBoolTokens(t *testing.T) {
+	tokens, err := ParseBoolTokens("shipped, Done", "")
+	if err != nil {
+		t.Fatalf("ParseBoolTokens() error = %v", err)
+	}
+
+	if got, ok := tokens.Parse("SHIPPED"); !got || !ok {
+		t.Errorf("Parse(\"SHIPPED\") = %v, %v; want true, true", got, ok)
+	}
+	// A custom truthy list replaces the defaults
+	if _, ok := tokens.Parse("yes"); ok {
+		t.Errorf("Parse(\"yes\") should not be recognised when truthy values are overridden")
+	}
+	// Default falsy values still apply
+	if got, ok := tokens.Parse("no"); got || !ok {
+		t.Errorf("Parse(\"no\") = %v, %v; want false, true", got, ok)
+	}
+
+	if _, err := ParseBoolTokens("yes", "yes"); err == nil {
+		t.Errorf("ParseBoolTokens() should fail when a value is both true and false")
+	}
+}
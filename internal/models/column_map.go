@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ColumnMap renames export columns to the column names the parser expects,
+// e.g. {"Story Points": "estimate"} for tools that use their own headers
+type ColumnMap map[string]string
+
+// ParseColumnMap parses a list like "Story Points=estimate,Title=name"
+func ParseColumnMap(s string) (ColumnMap, error) {
+	columnMap := make(ColumnMap)
+	if strings.TrimSpace(s) == "" {
+		return columnMap, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		source, target, ok := strings.Cut(part, "=")
+		source = strings.TrimSpace(source)
+		target = strings.TrimSpace(target)
+		if !ok || source == "" || target == "" {
+			return nil, fmt.Errorf("invalid column mapping: %s (expected export_column=column)", part)
+		}
+
+		columnMap[source] = target
+	}
+
+	return columnMap, nil
+}
+
+// Resolve returns the column name a header maps to, or the header itself when it isn't mapped
+func (m ColumnMap) Resolve(header string) string {
+	if target, ok := m[header]; ok {
+		return target
+	}
+	return header
+}
+
+// String formats the mapping in the form accepted by ParseColumnMap
+func (m ColumnMap) String() string {
+	sources := make([]string, 0, len(m))
+	for source := range m {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	parts := make([]string, 0, len(sources))
+	for _, source := range sources {
+		parts = append(parts, source+"="+m[source])
+	}
+	return strings.Join(parts, ",")
+}
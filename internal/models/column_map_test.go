@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestParseColumnMap(t *testing.T) {
+	columnMap, err := ParseColumnMap("Story Points=estimate, Title = name")
+	if err != nil {
+		t.Fatalf("ParseColumnMap() error = %v", err)
+	}
+
+	if got := columnMap.Resolve("Story Points"); got != "estimate" {
+		t.Errorf("Resolve(\"Story Points\") = %q, want \"estimate\"", got)
+	}
+	if got := columnMap.Resolve("Title"); got != "name" {
+		t.Errorf("Resolve(\"Title\") = %q, want \"name\"", got)
+	}
+	if got := columnMap.Resolve("id"); got != "id" {
+		t.Errorf("Resolve(\"id\") = %q, want unmapped header unchanged", got)
+	}
+	if got := columnMap.String(); got != "Story Points=estimate,Title=name" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, input := range []string{"estimate", "=estimate", "Points="} {
+		if _, err := ParseColumnMap(input); err == nil {
+			t.Errorf("ParseColumnMap(%q) should fail", input)
+		}
+	}
+}
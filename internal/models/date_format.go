@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateFormat holds the layouts timestamps in the CSV are read with, tried in
+// order, e.g. a date with and without the time of day
+type DateFormat []string
+
+// DefaultDateFormat is the layout of Shortcut exports: "2024/05/07 03:49:34"
+var DefaultDateFormat = DateFormat{"2006/01/02 15:04:05"}
+
+// DateFormatPresets name the date formats of common exports
+var DateFormatPresets = map[string]DateFormat{
+	"shortcut": DefaultDateFormat,
+	"iso":      {time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"},
+	"jira":     {"02/Jan/06 3:04 PM", "02/Jan/06"},
+	"eu":       {"02.01.2006 15:04:05", "02.01.2006 15:04", "02.01.2006"},
+	"us":       {"01/02/2006 15:04:05", "01/02/2006 3:04 PM", "01/02/2006"},
+}
+
+// dateFormatReference is the moment Go time layouts are written in
+var dateFormatReference = time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+
+// ParseDateFormat parses a preset name (shortcut, iso, jira, eu, us) or a Go
+// time layout such as "2006-01-02 15:04". Empty selects the default.
+func ParseDateFormat(s string) (DateFormat, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DefaultDateFormat, nil
+	}
+	if preset, ok := DateFormatPresets[s]; ok {
+		return preset, nil
+	}
+
+	// A layout must at least hold the year, month and day
+	parsed, err := time.Parse(s, dateFormatReference.Format(s))
+	if err != nil || parsed.Year() != 2006 || parsed.Month() != time.January || parsed.Day() != 2 {
+		return nil, fmt.Errorf("invalid date format: %s (must be one of: shortcut, iso, jira, eu, us, or a Go time layout such as \"2006-01-02 15:04\")", s)
+	}
+	return DateFormat{s}, nil
+}
+
+// Parse reads a timestamp with the first layout that fits it
+func (f DateFormat) Parse(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range f {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("timestamp %q doesn't match the date format %s", s, f)
+}
+
+// String returns the layouts, separated by " or "
+func (f DateFormat) String() string {
+	return strings.Join(f, " or ")
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		timestamp string
+		want      time.Time
+	}{
+		{"Default", "", "2024/05/07 03:49:34", time.Date(2024, 5, 7, 3, 49, 34, 0, time.UTC)},
+		{"ISO with time", "iso", "2024-05-07T03:49:34Z", time.Date(2024, 5, 7, 3, 49, 34, 0, time.UTC)},
+		{"ISO date only", "iso", "2024-05-07", time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC)},
+		{"Jira", "jira", "07/May/24 3:49 PM", time.Date(2024, 5, 7, 15, 49, 0, 0, time.UTC)},
+		{"EU", "eu", "07.05.2024", time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC)},
+		{"US", "us", "05/07/2024 15:49:34", time.Date(2024, 5, 7, 15, 49, 34, 0, time.UTC)},
+		{"Go layout", "2006-01-02 15:04", "2024-05-07 15:49", time.Date(2024, 5, 7, 15, 49, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := ParseDateFormat(tt.format)
+			if err != nil {
+				t.Fatalf("ParseDateFormat(%q) error = %v", tt.format, err)
+			}
+			got, err := format.Parse(tt.timestamp)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.timestamp, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.timestamp, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateFormat_Invalid(t *testing.T) {
+	for _, format := range []string{"european", "15:04", "YYYY-MM-DD"} {
+		if _, err := ParseDateFormat(format); err == nil {
+			t.Errorf("ParseDateFormat(%q) should fail", format)
+		}
+	}
+}
+
+func TestDateFormat_ParseMismatch(t *testing.T) {
+	if _, err := DefaultDateFormat.Parse("07.05.2024"); err == nil {
+		t.Error("Parse() should fail for a timestamp in another format")
+	}
+	if got, err := DefaultDateFormat.Parse(""); err != nil || !got.IsZero() {
+		t.Errorf("Parse(\"\") = %v, %v, want the zero time", got, err)
+	}
+}
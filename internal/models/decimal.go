@@ -0,0 +1,118 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DecimalSeparator defines how the decimal point is written in numeric columns
+type DecimalSeparator string
+
+const (
+	// DecimalAuto accepts either a dot or a comma as the decimal separator
+	DecimalAuto DecimalSeparator = "auto"
+	// DecimalDot expects values like "2.5"
+	DecimalDot DecimalSeparator = "dot"
+	// DecimalComma expects values like "2,5"
+	DecimalComma DecimalSeparator = "comma"
+)
+
+// IsValid checks if a DecimalSeparator is valid
+func (ds DecimalSeparator) IsValid() bool {
+	switch ds {
+	case DecimalAuto, DecimalDot, DecimalComma:
+		return true
+	}
+	return false
+}
+
+// ParseDecimalSeparator converts a string to a DecimalSeparator with validation
+func ParseDecimalSeparator(s string) (DecimalSeparator, error) {
+	ds := DecimalSeparator(s)
+	if !ds.IsValid() {
+		return "", fmt.Errorf("invalid decimal separator: %s (must be one of: auto, dot, comma)", s)
+	}
+	return ds, nil
+}
+
+// ErrAmbiguousDecimal is returned in auto mode for values like "1,000" or
+// "1.000", whose one separator may group thousands or start the decimals
+var ErrAmbiguousDecimal = errors.New("ambiguous decimal separator")
+
+// ErrUnexpectedSeparator is returned with a dot or comma decimal separator for
+// values whose other separator doesn't group thousands, such as "1.5" with a
+// comma separator, rather than reading them as 15
+var ErrUnexpectedSeparator = errors.New("unexpected decimal separator")
+
+// ParseDecimal converts a number written with the given decimal separator.
+// With a dot or comma separator the other one may only group thousands, as in
+// "1.000,5" with a comma, and returns ErrUnexpectedSeparator otherwise.
+// In auto mode the last '.' or ',' in the value is taken as the decimal point and
+// any other separators are treated as thousands separators, except that a
+// separator repeated without the other one groups thousands, as in
+// "1,000,000". A single separator followed by exactly three digits, as in
+// "1,000" or "1.000", could be either and returns ErrAmbiguousDecimal.
+func ParseDecimal(s string, sep DecimalSeparator) (float64, error) {
+	s = strings.TrimSpace(s)
+
+	switch sep {
+	case DecimalComma:
+		if !groupsWhole(s, ",", ".") {
+			return 0, fmt.Errorf("%w: %q has a '.' that doesn't group thousands, but the decimal separator is ','", ErrUnexpectedSeparator, s)
+		}
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.ReplaceAll(s, ",", ".")
+	case DecimalDot:
+		if !groupsWhole(s, ".", ",") {
+			return 0, fmt.Errorf("%w: %q has a ',' that doesn't group thousands, but the decimal separator is '.'", ErrUnexpectedSeparator, s)
+		}
+		s = strings.ReplaceAll(s, ",", "")
+	default:
+		lastDot := strings.LastIndex(s, ".")
+		lastComma := strings.LastIndex(s, ",")
+		if lastDot == -1 && strings.Count(s, ",") == 1 && groupsThousands(s, ",") ||
+			lastComma == -1 && strings.Count(s, ".") == 1 && groupsThousands(s, ".") {
+			return 0, fmt.Errorf("%w: %q may be thousands or a decimal", ErrAmbiguousDecimal, s)
+		}
+		if lastDot == -1 && strings.Count(s, ",") > 1 && groupsThousands(s, ",") {
+			s = strings.ReplaceAll(s, ",", "")
+		} else if lastComma == -1 && strings.Count(s, ".") > 1 && groupsThousands(s, ".") {
+			s = strings.ReplaceAll(s, ".", "")
+		} else if lastComma > lastDot {
+			s = strings.ReplaceAll(s, ".", "")
+			s = strings.ReplaceAll(s, ",", ".")
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
+
+// groupsThousands reports whether the separator groups the digits of s in
+// thousands, as in "1,000,000": one to three digits not starting with 0, then
+// groups of exactly three
+func groupsThousands(s, separator string) bool {
+	groups := strings.Split(strings.TrimPrefix(s, "-"), separator)
+	if len(groups[0]) == 0 || len(groups[0]) > 3 || groups[0][0] == '0' || strings.Trim(groups[0], "0123456789") != "" {
+		return false
+	}
+	for _, group := range groups[1:] {
+		if len(group) != 3 || strings.Trim(group, "0123456789") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// groupsWhole reports whether the thousands separator appears only in the
+// whole part of s, before the decimal separator, and groups it in thousands
+func groupsWhole(s, decimal, thousands string) bool {
+	whole, fraction, _ := strings.Cut(s, decimal)
+	if strings.Contains(fraction, thousands) {
+		return false
+	}
+	return !strings.Contains(whole, thousands) || groupsThousands(whole, thousands)
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		sep       DecimalSeparator
+		expected  float64
+		expectErr bool
+	}{
+		{"Auto dot", "2.5", DecimalAuto, 2.5, false},
+		{"Auto comma", "2,5", DecimalAuto, 2.5, false},
+		{"Auto integer", "8", DecimalAuto, 8, false},
+		{"Auto thousands with comma decimal", "1.234,5", DecimalAuto, 1234.5, false},
+		{"Auto thousands with dot decimal", "1,234.5", DecimalAuto, 1234.5, false},
+		{"Auto comma followed by three digits is ambiguous", "1,000", DecimalAuto, 0, true},
+		{"Auto dot followed by three digits is ambiguous", "1.000", DecimalAuto, 0, true},
+		{"Auto zero can't group thousands", "0.125", DecimalAuto, 0.125, false},
+		{"Auto four digits can't group thousands", "1234,567", DecimalAuto, 1234.567, false},
+		{"Auto comma decimal with two digits", "1,00", DecimalAuto, 1, false},
+		{"Auto repeated comma groups thousands", "1,000,000", DecimalAuto, 1000000, false},
+		{"Auto repeated dot groups thousands", "1.000.000", DecimalAuto, 1000000, false},
+		{"Auto repeated dot without thousands", "1.5.3", DecimalAuto, 0, true},
+		{"Dot separator with thousands", "1,000", DecimalDot, 1000, false},
+		{"Dot separator with thousands and decimals", "1,234,567.5", DecimalDot, 1234567.5, false},
+		{"Dot separator rejects a decimal comma", "2,5", DecimalDot, 0, true},
+		{"Comma separator", "0,5", DecimalComma, 0.5, false},
+		{"Comma separator with thousands", "1.000", DecimalComma, 1000, false},
+		{"Comma separator with thousands and decimals", "1.234,5", DecimalComma, 1234.5, false},
+		{"Comma separator rejects a decimal dot", "1.5", DecimalComma, 0, true},
+		{"Comma separator rejects a dot in the decimals", "1,5.000", DecimalComma, 0, true},
+		{"Not a number", "abc", DecimalAuto, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDecimal(tt.input, tt.sep)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseDecimal() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if !tt.expectErr && got != tt.expected {
+				t.Errorf("ParseDecimal(%q, %s) = %v, want %v", tt.input, tt.sep, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDecimal_Ambiguous(t *testing.T) {
+	_, err := ParseDecimal("1,000", DecimalAuto)
+	if !errors.Is(err, ErrAmbiguousDecimal) {
+		t.Errorf("ParseDecimal(\"1,000\") error = %v, want ErrAmbiguousDecimal", err)
+	}
+	if _, err := ParseDecimal("1.000", DecimalAuto); !errors.Is(err, ErrAmbiguousDecimal) {
+		t.Errorf("ParseDecimal(\"1.000\") error = %v, want ErrAmbiguousDecimal", err)
+	}
+	if got, err := ParseDecimal("1,000", DecimalComma); err != nil || got != 1 {
+		t.Errorf("ParseDecimal(\"1,000\", comma) = %v, %v; want 1", got, err)
+	}
+}
+
+func TestParseDecimal_UnexpectedSeparator(t *testing.T) {
+	if _, err := ParseDecimal("1.5", DecimalComma); !errors.Is(err, ErrUnexpectedSeparator) {
+		t.Errorf("ParseDecimal(\"1.5\", comma) error = %v, want ErrUnexpectedSeparator", err)
+	}
+	if _, err := ParseDecimal("2,5", DecimalDot); !errors.Is(err, ErrUnexpectedSeparator) {
+		t.Errorf("ParseDecimal(\"2,5\", dot) error = %v, want ErrUnexpectedSeparator", err)
+	}
+}
+
+func TestParseDecimalSeparator(t *testing.T) {
+	for _, s := range []string{"auto", "dot", "comma"} {
+		if _, err := ParseDecimalSeparator(s); err != nil {
+			t.Errorf("ParseDecimalSeparator(%q) error = %v", s, err)
+		}
+	}
+	if _, err := ParseDecimalSeparator("period"); err == nil {
+		t.Errorf("ParseDecimalSeparator(\"period\") should fail")
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EstimateMapping maps non-numeric estimates (e.g. t-shirt sizes) to point values.
+// Keys are stored in upper case so lookups are case-insensitive.
+type EstimateMapping map[string]float64
+
+// ParseEstimateMapping parses a list like "XS=1,S=2,M=3,L=5,XL=8"
+func ParseEstimateMapping(s string) (EstimateMapping, error) {
+	mapping := make(EstimateMapping)
+	if strings.TrimSpace(s) == "" {
+		return mapping, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		size, pointsStr, ok := strings.Cut(part, "=")
+		size = strings.TrimSpace(size)
+		if !ok || size == "" {
+			return nil, fmt.Errorf("invalid estimate mapping: %s (expected size=points)", part)
+		}
+
+		points, err := strconv.ParseFloat(strings.TrimSpace(pointsStr), 64)
+		if err != nil || points < 0 {
+			return nil, fmt.Errorf("invalid estimate mapping: %s (points must be a non-negative number)", part)
+		}
+
+		mapping[strings.ToUpper(size)] = points
+	}
+
+	return mapping, nil
+}
+
+// Lookup returns the point value for a non-numeric estimate
+func (m EstimateMapping) Lookup(estimate string) (float64, bool) {
+	points, ok := m[strings.ToUpper(strings.TrimSpace(estimate))]
+	return points, ok
+}
+
+// String formats the mapping in the form accepted by ParseEstimateMapping, ordered by points
+func (m EstimateMapping) String() string {
+	sizes := make([]string, 0, len(m))
+	for size := range m {
+		sizes = append(sizes, size)
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		if m[sizes[i]] != m[sizes[j]] {
+			return m[sizes[i]] < m[sizes[j]]
+		}
+		return sizes[i] < sizes[j]
+	})
+
+	parts := make([]string, 0, len(sizes))
+	for _, size := range sizes {
+		parts = append(parts, fmt.Sprintf("%s=%s", size, strconv.FormatFloat(m[size], 'f', -1, 64)))
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseEstimate converts an estimate to points, trying a number written with the given
+// decimal separator first and then the mapping.
+// It reports false for a non-empty estimate that is neither numeric nor mapped.
+func ParseEstimate(estimate string, mapping EstimateMapping, sep DecimalSeparator) (float64, bool) {
+	estimate = strings.TrimSpace(estimate)
+	if estimate == "" {
+		return 0, true
+	}
+	if val, err := ParseDecimal(estimate, sep); err == nil {
+		return val, true
+	}
+	if points, ok := mapping.Lookup(estimate); ok {
+		return points, true
+	}
+	return 0, false
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestParseEstimateMapping(t *testing.T) {
+	mapping, err := ParseEstimateMapping("XS=1, s=2,M=3,L=5,XL=8")
+	if err != nil {
+		t.Fatalf("ParseEstimateMapping() error = %v", err)
+	}
+	if len(mapping) != 5 {
+		t.Errorf("ParseEstimateMapping() returned %d entries, want 5", len(mapping))
+	}
+	if mapping["S"] != 2 {
+		t.Errorf("Expected lower-case size to be stored upper case, got %v", mapping)
+	}
+	if got := mapping.String(); got != "XS=1,S=2,M=3,L=5,XL=8" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, input := range []string{"XS", "=1", "M=big", "L=-1"} {
+		if _, err := ParseEstimateMapping(input); err == nil {
+			t.Errorf("ParseEstimateMapping(%q) should fail", input)
+		}
+	}
+}
+
+func TestParseEstimate(t *testing.T) {
+	mapping := EstimateMapping{"S": 2, "M": 3}
+
+	tests := []struct {
+		input    string
+		expected float64
+		ok       bool
+	}{
+		{"", 0, true},
+		{"5", 5, true},
+		{"2.5", 2.5, true},
+		{"2,5", 2.5, true},
+		{"m", 3, true},
+		{" S ", 2, true},
+		{"XL", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseEstimate(tt.input, mapping, DecimalAuto)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("ParseEstimate(%q) = %v, %v; want %v, %v", tt.input, got, ok, tt.expected, tt.ok)
+		}
+	}
+
+	if _, ok := ParseEstimate("M", nil, DecimalAuto); ok {
+		t.Errorf("ParseEstimate() without a mapping should not recognise sizes")
+	}
+}
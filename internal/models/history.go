@@ -0,0 +1,72 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// StateTransition records an item moving from one workflow state to another
+type StateTransition struct {
+	From string
+	To   string
+	At   time.Time
+}
+
+// StateHistory maps item IDs to their state transitions, oldest first
+type StateHistory map[string][]StateTransition
+
+// Add records a transition for an item, keeping the item's transitions in time order
+func (h StateHistory) Add(itemID string, transition StateTransition) {
+	transitions := append(h[itemID], transition)
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].At.Before(transitions[j].At)
+	})
+	h[itemID] = transitions
+}
+
+// Apply attaches each item's transitions and returns the items with the number
+// of items that have a history
+func (h StateHistory) Apply(items []KanbanItem) ([]KanbanItem, int) {
+	matched := 0
+	for i := range items {
+		if transitions, ok := h[items[i].ID]; ok {
+			items[i].History = transitions
+			matched++
+		}
+	}
+	return items, matched
+}
+
+// HasHistory reports whether state transitions are known for the item
+func (item KanbanItem) HasHistory() bool {
+	return len(item.History) > 0
+}
+
+// StateAt returns the state the item was in at t, or "" before its first transition
+func (item KanbanItem) StateAt(t time.Time) string {
+	state := ""
+	for _, transition := range item.History {
+		if transition.At.After(t) {
+			break
+		}
+		state = transition.To
+	}
+	return state
+}
+
+// StateDurations returns the days spent in each state from the first
+// transition until end. The last state entered before end runs until end.
+func (item KanbanItem) StateDurations(end time.Time) map[string]float64 {
+	durations := make(map[string]float64)
+	for i, transition := range item.History {
+		if !transition.At.Before(end) {
+			break
+		}
+		leave := end
+		if i+1 < len(item.History) && item.History[i+1].At.Before(end) {
+			leave = item.History[i+1].At
+		}
+		durations[transition.To] += leave.Sub(transition.At).Hours() / 24
+	}
+	return durations
+}
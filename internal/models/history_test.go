@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStateHistory(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	history := make(StateHistory)
+	// Added out of order; Add keeps them sorted
+	history.Add("1", StateTransition{From: "In Progress", To: "Review", At: day(5)})
+	history.Add("1", StateTransition{From: "Ready", To: "In Progress", At: day(2)})
+	history.Add("1", StateTransition{From: "Review", To: "Done", At: day(6)})
+
+	items, matched := history.Apply([]KanbanItem{{ID: "1"}, {ID: "2"}})
+	if matched != 1 || !items[0].HasHistory() || items[1].HasHistory() {
+		t.Fatalf("Apply() matched %d items, want only item 1", matched)
+	}
+	item := items[0]
+
+	stateTests := map[time.Time]string{
+		day(1): "",
+		day(2): "In Progress",
+		day(4): "In Progress",
+		day(5): "Review",
+		day(9): "Done",
+	}
+	for at, want := range stateTests {
+		if got := item.StateAt(at); got != want {
+			t.Errorf("StateAt(%s) = %q, want %q", at.Format("2006-01-02"), got, want)
+		}
+	}
+
+	durations := item.StateDurations(day(6))
+	if durations["In Progress"] != 3 || durations["Review"] != 1 {
+		t.Errorf("StateDurations() = %v, want In Progress 3 and Review 1", durations)
+	}
+	if _, ok := durations["Done"]; ok {
+		t.Errorf("StateDurations() counted a state entered at the end: %v", durations)
+	}
+}
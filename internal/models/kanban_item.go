@@ -2,7 +2,9 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -60,9 +62,35 @@ type KanbanItem struct {
 	Priority             string
 	Severity             string
 	ProductArea          string
+	ProductAreas         []string
 	SkillSet             string
 	TechnicalArea        string
 	CustomFields         map[string]string
+	Category             string // Assigned by classification rules, not read from the CSV
+	History              []StateTransition // From the state history file, oldest first
+	Source               Source // Where the item was read from, not a CSV column
+}
+
+// Source is the CSV row an item was read from, to trace a result back to the export
+type Source struct {
+	File string `json:"file"`
+	Row  int    `json:"row"`  // Data row number, starting at 1 for the first row after the header
+	Line int    `json:"line"` // Line of the file the row starts on; the header is line 1
+}
+
+// String formats the source as file:line, which editors can jump to
+func (s Source) String() string {
+	return fmt.Sprintf("%s:%d", s.File, s.Line)
+}
+
+// SourceRef returns where the item was read from, or nil for items that
+// weren't read from a CSV file
+func (item KanbanItem) SourceRef() *Source {
+	if item.Source.File == "" {
+		return nil
+	}
+	source := item.Source
+	return &source
 }
 
 // ParseTime attempts to parse time in the format provided by the CSV
@@ -75,12 +103,10 @@ func ParseTime(timeStr string) (time.Time, error) {
 	return time.Parse("2006/01/02 15:04:05", timeStr)
 }
 
-// ParseBool converts string to bool, handling empty strings
+// ParseBool converts string to bool using the default truthy values, handling empty strings
 func ParseBool(boolStr string) bool {
-	if strings.ToUpper(boolStr) == "TRUE" {
-		return true
-	}
-	return false
+	value, _ := defaultBoolTokens.Parse(boolStr)
+	return value
 }
 
 // ParseFloat converts string to float64, handling empty strings
@@ -115,33 +141,101 @@ func ParseStringList(listStr string) []string {
 	return strings.Split(listStr, ",")
 }
 
-// ParseExternalTickets processes the JSON-like string of external tickets
+// ParseProductAreas splits a product area value that may hold several areas separated by semicolons
+func ParseProductAreas(areaStr string) []string {
+	areas := []string{}
+	for _, area := range strings.Split(areaStr, ";") {
+		if area = strings.TrimSpace(area); area != "" {
+			areas = append(areas, area)
+		}
+	}
+	return areas
+}
+
+// GetProductAreas returns the item's product areas, parsing ProductArea when the list wasn't populated
+func (item KanbanItem) GetProductAreas() []string {
+	if len(item.ProductAreas) > 0 {
+		return item.ProductAreas
+	}
+	return ParseProductAreas(item.ProductArea)
+}
+
+// ticketRefPattern matches plain ticket references such as "JIRA-123", "repo#42" or URLs
+var ticketRefPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*-\d+|[\w./-]*#\d+|https?://\S+)$`)
+
+// ParseExternalTickets processes the JSON-like string of external tickets,
+// returning an empty slice for values that can't be parsed
 func ParseExternalTickets(ticketsStr string) []string {
-	// Remove the "#" prefix if present
-	if strings.HasPrefix(ticketsStr, "#") {
-		ticketsStr = ticketsStr[1:]
+	tickets, err := ParseExternalTicketsStrict(ticketsStr)
+	if err != nil {
+		return []string{}
+	}
+	return tickets
+}
+
+// ParseExternalTicketsStrict parses external tickets and reports values it can't understand.
+// It accepts JSON objects (ticket IDs as keys, e.g. Shortcut's `#{"JIRA-1":1}`), JSON arrays,
+// either with single or double quotes and after any prefix, and plain lists of ticket references.
+func ParseExternalTicketsStrict(ticketsStr string) ([]string, error) {
+	ticketsStr = strings.TrimSpace(ticketsStr)
+	if ticketsStr == "" {
+		return []string{}, nil
 	}
 	
-	// If empty or not JSON format, return empty slice
-	if ticketsStr == "" || !strings.HasPrefix(ticketsStr, "{") || !strings.HasSuffix(ticketsStr, "}") {
-		return []string{}
+	// Skip any prefix such as "#" before the JSON value
+	if start := strings.IndexAny(ticketsStr, "{["); start >= 0 {
+		if tickets, ok := parseTicketsJSON(ticketsStr[start:]); ok {
+			return tickets, nil
+		}
+		return []string{}, fmt.Errorf("unparsable external tickets: %s", ticketsStr)
 	}
 	
-	var tickets map[string]interface{}
-	err := json.Unmarshal([]byte(ticketsStr), &tickets)
-	if err != nil {
-		return []string{}
+	// Fall back to a plain list of ticket references
+	result := []string{}
+	for _, ref := range regexp.MustCompile(`[,;\s]+`).Split(ticketsStr, -1) {
+		ref = strings.Trim(ref, `"'`)
+		if ref == "" {
+			continue
+		}
+		if !ticketRefPattern.MatchString(ref) {
+			return []string{}, fmt.Errorf("unparsable external tickets: %s", ticketsStr)
+		}
+		result = append(result, ref)
 	}
 	
-	// Extract keys as ticket IDs/URLs
-	result := make([]string, 0, len(tickets))
-	for key := range tickets {
-		if key != "" { 
-			result = append(result, key)
+	return result, nil
+}
+
+// parseTicketsJSON extracts tickets from a JSON object's keys or a JSON array,
+// retrying with single quotes converted to double quotes
+func parseTicketsJSON(jsonStr string) ([]string, bool) {
+	for _, candidate := range []string{jsonStr, strings.ReplaceAll(jsonStr, "'", `"`)} {
+		var tickets map[string]interface{}
+		if err := json.Unmarshal([]byte(candidate), &tickets); err == nil {
+			// Extract keys as ticket IDs/URLs
+			result := make([]string, 0, len(tickets))
+			for key := range tickets {
+				if key != "" {
+					result = append(result, key)
+				}
+			}
+			sort.Strings(result)
+			return result, true
+		}
+		
+		var list []interface{}
+		if err := json.Unmarshal([]byte(candidate), &list); err == nil {
+			result := make([]string, 0, len(list))
+			for _, entry := range list {
+				if ref := strings.TrimSpace(fmt.Sprint(entry)); ref != "" {
+					result = append(result, ref)
+				}
+			}
+			return result, true
 		}
 	}
 	
-	return result
+	return nil, false
 }
 
 // ParseOwners splits owner string into individual owners
@@ -60,6 +60,13 @@ func TestParseBool(t *testing.T) {
 		{"false value", "false", false},
 		{"Empty string", "", false},
 		{"Random string", "random", false},
+		{"One", "1", true},
+		{"Zero", "0", false},
+		{"yes value", "yes", true},
+		{"Y value", "Y", true},
+		{"x marker", "x", true},
+		{"Check mark", "✓", true},
+		{"no value", "no", false},
 	}
 
 	for _, tt := range tests {
@@ -242,4 +249,90 @@ func TestParseCustomFields(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestParseProductAreas(t *testing.T) {
+	tests := []struct {
+		name    string
+		areaStr string
+		want    []string
+	}{
+		{"Single area", "Backend", []string{"Backend"}},
+		{"Semicolon separated", "Backend; Frontend;Mobile", []string{"Backend", "Frontend", "Mobile"}},
+		{"Empty entries dropped", "Backend;;", []string{"Backend"}},
+		{"Empty string", "", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseProductAreas(tt.areaStr)
+			if len(got) != len(tt.want) {
+				t.Errorf("ParseProductAreas() = %v, want %v", got, tt.want)
+				return
+			}
+			for i, v := range got {
+				if v != tt.want[i] {
+					t.Errorf("ParseProductAreas()[%d] = %v, want %v", i, v, tt.want[i])
+				}
+			}
+		})
+	}
+
+	// GetProductAreas falls back to parsing ProductArea
+	item := KanbanItem{ProductArea: "Backend;Frontend"}
+	if got := item.GetProductAreas(); len(got) != 2 {
+		t.Errorf("GetProductAreas() = %v, want 2 areas", got)
+	}
+}
+
+func TestParseExternalTicketsStrict(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      []string
+		expectErr bool
+	}{
+		{"Empty", "", []string{}, false},
+		{"Shortcut prefixed object", `#{"JIRA-1":1,"JIRA-2":1}`, []string{"JIRA-1", "JIRA-2"}, false},
+		{"Other prefix", `tickets: {"JIRA-3":1}`, []string{"JIRA-3"}, false},
+		{"Single quotes", `#{'JIRA-4':1}`, []string{"JIRA-4"}, false},
+		{"JSON array", `["JIRA-5", "https://github.com/org/repo/issues/6"]`, []string{"JIRA-5", "https://github.com/org/repo/issues/6"}, false},
+		{"Plain list", "JIRA-7, JIRA-8; repo#9", []string{"JIRA-7", "JIRA-8", "repo#9"}, false},
+		{"Broken JSON", `#{"JIRA-1":`, []string{}, true},
+		{"Free text", "invalid-json", []string{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExternalTicketsStrict(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseExternalTicketsStrict() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseExternalTicketsStrict() = %v, want %v", got, tt.want)
+			}
+			for i, v := range got {
+				if v != tt.want[i] {
+					t.Errorf("ParseExternalTicketsStrict()[%d] = %v, want %v", i, v, tt.want[i])
+				}
+			}
+		})
+	}
+
+	// The lenient variant drops unparsable values
+	if got := ParseExternalTickets("invalid-json"); len(got) != 0 {
+		t.Errorf("ParseExternalTickets() = %v, want empty", got)
+	}
+}
+
+func TestKanbanItem_SourceRef(t *testing.T) {
+	item := KanbanItem{ID: "1", Source: Source{File: "data/export.csv", Row: 4, Line: 6}}
+	source := item.SourceRef()
+	if source == nil || source.String() != "data/export.csv:6" || source.Row != 4 {
+		t.Errorf("SourceRef() = %v, want data/export.csv:6 for row 4", source)
+	}
+
+	// Items not read from a CSV file have no source
+	if source := (KanbanItem{ID: "2"}).SourceRef(); source != nil {
+		t.Errorf("SourceRef() = %v, want nil", source)
+	}
+}
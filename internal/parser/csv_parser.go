@@ -1,14 +1,19 @@
 package parser
 
 import (
+	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/quality"
+	"github.com/hannasdev/kanban-reports/pkg/terminal"
 )
 
 const (
@@ -19,12 +24,30 @@ const (
 var (
 	// RequiredColumns are the minimum columns needed for parsing
 	RequiredColumns = []string{"id", "name", "estimate", "is_completed", "completed_at"}
+
+	// ErrTooManyRowErrors is returned when more rows fail to parse than the configured maximum
+	ErrTooManyRowErrors = errors.New("too many rows failed to parse")
 )
 
 // CSVParser handles parsing of kanban CSV data
 type CSVParser struct {
-	filepath  string
-	delimiter models.DelimiterType
+	filepath         string
+	delimiter        models.DelimiterType
+	columnMap        models.ColumnMap
+	estimateMapping  models.EstimateMapping
+	boolTokens       models.BoolTokens
+	decimalSeparator models.DecimalSeparator
+	dateFormat       models.DateFormat
+	unknownEstimates map[string]int
+	unknownBools     map[string]int
+	issues           []quality.Issue
+	rowNumber        int
+	line             int // Of the file, where the current row starts
+	maxErrors        int
+	rowsRead         int
+	rowErrors        int
+	strings          interner  // Shared copies of the repeated values of the current parse
+	out              io.Writer // Progress and warnings; nil for standard output
 }
 
 // NewCSVParser creates a new CSV parser for the specified file
@@ -32,6 +55,13 @@ func NewCSVParser(filepath string) *CSVParser {
 	return &CSVParser{
 		filepath:  filepath,
 		delimiter: models.DelimiterComma, // Default to comma delimiter
+		boolTokens: models.DefaultBoolTokens(),
+		decimalSeparator: models.DecimalAuto,
+		dateFormat: models.DefaultDateFormat,
+		unknownEstimates: make(map[string]int),
+		unknownBools: make(map[string]int),
+		strings: make(interner),
+		maxErrors: -1, // No limit
 	}
 }
 
@@ -41,8 +71,71 @@ func (p *CSVParser) WithDelimiter(delimiter models.DelimiterType) *CSVParser {
 	return p
 }
 
+// WithColumnMap sets the mapping from export column names to the expected column names
+func (p *CSVParser) WithColumnMap(columnMap models.ColumnMap) *CSVParser {
+	p.columnMap = columnMap
+	return p
+}
+
+// WithEstimateMapping sets the mapping used for non-numeric estimates such as t-shirt sizes
+func (p *CSVParser) WithEstimateMapping(mapping models.EstimateMapping) *CSVParser {
+	p.estimateMapping = mapping
+	return p
+}
+
+// WithBoolTokens sets the values recognised as true and false in boolean columns
+func (p *CSVParser) WithBoolTokens(tokens models.BoolTokens) *CSVParser {
+	p.boolTokens = tokens
+	return p
+}
+
+// WithDecimalSeparator sets the decimal separator used by numeric estimates
+func (p *CSVParser) WithDecimalSeparator(sep models.DecimalSeparator) *CSVParser {
+	if sep == "" {
+		sep = models.DecimalAuto
+	}
+	p.decimalSeparator = sep
+	return p
+}
+
+// WithDateFormat sets the layouts timestamps are read with; nil keeps the default
+func (p *CSVParser) WithDateFormat(format models.DateFormat) *CSVParser {
+	if len(format) > 0 {
+		p.dateFormat = format
+	}
+	return p
+}
+
+// WithOutput sets where progress and warnings are written; io.Discard
+// silences them
+func (p *CSVParser) WithOutput(w io.Writer) *CSVParser {
+	p.out = w
+	return p
+}
+
+// output returns where progress and warnings are written
+func (p *CSVParser) output() io.Writer {
+	if p.out != nil {
+		return p.out
+	}
+	return terminal.Stdout()
+}
+
+// WithMaxErrors sets how many rows may fail to parse before parsing is aborted.
+// A negative value disables the limit.
+func (p *CSVParser) WithMaxErrors(maxErrors int) *CSVParser {
+	p.maxErrors = maxErrors
+	return p
+}
+
 // Parse reads the CSV file and returns a slice of KanbanItem
 func (p *CSVParser) Parse() ([]models.KanbanItem, error) {
+	return p.ParseContext(context.Background())
+}
+
+// ParseContext parses the CSV file like Parse, stopping with the context's
+// error when it is cancelled or times out
+func (p *CSVParser) ParseContext(ctx context.Context) ([]models.KanbanItem, error) {
 	file, err := p.openAndPrepareFile()
 	if err != nil {
 		return nil, err
@@ -60,15 +153,29 @@ func (p *CSVParser) Parse() ([]models.KanbanItem, error) {
 		return nil, err
 	}
 
-	items, err := p.parseDataRows(reader, colIndices)
+	p.unknownEstimates = make(map[string]int)
+	p.unknownBools = make(map[string]int)
+	p.issues = nil
+	p.rowsRead = 0
+	p.rowErrors = 0
+	p.strings = make(interner)
+	items, err := p.parseDataRows(ctx, reader, colIndices)
 	if err != nil {
 		return nil, err
 	}
+	if err := p.checkMaxErrors(); err != nil {
+		return nil, err
+	}
+	p.reportUnknownEstimates()
+	p.reportUnknownBools()
 
-	fmt.Printf("✅ Loaded %d kanban items\n", len(items))
+	fmt.Fprintf(p.output(), "✅ Loaded %d kanban items\n", len(items))
 	return items, nil
 }
 
+// byteOrderMark starts UTF-8 files saved by some Windows programs
+const byteOrderMark = "\ufeff"
+
 // openAndPrepareFile opens the CSV file and handles delimiter detection
 func (p *CSVParser) openAndPrepareFile() (*os.File, error) {
 	file, err := os.Open(p.filepath)
@@ -119,7 +226,7 @@ func (p *CSVParser) detectDelimiter(file *os.File) error {
 	
 	sampleContent := string(buffer[:n])
 	p.delimiter = models.DetectDelimiterType(sampleContent)
-	fmt.Printf("Detected %s-delimited CSV\n", p.delimiter.Name)
+	fmt.Fprintf(p.output(), "Detected %s-delimited CSV\n", p.delimiter.Name)
 	
 	return nil
 }
@@ -138,14 +245,18 @@ func (p *CSVParser) parseHeaders(reader *csv.Reader) ([]string, map[string]int,
 	if err != nil {
 		return nil, nil, fmt.Errorf("error reading CSV header: %w", err)
 	}
+	// Excel on Windows starts UTF-8 exports with a byte order mark
+	if len(headers) > 0 {
+		headers[0] = strings.TrimPrefix(headers[0], byteOrderMark)
+	}
 
 	// Create column index map for fast lookup
 	colIndices := make(map[string]int)
 	for i, header := range headers {
-		colIndices[strings.TrimSpace(header)] = i
+		colIndices[p.columnMap.Resolve(strings.TrimSpace(header))] = i
 	}
 
-	fmt.Println("Found columns:", strings.Join(headers, ", "))
+	fmt.Fprintln(p.output(), "Found columns:", strings.Join(headers, ", "))
 	return headers, colIndices, nil
 }
 
@@ -160,11 +271,15 @@ func (p *CSVParser) validateRequiredColumns(colIndices map[string]int) error {
 }
 
 // parseDataRows reads and parses all data rows from the CSV
-func (p *CSVParser) parseDataRows(reader *csv.Reader, colIndices map[string]int) ([]models.KanbanItem, error) {
+func (p *CSVParser) parseDataRows(ctx context.Context, reader *csv.Reader, colIndices map[string]int) ([]models.KanbanItem, error) {
 	var items []models.KanbanItem
 	rowNumber := 1 // Start at 1 since we already read the header
 	
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		p.rowNumber = rowNumber
 		row, err := reader.Read()
 		if err == io.EOF {
 			break
@@ -172,15 +287,21 @@ func (p *CSVParser) parseDataRows(reader *csv.Reader, colIndices map[string]int)
 		if err != nil {
 			return nil, fmt.Errorf("error reading CSV row %d: %w", rowNumber, err)
 		}
+		// Quoted values can span lines, so rows don't map to lines one to one
+		p.line, _ = reader.FieldPos(0)
 
+		p.rowsRead++
 		item, err := p.parseRow(row, colIndices)
 		if err != nil {
+			p.rowErrors++
 			// Log warning but continue processing
-			fmt.Printf("Warning: error parsing row %d: %v\n", rowNumber, err)
+			fmt.Fprintf(p.output(), "Warning: error parsing row %d: %v\n", rowNumber, err)
+			p.addIssue("", "", err.Error())
 			rowNumber++
 			continue
 		}
 
+		item.Source = models.Source{File: p.filepath, Row: rowNumber, Line: p.line}
 		items = append(items, item)
 		rowNumber++
 	}
@@ -188,6 +309,15 @@ func (p *CSVParser) parseDataRows(reader *csv.Reader, colIndices map[string]int)
 	return items, nil
 }
 
+// checkMaxErrors fails the parse when more rows were rejected than allowed
+func (p *CSVParser) checkMaxErrors() error {
+	if p.maxErrors < 0 || p.rowErrors <= p.maxErrors {
+		return nil
+	}
+	return fmt.Errorf("%w: %d of %d rows could not be parsed (maximum allowed: %d)",
+		ErrTooManyRowErrors, p.rowErrors, p.rowsRead, p.maxErrors)
+}
+
 // parseRow converts a CSV row into a KanbanItem
 func (p *CSVParser) parseRow(row []string, colIndices map[string]int) (models.KanbanItem, error) {
 	item := models.KanbanItem{}
@@ -221,6 +351,7 @@ func (p *CSVParser) parseRow(row []string, colIndices map[string]int) (models.Ka
 	// Parse organizational fields
 	p.parseOrganizationalFields(&item, getCol)
 
+	p.strings.internItem(&item)
 	return item, nil
 }
 
@@ -238,6 +369,7 @@ func (p *CSVParser) parseBasicFields(item *models.KanbanItem, getCol func(string
 	item.Priority = getCol("priority")
 	item.Severity = getCol("severity")
 	item.ProductArea = getCol("product_area")
+	item.ProductAreas = models.ParseProductAreas(item.ProductArea)
 	item.SkillSet = getCol("skill_set")
 	item.TechnicalArea = getCol("technical_area")
 
@@ -272,7 +404,7 @@ func (p *CSVParser) parseTimestamps(item *models.KanbanItem, getCol func(string)
 
 	for fieldName, timePtr := range timestampFields {
 		if timeStr := getCol(fieldName); timeStr != "" {
-			if parsedTime, err := models.ParseTime(timeStr); err == nil {
+			if parsedTime, err := p.dateFormat.Parse(timeStr); err == nil {
 				*timePtr = parsedTime
 			}
 			// Ignore parse errors for optional timestamp fields
@@ -285,26 +417,139 @@ func (p *CSVParser) parseTimestamps(item *models.KanbanItem, getCol func(string)
 // parseNumericFields parses numeric fields with validation
 func (p *CSVParser) parseNumericFields(item *models.KanbanItem, getCol func(string) string) error {
 	// Parse boolean fields
-	item.IsCompleted = models.ParseBool(getCol("is_completed"))
-	item.IsBlocked = models.ParseBool(getCol("is_blocked"))
-	item.IsABlocker = models.ParseBool(getCol("is_a_blocker"))
-	item.IsArchived = models.ParseBool(getCol("is_archived"))
-	item.EpicIsArchived = models.ParseBool(getCol("epic_is_archived"))
+	item.IsCompleted = p.parseBool("is_completed", getCol("is_completed"))
+	item.IsBlocked = p.parseBool("is_blocked", getCol("is_blocked"))
+	item.IsABlocker = p.parseBool("is_a_blocker", getCol("is_a_blocker"))
+	item.IsArchived = p.parseBool("is_archived", getCol("is_archived"))
+	item.EpicIsArchived = p.parseBool("epic_is_archived", getCol("epic_is_archived"))
 
 	// Parse numeric fields
-	item.Estimate = models.ParseFloat(getCol("estimate"))
+	estimate, ok := models.ParseEstimate(getCol("estimate"), p.estimateMapping, p.decimalSeparator)
+	if !ok {
+		p.unknownEstimates[strings.ToUpper(getCol("estimate"))]++
+		switch err := p.decimalError(getCol("estimate")); {
+		case errors.Is(err, models.ErrAmbiguousDecimal):
+			p.addIssue("estimate", getCol("estimate"), "estimate with an ambiguous decimal separator counted as 0")
+		case errors.Is(err, models.ErrUnexpectedSeparator):
+			p.addIssue("estimate", getCol("estimate"), fmt.Sprintf("estimate that doesn't match --decimal-separator %s counted as 0", p.decimalSeparator))
+		default:
+			p.addIssue("estimate", getCol("estimate"), "non-numeric estimate counted as 0")
+		}
+	}
+	item.Estimate = estimate
 	item.ExternalTicketCount = models.ParseInt(getCol("external_ticket_count"))
 
 	return nil
 }
 
+// parseBool converts a boolean column value, counting values that match no known token
+func (p *CSVParser) parseBool(column, value string) bool {
+	result, recognized := p.boolTokens.Parse(value)
+	if !recognized {
+		p.unknownBools[value]++
+		p.addIssue(column, value, "unrecognised boolean treated as false")
+	}
+	return result
+}
+
+// reportUnknownBools warns about boolean values that were neither truthy nor falsy
+func (p *CSVParser) reportUnknownBools() {
+	if len(p.unknownBools) == 0 {
+		return
+	}
+
+	var values []string
+	total := 0
+	for value, count := range p.unknownBools {
+		values = append(values, fmt.Sprintf("%q (%d)", value, count))
+		total += count
+	}
+	sort.Strings(values)
+
+	fmt.Fprintf(p.output(), "Warning: %d boolean values were not recognised and were treated as false: %s\n", total, strings.Join(values, ", "))
+	fmt.Fprintf(p.output(), "         Add them with --truthy or --falsy, e.g. --truthy \"true,yes,done\"\n")
+}
+
+// UnknownBools returns how often each unrecognised boolean value was seen in the last parse
+func (p *CSVParser) UnknownBools() map[string]int {
+	return p.unknownBools
+}
+
+// reportUnknownEstimates warns about estimates that were neither numeric nor mapped
+func (p *CSVParser) reportUnknownEstimates() {
+	if len(p.unknownEstimates) == 0 {
+		return
+	}
+
+	var values, ambiguous, mismatched []string
+	total := 0
+	for value, count := range p.unknownEstimates {
+		values = append(values, fmt.Sprintf("%s (%d)", value, count))
+		total += count
+		switch err := p.decimalError(value); {
+		case errors.Is(err, models.ErrAmbiguousDecimal):
+			ambiguous = append(ambiguous, value)
+		case errors.Is(err, models.ErrUnexpectedSeparator):
+			mismatched = append(mismatched, value)
+		}
+	}
+	sort.Strings(values)
+	sort.Strings(ambiguous)
+	sort.Strings(mismatched)
+
+	fmt.Fprintf(p.output(), "Warning: %d items have non-numeric estimates that were counted as 0: %s\n", total, strings.Join(values, ", "))
+	if len(ambiguous) > 0 {
+		fmt.Fprintf(p.output(), "         %s could use the separator for thousands or decimals; choose with --decimal-separator dot or comma\n", strings.Join(ambiguous, ", "))
+	}
+	if len(mismatched) > 0 {
+		fmt.Fprintf(p.output(), "         %s don't use the decimal separator of --decimal-separator %s; check the column or choose another\n", strings.Join(mismatched, ", "), p.decimalSeparator)
+	}
+	if len(ambiguous)+len(mismatched) < len(p.unknownEstimates) {
+		fmt.Fprintf(p.output(), "         Map them to points with --estimate-map, e.g. --estimate-map \"XS=1,S=2,M=3,L=5,XL=8\"\n")
+	}
+}
+
+// decimalError returns why an estimate isn't a number with the decimal
+// separator, such as models.ErrAmbiguousDecimal for "1,000"
+func (p *CSVParser) decimalError(value string) error {
+	_, err := models.ParseDecimal(value, p.decimalSeparator)
+	return err
+}
+
+// UnknownEstimates returns how often each unrecognised estimate value was seen in the last parse
+func (p *CSVParser) UnknownEstimates() map[string]int {
+	return p.unknownEstimates
+}
+
+// addIssue records a data-quality issue for the row currently being parsed
+func (p *CSVParser) addIssue(column, value, message string) {
+	p.issues = append(p.issues, quality.Issue{
+		Source:  quality.SourceParser,
+		File:    p.filepath,
+		Row:     p.rowNumber,
+		Line:    p.line,
+		Column:  column,
+		Value:   value,
+		Message: message,
+	})
+}
+
+// Issues returns the data-quality issues found in the last parse
+func (p *CSVParser) Issues() []quality.Issue {
+	return p.issues
+}
+
 // parseCollectionFields parses array and map fields
 func (p *CSVParser) parseCollectionFields(item *models.KanbanItem, getCol func(string) string) {
 	item.Owners = models.ParseOwners(getCol("owners"))
 	item.Labels = models.ParseStringList(getCol("labels"))
 	item.EpicLabels = models.ParseStringList(getCol("epic_labels"))
 	item.Tasks = models.ParseStringList(getCol("tasks"))
-	item.ExternalTickets = models.ParseExternalTickets(getCol("external_tickets"))
+	tickets, err := models.ParseExternalTicketsStrict(getCol("external_tickets"))
+	if err != nil {
+		p.addIssue("external_tickets", getCol("external_tickets"), "unparsable external tickets ignored")
+	}
+	item.ExternalTickets = tickets
 	item.MilestoneCategories = models.ParseStringList(getCol("milestone_categories"))
 	item.CustomFields = models.ParseCustomFields(getCol("custom_fields"))
 }
@@ -2,9 +2,16 @@
 package parser
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
 )
@@ -394,8 +401,8 @@ func TestCSVParser_DataTypeHandling(t *testing.T) {
 2,Task 2,2,1,2024/05/02 10:00:00,0,1`,
 			itemIndex: 0,
 			validate: func(item models.KanbanItem) bool {
-				// Invalid boolean should default to false
-				return !item.IsCompleted && !item.IsBlocked && !item.IsABlocker
+				// Unrecognised values default to false; yes/no are recognised
+				return !item.IsCompleted && item.IsBlocked && !item.IsABlocker
 			},
 		},
 		{
@@ -486,6 +493,12 @@ func TestCSVParser_DelimiterHandling(t *testing.T) {
 			delimiter:  models.DelimiterSemicolon,
 			expectRows: 1,
 		},
+		{
+			name:       "Windows line endings and byte order mark",
+			csvContent: "\ufeffid,name,estimate,is_completed,completed_at\r\n1,Task 1,3,TRUE,2024/05/01 10:00:00\r\n2,Task 2,1,TRUE,2024/05/02 10:00:00\r\n",
+			delimiter:  models.DelimiterAuto,
+			expectRows: 2,
+		},
 		{
 			name:       "Comma delimited content",
 			csvContent: "id,name,estimate,is_completed,completed_at\n1,Task 1,3,TRUE,2024/05/01 10:00:00",
@@ -565,4 +578,358 @@ func TestCSVParser_ErrorRecovery(t *testing.T) {
 	if validItems == 0 {
 		t.Errorf("Expected at least some valid items to be parsed")
 	}
-}
\ No newline at end of file
+}
+func TestCSVParser_EstimateMapping(t *testing.T) {
+	csvContent := `id,name,estimate,is_completed,completed_at
+1,Task 1,S,TRUE,2024/05/01 10:00:00
+2,Task 2,xl,TRUE,2024/05/02 10:00:00
+3,Task 3,3,TRUE,2024/05/03 10:00:00
+4,Task 4,Huge,TRUE,2024/05/04 10:00:00`
+
+	tempFile, err := os.CreateTemp("", "csv-estimates-*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.WriteString(csvContent); err != nil {
+		t.Fatalf("Failed to write test content: %v", err)
+	}
+	tempFile.Close()
+
+	mapping := models.EstimateMapping{"S": 2, "XL": 8}
+	parser := NewCSVParser(tempFile.Name()).WithEstimateMapping(mapping)
+	items, err := parser.Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []float64{2, 8, 3, 0}
+	for i, want := range expected {
+		if items[i].Estimate != want {
+			t.Errorf("Item %d estimate = %v, want %v", i+1, items[i].Estimate, want)
+		}
+	}
+
+	unknown := parser.UnknownEstimates()
+	if len(unknown) != 1 || unknown["HUGE"] != 1 {
+		t.Errorf("UnknownEstimates() = %v, want map[HUGE:1]", unknown)
+	}
+}
+
+func TestCSVParser_DecimalSeparator(t *testing.T) {
+	csvContent := `id;name;estimate;is_completed;completed_at
+1;Task 1;2,5;TRUE;2024/05/01 10:00:00
+2;Task 2;1.5;TRUE;2024/05/02 10:00:00`
+
+	tempFile, err := os.CreateTemp("", "csv-decimal-*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.WriteString(csvContent); err != nil {
+		t.Fatalf("Failed to write test content: %v", err)
+	}
+	tempFile.Close()
+
+	items, err := NewCSVParser(tempFile.Name()).WithDelimiter(models.DelimiterSemicolon).Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if items[0].Estimate != 2.5 || items[1].Estimate != 1.5 {
+		t.Errorf("Auto decimal parsing = %v, %v; want 2.5, 1.5", items[0].Estimate, items[1].Estimate)
+	}
+
+	var out strings.Builder
+	parser := NewCSVParser(tempFile.Name()).
+		WithDelimiter(models.DelimiterSemicolon).
+		WithDecimalSeparator(models.DecimalComma).
+		WithOutput(&out)
+	items, err = parser.Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if items[0].Estimate != 2.5 || items[1].Estimate != 0 {
+		t.Errorf("Comma decimal parsing = %v, %v; want 2.5, 0 rather than 15", items[0].Estimate, items[1].Estimate)
+	}
+	issues := parser.Issues()
+	if len(issues) != 1 || issues[0].Value != "1.5" || !strings.Contains(issues[0].Message, "doesn't match --decimal-separator comma") {
+		t.Errorf("Issues() = %+v, want one for 1.5", issues)
+	}
+	if !strings.Contains(out.String(), "1.5 don't use the decimal separator of --decimal-separator comma") {
+		t.Errorf("Warning doesn't name the mismatched estimate:\n%s", out.String())
+	}
+}
+
+func TestCSVParser_AmbiguousDecimal(t *testing.T) {
+	csvContent := `id;name;estimate;is_completed;completed_at
+1;Task 1;1,000;TRUE;2024/05/01 10:00:00`
+
+	tempFile, err := os.CreateTemp("", "csv-ambiguous-decimal-*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.WriteString(csvContent); err != nil {
+		t.Fatalf("Failed to write test content: %v", err)
+	}
+	tempFile.Close()
+
+	var out strings.Builder
+	parser := NewCSVParser(tempFile.Name()).WithDelimiter(models.DelimiterSemicolon).WithOutput(&out)
+	items, err := parser.Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if items[0].Estimate != 0 {
+		t.Errorf("Auto decimal parsing of 1,000 = %v, want 0 rather than a guess", items[0].Estimate)
+	}
+	if !strings.Contains(out.String(), "1,000 could use the separator for thousands or decimals; choose with --decimal-separator") {
+		t.Errorf("Expected a warning about the ambiguous separator, got:\n%s", out.String())
+	}
+	if issues := parser.Issues(); len(issues) != 1 || !strings.Contains(issues[0].Message, "ambiguous decimal separator") {
+		t.Errorf("Issues() = %+v, want one ambiguous estimate", issues)
+	}
+
+	items, err = NewCSVParser(tempFile.Name()).
+		WithDelimiter(models.DelimiterSemicolon).
+		WithDecimalSeparator(models.DecimalDot).
+		WithOutput(io.Discard).
+		Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if items[0].Estimate != 1000 {
+		t.Errorf("Dot decimal parsing of 1,000 = %v, want 1000", items[0].Estimate)
+	}
+}
+
+func TestCSVParser_DateFormat(t *testing.T) {
+	csvContent := `id,name,estimate,is_completed,created_at,completed_at
+1,Task 1,3,TRUE,01/May/24 9:15 AM,07/May/24 3:49 PM`
+
+	tempFile, err := os.CreateTemp("", "csv-date-format-*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.WriteString(csvContent); err != nil {
+		t.Fatalf("Failed to write test content: %v", err)
+	}
+	tempFile.Close()
+
+	items, err := NewCSVParser(tempFile.Name()).Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !items[0].CompletedAt.IsZero() {
+		t.Errorf("Default format read %v from a Jira timestamp, want it left empty", items[0].CompletedAt)
+	}
+
+	items, err = NewCSVParser(tempFile.Name()).WithDateFormat(models.DateFormatPresets["jira"]).Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := time.Date(2024, 5, 7, 15, 49, 0, 0, time.UTC)
+	if !items[0].CompletedAt.Equal(want) || items[0].CreatedAt.Day() != 1 {
+		t.Errorf("Jira format read completed_at %v, created_at %v; want %v and May 1", items[0].CompletedAt, items[0].CreatedAt, want)
+	}
+}
+
+func TestCSVParser_Issues(t *testing.T) {
+	csvContent := `id,name,estimate,is_completed,completed_at,external_tickets
+1,"Task 1
+spanning two lines",3,TRUE,2024/05/01 10:00:00,"#{""JIRA-1"":1}"
+2,Task 2,3,TRUE,2024/05/02 10:00:00,'not tickets at all'
+3,Task 3,3,maybe,2024/05/03 10:00:00,JIRA-2;JIRA-3
+,Task 4,3,TRUE,2024/05/04 10:00:00,`
+
+	tempFile, err := os.CreateTemp("", "csv-issues-*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.WriteString(csvContent); err != nil {
+		t.Fatalf("Failed to write test content: %v", err)
+	}
+	tempFile.Close()
+
+	parser := NewCSVParser(tempFile.Name())
+	items, err := parser.Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(items))
+	}
+	if len(items[0].ExternalTickets) != 1 || len(items[2].ExternalTickets) != 2 {
+		t.Errorf("ExternalTickets = %v, %v; want 1 and 2 tickets", items[0].ExternalTickets, items[2].ExternalTickets)
+	}
+	if want := (models.Source{File: tempFile.Name(), Row: 3, Line: 5}); items[2].Source != want {
+		t.Errorf("Source = %+v, want %+v", items[2].Source, want)
+	}
+
+	issues := parser.Issues()
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 issues, got %d: %v", len(issues), issues)
+	}
+	// The first row spans two lines, so later rows start a line further down
+	expected := []struct {
+		row    int
+		line   int
+		column string
+	}{
+		{2, 4, "external_tickets"},
+		{3, 5, "is_completed"},
+		{4, 6, ""},
+	}
+	for i, exp := range expected {
+		if issues[i].File != tempFile.Name() || issues[i].Row != exp.row || issues[i].Line != exp.line || issues[i].Column != exp.column {
+			t.Errorf("Issue %d = row %d, line %d, column %q; want row %d, line %d, column %q",
+				i, issues[i].Row, issues[i].Line, issues[i].Column, exp.row, exp.line, exp.column)
+		}
+	}
+}
+
+func TestCSVParser_ParseContext_Cancelled(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "csv-cancel-*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.WriteString("id,name,estimate,is_completed,completed_at\n1,Task 1,3,TRUE,2024/05/01 10:00:00\n"); err != nil {
+		t.Fatalf("Failed to write test content: %v", err)
+	}
+	tempFile.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewCSVParser(tempFile.Name()).ParseContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCSVParser_MaxErrors(t *testing.T) {
+	csvContent := `id,name,estimate,is_completed,completed_at
+1,Task 1,3,TRUE,2024/05/01 10:00:00
+,Missing ID,3,TRUE,2024/05/02 10:00:00
+3,,3,TRUE,2024/05/03 10:00:00
+4,Task 4,3,TRUE,2024/05/04 10:00:00`
+
+	tempFile, err := os.CreateTemp("", "csv-max-errors-*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.WriteString(csvContent); err != nil {
+		t.Fatalf("Failed to write test content: %v", err)
+	}
+	tempFile.Close()
+
+	tests := []struct {
+		name      string
+		maxErrors int
+		expectErr bool
+	}{
+		{"No limit", -1, false},
+		{"Limit not exceeded", 2, false},
+		{"Limit exceeded", 1, true},
+		{"No errors allowed", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := NewCSVParser(tempFile.Name()).WithMaxErrors(tt.maxErrors).Parse()
+			if tt.expectErr {
+				if !errors.Is(err, ErrTooManyRowErrors) {
+					t.Fatalf("Expected ErrTooManyRowErrors, got %v", err)
+				}
+				if !strings.Contains(err.Error(), "2 of 4 rows") {
+					t.Errorf("Error should report failed and total rows, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(items) != 2 {
+				t.Errorf("Expected 2 items, got %d", len(items))
+			}
+		})
+	}
+}
+
+func TestCSVParser_WithOutput(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "csv-output-*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+	tempFile.WriteString("id;name;estimate;is_completed;completed_at\n1;Task 1;3;TRUE;2024/05/01 10:00:00")
+	tempFile.Close()
+
+	var out strings.Builder
+	items, err := NewCSVParser(tempFile.Name()).WithDelimiter(models.DelimiterAuto).WithOutput(&out).Parse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Errorf("Expected 1 item, got %d", len(items))
+	}
+
+	for _, want := range []string{"Detected semicolon-delimited CSV", "✅ Loaded 1 kanban items"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+// writeBenchmarkCSV writes an export of n rows whose team, epic, state,
+// label and owner values repeat, as in the export of a large organization
+func writeBenchmarkCSV(b *testing.B, n int) string {
+	b.Helper()
+	var content strings.Builder
+	content.WriteString("id,name,type,estimate,is_completed,completed_at,created_at,started_at,owners,labels,state,epic,team,project,workflow,priority,custom_fields\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&content, "%d,Story number %d about the checkout flow,%s,%d,TRUE,2024/05/%02d 10:00:00,2024/04/%02d 09:00:00,2024/05/01 09:00:00,\"owner%d@example.com,owner%d@example.com\",\"component:api,%s\",%s,Epic %d,Team %d,Project %d,Default Workflow,%s,Environment=Production\n",
+			i, i, []string{"feature", "bug", "chore"}[i%3], i%8+1, i%28+1, i%28+1, i%40, (i+7)%40,
+			[]string{"ad-hoc-request", "frontend", "backend"}[i%3], []string{"Done", "Released", "Closed"}[i%3],
+			i%60, i%12, i%5, []string{"High", "Medium", "Low"}[i%3])
+	}
+	path := filepath.Join(b.TempDir(), "large.csv")
+	if err := os.WriteFile(path, []byte(content.String()), 0644); err != nil {
+		b.Fatalf("Failed to write test file: %v", err)
+	}
+	return path
+}
+
+func BenchmarkCSVParser_Parse(b *testing.B) {
+	const rows = 20000
+	path := writeBenchmarkCSV(b, rows)
+
+	b.ReportAllocs()
+	var retained uint64
+	for i := 0; i < b.N; i++ {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		items, err := NewCSVParser(path).WithOutput(io.Discard).Parse()
+		if err != nil {
+			b.Fatalf("Parse() error = %v", err)
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		retained += after.HeapAlloc - before.HeapAlloc
+		runtime.KeepAlive(items)
+	}
+	// Memory the parsed items keep alive, the part string sharing reduces
+	b.ReportMetric(float64(retained)/float64(b.N)/rows, "retained-B/item")
+}
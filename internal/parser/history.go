@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// HistoryColumns are the columns required in a state history file
+var HistoryColumns = []string{"item_id", "from", "to", "timestamp"}
+
+// ParseHistory reads a state history CSV with one transition per row and the
+// columns item_id, from, to and timestamp. The delimiter is detected the same
+// way as for the main export.
+func ParseHistory(r io.Reader) (models.StateHistory, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimPrefix(string(content), byteOrderMark)
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = models.DetectDelimiterType(text).Value
+	reader.FieldsPerRecord = -1
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading header: %w", err)
+	}
+	colIndices := make(map[string]int)
+	for i, header := range headers {
+		colIndices[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	var missing []string
+	for _, column := range HistoryColumns {
+		if _, ok := colIndices[column]; !ok {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required columns: %s", strings.Join(missing, ", "))
+	}
+
+	history := make(models.StateHistory)
+	lineNumber := 1
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		lineNumber++
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		getCol := func(name string) string {
+			if i := colIndices[name]; i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		itemID := getCol("item_id")
+		if itemID == "" {
+			continue
+		}
+		at, err := models.ParseTime(getCol("timestamp"))
+		if err != nil || at.IsZero() {
+			return nil, fmt.Errorf("line %d: invalid timestamp %q (expected YYYY/MM/DD HH:MM:SS)", lineNumber, getCol("timestamp"))
+		}
+		history.Add(itemID, models.StateTransition{From: getCol("from"), To: getCol("to"), At: at})
+	}
+
+	return history, nil
+}
+
+// LoadHistory reads a state history file from disk
+func LoadHistory(path string) (models.StateHistory, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening history file: %w", err)
+	}
+	defer file.Close()
+
+	history, err := ParseHistory(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading history file '%s': %w", path, err)
+	}
+	return history, nil
+}
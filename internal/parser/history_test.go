@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseHistory(t *testing.T) {
+	t.Run("Valid history", func(t *testing.T) {
+		input := "item_id;from;to;timestamp\n" +
+			"1;In Progress;Review;2024/06/05 10:00:00\n" +
+			"1;Ready;In Progress;2024/06/02 09:00:00\n" +
+			"2;Ready;In Progress;2024/06/03 09:00:00\n" +
+			";Ready;Done;2024/06/03 09:00:00\n"
+
+		history, err := ParseHistory(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ParseHistory() error = %v", err)
+		}
+		if len(history) != 2 || len(history["1"]) != 2 {
+			t.Fatalf("ParseHistory() = %v, want 2 items with 2 transitions for item 1", history)
+		}
+		if history["1"][0].To != "In Progress" || history["1"][1].To != "Review" {
+			t.Errorf("ParseHistory() transitions not in time order: %v", history["1"])
+		}
+	})
+
+	errorTests := []struct {
+		name     string
+		input    string
+		errorMsg string
+	}{
+		{"Missing columns", "item_id,to\n1,Done\n", "missing required columns: from, timestamp"},
+		{"Invalid timestamp", "item_id,from,to,timestamp\n1,Ready,Done,yesterday\n", "line 2: invalid timestamp"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseHistory(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("ParseHistory() error = %v, want %q", err, tt.errorMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// interner shares one copy of each distinct string. Teams, epics, states,
+// labels and owners repeat across the rows of an export, and the fields of a
+// CSV row are slices of one string holding the whole row, so an item keeping
+// any of its fields would otherwise keep the row alive too.
+type interner map[string]string
+
+// intern returns the shared copy of s, making one the first time s is seen
+func (in interner) intern(s string) string {
+	if s == "" {
+		return ""
+	}
+	if shared, ok := in[s]; ok {
+		return shared
+	}
+	s = strings.Clone(s)
+	in[s] = s
+	return s
+}
+
+// internAll replaces each string of values with its shared copy
+func (in interner) internAll(values []string) {
+	for i, value := range values {
+		values[i] = in.intern(value)
+	}
+}
+
+// internItem replaces the repeated strings of an item with shared copies, and
+// copies the strings unique to it so it no longer refers to its CSV row
+func (in interner) internItem(item *models.KanbanItem) {
+	item.ID = strings.Clone(item.ID)
+	item.Name = strings.Clone(item.Name)
+	item.Description = strings.Clone(item.Description)
+
+	for _, field := range []*string{
+		&item.Type, &item.Requester, &item.State, &item.EpicID, &item.Epic,
+		&item.ProjectID, &item.Project, &item.IterationID, &item.Iteration,
+		&item.UTCOffset, &item.TeamID, &item.Team, &item.EpicState,
+		&item.MilestoneID, &item.Milestone, &item.MilestoneState,
+		&item.Workflow, &item.WorkflowID, &item.Priority, &item.Severity,
+		&item.ProductArea, &item.SkillSet, &item.TechnicalArea,
+	} {
+		*field = in.intern(*field)
+	}
+
+	in.internAll(item.Owners)
+	in.internAll(item.Labels)
+	in.internAll(item.EpicLabels)
+	in.internAll(item.MilestoneCategories)
+	in.internAll(item.ProductAreas)
+	in.internAll(item.ExternalTickets)
+	for i, task := range item.Tasks {
+		item.Tasks[i] = strings.Clone(task)
+	}
+
+	if len(item.CustomFields) > 0 {
+		fields := make(map[string]string, len(item.CustomFields))
+		for key, value := range item.CustomFields {
+			fields[in.intern(key)] = in.intern(value)
+		}
+		item.CustomFields = fields
+	}
+}
@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestInternerSharesRepeatedValues(t *testing.T) {
+	in := make(interner)
+	// Two rows holding the same values in different backing strings
+	rows := []string{"1,Platform,api", "2,Platform,api"}
+
+	var items []models.KanbanItem
+	for _, row := range rows {
+		item := models.KanbanItem{ID: row[:1], Team: row[2:10], Labels: []string{row[11:]}}
+		in.internItem(&item)
+		items = append(items, item)
+	}
+
+	if unsafe.StringData(items[0].Team) != unsafe.StringData(items[1].Team) {
+		t.Error("Teams with the same value should share one string")
+	}
+	if unsafe.StringData(items[0].Labels[0]) != unsafe.StringData(items[1].Labels[0]) {
+		t.Error("Labels with the same value should share one string")
+	}
+	for i, item := range items {
+		if unsafe.StringData(item.ID) == unsafe.StringData(rows[i]) {
+			t.Errorf("Item %d still refers to its row", i)
+		}
+		if item.Team != "Platform" || item.Labels[0] != "api" {
+			t.Errorf("Item %d values changed: %+v", i, item)
+		}
+	}
+
+	if got := in.intern(""); got != "" {
+		t.Errorf("intern(\"\") = %q", got)
+	}
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// FileSource describes one CSV file and the parsing overrides that apply to it.
+// Exports from different tools rarely share a delimiter or header names.
+type FileSource struct {
+	Path      string
+	Delimiter models.DelimiterType // Zero value uses the parser's delimiter
+	ColumnMap models.ColumnMap     // Merged over the parser's column map
+}
+
+// ParseSources parses each file with the parser's settings plus the file's overrides
+// and returns the items from all files in order. The row error limit applies to
+// all files together.
+func (p *CSVParser) ParseSources(sources []FileSource) ([]models.KanbanItem, error) {
+	return p.ParseSourcesContext(context.Background(), sources)
+}
+
+// ParseSourcesContext parses the files like ParseSources, stopping with the
+// context's error when it is cancelled or times out
+func (p *CSVParser) ParseSourcesContext(ctx context.Context, sources []FileSource) ([]models.KanbanItem, error) {
+	var items []models.KanbanItem
+	p.rowsRead = 0
+	p.rowErrors = 0
+
+	for _, source := range sources {
+		fileParser := NewCSVParser(source.Path).
+			WithDelimiter(p.delimiter).
+			WithColumnMap(mergeColumnMaps(p.columnMap, source.ColumnMap)).
+			WithEstimateMapping(p.estimateMapping).
+			WithBoolTokens(p.boolTokens).
+			WithDecimalSeparator(p.decimalSeparator).
+			WithDateFormat(p.dateFormat).
+			WithOutput(p.out)
+
+		if source.Delimiter.Name != "" {
+			fileParser.WithDelimiter(source.Delimiter)
+		}
+
+		fileItems, err := fileParser.ParseContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source.Path, err)
+		}
+
+		for value, count := range fileParser.UnknownEstimates() {
+			p.unknownEstimates[value] += count
+		}
+		for value, count := range fileParser.UnknownBools() {
+			p.unknownBools[value] += count
+		}
+		p.issues = append(p.issues, fileParser.Issues()...)
+		p.rowsRead += fileParser.rowsRead
+		p.rowErrors += fileParser.rowErrors
+		items = append(items, fileItems...)
+	}
+
+	if err := p.checkMaxErrors(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// MergeDuplicates keeps one item per ID, e.g. of the same story exported from
+// several boards: the most recently updated one, or the one read last when
+// they were updated at the same time. Items keep the position of the first
+// item with their ID, and items without an ID are all kept. It returns the
+// merged items and how many duplicates were dropped.
+func MergeDuplicates(items []models.KanbanItem) ([]models.KanbanItem, int) {
+	merged := make([]models.KanbanItem, 0, len(items))
+	positions := make(map[string]int, len(items))
+	for _, item := range items {
+		if item.ID == "" {
+			merged = append(merged, item)
+			continue
+		}
+		i, seen := positions[item.ID]
+		if !seen {
+			positions[item.ID] = len(merged)
+			merged = append(merged, item)
+			continue
+		}
+		if !item.UpdatedAt.Before(merged[i].UpdatedAt) {
+			merged[i] = item
+		}
+	}
+	return merged, len(items) - len(merged)
+}
+
+// mergeColumnMaps combines a base column map with per-file overrides
+func mergeColumnMaps(base, overrides models.ColumnMap) models.ColumnMap {
+	merged := make(models.ColumnMap, len(base)+len(overrides))
+	for source, target := range base {
+		merged[source] = target
+	}
+	for source, target := range overrides {
+		merged[source] = target
+	}
+	return merged
+}
@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestCSVParser_ParseSources(t *testing.T) {
+	dir := t.TempDir()
+
+	commaFile := filepath.Join(dir, "board-a.csv")
+	commaContent := `id,name,estimate,is_completed,completed_at
+1,Task 1,3,TRUE,2024/05/01 10:00:00`
+	if err := os.WriteFile(commaFile, []byte(commaContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	// A second export from another tool with semicolons and its own headers
+	otherFile := filepath.Join(dir, "board-b.csv")
+	otherContent := `Key;Title;Story Points;Done;Resolved
+2;Task 2;5;TRUE;2024/05/02 10:00:00`
+	if err := os.WriteFile(otherFile, []byte(otherContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	sources := []FileSource{
+		{Path: commaFile},
+		{
+			Path:      otherFile,
+			Delimiter: models.DelimiterSemicolon,
+			ColumnMap: models.ColumnMap{
+				"Key":          "id",
+				"Title":        "name",
+				"Story Points": "estimate",
+				"Done":         "is_completed",
+				"Resolved":     "completed_at",
+			},
+		},
+	}
+
+	parser := NewCSVParser("").WithDelimiter(models.DelimiterComma)
+	items, err := parser.ParseSources(sources)
+	if err != nil {
+		t.Fatalf("ParseSources() error = %v", err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("ParseSources() returned %d items, want 2", len(items))
+	}
+	if items[1].ID != "2" || items[1].Name != "Task 2" || items[1].Estimate != 5 || !items[1].IsCompleted {
+		t.Errorf("Second file not parsed with its overrides: %+v", items[1])
+	}
+
+	// Without the overrides the second file is missing required columns
+	if _, err := parser.ParseSources([]FileSource{{Path: otherFile}}); err == nil {
+		t.Errorf("ParseSources() should fail without the column map override")
+	}
+}
+
+func TestCSVParser_WithColumnMap(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "export.csv")
+	content := `id,Title,Points,is_completed,completed_at
+1,Task 1,8,TRUE,2024/05/01 10:00:00`
+	if err := os.WriteFile(tempFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	items, err := NewCSVParser(tempFile).
+		WithColumnMap(models.ColumnMap{"Title": "name", "Points": "estimate"}).
+		Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if items[0].Name != "Task 1" || items[0].Estimate != 8 {
+		t.Errorf("Column map not applied: %+v", items[0])
+	}
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2024, 5, day, 10, 0, 0, 0, time.UTC) }
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Old copy", UpdatedAt: at(2)},
+		{ID: "2", Name: "Only copy", UpdatedAt: at(1)},
+		{Name: "No ID"},
+		{ID: "1", Name: "New copy", UpdatedAt: at(5)},
+		{ID: "1", Name: "Stale copy", UpdatedAt: at(3)},
+		{Name: "No ID"},
+		{ID: "2", Name: "Same time, read last", UpdatedAt: at(1)},
+	}
+
+	merged, dropped := MergeDuplicates(items)
+	if dropped != 3 {
+		t.Errorf("dropped = %d, want 3", dropped)
+	}
+	want := []string{"New copy", "Same time, read last", "No ID", "No ID"}
+	if len(merged) != len(want) {
+		t.Fatalf("merged %d items, want %d", len(merged), len(want))
+	}
+	for i, item := range merged {
+		if item.Name != want[i] {
+			t.Errorf("item %d = %q, want %q", i, item.Name, want[i])
+		}
+	}
+}
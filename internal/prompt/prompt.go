@@ -0,0 +1,207 @@
+// Package prompt provides line-based prompts for interactive terminal sessions:
+// free text, numbers, dates, numbered selections and confirmations, each
+// repeated until the answer is valid.
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Validator checks an answer; the error message is shown when it is rejected
+type Validator func(answer string) error
+
+// Prompter asks questions on a writer and reads the answers, one per line
+type Prompter struct {
+	scanner *bufio.Scanner
+	writer  io.Writer
+	echo    bool               // Print each answer after its prompt, for scripted sessions
+	check   func(string) error // Inspects every raw answer, e.g. for quit commands
+}
+
+// New creates a prompter reading answers from reader and writing to writer
+func New(reader io.Reader, writer io.Writer) *Prompter {
+	return &Prompter{
+		scanner: bufio.NewScanner(reader),
+		writer:  writer,
+	}
+}
+
+// WithEcho prints each answer after its prompt so scripted sessions read
+// like interactive ones
+func (p *Prompter) WithEcho(echo bool) *Prompter {
+	p.echo = echo
+	return p
+}
+
+// WithCheck sets a function run on every answer before it is validated; an
+// error from it is returned from the prompt instead of asking again
+func (p *Prompter) WithCheck(check func(answer string) error) *Prompter {
+	p.check = check
+	return p
+}
+
+// Print writes msg as is
+func (p *Prompter) Print(msg string) {
+	fmt.Fprint(p.writer, msg)
+}
+
+// Printf writes formatted text
+func (p *Prompter) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(p.writer, format, args...)
+}
+
+// Println writes msg followed by a newline
+func (p *Prompter) Println(msg string) {
+	fmt.Fprintln(p.writer, msg)
+}
+
+// Heading writes a section title underlined to its width
+func (p *Prompter) Heading(title string) {
+	p.Println("\n" + title)
+	p.Println(strings.Repeat("-", utf8.RuneCountInString(title)))
+}
+
+// Input shows prompt and returns the trimmed answer
+func (p *Prompter) Input(prompt string) (string, error) {
+	p.Print(prompt)
+	if !p.scanner.Scan() {
+		if p.echo {
+			return "", fmt.Errorf("answers ended before the prompt %q", strings.TrimSpace(prompt))
+		}
+		return "", fmt.Errorf("failed to read input")
+	}
+
+	input := p.scanner.Text()
+	if p.echo {
+		p.Println(input)
+	}
+
+	if p.check != nil {
+		if err := p.check(input); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSpace(input), nil
+}
+
+// Text asks until validate accepts the answer. An empty answer is replaced
+// by def when def is not empty; validate may be nil.
+func (p *Prompter) Text(prompt, def string, validate Validator) (string, error) {
+	for {
+		answer, err := p.Input(prompt)
+		if err != nil {
+			return "", err
+		}
+		if answer == "" && def != "" {
+			answer = def
+		}
+
+		if validate != nil {
+			if err := validate(answer); err != nil {
+				p.Printf("❌ %v\n", err)
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// Int asks for a whole number of at least min
+func (p *Prompter) Int(prompt string, min int) (int, error) {
+	var value int
+	_, err := p.Text(prompt, "", func(answer string) error {
+		n, err := strconv.Atoi(answer)
+		if err != nil || n < min {
+			if min == 1 {
+				return errors.New("Please enter a valid positive number")
+			}
+			return fmt.Errorf("Please enter a whole number of at least %d", min)
+		}
+		value = n
+		return nil
+	})
+	return value, err
+}
+
+// Date asks for a date in YYYY-MM-DD format; validate, when not nil, can
+// reject dates that are well formed
+func (p *Prompter) Date(prompt string, validate func(time.Time) error) (time.Time, error) {
+	var value time.Time
+	_, err := p.Text(prompt, "", func(answer string) error {
+		date, err := time.Parse("2006-01-02", answer)
+		if err != nil {
+			return errors.New("Invalid date format. Please use YYYY-MM-DD")
+		}
+		if validate != nil {
+			if err := validate(date); err != nil {
+				return err
+			}
+		}
+		value = date
+		return nil
+	})
+	return value, err
+}
+
+// Select lists options numbered from 1 and returns the index of the chosen
+// one. When def is a valid index an empty answer selects it; use -1 for no
+// default.
+func (p *Prompter) Select(options []string, def int) (int, error) {
+	for i, option := range options {
+		p.Printf("%d. %s\n", i+1, option)
+	}
+
+	prompt := fmt.Sprintf("\nEnter your choice (1-%d): ", len(options))
+	invalid := fmt.Sprintf("Please enter a number between 1 and %d", len(options))
+	if len(options) == 2 {
+		prompt = "\nEnter your choice (1 or 2): "
+		invalid = "Please enter 1 or 2"
+	}
+
+	var choice int
+	_, err := p.Text(prompt, "", func(answer string) error {
+		if answer == "" && def >= 0 && def < len(options) {
+			choice = def
+			return nil
+		}
+		n, err := strconv.Atoi(answer)
+		if err != nil || n < 1 || n > len(options) {
+			return errors.New(invalid)
+		}
+		choice = n - 1
+		return nil
+	})
+	return choice, err
+}
+
+// Confirm asks a yes/no question; an empty answer returns def
+func (p *Prompter) Confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+
+	var value bool
+	_, err := p.Text(fmt.Sprintf("%s [%s]: ", question, hint), "", func(answer string) error {
+		switch strings.ToLower(answer) {
+		case "":
+			value = def
+		case "y", "yes":
+			value = true
+		case "n", "no":
+			value = false
+		default:
+			return errors.New("Please answer yes or no")
+		}
+		return nil
+	})
+	return value, err
+}
@@ -0,0 +1,204 @@
+package prompt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestPrompter returns a prompter reading input and the output it writes
+func newTestPrompter(input string) (*Prompter, *strings.Builder) {
+	output := &strings.Builder{}
+	return New(strings.NewReader(input), output), output
+}
+
+func TestInput(t *testing.T) {
+	p, output := newTestPrompter("  answer  \n")
+
+	got, err := p.Input("Question: ")
+	if err != nil {
+		t.Fatalf("Input() error = %v", err)
+	}
+	if got != "answer" {
+		t.Errorf("Input() = %q, want %q", got, "answer")
+	}
+	if output.String() != "Question: " {
+		t.Errorf("output = %q, want only the prompt", output.String())
+	}
+
+	if _, err := p.Input("Again: "); err == nil {
+		t.Errorf("Input() at end of input: expected error, got nil")
+	}
+}
+
+func TestInput_EchoAndCheck(t *testing.T) {
+	errStop := errors.New("stop")
+	output := &strings.Builder{}
+	p := New(strings.NewReader("go\nstop\n"), output).
+		WithEcho(true).
+		WithCheck(func(answer string) error {
+			if answer == "stop" {
+				return errStop
+			}
+			return nil
+		})
+
+	if _, err := p.Input("First: "); err != nil {
+		t.Fatalf("Input() error = %v", err)
+	}
+	if _, err := p.Input("Second: "); err != errStop {
+		t.Errorf("Input() error = %v, want the check error", err)
+	}
+	if output.String() != "First: go\nSecond: stop\n" {
+		t.Errorf("output = %q, want prompts with echoed answers", output.String())
+	}
+
+	_, err := p.Input("Third: ")
+	if err == nil || !strings.Contains(err.Error(), `answers ended before the prompt "Third:"`) {
+		t.Errorf("Input() error = %v, want answers ended error", err)
+	}
+}
+
+func TestText(t *testing.T) {
+	notEmpty := func(answer string) error {
+		if answer == "" {
+			return errors.New("Please enter something")
+		}
+		return nil
+	}
+
+	t.Run("Retries until valid", func(t *testing.T) {
+		p, output := newTestPrompter("\nvalue\n")
+		got, err := p.Text("Name: ", "", notEmpty)
+		if err != nil || got != "value" {
+			t.Errorf("Text() = %q, %v; want %q", got, err, "value")
+		}
+		if !strings.Contains(output.String(), "❌ Please enter something\n") {
+			t.Errorf("output doesn't contain the validation message: %q", output.String())
+		}
+	})
+
+	t.Run("Empty answer uses default", func(t *testing.T) {
+		p, _ := newTestPrompter("\n")
+		got, err := p.Text("Name: ", "fallback", notEmpty)
+		if err != nil || got != "fallback" {
+			t.Errorf("Text() = %q, %v; want %q", got, err, "fallback")
+		}
+	})
+}
+
+func TestInt(t *testing.T) {
+	p, output := newTestPrompter("abc\n0\n14\n")
+
+	got, err := p.Int("Days: ", 1)
+	if err != nil || got != 14 {
+		t.Errorf("Int() = %d, %v; want 14", got, err)
+	}
+	if strings.Count(output.String(), "❌ Please enter a valid positive number") != 2 {
+		t.Errorf("output should reject two answers: %q", output.String())
+	}
+}
+
+func TestDate(t *testing.T) {
+	p, output := newTestPrompter("2024/01/01\n2023-12-31\n2024-02-01\n")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got, err := p.Date("End: ", func(date time.Time) error {
+		if date.Before(start) {
+			return errors.New("End date cannot be before start date")
+		}
+		return nil
+	})
+	if err != nil || !got.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Date() = %v, %v; want 2024-02-01", got, err)
+	}
+
+	for _, want := range []string{"❌ Invalid date format. Please use YYYY-MM-DD", "❌ End date cannot be before start date"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("output doesn't contain %q", want)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	options := []string{"Red", "Green", "Blue"}
+
+	tests := []struct {
+		name    string
+		input   string
+		def     int
+		want    int
+		wantErr bool
+	}{
+		{"First option", "1\n", -1, 0, false},
+		{"Last option", "3\n", -1, 2, false},
+		{"Out of range then valid", "4\n2\n", -1, 1, false},
+		{"Empty answer uses default", "\n", 1, 1, false},
+		{"Empty answer without default", "\n3\n", -1, 2, false},
+		{"No answer", "", -1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, output := newTestPrompter(tt.input)
+
+			got, err := p.Select(options, tt.def)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Select() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Select() = %d, want %d", got, tt.want)
+			}
+			if !strings.HasPrefix(output.String(), "1. Red\n2. Green\n3. Blue\n\nEnter your choice (1-3): ") {
+				t.Errorf("output doesn't start with the numbered options: %q", output.String())
+			}
+		})
+	}
+}
+
+func TestSelect_TwoOptions(t *testing.T) {
+	p, output := newTestPrompter("5\n2\n")
+
+	got, err := p.Select([]string{"Yes", "No"}, -1)
+	if err != nil || got != 1 {
+		t.Errorf("Select() = %d, %v; want 1", got, err)
+	}
+	for _, want := range []string{"Enter your choice (1 or 2): ", "❌ Please enter 1 or 2"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("output doesn't contain %q", want)
+		}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		def   bool
+		want  bool
+	}{
+		{"y\n", false, true},
+		{"YES\n", false, true},
+		{"n\n", true, false},
+		{"\n", true, true},
+		{"\n", false, false},
+		{"maybe\nno\n", true, false},
+	}
+
+	for _, tt := range tests {
+		p, _ := newTestPrompter(tt.input)
+		got, err := p.Confirm("Continue?", tt.def)
+		if err != nil || got != tt.want {
+			t.Errorf("Confirm() with input %q, default %v = %v, %v; want %v", tt.input, tt.def, got, err, tt.want)
+		}
+	}
+}
+
+func TestHeading(t *testing.T) {
+	p, output := newTestPrompter("")
+	p.Heading("📁 CSV File Selection")
+
+	if output.String() != "\n📁 CSV File Selection\n--------------------\n" {
+		t.Errorf("Heading() output = %q", output.String())
+	}
+}
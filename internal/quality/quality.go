@@ -0,0 +1,128 @@
+package quality
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/schema"
+)
+
+// MaxExamplesPerColumn limits how many offending rows are listed for each column
+const MaxExamplesPerColumn = 5
+
+// Sources of issues
+const (
+	SourceParser = "parser" // Found while reading the CSV
+	SourceFilter = "filter" // Found while filtering the loaded items
+)
+
+// Issue is a single data-quality finding from parsing or filtering an export
+type Issue struct {
+	Source  string `json:"source"`           // Where the issue was found (SourceParser or SourceFilter)
+	File    string `json:"file,omitempty"`   // CSV file the row was read from
+	Row     int    `json:"row,omitempty"`    // Data row number, starting at 1 for the first row after the header
+	Line    int    `json:"line,omitempty"`   // Line of the file the row starts on; the header is line 1
+	Column  string `json:"column,omitempty"` // Column the value came from; empty for whole-row problems
+	Value   string `json:"value,omitempty"`  // The offending raw value
+	Message string `json:"message"`          // What was wrong and how the value was treated
+}
+
+// String formats the issue as a single line
+func (i Issue) String() string {
+	if i.Row == 0 {
+		return i.Message
+	}
+	row := fmt.Sprintf("row %d", i.Row)
+	if i.File != "" {
+		row += fmt.Sprintf(" (%s:%d)", i.File, i.Line)
+	}
+	if i.Column == "" {
+		return fmt.Sprintf("%s: %s", row, i.Message)
+	}
+	return fmt.Sprintf("%s, %s: %s (%q)", row, i.Column, i.Message, i.Value)
+}
+
+// FormatReport renders the issues grouped by column, with counts and example rows
+func FormatReport(issues []Issue) string {
+	report := "# Data Quality Report\n\n"
+
+	if len(issues) == 0 {
+		report += "No data-quality issues found.\n"
+		return report
+	}
+
+	byColumn := make(map[string][]Issue)
+	for _, issue := range issues {
+		column := issue.Column
+		if column == "" {
+			column = "(row)"
+		}
+		byColumn[column] = append(byColumn[column], issue)
+	}
+
+	var columns []string
+	for column := range byColumn {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	report += fmt.Sprintf("%d issues found in %d columns.\n\n", len(issues), len(columns))
+
+	for _, column := range columns {
+		columnIssues := byColumn[column]
+		report += fmt.Sprintf("## %s (%d issues)\n\n", column, len(columnIssues))
+
+		for i, issue := range columnIssues {
+			if i >= MaxExamplesPerColumn {
+				report += fmt.Sprintf("- ... and %d more\n", len(columnIssues)-MaxExamplesPerColumn)
+				break
+			}
+			report += "- " + issue.String() + "\n"
+		}
+		report += "\n"
+	}
+
+	return strings.TrimRight(report, "\n") + "\n"
+}
+
+// FormatProblems lists the issues one per line as "file:line: message", the
+// form editors' problem matchers read, so they can jump to the offending rows.
+// Issues about the whole file point at the header. File is used for issues
+// that don't name the file they were found in.
+func FormatProblems(file string, issues []Issue) string {
+	var b strings.Builder
+	for _, issue := range issues {
+		issueFile := issue.File
+		if issueFile == "" {
+			issueFile = file
+		}
+		line := issue.Line
+		if line == 0 {
+			line = 1
+		}
+		message := issue.Message
+		if issue.Column != "" {
+			message = fmt.Sprintf("%s: %s (%q)", issue.Column, issue.Message, issue.Value)
+		}
+		fmt.Fprintf(&b, "%s:%d: %s\n", issueFile, line, message)
+	}
+	return b.String()
+}
+
+// JSONSchema describes the JSON array of issues written by WriteJSON
+func JSONSchema() (schema.Schema, error) {
+	return schema.For("Kanban data-quality issues", []Issue{})
+}
+
+// WriteJSON writes the issues as a JSON array for tools that track data quality over time
+func WriteJSON(w io.Writer, issues []Issue) error {
+	if issues == nil {
+		issues = []Issue{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(issues)
+}
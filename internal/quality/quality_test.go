@@ -0,0 +1,131 @@
+package quality
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormatReport(t *testing.T) {
+	issues := []Issue{
+		{Row: 2, Column: "external_tickets", Value: "oops", Message: "unparsable external tickets"},
+		{Row: 4, Column: "estimate", Value: "Huge", Message: "non-numeric estimate counted as 0"},
+		{Row: 5, Message: "missing required field: id"},
+	}
+
+	report := FormatReport(issues)
+
+	expected := []string{
+		"# Data Quality Report",
+		"3 issues found in 3 columns.",
+		"## estimate (1 issues)",
+		`- row 4, estimate: non-numeric estimate counted as 0 ("Huge")`,
+		"## external_tickets (1 issues)",
+		"## (row) (1 issues)",
+		"- row 5: missing required field: id",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
+
+func TestIssue_String(t *testing.T) {
+	tests := []struct {
+		issue Issue
+		want  string
+	}{
+		{Issue{Message: "3 reopened items"}, "3 reopened items"},
+		{Issue{Row: 5, Message: "missing required field: id"}, "row 5: missing required field: id"},
+		{Issue{File: "export.csv", Row: 5, Line: 7, Message: "missing required field: id"}, "row 5 (export.csv:7): missing required field: id"},
+		{Issue{File: "export.csv", Row: 2, Line: 3, Column: "estimate", Value: "Huge", Message: "non-numeric estimate counted as 0"},
+			`row 2 (export.csv:3), estimate: non-numeric estimate counted as 0 ("Huge")`},
+	}
+	for _, tt := range tests {
+		if got := tt.issue.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestFormatReport_NoIssues(t *testing.T) {
+	if report := FormatReport(nil); !strings.Contains(report, "No data-quality issues found.") {
+		t.Errorf("Expected no-issues message, got:\n%s", report)
+	}
+}
+
+func TestFormatReport_LimitsExamples(t *testing.T) {
+	var issues []Issue
+	for i := 1; i <= MaxExamplesPerColumn+3; i++ {
+		issues = append(issues, Issue{Row: i, Column: "is_completed", Value: fmt.Sprint(i), Message: "unrecognised boolean"})
+	}
+
+	report := FormatReport(issues)
+	if !strings.Contains(report, "... and 3 more") {
+		t.Errorf("Expected remaining issues to be summarised, got:\n%s", report)
+	}
+}
+
+func TestFormatProblems(t *testing.T) {
+	issues := []Issue{
+		{Row: 2, Line: 3, Column: "estimate", Value: "Huge", Message: "non-numeric estimate counted as 0"},
+		{Row: 5, Line: 7, Message: "missing required field: id"},
+		{Message: "3 reopened items"},
+		{File: "data/other.csv", Row: 1, Line: 2, Message: "missing required field: id"},
+	}
+
+	got := FormatProblems("data/export.csv", issues)
+	want := `data/export.csv:3: estimate: non-numeric estimate counted as 0 ("Huge")
+data/export.csv:7: missing required field: id
+data/export.csv:1: 3 reopened items
+data/other.csv:2: missing required field: id
+`
+	if got != want {
+		t.Errorf("FormatProblems() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := FormatProblems("data/export.csv", nil); got != "" {
+		t.Errorf("FormatProblems(nil) = %q, want no lines", got)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf strings.Builder
+	issues := []Issue{
+		{Source: SourceParser, Row: 3, Column: "estimate", Value: "Huge", Message: "non-numeric estimate counted as 0"},
+		{Source: SourceFilter, Message: "2 reopened items excluded"},
+	}
+
+	if err := WriteJSON(&buf, issues); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	output := buf.String()
+	expected := []string{
+		`"source": "parser"`,
+		`"row": 3`,
+		`"column": "estimate"`,
+		`"value": "Huge"`,
+		`"source": "filter"`,
+		`"message": "2 reopened items excluded"`,
+	}
+	for _, str := range expected {
+		if !strings.Contains(output, str) {
+			t.Errorf("Output doesn't contain %q\nGot:\n%s", str, output)
+		}
+	}
+	if strings.Count(output, `"row"`) != 1 {
+		t.Errorf("Row should be omitted for filter warnings, got:\n%s", output)
+	}
+}
+
+func TestWriteJSON_Empty(t *testing.T) {
+	var buf strings.Builder
+	if err := WriteJSON(&buf, nil); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected empty JSON array, got %q", buf.String())
+	}
+}
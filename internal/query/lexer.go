@@ -0,0 +1,124 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tokenKind is the kind of a lexical token
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator // ==, !=, <, <=, >, >=, &&, ||, !, (, ) and ,
+)
+
+// token is a piece of an expression; pos is its column, starting at 1
+type token struct {
+	kind   tokenKind
+	text   string  // The identifier, the operator or the unquoted string
+	number float64 // The value of a number
+	pos    int
+}
+
+// String describes the token in error messages
+func (t token) String() string {
+	switch t.kind {
+	case tokenEnd:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.text)
+	case tokenNumber:
+		return strconv.FormatFloat(t.number, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// errorf returns an error pointing at the token's column
+func (t token) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("column %d: %s", t.pos, fmt.Sprintf(format, args...))
+}
+
+// operators lists the operators, two-character ones first so they win
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", ","}
+
+// tokenize splits an expression into tokens, ending with a tokenEnd
+func tokenize(text string) ([]token, error) {
+	var tokens []token
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("column %d: unterminated string", pos)
+			}
+			value, err := strconv.Unquote(string(runes[i : end+1]))
+			if err != nil {
+				return nil, fmt.Errorf("column %d: invalid string %s", pos, string(runes[i:end+1]))
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value, pos: pos})
+			i = end + 1
+
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			end := i + 1
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			number, err := strconv.ParseFloat(string(runes[i:end]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("column %d: invalid number %s", pos, string(runes[i:end]))
+			}
+			tokens = append(tokens, token{kind: tokenNumber, number: number, pos: pos})
+			i = end
+
+		case unicode.IsLetter(r) || r == '_':
+			end := i + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[i:end]), pos: pos})
+			i = end
+
+		default:
+			rest := string(runes[i:])
+			operator := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(rest, candidate) {
+					operator = candidate
+					break
+				}
+			}
+			switch {
+			case operator != "":
+				tokens = append(tokens, token{kind: tokenOperator, text: operator, pos: pos})
+				i += len([]rune(operator))
+			case r == '=':
+				return nil, fmt.Errorf("column %d: unexpected \"=\" (use == to compare)", pos)
+			case r == '&' || r == '|':
+				return nil, fmt.Errorf("column %d: unexpected %q (use && or ||)", pos, string(r))
+			case r == '\'':
+				return nil, fmt.Errorf("column %d: strings are quoted with \" not '", pos)
+			default:
+				return nil, fmt.Errorf("column %d: unexpected %q", pos, string(r))
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, pos: len(runes) + 1}), nil
+}
@@ -0,0 +1,356 @@
+package query
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// node is a condition of an expression
+type node interface {
+	eval(item models.KanbanItem) bool
+}
+
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+type notNode struct{ operand node }
+type constNode bool
+type boolFieldNode struct{ field field }
+
+func (n andNode) eval(item models.KanbanItem) bool {
+	return n.left.eval(item) && n.right.eval(item)
+}
+
+func (n orNode) eval(item models.KanbanItem) bool {
+	return n.left.eval(item) || n.right.eval(item)
+}
+
+func (n notNode) eval(item models.KanbanItem) bool {
+	return !n.operand.eval(item)
+}
+
+func (n constNode) eval(models.KanbanItem) bool {
+	return bool(n)
+}
+
+func (n boolFieldNode) eval(item models.KanbanItem) bool {
+	return n.field.value(item).(bool)
+}
+
+// compareNode compares a field with a value or another field of its kind
+type compareNode struct {
+	op          string
+	kind        kind
+	left, right func(item models.KanbanItem) interface{}
+}
+
+func (n compareNode) eval(item models.KanbanItem) bool {
+	left, right := n.left(item), n.right(item)
+	switch n.kind {
+	case kindNumber:
+		return compareOrdered(n.op, left.(float64), right.(float64))
+	case kindDate:
+		// Items without the date match no comparison but !=
+		l, r := left.(time.Time), right.(time.Time)
+		if l.IsZero() || r.IsZero() {
+			return n.op == "!="
+		}
+		return compareOrdered(n.op, day(l).Unix(), day(r).Unix())
+	case kindBool:
+		return (left.(bool) == right.(bool)) == (n.op == "==")
+	}
+	return strings.EqualFold(strings.TrimSpace(left.(string)), strings.TrimSpace(right.(string))) == (n.op == "==")
+}
+
+// compareOrdered applies a comparison operator to two ordered values
+func compareOrdered[T float64 | int64](op string, left, right T) bool {
+	switch op {
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	}
+	return left >= right
+}
+
+// day returns the calendar day of a time, so dates compare by day
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// hasNode tests whether a list field holds a value, ignoring case
+type hasNode struct {
+	list  field
+	value string
+}
+
+func (n hasNode) eval(item models.KanbanItem) bool {
+	for _, value := range n.list.value(item).([]string) {
+		if strings.EqualFold(strings.TrimSpace(value), n.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsNode tests whether a text field contains some text, ignoring case
+type containsNode struct {
+	text  field
+	value string
+}
+
+func (n containsNode) eval(item models.KanbanItem) bool {
+	return strings.Contains(strings.ToLower(n.text.value(item).(string)), strings.ToLower(n.value))
+}
+
+// parser builds the conditions of an expression by recursive descent:
+//
+//	expression = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" expression ")" | call | comparison | operand
+//	call       = ("has" | "contains") "(" field "," string ")"
+//	comparison = operand ("==" | "!=" | "<" | "<=" | ">" | ">=") operand
+type parser struct {
+	tokens []token
+	next   int
+}
+
+// peek returns the next token without consuming it
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+// take consumes the next token
+func (p *parser) take() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEnd {
+		p.next++
+	}
+	return t
+}
+
+// isOperator reports whether the next token is one of the operators
+func (p *parser) isOperator(operators ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, operator := range operators {
+		if t.text == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// expect consumes the operator, or fails
+func (p *parser) expect(operator string) error {
+	if !p.isOperator(operator) {
+		t := p.peek()
+		return t.errorf("expected %q, got %s", operator, t)
+	}
+	p.take()
+	return nil
+}
+
+func (p *parser) expression() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.take()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.take()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (node, error) {
+	switch {
+	case p.isOperator("!"):
+		p.take()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	case p.isOperator("("):
+		p.take()
+		inner, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+
+	t := p.peek()
+	if t.kind == tokenIdent && p.tokens[p.next+1].kind == tokenOperator && p.tokens[p.next+1].text == "(" {
+		return p.call()
+	}
+
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	if p.isOperator("==", "!=", "<", "<=", ">", ">=") {
+		op := p.take()
+		right, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		return comparison(op, left, right)
+	}
+	switch {
+	case left.field != nil && left.field.kind == kindBool:
+		return boolFieldNode{*left.field}, nil
+	case left.field == nil && left.kind == kindBool:
+		return constNode(left.literal.(bool)), nil
+	case left.field != nil && left.field.kind == kindList:
+		return nil, left.token.errorf("%s is a list; test it with has(%s, \"value\")", left.token.text, left.token.text)
+	}
+	if left.field != nil {
+		return nil, left.token.errorf("%s is not a condition; compare it with a value, e.g. %s == \"value\"", left.token.text, left.token.text)
+	}
+	return nil, left.token.errorf("%s is not a condition", left.token)
+}
+
+// operand is a field or a literal value
+type operand struct {
+	token   token
+	field   *field
+	kind    kind // Of the literal
+	literal interface{}
+}
+
+func (p *parser) operand() (operand, error) {
+	t := p.take()
+	switch t.kind {
+	case tokenString:
+		return operand{token: t, kind: kindText, literal: t.text}, nil
+	case tokenNumber:
+		return operand{token: t, kind: kindNumber, literal: t.number}, nil
+	case tokenIdent:
+		if t.text == "true" || t.text == "false" {
+			return operand{token: t, kind: kindBool, literal: t.text == "true"}, nil
+		}
+		f, ok := fields[t.text]
+		if !ok {
+			return operand{}, t.errorf("unknown field %q (fields are named like the CSV columns: %s)", t.text, strings.Join(FieldNames(), ", "))
+		}
+		return operand{token: t, field: &f}, nil
+	case tokenEnd:
+		return operand{}, t.errorf("expected a field or value, got %s", t)
+	}
+	return operand{}, t.errorf("unexpected %s", t)
+}
+
+// flipped turns a comparison around, so the field can be on the left
+var flipped = map[string]string{"==": "==", "!=": "!=", "<": ">", "<=": ">=", ">": "<", ">=": "<="}
+
+// comparison checks that the two sides of a comparison have the same kind
+// and builds it. Text given for a date field is parsed as a date.
+func comparison(op token, left, right operand) (node, error) {
+	if left.field == nil {
+		if right.field == nil {
+			return nil, left.token.errorf("compare a field with a value, not two values")
+		}
+		left, right = right, left
+		op.text = flipped[op.text]
+	}
+
+	name := left.token.text
+	k := left.field.kind
+	if k == kindList {
+		return nil, left.token.errorf("%s is a list; test it with has(%s, \"value\")", name, name)
+	}
+	if (op.text != "==" && op.text != "!=") && k != kindNumber && k != kindDate {
+		return nil, op.errorf("%s compares numbers and dates; %s is %s", op.text, name, k)
+	}
+
+	n := compareNode{op: op.text, kind: k, left: left.field.value}
+	switch {
+	case right.field != nil:
+		if right.field.kind != k {
+			return nil, right.token.errorf("cannot compare %s (%s) with %s (%s)", name, k, right.token.text, right.field.kind)
+		}
+		n.right = right.field.value
+	case k == kindDate && right.kind == kindText:
+		date, err := time.Parse(DateFormat, right.literal.(string))
+		if err != nil {
+			return nil, right.token.errorf("invalid date %s for %s (expected YYYY-MM-DD)", right.token, name)
+		}
+		n.right = func(models.KanbanItem) interface{} { return date }
+	case right.kind != k:
+		return nil, right.token.errorf("cannot compare %s (%s) with %s (%s)", name, k, right.token, right.kind)
+	default:
+		value := right.literal
+		n.right = func(models.KanbanItem) interface{} { return value }
+	}
+	return n, nil
+}
+
+// call parses has(list, "value") or contains(text, "value")
+func (p *parser) call() (node, error) {
+	name := p.take()
+	p.take() // (
+	if name.text != "has" && name.text != "contains" {
+		return nil, name.errorf("unknown function %q (functions are has and contains)", name.text)
+	}
+
+	arg, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	value := p.take()
+	if value.kind != tokenString {
+		return nil, value.errorf("%s takes a quoted value, got %s", name.text, value)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case arg.field == nil:
+		return nil, arg.token.errorf("%s takes a field first, got %s", name.text, arg.token)
+	case name.text == "has" && arg.field.kind != kindList:
+		return nil, arg.token.errorf("has takes a list field such as labels or owners; %s is %s", arg.token.text, arg.field.kind)
+	case name.text == "contains" && arg.field.kind != kindText:
+		return nil, arg.token.errorf("contains takes a text field such as name or epic; %s is %s", arg.token.text, arg.field.kind)
+	case name.text == "has":
+		return hasNode{*arg.field, strings.TrimSpace(value.text)}, nil
+	}
+	return containsNode{*arg.field, value.text}, nil
+}
@@ -0,0 +1,163 @@
+// Package query filters items with expressions over their fields, such as
+// team == "Payments" && estimate >= 3 && has(labels, "backend")
+package query
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// DateFormat is the format of dates compared with date fields
+const DateFormat = "2006-01-02"
+
+// kind is the type of a field or literal
+type kind int
+
+const (
+	kindText kind = iota
+	kindNumber
+	kindBool
+	kindDate
+	kindList
+)
+
+// String names the kind in error messages
+func (k kind) String() string {
+	switch k {
+	case kindNumber:
+		return "number"
+	case kindBool:
+		return "true or false"
+	case kindDate:
+		return "date"
+	case kindList:
+		return "list"
+	}
+	return "text"
+}
+
+// field is an item field expressions can test, named like its CSV column
+type field struct {
+	kind  kind
+	value func(item models.KanbanItem) interface{}
+}
+
+func textField(get func(item models.KanbanItem) string) field {
+	return field{kindText, func(item models.KanbanItem) interface{} { return get(item) }}
+}
+
+func numberField(get func(item models.KanbanItem) float64) field {
+	return field{kindNumber, func(item models.KanbanItem) interface{} { return get(item) }}
+}
+
+func boolField(get func(item models.KanbanItem) bool) field {
+	return field{kindBool, func(item models.KanbanItem) interface{} { return get(item) }}
+}
+
+func dateField(get func(item models.KanbanItem) time.Time) field {
+	return field{kindDate, func(item models.KanbanItem) interface{} { return get(item) }}
+}
+
+func listField(get func(item models.KanbanItem) []string) field {
+	return field{kindList, func(item models.KanbanItem) interface{} { return get(item) }}
+}
+
+// fields are the item fields expressions can test
+var fields = map[string]field{
+	"id":                    textField(func(item models.KanbanItem) string { return item.ID }),
+	"name":                  textField(func(item models.KanbanItem) string { return item.Name }),
+	"type":                  textField(func(item models.KanbanItem) string { return item.Type }),
+	"requester":             textField(func(item models.KanbanItem) string { return item.Requester }),
+	"state":                 textField(func(item models.KanbanItem) string { return item.State }),
+	"epic":                  textField(func(item models.KanbanItem) string { return item.Epic }),
+	"epic_state":            textField(func(item models.KanbanItem) string { return item.EpicState }),
+	"team":                  textField(func(item models.KanbanItem) string { return item.Team }),
+	"project":               textField(func(item models.KanbanItem) string { return item.Project }),
+	"iteration":             textField(func(item models.KanbanItem) string { return item.Iteration }),
+	"milestone":             textField(func(item models.KanbanItem) string { return item.Milestone }),
+	"workflow":              textField(func(item models.KanbanItem) string { return item.Workflow }),
+	"priority":              textField(func(item models.KanbanItem) string { return item.Priority }),
+	"severity":              textField(func(item models.KanbanItem) string { return item.Severity }),
+	"product_area":          textField(func(item models.KanbanItem) string { return item.ProductArea }),
+	"skill_set":             textField(func(item models.KanbanItem) string { return item.SkillSet }),
+	"technical_area":        textField(func(item models.KanbanItem) string { return item.TechnicalArea }),
+	"category":              textField(func(item models.KanbanItem) string { return item.Category }),
+	"estimate":              numberField(func(item models.KanbanItem) float64 { return item.Estimate }),
+	"external_ticket_count": numberField(func(item models.KanbanItem) float64 { return float64(item.ExternalTicketCount) }),
+	"is_completed":          boolField(func(item models.KanbanItem) bool { return item.IsCompleted }),
+	"is_blocked":            boolField(func(item models.KanbanItem) bool { return item.IsBlocked }),
+	"is_a_blocker":          boolField(func(item models.KanbanItem) bool { return item.IsABlocker }),
+	"is_archived":           boolField(func(item models.KanbanItem) bool { return item.IsArchived }),
+	"created_at":            dateField(func(item models.KanbanItem) time.Time { return item.CreatedAt }),
+	"started_at":            dateField(func(item models.KanbanItem) time.Time { return item.StartedAt }),
+	"updated_at":            dateField(func(item models.KanbanItem) time.Time { return item.UpdatedAt }),
+	"moved_at":              dateField(func(item models.KanbanItem) time.Time { return item.MovedAt }),
+	"completed_at":          dateField(func(item models.KanbanItem) time.Time { return item.CompletedAt }),
+	"due_date":              dateField(func(item models.KanbanItem) time.Time { return item.DueDate }),
+	"labels":                listField(func(item models.KanbanItem) []string { return item.Labels }),
+	"epic_labels":           listField(func(item models.KanbanItem) []string { return item.EpicLabels }),
+	"owners":                listField(func(item models.KanbanItem) []string { return item.Owners }),
+	"product_areas":         listField(models.KanbanItem.GetProductAreas),
+	"external_tickets":      listField(func(item models.KanbanItem) []string { return item.ExternalTickets }),
+}
+
+// FieldNames lists the fields expressions can test, sorted
+func FieldNames() []string {
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Query is a parsed filter expression
+type Query struct {
+	text string
+	root node
+}
+
+// Parse parses a filter expression. Comparisons (==, !=, <, <=, >, >=) take a
+// field and a value of its type: text and lists of text compare ignoring
+// case, dates are given as "YYYY-MM-DD". has(list, "value") tests a list
+// field and contains(field, "text") part of a text field. Conditions combine
+// with &&, || and !, and parentheses.
+func Parse(text string) (*Query, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEnd {
+		return nil, next.errorf("unexpected %s", next)
+	}
+	return &Query{text: strings.TrimSpace(text), root: root}, nil
+}
+
+// Matches reports whether the item satisfies the expression
+func (q *Query) Matches(item models.KanbanItem) bool {
+	return q.root.eval(item)
+}
+
+// Filter returns the items that satisfy the expression
+func (q *Query) Filter(items []models.KanbanItem) []models.KanbanItem {
+	var kept []models.KanbanItem
+	for _, item := range items {
+		if q.Matches(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// String returns the expression as it was given
+func (q *Query) String() string {
+	return q.text
+}
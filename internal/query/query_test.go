@@ -0,0 +1,147 @@
+package query
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func queryItems() []models.KanbanItem {
+	return []models.KanbanItem{
+		{ID: "1", Name: "Card payments", Team: "Payments", Estimate: 5, Labels: []string{"Backend", "api"},
+			IsCompleted: true, CompletedAt: time.Date(2024, 5, 31, 17, 0, 0, 0, time.UTC)},
+		{ID: "2", Name: "Refund form", Team: "payments", Estimate: 2, Labels: []string{"frontend"}, IsBlocked: true},
+		{ID: "3", Name: "Search index", Team: "Core", Estimate: 8, Labels: []string{"backend"},
+			IsCompleted: true, CompletedAt: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: "4", Name: "Docs", Estimate: 1},
+	}
+}
+
+// matchingIDs returns the IDs of the items the expression keeps
+func matchingIDs(t *testing.T, expression string) string {
+	t.Helper()
+	q, err := Parse(expression)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", expression, err)
+	}
+	var ids []string
+	for _, item := range q.Filter(queryItems()) {
+		ids = append(ids, item.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestQuery_Matches(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{`team == "Payments" && estimate >= 3 && has(labels, "backend")`, "1"},
+		{`team == "payments"`, "1,2"},
+		{`team != "Payments"`, "3,4"},
+		{`team == ""`, "4"},
+		{`estimate > 2 || is_blocked`, "1,2,3"},
+		{`3 <= estimate`, "1,3"},
+		{`!(team == "Core") && !is_blocked`, "1,4"},
+		{`has(labels, "BACKEND")`, "1,3"},
+		{`contains(name, "pay")`, "1"},
+		{`is_completed == false`, "2,4"},
+		{`completed_at <= "2024-05-31"`, "1"},
+		{`completed_at >= "2024-06-01"`, "3"},
+		{`completed_at == "2024-05-31"`, "1"},
+		{`completed_at != "2024-05-31"`, "2,3,4"},
+		{`estimate == -1 || true`, "1,2,3,4"},
+		{`team == "Payments" || team == "Core" && estimate > 5`, "1,2,3"},
+		{`(team == "Payments" || team == "Core") && estimate > 5`, "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			if got := matchingIDs(t, tt.expression); got != tt.want {
+				t.Errorf("Matching items = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_ProductAreas(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", ProductArea: "Payments;Mobile"}, // Not split by the parser
+		{ID: "2", ProductAreas: []string{"Payments", "Web"}},
+		{ID: "3"},
+	}
+
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{`has(product_areas, "Mobile")`, "1"},
+		{`has(product_areas, "payments")`, "1,2"},
+		{`has(product_areas, "")`, ""},
+		{`!has(product_areas, "Web")`, "1,3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			q, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.expression, err)
+			}
+			var ids []string
+			for _, item := range q.Filter(items) {
+				ids = append(ids, item.ID)
+			}
+			if got := strings.Join(ids, ","); got != tt.want {
+				t.Errorf("Matching items = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		expression string
+		errorMsg   string
+	}{
+		{``, "column 1: expected a field or value, got end of expression"},
+		{`team = "Payments"`, `column 6: unexpected "=" (use == to compare)`},
+		{`teem == "Payments"`, `column 1: unknown field "teem"`},
+		{`team == 'Payments'`, "strings are quoted with \" not '"},
+		{`team == "Payments`, "column 9: unterminated string"},
+		{`estimate >= "3"`, `cannot compare estimate (number) with "3" (text)`},
+		{`team > "A"`, "> compares numbers and dates; team is text"},
+		{`labels == "backend"`, `labels is a list; test it with has(labels, "value")`},
+		{`completed_at > "last week"`, `invalid date "last week" for completed_at (expected YYYY-MM-DD)`},
+		{`has(team, "Core")`, "has takes a list field such as labels or owners; team is text"},
+		{`matches(name, "x")`, `unknown function "matches"`},
+		{`team`, `team is not a condition; compare it with a value, e.g. team == "value"`},
+		{`(is_blocked`, `expected ")", got end of expression`},
+		{`is_blocked is_archived`, `column 12: unexpected "is_archived"`},
+		{`"a" == "b"`, "compare a field with a value, not two values"},
+		{`team == "A" & estimate > 1`, `unexpected "&" (use && or ||)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := Parse(tt.expression)
+			if err == nil {
+				t.Fatalf("Parse(%q) expected an error", tt.expression)
+			}
+			if !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.expression, err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestQuery_String(t *testing.T) {
+	q, err := Parse(`  estimate >= 3 `)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if q.String() != "estimate >= 3" {
+		t.Errorf("String() = %q", q.String())
+	}
+}
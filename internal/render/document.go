@@ -0,0 +1,135 @@
+package render
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// ReportDocument is the structured form of a report or metrics run, which a
+// Renderer turns into text, Markdown or HTML
+type ReportDocument struct {
+	Title    string
+	Fields   []Field // Details of the run, such as the date range
+	Sections []Section
+}
+
+// Field is a labelled detail of a run, e.g. "Date Range: All Time"
+type Field struct {
+	Label string
+	Value string
+}
+
+// Section is the output of one report or metrics type
+type Section struct {
+	Name   string // Report or metrics type, used as an anchor in HTML
+	Blocks []Block
+}
+
+// Append adds the fields and sections of another document, e.g. to combine a
+// report with metrics in one document. Fields both documents share, such as
+// the date range, are kept once.
+func (d *ReportDocument) Append(other ReportDocument) {
+	for _, field := range other.Fields {
+		if !d.hasField(field) {
+			d.Fields = append(d.Fields, field)
+		}
+	}
+	d.Sections = append(d.Sections, other.Sections...)
+}
+
+// hasField reports whether the document already has the field
+func (d *ReportDocument) hasField(field Field) bool {
+	for _, existing := range d.Fields {
+		if existing == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Block is one element of a section's content
+type Block interface {
+	isBlock()
+}
+
+// Heading is a section title; level 1 is the top level within a section
+type Heading struct {
+	Level int
+	Text  string
+}
+
+// Paragraph is text kept on separate lines
+type Paragraph struct {
+	Lines []string
+}
+
+// List is a bulleted list
+type List struct {
+	Items []string
+}
+
+// Table is a table with a header row
+type Table struct {
+	Headers []string
+	Rows    [][]string
+	Heatmap bool // A matrix whose last row and column are totals; HTML shades the other cells by value
+}
+
+// Preformatted is text whose layout must be kept, such as aligned columns
+type Preformatted struct {
+	Text string
+}
+
+// Rule separates parts of a section
+type Rule struct{}
+
+// Chart is a figure drawn as SVG in HTML. Other formats show its drawing in
+// text, which keeps its layout like Preformatted.
+type Chart struct {
+	SVG  string
+	Text string
+}
+
+// Image is a picture saved next to the document, such as a chart from
+// --charts-dir. Src is relative to the document. Text output leaves it out.
+type Image struct {
+	Src string
+	Alt string
+}
+
+func (Heading) isBlock()      {}
+func (Paragraph) isBlock()    {}
+func (List) isBlock()         {}
+func (Table) isBlock()        {}
+func (Preformatted) isBlock() {}
+func (Rule) isBlock()         {}
+func (Chart) isBlock()        {}
+func (Image) isBlock()        {}
+
+// DateRangeField describes the date range of a run the same way as the text
+// report headers
+func DateRangeField(startDate, endDate time.Time) Field {
+	switch {
+	case !startDate.IsZero() && !endDate.IsZero():
+		return Field{"Date Range", fmt.Sprintf("%s to %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))}
+	case !startDate.IsZero():
+		return Field{"From", startDate.Format("2006-01-02")}
+	case !endDate.IsZero():
+		return Field{"To", endDate.Format("2006-01-02")}
+	default:
+		return Field{"Date Range", "All Time"}
+	}
+}
+
+// FilterFields describes the ad-hoc filter of a run, if any
+func FilterFields(filter types.AdHocFilterType) []Field {
+	switch filter {
+	case types.AdHocFilterExclude:
+		return []Field{{"Filter", "Excluding ad-hoc requests"}}
+	case types.AdHocFilterOnly:
+		return []Field{{"Filter", "Only ad-hoc requests"}}
+	}
+	return nil
+}
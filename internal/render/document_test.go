@@ -0,0 +1,63 @@
+package render
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestDateRangeField(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		want  Field
+	}{
+		{"Both dates", start, end, Field{"Date Range", "2024-05-01 to 2024-05-31"}},
+		{"Start only", start, time.Time{}, Field{"From", "2024-05-01"}},
+		{"End only", time.Time{}, end, Field{"To", "2024-05-31"}},
+		{"All time", time.Time{}, time.Time{}, Field{"Date Range", "All Time"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DateRangeField(tt.start, tt.end); got != tt.want {
+				t.Errorf("DateRangeField() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterFields(t *testing.T) {
+	if fields := FilterFields(types.AdHocFilterInclude); fields != nil {
+		t.Errorf("FilterFields(include) = %v, want none", fields)
+	}
+	want := []Field{{"Filter", "Only ad-hoc requests"}}
+	if fields := FilterFields(types.AdHocFilterOnly); !reflect.DeepEqual(fields, want) {
+		t.Errorf("FilterFields(only) = %v, want %v", fields, want)
+	}
+}
+
+func TestReportDocumentAppend(t *testing.T) {
+	doc := ReportDocument{
+		Fields:   []Field{{"Report Type", "team"}, {"Date Range", "All Time"}},
+		Sections: []Section{{Name: "team"}},
+	}
+	doc.Append(ReportDocument{
+		Fields:   []Field{{"Metrics Type", "flow"}, {"Date Range", "All Time"}},
+		Sections: []Section{{Name: "flow"}},
+	})
+
+	wantFields := []Field{{"Report Type", "team"}, {"Date Range", "All Time"}, {"Metrics Type", "flow"}}
+	if !reflect.DeepEqual(doc.Fields, wantFields) {
+		t.Errorf("Fields = %v, want %v", doc.Fields, wantFields)
+	}
+	if len(doc.Sections) != 2 || doc.Sections[1].Name != "flow" {
+		t.Errorf("Sections = %v, want team and flow", doc.Sections)
+	}
+}
@@ -0,0 +1,222 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// Explorer is the items behind a document, which the HTML page filters in the
+// browser by team, epic and date, recomputing its totals. Other formats leave
+// it out.
+type Explorer struct {
+	Unit      string // Column title of the amounts, or "" when counting items
+	DateLabel string // What the dates are, e.g. Completed
+	Items     []ExplorerItem
+}
+
+// ExplorerItem is one item of an Explorer, with the names the reports group it by
+type ExplorerItem struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Team   string   `json:"team"`
+	Epic   string   `json:"epic"`
+	Owners []string `json:"owners"`
+	Amount float64  `json:"amount"` // Divided among the owners like the contributor report
+	Date   string   `json:"date"`   // YYYY-MM-DD, or "" when the item doesn't have one
+}
+
+func (Explorer) isBlock() {}
+
+// explorerScript filters the items of each explorer on the page and redraws
+// its chart of items per week and its totals. Dragging across the chart picks
+// the date range.
+const explorerScript = `(function () {
+  "use strict";
+  function addDays(date, days) {
+    var d = new Date(date + "T00:00:00Z");
+    d.setUTCDate(d.getUTCDate() + days);
+    return d.toISOString().slice(0, 10);
+  }
+  // Weeks start on Monday, as in the weekly reports
+  function weekOf(date) {
+    var d = new Date(date + "T00:00:00Z");
+    return addDays(date, -((d.getUTCDay() + 6) % 7));
+  }
+  function element(tag, text, className) {
+    var el = document.createElement(tag);
+    if (text !== undefined) el.textContent = text;
+    if (className) el.className = className;
+    return el;
+  }
+  function svg(tag, attrs) {
+    var el = document.createElementNS("http://www.w3.org/2000/svg", tag);
+    for (var name in attrs) el.setAttribute(name, attrs[name]);
+    return el;
+  }
+
+  document.querySelectorAll(".explorer").forEach(function (root) {
+    var items = JSON.parse(root.querySelector(".explorer-data").textContent);
+    var unit = root.getAttribute("data-unit");
+    var form = root.querySelector("form");
+    var chart = root.querySelector("svg");
+    var results = root.querySelector(".results");
+
+    var weeks = [];
+    var dates = items.map(function (item) { return item.date; }).filter(Boolean).sort();
+    if (dates.length > 0) {
+      for (var week = weekOf(dates[0]); week <= dates[dates.length - 1]; week = addDays(week, 7)) weeks.push(week);
+    }
+
+    function matches(item, withDates) {
+      if (form.team.value && item.team !== form.team.value) return false;
+      if (form.epic.value && item.epic !== form.epic.value) return false;
+      if (!withDates || (!form.from.value && !form.to.value)) return true;
+      if (!item.date) return false;
+      return (!form.from.value || item.date >= form.from.value) && (!form.to.value || item.date <= form.to.value);
+    }
+
+    function totalsTable(title, groups) {
+      var names = Object.keys(groups).sort(function (a, b) {
+        return groups[b].amount - groups[a].amount || groups[b].items - groups[a].items || a.localeCompare(b);
+      });
+      var table = element("table");
+      var head = table.createTHead().insertRow();
+      head.appendChild(element("th", title));
+      if (unit) head.appendChild(element("th", unit, "num"));
+      head.appendChild(element("th", "Items", "num"));
+      var body = table.createTBody();
+      names.forEach(function (name) {
+        var row = body.insertRow();
+        row.appendChild(element("td", name));
+        if (unit) row.appendChild(element("td", groups[name].amount.toFixed(1), "num"));
+        row.appendChild(element("td", String(groups[name].items), "num"));
+      });
+      return table;
+    }
+
+    function drawChart(counts) {
+      while (chart.firstChild) chart.removeChild(chart.firstChild);
+      var width = 600, height = 80, most = Math.max.apply(null, counts.concat(1));
+      var barWidth = width / Math.max(weeks.length, 1);
+      chart.setAttribute("viewBox", "0 0 " + width + " " + height);
+      weeks.forEach(function (week, i) {
+        var end = addDays(week, 6);
+        var selected = (!form.from.value || end >= form.from.value) && (!form.to.value || week <= form.to.value);
+        var barHeight = counts[i] / most * (height - 4);
+        var bar = svg("rect", {x: i * barWidth + 1, y: height - barHeight, width: Math.max(barWidth - 2, 1), height: barHeight, "class": selected ? "bar" : "bar dimmed"});
+        bar.appendChild(svg("title", {})).textContent = week + ": " + counts[i] + " items";
+        chart.appendChild(bar);
+      });
+    }
+
+    function update() {
+      var byTeam = {}, byEpic = {}, byOwner = {}, total = {amount: 0, items: 0};
+      function add(groups, name, amount) {
+        groups[name] = groups[name] || {amount: 0, items: 0};
+        groups[name].amount += amount;
+        groups[name].items++;
+      }
+      var counts = weeks.map(function () { return 0; });
+      items.forEach(function (item) {
+        if (matches(item, false) && item.date) counts[Math.round((Date.parse(weekOf(item.date)) - Date.parse(weeks[0])) / 6048e5)]++;
+        if (!matches(item, true)) return;
+        add(byTeam, item.team, item.amount);
+        add(byEpic, item.epic, item.amount);
+        item.owners.forEach(function (owner) { add(byOwner, owner, item.amount / item.owners.length); });
+        total.amount += item.amount;
+        total.items++;
+      });
+      drawChart(counts);
+
+      while (results.firstChild) results.removeChild(results.firstChild);
+      var summary = total.items + " items";
+      if (unit) summary = total.amount.toFixed(1) + " " + unit.toLowerCase() + " across " + summary;
+      results.appendChild(element("p", "Matching: " + summary));
+      [["Team", byTeam], ["Epic", byEpic], ["Contributor", byOwner]].forEach(function (part) {
+        results.appendChild(element("h3", "By " + part[0]));
+        results.appendChild(totalsTable(part[0], part[1]));
+      });
+    }
+
+    // Dragging across the chart selects whole weeks
+    var dragFrom = null;
+    function weekAt(event) {
+      var box = chart.getBoundingClientRect();
+      var i = Math.floor((event.clientX - box.left) / box.width * weeks.length);
+      return Math.min(Math.max(i, 0), weeks.length - 1);
+    }
+    function brush(event) {
+      var to = weekAt(event);
+      form.from.value = weeks[Math.min(dragFrom, to)];
+      form.to.value = addDays(weeks[Math.max(dragFrom, to)], 6);
+      update();
+    }
+    chart.addEventListener("pointerdown", function (event) {
+      if (weeks.length === 0) return;
+      dragFrom = weekAt(event);
+      chart.setPointerCapture(event.pointerId);
+      brush(event);
+    });
+    chart.addEventListener("pointermove", function (event) { if (dragFrom !== null) brush(event); });
+    chart.addEventListener("pointerup", function () { dragFrom = null; });
+
+    form.addEventListener("input", update);
+    form.addEventListener("change", update);
+    form.addEventListener("reset", function () { setTimeout(update, 0); });
+    update();
+  });
+})();
+`
+
+// htmlExplorer formats the controls, the embedded items and the script of an explorer
+func htmlExplorer(explorer Explorer) string {
+	// Marshal escapes <, > and &, so the items can't end the script element
+	data, err := json.Marshal(explorer.Items)
+	if err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<div class=\"explorer\" data-unit=\"%s\">\n<form class=\"filters\">\n", html.EscapeString(explorer.Unit))
+	b.WriteString(htmlSelect("Team", "team", explorer.values(func(item ExplorerItem) string { return item.Team })))
+	b.WriteString(htmlSelect("Epic", "epic", explorer.values(func(item ExplorerItem) string { return item.Epic })))
+	dateLabel := html.EscapeString(explorer.DateLabel)
+	fmt.Fprintf(&b, "<label>%s from <input type=\"date\" name=\"from\"></label>\n", dateLabel)
+	fmt.Fprintf(&b, "<label>%s to <input type=\"date\" name=\"to\"></label>\n", dateLabel)
+	b.WriteString("<button type=\"reset\">Clear</button>\n</form>\n")
+	fmt.Fprintf(&b, "<svg class=\"brush\" role=\"img\" aria-label=\"Items per week by %s date; drag to pick dates\"></svg>\n", strings.ToLower(dateLabel))
+	b.WriteString("<div class=\"results\"></div>\n")
+	b.WriteString("<noscript><p>Filtering the items needs JavaScript.</p></noscript>\n")
+	fmt.Fprintf(&b, "<script type=\"application/json\" class=\"explorer-data\">%s</script>\n", data)
+	b.WriteString("<script>\n" + explorerScript + "</script>\n</div>\n")
+	return b.String()
+}
+
+// htmlSelect formats a dropdown of the values, with an option for all of them
+func htmlSelect(label, name string, values []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<label>%s <select name=\"%s\">\n<option value=\"\">All</option>\n", label, name)
+	for _, value := range values {
+		escaped := html.EscapeString(value)
+		fmt.Fprintf(&b, "<option value=\"%s\">%s</option>\n", escaped, escaped)
+	}
+	b.WriteString("</select></label>\n")
+	return b.String()
+}
+
+// values returns the distinct values of a field of the items, sorted
+func (e Explorer) values(field func(ExplorerItem) string) []string {
+	seen := make(map[string]bool)
+	var values []string
+	for _, item := range e.Items {
+		if value := field(item); !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
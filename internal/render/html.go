@@ -0,0 +1,161 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// htmlStyle is the stylesheet embedded in HTML output, so the page can be
+// shared as a single file
+const htmlStyle = `body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; color: #1f2328; line-height: 1.5; margin: 0; background: #f6f8fa; }
+main { max-width: 960px; margin: 2rem auto; padding: 2rem; background: #fff; border: 1px solid #d0d7de; border-radius: 6px; }
+h1 { margin-top: 0; border-bottom: 1px solid #d0d7de; padding-bottom: 0.3em; }
+dl.fields { display: grid; grid-template-columns: max-content auto; gap: 0.2rem 1rem; margin: 0 0 1.5rem; }
+dl.fields dt { font-weight: 600; }
+dl.fields dd { margin: 0; }
+section { border-top: 1px solid #d0d7de; padding-top: 1rem; margin-top: 1.5rem; }
+table { border-collapse: collapse; margin: 1rem 0; font-variant-numeric: tabular-nums; }
+th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.75rem; text-align: left; }
+th { background: #f6f8fa; }
+tbody tr:nth-child(even) { background: #f9fafb; }
+.num { text-align: right; }
+pre { background: #f6f8fa; padding: 1rem; overflow-x: auto; border-radius: 6px; }
+figure.chart { margin: 1rem 0; overflow-x: auto; }
+figure.chart img { max-width: 100%; }
+.explorer form { display: flex; flex-wrap: wrap; gap: 0.5rem 1rem; align-items: center; }
+.explorer svg { display: block; width: 100%; height: 80px; margin: 1rem 0; cursor: crosshair; touch-action: none; }
+.explorer .bar { fill: #0969da; }
+.explorer .bar.dimmed { fill: #d0d7de; }
+`
+
+// boldPattern matches **bold** text, which the text output uses for emphasis
+var boldPattern = regexp.MustCompile(`\*\*(.+?)\*\*`)
+
+// HTMLRenderer renders a document as a standalone, styled HTML page
+type HTMLRenderer struct{}
+
+// Render renders the document as HTML. Section headings are nested one level
+// below the document title.
+func (HTMLRenderer) Render(doc ReportDocument) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(doc.Title))
+	b.WriteString("<style>\n" + htmlStyle + "</style>\n</head>\n<body>\n<main>\n")
+
+	if doc.Title != "" {
+		fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(doc.Title))
+	}
+	if len(doc.Fields) > 0 {
+		b.WriteString("<dl class=\"fields\">\n")
+		for _, field := range doc.Fields {
+			fmt.Fprintf(&b, "<dt>%s</dt><dd>%s</dd>\n", html.EscapeString(field.Label), html.EscapeString(field.Value))
+		}
+		b.WriteString("</dl>\n")
+	}
+
+	for _, section := range doc.Sections {
+		if section.Name != "" {
+			fmt.Fprintf(&b, "<section id=\"%s\">\n", html.EscapeString(section.Name))
+		} else {
+			b.WriteString("<section>\n")
+		}
+		for _, block := range section.Blocks {
+			switch block := block.(type) {
+			case Heading:
+				level := min(block.Level+1, 6)
+				fmt.Fprintf(&b, "<h%d>%s</h%d>\n", level, inlineHTML(block.Text), level)
+			case Paragraph:
+				lines := make([]string, len(block.Lines))
+				for i, line := range block.Lines {
+					lines[i] = inlineHTML(line)
+				}
+				b.WriteString("<p>" + strings.Join(lines, "<br>\n") + "</p>\n")
+			case List:
+				b.WriteString("<ul>\n")
+				for _, item := range block.Items {
+					b.WriteString("<li>" + inlineHTML(item) + "</li>\n")
+				}
+				b.WriteString("</ul>\n")
+			case Table:
+				b.WriteString(htmlTable(block))
+			case Preformatted:
+				b.WriteString("<pre>" + html.EscapeString(block.Text) + "</pre>\n")
+			case Rule:
+				b.WriteString("<hr>\n")
+			case Chart:
+				b.WriteString("<figure class=\"chart\">\n" + block.SVG + "\n</figure>\n")
+			case Image:
+				fmt.Fprintf(&b, "<figure class=\"chart\"><img src=\"%s\" alt=\"%s\"></figure>\n", html.EscapeString(block.Src), html.EscapeString(block.Alt))
+			case Explorer:
+				b.WriteString(htmlExplorer(block))
+			}
+		}
+		b.WriteString("</section>\n")
+	}
+
+	b.WriteString("</main>\n</body>\n</html>\n")
+	return b.String()
+}
+
+// htmlTable formats a table as HTML, right-aligning numbers and shading the
+// cells of heatmaps
+func htmlTable(table Table) string {
+	numeric := numericColumns(table)
+	cell := func(tag string, i int, text string) string {
+		if numeric[i] {
+			return fmt.Sprintf("<%s class=\"num\">%s</%s>", tag, inlineHTML(text), tag)
+		}
+		return fmt.Sprintf("<%s>%s</%s>", tag, inlineHTML(text), tag)
+	}
+
+	// Heatmap cells are shaded relative to the largest value, leaving out the
+	// names in the first column and the totals in the last row and column
+	heat := func(r, i int) (float64, bool) {
+		if !table.Heatmap || i == 0 || i >= len(table.Headers)-1 || r >= len(table.Rows)-1 || i >= len(table.Rows[r]) {
+			return 0, false
+		}
+		value, err := strconv.ParseFloat(table.Rows[r][i], 64)
+		return value, err == nil && value > 0
+	}
+	hottest := 0.0
+	for r := range table.Rows {
+		for i := range table.Headers {
+			if value, ok := heat(r, i); ok {
+				hottest = max(hottest, value)
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for i, header := range table.Headers {
+		b.WriteString(cell("th", i, header))
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for r, row := range table.Rows {
+		b.WriteString("<tr>")
+		for i := range table.Headers {
+			text := ""
+			if i < len(row) {
+				text = row[i]
+			}
+			if value, ok := heat(r, i); ok {
+				fmt.Fprintf(&b, "<td class=\"num heat\" style=\"background: rgba(9, 105, 218, %.2f)\">%s</td>", 0.05+0.45*value/hottest, inlineHTML(text))
+				continue
+			}
+			b.WriteString(cell("td", i, text))
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return b.String()
+}
+
+// inlineHTML escapes text for HTML, keeping **bold** emphasis
+func inlineHTML(text string) string {
+	return boldPattern.ReplaceAllString(html.EscapeString(text), "<strong>$1</strong>")
+}
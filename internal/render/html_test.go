@@ -0,0 +1,141 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLRenderer(t *testing.T) {
+	output := HTMLRenderer{}.Render(sampleDocument())
+
+	expected := []string{
+		"<!DOCTYPE html>",
+		"<title>Kanban Report</title>",
+		"<style>",
+		"<h1>Kanban Report</h1>",
+		"<dt>Report Type</dt><dd>team</dd>",
+		`<section id="team">`,
+		"<h2>Story Points by Team</h2>",
+		`<tr><th>Team</th><th class="num">Points</th><th class="num">Share</th></tr>`,
+		`<tr><td>Team &lt;Alpha&gt;</td><td class="num">11.0</td><td class="num">61.1%</td></tr>`,
+		"<p>Total: <strong>18.0</strong> points<br>\nacross 4 items</p>",
+		"<ul>\n<li>First</li>\n<li>Second</li>\n</ul>",
+		"<pre>Insights    11.0 points\n  Analytics 11.0 points</pre>",
+		"<hr>",
+		"</html>\n",
+	}
+	for _, str := range expected {
+		if !strings.Contains(output, str) {
+			t.Errorf("Output doesn't contain expected string: %q\nGot:\n%s", str, output)
+		}
+	}
+}
+
+func TestHTMLRenderer_EscapesText(t *testing.T) {
+	doc := ReportDocument{
+		Title:    "<script>",
+		Sections: []Section{{Name: `"x"`, Blocks: []Block{Preformatted{"a < b & c"}}}},
+	}
+
+	output := HTMLRenderer{}.Render(doc)
+	if strings.Contains(output, "<script>") {
+		t.Errorf("Render() didn't escape the title:\n%s", output)
+	}
+	for _, str := range []string{"&lt;script&gt;", `<section id="&#34;x&#34;">`, "<pre>a &lt; b &amp; c</pre>"} {
+		if !strings.Contains(output, str) {
+			t.Errorf("Output doesn't contain expected string: %q\nGot:\n%s", str, output)
+		}
+	}
+}
+
+func TestHTMLTable_Heatmap(t *testing.T) {
+	table := Table{
+		Headers: []string{"Team", "2024-01", "2024-02", "Total"},
+		Rows: [][]string{
+			{"Alpha", "4.0", "", "4.0"},
+			{"Beta", "2.0", "8.0", "10.0"},
+			{"Total", "6.0", "8.0", "14.0"},
+		},
+		Heatmap: true,
+	}
+
+	output := htmlTable(table)
+	for _, str := range []string{
+		`<td class="num heat" style="background: rgba(9, 105, 218, 0.28)">4.0</td><td class="num"></td>`,
+		`<td class="num heat" style="background: rgba(9, 105, 218, 0.50)">8.0</td><td class="num">10.0</td>`,
+		`<tr><td>Total</td><td class="num">6.0</td><td class="num">8.0</td><td class="num">14.0</td></tr>`,
+	} {
+		if !strings.Contains(output, str) {
+			t.Errorf("Output doesn't contain expected string: %q\nGot:\n%s", str, output)
+		}
+	}
+
+	table.Heatmap = false
+	if strings.Contains(htmlTable(table), "heat") {
+		t.Errorf("htmlTable() shaded a table that isn't a heatmap")
+	}
+}
+
+func TestHTMLRenderer_Explorer(t *testing.T) {
+	doc := ReportDocument{Sections: []Section{{Name: "explorer", Blocks: []Block{Explorer{
+		Unit:      "Points",
+		DateLabel: "Completed",
+		Items: []ExplorerItem{
+			{ID: "1", Name: "</script><b>", Team: "Beta", Epic: "Search", Owners: []string{"Ann"}, Amount: 3, Date: "2024-05-07"},
+			{ID: "2", Name: "Fix", Team: "Alpha & Co", Epic: "Search", Owners: []string{"Bo"}, Amount: 1},
+		},
+	}}}}}
+
+	output := HTMLRenderer{}.Render(doc)
+	for _, str := range []string{
+		`<div class="explorer" data-unit="Points">`,
+		"<label>Team <select name=\"team\">\n<option value=\"\">All</option>\n<option value=\"Alpha &amp; Co\">Alpha &amp; Co</option>\n<option value=\"Beta\">Beta</option>\n</select></label>",
+		"<option value=\"Search\">Search</option>\n</select>",
+		`<label>Completed from <input type="date" name="from"></label>`,
+		`<script type="application/json" class="explorer-data">[{"id":"1","name":"\u003c/script\u003e\u003cb\u003e"`,
+		`"amount":1,"date":""}]</script>`,
+		"document.querySelectorAll(\".explorer\")",
+	} {
+		if !strings.Contains(output, str) {
+			t.Errorf("Output doesn't contain expected string: %q\nGot:\n%s", str, output)
+		}
+	}
+
+	if strings.Contains(output, "</script><b>") {
+		t.Errorf("Render() let an item name end the script element:\n%s", output)
+	}
+	if markdown := (MarkdownRenderer{}).Render(doc); strings.Contains(markdown, "Alpha") {
+		t.Errorf("MarkdownRenderer rendered the explorer:\n%s", markdown)
+	}
+}
+
+func TestRenderers_Chart(t *testing.T) {
+	doc := ReportDocument{Sections: []Section{{Name: "scatterplot", Blocks: []Block{Chart{
+		SVG:  `<svg xmlns="http://www.w3.org/2000/svg"><circle r="1"/></svg>`,
+		Text: "  10.0 | *",
+	}}}}}
+
+	if output := (HTMLRenderer{}).Render(doc); !strings.Contains(output, "<figure class=\"chart\">\n<svg xmlns=\"http://www.w3.org/2000/svg\"><circle r=\"1\"/></svg>\n</figure>") {
+		t.Errorf("HTMLRenderer didn't embed the SVG:\n%s", output)
+	}
+	if output := (MarkdownRenderer{}).Render(doc); !strings.Contains(output, "```text\n  10.0 | *\n```") || strings.Contains(output, "<svg") {
+		t.Errorf("MarkdownRenderer didn't fall back to the text chart:\n%s", output)
+	}
+	if output := (TextRenderer{}).Render(doc); !strings.Contains(output, "  10.0 | *") {
+		t.Errorf("TextRenderer didn't write the text chart:\n%s", output)
+	}
+}
+
+func TestRenderers_Image(t *testing.T) {
+	doc := ReportDocument{Sections: []Section{{Name: "charts", Blocks: []Block{Image{Src: "charts/cfd (1).svg", Alt: "Flow [weekly]"}}}}}
+
+	if output := (HTMLRenderer{}).Render(doc); !strings.Contains(output, `<figure class="chart"><img src="charts/cfd (1).svg" alt="Flow [weekly]"></figure>`) {
+		t.Errorf("HTMLRenderer didn't show the image:\n%s", output)
+	}
+	if output := (MarkdownRenderer{}).Render(doc); !strings.Contains(output, `![Flow \[weekly\]](<charts/cfd (1).svg>)`) {
+		t.Errorf("MarkdownRenderer didn't show the image:\n%s", output)
+	}
+	if output := (TextRenderer{}).Render(doc); strings.Contains(output, "cfd") {
+		t.Errorf("TextRenderer wrote the image:\n%s", output)
+	}
+}
@@ -0,0 +1,86 @@
+package render
+
+import (
+	"strings"
+)
+
+// MarkdownRenderer renders a document as GitHub-flavored Markdown
+type MarkdownRenderer struct{}
+
+// Render renders the document as Markdown. Section headings are nested one
+// level below the document title.
+func (MarkdownRenderer) Render(doc ReportDocument) string {
+	var b strings.Builder
+	if doc.Title != "" {
+		b.WriteString("# " + doc.Title + "\n\n")
+	}
+	for _, field := range doc.Fields {
+		b.WriteString("- **" + field.Label + ":** " + field.Value + "\n")
+	}
+	if len(doc.Fields) > 0 {
+		b.WriteString("\n")
+	}
+
+	for i, section := range doc.Sections {
+		if i > 0 {
+			b.WriteString("---\n\n")
+		}
+		for _, block := range section.Blocks {
+			switch block := block.(type) {
+			case Heading:
+				b.WriteString(strings.Repeat("#", min(block.Level+1, 6)) + " " + block.Text + "\n\n")
+			case Paragraph:
+				// A trailing backslash keeps the line breaks of the text output
+				b.WriteString(strings.Join(block.Lines, "\\\n") + "\n\n")
+			case List:
+				for _, item := range block.Items {
+					b.WriteString("- " + item + "\n")
+				}
+				b.WriteString("\n")
+			case Table:
+				b.WriteString(markdownTable(block) + "\n")
+			case Preformatted:
+				b.WriteString("```text\n" + block.Text + "\n```\n\n")
+			case Chart:
+				b.WriteString("```text\n" + block.Text + "\n```\n\n")
+			case Image:
+				// Angle brackets keep paths with spaces or parentheses whole
+				b.WriteString("![" + strings.NewReplacer("[", "\\[", "]", "\\]").Replace(block.Alt) + "](<" + block.Src + ">)\n\n")
+			case Rule:
+				b.WriteString("***\n\n")
+			}
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// markdownTable formats a table as a Markdown pipe table, right-aligning numbers
+func markdownTable(table Table) string {
+	numeric := numericColumns(table)
+	escape := strings.NewReplacer("|", `\|`)
+
+	formatRow := func(row []string) string {
+		cells := make([]string, len(table.Headers))
+		for i := range cells {
+			if i < len(row) {
+				cells[i] = escape.Replace(row[i])
+			}
+		}
+		return "| " + strings.Join(cells, " | ") + " |\n"
+	}
+
+	text := formatRow(table.Headers)
+	alignments := make([]string, len(table.Headers))
+	for i := range alignments {
+		alignments[i] = "---"
+		if numeric[i] {
+			alignments[i] = "---:"
+		}
+	}
+	text += "| " + strings.Join(alignments, " | ") + " |\n"
+	for _, row := range table.Rows {
+		text += formatRow(row)
+	}
+	return text
+}
@@ -0,0 +1,42 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownRenderer(t *testing.T) {
+	output := MarkdownRenderer{}.Render(sampleDocument())
+
+	expected := []string{
+		"# Kanban Report\n\n",
+		"- **Report Type:** team\n- **Date Range:** All Time\n",
+		"## Story Points by Team\n",
+		"| Team | Points | Share |\n| --- | ---: | ---: |\n| Team <Alpha> | 11.0 | 61.1% |\n",
+		"Total: **18.0** points\\\nacross 4 items",
+		"---\n\n- First\n- Second",
+		"```text\nInsights    11.0 points\n  Analytics 11.0 points\n```",
+		"***",
+	}
+	for _, str := range expected {
+		if !strings.Contains(output, str) {
+			t.Errorf("Output doesn't contain expected string: %q\nGot:\n%s", str, output)
+		}
+	}
+}
+
+func TestMarkdownTable_EscapesPipes(t *testing.T) {
+	table := Table{Headers: []string{"Epic"}, Rows: [][]string{{"A|B"}, {"C"}}}
+
+	if output := markdownTable(table); !strings.Contains(output, `| A\|B |`) {
+		t.Errorf("markdownTable() didn't escape the pipe:\n%s", output)
+	}
+}
+
+func TestMarkdownRenderer_HeadingLevels(t *testing.T) {
+	doc := ReportDocument{Sections: []Section{{Blocks: []Block{Heading{6, "Deep"}}}}}
+
+	if output := (MarkdownRenderer{}).Render(doc); output != "###### Deep\n" {
+		t.Errorf("Render() = %q, want a level 6 heading", output)
+	}
+}
@@ -0,0 +1,133 @@
+package render
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parse splits the text of a report or metrics section into blocks. It
+// recognizes the conventions the text output already follows: "#" headings,
+// "Title:" headings followed by a blank line, "- " lists, tables with a
+// dashed separator row, and aligned columns, which are kept as preformatted.
+func Parse(text string) []Block {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	var blocks []Block
+	titles := 0
+
+	for i := 0; i < len(lines); {
+		line := strings.TrimRight(lines[i], " ")
+
+		switch {
+		case line == "":
+			i++
+
+		case isMarkdownHeading(line):
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			blocks = append(blocks, Heading{level, strings.TrimSpace(line[level:])})
+			i++
+
+		case isRule(line):
+			blocks = append(blocks, Rule{})
+			i++
+
+		case i+1 < len(lines) && strings.Contains(line, "|") && isTableSeparator(lines[i+1]):
+			table := Table{Headers: splitCells(line)}
+			i += 2
+			for ; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+				table.Rows = append(table.Rows, splitCells(lines[i]))
+			}
+			blocks = append(blocks, table)
+
+		case isListItem(line):
+			var list List
+			for ; i < len(lines) && isListItem(lines[i]); i++ {
+				item := strings.TrimPrefix(strings.TrimPrefix(lines[i], "- "), "• ")
+				list.Items = append(list.Items, strings.TrimSpace(item))
+			}
+			blocks = append(blocks, list)
+
+		case isTitle(line) && (i+1 == len(lines) || strings.TrimSpace(lines[i+1]) == ""):
+			// The first title names the report; later ones are its subsections
+			blocks = append(blocks, Heading{min(titles+1, 2), strings.TrimSuffix(line, ":")})
+			titles++
+			i++
+
+		case isAligned(line):
+			var pre []string
+			for ; i < len(lines) && isAligned(strings.TrimRight(lines[i], " ")); i++ {
+				pre = append(pre, strings.TrimRight(lines[i], " "))
+			}
+			blocks = append(blocks, Preformatted{strings.Join(pre, "\n")})
+
+		default:
+			var paragraph Paragraph
+			for ; i < len(lines); i++ {
+				next := strings.TrimRight(lines[i], " ")
+				if next == "" || isMarkdownHeading(next) || isRule(next) || isListItem(next) || isAligned(next) ||
+					(i+1 < len(lines) && strings.Contains(next, "|") && isTableSeparator(lines[i+1])) {
+					break
+				}
+				paragraph.Lines = append(paragraph.Lines, next)
+			}
+			blocks = append(blocks, paragraph)
+		}
+	}
+
+	return blocks
+}
+
+// isMarkdownHeading reports whether a line is a "#" heading
+func isMarkdownHeading(line string) bool {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	return level > 0 && level <= 6 && strings.HasPrefix(line[level:], " ")
+}
+
+// isRule reports whether a line is a separator made of one repeated symbol
+func isRule(line string) bool {
+	first, _ := utf8.DecodeRuneInString(line)
+	if utf8.RuneCountInString(line) < 3 || unicode.IsLetter(first) || unicode.IsDigit(first) || first == '#' {
+		return false
+	}
+	return strings.Trim(line, string(first)) == ""
+}
+
+// isTableSeparator reports whether a line is the dashed row under a table header
+func isTableSeparator(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.Contains(line, "|") && strings.Contains(line, "-") && strings.Trim(line, "-|: ") == ""
+}
+
+// splitCells splits a table row into its trimmed cells
+func splitCells(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := strings.Split(line, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
+
+// isListItem reports whether a line is a "- " list item
+func isListItem(line string) bool {
+	return strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "• ")
+}
+
+// isTitle reports whether a line is a "Title:" heading of the text reports
+func isTitle(line string) bool {
+	return strings.HasSuffix(line, ":") && !strings.HasPrefix(line, " ") && !strings.Contains(line, "|")
+}
+
+// isAligned reports whether a line is laid out in columns or indented, so
+// its spacing has to be kept. Spaces after a leading marker such as "⚠️  "
+// don't count.
+func isAligned(line string) bool {
+	if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+		return true
+	}
+	text := strings.TrimLeftFunc(line, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+	})
+	return strings.Contains(strings.TrimLeft(text, " "), "  ")
+}
@@ -0,0 +1,80 @@
+package render
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	text := `# Throughput Analysis by Month
+
+## How to use this data:
+- Use average throughput to forecast
+- Look for trends
+
+Month | Items Completed | Story Points
+------|-----------------|-------------
+2024-05 |               3 |         8.0
+2024-06 |               2 |        11.0
+
+Flow Efficiency: 72.7%
+Based on 5 items.
+
+⚠️  High-priority work is above 70% of effort.
+
+================================================================================
+
+Story Points by Team:
+
+Team Beta                        11.0 points    2 items
+  Sub team                        2.0 points
+
+Total: 11.0 points across 2 items
+`
+
+	want := []Block{
+		Heading{1, "Throughput Analysis by Month"},
+		Heading{2, "How to use this data:"},
+		List{[]string{"Use average throughput to forecast", "Look for trends"}},
+		Table{
+			Headers: []string{"Month", "Items Completed", "Story Points"},
+			Rows:    [][]string{{"2024-05", "3", "8.0"}, {"2024-06", "2", "11.0"}},
+		},
+		Paragraph{[]string{"Flow Efficiency: 72.7%", "Based on 5 items."}},
+		Paragraph{[]string{"⚠️  High-priority work is above 70% of effort."}},
+		Rule{},
+		Heading{1, "Story Points by Team"},
+		Preformatted{"Team Beta                        11.0 points    2 items\n  Sub team                        2.0 points"},
+		Paragraph{[]string{"Total: 11.0 points across 2 items"}},
+	}
+
+	got := Parse(text)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParse_LaterTitlesAreSubsections(t *testing.T) {
+	text := "Cross-Epic Contention:\n\nContributors working on 3 or more epics at the same time:\n\nNo contributor.\n"
+
+	want := []Block{
+		Heading{1, "Cross-Epic Contention"},
+		Heading{2, "Contributors working on 3 or more epics at the same time"},
+		Paragraph{[]string{"No contributor."}},
+	}
+	if got := Parse(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %#v, want %#v", got, want)
+	}
+}
+
+func TestParse_TitleNeedsBlankLine(t *testing.T) {
+	text := "This analysis shows:\n- How much time is spent\n"
+
+	want := []Block{
+		Paragraph{[]string{"This analysis shows:"}},
+		List{[]string{"How much time is spent"}},
+	}
+	if got := Parse(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// Renderer turns a ReportDocument into output in one format
+type Renderer interface {
+	Render(doc ReportDocument) string
+}
+
+// ForFormat returns the renderer for an output format. JSON is not rendered
+// from documents, since it holds the structured results instead.
+func ForFormat(format types.OutputFormat) (Renderer, error) {
+	switch format {
+	case types.FormatText, "":
+		return TextRenderer{}, nil
+	case types.FormatMarkdown:
+		return MarkdownRenderer{}, nil
+	case types.FormatHTML:
+		return HTMLRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("no document renderer for output format: %s", format)
+	}
+}
+
+// TextRenderer renders a document as plain text with aligned tables
+type TextRenderer struct{}
+
+// Render renders the document as plain text
+func (TextRenderer) Render(doc ReportDocument) string {
+	var b strings.Builder
+	if doc.Title != "" {
+		b.WriteString(doc.Title + "\n" + strings.Repeat("=", utf8.RuneCountInString(doc.Title)) + "\n\n")
+	}
+	for _, field := range doc.Fields {
+		fmt.Fprintf(&b, "%s: %s\n", field.Label, field.Value)
+	}
+	if len(doc.Fields) > 0 {
+		b.WriteString("\n")
+	}
+
+	for i, section := range doc.Sections {
+		if i > 0 {
+			b.WriteString(strings.Repeat("=", 80) + "\n\n")
+		}
+		for _, block := range section.Blocks {
+			switch block := block.(type) {
+			case Heading:
+				b.WriteString(strings.Repeat("#", block.Level) + " " + block.Text + "\n\n")
+			case Paragraph:
+				b.WriteString(strings.Join(block.Lines, "\n") + "\n\n")
+			case List:
+				for _, item := range block.Items {
+					b.WriteString("- " + item + "\n")
+				}
+				b.WriteString("\n")
+			case Table:
+				b.WriteString(textTable(block) + "\n")
+			case Preformatted:
+				b.WriteString(block.Text + "\n\n")
+			case Chart:
+				b.WriteString(block.Text + "\n\n")
+			case Rule:
+				b.WriteString(strings.Repeat("-", 80) + "\n\n")
+			}
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// textTable lays out a table in aligned columns, right-aligning numbers
+func textTable(table Table) string {
+	numeric := numericColumns(table)
+	widths := make([]int, len(table.Headers))
+	for _, row := range append([][]string{table.Headers}, table.Rows...) {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+			}
+		}
+	}
+
+	formatRow := func(row []string) string {
+		cells := make([]string, len(widths))
+		for i := range widths {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+			if numeric[i] {
+				cells[i] = padding + cell
+			} else {
+				cells[i] = cell + padding
+			}
+		}
+		return strings.TrimRight(strings.Join(cells, " | "), " ") + "\n"
+	}
+
+	text := formatRow(table.Headers)
+	dashes := make([]string, len(widths))
+	for i, width := range widths {
+		dashes[i] = strings.Repeat("-", width)
+	}
+	text += strings.Join(dashes, "-|-") + "\n"
+	for _, row := range table.Rows {
+		text += formatRow(row)
+	}
+	return text
+}
+
+// numberPattern matches cells holding a number, optionally with a unit such
+// as "%" or "d"
+var numberPattern = regexp.MustCompile(`^[-+]?[0-9][0-9.,]*\s*(%|d|h|days|x|×)?$`)
+
+// numericColumns reports which columns of a table hold only numbers, so they
+// can be right-aligned. Empty cells and "-" don't count either way.
+func numericColumns(table Table) []bool {
+	numeric := make([]bool, len(table.Headers))
+	for i := range numeric {
+		numbers := 0
+		numeric[i] = true
+		for _, row := range table.Rows {
+			if i >= len(row) || row[i] == "" || row[i] == "-" {
+				continue
+			}
+			if !numberPattern.MatchString(row[i]) {
+				numeric[i] = false
+				break
+			}
+			numbers++
+		}
+		numeric[i] = numeric[i] && numbers > 0
+	}
+	return numeric
+}
@@ -0,0 +1,93 @@
+package render
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// sampleDocument returns a document using every kind of block
+func sampleDocument() ReportDocument {
+	return ReportDocument{
+		Title:  "Kanban Report",
+		Fields: []Field{{"Report Type", "team"}, {"Date Range", "All Time"}},
+		Sections: []Section{
+			{Name: "team", Blocks: []Block{
+				Heading{1, "Story Points by Team"},
+				Table{Headers: []string{"Team", "Points", "Share"}, Rows: [][]string{
+					{"Team <Alpha>", "11.0", "61.1%"},
+					{"Team Beta", "7.0", "38.9%"},
+				}},
+				Paragraph{[]string{"Total: **18.0** points", "across 4 items"}},
+			}},
+			{Name: "hierarchy", Blocks: []Block{
+				List{[]string{"First", "Second"}},
+				Preformatted{"Insights    11.0 points\n  Analytics 11.0 points"},
+				Rule{},
+			}},
+		},
+	}
+}
+
+func TestForFormat(t *testing.T) {
+	tests := []struct {
+		format    types.OutputFormat
+		want      Renderer
+		expectErr bool
+	}{
+		{types.FormatText, TextRenderer{}, false},
+		{types.FormatMarkdown, MarkdownRenderer{}, false},
+		{types.FormatHTML, HTMLRenderer{}, false},
+		{types.FormatJSON, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			got, err := ForFormat(tt.format)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ForFormat() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ForFormat() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextRenderer(t *testing.T) {
+	output := TextRenderer{}.Render(sampleDocument())
+
+	expected := []string{
+		"Kanban Report\n=============\n",
+		"Report Type: team\nDate Range: All Time\n",
+		"# Story Points by Team",
+		"Team         | Points | Share\n",
+		"-------------|--------|------\n",
+		"Team <Alpha> |   11.0 | 61.1%\n",
+		"Total: **18.0** points\nacross 4 items",
+		"- First\n- Second",
+		"Insights    11.0 points\n  Analytics 11.0 points",
+	}
+	for _, str := range expected {
+		if !strings.Contains(output, str) {
+			t.Errorf("Output doesn't contain expected string: %q\nGot:\n%s", str, output)
+		}
+	}
+}
+
+func TestNumericColumns(t *testing.T) {
+	table := Table{
+		Headers: []string{"Name", "Points", "Share", "Age", "Bar", "Empty"},
+		Rows: [][]string{
+			{"alice", "3.0", "20.0%", "4d", "###", ""},
+			{"bob", "-", "80.0%", "12d", "", "-"},
+		},
+	}
+
+	want := []bool{false, true, true, true, false, false}
+	if got := numericColumns(table); !reflect.DeepEqual(got, want) {
+		t.Errorf("numericColumns() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,239 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+)
+
+// DefaultAllocateBy is the field the allocation report divides each owner's
+// work by when none is given
+const DefaultAllocateBy GroupField = "epic"
+
+// AllocationRow is the share of an owner's completed work that went to one
+// value of the allocation field
+type AllocationRow struct {
+	Name   string    `json:"name"`
+	Shares []float64 `json:"shares"` // One per month, of the owner's work in that month (0-1)
+	Share  float64   `json:"total_share"`
+	Amount float64   `json:"total_amount"`
+}
+
+// OwnerAllocation is where the completed work of one owner, or of all owners
+// together, went in each month
+type OwnerAllocation struct {
+	Name    string          `json:"name"`
+	Amounts []float64       `json:"amounts"` // The owner's completed work, one per month
+	Amount  float64         `json:"total_amount"`
+	Items   int             `json:"total_items"`
+	Rows    []AllocationRow `json:"allocation"`
+}
+
+// AllocationResult estimates how each owner's time was divided between the
+// values of a field, such as epics or product areas, as their share of the
+// work they completed in each month, from the first to the last month with
+// completed work
+type AllocationResult struct {
+	Field  string            `json:"field"`
+	Months []string          `json:"months"` // As YYYY-MM
+	Team   OwnerAllocation   `json:"team"`   // All owners together
+	Owners []OwnerAllocation `json:"owners"`
+}
+
+// allocationTally adds up the work of one owner while the result is built
+type allocationTally struct {
+	name    string
+	amounts []float64
+	items   int
+	cells   map[string][]float64 // Work per value of the field, one per month
+}
+
+// add credits work to a value of the field in a month
+func (t *allocationTally) add(value string, month int, amount float64) {
+	if t.cells[value] == nil {
+		t.cells[value] = make([]float64, len(t.amounts))
+	}
+	t.cells[value][month] += amount
+	t.amounts[month] += amount
+}
+
+// allocation turns the tally into shares of the owner's work, largest first
+func (t *allocationTally) allocation() OwnerAllocation {
+	owner := OwnerAllocation{Name: t.name, Amounts: t.amounts, Items: t.items, Rows: []AllocationRow{}}
+	for _, amount := range t.amounts {
+		owner.Amount += amount
+	}
+
+	for value, cells := range t.cells {
+		row := AllocationRow{Name: value, Shares: make([]float64, len(cells))}
+		for i, amount := range cells {
+			row.Amount += amount
+			if t.amounts[i] > 0 {
+				row.Shares[i] = amount / t.amounts[i]
+			}
+		}
+		if owner.Amount > 0 {
+			row.Share = row.Amount / owner.Amount
+		}
+		owner.Rows = append(owner.Rows, row)
+	}
+	sort.Slice(owner.Rows, func(i, j int) bool {
+		if owner.Rows[i].Amount != owner.Rows[j].Amount {
+			return owner.Rows[i].Amount > owner.Rows[j].Amount
+		}
+		return owner.Rows[i].Name < owner.Rows[j].Name
+	})
+	return owner
+}
+
+// allocationResult divides the completed work of each owner between the
+// values of the allocation field by month of completion. Like the contributor
+// report, an item's work is split equally between its owners; as time can't
+// be spent twice, it is also split equally between its values of the field.
+func (r *Reporter) allocationResult(items []models.KanbanItem) AllocationResult {
+	result := AllocationResult{Field: r.allocateBy.Title(), Months: []string{}, Owners: []OwnerAllocation{}}
+
+	var first, last time.Time
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		month := time.Date(item.CompletedAt.Year(), item.CompletedAt.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if first.IsZero() || month.Before(first) {
+			first = month
+		}
+		if month.After(last) {
+			last = month
+		}
+	}
+
+	// Months without completions keep their column, so gaps stand out
+	column := make(map[string]int)
+	for month := first; !first.IsZero() && !month.After(last); month = month.AddDate(0, 1, 0) {
+		column[month.Format("2006-01")] = len(result.Months)
+		result.Months = append(result.Months, month.Format("2006-01"))
+	}
+
+	newTally := func(name string) *allocationTally {
+		return &allocationTally{name: name, amounts: make([]float64, len(result.Months)), cells: make(map[string][]float64)}
+	}
+	team := newTally("All Owners")
+	owners := make(map[string]*allocationTally)
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		month := column[item.CompletedAt.Format("2006-01")]
+		names := item.Owners
+		if len(names) == 0 {
+			names = []string{"Unassigned"}
+		}
+		values := pivotValues(r.allocateBy, item)
+		share := r.unit.Value(item.Estimate) / float64(len(names)*len(values))
+
+		team.items++
+		for _, name := range names {
+			owner, exists := owners[name]
+			if !exists {
+				owner = newTally(name)
+				owners[name] = owner
+			}
+			owner.items++
+			for _, value := range values {
+				owner.add(value, month, share)
+				team.add(value, month, share)
+			}
+		}
+	}
+
+	result.Team = team.allocation()
+	for _, owner := range owners {
+		result.Owners = append(result.Owners, owner.allocation())
+	}
+	// Sort owners by amount in descending order, then by name
+	sort.Slice(result.Owners, func(i, j int) bool {
+		if result.Owners[i].Amount != result.Owners[j].Amount {
+			return result.Owners[i].Amount > result.Owners[j].Amount
+		}
+		return result.Owners[i].Name < result.Owners[j].Name
+	})
+
+	return result
+}
+
+// allocationTable lays out an owner's allocation with a row per value of the
+// field, a column per month with the share of that month's work, and the
+// owner's work per month in the last row. Shared items credit each owner
+// part of them, so even item counts keep a decimal.
+func (r *Reporter) allocationTable(result AllocationResult, owner OwnerAllocation) render.Table {
+	amount := func(value float64) string {
+		if value == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%.1f", value)
+	}
+	table := render.Table{Headers: append(append([]string{result.Field}, result.Months...), "Total")}
+	for _, allocation := range owner.Rows {
+		row := []string{allocation.Name}
+		for _, share := range allocation.Shares {
+			row = append(row, percentCell(share))
+		}
+		table.Rows = append(table.Rows, append(row, percentCell(allocation.Share)))
+	}
+	totals := []string{r.unit.ColumnTitle()}
+	for _, value := range owner.Amounts {
+		totals = append(totals, amount(value))
+	}
+	table.Rows = append(table.Rows, append(totals, amount(owner.Amount)))
+
+	return table
+}
+
+// percentCell formats a share as a whole percentage, leaving empty shares blank
+func percentCell(share float64) string {
+	if share == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.0f%%", share*100)
+}
+
+// allocationTitle returns the heading of the allocation report
+func (r *Reporter) allocationTitle() string {
+	return "Time Allocation by " + r.allocateBy.Title()
+}
+
+// allocationHeading names an owner with the work their shares are of
+func (r *Reporter) allocationHeading(owner OwnerAllocation) string {
+	if r.unit.CountsItems() {
+		return fmt.Sprintf("%s (%d items)", owner.Name, owner.Items)
+	}
+	return fmt.Sprintf("%s (%.1f %s, %d items)", owner.Name, owner.Amount, r.unit.Label(), owner.Items)
+}
+
+// allocationNote explains how the shares are estimated
+func (r *Reporter) allocationNote() string {
+	if r.unit.CountsItems() {
+		return fmt.Sprintf("Shares estimate where time went from the items completed in each month. Each item is split evenly between its owners, and between its %s values when it has several.",
+			r.allocateBy.Title())
+	}
+	return fmt.Sprintf("Shares estimate where time went from the %s completed in each month. An item's %s are split evenly between its owners, and between its %s values when it has several.",
+		r.unit.Label(), r.unit.Label(), r.allocateBy.Title())
+}
+
+// generateAllocationReport creates a report of how each owner's completed
+// work was divided between the values of the allocation field by month
+func (r *Reporter) generateAllocationReport(items []models.KanbanItem) (string, error) {
+	result := r.allocationResult(items)
+	report := r.allocationTitle() + ":\n\n"
+	if len(result.Months) == 0 {
+		return report + "No completed items.\n", nil
+	}
+
+	for _, owner := range append([]OwnerAllocation{result.Team}, result.Owners...) {
+		report += r.allocationHeading(owner) + "\n" + formatMatrix(r.allocationTable(result, owner)) + "\n"
+	}
+	return report + r.allocationNote() + "\n", nil
+}
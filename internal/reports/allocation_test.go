@@ -0,0 +1,118 @@
+package reports
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// allocationItems are completions in May and July by Ana and Ben, none in June
+func allocationItems() []models.KanbanItem {
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC) }
+	return []models.KanbanItem{
+		{ID: "1", Epic: "Checkout", ProductArea: "Payments", Owners: []string{"Ana"}, Estimate: 6, IsCompleted: true, CompletedAt: day(time.May, 3)},
+		{ID: "2", Epic: "Search", ProductArea: "Discovery", Owners: []string{"Ana", "Ben"}, Estimate: 4, IsCompleted: true, CompletedAt: day(time.May, 20)},
+		{ID: "3", Epic: "Checkout", ProductAreas: []string{"Payments", "Mobile"}, Owners: []string{"Ben"}, Estimate: 2, IsCompleted: true, CompletedAt: day(time.July, 1)},
+		{ID: "4", Owners: []string{"Ana"}, Estimate: 2, IsCompleted: true, CompletedAt: day(time.July, 9)},
+		{ID: "5", Epic: "Search", Owners: []string{"Ana"}, Estimate: 8}, // Not completed
+	}
+}
+
+// findAllocationRow returns the row of a value in an owner's allocation
+func findAllocationRow(t *testing.T, owner OwnerAllocation, name string) AllocationRow {
+	t.Helper()
+	for _, row := range owner.Rows {
+		if row.Name == name {
+			return row
+		}
+	}
+	t.Fatalf("%s has no %s row: %+v", owner.Name, name, owner.Rows)
+	return AllocationRow{}
+}
+
+func TestAllocationResult(t *testing.T) {
+	result := NewReporter(nil).allocationResult(allocationItems())
+
+	if result.Field != "Epic" || strings.Join(result.Months, ",") != "2024-05,2024-06,2024-07" {
+		t.Fatalf("field and months = %s %v, want Epic for 2024-05 through 2024-07", result.Field, result.Months)
+	}
+	if len(result.Owners) != 2 || result.Owners[0].Name != "Ana" || result.Owners[1].Name != "Ben" {
+		t.Fatalf("owners = %+v, want Ana then Ben", result.Owners)
+	}
+
+	// Ana: 6 points of Checkout and half of the 4 points of Search in May,
+	// 2 points without an epic in July
+	ana := result.Owners[0]
+	if ana.Amount != 10 || ana.Items != 3 || ana.Amounts[0] != 8 || ana.Amounts[1] != 0 || ana.Amounts[2] != 2 {
+		t.Errorf("Ana = %+v, want 8, 0, 2 for 10 points across 3 items", ana)
+	}
+	checkout := findAllocationRow(t, ana, "Checkout")
+	if checkout.Shares[0] != 0.75 || checkout.Shares[2] != 0 || checkout.Share != 0.6 || checkout.Amount != 6 {
+		t.Errorf("Ana's Checkout = %+v, want 75%% of May and 60%% overall", checkout)
+	}
+	if unspecified := findAllocationRow(t, ana, "Unspecified"); unspecified.Shares[2] != 1 {
+		t.Errorf("Ana's Unspecified = %+v, want all of July", unspecified)
+	}
+
+	team := result.Team
+	if team.Name != "All Owners" || team.Amount != 14 || team.Items != 4 {
+		t.Errorf("team = %+v, want 14 points across 4 items", team)
+	}
+	if search := findAllocationRow(t, team, "Search"); math.Abs(search.Share-4.0/14) > 1e-9 || search.Shares[0] != 0.4 {
+		t.Errorf("team's Search = %+v, want 40%% of May", search)
+	}
+}
+
+func TestAllocationResult_SplitsSeveralValues(t *testing.T) {
+	result := NewReporter(nil).WithAllocateBy("product_area").allocationResult(allocationItems())
+
+	ben := result.Owners[1]
+	if ben.Name != "Ben" || ben.Amount != 4 {
+		t.Fatalf("Ben = %+v, want 4 points", ben)
+	}
+	payments := findAllocationRow(t, ben, "Payments")
+	mobile := findAllocationRow(t, ben, "Mobile")
+	if payments.Amount != 1 || mobile.Amount != 1 || payments.Shares[2] != 0.5 {
+		t.Errorf("Payments and Mobile = %+v and %+v, want 1 point and half of July each", payments, mobile)
+	}
+}
+
+func TestGenerateAllocationReport(t *testing.T) {
+	report, err := NewReporter(nil).generateAllocationReport(allocationItems())
+	if err != nil {
+		t.Fatalf("generateAllocationReport() error = %v", err)
+	}
+
+	for _, str := range []string{
+		"Time Allocation by Epic:",
+		"All Owners (14.0 points, 4 items)",
+		"Ana (10.0 points, 3 items)",
+		"Epic        | 2024-05 | 2024-06 | 2024-07 | Total",
+		"Checkout    |     75% |         |         |   60%",
+		"Points      |     8.0 |         |     2.0 |  10.0",
+		"split evenly between its owners",
+	} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	empty, _ := NewReporter(nil).generateAllocationReport(nil)
+	if !strings.Contains(empty, "No completed items.") {
+		t.Errorf("Expected no completed items, got:\n%s", empty)
+	}
+}
+
+func TestGenerateAllocationReport_Items(t *testing.T) {
+	report, err := NewReporter(nil).WithUnit(types.UnitItems).generateAllocationReport(allocationItems())
+	if err != nil {
+		t.Fatalf("generateAllocationReport() error = %v", err)
+	}
+	if !strings.Contains(report, "Ben (2 items)") || !strings.Contains(report, "Items    |     0.5 |         |     1.0 |   1.5") {
+		t.Errorf("Expected item counts, got:\n%s", report)
+	}
+}
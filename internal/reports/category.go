@@ -0,0 +1,43 @@
+package reports
+
+import (
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// categoryResult totals story points by category, as assigned by the
+// classification rules, with each category's share of the total
+func (r *Reporter) categoryResult(items []models.KanbanItem) TotalsResult {
+	categoryPoints := make(map[string]float64)
+	categoryItems := make(map[string]int)
+	totalPoints := 0.0
+
+	for _, item := range items {
+		category := item.Category
+		if category == "" {
+			category = "Uncategorized"
+		}
+
+		categoryPoints[category] += r.unit.Value(item.Estimate)
+		categoryItems[category]++
+		totalPoints += r.unit.Value(item.Estimate)
+	}
+
+	result := TotalsResult{Groups: groupTotals(categoryPoints, categoryItems), Amount: totalPoints, Items: len(items)}
+	for i, group := range result.Groups {
+		share := 0.0
+		if r.unit.CountsItems() {
+			share = float64(group.Items) / float64(len(items)) * 100
+		} else if totalPoints > 0 {
+			share = group.Amount / totalPoints * 100
+		}
+		result.Groups[i].Share = &share
+	}
+
+	return result
+}
+
+// generateCategoryReport creates a report of story points by category, as
+// assigned by the classification rules, with each category's share of the total
+func (r *Reporter) generateCategoryReport(items []models.KanbanItem) (string, error) {
+	return r.formatTotals("Category", 30, r.categoryResult(items)), nil
+}
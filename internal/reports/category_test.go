@@ -0,0 +1,41 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestGenerateCategoryReport(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Category: "Roadmap", Estimate: 5},
+		{ID: "2", Category: "KTLO", Estimate: 2},
+		{ID: "3", Category: "Roadmap", Estimate: 1},
+		{ID: "4", Estimate: 2}, // Not classified
+	}
+
+	reporter := NewReporter(items)
+	report, err := reporter.generateCategoryReport(items)
+	if err != nil {
+		t.Fatalf("generateCategoryReport() error = %v", err)
+	}
+
+	expected := []string{
+		"Story Points by Category:",
+		"Roadmap                           6.0 points    2 items   60.0%",
+		"KTLO                              2.0 points    1 items   20.0%",
+		"Uncategorized                     2.0 points    1 items   20.0%",
+		"Total: 10.0 points across 4 items",
+	}
+	for _, want := range expected {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report doesn't contain %q\n%s", want, report)
+		}
+	}
+
+	// Categories with equal points are listed by name
+	if strings.Index(report, "KTLO") > strings.Index(report, "Uncategorized") {
+		t.Errorf("KTLO should be listed before Uncategorized")
+	}
+}
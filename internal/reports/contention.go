@@ -0,0 +1,203 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// DefaultMinEpics is the number of concurrent epics at which a contributor is listed
+const DefaultMinEpics = 3
+
+// MaxEpicPairs is the number of epic pairs shown in the contention report
+const MaxEpicPairs = 10
+
+// activeSpan is the time an item was in progress
+type activeSpan struct {
+	epic  string
+	start time.Time
+	end   time.Time
+}
+
+// withOpenItems adds the open items missing from items, since work still in
+// progress also competes for people
+func (r *Reporter) withOpenItems(items []models.KanbanItem) []models.KanbanItem {
+	present := make(map[string]bool)
+	for _, item := range items {
+		present[item.ID] = true
+	}
+	combined := append([]models.KanbanItem{}, items...)
+	for _, item := range r.openItems() {
+		if !present[item.ID] {
+			combined = append(combined, item)
+		}
+	}
+	return combined
+}
+
+// ContendedContributor is a contributor who worked on several epics at once
+type ContendedContributor struct {
+	Name  string   `json:"name"`
+	Epics []string `json:"epics"`
+	From  string   `json:"from"` // Date the most epics were in progress at once
+}
+
+// EpicPair is a pair of epics worked on by the same people at overlapping times
+type EpicPair struct {
+	Name   string   `json:"name"` // Both epics, as "A & B"
+	Epics  []string `json:"epics"`
+	People []string `json:"people"`
+}
+
+// ContentionResult holds the contended contributors and the epic pairs
+// sharing the most contributors
+type ContentionResult struct {
+	MinEpics     int                    `json:"min_epics"`
+	Contributors []ContendedContributor `json:"contributors"`
+	EpicPairs    []EpicPair             `json:"epic_pairs"`
+}
+
+// contentionResult finds contributors who worked on minEpics or more epics at
+// the same time, and the epic pairs sharing the most contributors
+func (r *Reporter) contentionResult(items []models.KanbanItem, minEpics int, asOf time.Time) ContentionResult {
+	if minEpics < 2 {
+		minEpics = DefaultMinEpics
+	}
+
+	spans := make(map[string][]activeSpan)
+	for _, item := range items {
+		if item.Epic == "" || len(item.Owners) == 0 {
+			continue
+		}
+		start := item.StartedAt
+		if start.IsZero() {
+			start = item.CreatedAt
+		}
+		end := asOf
+		if item.IsCompleted && !item.CompletedAt.IsZero() {
+			end = item.CompletedAt
+		}
+		if start.IsZero() || end.Before(start) {
+			continue
+		}
+		for _, owner := range item.Owners {
+			spans[owner] = append(spans[owner], activeSpan{item.Epic, start, end})
+		}
+	}
+
+	var contended []ContendedContributor
+	sharedBy := make(map[[2]string][]string)
+
+	for owner, ownerSpans := range spans {
+		// The most epics in progress at once is reached at the start of some span
+		var peak []string
+		var peakAt time.Time
+		for _, candidate := range ownerSpans {
+			active := make(map[string]bool)
+			for _, span := range ownerSpans {
+				if !span.start.After(candidate.start) && !span.end.Before(candidate.start) {
+					active[span.epic] = true
+				}
+			}
+			if len(active) > len(peak) {
+				peak = peak[:0]
+				for epic := range active {
+					peak = append(peak, epic)
+				}
+				peakAt = candidate.start
+			}
+		}
+		if len(peak) >= minEpics {
+			sort.Strings(peak)
+			contended = append(contended, ContendedContributor{owner, append([]string{}, peak...), peakAt.Format("2006-01-02")})
+		}
+
+		// Epic pairs this person worked on at overlapping times
+		pairs := make(map[[2]string]bool)
+		for i, a := range ownerSpans {
+			for _, b := range ownerSpans[i+1:] {
+				if a.epic == b.epic || a.start.After(b.end) || b.start.After(a.end) {
+					continue
+				}
+				pair := [2]string{a.epic, b.epic}
+				if pair[1] < pair[0] {
+					pair = [2]string{b.epic, a.epic}
+				}
+				pairs[pair] = true
+			}
+		}
+		for pair := range pairs {
+			sharedBy[pair] = append(sharedBy[pair], owner)
+		}
+	}
+
+	sort.Slice(contended, func(i, j int) bool {
+		if len(contended[i].Epics) != len(contended[j].Epics) {
+			return len(contended[i].Epics) > len(contended[j].Epics)
+		}
+		return contended[i].Name < contended[j].Name
+	})
+
+	var pairs [][2]string
+	for pair := range sharedBy {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if len(sharedBy[pairs[i]]) != len(sharedBy[pairs[j]]) {
+			return len(sharedBy[pairs[i]]) > len(sharedBy[pairs[j]])
+		}
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+
+	result := ContentionResult{MinEpics: minEpics, Contributors: contended, EpicPairs: []EpicPair{}}
+	if result.Contributors == nil {
+		result.Contributors = []ContendedContributor{}
+	}
+	for i, pair := range pairs {
+		if i >= MaxEpicPairs {
+			break
+		}
+		people := sharedBy[pair]
+		sort.Strings(people)
+		result.EpicPairs = append(result.EpicPairs, EpicPair{pair[0] + " & " + pair[1], []string{pair[0], pair[1]}, people})
+	}
+
+	return result
+}
+
+// generateContentionReport lists contributors who worked on minEpics or more
+// epics at the same time, and the epic pairs sharing the most contributors
+func (r *Reporter) generateContentionReport(items []models.KanbanItem, minEpics int, asOf time.Time) (string, error) {
+	result := r.contentionResult(items, minEpics, asOf)
+
+	report := "Cross-Epic Contention:\n\n"
+	report += fmt.Sprintf("Contributors working on %d or more epics at the same time:\n\n", result.MinEpics)
+	if len(result.Contributors) == 0 {
+		report += fmt.Sprintf("No contributor worked on %d or more epics at the same time.\n", result.MinEpics)
+	}
+	for _, c := range result.Contributors {
+		report += fmt.Sprintf("%-30s %2d epics at once (from %s): %s\n",
+			c.Name, len(c.Epics), c.From, strings.Join(c.Epics, ", "))
+	}
+
+	report += "\nEpics Sharing the Most Contributors:\n\n"
+	if len(result.EpicPairs) == 0 {
+		report += "No epics shared contributors at the same time.\n"
+	}
+	for _, pair := range result.EpicPairs {
+		noun := "people"
+		if len(pair.People) == 1 {
+			noun = "person"
+		}
+		report += fmt.Sprintf("%-50s %2d %s (%s)\n",
+			pair.Name, len(pair.People), noun, strings.Join(pair.People, ", "))
+	}
+
+	return report, nil
+}
@@ -0,0 +1,69 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestGenerateContentionReport(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 6, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	items := []models.KanbanItem{
+		// ana works on three epics at once from June 5
+		{ID: "1", Owners: []string{"ana"}, Epic: "Billing", StartedAt: day(1), CompletedAt: day(10), IsCompleted: true},
+		{ID: "2", Owners: []string{"ana", "ben"}, Epic: "Search", StartedAt: day(3), CompletedAt: day(8), IsCompleted: true},
+		{ID: "3", Owners: []string{"ana"}, Epic: "Mobile", StartedAt: day(5)},
+		// ben overlaps Billing and Search, but only two epics at once
+		{ID: "4", Owners: []string{"ben"}, Epic: "Billing", StartedAt: day(7), CompletedAt: day(9), IsCompleted: true},
+		// cy moves between epics without overlap
+		{ID: "5", Owners: []string{"cy"}, Epic: "Billing", StartedAt: day(1), CompletedAt: day(2), IsCompleted: true},
+		{ID: "6", Owners: []string{"cy"}, Epic: "Search", StartedAt: day(3), CompletedAt: day(4), IsCompleted: true},
+		{ID: "7", Owners: []string{"cy"}, Epic: "Mobile", StartedAt: day(5), CompletedAt: day(6), IsCompleted: true},
+		// No epic
+		{ID: "8", Owners: []string{"ana"}, StartedAt: day(5)},
+	}
+
+	reporter := NewReporter(items)
+	report, err := reporter.generateContentionReport(items, 3, day(20))
+	if err != nil {
+		t.Fatalf("generateContentionReport() error = %v", err)
+	}
+
+	expected := []string{
+		"Contributors working on 3 or more epics at the same time:",
+		"ana                             3 epics at once (from 2024-06-05): Billing, Mobile, Search",
+		"Billing & Search                                    2 people (ana, ben)",
+		"Billing & Mobile                                    1 person (ana)",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+	for _, unexpected := range []string{"ben                             2 epics", "cy"} {
+		if strings.Contains(report, unexpected) {
+			t.Errorf("Report contains unexpected string: %q\nGot:\n%s", unexpected, report)
+		}
+	}
+}
+
+func TestGenerateContentionReport_NoContention(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Owners: []string{"ana"}, Epic: "Billing", StartedAt: time.Now().AddDate(0, 0, -3)},
+	}
+
+	report, err := NewReporter(items).generateContentionReport(items, 2, time.Now())
+	if err != nil {
+		t.Fatalf("generateContentionReport() error = %v", err)
+	}
+	for _, str := range []string{"No contributor worked on 2 or more epics", "No epics shared contributors"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
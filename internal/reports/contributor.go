@@ -3,67 +3,107 @@ package reports
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
 )
 
-// generateContributorReport creates a report of story points by contributor
-func (r *Reporter) generateContributorReport(items []models.KanbanItem) (string, error) {
+// contributorResult credits story points to contributors, splitting each item
+// equally between its owners
+func (r *Reporter) contributorResult(items []models.KanbanItem) TotalsResult {
     // Map to track points by contributor
     contributorPoints := make(map[string]float64)
     contributorItems := make(map[string]int)
+    contributorDetails := make(map[string][]ItemDetail)
     
     // Calculate points by contributor
     for _, item := range items {
         // If no owners, credit to "Unassigned"
         if len(item.Owners) == 0 {
-            contributorPoints["Unassigned"] += item.Estimate
+            contributorPoints["Unassigned"] += r.unit.Value(item.Estimate)
             contributorItems["Unassigned"]++
+            if r.detail {
+                contributorDetails["Unassigned"] = append(contributorDetails["Unassigned"], itemDetail(item, r.unit.Value(item.Estimate)))
+            }
             continue
         }
         
         // Distribute points equally among owners
-        pointsPerOwner := item.Estimate / float64(len(item.Owners))
+        pointsPerOwner := r.unit.Value(item.Estimate) / float64(len(item.Owners))
         for _, owner := range item.Owners {
             contributorPoints[owner] += pointsPerOwner
             contributorItems[owner]++
+            if r.detail {
+                contributorDetails[owner] = append(contributorDetails[owner], itemDetail(item, pointsPerOwner))
+            }
         }
     }
     
-    // Sort contributors by points
-    type contributorStat struct {
-        name       string
-        points     float64
-        itemCount  int
+    result := sumTotals(groupTotals(contributorPoints, contributorItems))
+    if r.detail {
+        for i := range result.Groups {
+            result.Groups[i].Details = topDetails(contributorDetails[result.Groups[i].Name], r.top)
+        }
     }
-    
-    var stats []contributorStat
-    for name, points := range contributorPoints {
-        stats = append(stats, contributorStat{
-            name:      name,
-            points:    points,
-            itemCount: contributorItems[name],
-        })
+    return result
+}
+
+// itemDetail describes a completed item and the amount credited for it
+func itemDetail(item models.KanbanItem, amount float64) ItemDetail {
+    detail := ItemDetail{ID: item.ID, Name: item.Name, Amount: amount, Epic: item.Epic, Source: item.SourceRef()}
+    if !item.StartedAt.IsZero() && !item.CompletedAt.IsZero() && !item.CompletedAt.Before(item.StartedAt) {
+        days := item.CompletedAt.Sub(item.StartedAt).Hours() / 24
+        detail.CycleTimeDays = &days
     }
-    
-    // Sort by points in descending order
-    sort.Slice(stats, func(i, j int) bool {
-        return stats[i].points > stats[j].points
+    return detail
+}
+
+// topDetails sorts the items by amount in descending order, then by ID, and
+// keeps the first top of them, or all when top is 0
+func topDetails(details []ItemDetail, top int) []ItemDetail {
+    sort.SliceStable(details, func(i, j int) bool {
+        if details[i].Amount != details[j].Amount {
+            return details[i].Amount > details[j].Amount
+        }
+        return details[i].ID < details[j].ID
     })
-    
-    // Generate report string
-    report := "Story Points by Contributor:\n\n"
-    totalPoints := 0.0
-    totalItems := 0
-    
-    for _, stat := range stats {
-        report += fmt.Sprintf("%-30s %6.1f points  %3d items\n", 
-            stat.name, stat.points, stat.itemCount)
-        totalPoints += stat.points
-        totalItems += stat.itemCount
+    if top > 0 && len(details) > top {
+        details = details[:top]
     }
-    
-    report += fmt.Sprintf("\nTotal: %.1f points across %d items\n", totalPoints, totalItems)
-    
-    return report, nil
-}
\ No newline at end of file
+    return details
+}
+
+// formatDetails formats the items of a contributor indented beneath their
+// summary line, noting how many items --top left out
+func (r *Reporter) formatDetails(group GroupTotal) string {
+    report := ""
+    for _, detail := range group.Details {
+        line := fmt.Sprintf("    %-40s %6.1f %s", "#"+detail.ID+" "+detail.Name, detail.Amount, r.unit.Label())
+        if detail.Epic != "" {
+            line += "  " + detail.Epic
+        }
+        if detail.CycleTimeDays != nil {
+            line += fmt.Sprintf("  (%.1f days)", *detail.CycleTimeDays)
+        }
+        report += strings.TrimRight(line, " ") + "\n"
+    }
+    if more := group.Items - len(group.Details); more > 0 {
+        report += fmt.Sprintf("    ... and %d more items\n", more)
+    }
+    return report
+}
+
+// generateContributorReport creates a report of story points by contributor
+func (r *Reporter) generateContributorReport(items []models.KanbanItem) (string, error) {
+    result := r.contributorResult(items)
+    if !r.detail {
+        return r.formatTotals("Contributor", 30, result), nil
+    }
+
+    report := r.unit.Title() + " by Contributor:\n\n"
+    for _, group := range result.Groups {
+        report += fmt.Sprintf("%-30s %s\n", group.Name, r.formatAmount(group.Amount, group.Items))
+        report += r.formatDetails(group) + "\n"
+    }
+    return report + r.formatTotal(result.Amount, result.Items), nil
+}
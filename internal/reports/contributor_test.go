@@ -59,4 +59,76 @@ func TestGenerateContributorReport(t *testing.T) {
 	if !strings.Contains(report, "Total: 9.0 points") {
 		t.Errorf("Report doesn't contain correct total points")
 	}
-}
\ No newline at end of file
+}
+func TestContributorReportDetail(t *testing.T) {
+	completed := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Login page", Owners: []string{"alice"}, Epic: "Auth", StartedAt: completed.AddDate(0, 0, -3), CompletedAt: completed, Estimate: 3},
+		{ID: "2", Name: "Password reset", Owners: []string{"alice", "bob"}, Epic: "Auth", CompletedAt: completed, Estimate: 8},
+		{ID: "3", Name: "Fix typo", Owners: []string{"alice"}, CompletedAt: completed, Estimate: 1},
+	}
+
+	tests := []struct {
+		name      string
+		top       int
+		wantIDs   []string
+		wantLines []string
+		notLines  []string
+	}{
+		{
+			name:      "all items, largest first",
+			wantIDs:   []string{"2", "1", "3"},
+			wantLines: []string{"#2 Password reset", "#1 Login page", "Auth  (3.0 days)", "#3 Fix typo"},
+			notLines:  []string{"more items"},
+		},
+		{
+			name:      "top limits the items and counts the rest",
+			top:       2,
+			wantIDs:   []string{"2", "1"},
+			wantLines: []string{"#2 Password reset", "... and 1 more items"},
+			notLines:  []string{"#3 Fix typo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := NewReporter(items).WithDetail(true, tt.top)
+
+			alice := reporter.contributorResult(items).Groups[0]
+			if alice.Name != "alice" || alice.Amount != 8 || alice.Items != 3 {
+				t.Fatalf("first contributor = %+v, want alice with 8 points across 3 items", alice)
+			}
+			var ids []string
+			for _, detail := range alice.Details {
+				ids = append(ids, detail.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("alice's items = %v, want %v", ids, tt.wantIDs)
+			}
+			if alice.Details[0].Amount != 4 {
+				t.Errorf("credited share of a shared item = %.1f, want 4.0", alice.Details[0].Amount)
+			}
+			if alice.Details[0].CycleTimeDays != nil {
+				t.Errorf("cycle time of an item without a start date = %v, want none", *alice.Details[0].CycleTimeDays)
+			}
+
+			report, err := reporter.generateContributorReport(items)
+			if err != nil {
+				t.Fatalf("generateContributorReport() error = %v", err)
+			}
+			for _, line := range tt.wantLines {
+				if !strings.Contains(report, line) {
+					t.Errorf("report doesn't contain %q:\n%s", line, report)
+				}
+			}
+			for _, line := range tt.notLines {
+				if strings.Contains(report, line) {
+					t.Errorf("report contains %q:\n%s", line, report)
+				}
+			}
+			if !strings.Contains(report, "Total: 12.0 points") {
+				t.Errorf("report doesn't contain the total:\n%s", report)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// DeliveryGroup is the completed work of one milestone or iteration and how
+// it kept to its due dates
+type DeliveryGroup struct {
+	Name          string  `json:"name"`
+	Amount        float64 `json:"amount"`
+	Items         int     `json:"items"`
+	DueDate       string  `json:"due_date,omitempty"` // Milestone due date, from milestone_due_date
+	LastCompleted string  `json:"last_completed"`
+	DaysLate      *int    `json:"days_late,omitempty"` // Last completion after the due date; negative when early
+	ItemsDue      int     `json:"items_due"`           // Items with a due date of their own
+	ItemsLate     int     `json:"items_late"`          // Items completed after their due date
+}
+
+// DeliveryResult holds the milestones or iterations in order of completion,
+// with the work outside any of them last
+type DeliveryResult struct {
+	Groups []DeliveryGroup `json:"groups"`
+	Amount float64         `json:"total_amount"`
+	Items  int             `json:"total_items"`
+}
+
+// milestoneResult totals story points by milestone, comparing each
+// milestone's due date with its last completion
+func (r *Reporter) milestoneResult(items []models.KanbanItem) DeliveryResult {
+	return r.deliveryResult(items, "No Milestone", func(item models.KanbanItem) (string, time.Time) {
+		return item.Milestone, item.MilestoneDueDate
+	})
+}
+
+// iterationResult totals story points by iteration, named by the iteration
+// column or else its ID
+func (r *Reporter) iterationResult(items []models.KanbanItem) DeliveryResult {
+	return r.deliveryResult(items, "No Iteration", func(item models.KanbanItem) (string, time.Time) {
+		if item.Iteration != "" {
+			return item.Iteration, time.Time{}
+		}
+		return item.IterationID, time.Time{}
+	})
+}
+
+// deliveryResult groups items by the name group returns, with the group's due
+// date when it has one. Items completed after their own due date count as
+// late in every grouping.
+func (r *Reporter) deliveryResult(items []models.KanbanItem, none string, group func(models.KanbanItem) (string, time.Time)) DeliveryResult {
+	result := DeliveryResult{Groups: []DeliveryGroup{}}
+	groups := make(map[string]*DeliveryGroup)
+	dueDates := make(map[string]time.Time)
+	lastCompleted := make(map[string]time.Time)
+
+	for _, item := range items {
+		name, dueDate := group(item)
+		if name == "" {
+			name = none
+		}
+		g, exists := groups[name]
+		if !exists {
+			g = &DeliveryGroup{Name: name}
+			groups[name] = g
+		}
+
+		value := r.unit.Value(item.Estimate)
+		g.Amount += value
+		g.Items++
+		result.Amount += value
+		result.Items++
+
+		if dueDates[name].IsZero() && !dueDate.IsZero() {
+			dueDates[name] = dueDate
+		}
+		if item.CompletedAt.After(lastCompleted[name]) {
+			lastCompleted[name] = item.CompletedAt
+		}
+		if !item.DueDate.IsZero() && !item.CompletedAt.IsZero() {
+			g.ItemsDue++
+			if daysBetween(item.DueDate, item.CompletedAt) > 0 {
+				g.ItemsLate++
+			}
+		}
+	}
+
+	for name, g := range groups {
+		if last := lastCompleted[name]; !last.IsZero() {
+			g.LastCompleted = last.Format("2006-01-02")
+			if due := dueDates[name]; !due.IsZero() {
+				g.DueDate = due.Format("2006-01-02")
+				late := daysBetween(due, last)
+				g.DaysLate = &late
+			}
+		}
+		result.Groups = append(result.Groups, *g)
+	}
+
+	// Completed groups in order of their last completion; the work outside
+	// any group goes last
+	sort.Slice(result.Groups, func(i, j int) bool {
+		a, b := result.Groups[i], result.Groups[j]
+		if (a.Name == none) != (b.Name == none) {
+			return b.Name == none
+		}
+		if a.LastCompleted != b.LastCompleted {
+			return a.LastCompleted < b.LastCompleted
+		}
+		return a.Name < b.Name
+	})
+
+	return result
+}
+
+// daysBetween returns the calendar days from one date to another, negative
+// when to comes first
+func daysBetween(from, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
+
+// generateMilestoneReport creates a report of story points by milestone
+func (r *Reporter) generateMilestoneReport(items []models.KanbanItem) (string, error) {
+	return r.formatDelivery("Milestone", r.milestoneResult(items)), nil
+}
+
+// generateIterationReport creates a report of story points by iteration
+func (r *Reporter) generateIterationReport(items []models.KanbanItem) (string, error) {
+	return r.formatDelivery("Iteration", r.iterationResult(items)), nil
+}
+
+// formatDelivery formats the groups of a milestone or iteration report, each
+// followed by its due date comparison
+func (r *Reporter) formatDelivery(title string, result DeliveryResult) string {
+	report := r.unit.Title() + " by " + title + ":\n\n"
+	for _, group := range result.Groups {
+		report += fmt.Sprintf("%-30s %s  %s\n", group.Name, r.formatAmount(group.Amount, group.Items), deliveryNote(group))
+	}
+	report += "\n" + r.formatTotal(result.Amount, result.Items)
+	return report
+}
+
+// deliveryNote describes when a group was done and how it kept to its due dates
+func deliveryNote(group DeliveryGroup) string {
+	if group.LastCompleted == "" {
+		return "not completed"
+	}
+	note := "last done " + group.LastCompleted
+	if group.DaysLate != nil {
+		note = fmt.Sprintf("due %s, %s (%s)", group.DueDate, note, formatDaysLate(*group.DaysLate))
+	}
+	if group.ItemsDue > 0 {
+		note += fmt.Sprintf(", %d of %d items past their due date", group.ItemsLate, group.ItemsDue)
+	}
+	return note
+}
+
+// formatDaysLate describes a completion relative to its due date
+func formatDaysLate(days int) string {
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%d days late", days)
+	case days < 0:
+		return fmt.Sprintf("%d days early", -days)
+	}
+	return "on the due date"
+}
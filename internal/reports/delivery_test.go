@@ -0,0 +1,70 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestGenerateMilestoneReport(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 15, 0, 0, 0, time.UTC) }
+	items := []models.KanbanItem{
+		{ID: "1", Milestone: "Beta", MilestoneDueDate: day(20), Estimate: 5, CompletedAt: day(18), DueDate: day(17)},
+		{ID: "2", Milestone: "Beta", MilestoneDueDate: day(20), Estimate: 3, CompletedAt: day(23)},
+		{ID: "3", Milestone: "Alpha", MilestoneDueDate: day(10), Estimate: 2, CompletedAt: day(8), DueDate: day(9)},
+		{ID: "4", Estimate: 1, CompletedAt: day(1)}, // No milestone
+	}
+
+	report, err := NewReporter(items).generateMilestoneReport(items)
+	if err != nil {
+		t.Fatalf("generateMilestoneReport() error = %v", err)
+	}
+
+	expected := []string{
+		"Story Points by Milestone:",
+		"Alpha                             2.0 points    1 items  due 2024-05-10, last done 2024-05-08 (2 days early), 0 of 1 items past their due date",
+		"Beta                              8.0 points    2 items  due 2024-05-20, last done 2024-05-23 (3 days late), 1 of 1 items past their due date",
+		"No Milestone                      1.0 points    1 items  last done 2024-05-01",
+		"Total: 11.0 points across 4 items",
+	}
+	for _, want := range expected {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report doesn't contain %q\n%s", want, report)
+		}
+	}
+
+	// Milestones follow their completion; items outside any come last
+	if strings.Index(report, "Alpha") > strings.Index(report, "Beta") || strings.Index(report, "Beta") > strings.Index(report, "No Milestone") {
+		t.Errorf("Milestones out of order:\n%s", report)
+	}
+}
+
+func TestIterationResult(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	items := []models.KanbanItem{
+		{ID: "1", Iteration: "Sprint 2", Estimate: 3, CompletedAt: day(20)},
+		{ID: "2", IterationID: "101", Estimate: 2, CompletedAt: day(6), DueDate: day(3)},
+		{ID: "3", Iteration: "Sprint 2", Estimate: 1, CompletedAt: day(17), DueDate: day(17)},
+	}
+
+	result := NewReporter(items).iterationResult(items)
+	if len(result.Groups) != 2 {
+		t.Fatalf("iterationResult() = %+v, want 2 iterations", result.Groups)
+	}
+
+	first, second := result.Groups[0], result.Groups[1]
+	if first.Name != "101" || first.ItemsDue != 1 || first.ItemsLate != 1 {
+		t.Errorf("first iteration = %+v, want 101 with its one item late", first)
+	}
+	if second.Name != "Sprint 2" || second.Amount != 4 || second.LastCompleted != "2024-05-20" || second.ItemsDue != 1 || second.ItemsLate != 0 {
+		t.Errorf("second iteration = %+v, want Sprint 2 with 4 points, done 2024-05-20 and no late items", second)
+	}
+	if first.DaysLate != nil || first.DueDate != "" {
+		t.Errorf("iterations have no due date of their own, got %+v", first)
+	}
+	if result.Amount != 6 || result.Items != 3 {
+		t.Errorf("total = %v points, %d items, want 6 and 3", result.Amount, result.Items)
+	}
+}
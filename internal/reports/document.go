@@ -0,0 +1,207 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+	"github.com/hannasdev/kanban-reports/pkg/filtering"
+)
+
+// Document builds the structured document for the report types, with one
+// section per report type, for rendering as Markdown or HTML
+func (r *Reporter) Document(reportTypes []ReportType, startDate, endDate time.Time, filterField models.FilterField) (render.ReportDocument, error) {
+	filteredItems := filtering.FilterItemsByDateRangeWithRules(
+		r.items,
+		startDate,
+		endDate,
+		filterField,
+		r.adHocFilter,
+		r.adHocRules,
+	)
+
+	var names []string
+	for _, reportType := range reportTypes {
+		names = append(names, string(reportType))
+	}
+	doc := render.ReportDocument{
+		Title:  "Kanban Report",
+		Fields: []render.Field{{Label: "Report Type", Value: strings.Join(names, ", ")}, render.DateRangeField(startDate, endDate)},
+	}
+	doc.Fields = append(doc.Fields, render.FilterFields(r.adHocFilter)...)
+
+	if len(filteredItems) == 0 && !includesOpenWork(reportTypes) {
+		doc.Sections = []render.Section{{
+			Name:   "report",
+			Blocks: []render.Block{render.Paragraph{Lines: []string{"No items completed in the specified date range."}}},
+		}}
+		return doc, nil
+	}
+
+	for _, reportType := range reportTypes {
+		blocks, err := r.sectionBlocks(reportType, filteredItems)
+		if err != nil {
+			return render.ReportDocument{}, err
+		}
+		doc.Sections = append(doc.Sections, render.Section{Name: string(reportType), Blocks: blocks})
+	}
+	if r.hierarchy {
+		doc.Sections = append(doc.Sections, render.Section{Name: "hierarchy", Blocks: render.Parse(r.generateHierarchySection(filteredItems))})
+	}
+
+	return doc, nil
+}
+
+// sectionBlocks builds the content of one report type. The totals reports
+// become tables; the others keep the layout of the text report.
+func (r *Reporter) sectionBlocks(reportType ReportType, items []models.KanbanItem) ([]render.Block, error) {
+	switch reportType {
+	case ReportTypeContributor:
+		return r.contributorBlocks(items), nil
+	case ReportTypeEpic:
+		return r.totalsBlocks("Epic", r.epicResult(items)), nil
+	case ReportTypeTeam:
+		return r.totalsBlocks("Team", r.teamResult(items)), nil
+	case ReportTypeCategory:
+		return r.totalsBlocks("Category", r.categoryResult(items)), nil
+	case ReportTypeProductArea:
+		result := r.productAreaResult(items)
+		blocks := r.totalsBlocks("Product Area", result)
+		if result.MultiAreaItems > 0 {
+			blocks = append(blocks, render.Paragraph{Lines: []string{r.multiAreaNote(result.MultiAreaItems)}})
+		}
+		return blocks, nil
+	case ReportTypeLabel:
+		result := r.labelResult(items)
+		blocks := r.totalsBlocks(r.labelHeading(), result)
+		if result.MultiLabelItems > 0 {
+			blocks = append(blocks, render.Paragraph{Lines: []string{r.multiLabelNote(result.MultiLabelItems)}})
+		}
+		return blocks, nil
+	case ReportTypeGroup:
+		if r.groupBy == "" {
+			break
+		}
+		result := r.groupResult(items)
+		blocks := r.totalsBlocks(r.groupBy.Title(), result)
+		if result.MultiValueItems > 0 {
+			blocks = append(blocks, render.Paragraph{Lines: []string{r.multiValueNote(result.MultiValueItems)}})
+		}
+		return blocks, nil
+	case ReportTypePivot:
+		if r.pivotRows == "" || r.pivotCols == "" {
+			break
+		}
+		result := r.pivotResult(items)
+		if len(result.Rows) > 0 {
+			return []render.Block{
+				render.Heading{Level: 1, Text: r.pivotTitle()},
+				r.pivotTable(result),
+			}, nil
+		}
+	case ReportTypeEpicProgress:
+		result := r.epicProgressResult(r.scopedItems(), time.Now())
+		if len(result.Epics) > 0 {
+			return []render.Block{
+				render.Heading{Level: 1, Text: fmt.Sprintf("Epic Progress (as of %s)", result.AsOf)},
+				r.epicProgressTable(result),
+				render.Paragraph{Lines: []string{fmt.Sprintf("Projected finishes assume the velocity of the last %d weeks continues.", result.VelocityWeeks)}},
+			}, nil
+		}
+	case ReportTypeAllocation:
+		result := r.allocationResult(items)
+		if len(result.Months) > 0 {
+			blocks := []render.Block{render.Heading{Level: 1, Text: r.allocationTitle()}}
+			for _, owner := range append([]OwnerAllocation{result.Team}, result.Owners...) {
+				blocks = append(blocks, render.Heading{Level: 2, Text: r.allocationHeading(owner)}, r.allocationTable(result, owner))
+			}
+			return append(blocks, render.Paragraph{Lines: []string{r.allocationNote()}}), nil
+		}
+	case ReportTypeTeamMonth:
+		result := r.teamMonthResult(items)
+		if len(result.Months) > 0 {
+			return []render.Block{
+				render.Heading{Level: 1, Text: r.unit.Title() + " by Team and Month"},
+				r.teamMonthTable(result),
+			}, nil
+		}
+	}
+
+	section, err := r.generateSection(reportType, items)
+	if err != nil {
+		return nil, err
+	}
+	return render.Parse(section), nil
+}
+
+// totalsBlocks builds a table with one row per group, followed by the total
+func (r *Reporter) totalsBlocks(title string, result TotalsResult) []render.Block {
+	table := render.Table{Headers: []string{title}}
+	if !r.unit.CountsItems() {
+		table.Headers = append(table.Headers, r.unit.ColumnTitle())
+	}
+	table.Headers = append(table.Headers, "Items")
+	hasShare := len(result.Groups) > 0 && result.Groups[0].Share != nil
+	if hasShare {
+		table.Headers = append(table.Headers, "Share")
+	}
+
+	for _, group := range result.Groups {
+		row := []string{group.Name}
+		if !r.unit.CountsItems() {
+			row = append(row, fmt.Sprintf("%.1f", group.Amount))
+		}
+		row = append(row, fmt.Sprintf("%d", group.Items))
+		if hasShare && group.Share != nil {
+			row = append(row, fmt.Sprintf("%.1f%%", *group.Share))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return []render.Block{
+		render.Heading{Level: 1, Text: r.unit.Title() + " by " + title},
+		table,
+		render.Paragraph{Lines: []string{strings.TrimSuffix(r.formatTotal(result.Amount, result.Items), "\n")}},
+	}
+}
+
+// contributorBlocks returns the contributor totals followed, with --detail,
+// by a table of each contributor's completed items
+func (r *Reporter) contributorBlocks(items []models.KanbanItem) []render.Block {
+	result := r.contributorResult(items)
+	blocks := r.totalsBlocks("Contributor", result)
+	if !r.detail {
+		return blocks
+	}
+
+	for _, group := range result.Groups {
+		table := render.Table{Headers: []string{"Item", r.unit.ColumnTitle(), "Epic", "Cycle Time (days)"}}
+		for _, detail := range group.Details {
+			cycleTime := ""
+			if detail.CycleTimeDays != nil {
+				cycleTime = fmt.Sprintf("%.1f", *detail.CycleTimeDays)
+			}
+			table.Rows = append(table.Rows, []string{"#" + detail.ID + " " + detail.Name, fmt.Sprintf("%.1f", detail.Amount), detail.Epic, cycleTime})
+		}
+		blocks = append(blocks, render.Heading{Level: 2, Text: group.Name}, table)
+		if more := group.Items - len(group.Details); more > 0 {
+			blocks = append(blocks, render.Paragraph{Lines: []string{fmt.Sprintf("... and %d more items", more)}})
+		}
+	}
+	return blocks
+}
+
+// renderDocument renders the document for the report types in the reporter's format
+func (r *Reporter) renderDocument(reportTypes []ReportType, startDate, endDate time.Time, filterField models.FilterField) (string, error) {
+	renderer, err := render.ForFormat(r.format)
+	if err != nil {
+		return "", err
+	}
+	doc, err := r.Document(reportTypes, startDate, endDate, filterField)
+	if err != nil {
+		return "", err
+	}
+	return renderer.Render(doc), nil
+}
@@ -0,0 +1,141 @@
+package reports
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestDocument(t *testing.T) {
+	completed := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Estimate: 3, IsCompleted: true, CompletedAt: completed, Owners: []string{"alice"}, Team: "Team A", Project: "Project X", Epic: "Epic A"},
+		{ID: "2", Name: "Task 2", Estimate: 2, IsCompleted: true, CompletedAt: completed, Owners: []string{"alice", "bob"}, Team: "Team B", Project: "Project X", Epic: "Epic A"},
+	}
+
+	doc, err := NewReporter(items).WithAdHocFilter(types.AdHocFilterExclude).WithHierarchy(true).
+		Document([]ReportType{ReportTypeContributor, ReportTypeTeam}, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Document() error = %v", err)
+	}
+
+	wantFields := []render.Field{
+		{Label: "Report Type", Value: "contributor, team"},
+		{Label: "Date Range", Value: "All Time"},
+		{Label: "Filter", Value: "Excluding ad-hoc requests"},
+	}
+	if !reflect.DeepEqual(doc.Fields, wantFields) {
+		t.Errorf("Fields = %v, want %v", doc.Fields, wantFields)
+	}
+
+	if len(doc.Sections) != 3 || doc.Sections[0].Name != "contributor" || doc.Sections[1].Name != "team" || doc.Sections[2].Name != "hierarchy" {
+		t.Fatalf("Sections = %+v, want contributor, team and hierarchy", doc.Sections)
+	}
+
+	table, ok := doc.Sections[0].Blocks[1].(render.Table)
+	if !ok {
+		t.Fatalf("second contributor block = %#v, want a table", doc.Sections[0].Blocks[1])
+	}
+	wantTable := render.Table{
+		Headers: []string{"Contributor", "Points", "Items"},
+		Rows:    [][]string{{"alice", "4.0", "2"}, {"bob", "1.0", "1"}},
+	}
+	if !reflect.DeepEqual(table, wantTable) {
+		t.Errorf("contributor table = %#v, want %#v", table, wantTable)
+	}
+}
+
+func TestDocument_CountingItems(t *testing.T) {
+	completed := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{ID: "1", Estimate: 3, IsCompleted: true, CompletedAt: completed, Epic: "Epic A"},
+	}
+
+	doc, err := NewReporter(items).WithUnit(types.UnitItems).
+		Document([]ReportType{ReportTypeEpic}, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Document() error = %v", err)
+	}
+
+	table := doc.Sections[0].Blocks[1].(render.Table)
+	if !reflect.DeepEqual(table.Headers, []string{"Epic", "Items"}) {
+		t.Errorf("Headers = %v, want the epic and item count only", table.Headers)
+	}
+}
+
+func TestGenerateReportMarkdown(t *testing.T) {
+	completed := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{ID: "1", Estimate: 3, IsCompleted: true, CompletedAt: completed, Team: "Team A"},
+	}
+
+	output, err := NewReporter(items).WithFormat(types.FormatMarkdown).
+		GenerateReport(ReportTypeTeam, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+
+	expected := []string{
+		"# Kanban Report",
+		"## Story Points by Team",
+		"| Team A | 3.0 | 1 |",
+		"Total: 3.0 points across 1 items",
+	}
+	for _, str := range expected {
+		if !strings.Contains(output, str) {
+			t.Errorf("Output doesn't contain expected string: %q\nGot:\n%s", str, output)
+		}
+	}
+}
+
+func TestDocumentNoItems(t *testing.T) {
+	output, err := NewReporter(nil).WithFormat(types.FormatHTML).
+		GenerateReport(ReportTypeTeam, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if !strings.Contains(output, "<p>No items completed in the specified date range.</p>") {
+		t.Errorf("Expected no-items message, got:\n%s", output)
+	}
+}
+
+func TestExplorerSection(t *testing.T) {
+	completed := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Estimate: 3, IsCompleted: true, CompletedAt: completed, Owners: []string{"alice", "bob"}, Team: "Team A", Epic: "Epic A"},
+		{ID: "2", Name: "Task 2", Estimate: 2, IsCompleted: true, CompletedAt: completed.AddDate(0, -2, 0), CreatedAt: completed.AddDate(0, -3, 0)},
+		{ID: "3", Name: "Open", Estimate: 5, CreatedAt: completed},
+	}
+
+	section := NewReporter(items).ExplorerSection(completed.AddDate(0, 0, -7), completed, models.FilterFieldCompletedAt)
+
+	if section.Name != "explorer" || len(section.Blocks) != 3 {
+		t.Fatalf("section = %+v, want the explorer with a heading and a note", section)
+	}
+	explorer, ok := section.Blocks[2].(render.Explorer)
+	if !ok {
+		t.Fatalf("Blocks[2] = %T, want render.Explorer", section.Blocks[2])
+	}
+	want := render.Explorer{
+		Unit:      "Points",
+		DateLabel: "Completed",
+		Items:     []render.ExplorerItem{{ID: "1", Name: "Task 1", Team: "Team A", Epic: "Epic A", Owners: []string{"alice", "bob"}, Amount: 3, Date: "2024-05-10"}},
+	}
+	if !reflect.DeepEqual(explorer, want) {
+		t.Errorf("explorer = %+v, want %+v", explorer, want)
+	}
+
+	all := NewReporter(items).WithUnit(types.UnitItems).ExplorerSection(time.Time{}, time.Time{}, models.FilterFieldCreatedAt)
+	explorer = all.Blocks[2].(render.Explorer)
+	if explorer.Unit != "" || explorer.DateLabel != "Created" || len(explorer.Items) != 2 {
+		t.Fatalf("explorer = %+v, want the items with a created date counted", explorer)
+	}
+	if item := explorer.Items[1]; item.Team != "No Team" || item.Epic != "No Epic" || !reflect.DeepEqual(item.Owners, []string{"Unassigned"}) || item.Date != "2024-05-10" || item.Amount != 1 {
+		t.Errorf("item = %+v, want the names the reports give items without a team, epic or owner", item)
+	}
+}
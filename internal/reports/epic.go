@@ -1,14 +1,11 @@
 package reports
 
 import (
-	"fmt"
-	"sort"
-
 	"github.com/hannasdev/kanban-reports/internal/models"
 )
 
-// generateEpicReport creates a report of story points by epic
-func (r *Reporter) generateEpicReport(items []models.KanbanItem) (string, error) {
+// epicResult totals story points by epic
+func (r *Reporter) epicResult(items []models.KanbanItem) TotalsResult {
 	// Map to track points by epic
 	epicPoints := make(map[string]float64)
 	epicItems := make(map[string]int)
@@ -20,44 +17,14 @@ func (r *Reporter) generateEpicReport(items []models.KanbanItem) (string, error)
 			epicName = "No Epic"
 		}
 		
-		epicPoints[epicName] += item.Estimate
+		epicPoints[epicName] += r.unit.Value(item.Estimate)
 		epicItems[epicName]++
 	}
 	
-	// Sort epics by points
-	type epicStat struct {
-		name      string
-		points    float64
-		itemCount int
-	}
-	
-	var stats []epicStat
-	for name, points := range epicPoints {
-		stats = append(stats, epicStat{
-			name:      name,
-			points:    points,
-			itemCount: epicItems[name],
-		})
-	}
-	
-	// Sort by points in descending order
-	sort.Slice(stats, func(i, j int) bool {
-		return stats[i].points > stats[j].points
-	})
-	
-	// Generate report string
-	report := "Story Points by Epic:\n\n"
-	totalPoints := 0.0
-	totalItems := 0
-	
-	for _, stat := range stats {
-		report += fmt.Sprintf("%-50s %6.1f points  %3d items\n", 
-			stat.name, stat.points, stat.itemCount)
-		totalPoints += stat.points
-		totalItems += stat.itemCount
-	}
-	
-	report += fmt.Sprintf("\nTotal: %.1f points across %d items\n", totalPoints, totalItems)
-	
-	return report, nil
-}
\ No newline at end of file
+	return sumTotals(groupTotals(epicPoints, epicItems))
+}
+
+// generateEpicReport creates a report of story points by epic
+func (r *Reporter) generateEpicReport(items []models.KanbanItem) (string, error) {
+	return r.formatTotals("Epic", 50, r.epicResult(items)), nil
+}
@@ -0,0 +1,107 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// EpicContributors is an epic with the contributors credited within it
+type EpicContributors struct {
+	Name         string       `json:"name"`
+	Amount       float64      `json:"amount"`
+	Items        int          `json:"items"`
+	Contributors []GroupTotal `json:"contributors"`
+}
+
+// EpicContributorResult holds the epics, each with its contributors, and the
+// total of all of them
+type EpicContributorResult struct {
+	Epic   string             `json:"epic,omitempty"` // Only set when limited to one epic
+	Epics  []EpicContributors `json:"epics"`
+	Amount float64            `json:"total_amount"`
+	Items  int                `json:"total_items"`
+}
+
+// epicContributorResult credits the work of each epic to its contributors,
+// splitting each item equally between its owners as the contributor report
+// does. When the reporter has an epic, only that epic is included.
+func (r *Reporter) epicContributorResult(items []models.KanbanItem) EpicContributorResult {
+	result := EpicContributorResult{Epic: r.epic, Epics: []EpicContributors{}}
+
+	epicAmounts := make(map[string]float64)
+	epicItems := make(map[string]int)
+	contributorAmounts := make(map[string]map[string]float64)
+	contributorItems := make(map[string]map[string]int)
+
+	for _, item := range items {
+		epicName := item.Epic
+		if epicName == "" {
+			epicName = "No Epic"
+		}
+		if r.epic != "" && !strings.EqualFold(epicName, r.epic) {
+			continue
+		}
+		if contributorAmounts[epicName] == nil {
+			contributorAmounts[epicName] = make(map[string]float64)
+			contributorItems[epicName] = make(map[string]int)
+		}
+
+		value := r.unit.Value(item.Estimate)
+		epicAmounts[epicName] += value
+		epicItems[epicName]++
+
+		owners := item.Owners
+		if len(owners) == 0 {
+			owners = []string{"Unassigned"}
+		}
+		for _, owner := range owners {
+			contributorAmounts[epicName][owner] += value / float64(len(owners))
+			contributorItems[epicName][owner]++
+		}
+	}
+
+	for name, amount := range epicAmounts {
+		result.Epics = append(result.Epics, EpicContributors{
+			Name:         name,
+			Amount:       amount,
+			Items:        epicItems[name],
+			Contributors: groupTotals(contributorAmounts[name], contributorItems[name]),
+		})
+		result.Amount += amount
+		result.Items += epicItems[name]
+	}
+
+	// Sort epics by amount in descending order, then by name
+	sort.Slice(result.Epics, func(i, j int) bool {
+		if result.Epics[i].Amount != result.Epics[j].Amount {
+			return result.Epics[i].Amount > result.Epics[j].Amount
+		}
+		return result.Epics[i].Name < result.Epics[j].Name
+	})
+
+	return result
+}
+
+// generateEpicContributorReport creates a report of the contributors within each epic
+func (r *Reporter) generateEpicContributorReport(items []models.KanbanItem) (string, error) {
+	result := r.epicContributorResult(items)
+
+	if r.epic != "" && len(result.Epics) == 0 {
+		return fmt.Sprintf("No items in epic %q in the specified date range.\n", r.epic), nil
+	}
+
+	report := r.unit.Title() + " by Epic → Contributor:\n\n"
+	for _, epic := range result.Epics {
+		report += r.formatHierarchyLine(0, epic.Name, epic.Amount, epic.Items)
+		for _, contributor := range epic.Contributors {
+			report += r.formatHierarchyLine(1, contributor.Name, contributor.Amount, contributor.Items)
+		}
+		report += "\n"
+	}
+	report += r.formatTotal(result.Amount, result.Items)
+
+	return report, nil
+}
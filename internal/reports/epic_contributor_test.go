@@ -0,0 +1,87 @@
+package reports
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func epicContributorItems() []models.KanbanItem {
+	return []models.KanbanItem{
+		{ID: "1", Epic: "Checkout", Owners: []string{"alice"}, Estimate: 5},
+		{ID: "2", Epic: "Checkout", Owners: []string{"alice", "bob"}, Estimate: 4},
+		{ID: "3", Epic: "Search", Owners: []string{"bob"}, Estimate: 3},
+		{ID: "4", Epic: "", Estimate: 1}, // No epic, no owner
+	}
+}
+
+func TestEpicContributorResult(t *testing.T) {
+	result := NewReporter(nil).epicContributorResult(epicContributorItems())
+
+	if result.Amount != 13 || result.Items != 4 || len(result.Epics) != 3 {
+		t.Fatalf("result = %+v, want 3 epics with 13 points across 4 items", result)
+	}
+
+	checkout := result.Epics[0]
+	if checkout.Name != "Checkout" || checkout.Amount != 9 || checkout.Items != 2 {
+		t.Errorf("first epic = %+v, want Checkout with 9 points across 2 items", checkout)
+	}
+	// Alice: 5 + 4/2 = 7, Bob: 4/2 = 2
+	want := []GroupTotal{{Name: "alice", Amount: 7, Items: 2}, {Name: "bob", Amount: 2, Items: 1}}
+	if len(checkout.Contributors) != len(want) {
+		t.Fatalf("Checkout contributors = %+v, want %+v", checkout.Contributors, want)
+	}
+	for i, w := range want {
+		if !reflect.DeepEqual(checkout.Contributors[i], w) {
+			t.Errorf("Checkout contributor %d = %+v, want %+v", i, checkout.Contributors[i], w)
+		}
+	}
+
+	noEpic := result.Epics[2]
+	if noEpic.Name != "No Epic" || noEpic.Contributors[0].Name != "Unassigned" {
+		t.Errorf("last epic = %+v, want No Epic credited to Unassigned", noEpic)
+	}
+}
+
+func TestEpicContributorResult_OneEpic(t *testing.T) {
+	reporter := NewReporter(nil).WithEpic(" search ")
+	result := reporter.epicContributorResult(epicContributorItems())
+
+	if result.Epic != "search" || len(result.Epics) != 1 || result.Epics[0].Name != "Search" {
+		t.Fatalf("result = %+v, want only the Search epic", result)
+	}
+	if result.Amount != 3 || result.Items != 1 {
+		t.Errorf("totals = %.1f / %d, want 3.0 / 1", result.Amount, result.Items)
+	}
+}
+
+func TestGenerateEpicContributorReport(t *testing.T) {
+	reporter := NewReporter(nil)
+	report, err := reporter.generateEpicContributorReport(epicContributorItems())
+	if err != nil {
+		t.Fatalf("generateEpicContributorReport() error = %v", err)
+	}
+
+	for _, str := range []string{
+		"Story Points by Epic → Contributor:",
+		"Checkout                                              9.0 points    2 items",
+		"  alice                                               7.0 points    2 items",
+		"Total: 13.0 points across 4 items",
+	} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	reporter.WithUnit(types.UnitItems).WithEpic("Billing")
+	report, err = reporter.generateEpicContributorReport(epicContributorItems())
+	if err != nil {
+		t.Fatalf("generateEpicContributorReport() error = %v", err)
+	}
+	if !strings.Contains(report, `No items in epic "Billing"`) {
+		t.Errorf("Report for a missing epic should say so\nGot:\n%s", report)
+	}
+}
@@ -0,0 +1,368 @@
+package reports
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+)
+
+// epicVelocityWeeks is how many weeks before now an epic's velocity, and so
+// its projected finish, is based on
+const epicVelocityWeeks = 4
+
+// progressBarWidth is the number of characters of the text progress bars
+const progressBarWidth = 20
+
+// EpicProgress is how far one epic has come: its completed work against all
+// of its work, and when it is projected to finish at its recent velocity
+type EpicProgress struct {
+	Name            string  `json:"name"`
+	State           string  `json:"state,omitempty"`      // From epic_state
+	CreatedAt       string  `json:"created_at,omitempty"` // From epic_created_at
+	DueDate         string  `json:"due_date,omitempty"`   // From epic_due_date
+	TotalAmount     float64 `json:"total_amount"`
+	DoneAmount      float64 `json:"done_amount"`
+	TotalItems      int     `json:"total_items"`
+	DoneItems       int     `json:"done_items"`
+	PercentComplete float64 `json:"percent_complete"`
+	FirstCompleted  string  `json:"first_completed,omitempty"`
+	LastCompleted   string  `json:"last_completed,omitempty"`
+	WeeklyVelocity  float64 `json:"weekly_velocity"`            // Completed per week over the last epicVelocityWeeks weeks
+	ProjectedFinish string  `json:"projected_finish,omitempty"` // Last completion when done; unset without recent velocity
+	DaysLate        *int    `json:"days_late,omitempty"`        // Projected finish after the due date; negative when early
+
+	// The projection from the sizes of the open items and how long items of
+	// each size took, which weighs large items by their own history
+	RemainingSizes  []SizeMix `json:"remaining_sizes,omitempty"`
+	SizeMixFinish   string    `json:"size_mix_finish,omitempty"`    // Unset without recent completions or cycle times
+	SizeMixDaysLate *int      `json:"size_mix_days_late,omitempty"` // Size mix finish after the due date; negative when early
+}
+
+// SizeMix is the open items of one estimate size in an epic and the median
+// cycle time of the completed items of the closest size
+type SizeMix struct {
+	Size          float64  `json:"size"`
+	Items         int      `json:"items"`
+	CycleTimeDays float64 `json:"cycle_time_days"`
+}
+
+// EpicProgressResult holds the progress of each epic as of a date, the
+// furthest along first
+type EpicProgressResult struct {
+	AsOf          string         `json:"as_of"`
+	VelocityWeeks int            `json:"velocity_weeks"`
+	Epics         []EpicProgress `json:"epics"`
+}
+
+// scopedItems returns all items, open or completed, that pass the ad-hoc
+// filter. An epic's progress covers all of its work, and the cycle times of
+// its sizes all of the history, so the items aren't limited to the date range.
+func (r *Reporter) scopedItems() []models.KanbanItem {
+	var items []models.KanbanItem
+	for _, item := range r.items {
+		if r.passesAdHocFilter(item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// epicProgressResult totals each epic's work and completed work and projects
+// when the rest will be done at the epic's velocity in the weeks before asOf,
+// and from the sizes of the rest. Items without an epic only add to the
+// cycle times of their size.
+func (r *Reporter) epicProgressResult(items []models.KanbanItem, asOf time.Time) EpicProgressResult {
+	result := EpicProgressResult{AsOf: asOf.Format("2006-01-02"), VelocityWeeks: epicVelocityWeeks, Epics: []EpicProgress{}}
+	epics := make(map[string]*EpicProgress)
+	firstCompleted := make(map[string]time.Time)
+	lastCompleted := make(map[string]time.Time)
+	dueDates := make(map[string]time.Time)
+	recent := make(map[string]float64)
+	openSizes := make(map[string]map[float64]int)
+	recentSizes := make(map[string][]float64)
+	since := asOf.AddDate(0, 0, -7*epicVelocityWeeks)
+	sizes := newSizeCycleTimes(items)
+
+	for _, item := range items {
+		if item.Epic == "" {
+			continue
+		}
+		epic, exists := epics[item.Epic]
+		if !exists {
+			epic = &EpicProgress{Name: item.Epic}
+			epics[item.Epic] = epic
+		}
+		if epic.State == "" {
+			epic.State = item.EpicState
+		}
+		if epic.CreatedAt == "" && !item.EpicCreatedAt.IsZero() {
+			epic.CreatedAt = item.EpicCreatedAt.Format("2006-01-02")
+		}
+		if dueDates[item.Epic].IsZero() && !item.EpicDueDate.IsZero() {
+			dueDates[item.Epic] = item.EpicDueDate
+		}
+
+		value := r.unit.Value(item.Estimate)
+		epic.TotalAmount += value
+		epic.TotalItems++
+		if !item.IsCompleted {
+			if openSizes[item.Epic] == nil {
+				openSizes[item.Epic] = make(map[float64]int)
+			}
+			openSizes[item.Epic][item.Estimate]++
+			continue
+		}
+		epic.DoneAmount += value
+		epic.DoneItems++
+		if completed := item.CompletedAt; !completed.IsZero() {
+			if first := firstCompleted[item.Epic]; first.IsZero() || completed.Before(first) {
+				firstCompleted[item.Epic] = completed
+			}
+			if completed.After(lastCompleted[item.Epic]) {
+				lastCompleted[item.Epic] = completed
+			}
+			if completed.After(since) && !completed.After(asOf) {
+				recent[item.Epic] += value
+				recentSizes[item.Epic] = append(recentSizes[item.Epic], item.Estimate)
+			}
+		}
+	}
+
+	for name, epic := range epics {
+		if epic.TotalAmount > 0 {
+			epic.PercentComplete = epic.DoneAmount / epic.TotalAmount * 100
+		}
+		if first := firstCompleted[name]; !first.IsZero() {
+			epic.FirstCompleted = first.Format("2006-01-02")
+			epic.LastCompleted = lastCompleted[name].Format("2006-01-02")
+		}
+		epic.WeeklyVelocity = recent[name] / epicVelocityWeeks
+
+		var finish time.Time
+		remaining := epic.TotalAmount - epic.DoneAmount
+		switch {
+		case remaining <= 0 && epic.DoneItems == epic.TotalItems:
+			finish = lastCompleted[name]
+		case epic.WeeklyVelocity > 0:
+			days := math.Ceil(remaining / epic.WeeklyVelocity * 7)
+			finish = asOf.AddDate(0, 0, int(days))
+		}
+		if !finish.IsZero() {
+			epic.ProjectedFinish = finish.Format("2006-01-02")
+			if due := dueDates[name]; !due.IsZero() {
+				late := daysBetween(due, finish)
+				epic.DaysLate = &late
+			}
+		}
+		if due := dueDates[name]; !due.IsZero() {
+			epic.DueDate = due.Format("2006-01-02")
+		}
+		sizes.project(epic, openSizes[name], recentSizes[name], asOf, dueDates[name])
+		result.Epics = append(result.Epics, *epic)
+	}
+
+	sort.Slice(result.Epics, func(i, j int) bool {
+		a, b := result.Epics[i], result.Epics[j]
+		if a.PercentComplete != b.PercentComplete {
+			return a.PercentComplete > b.PercentComplete
+		}
+		return a.Name < b.Name
+	})
+
+	return result
+}
+
+// sizeCycleTimes holds the median cycle time, in days, of the completed items
+// of each estimate size
+type sizeCycleTimes map[float64]float64
+
+// newSizeCycleTimes collects the median cycle time of each estimate size from
+// the completed items with a start date
+func newSizeCycleTimes(items []models.KanbanItem) sizeCycleTimes {
+	bySize := make(map[float64][]float64)
+	for _, item := range items {
+		if item.IsCompleted && !item.StartedAt.IsZero() && !item.CompletedAt.Before(item.StartedAt) {
+			bySize[item.Estimate] = append(bySize[item.Estimate], item.CompletedAt.Sub(item.StartedAt).Hours()/24)
+		}
+	}
+	sizes := make(sizeCycleTimes)
+	for size, days := range bySize {
+		sort.Float64s(days)
+		middle := len(days) / 2
+		if len(days)%2 == 0 {
+			sizes[size] = (days[middle-1] + days[middle]) / 2
+		} else {
+			sizes[size] = days[middle]
+		}
+	}
+	return sizes
+}
+
+// days returns the median cycle time of the size, or of the closest size
+// with completed items, preferring the larger of two equally close sizes
+func (s sizeCycleTimes) days(size float64) float64 {
+	if days, ok := s[size]; ok {
+		return days
+	}
+	closest, found := 0.0, false
+	for known := range s {
+		diff, best := math.Abs(known-size), math.Abs(closest-size)
+		if !found || diff < best || (diff == best && known > closest) {
+			closest, found = known, true
+		}
+	}
+	return s[closest]
+}
+
+// project sets the epic's finish projected from the sizes of its open items.
+// The work left and the work of the last epicVelocityWeeks weeks are both
+// weighed by the cycle times of their sizes, so an epic left with its large
+// items isn't projected at the pace of the small ones done before them. No
+// epic finishes before its slowest open item could.
+func (s sizeCycleTimes) project(epic *EpicProgress, open map[float64]int, recent []float64, asOf, due time.Time) {
+	if len(open) == 0 || len(s) == 0 {
+		return
+	}
+
+	remaining, slowest := 0.0, 0.0
+	for size, count := range open {
+		days := s.days(size)
+		remaining += days * float64(count)
+		slowest = math.Max(slowest, days)
+		epic.RemainingSizes = append(epic.RemainingSizes, SizeMix{Size: size, Items: count, CycleTimeDays: days})
+	}
+	sort.Slice(epic.RemainingSizes, func(i, j int) bool { return epic.RemainingSizes[i].Size > epic.RemainingSizes[j].Size })
+
+	delivered := 0.0
+	for _, size := range recent {
+		delivered += s.days(size)
+	}
+	if delivered <= 0 {
+		return
+	}
+
+	weeks := remaining / (delivered / epicVelocityWeeks)
+	finish := asOf.AddDate(0, 0, int(math.Ceil(math.Max(weeks*7, slowest))))
+	epic.SizeMixFinish = finish.Format("2006-01-02")
+	if !due.IsZero() {
+		late := daysBetween(due, finish)
+		epic.SizeMixDaysLate = &late
+	}
+}
+
+// generateEpicProgressReport creates a report of each epic's completed work
+// against all of its work, with its projected finish
+func (r *Reporter) generateEpicProgressReport(items []models.KanbanItem, asOf time.Time) (string, error) {
+	result := r.epicProgressResult(items, asOf)
+
+	report := fmt.Sprintf("Epic Progress (as of %s, regardless of date range):\n\n", result.AsOf)
+	if len(result.Epics) == 0 {
+		return report + "No items with an epic.\n", nil
+	}
+
+	for _, epic := range result.Epics {
+		report += fmt.Sprintf("%-30s %s %5.1f%%  %s\n", epic.Name, progressBar(epic.PercentComplete), epic.PercentComplete, r.progressAmount(epic))
+		var dates []string
+		if epic.State != "" {
+			dates = append(dates, "state "+epic.State)
+		}
+		if epic.CreatedAt != "" {
+			dates = append(dates, "created "+epic.CreatedAt)
+		}
+		if epic.FirstCompleted != "" {
+			dates = append(dates, fmt.Sprintf("completions %s to %s", epic.FirstCompleted, epic.LastCompleted))
+		}
+		if len(dates) > 0 {
+			report += fmt.Sprintf("%-30s %s\n", "", strings.Join(dates, ", "))
+		}
+		report += fmt.Sprintf("%-30s %s\n", "", r.projectionNote(epic, result.VelocityWeeks))
+		if len(epic.RemainingSizes) > 0 {
+			report += fmt.Sprintf("%-30s %s\n", "", sizeMixNote(epic))
+		}
+		report += "\n"
+	}
+
+	return strings.TrimSuffix(report, "\n"), nil
+}
+
+// progressAmount formats the completed work of an epic against all of its work
+func (r *Reporter) progressAmount(epic EpicProgress) string {
+	if r.unit.CountsItems() {
+		return fmt.Sprintf("%d of %d items", epic.DoneItems, epic.TotalItems)
+	}
+	return fmt.Sprintf("%.1f of %.1f %s, %d of %d items", epic.DoneAmount, epic.TotalAmount, r.unit.Label(), epic.DoneItems, epic.TotalItems)
+}
+
+// projectionNote describes an epic's recent velocity and when it is projected
+// to finish, against its due date
+func (r *Reporter) projectionNote(epic EpicProgress, velocityWeeks int) string {
+	var note string
+	switch {
+	case epic.DoneItems == epic.TotalItems && epic.ProjectedFinish != "":
+		note = "done " + epic.ProjectedFinish
+	case epic.ProjectedFinish != "":
+		note = fmt.Sprintf("%.1f %s/week over the last %d weeks, projected finish %s", epic.WeeklyVelocity, r.unit.Label(), velocityWeeks, epic.ProjectedFinish)
+	default:
+		note = fmt.Sprintf("no completions in the last %d weeks to project a finish from", velocityWeeks)
+	}
+	if epic.DueDate != "" {
+		if epic.DaysLate != nil {
+			note += fmt.Sprintf(" (due %s, %s)", epic.DueDate, formatDaysLate(*epic.DaysLate))
+		} else {
+			note += fmt.Sprintf(" (due %s)", epic.DueDate)
+		}
+	}
+	return note
+}
+
+// sizeMixNote describes the sizes of an epic's open items and the finish
+// projected from them, against its due date
+func sizeMixNote(epic EpicProgress) string {
+	var sizes []string
+	for _, mix := range epic.RemainingSizes {
+		sizes = append(sizes, fmt.Sprintf("%d × %g (%.1f days)", mix.Items, mix.Size, mix.CycleTimeDays))
+	}
+	note := "open sizes " + strings.Join(sizes, ", ") + ": "
+	if epic.SizeMixFinish == "" {
+		return note + "no recent completions to project a finish by size from"
+	}
+	note += "projected finish " + epic.SizeMixFinish + " by size mix"
+	if epic.SizeMixDaysLate != nil {
+		note += fmt.Sprintf(" (%s)", formatDaysLate(*epic.SizeMixDaysLate))
+	}
+	return note
+}
+
+// progressBar draws a percentage as a bar of progressBarWidth characters
+func progressBar(percent float64) string {
+	filled := int(math.Round(percent / 100 * progressBarWidth))
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", progressBarWidth-filled) + "]"
+}
+
+// epicProgressTable lays out the progress of the epics for documents
+func (r *Reporter) epicProgressTable(result EpicProgressResult) render.Table {
+	table := render.Table{Headers: []string{"Epic", "State", "Done", "Total", "Complete", "First Completed", "Last Completed", "Per Week", "Projected Finish", "By Size Mix", "Due"}}
+	for _, epic := range result.Epics {
+		done, total := fmt.Sprintf("%.1f", epic.DoneAmount), fmt.Sprintf("%.1f", epic.TotalAmount)
+		if r.unit.CountsItems() {
+			done, total = fmt.Sprintf("%d", epic.DoneItems), fmt.Sprintf("%d", epic.TotalItems)
+		}
+		due := epic.DueDate
+		if epic.DaysLate != nil {
+			due += " (" + formatDaysLate(*epic.DaysLate) + ")"
+		}
+		table.Rows = append(table.Rows, []string{
+			epic.Name, epic.State, done, total, fmt.Sprintf("%.1f%%", epic.PercentComplete),
+			epic.FirstCompleted, epic.LastCompleted, fmt.Sprintf("%.1f", epic.WeeklyVelocity), epic.ProjectedFinish, epic.SizeMixFinish, due,
+		})
+	}
+	return table
+}
@@ -0,0 +1,172 @@
+package reports
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func epicProgressItems(asOf time.Time) []models.KanbanItem {
+	due := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	return []models.KanbanItem{
+		// Checkout: 8 of 20 points, 4 of them in the last 4 weeks
+		{ID: "1", Epic: "Checkout", EpicState: "In Progress", EpicDueDate: due, EpicCreatedAt: created, Estimate: 4, IsCompleted: true, CompletedAt: asOf.AddDate(0, -2, 0)},
+		{ID: "2", Epic: "Checkout", Estimate: 4, IsCompleted: true, CompletedAt: asOf.AddDate(0, 0, -7)},
+		{ID: "3", Epic: "Checkout", Estimate: 12},
+		// Search: done
+		{ID: "4", Epic: "Search", EpicState: "Done", Estimate: 3, IsCompleted: true, CompletedAt: asOf.AddDate(0, 0, -40)},
+		// Billing: nothing completed recently
+		{ID: "5", Epic: "Billing", Estimate: 5},
+		{ID: "6", Estimate: 8},
+	}
+}
+
+func TestEpicProgressResult(t *testing.T) {
+	asOf := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+	items := epicProgressItems(asOf)
+
+	result := NewReporter(items).epicProgressResult(items, asOf)
+
+	if result.AsOf != "2024-05-20" || result.VelocityWeeks != 4 {
+		t.Errorf("AsOf, VelocityWeeks = %q, %d, want 2024-05-20, 4", result.AsOf, result.VelocityWeeks)
+	}
+	var names []string
+	for _, epic := range result.Epics {
+		names = append(names, epic.Name)
+	}
+	if strings.Join(names, ",") != "Search,Checkout,Billing" {
+		t.Fatalf("epics = %v, want the furthest along first and items without an epic left out", names)
+	}
+
+	search := result.Epics[0]
+	if search.PercentComplete != 100 || search.ProjectedFinish != "2024-04-10" || search.DaysLate != nil {
+		t.Errorf("Search = %+v, want done on its last completion without a due date", search)
+	}
+
+	checkout := result.Epics[1]
+	if checkout.TotalAmount != 20 || checkout.DoneAmount != 8 || checkout.DoneItems != 2 || checkout.PercentComplete != 40 {
+		t.Errorf("Checkout = %+v, want 8 of 20 points and 2 of 3 items done", checkout)
+	}
+	if checkout.State != "In Progress" || checkout.CreatedAt != "2024-01-15" || checkout.DueDate != "2024-06-01" {
+		t.Errorf("Checkout epic fields = %q, %q, %q, want In Progress, 2024-01-15, 2024-06-01", checkout.State, checkout.CreatedAt, checkout.DueDate)
+	}
+	if checkout.FirstCompleted != "2024-03-20" || checkout.LastCompleted != "2024-05-13" {
+		t.Errorf("Checkout completions = %s to %s, want 2024-03-20 to 2024-05-13", checkout.FirstCompleted, checkout.LastCompleted)
+	}
+	// 12 points left at 1 point a week take 12 weeks
+	if checkout.WeeklyVelocity != 1 || checkout.ProjectedFinish != "2024-08-12" {
+		t.Errorf("Checkout projection = %.1f/week, finish %s, want 1.0/week, finish 2024-08-12", checkout.WeeklyVelocity, checkout.ProjectedFinish)
+	}
+	if checkout.DaysLate == nil || *checkout.DaysLate != 72 {
+		t.Errorf("Checkout DaysLate = %v, want 72", checkout.DaysLate)
+	}
+
+	billing := result.Epics[2]
+	if billing.PercentComplete != 0 || billing.ProjectedFinish != "" {
+		t.Errorf("Billing = %+v, want no progress and no projection", billing)
+	}
+}
+
+func TestGenerateEpicProgressReport(t *testing.T) {
+	asOf := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+	items := epicProgressItems(asOf)
+
+	report, err := NewReporter(items).generateEpicProgressReport(items, asOf)
+	if err != nil {
+		t.Fatalf("generateEpicProgressReport() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"Epic Progress (as of 2024-05-20",
+		"[########------------]  40.0%  8.0 of 20.0 points, 2 of 3 items",
+		"state In Progress, created 2024-01-15, completions 2024-03-20 to 2024-05-13",
+		"1.0 points/week over the last 4 weeks, projected finish 2024-08-12 (due 2024-06-01, 72 days late)",
+		"done 2024-04-10",
+		"no completions in the last 4 weeks to project a finish from",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report doesn't contain %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestGenerateReport_EpicProgressIgnoresDateRange(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Old", Epic: "Checkout", Estimate: 2, IsCompleted: true, CompletedAt: time.Now().AddDate(-1, 0, 0)},
+		{ID: "2", Name: "Open", Epic: "Checkout", Estimate: 2},
+	}
+
+	report, err := NewReporter(items).GenerateReport(ReportTypeEpicProgress, time.Now().AddDate(0, 0, -7), time.Now(), models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if !strings.Contains(report, "50.0%") {
+		t.Errorf("report doesn't count work completed before the date range:\n%s", report)
+	}
+}
+
+func TestEpicProgressResult_SizeMix(t *testing.T) {
+	asOf := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	small := func(id string, completed time.Time) models.KanbanItem {
+		return models.KanbanItem{ID: id, Epic: "Platform", EpicDueDate: due, Estimate: 1, IsCompleted: true, StartedAt: completed.AddDate(0, 0, -2), CompletedAt: completed}
+	}
+	items := []models.KanbanItem{
+		// Three small items of 2 days each in the last 4 weeks
+		small("1", asOf.AddDate(0, 0, -3)),
+		small("2", asOf.AddDate(0, 0, -10)),
+		small("3", asOf.AddDate(0, 0, -17)),
+		// Large items took 20 days, outside the epic
+		{ID: "4", Estimate: 8, IsCompleted: true, StartedAt: asOf.AddDate(0, -6, 0), CompletedAt: asOf.AddDate(0, -6, 20)},
+		// Left: two large items and one of a size without history
+		{ID: "5", Epic: "Platform", Estimate: 8},
+		{ID: "6", Epic: "Platform", Estimate: 8},
+		{ID: "7", Epic: "Platform", Estimate: 5},
+	}
+
+	epic := NewReporter(items).epicProgressResult(items, asOf).Epics[0]
+
+	// 21 points at 0.75 points a week take 28 weeks
+	if epic.ProjectedFinish != "2024-12-02" {
+		t.Errorf("ProjectedFinish = %s, want 2024-12-02", epic.ProjectedFinish)
+	}
+	// 60 days of work left at 6 days of work done in 4 weeks take 40 weeks
+	if epic.SizeMixFinish != "2025-02-24" || epic.SizeMixDaysLate == nil || *epic.SizeMixDaysLate != 55 {
+		t.Errorf("SizeMixFinish = %s, %v days late, want 2025-02-24, 55 days late", epic.SizeMixFinish, epic.SizeMixDaysLate)
+	}
+	want := []SizeMix{{Size: 8, Items: 2, CycleTimeDays: 20}, {Size: 5, Items: 1, CycleTimeDays: 20}}
+	if !reflect.DeepEqual(epic.RemainingSizes, want) {
+		t.Errorf("RemainingSizes = %+v, want %+v", epic.RemainingSizes, want)
+	}
+
+	report, err := NewReporter(items).generateEpicProgressReport(items, asOf)
+	if err != nil {
+		t.Fatalf("generateEpicProgressReport() error = %v", err)
+	}
+	if want := "open sizes 2 × 8 (20.0 days), 1 × 5 (20.0 days): projected finish 2025-02-24 by size mix (55 days late)"; !strings.Contains(report, want) {
+		t.Errorf("report doesn't contain %q:\n%s", want, report)
+	}
+}
+
+func TestSizeCycleTimes_Days(t *testing.T) {
+	sizes := sizeCycleTimes{1: 2, 3: 5, 8: 20}
+
+	tests := []struct {
+		size float64
+		want float64
+	}{
+		{3, 5},
+		{2, 5},   // Equally close to 1 and 3: the larger
+		{13, 20}, // Larger than any size with history
+		{0, 2},
+	}
+	for _, tt := range tests {
+		if got := sizes.days(tt.size); got != tt.want {
+			t.Errorf("days(%g) = %g, want %g", tt.size, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package reports
+
+import (
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+	"github.com/hannasdev/kanban-reports/pkg/filtering"
+)
+
+// ExplorerSection builds the section of HTML output that filters the items of
+// the date range in the browser by team, epic and date and totals them again
+func (r *Reporter) ExplorerSection(startDate, endDate time.Time, filterField models.FilterField) render.Section {
+	items := filtering.FilterItemsByDateRangeWithRules(r.items, startDate, endDate, filterField, r.adHocFilter, r.adHocRules)
+
+	explorer := render.Explorer{DateLabel: explorerDateLabel(filterField)}
+	if !r.unit.CountsItems() {
+		explorer.Unit = r.unit.ColumnTitle()
+	}
+	for _, item := range items {
+		explorer.Items = append(explorer.Items, explorerItem(item, r.unit.Value(item.Estimate), filterField))
+	}
+
+	return render.Section{Name: "explorer", Blocks: []render.Block{
+		render.Heading{Level: 1, Text: "Explore the Items"},
+		render.Paragraph{Lines: []string{"Pick a team, an epic or dates, or drag across the chart, to total the matching items. The other sections cover all of them."}},
+		explorer,
+	}}
+}
+
+// explorerItem names the groups of an item the way the team, epic and
+// contributor reports do
+func explorerItem(item models.KanbanItem, amount float64, filterField models.FilterField) render.ExplorerItem {
+	result := render.ExplorerItem{ID: item.ID, Name: item.Name, Team: item.Team, Epic: item.Epic, Owners: item.Owners, Amount: amount}
+	if result.Team == "" {
+		result.Team = "No Team"
+	}
+	if result.Epic == "" {
+		result.Epic = "No Epic"
+	}
+	if len(result.Owners) == 0 {
+		result.Owners = []string{"Unassigned"}
+	}
+	if date, ok := filterField.GetItemDate(item); ok {
+		result.Date = date.Format("2006-01-02")
+	}
+	return result
+}
+
+// explorerDateLabel names the dates the items are filtered by
+func explorerDateLabel(filterField models.FilterField) string {
+	switch filterField {
+	case models.FilterFieldCreatedAt:
+		return "Created"
+	case models.FilterFieldStartedAt:
+		return "Started"
+	}
+	return "Completed"
+}
@@ -0,0 +1,178 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// GroupField is the item field the group and pivot reports total by: a column
+// of the export such as priority or skill_set, the month of completion, or a
+// custom field as custom:KEY
+type GroupField string
+
+// customFieldPrefix marks a group field read from the custom_fields column
+const customFieldPrefix = "custom:"
+
+// groupFieldValues returns the values of each column an item can be grouped
+// by. Columns holding lists return each value.
+var groupFieldValues = map[string]func(models.KanbanItem) []string{
+	"type":                 func(item models.KanbanItem) []string { return []string{item.Type} },
+	"requester":            func(item models.KanbanItem) []string { return []string{item.Requester} },
+	"owners":               func(item models.KanbanItem) []string { return item.Owners },
+	"state":                func(item models.KanbanItem) []string { return []string{item.State} },
+	"epic":                 func(item models.KanbanItem) []string { return []string{item.Epic} },
+	"epic_state":           func(item models.KanbanItem) []string { return []string{item.EpicState} },
+	"project":              func(item models.KanbanItem) []string { return []string{item.Project} },
+	"iteration":            func(item models.KanbanItem) []string { return []string{item.Iteration} },
+	"team":                 func(item models.KanbanItem) []string { return []string{item.Team} },
+	"milestone":            func(item models.KanbanItem) []string { return []string{item.Milestone} },
+	"milestone_state":      func(item models.KanbanItem) []string { return []string{item.MilestoneState} },
+	"milestone_categories": func(item models.KanbanItem) []string { return item.MilestoneCategories },
+	"workflow":             func(item models.KanbanItem) []string { return []string{item.Workflow} },
+	"priority":             func(item models.KanbanItem) []string { return []string{item.Priority} },
+	"severity":             func(item models.KanbanItem) []string { return []string{item.Severity} },
+	"product_area":         func(item models.KanbanItem) []string { return item.GetProductAreas() },
+	"skill_set":            func(item models.KanbanItem) []string { return []string{item.SkillSet} },
+	"technical_area":       func(item models.KanbanItem) []string { return []string{item.TechnicalArea} },
+	"labels":               func(item models.KanbanItem) []string { return item.Labels },
+	"category":             func(item models.KanbanItem) []string { return []string{item.Category} },
+	"month":                completionMonth,
+}
+
+// completionMonth returns the month an item was completed in as YYYY-MM, so
+// that it sorts in time order
+func completionMonth(item models.KanbanItem) []string {
+	if item.CompletedAt.IsZero() {
+		return nil
+	}
+	return []string{item.CompletedAt.Format("2006-01")}
+}
+
+// ParseGroupField parses a column name, with dashes or underscores, or a
+// custom field as custom:KEY
+func ParseGroupField(s string) (GroupField, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(strings.ToLower(s), customFieldPrefix) {
+		key := strings.TrimSpace(s[len(customFieldPrefix):])
+		if key == "" {
+			return "", fmt.Errorf("invalid group-by field: %s (custom fields are given as custom:KEY)", s)
+		}
+		return GroupField(customFieldPrefix + key), nil
+	}
+
+	name := strings.ReplaceAll(strings.ToLower(s), "-", "_")
+	if _, ok := groupFieldValues[name]; !ok {
+		return "", fmt.Errorf("invalid group-by field: %s (must be custom:KEY or one of: %s)", s, strings.Join(GroupFieldNames(), ", "))
+	}
+	return GroupField(name), nil
+}
+
+// GroupFieldNames returns the columns an item can be grouped by, sorted
+func GroupFieldNames() []string {
+	names := make([]string, 0, len(groupFieldValues))
+	for name := range groupFieldValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValuesOf returns the distinct, non-empty values of the field for an item.
+// Custom field keys are matched without regard to case.
+func (f GroupField) ValuesOf(item models.KanbanItem) []string {
+	var values []string
+	if key, ok := strings.CutPrefix(string(f), customFieldPrefix); ok {
+		for k, v := range item.CustomFields {
+			if strings.EqualFold(k, key) {
+				values = append(values, v)
+				break
+			}
+		}
+	} else if valuesOf, ok := groupFieldValues[string(f)]; ok {
+		values = valuesOf(item)
+	}
+
+	var distinct []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		distinct = append(distinct, value)
+	}
+	return distinct
+}
+
+// Title returns the heading of the field: the custom field key as given, or
+// the column name in words
+func (f GroupField) Title() string {
+	if key, ok := strings.CutPrefix(string(f), customFieldPrefix); ok {
+		return key
+	}
+	words := strings.Split(string(f), "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// groupResult totals story points by the values of the group-by field. Like
+// labels, items count in full toward each of their values, so the total
+// counts each item once rather than adding up the groups.
+func (r *Reporter) groupResult(items []models.KanbanItem) TotalsResult {
+	groupPoints := make(map[string]float64)
+	groupItems := make(map[string]int)
+	totalPoints := 0.0
+	multiValueItems := 0
+
+	for _, item := range items {
+		values := r.groupBy.ValuesOf(item)
+		if len(values) == 0 {
+			values = []string{"Unspecified"}
+		}
+		if len(values) > 1 {
+			multiValueItems++
+		}
+
+		points := r.unit.Value(item.Estimate)
+		for _, value := range values {
+			groupPoints[value] += points
+			groupItems[value]++
+		}
+		totalPoints += points
+	}
+
+	return TotalsResult{
+		Groups:          groupTotals(groupPoints, groupItems),
+		Amount:          totalPoints,
+		Items:           len(items),
+		MultiValueItems: multiValueItems,
+	}
+}
+
+// generateGroupReport creates a report of story points by the group-by field
+func (r *Reporter) generateGroupReport(items []models.KanbanItem) (string, error) {
+	if r.groupBy == "" {
+		return "", fmt.Errorf("the group report needs a field to group by")
+	}
+	result := r.groupResult(items)
+	report := r.formatTotals(r.groupBy.Title(), 30, result)
+
+	if result.MultiValueItems > 0 {
+		report += "\n" + r.multiValueNote(result.MultiValueItems) + "\n"
+	}
+
+	return report, nil
+}
+
+// multiValueNote explains how items with several values of the field are counted
+func (r *Reporter) multiValueNote(multiValueItems int) string {
+	return fmt.Sprintf("%d items have several %s values and are counted in full under each, so the groups add up to more than the total.", multiValueItems, r.groupBy.Title())
+}
@@ -0,0 +1,102 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestGenerateGroupReport(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Priority: "High", Owners: []string{"alice", "bob"}, CustomFields: map[string]string{"Domain": "payments"}, Estimate: 5},
+		{ID: "2", Priority: "Low", Owners: []string{"alice"}, CustomFields: map[string]string{"domain": "search"}, Estimate: 2},
+		{ID: "3", Priority: "High", CustomFields: map[string]string{"domain": "payments"}, Estimate: 1},
+		{ID: "4", Estimate: 2}, // No priority, owners or custom fields
+	}
+
+	tests := []struct {
+		name     string
+		field    string
+		expected []string
+	}{
+		{
+			name:  "Column",
+			field: "priority",
+			expected: []string{
+				"Story Points by Priority:",
+				"High                              6.0 points    2 items",
+				"Low                               2.0 points    1 items",
+				"Unspecified                       2.0 points    1 items",
+				"Total: 10.0 points across 4 items",
+			},
+		},
+		{
+			name:  "Custom field matched without regard to case",
+			field: "custom:domain",
+			expected: []string{
+				"Story Points by domain:",
+				"payments                          6.0 points    2 items",
+				"search                            2.0 points    1 items",
+				"Unspecified                       2.0 points    1 items",
+			},
+		},
+		{
+			name:  "List column counts items under each value",
+			field: "owners",
+			expected: []string{
+				"Story Points by Owners:",
+				"alice                             7.0 points    2 items",
+				"bob                               5.0 points    1 items",
+				"Unspecified                       3.0 points    2 items",
+				"Total: 10.0 points across 4 items",
+				"1 items have several Owners values and are counted in full under each",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, err := ParseGroupField(tt.field)
+			if err != nil {
+				t.Fatalf("ParseGroupField(%q) error = %v", tt.field, err)
+			}
+			report, err := NewReporter(items).WithGroupBy(field).generateGroupReport(items)
+			if err != nil {
+				t.Fatalf("generateGroupReport() error = %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(report, want) {
+					t.Errorf("Report doesn't contain %q\n%s", want, report)
+				}
+			}
+		})
+	}
+}
+
+func TestParseGroupField(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected GroupField
+		wantErr  bool
+	}{
+		{input: "priority", expected: "priority"},
+		{input: "Skill-Set", expected: "skill_set"},
+		{input: "custom:Domain", expected: "custom:Domain"},
+		{input: "CUSTOM: domain ", expected: "custom:domain"},
+		{input: "custom:", wantErr: true},
+		{input: "estimate", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			field, err := ParseGroupField(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGroupField(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if field != tt.expected {
+				t.Errorf("ParseGroupField(%q) = %q, want %q", tt.input, field, tt.expected)
+			}
+		})
+	}
+}
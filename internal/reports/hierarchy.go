@@ -0,0 +1,165 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// hierarchyNameWidth is the column width used for names, including indentation
+const hierarchyNameWidth = 50
+
+// hierarchyNode is a single level in the project → epic → item breakdown
+type hierarchyNode struct {
+	name      string
+	points    float64
+	itemCount int
+	children  map[string]*hierarchyNode
+	items     []models.KanbanItem
+}
+
+// newHierarchyNode creates an empty node with the given name
+func newHierarchyNode(name string) *hierarchyNode {
+	return &hierarchyNode{
+		name:     name,
+		children: make(map[string]*hierarchyNode),
+	}
+}
+
+// child returns the named child node, creating it if needed
+func (n *hierarchyNode) child(name string) *hierarchyNode {
+	c, exists := n.children[name]
+	if !exists {
+		c = newHierarchyNode(name)
+		n.children[name] = c
+	}
+	return c
+}
+
+// sortedChildren returns child nodes ordered by points (descending), then name
+func (n *hierarchyNode) sortedChildren() []*hierarchyNode {
+	children := make([]*hierarchyNode, 0, len(n.children))
+	for _, c := range n.children {
+		children = append(children, c)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].points != children[j].points {
+			return children[i].points > children[j].points
+		}
+		return children[i].name < children[j].name
+	})
+	return children
+}
+
+// buildHierarchy groups items into a project → epic → item tree with subtotals in the given unit
+func buildHierarchy(items []models.KanbanItem, unit types.EstimateUnit) *hierarchyNode {
+	root := newHierarchyNode("")
+
+	for _, item := range items {
+		projectName := item.Project
+		if projectName == "" {
+			projectName = "No Project"
+		}
+
+		epicName := item.Epic
+		if epicName == "" {
+			epicName = "No Epic"
+		}
+
+		project := root.child(projectName)
+		epic := project.child(epicName)
+
+		for _, node := range []*hierarchyNode{root, project, epic} {
+			node.points += unit.Value(item.Estimate)
+			node.itemCount++
+		}
+		epic.items = append(epic.items, item)
+	}
+
+	return root
+}
+
+// HierarchyEntry is a project, epic or item in the breakdown with its subtotal
+type HierarchyEntry struct {
+	Name     string           `json:"name"`
+	ID       string           `json:"id,omitempty"`     // Only set for items
+	Source   *models.Source   `json:"source,omitempty"` // Only set for items read from a CSV file
+	Amount   float64          `json:"amount"`
+	Items    int              `json:"items"`
+	Children []HierarchyEntry `json:"children,omitempty"`
+}
+
+// hierarchyResult returns the projects of the breakdown, each with its epics
+// and their items, and the total of all of them
+func (r *Reporter) hierarchyResult(items []models.KanbanItem) HierarchyEntry {
+	root := buildHierarchy(items, r.unit)
+	result := HierarchyEntry{Amount: root.points, Items: root.itemCount, Children: []HierarchyEntry{}}
+
+	for _, project := range root.sortedChildren() {
+		projectEntry := HierarchyEntry{Name: project.name, Amount: project.points, Items: project.itemCount}
+
+		for _, epic := range project.sortedChildren() {
+			epicEntry := HierarchyEntry{Name: epic.name, Amount: epic.points, Items: epic.itemCount}
+
+			// Sort items by points in descending order, then by ID
+			epicItems := epic.items
+			sort.SliceStable(epicItems, func(i, j int) bool {
+				if epicItems[i].Estimate != epicItems[j].Estimate {
+					return epicItems[i].Estimate > epicItems[j].Estimate
+				}
+				return epicItems[i].ID < epicItems[j].ID
+			})
+
+			for _, item := range epicItems {
+				epicEntry.Children = append(epicEntry.Children, HierarchyEntry{
+					Name:   item.Name,
+					ID:     item.ID,
+					Source: item.SourceRef(),
+					Amount: r.unit.Value(item.Estimate),
+					Items:  1,
+				})
+			}
+			projectEntry.Children = append(projectEntry.Children, epicEntry)
+		}
+		result.Children = append(result.Children, projectEntry)
+	}
+
+	return result
+}
+
+// generateHierarchySection creates an indented project → epic → item breakdown
+func (r *Reporter) generateHierarchySection(items []models.KanbanItem) string {
+	result := r.hierarchyResult(items)
+
+	report := r.unit.Title() + " by Project → Epic → Item:\n\n"
+
+	for _, project := range result.Children {
+		report += r.formatHierarchyLine(0, project.Name, project.Amount, project.Items)
+
+		for _, epic := range project.Children {
+			report += r.formatHierarchyLine(1, epic.Name, epic.Amount, epic.Items)
+
+			for _, item := range epic.Children {
+				indent := strings.Repeat("  ", 2)
+				label := fmt.Sprintf("#%s %s", item.ID, item.Name)
+				report += fmt.Sprintf("%s%-*s %6.1f %s\n",
+					indent, hierarchyNameWidth-len(indent), label, item.Amount, r.unit.Label())
+			}
+		}
+		report += "\n"
+	}
+
+	report += r.formatTotal(result.Amount, result.Items)
+
+	return report
+}
+
+// formatHierarchyLine formats a subtotal line indented to the given depth
+func (r *Reporter) formatHierarchyLine(depth int, name string, points float64, itemCount int) string {
+	indent := strings.Repeat("  ", depth)
+	return fmt.Sprintf("%s%-*s %s\n",
+		indent, hierarchyNameWidth-len(indent), name, r.formatAmount(points, itemCount))
+}
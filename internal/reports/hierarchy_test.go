@@ -0,0 +1,119 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestBuildHierarchy(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Project: "Platform", Epic: "Auth", Estimate: 3},
+		{ID: "2", Name: "Task 2", Project: "Platform", Epic: "Auth", Estimate: 2},
+		{ID: "3", Name: "Task 3", Project: "Platform", Epic: "", Estimate: 1},
+		{ID: "4", Name: "Task 4", Project: "", Epic: "Billing", Estimate: 5},
+	}
+
+	root := buildHierarchy(items, types.UnitPoints)
+
+	if root.points != 11 || root.itemCount != 4 {
+		t.Errorf("Root totals = %.1f points / %d items, want 11.0 / 4", root.points, root.itemCount)
+	}
+
+	platform, ok := root.children["Platform"]
+	if !ok {
+		t.Fatalf("Expected 'Platform' project node")
+	}
+	if platform.points != 6 || platform.itemCount != 3 {
+		t.Errorf("Platform totals = %.1f / %d, want 6.0 / 3", platform.points, platform.itemCount)
+	}
+
+	auth, ok := platform.children["Auth"]
+	if !ok {
+		t.Fatalf("Expected 'Auth' epic node under Platform")
+	}
+	if auth.points != 5 || len(auth.items) != 2 {
+		t.Errorf("Auth totals = %.1f / %d items, want 5.0 / 2", auth.points, len(auth.items))
+	}
+
+	if _, ok := platform.children["No Epic"]; !ok {
+		t.Errorf("Expected item without epic to be grouped under 'No Epic'")
+	}
+
+	noProject, ok := root.children["No Project"]
+	if !ok {
+		t.Fatalf("Expected item without project to be grouped under 'No Project'")
+	}
+	if _, ok := noProject.children["Billing"]; !ok {
+		t.Errorf("Expected 'Billing' epic under 'No Project'")
+	}
+}
+
+func TestGenerateHierarchySection(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Login page", Project: "Platform", Epic: "Auth", Estimate: 3},
+		{ID: "2", Name: "Invoices", Project: "Finance", Epic: "Billing", Estimate: 8},
+	}
+
+	reporter := NewReporter(items)
+	section := reporter.generateHierarchySection(items)
+
+	expected := []string{
+		"Story Points by Project → Epic → Item",
+		"Platform",
+		"  Auth",
+		"    #1 Login page",
+		"Finance",
+		"  Billing",
+		"    #2 Invoices",
+		"Total: 11.0 points across 2 items",
+	}
+	for _, str := range expected {
+		if !strings.Contains(section, str) {
+			t.Errorf("Hierarchy section doesn't contain expected string: %q\nGot:\n%s", str, section)
+		}
+	}
+
+	// Projects are ordered by points, so Finance (8) comes before Platform (3)
+	if strings.Index(section, "Finance") > strings.Index(section, "Platform") {
+		t.Errorf("Expected projects to be sorted by points in descending order")
+	}
+}
+
+func TestGenerateReportWithHierarchy(t *testing.T) {
+	items := []models.KanbanItem{
+		{
+			ID:          "1",
+			Name:        "Task 1",
+			Project:     "Platform",
+			Epic:        "Auth",
+			Team:        "Team A",
+			IsCompleted: true,
+			CompletedAt: time.Now(),
+			Estimate:    3,
+		},
+	}
+
+	reporter := NewReporter(items)
+
+	report, err := reporter.GenerateReport(ReportTypeTeam, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if strings.Contains(report, "Project → Epic → Item") {
+		t.Errorf("Hierarchy section should not be included by default")
+	}
+
+	report, err = reporter.WithHierarchy(true).GenerateReport(ReportTypeTeam, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	for _, str := range []string{"Story Points by Team", "Story Points by Project → Epic → Item", "Platform", "#1 Task 1"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q", str)
+		}
+	}
+}
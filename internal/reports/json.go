@@ -0,0 +1,150 @@
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/schema"
+	"github.com/hannasdev/kanban-reports/pkg/filtering"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// Section is the result of one report type in a JSON document
+type Section struct {
+	Type ReportType  `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// Document is the JSON form of a report run, with one section per report type
+type Document struct {
+	ReportTypes []ReportType          `json:"report_types"`
+	Start       string                `json:"start,omitempty"`
+	End         string                `json:"end,omitempty"`
+	AdHocFilter types.AdHocFilterType `json:"ad_hoc_filter"`
+	Unit        types.EstimateUnit    `json:"unit"`
+	Message     string                `json:"message,omitempty"`
+	Sections    []Section             `json:"sections"`
+	Hierarchy   *HierarchyEntry       `json:"hierarchy,omitempty"`
+}
+
+// generateJSON builds the JSON document for the report types from the filtered items
+func (r *Reporter) generateJSON(reportTypes []ReportType, items []models.KanbanItem, startDate, endDate time.Time) (string, error) {
+	doc := Document{
+		ReportTypes: reportTypes,
+		AdHocFilter: r.adHocFilter,
+		Unit:        r.unit,
+		Sections:    []Section{},
+	}
+	if !startDate.IsZero() {
+		doc.Start = startDate.Format("2006-01-02")
+	}
+	if !endDate.IsZero() {
+		doc.End = endDate.Format("2006-01-02")
+	}
+
+	if len(items) == 0 && !includesOpenWork(reportTypes) {
+		doc.Message = "No items completed in the specified date range."
+	} else {
+		for _, reportType := range reportTypes {
+			data, err := r.sectionResult(reportType, items)
+			if err != nil {
+				return "", err
+			}
+			doc.Sections = append(doc.Sections, Section{reportType, data})
+		}
+		if r.hierarchy {
+			hierarchy := r.hierarchyResult(items)
+			doc.Hierarchy = &hierarchy
+		}
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error encoding report as JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// Result computes the structured result of a report type over the items
+// in the date range: the data of its section in the JSON output
+func (r *Reporter) Result(reportType ReportType, startDate, endDate time.Time, filterField models.FilterField) (interface{}, error) {
+	filteredItems := filtering.FilterItemsByDateRangeWithRules(r.items, startDate, endDate, filterField, r.adHocFilter, r.adHocRules)
+	return r.sectionResult(reportType, filteredItems)
+}
+
+// sectionResult computes the structured result of a single report type
+func (r *Reporter) sectionResult(reportType ReportType, items []models.KanbanItem) (interface{}, error) {
+	switch reportType {
+	case ReportTypeContributor:
+		return r.contributorResult(items), nil
+	case ReportTypeEpic:
+		return r.epicResult(items), nil
+	case ReportTypeProductArea:
+		return r.productAreaResult(items), nil
+	case ReportTypeTeam:
+		return r.teamResult(items), nil
+	case ReportTypeCategory:
+		return r.categoryResult(items), nil
+	case ReportTypeWorkload:
+		// Open work has no completion date, so it is taken from all items
+		return r.workloadResult(r.openItems(), time.Now()), nil
+	case ReportTypeContention:
+		return r.contentionResult(r.withOpenItems(items), r.minEpics, time.Now()), nil
+	case ReportTypeEpicContributor:
+		return r.epicContributorResult(items), nil
+	case ReportTypeTeamMonth:
+		return r.teamMonthResult(items), nil
+	case ReportTypeLabel:
+		return r.labelResult(items), nil
+	case ReportTypeMilestone:
+		return r.milestoneResult(items), nil
+	case ReportTypeIteration:
+		return r.iterationResult(items), nil
+	case ReportTypeState:
+		// Open work has no completion date, so it is taken from all items
+		return r.stateResult(r.openItems(), items, time.Now()), nil
+	case ReportTypeGroup:
+		if r.groupBy == "" {
+			return nil, fmt.Errorf("the group report needs a field to group by")
+		}
+		return r.groupResult(items), nil
+	case ReportTypePivot:
+		if r.pivotRows == "" || r.pivotCols == "" {
+			return nil, fmt.Errorf("the pivot report needs fields for its rows and columns")
+		}
+		return r.pivotResult(items), nil
+	case ReportTypeEpicProgress:
+		// An epic's progress covers all of its work, so it is taken from all items
+		return r.epicProgressResult(r.scopedItems(), time.Now()), nil
+	case ReportTypeAllocation:
+		return r.allocationResult(items), nil
+	default:
+		return nil, fmt.Errorf("unknown report type: %s", reportType)
+	}
+}
+
+// JSONSchema describes the JSON document of reports, with the result held by
+// the section of each report type
+func JSONSchema() (schema.Schema, error) {
+	return schema.ForDocument("Kanban report", Document{}, Section{}, []schema.Section{
+		{Type: string(ReportTypeContributor), Results: []interface{}{TotalsResult{}}},
+		{Type: string(ReportTypeEpic), Results: []interface{}{TotalsResult{}}},
+		{Type: string(ReportTypeProductArea), Results: []interface{}{TotalsResult{}}},
+		{Type: string(ReportTypeTeam), Results: []interface{}{TotalsResult{}}},
+		{Type: string(ReportTypeCategory), Results: []interface{}{TotalsResult{}}},
+		{Type: string(ReportTypeWorkload), Results: []interface{}{WorkloadResult{}}},
+		{Type: string(ReportTypeContention), Results: []interface{}{ContentionResult{}}},
+		{Type: string(ReportTypeEpicContributor), Results: []interface{}{EpicContributorResult{}}},
+		{Type: string(ReportTypeTeamMonth), Results: []interface{}{TeamMonthResult{}}},
+		{Type: string(ReportTypeLabel), Results: []interface{}{TotalsResult{}}},
+		{Type: string(ReportTypeMilestone), Results: []interface{}{DeliveryResult{}}},
+		{Type: string(ReportTypeIteration), Results: []interface{}{DeliveryResult{}}},
+		{Type: string(ReportTypeState), Results: []interface{}{StateResult{}}},
+		{Type: string(ReportTypeGroup), Results: []interface{}{TotalsResult{}}},
+		{Type: string(ReportTypePivot), Results: []interface{}{PivotResult{}}},
+		{Type: string(ReportTypeEpicProgress), Results: []interface{}{EpicProgressResult{}}},
+		{Type: string(ReportTypeAllocation), Results: []interface{}{AllocationResult{}}},
+	})
+}
@@ -0,0 +1,123 @@
+package reports
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/schema"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestGenerateReportJSON(t *testing.T) {
+	completed := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Estimate: 3, IsCompleted: true, CompletedAt: completed, Owners: []string{"alice"}, Epic: "Epic A", Project: "Project X"},
+		{ID: "2", Name: "Task 2", Estimate: 2, IsCompleted: true, CompletedAt: completed, Owners: []string{"alice", "bob"}, Epic: "Epic B", Project: "Project X"},
+	}
+
+	output, err := NewReporter(items).WithFormat(types.FormatJSON).WithHierarchy(true).
+		GenerateReports([]ReportType{ReportTypeContributor, ReportTypeEpic}, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReports() error = %v", err)
+	}
+
+	var doc struct {
+		ReportTypes []ReportType `json:"report_types"`
+		Sections    []struct {
+			Type ReportType   `json:"type"`
+			Data TotalsResult `json:"data"`
+		} `json:"sections"`
+		Hierarchy *HierarchyEntry `json:"hierarchy"`
+	}
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("GenerateReports() returned invalid JSON: %v\n%s", err, output)
+	}
+
+	if len(doc.Sections) != 2 || doc.Sections[0].Type != ReportTypeContributor || doc.Sections[1].Type != ReportTypeEpic {
+		t.Fatalf("sections = %+v, want contributor and epic", doc.Sections)
+	}
+
+	contributors := doc.Sections[0].Data
+	if len(contributors.Groups) != 2 || contributors.Groups[0].Name != "alice" || contributors.Groups[0].Amount != 4 {
+		t.Errorf("contributor groups = %+v, want alice first with 4 points", contributors.Groups)
+	}
+	if contributors.Amount != 5 {
+		t.Errorf("contributor total = %v, want 5", contributors.Amount)
+	}
+
+	if doc.Hierarchy == nil || len(doc.Hierarchy.Children) != 1 || len(doc.Hierarchy.Children[0].Children) != 2 {
+		t.Fatalf("hierarchy = %+v, want one project with two epics", doc.Hierarchy)
+	}
+	if item := doc.Hierarchy.Children[0].Children[0].Children[0]; item.ID != "1" || item.Amount != 3 {
+		t.Errorf("first item = %+v, want #1 with 3 points", item)
+	}
+}
+
+func TestReporterResult(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Estimate: 3, IsCompleted: true, CompletedAt: time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC), Team: "Platform"},
+		{ID: "2", Estimate: 2, IsCompleted: true, CompletedAt: time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), Team: "Platform"},
+	}
+
+	result, err := NewReporter(items).Result(ReportTypeTeam,
+		time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("Result() error = %v", err)
+	}
+	totals, ok := result.(TotalsResult)
+	if !ok {
+		t.Fatalf("Result() = %T, want TotalsResult", result)
+	}
+	if totals.Amount != 2 || totals.Items != 1 {
+		t.Errorf("Result() = %+v, want only the item completed in June", totals)
+	}
+}
+
+func TestGenerateReportJSONNoItems(t *testing.T) {
+	output, err := NewReporter(nil).WithFormat(types.FormatJSON).
+		GenerateReport(ReportTypeTeam, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if !json.Valid([]byte(output)) {
+		t.Fatalf("GenerateReport() returned invalid JSON:\n%s", output)
+	}
+	if !strings.Contains(output, `"message": "No items completed in the specified date range."`) {
+		t.Errorf("output doesn't contain the no-items message:\n%s", output)
+	}
+}
+
+func TestJSONSchema_MatchesOutput(t *testing.T) {
+	jsonSchema, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+
+	completed := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Estimate: 3, IsCompleted: true, CompletedAt: completed, StartedAt: completed.AddDate(0, 0, -3), Owners: []string{"alice"}, Epic: "Epic A", Team: "Platform", ProductArea: "Checkout"},
+		{ID: "2", Name: "Task 2", Estimate: 2, StartedAt: completed, Owners: []string{"alice", "bob"}, Epic: "Epic B"},
+	}
+	reportTypes := []ReportType{
+		ReportTypeContributor, ReportTypeEpic, ReportTypeProductArea, ReportTypeTeam, ReportTypeCategory,
+		ReportTypeWorkload, ReportTypeContention, ReportTypeEpicContributor, ReportTypeTeamMonth,
+	}
+
+	output, err := NewReporter(items).WithFormat(types.FormatJSON).WithHierarchy(true).
+		GenerateReports(reportTypes, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReports() error = %v", err)
+	}
+	if err := schema.Validate(jsonSchema, []byte(output)); err != nil {
+		t.Errorf("Output doesn't match the schema: %v\n%s", err, output)
+	}
+
+	// A section claiming another type's result is rejected
+	mismatched := strings.Replace(output, `"type": "workload"`, `"type": "team"`, 1)
+	if err := schema.Validate(jsonSchema, []byte(mismatched)); err == nil {
+		t.Error("Validate() accepted a workload result in a team section")
+	}
+}
@@ -0,0 +1,84 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// labelResult totals story points by label. Items count in full toward each
+// of their labels, so the total counts each item once rather than adding up
+// the labels. With a label prefix only the labels starting with it count.
+func (r *Reporter) labelResult(items []models.KanbanItem) TotalsResult {
+	labelPoints := make(map[string]float64)
+	labelItems := make(map[string]int)
+	totalPoints := 0.0
+	multiLabelItems := 0
+
+	for _, item := range items {
+		labels := r.matchingLabels(item)
+		if len(labels) == 0 {
+			labels = []string{"Unlabeled"}
+		}
+		if len(labels) > 1 {
+			multiLabelItems++
+		}
+
+		points := r.unit.Value(item.Estimate)
+		for _, label := range labels {
+			labelPoints[label] += points
+			labelItems[label]++
+		}
+		totalPoints += points
+	}
+
+	return TotalsResult{
+		Groups:          groupTotals(labelPoints, labelItems),
+		Amount:          totalPoints,
+		Items:           len(items),
+		MultiLabelItems: multiLabelItems,
+	}
+}
+
+// matchingLabels returns the labels of an item that start with the label
+// prefix, without regard to case, each once
+func (r *Reporter) matchingLabels(item models.KanbanItem) []string {
+	prefix := strings.ToLower(r.labelPrefix)
+	var labels []string
+	seen := make(map[string]bool)
+	for _, label := range item.Labels {
+		label = strings.TrimSpace(label)
+		if label == "" || seen[label] || !strings.HasPrefix(strings.ToLower(label), prefix) {
+			continue
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// generateLabelReport creates a report of story points by label
+func (r *Reporter) generateLabelReport(items []models.KanbanItem) (string, error) {
+	result := r.labelResult(items)
+	report := r.formatTotals(r.labelHeading(), 30, result)
+
+	if result.MultiLabelItems > 0 {
+		report += "\n" + r.multiLabelNote(result.MultiLabelItems) + "\n"
+	}
+
+	return report, nil
+}
+
+// labelHeading names the groups of the label report, with the prefix they share
+func (r *Reporter) labelHeading() string {
+	if r.labelPrefix != "" {
+		return fmt.Sprintf("Label (%s)", r.labelPrefix)
+	}
+	return "Label"
+}
+
+// multiLabelNote explains how items with several labels are counted
+func (r *Reporter) multiLabelNote(multiLabelItems int) string {
+	return fmt.Sprintf("%d items have several labels and are counted in full under each, so the labels add up to more than the total.", multiLabelItems)
+}
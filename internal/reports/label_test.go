@@ -0,0 +1,69 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestGenerateLabelReport(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Labels: []string{"component:api", "bug"}, Estimate: 5},
+		{ID: "2", Labels: []string{"Component:web", "component:api"}, Estimate: 2},
+		{ID: "3", Labels: []string{"bug"}, Estimate: 1},
+		{ID: "4", Estimate: 2}, // No labels
+	}
+
+	tests := []struct {
+		name     string
+		prefix   string
+		expected []string
+		missing  []string
+	}{
+		{
+			name:   "All labels",
+			prefix: "",
+			expected: []string{
+				"Story Points by Label:",
+				"component:api                     7.0 points    2 items",
+				"bug                               6.0 points    2 items",
+				"Unlabeled                         2.0 points    1 items",
+				"Total: 10.0 points across 4 items",
+				"2 items have several labels and are counted in full under each",
+			},
+		},
+		{
+			name:   "Prefix matched without regard to case",
+			prefix: "component:",
+			expected: []string{
+				"Story Points by Label (component:):",
+				"component:api                     7.0 points    2 items",
+				"Component:web                     2.0 points    1 items",
+				"Unlabeled                         3.0 points    2 items",
+				"Total: 10.0 points across 4 items",
+				"1 items have several labels",
+			},
+			missing: []string{"bug"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := NewReporter(items).WithLabelPrefix(tt.prefix).generateLabelReport(items)
+			if err != nil {
+				t.Fatalf("generateLabelReport() error = %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(report, want) {
+					t.Errorf("Report doesn't contain %q\n%s", want, report)
+				}
+			}
+			for _, unwanted := range tt.missing {
+				if strings.Contains(report, unwanted) {
+					t.Errorf("Report shouldn't contain %q\n%s", unwanted, report)
+				}
+			}
+		})
+	}
+}
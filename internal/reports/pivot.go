@@ -0,0 +1,146 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+)
+
+// PivotRow is the work of one value of the row field, split by the values of
+// the column field
+type PivotRow struct {
+	Name    string    `json:"name"`
+	Amounts []float64 `json:"amounts"` // One per column
+	Amount  float64   `json:"total_amount"`
+	Items   int       `json:"total_items"`
+}
+
+// PivotResult holds the work of each pair of row and column values as a
+// cross-tab. Items with several values of a field, such as owners or labels,
+// count in full toward each, so the cells may add up to more than the totals.
+type PivotResult struct {
+	RowField     string     `json:"row_field"`
+	ColumnField  string     `json:"column_field"`
+	Columns      []string   `json:"columns"`
+	Rows         []PivotRow `json:"rows"`
+	ColumnTotals []float64  `json:"column_totals"`
+	Amount       float64    `json:"total_amount"`
+	Items        int        `json:"total_items"`
+}
+
+// pivotValues returns the values of the field for an item, or Unspecified
+func pivotValues(field GroupField, item models.KanbanItem) []string {
+	values := field.ValuesOf(item)
+	if len(values) == 0 {
+		return []string{"Unspecified"}
+	}
+	return values
+}
+
+// pivotOrder sorts the values of a field: months in time order, others by
+// amount in descending order, then by name
+func pivotOrder(field GroupField, amounts map[string]float64) []string {
+	names := make([]string, 0, len(amounts))
+	for name := range amounts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if field != "month" && amounts[names[i]] != amounts[names[j]] {
+			return amounts[names[i]] > amounts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// pivotResult totals the work of the items by the values of the row and
+// column fields
+func (r *Reporter) pivotResult(items []models.KanbanItem) PivotResult {
+	result := PivotResult{
+		RowField:     r.pivotRows.Title(),
+		ColumnField:  r.pivotCols.Title(),
+		Columns:      []string{},
+		Rows:         []PivotRow{},
+		ColumnTotals: []float64{},
+	}
+
+	cells := make(map[string]map[string]float64)
+	rowAmounts := make(map[string]float64)
+	rowItems := make(map[string]int)
+	columnAmounts := make(map[string]float64)
+	for _, item := range items {
+		value := r.unit.Value(item.Estimate)
+		columns := pivotValues(r.pivotCols, item)
+		for _, row := range pivotValues(r.pivotRows, item) {
+			if cells[row] == nil {
+				cells[row] = make(map[string]float64)
+			}
+			for _, column := range columns {
+				cells[row][column] += value
+			}
+			rowAmounts[row] += value
+			rowItems[row]++
+		}
+		for _, column := range columns {
+			columnAmounts[column] += value
+		}
+		result.Amount += value
+		result.Items++
+	}
+
+	result.Columns = pivotOrder(r.pivotCols, columnAmounts)
+	for _, column := range result.Columns {
+		result.ColumnTotals = append(result.ColumnTotals, columnAmounts[column])
+	}
+	for _, name := range pivotOrder(r.pivotRows, rowAmounts) {
+		row := PivotRow{Name: name, Amount: rowAmounts[name], Items: rowItems[name]}
+		for _, column := range result.Columns {
+			row.Amounts = append(row.Amounts, cells[name][column])
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result
+}
+
+// pivotTable lays out the result as a matrix with a row per row value, a
+// column per column value, and totals in the last row and column
+func (r *Reporter) pivotTable(result PivotResult) render.Table {
+	amount := r.cellAmount
+	table := render.Table{Headers: append(append([]string{result.RowField}, result.Columns...), "Total"), Heatmap: true}
+	for _, pivotRow := range result.Rows {
+		row := []string{pivotRow.Name}
+		for _, value := range pivotRow.Amounts {
+			row = append(row, amount(value))
+		}
+		table.Rows = append(table.Rows, append(row, amount(pivotRow.Amount)))
+	}
+	totals := []string{"Total"}
+	for _, value := range result.ColumnTotals {
+		totals = append(totals, amount(value))
+	}
+	table.Rows = append(table.Rows, append(totals, amount(result.Amount)))
+
+	return table
+}
+
+// pivotTitle returns the heading of the pivot report
+func (r *Reporter) pivotTitle() string {
+	return fmt.Sprintf("%s by %s and %s", r.unit.Title(), r.pivotRows.Title(), r.pivotCols.Title())
+}
+
+// generatePivotReport creates a cross-tab of the work by the row and column fields
+func (r *Reporter) generatePivotReport(items []models.KanbanItem) (string, error) {
+	if r.pivotRows == "" || r.pivotCols == "" {
+		return "", fmt.Errorf("the pivot report needs fields for its rows and columns")
+	}
+	result := r.pivotResult(items)
+	title := r.pivotTitle() + ":\n\n"
+	if len(result.Rows) == 0 {
+		return title + "No completed items.\n", nil
+	}
+
+	return title + formatMatrix(r.pivotTable(result)), nil
+}
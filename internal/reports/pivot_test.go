@@ -0,0 +1,78 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// pivotItems are completed work of two teams across item types and months
+func pivotItems() []models.KanbanItem {
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC) }
+	return []models.KanbanItem{
+		{ID: "1", Team: "Platform", Type: "feature", Estimate: 5, IsCompleted: true, CompletedAt: day(time.March, 4)},
+		{ID: "2", Team: "Platform", Type: "bug", Estimate: 1, IsCompleted: true, CompletedAt: day(time.January, 9)},
+		{ID: "3", Team: "Mobile", Type: "feature", Estimate: 3, IsCompleted: true, CompletedAt: day(time.January, 22)},
+		{ID: "4", Type: "chore", Estimate: 2, IsCompleted: true, CompletedAt: day(time.March, 15)}, // No team
+	}
+}
+
+func TestPivotResult(t *testing.T) {
+	result := NewReporter(nil).WithPivot("team", "type").pivotResult(pivotItems())
+
+	if strings.Join(result.Columns, ",") != "feature,chore,bug" {
+		t.Errorf("columns = %v, want feature, chore, bug by amount", result.Columns)
+	}
+	if len(result.Rows) != 3 || result.Rows[0].Name != "Platform" || result.Rows[2].Name != "Unspecified" {
+		t.Fatalf("rows = %+v, want Platform, Mobile, Unspecified", result.Rows)
+	}
+
+	platform := result.Rows[0]
+	if platform.Amounts[0] != 5 || platform.Amounts[1] != 0 || platform.Amounts[2] != 1 || platform.Amount != 6 || platform.Items != 2 {
+		t.Errorf("Platform = %+v, want 5, 0, 1 for 6 points across 2 items", platform)
+	}
+	if result.ColumnTotals[0] != 8 || result.Amount != 11 || result.Items != 4 {
+		t.Errorf("totals = %v / %.1f / %d, want 8 feature points of 11 across 4 items", result.ColumnTotals, result.Amount, result.Items)
+	}
+
+	// Months are in time order rather than by amount
+	result = NewReporter(nil).WithPivot("type", "month").pivotResult(pivotItems())
+	if strings.Join(result.Columns, ",") != "2024-01,2024-03" {
+		t.Errorf("columns = %v, want 2024-01, 2024-03", result.Columns)
+	}
+}
+
+func TestGeneratePivotReport(t *testing.T) {
+	reporter := NewReporter(nil).WithPivot("team", "type")
+	report, err := reporter.generatePivotReport(pivotItems())
+	if err != nil {
+		t.Fatalf("generatePivotReport() error = %v", err)
+	}
+
+	for _, str := range []string{
+		"Story Points by Team and Type:",
+		"Team        | feature | chore | bug | Total",
+		"Platform    |     5.0 |       | 1.0 |   6.0",
+		"Total       |     8.0 |   2.0 | 1.0 |  11.0",
+	} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	reporter.WithUnit(types.UnitItems)
+	report, err = reporter.generatePivotReport(pivotItems())
+	if err != nil {
+		t.Fatalf("generatePivotReport() error = %v", err)
+	}
+	if !strings.Contains(report, "Platform    |       1 |   1 |       |     2") {
+		t.Errorf("Items report should count items\nGot:\n%s", report)
+	}
+
+	if _, err := NewReporter(nil).generatePivotReport(pivotItems()); err == nil {
+		t.Error("generatePivotReport() without fields should return an error")
+	}
+}
@@ -2,62 +2,62 @@ package reports
 
 import (
 	"fmt"
-	"sort"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
 )
 
-// generateProductAreaReport creates a report of story points by product area
-func (r *Reporter) generateProductAreaReport(items []models.KanbanItem) (string, error) {
+// productAreaResult totals story points by product area, attributing items in
+// several areas according to the product area mode
+func (r *Reporter) productAreaResult(items []models.KanbanItem) TotalsResult {
 	// Map to track points by product area
 	areaPoints := make(map[string]float64)
 	areaItems := make(map[string]int)
 	
+	multiAreaItems := 0
+	
 	// Calculate points by product area
 	for _, item := range items {
-		areaName := item.ProductArea
-		if areaName == "" {
-			areaName = "Uncategorized"
+		areas := item.GetProductAreas()
+		if len(areas) == 0 {
+			areas = []string{"Uncategorized"}
+		}
+		if len(areas) > 1 {
+			multiAreaItems++
 		}
 		
-		areaPoints[areaName] += item.Estimate
-		areaItems[areaName]++
-	}
-	
-	// Sort areas by points
-	type areaStat struct {
-		name      string
-		points    float64
-		itemCount int
-	}
-	
-	var stats []areaStat
-	for name, points := range areaPoints {
-		stats = append(stats, areaStat{
-			name:      name,
-			points:    points,
-			itemCount: areaItems[name],
-		})
+		// Split points between areas, or credit each area in full
+		points := r.unit.Value(item.Estimate)
+		if r.productAreaMode != ProductAreaModeDuplicate {
+			points /= float64(len(areas))
+		}
+		
+		for _, areaName := range areas {
+			areaPoints[areaName] += points
+			areaItems[areaName]++
+		}
 	}
 	
-	// Sort by points in descending order
-	sort.Slice(stats, func(i, j int) bool {
-		return stats[i].points > stats[j].points
-	})
-	
-	// Generate report string
-	report := "Story Points by Product Area:\n\n"
-	totalPoints := 0.0
-	totalItems := 0
+	result := sumTotals(groupTotals(areaPoints, areaItems))
+	result.MultiAreaItems = multiAreaItems
+	return result
+}
+
+// generateProductAreaReport creates a report of story points by product area
+func (r *Reporter) generateProductAreaReport(items []models.KanbanItem) (string, error) {
+	result := r.productAreaResult(items)
+	report := r.formatTotals("Product Area", 30, result)
 	
-	for _, stat := range stats {
-		report += fmt.Sprintf("%-30s %6.1f points  %3d items\n", 
-			stat.name, stat.points, stat.itemCount)
-		totalPoints += stat.points
-		totalItems += stat.itemCount
+	if result.MultiAreaItems > 0 {
+		report += "\n" + r.multiAreaNote(result.MultiAreaItems) + "\n"
 	}
 	
-	report += fmt.Sprintf("\nTotal: %.1f points across %d items\n", totalPoints, totalItems)
-	
 	return report, nil
-}
\ No newline at end of file
+}
+
+// multiAreaNote explains how items in several product areas are counted
+func (r *Reporter) multiAreaNote(multiAreaItems int) string {
+	if r.productAreaMode == ProductAreaModeDuplicate {
+		return fmt.Sprintf("%d items belong to several product areas and are counted in full in each, so totals include them more than once.", multiAreaItems)
+	}
+	return fmt.Sprintf("%d items belong to several product areas; their %s are split evenly between them.", multiAreaItems, r.unit.Label())
+}
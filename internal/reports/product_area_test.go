@@ -239,4 +239,30 @@ func TestGenerateProductAreaReport_SpecialCharactersInNames(t *testing.T) {
 	if !strings.Contains(report, "Data Science/Analytics") {
 		t.Errorf("Report doesn't contain product area with slash")
 	}
-}
\ No newline at end of file
+}
+func TestGenerateProductAreaReport_MultipleAreas(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Shared Task", ProductArea: "Backend;Frontend", IsCompleted: true, CompletedAt: time.Now(), Estimate: 4},
+		{ID: "2", Name: "Backend Task", ProductArea: "Backend", IsCompleted: true, CompletedAt: time.Now(), Estimate: 1},
+	}
+
+	report, err := NewReporter(items).generateProductAreaReport(items)
+	if err != nil {
+		t.Fatalf("generateProductAreaReport() error = %v", err)
+	}
+	for _, str := range []string{"Backend                           3.0 points    2 items", "Frontend                          2.0 points    1 items", "split evenly"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Split report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	report, err = NewReporter(items).WithProductAreaMode(ProductAreaModeDuplicate).generateProductAreaReport(items)
+	if err != nil {
+		t.Fatalf("generateProductAreaReport() error = %v", err)
+	}
+	for _, str := range []string{"Backend                           5.0 points    2 items", "Frontend                          4.0 points    1 items", "counted in full"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Duplicate report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+}
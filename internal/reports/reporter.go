@@ -14,6 +14,21 @@ import (
 type Reporter struct {
 	items      []models.KanbanItem
 	adHocFilter types.AdHocFilterType
+	adHocRules filtering.AdHocRules
+	hierarchy  bool
+	unit       types.EstimateUnit
+	productAreaMode ProductAreaMode
+	separator  string
+	minEpics   int
+	epic       string
+	labelPrefix string
+	groupBy    GroupField
+	pivotRows  GroupField
+	pivotCols  GroupField
+	allocateBy GroupField
+	detail     bool
+	top        int
+	format     types.OutputFormat
 }
 
 // NewReporter creates a new reporter with the given items
@@ -21,6 +36,13 @@ func NewReporter(items []models.KanbanItem) *Reporter {
 	return &Reporter{
 		items:      items,
 		adHocFilter: types.AdHocFilterInclude,
+		adHocRules: filtering.DefaultAdHocRules(),
+		unit:       types.UnitPoints,
+		productAreaMode: ProductAreaModeSplit,
+		separator:  DefaultSeparator,
+		minEpics:   DefaultMinEpics,
+		allocateBy: DefaultAllocateBy,
+		format:     types.FormatText,
 	}
 }
 
@@ -30,45 +52,254 @@ func (r *Reporter) WithAdHocFilter(filter types.AdHocFilterType) *Reporter {
 	return r
 }
 
+// WithAdHocRules sets the rules that decide which items are ad-hoc requests
+func (r *Reporter) WithAdHocRules(rules filtering.AdHocRules) *Reporter {
+	if rules.IsEmpty() {
+		rules = filtering.DefaultAdHocRules()
+	}
+	r.adHocRules = rules
+	return r
+}
+
+// WithHierarchy enables the project → epic → item breakdown section
+func (r *Reporter) WithHierarchy(enabled bool) *Reporter {
+	r.hierarchy = enabled
+	return r
+}
+
+// WithUnit sets the unit estimates are aggregated and labeled in
+func (r *Reporter) WithUnit(unit types.EstimateUnit) *Reporter {
+	if unit == "" {
+		unit = types.UnitPoints
+	}
+	r.unit = unit
+	return r
+}
+
+// WithProductAreaMode sets how items with several product areas are attributed
+func (r *Reporter) WithProductAreaMode(mode ProductAreaMode) *Reporter {
+	if mode == "" {
+		mode = ProductAreaModeSplit
+	}
+	r.productAreaMode = mode
+	return r
+}
+
+// WithSeparator sets the line placed between sections of combined reports
+func (r *Reporter) WithSeparator(separator string) *Reporter {
+	if separator == "" {
+		separator = DefaultSeparator
+	}
+	r.separator = separator
+	return r
+}
+
+// WithMinEpics sets the number of concurrent epics at which the contention
+// report lists a contributor
+func (r *Reporter) WithMinEpics(minEpics int) *Reporter {
+	if minEpics < 2 {
+		minEpics = DefaultMinEpics
+	}
+	r.minEpics = minEpics
+	return r
+}
+
+// WithEpic limits the epic-contributor report to one epic, matched without
+// regard to case. An empty name includes all epics.
+func (r *Reporter) WithEpic(epic string) *Reporter {
+	r.epic = strings.TrimSpace(epic)
+	return r
+}
+
+// WithLabelPrefix limits the label report to labels starting with the
+// prefix, such as "component:", matched without regard to case
+func (r *Reporter) WithLabelPrefix(prefix string) *Reporter {
+	r.labelPrefix = strings.TrimSpace(prefix)
+	return r
+}
+
+// WithGroupBy sets the field the group report totals by
+func (r *Reporter) WithGroupBy(field GroupField) *Reporter {
+	r.groupBy = field
+	return r
+}
+
+// WithPivot sets the fields the rows and columns of the pivot report total by
+func (r *Reporter) WithPivot(rows, cols GroupField) *Reporter {
+	r.pivotRows = rows
+	r.pivotCols = cols
+	return r
+}
+
+// WithAllocateBy sets the field the allocation report divides each owner's
+// work by, or epics when empty
+func (r *Reporter) WithAllocateBy(field GroupField) *Reporter {
+	if field == "" {
+		field = DefaultAllocateBy
+	}
+	r.allocateBy = field
+	return r
+}
+
+// WithDetail sets whether the contributor report lists each contributor's
+// completed items, keeping at most top of them when top is above 0
+func (r *Reporter) WithDetail(detail bool, top int) *Reporter {
+	r.detail = detail
+	r.top = top
+	return r
+}
+
+// WithFormat sets whether reports are returned as text, a JSON document, or
+// a Markdown or HTML document
+func (r *Reporter) WithFormat(format types.OutputFormat) *Reporter {
+	if format == "" {
+		format = types.FormatText
+	}
+	r.format = format
+	return r
+}
+
 // GenerateReport generates a report based on the specified type and time period
 func (r *Reporter) GenerateReport(reportType ReportType, startDate, endDate time.Time, filterField models.FilterField) (string, error) {
+	if r.format == types.FormatMarkdown || r.format == types.FormatHTML {
+		return r.renderDocument([]ReportType{reportType}, startDate, endDate, filterField)
+	}
+
 	// Filter items by date field
-	filteredItems := filtering.FilterItemsByDateRange(
+	filteredItems := filtering.FilterItemsByDateRangeWithRules(
 		r.items,
 		startDate, 
 		endDate, 
 		filterField, 
 		r.adHocFilter,
+		r.adHocRules,
 	)
 	
-	if len(filteredItems) == 0 {
+	if r.format == types.FormatJSON {
+		return r.generateJSON([]ReportType{reportType}, filteredItems, startDate, endDate)
+	}
+	
+	if len(filteredItems) == 0 && !includesOpenWork([]ReportType{reportType}) {
 		return "No items completed in the specified date range.", nil
 	}
 
 		// Generate appropriate report based on type
-	var reportContent string
-	var err error
+	reportContent, err := r.generateSection(reportType, filteredItems)
+	if err != nil {
+		return "", err
+	}
+
+	// Append the project → epic → item breakdown when requested
+	if r.hierarchy {
+		reportContent += "\n" + r.generateHierarchySection(filteredItems)
+	}
+
+		 // Add date range information to the report
+	reportWithDateInfo := r.addDateRangeInfo(reportContent, reportType, startDate, endDate)
+	return reportWithDateInfo, nil
+}
+
+// GenerateReports generates several report types from one filtering pass and
+// combines them into a single document with one section per report type
+func (r *Reporter) GenerateReports(reportTypes []ReportType, startDate, endDate time.Time, filterField models.FilterField) (string, error) {
+	if len(reportTypes) == 1 {
+		return r.GenerateReport(reportTypes[0], startDate, endDate, filterField)
+	}
+	if r.format == types.FormatMarkdown || r.format == types.FormatHTML {
+		return r.renderDocument(reportTypes, startDate, endDate, filterField)
+	}
+
+	filteredItems := filtering.FilterItemsByDateRangeWithRules(
+		r.items,
+		startDate,
+		endDate,
+		filterField,
+		r.adHocFilter,
+		r.adHocRules,
+	)
+
+	if r.format == types.FormatJSON {
+		return r.generateJSON(reportTypes, filteredItems, startDate, endDate)
+	}
+
+	if len(filteredItems) == 0 && !includesOpenWork(reportTypes) {
+		return "No items completed in the specified date range.", nil
+	}
+
+	var sections []string
+	var names []string
+	for _, reportType := range reportTypes {
+		section, err := r.generateSection(reportType, filteredItems)
+		if err != nil {
+			return "", err
+		}
+		sections = append(sections, section)
+		names = append(names, string(reportType))
+	}
 
+	// The breakdown doesn't depend on the report type, so it is added once at the end
+	if r.hierarchy {
+		sections = append(sections, r.generateHierarchySection(filteredItems))
+	}
+
+	reportContent := strings.Join(sections, "\n"+r.separator+"\n\n")
+	return r.addDateRangeInfo(reportContent, ReportType(strings.Join(names, ", ")), startDate, endDate), nil
+}
+
+// generateSection generates the report body for a single report type
+func (r *Reporter) generateSection(reportType ReportType, items []models.KanbanItem) (string, error) {
 	switch reportType {
 	case ReportTypeContributor:
-		reportContent, err = r.generateContributorReport(filteredItems)
+		return r.generateContributorReport(items)
 	case ReportTypeEpic:
-		reportContent, err = r.generateEpicReport(filteredItems)
+		return r.generateEpicReport(items)
 	case ReportTypeProductArea:
-		reportContent, err = r.generateProductAreaReport(filteredItems)
+		return r.generateProductAreaReport(items)
 	case ReportTypeTeam:
-		reportContent, err = r.generateTeamReport(filteredItems)
+		return r.generateTeamReport(items)
+	case ReportTypeCategory:
+		return r.generateCategoryReport(items)
+	case ReportTypeWorkload:
+		// Open work has no completion date, so it is taken from all items
+		return r.generateWorkloadReport(r.openItems(), time.Now())
+	case ReportTypeContention:
+		return r.generateContentionReport(r.withOpenItems(items), r.minEpics, time.Now())
+	case ReportTypeEpicContributor:
+		return r.generateEpicContributorReport(items)
+	case ReportTypeTeamMonth:
+		return r.generateTeamMonthReport(items)
+	case ReportTypeLabel:
+		return r.generateLabelReport(items)
+	case ReportTypeMilestone:
+		return r.generateMilestoneReport(items)
+	case ReportTypeIteration:
+		return r.generateIterationReport(items)
+	case ReportTypeState:
+		// Open work has no completion date, so it is taken from all items
+		return r.generateStateReport(r.openItems(), items, time.Now())
+	case ReportTypeGroup:
+		return r.generateGroupReport(items)
+	case ReportTypePivot:
+		return r.generatePivotReport(items)
+	case ReportTypeEpicProgress:
+		// An epic's progress covers all of its work, so it is taken from all items
+		return r.generateEpicProgressReport(r.scopedItems(), time.Now())
+	case ReportTypeAllocation:
+		return r.generateAllocationReport(items)
 	default:
 		return "", fmt.Errorf("unknown report type: %s", reportType)
 	}
+}
 
-		if err != nil {
-		return "", err
+// includesOpenWork reports whether a report of open work, which has no
+// completion date to filter by, is among the report types
+func includesOpenWork(reportTypes []ReportType) bool {
+	for _, reportType := range reportTypes {
+		if reportType == ReportTypeWorkload || reportType == ReportTypeState || reportType == ReportTypeEpicProgress {
+			return true
+		}
 	}
-
-		 // Add date range information to the report
-	reportWithDateInfo := r.addDateRangeInfo(reportContent, reportType, startDate, endDate)
-	return reportWithDateInfo, nil
+	return false
 }
 
 // addDateRangeInfo adds date range information to the beginning of the report
@@ -107,6 +338,23 @@ func (r *Reporter) addDateRangeInfo(report string, reportType ReportType, startD
 	return header + report
 }
 
+// formatAmount formats an aggregated estimate followed by the item count.
+// When counting items the amount already is the item count, so it is shown once.
+func (r *Reporter) formatAmount(amount float64, itemCount int) string {
+	if r.unit.CountsItems() {
+		return fmt.Sprintf("%6.1f items", amount)
+	}
+	return fmt.Sprintf("%6.1f %s  %3d items", amount, r.unit.Label(), itemCount)
+}
+
+// formatTotal formats the closing total line of a report
+func (r *Reporter) formatTotal(amount float64, itemCount int) string {
+	if r.unit.CountsItems() {
+		return fmt.Sprintf("Total: %d items\n", itemCount)
+	}
+	return fmt.Sprintf("Total: %.1f %s across %d items\n", amount, r.unit.Label(), itemCount)
+}
+
 // filterItemsByDateRange returns items filtered by date range on the specified field
 func (r *Reporter) filterItemsByDateRange(startDate, endDate time.Time, filterField models.FilterField) []models.KanbanItem {
 	var filtered []models.KanbanItem
@@ -145,12 +393,7 @@ func (r *Reporter) filterItemsByDateRange(startDate, endDate time.Time, filterFi
 	return filtered
 }
 
-// isAdHocRequest checks if an item is an ad-hoc request (has "ad-hoc-request" label)
+// isAdHocRequest checks if an item is an ad-hoc request under the configured rules
 func (r *Reporter) isAdHocRequest(item models.KanbanItem) bool {
-	for _, label := range item.Labels {
-		if strings.ToLower(label) == "ad-hoc-request" {
-			return true
-		}
-	}
-	return false
+	return r.adHocRules.Matches(item)
 }
\ No newline at end of file
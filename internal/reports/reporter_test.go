@@ -259,6 +259,55 @@ func TestGenerateReport(t *testing.T) {
 	}
 }
 
+func TestGenerateReports(t *testing.T) {
+	now := time.Now()
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Owners: []string{"john@example.com"}, IsCompleted: true, CompletedAt: now, Estimate: 3, Epic: "Epic 1", Team: "Team A"},
+		{ID: "2", Name: "Task 2", Owners: []string{"jane@example.com"}, IsCompleted: true, CompletedAt: now, Estimate: 2, Epic: "Epic 2", Team: "Team A"},
+	}
+
+	reporter := NewReporter(items).WithHierarchy(true)
+	report, err := reporter.GenerateReports([]ReportType{ReportTypeContributor, ReportTypeEpic, ReportTypeTeam}, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReports() error = %v", err)
+	}
+
+	expected := []string{
+		"Report Type: contributor, epic, team",
+		"Story Points by Contributor",
+		"Story Points by Epic",
+		"Story Points by Team",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %s", str)
+		}
+	}
+
+	// Sections appear in the requested order, with the hierarchy once at the end
+	contributorIdx := strings.Index(report, "Story Points by Contributor")
+	epicIdx := strings.Index(report, "Story Points by Epic")
+	teamIdx := strings.Index(report, "Story Points by Team")
+	if !(contributorIdx < epicIdx && epicIdx < teamIdx) {
+		t.Errorf("Sections are not in the requested order")
+	}
+	if count := strings.Count(report, strings.Repeat("=", 80)); count != 3 {
+		t.Errorf("Expected 3 section separators, got %d", count)
+	}
+
+	custom, err := NewReporter(items).WithSeparator("-- next --").GenerateReports([]ReportType{ReportTypeEpic, ReportTypeTeam}, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReports() error = %v", err)
+	}
+	if !strings.Contains(custom, "\n-- next --\n\nStory Points by Team") || strings.Contains(custom, strings.Repeat("=", 80)) {
+		t.Errorf("Custom separator not used between sections:\n%s", custom)
+	}
+
+	if _, err := reporter.GenerateReports([]ReportType{ReportTypeTeam, "invalid-type"}, time.Time{}, time.Time{}, models.FilterFieldCompletedAt); err == nil {
+		t.Errorf("GenerateReports() with invalid type should return error")
+	}
+}
+
 func TestAddDateRangeInfo(t *testing.T) {
 	reporter := NewReporter(nil)
 	
@@ -419,4 +468,33 @@ func TestGenerateReportWithDateRange(t *testing.T) {
 	if strings.Contains(report, "jane@example.com") {
 		t.Errorf("Report includes items outside the date range")
 	}
-}
\ No newline at end of file
+}
+func TestGenerateReportWithUnit(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Task 1", Team: "Team A", IsCompleted: true, CompletedAt: time.Now(), Estimate: 4},
+		{ID: "2", Name: "Task 2", Team: "Team A", IsCompleted: true, CompletedAt: time.Now(), Estimate: 6},
+	}
+
+	report, err := NewReporter(items).WithUnit(types.UnitHours).GenerateReport(ReportTypeTeam, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	for _, str := range []string{"Hours by Team:", "10.0 hours", "Total: 10.0 hours across 2 items"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Hours report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	report, err = NewReporter(items).WithUnit(types.UnitItems).GenerateReport(ReportTypeTeam, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	for _, str := range []string{"Items by Team:", "2.0 items", "Total: 2 items"} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Items report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+	if strings.Contains(report, "points") {
+		t.Errorf("Items report should not mention points")
+	}
+}
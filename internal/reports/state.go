@@ -0,0 +1,76 @@
+package reports
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// StateResult is a snapshot of the board from the export: the open items in
+// each workflow state now, and the states completed items ended in
+type StateResult struct {
+	AsOf      string       `json:"as_of"`
+	Open      TotalsResult `json:"open"`      // Regardless of the date range
+	Completed TotalsResult `json:"completed"` // Within the date range
+}
+
+// stateResult totals the open and the completed items by workflow state
+func (r *Reporter) stateResult(open, completed []models.KanbanItem, asOf time.Time) StateResult {
+	return StateResult{
+		AsOf:      asOf.Format("2006-01-02"),
+		Open:      r.stateTotals(open, false),
+		Completed: r.stateTotals(completed, true),
+	}
+}
+
+// stateTotals totals the items of one side of the board by their state
+func (r *Reporter) stateTotals(items []models.KanbanItem, completed bool) TotalsResult {
+	statePoints := make(map[string]float64)
+	stateItems := make(map[string]int)
+
+	for _, item := range items {
+		if item.IsCompleted != completed {
+			continue
+		}
+		state := item.State
+		if state == "" {
+			state = "No State"
+		}
+		statePoints[state] += r.unit.Value(item.Estimate)
+		stateItems[state]++
+	}
+
+	return sumTotals(groupTotals(statePoints, stateItems))
+}
+
+// generateStateReport creates a board summary of the open items by workflow
+// state as of now, and of the completed items by the state they ended in
+func (r *Reporter) generateStateReport(open, completed []models.KanbanItem, asOf time.Time) (string, error) {
+	result := r.stateResult(open, completed, asOf)
+
+	report := fmt.Sprintf("Open Work by State (as of %s, regardless of date range):\n\n", result.AsOf)
+	if len(result.Open.Groups) == 0 {
+		report += "No open items.\n"
+	} else {
+		report += r.formatStateTotals(result.Open)
+	}
+
+	report += "\n" + r.unit.Title() + " by State at Completion:\n\n"
+	if len(result.Completed.Groups) == 0 {
+		report += "No items completed in the specified date range.\n"
+	} else {
+		report += r.formatStateTotals(result.Completed)
+	}
+
+	return report, nil
+}
+
+// formatStateTotals formats the states of one side of the board and their total
+func (r *Reporter) formatStateTotals(totals TotalsResult) string {
+	var report string
+	for _, group := range totals.Groups {
+		report += fmt.Sprintf("%-30s %s\n", group.Name, r.formatAmount(group.Amount, group.Items))
+	}
+	return report + "\n" + r.formatTotal(totals.Amount, totals.Items)
+}
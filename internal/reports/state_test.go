@@ -0,0 +1,57 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+func TestStateResult(t *testing.T) {
+	asOf := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{ID: "1", State: "In Progress", Estimate: 3},
+		{ID: "2", State: "In Progress", Estimate: 2},
+		{ID: "3", State: "Ready", Estimate: 1},
+		{ID: "4", Estimate: 6},
+		{ID: "5", State: "Done", Estimate: 8, IsCompleted: true, CompletedAt: asOf},
+		{ID: "6", State: "Won't Do", Estimate: 1, IsCompleted: true, CompletedAt: asOf},
+	}
+
+	result := NewReporter(items).stateResult(items, items, asOf)
+
+	if result.AsOf != "2024-05-20" {
+		t.Errorf("AsOf = %q, want 2024-05-20", result.AsOf)
+	}
+	if result.Open.Amount != 12 || result.Open.Items != 4 || len(result.Open.Groups) != 3 {
+		t.Errorf("Open = %+v, want 12 points over 4 items in 3 states", result.Open)
+	}
+	if first := result.Open.Groups[0]; first.Name != "No State" || first.Amount != 6 {
+		t.Errorf("largest open state = %+v, want No State with 6 points", first)
+	}
+	if result.Completed.Amount != 9 || result.Completed.Items != 2 || len(result.Completed.Groups) != 2 {
+		t.Errorf("Completed = %+v, want 9 points over 2 items in 2 states", result.Completed)
+	}
+}
+
+func TestGenerateReport_StateIgnoresDateRangeForOpenWork(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Open", State: "In Review", Estimate: 3, CreatedAt: time.Now().AddDate(-1, 0, 0)},
+		{ID: "2", Name: "Old", State: "Done", Estimate: 2, IsCompleted: true, CompletedAt: time.Now().AddDate(-1, 0, 0)},
+	}
+
+	report, err := NewReporter(items).GenerateReport(ReportTypeState, time.Now().AddDate(0, 0, -7), time.Now(), models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+
+	for _, want := range []string{"Open Work by State", "In Review", "No items completed in the specified date range."} {
+		if !strings.Contains(report, want) {
+			t.Errorf("GenerateReport() missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "Done") {
+		t.Errorf("GenerateReport() should leave out items completed before the date range:\n%s", report)
+	}
+}
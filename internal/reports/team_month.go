@@ -0,0 +1,169 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+)
+
+// TeamMonths is the work a team completed in each month of the heatmap
+type TeamMonths struct {
+	Name    string    `json:"name"`
+	Amounts []float64 `json:"amounts"` // One per month
+	Amount  float64   `json:"total_amount"`
+	Items   int       `json:"total_items"`
+}
+
+// TeamMonthResult holds the work completed by each team in each month, from
+// the first to the last month with completed work
+type TeamMonthResult struct {
+	Months      []string     `json:"months"` // As YYYY-MM
+	Teams       []TeamMonths `json:"teams"`
+	MonthTotals []float64    `json:"month_totals"`
+	Amount      float64      `json:"total_amount"`
+	Items       int          `json:"total_items"`
+}
+
+// teamMonthResult totals the completed work of each team by month of completion
+func (r *Reporter) teamMonthResult(items []models.KanbanItem) TeamMonthResult {
+	result := TeamMonthResult{Months: []string{}, Teams: []TeamMonths{}, MonthTotals: []float64{}}
+
+	var first, last time.Time
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		month := time.Date(item.CompletedAt.Year(), item.CompletedAt.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if first.IsZero() || month.Before(first) {
+			first = month
+		}
+		if month.After(last) {
+			last = month
+		}
+	}
+	if first.IsZero() {
+		return result
+	}
+
+	// Months without completions keep their column, so gaps stand out
+	column := make(map[string]int)
+	for month := first; !month.After(last); month = month.AddDate(0, 1, 0) {
+		column[month.Format("2006-01")] = len(result.Months)
+		result.Months = append(result.Months, month.Format("2006-01"))
+	}
+	result.MonthTotals = make([]float64, len(result.Months))
+
+	teams := make(map[string]*TeamMonths)
+	for _, item := range items {
+		if !item.IsCompleted || item.CompletedAt.IsZero() {
+			continue
+		}
+		teamName := item.Team
+		if teamName == "" {
+			teamName = "No Team"
+		}
+		team, exists := teams[teamName]
+		if !exists {
+			team = &TeamMonths{Name: teamName, Amounts: make([]float64, len(result.Months))}
+			teams[teamName] = team
+		}
+
+		value := r.unit.Value(item.Estimate)
+		i := column[item.CompletedAt.Format("2006-01")]
+		team.Amounts[i] += value
+		team.Amount += value
+		team.Items++
+		result.MonthTotals[i] += value
+		result.Amount += value
+		result.Items++
+	}
+
+	for _, team := range teams {
+		result.Teams = append(result.Teams, *team)
+	}
+	// Sort teams by amount in descending order, then by name
+	sort.Slice(result.Teams, func(i, j int) bool {
+		if result.Teams[i].Amount != result.Teams[j].Amount {
+			return result.Teams[i].Amount > result.Teams[j].Amount
+		}
+		return result.Teams[i].Name < result.Teams[j].Name
+	})
+
+	return result
+}
+
+// teamMonthTable lays out the result as a matrix with a row per team, a
+// column per month, and totals in the last row and column
+func (r *Reporter) teamMonthTable(result TeamMonthResult) render.Table {
+	amount := r.cellAmount
+	table := render.Table{Headers: append(append([]string{"Team"}, result.Months...), "Total"), Heatmap: true}
+	for _, team := range result.Teams {
+		row := []string{team.Name}
+		for _, value := range team.Amounts {
+			row = append(row, amount(value))
+		}
+		table.Rows = append(table.Rows, append(row, amount(team.Amount)))
+	}
+	totals := []string{"Total"}
+	for _, value := range result.MonthTotals {
+		totals = append(totals, amount(value))
+	}
+	table.Rows = append(table.Rows, append(totals, amount(result.Amount)))
+
+	return table
+}
+
+// generateTeamMonthReport creates a heatmap of the work each team completed by month
+func (r *Reporter) generateTeamMonthReport(items []models.KanbanItem) (string, error) {
+	result := r.teamMonthResult(items)
+	title := r.unit.Title() + " by Team and Month:\n\n"
+	if len(result.Months) == 0 {
+		return title + "No completed items.\n", nil
+	}
+
+	return title + formatMatrix(r.teamMonthTable(result)), nil
+}
+
+// cellAmount formats the amount of a matrix cell. Empty cells are left blank
+// so the busy cells stand out.
+func (r *Reporter) cellAmount(value float64) string {
+	if value == 0 {
+		return ""
+	}
+	if r.unit.CountsItems() {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.1f", value)
+}
+
+// formatMatrix formats a table as text with names left-aligned and amounts
+// right-aligned, each column as wide as its widest cell
+func formatMatrix(table render.Table) string {
+	widths := make([]int, len(table.Headers))
+	for _, row := range append([][]string{table.Headers}, table.Rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+	formatRow := func(row []string) string {
+		cells := []string{fmt.Sprintf("%-*s", widths[0], row[0])}
+		for i := 1; i < len(row); i++ {
+			cells = append(cells, fmt.Sprintf("%*s", widths[i], row[i]))
+		}
+		return strings.Join(cells, " | ") + "\n"
+	}
+	dashes := make([]string, len(widths))
+	for i, width := range widths {
+		dashes[i] = strings.Repeat("-", width)
+	}
+
+	report := formatRow(table.Headers) + strings.Join(dashes, "-|-") + "\n"
+	for _, row := range table.Rows {
+		report += formatRow(row)
+	}
+	return report
+}
@@ -0,0 +1,85 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// teamMonthItems are completions in January and March, none in February
+func teamMonthItems() []models.KanbanItem {
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC) }
+	return []models.KanbanItem{
+		{ID: "1", Team: "Platform", Estimate: 5, IsCompleted: true, CompletedAt: day(time.January, 10)},
+		{ID: "2", Team: "Platform", Estimate: 3, IsCompleted: true, CompletedAt: day(time.March, 2)},
+		{ID: "3", Team: "Mobile", Estimate: 2, IsCompleted: true, CompletedAt: day(time.January, 31)},
+		{ID: "4", Estimate: 1, IsCompleted: true, CompletedAt: day(time.March, 20)},
+		{ID: "5", Team: "Mobile", Estimate: 8}, // Not completed
+	}
+}
+
+func TestTeamMonthResult(t *testing.T) {
+	result := NewReporter(nil).teamMonthResult(teamMonthItems())
+
+	if strings.Join(result.Months, ",") != "2024-01,2024-02,2024-03" {
+		t.Fatalf("months = %v, want 2024-01 through 2024-03", result.Months)
+	}
+	if len(result.Teams) != 3 || result.Teams[0].Name != "Platform" || result.Teams[2].Name != "No Team" {
+		t.Fatalf("teams = %+v, want Platform, Mobile, No Team", result.Teams)
+	}
+
+	platform := result.Teams[0]
+	if platform.Amounts[0] != 5 || platform.Amounts[1] != 0 || platform.Amounts[2] != 3 || platform.Amount != 8 || platform.Items != 2 {
+		t.Errorf("Platform = %+v, want 5, 0, 3 for 8 points across 2 items", platform)
+	}
+	if result.MonthTotals[0] != 7 || result.MonthTotals[2] != 4 || result.Amount != 11 || result.Items != 4 {
+		t.Errorf("totals = %v / %.1f / %d, want 7, 0, 4 for 11 points across 4 items", result.MonthTotals, result.Amount, result.Items)
+	}
+}
+
+func TestGenerateTeamMonthReport(t *testing.T) {
+	reporter := NewReporter(nil)
+	report, err := reporter.generateTeamMonthReport(teamMonthItems())
+	if err != nil {
+		t.Fatalf("generateTeamMonthReport() error = %v", err)
+	}
+
+	for _, str := range []string{
+		"Story Points by Team and Month:",
+		"Team     | 2024-01 | 2024-02 | 2024-03 | Total",
+		"Platform |     5.0 |         |     3.0 |   8.0",
+		"Total    |     7.0 |         |     4.0 |  11.0",
+	} {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+
+	reporter.WithUnit(types.UnitItems)
+	report, err = reporter.generateTeamMonthReport(teamMonthItems())
+	if err != nil {
+		t.Fatalf("generateTeamMonthReport() error = %v", err)
+	}
+	if !strings.Contains(report, "Platform |       1 |         |       1 |     2") {
+		t.Errorf("Items report should count items\nGot:\n%s", report)
+	}
+}
+
+func TestTeamMonthDocument(t *testing.T) {
+	reporter := NewReporter(teamMonthItems()).WithFormat(types.FormatHTML)
+	output, err := reporter.GenerateReport(ReportTypeTeamMonth, time.Time{}, time.Time{}, models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+
+	// The busiest cell is the darkest; totals are not shaded
+	if !strings.Contains(output, `<td class="num heat" style="background: rgba(9, 105, 218, 0.50)">5.0</td>`) {
+		t.Errorf("HTML should shade the busiest cell the darkest\nGot:\n%s", output)
+	}
+	if !strings.Contains(output, `<td class="num">11.0</td>`) {
+		t.Errorf("HTML should leave the total unshaded\nGot:\n%s", output)
+	}
+}
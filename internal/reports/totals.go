@@ -0,0 +1,76 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// GroupTotal is the estimate and item count credited to one contributor, epic, team or other group
+type GroupTotal struct {
+	Name    string       `json:"name"`
+	Amount  float64      `json:"amount"`
+	Items   int          `json:"items"`
+	Share   *float64     `json:"share_percent,omitempty"` // Only set by reports that show each group's share
+	Details []ItemDetail `json:"details,omitempty"`       // Only set by the contributor report with --detail
+}
+
+// ItemDetail is a completed item that makes up part of a group's total
+type ItemDetail struct {
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	Amount        float64        `json:"amount"` // The share credited to the group
+	Epic          string         `json:"epic,omitempty"`
+	CycleTimeDays *float64       `json:"cycle_time_days,omitempty"` // Only set for items with a start date
+	Source        *models.Source `json:"source,omitempty"`          // Only set for items read from a CSV file
+}
+
+// TotalsResult holds the groups of a report sorted by amount, and the report total
+type TotalsResult struct {
+	Groups          []GroupTotal `json:"groups"`
+	Amount          float64      `json:"total_amount"`
+	Items           int          `json:"total_items"`
+	MultiAreaItems  int          `json:"multi_area_items,omitempty"`  // Items in several product areas
+	MultiLabelItems int          `json:"multi_label_items,omitempty"` // Items with several labels
+	MultiValueItems int          `json:"multi_value_items,omitempty"` // Items with several values of the group-by field
+}
+
+// groupTotals sorts the groups by amount in descending order, then by name
+func groupTotals(amounts map[string]float64, counts map[string]int) []GroupTotal {
+	groups := []GroupTotal{}
+	for name, amount := range amounts {
+		groups = append(groups, GroupTotal{Name: name, Amount: amount, Items: counts[name]})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Amount != groups[j].Amount {
+			return groups[i].Amount > groups[j].Amount
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// sumTotals returns a result with the total amount and item count of the groups
+func sumTotals(groups []GroupTotal) TotalsResult {
+	result := TotalsResult{Groups: groups}
+	for _, group := range groups {
+		result.Amount += group.Amount
+		result.Items += group.Items
+	}
+	return result
+}
+
+// formatTotals formats one line per group, padding names to nameWidth, and the total line
+func (r *Reporter) formatTotals(title string, nameWidth int, result TotalsResult) string {
+	report := r.unit.Title() + " by " + title + ":\n\n"
+	for _, group := range result.Groups {
+		report += fmt.Sprintf("%-*s %s", nameWidth, group.Name, r.formatAmount(group.Amount, group.Items))
+		if group.Share != nil {
+			report += fmt.Sprintf("  %5.1f%%", *group.Share)
+		}
+		report += "\n"
+	}
+	report += "\n" + r.formatTotal(result.Amount, result.Items)
+	return report
+}
@@ -2,8 +2,12 @@ package reports
 
 import (
 	"fmt"
+	"strings"
 )
 
+// DefaultSeparator is the line placed between sections of combined reports
+var DefaultSeparator = strings.Repeat("=", 80)
+
 // ReportType defines the type of report to generate
 type ReportType string
 
@@ -16,12 +20,38 @@ const (
 	ReportTypeProductArea ReportType = "product-area"
 	// ReportTypeTeam generates report by team
 	ReportTypeTeam ReportType = "team"
+	// ReportTypeCategory generates report by category from classification rules
+	ReportTypeCategory ReportType = "category"
+	// ReportTypeWorkload generates report of open items by owner
+	ReportTypeWorkload ReportType = "workload"
+	// ReportTypeContention generates report of contributors spread across epics
+	ReportTypeContention ReportType = "contention"
+	// ReportTypeEpicContributor generates report of contributors within each epic
+	ReportTypeEpicContributor ReportType = "epic-contributor"
+	// ReportTypeTeamMonth generates a heatmap of team throughput by month
+	ReportTypeTeamMonth ReportType = "team-month"
+	// ReportTypeLabel generates report by label
+	ReportTypeLabel ReportType = "label"
+	// ReportTypeMilestone generates report by milestone with due dates
+	ReportTypeMilestone ReportType = "milestone"
+	// ReportTypeIteration generates report by iteration
+	ReportTypeIteration ReportType = "iteration"
+	// ReportTypeState generates a board summary by workflow state
+	ReportTypeState ReportType = "state"
+	// ReportTypeGroup generates report by any column or custom field
+	ReportTypeGroup ReportType = "group"
+	// ReportTypePivot generates cross-tab report by two columns or custom fields
+	ReportTypePivot ReportType = "pivot"
+	// ReportTypeEpicProgress generates report of each epic's progress and projected finish
+	ReportTypeEpicProgress ReportType = "epic-progress"
+	// ReportTypeAllocation generates report of each owner's share of completed work by epic or another field per month
+	ReportTypeAllocation ReportType = "allocation"
 )
 
 // Validation function for ReportType
 func (rt ReportType) IsValid() bool {
 	switch rt {
-	case ReportTypeContributor, ReportTypeEpic, ReportTypeProductArea, ReportTypeTeam:
+	case ReportTypeContributor, ReportTypeEpic, ReportTypeProductArea, ReportTypeTeam, ReportTypeCategory, ReportTypeWorkload, ReportTypeContention, ReportTypeEpicContributor, ReportTypeTeamMonth, ReportTypeLabel, ReportTypeMilestone, ReportTypeIteration, ReportTypeState, ReportTypeGroup, ReportTypePivot, ReportTypeEpicProgress, ReportTypeAllocation:
 		return true
 	}
 	return false
@@ -35,3 +65,57 @@ func ParseReportType(s string) (ReportType, error) {
 	}
 	return rt, nil
 }
+
+// ParseReportTypes parses a comma-separated list of report types, ignoring duplicates
+func ParseReportTypes(s string) ([]ReportType, error) {
+	var result []ReportType
+	seen := make(map[ReportType]bool)
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		rt, err := ParseReportType(part)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[rt] {
+			seen[rt] = true
+			result = append(result, rt)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("invalid report type: %s", s)
+	}
+	return result, nil
+}
+
+// ProductAreaMode defines how items with several product areas are attributed
+type ProductAreaMode string
+
+const (
+	// ProductAreaModeSplit divides an item's estimate evenly between its product areas
+	ProductAreaModeSplit ProductAreaMode = "split"
+	// ProductAreaModeDuplicate credits the full estimate to each of an item's product areas
+	ProductAreaModeDuplicate ProductAreaMode = "duplicate"
+)
+
+// IsValid checks if a ProductAreaMode is valid
+func (m ProductAreaMode) IsValid() bool {
+	switch m {
+	case ProductAreaModeSplit, ProductAreaModeDuplicate:
+		return true
+	}
+	return false
+}
+
+// ParseProductAreaMode converts a string to a ProductAreaMode with validation
+func ParseProductAreaMode(s string) (ProductAreaMode, error) {
+	m := ProductAreaMode(s)
+	if !m.IsValid() {
+		return "", fmt.Errorf("invalid product area mode: %s (must be one of: split, duplicate)", s)
+	}
+	return m, nil
+}
@@ -14,6 +14,8 @@ func TestReportType_IsValid(t *testing.T) {
 		{"Valid epic", ReportTypeEpic, true},
 		{"Valid product-area", ReportTypeProductArea, true},
 		{"Valid team", ReportTypeTeam, true},
+		{"Valid epic-contributor", ReportTypeEpicContributor, true},
+		{"Valid team-month", ReportTypeTeamMonth, true},
 		{"Invalid type", ReportType("invalid"), false},
 		{"Empty type", ReportType(""), false},
 		{"Case sensitive - wrong case", ReportType("Contributor"), false},
@@ -59,6 +61,39 @@ func TestParseReportType(t *testing.T) {
 	}
 }
 
+func TestParseReportTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  []ReportType
+		expectErr bool
+	}{
+		{"Single type", "team", []ReportType{ReportTypeTeam}, false},
+		{"Several types in order", "contributor, epic,team", []ReportType{ReportTypeContributor, ReportTypeEpic, ReportTypeTeam}, false},
+		{"Duplicates ignored", "epic,team,epic", []ReportType{ReportTypeEpic, ReportTypeTeam}, false},
+		{"Invalid type in list", "epic,invalid", nil, true},
+		{"Empty string", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseReportTypes(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ParseReportTypes() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("ParseReportTypes() = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("ParseReportTypes()[%d] = %v, want %v", i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
 func TestReportTypeConstants(t *testing.T) {
 	// Test that the constants have the expected string values
 	tests := []struct {
@@ -79,4 +114,14 @@ func TestReportTypeConstants(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestParseProductAreaMode(t *testing.T) {
+	for _, s := range []string{"split", "duplicate"} {
+		if _, err := ParseProductAreaMode(s); err != nil {
+			t.Errorf("ParseProductAreaMode(%q) error = %v", s, err)
+		}
+	}
+	if _, err := ParseProductAreaMode("share"); err == nil {
+		t.Errorf("ParseProductAreaMode(\"share\") should fail")
+	}
+}
@@ -0,0 +1,170 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// OverloadFactor is how many times the median open item count an owner must
+// carry to be flagged as overloaded
+const OverloadFactor = 2.0
+
+// openItems returns the items still in progress, honoring the ad-hoc request filter
+func (r *Reporter) openItems() []models.KanbanItem {
+	var open []models.KanbanItem
+	for _, item := range r.items {
+		if !item.IsCompleted && r.passesAdHocFilter(item) {
+			open = append(open, item)
+		}
+	}
+	return open
+}
+
+// passesAdHocFilter reports whether the ad-hoc filter keeps the item
+func (r *Reporter) passesAdHocFilter(item models.KanbanItem) bool {
+	isAdHoc := r.isAdHocRequest(item)
+	return !(r.adHocFilter == types.AdHocFilterExclude && isAdHoc) && !(r.adHocFilter == types.AdHocFilterOnly && !isAdHoc)
+}
+
+// OwnerLoad is the open work carried by one owner
+type OwnerLoad struct {
+	Name       string  `json:"name"`
+	Amount     float64 `json:"amount"`
+	Items      int     `json:"items"`
+	Blocked    int     `json:"blocked"`
+	OldestAge  float64 `json:"oldest_age_days"`
+	OldestName string  `json:"oldest_item"`
+	Overloaded bool    `json:"overloaded"`
+}
+
+// WorkloadResult holds the open work by owner, sorted by open items
+type WorkloadResult struct {
+	AsOf        string      `json:"as_of"`
+	Owners      []OwnerLoad `json:"owners"`
+	Amount      float64     `json:"total_amount"`
+	Items       int         `json:"total_items"`
+	MedianItems float64     `json:"median_items"`
+}
+
+// workloadResult totals the open items by owner with their estimate and
+// oldest age, flagging owners carrying far more than the median
+func (r *Reporter) workloadResult(items []models.KanbanItem, asOf time.Time) WorkloadResult {
+	loads := make(map[string]*OwnerLoad)
+	result := WorkloadResult{AsOf: asOf.Format("2006-01-02"), Owners: []OwnerLoad{}}
+	for _, item := range items {
+		if item.IsCompleted {
+			continue
+		}
+		result.Items++
+
+		owners := item.Owners
+		if len(owners) == 0 {
+			owners = []string{"Unassigned"}
+		}
+
+		start := item.CreatedAt
+		if !item.StartedAt.IsZero() {
+			start = item.StartedAt
+		}
+		age := 0.0
+		if !start.IsZero() {
+			age = asOf.Sub(start).Hours() / 24
+		}
+
+		// Distribute points equally among owners, like the contributor report
+		pointsPerOwner := r.unit.Value(item.Estimate) / float64(len(owners))
+		for _, owner := range owners {
+			load, exists := loads[owner]
+			if !exists {
+				load = &OwnerLoad{Name: owner}
+				loads[owner] = load
+			}
+			load.Amount += pointsPerOwner
+			load.Items++
+			if item.IsBlocked {
+				load.Blocked++
+			}
+			if age > load.OldestAge || load.OldestName == "" {
+				load.OldestAge = age
+				load.OldestName = item.Name
+			}
+		}
+	}
+
+	var counts []int
+	for _, load := range loads {
+		result.Owners = append(result.Owners, *load)
+		if load.Name != "Unassigned" {
+			counts = append(counts, load.Items)
+		}
+	}
+
+	// Sort by open items, then points, in descending order
+	stats := result.Owners
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Items != stats[j].Items {
+			return stats[i].Items > stats[j].Items
+		}
+		if stats[i].Amount != stats[j].Amount {
+			return stats[i].Amount > stats[j].Amount
+		}
+		return stats[i].Name < stats[j].Name
+	})
+
+	sort.Ints(counts)
+	if len(counts) > 0 {
+		result.MedianItems = float64(counts[len(counts)/2])
+		if len(counts)%2 == 0 {
+			result.MedianItems = float64(counts[len(counts)/2-1]+counts[len(counts)/2]) / 2
+		}
+	}
+
+	for i, stat := range stats {
+		if stat.Name != "Unassigned" && len(counts) > 1 && float64(stat.Items) >= OverloadFactor*result.MedianItems {
+			stats[i].Overloaded = true
+		}
+		result.Amount += stat.Amount
+	}
+
+	return result
+}
+
+// generateWorkloadReport creates a report of open items by owner with their
+// estimate and oldest age, flagging owners carrying far more than the median
+func (r *Reporter) generateWorkloadReport(items []models.KanbanItem, asOf time.Time) (string, error) {
+	result := r.workloadResult(items, asOf)
+
+	report := fmt.Sprintf("Open Work by Owner (as of %s, regardless of date range):\n\n", result.AsOf)
+	if len(result.Owners) == 0 {
+		return report + "No open items.\n", nil
+	}
+
+	var overloaded []string
+	for _, stat := range result.Owners {
+		marker := ""
+		if stat.Overloaded {
+			marker = "  ⚠️"
+			overloaded = append(overloaded, stat.Name)
+		}
+		blocked := ""
+		if stat.Blocked > 0 {
+			blocked = fmt.Sprintf(", %d blocked", stat.Blocked)
+		}
+		report += fmt.Sprintf("%-30s %s  oldest %5.1f days (%s%s)%s\n",
+			stat.Name, r.formatAmount(stat.Amount, stat.Items), stat.OldestAge, stat.OldestName, blocked, marker)
+	}
+
+	report += "\n" + r.formatTotal(result.Amount, result.Items)
+
+	if len(overloaded) > 0 {
+		report += fmt.Sprintf("\n⚠️  Carrying at least %.0f× the median of %.1f open items: %s\n",
+			OverloadFactor, result.MedianItems, strings.Join(overloaded, ", "))
+	}
+
+	return report, nil
+}
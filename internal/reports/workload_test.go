@@ -0,0 +1,67 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestGenerateWorkloadReport(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return asOf.AddDate(0, 0, -days)
+	}
+
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Login", Owners: []string{"ana"}, Estimate: 3, CreatedAt: daysAgo(20), StartedAt: daysAgo(12)},
+		{ID: "2", Name: "Search", Owners: []string{"ana"}, Estimate: 2, CreatedAt: daysAgo(5), IsBlocked: true},
+		{ID: "3", Name: "Export", Owners: []string{"ana", "ben"}, Estimate: 4, CreatedAt: daysAgo(3)},
+		{ID: "4", Name: "Billing", Owners: []string{"ana"}, Estimate: 1, CreatedAt: daysAgo(2)},
+		{ID: "5", Name: "Docs", Owners: []string{"cy"}, Estimate: 1, CreatedAt: daysAgo(1)},
+		{ID: "6", Name: "Triage", Estimate: 2, CreatedAt: daysAgo(9)},
+		{ID: "7", Name: "Shipped", Owners: []string{"ben"}, Estimate: 8, IsCompleted: true, CompletedAt: daysAgo(1)},
+	}
+
+	reporter := NewReporter(items)
+	report, err := reporter.generateWorkloadReport(items, asOf)
+	if err != nil {
+		t.Fatalf("generateWorkloadReport() error = %v", err)
+	}
+
+	expected := []string{
+		"Open Work by Owner (as of 2024-06-30, regardless of date range):",
+		"ana                               8.0 points    4 items  oldest  12.0 days (Login, 1 blocked)  ⚠️",
+		"ben                               2.0 points    1 items  oldest   3.0 days (Export)",
+		"Unassigned                        2.0 points    1 items  oldest   9.0 days (Triage)",
+		"Total: 13.0 points across 6 items",
+		"Carrying at least 2× the median of 1.0 open items: ana",
+	}
+	for _, str := range expected {
+		if !strings.Contains(report, str) {
+			t.Errorf("Report doesn't contain expected string: %q\nGot:\n%s", str, report)
+		}
+	}
+	if strings.Contains(report, "Shipped") {
+		t.Errorf("Report includes a completed item:\n%s", report)
+	}
+}
+
+func TestGenerateReport_WorkloadIgnoresDateRange(t *testing.T) {
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Open", Owners: []string{"ana"}, Estimate: 3, CreatedAt: time.Now().AddDate(0, 0, -2)},
+		{ID: "2", Name: "Ad-hoc", Owners: []string{"ben"}, Estimate: 1, Labels: []string{"ad-hoc-request"}, CreatedAt: time.Now()},
+	}
+
+	reporter := NewReporter(items).WithAdHocFilter(types.AdHocFilterExclude)
+	report, err := reporter.GenerateReport(ReportTypeWorkload, time.Now().AddDate(0, 0, -7), time.Now(), models.FilterFieldCompletedAt)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+
+	if !strings.Contains(report, "ana") || strings.Contains(report, "ben") {
+		t.Errorf("GenerateReport() should list open non-ad-hoc work regardless of completion dates:\n%s", report)
+	}
+}
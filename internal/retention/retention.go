@@ -0,0 +1,92 @@
+// Package retention removes earlier output files. It is reached only after a
+// run saves to --output-template with --keep-last or --keep-days: the pattern
+// of the template matches that run's earlier files, whether each run starts
+// from the shell, a crontab or install-service, and whether it replaces the
+// file or adds to it with --append.
+package retention
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/signing"
+)
+
+// Policy decides which earlier output files are kept
+type Policy struct {
+	KeepLast int // Keep only the newest N files (0 keeps any number)
+	KeepDays int // Remove files older than this many days (0 keeps any age)
+}
+
+// IsSet reports whether the policy removes any files
+func (p Policy) IsSet() bool {
+	return p.KeepLast > 0 || p.KeepDays > 0
+}
+
+// output is a file matching the output pattern
+type output struct {
+	path    string
+	modTime time.Time
+}
+
+// Prune removes the files matching pattern that fall outside the policy,
+// along with their checksum and signature files, and returns their paths.
+// The file at current, which was just written, is always kept and counts as
+// the newest.
+func Prune(pattern, current string, policy Policy, now time.Time) ([]string, error) {
+	if !policy.IsSet() {
+		return nil, nil
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output pattern %s: %v", pattern, err)
+	}
+
+	var outputs []output
+	for _, path := range matches {
+		if filepath.Clean(path) == filepath.Clean(current) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		outputs = append(outputs, output{path, info.ModTime()})
+	}
+
+	// Newest first; names break ties, since dated names sort by date
+	sort.Slice(outputs, func(i, j int) bool {
+		if !outputs[i].modTime.Equal(outputs[j].modTime) {
+			return outputs[i].modTime.After(outputs[j].modTime)
+		}
+		return outputs[i].path > outputs[j].path
+	})
+
+	cutoff := now.AddDate(0, 0, -policy.KeepDays)
+	var removed []string
+	for i, out := range outputs {
+		// The current file is the newest one kept
+		tooMany := policy.KeepLast > 0 && i+1 >= policy.KeepLast
+		tooOld := policy.KeepDays > 0 && out.modTime.Before(cutoff)
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(out.path); err != nil {
+			return removed, fmt.Errorf("error removing old output %s: %v", out.path, err)
+		}
+		removed = append(removed, out.path)
+
+		// The checksum and signature only make sense next to their output
+		for _, extension := range []string{signing.ChecksumExtension, signing.SignatureExtension} {
+			if err := os.Remove(out.path + extension); err != nil && !os.IsNotExist(err) {
+				return removed, fmt.Errorf("error removing old output %s: %v", out.path+extension, err)
+			}
+		}
+	}
+
+	return removed, nil
+}
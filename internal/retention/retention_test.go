@@ -0,0 +1,122 @@
+package retention
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// writeOutputs creates files in dir with modification times the given number
+// of days before now
+func writeOutputs(t *testing.T, dir string, now time.Time, ages map[string]int) {
+	t.Helper()
+	for name, days := range ages {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		modTime := now.AddDate(0, 0, -days)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set time of %s: %v", name, err)
+		}
+	}
+}
+
+// remaining lists the files left in dir
+func remaining(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		policy Policy
+		want   []string
+	}{
+		{"No policy keeps everything", Policy{}, []string{"notes.md", "report-06-02.md", "report-06-09.md", "report-06-16.md", "report-06-23.md", "report-06-30.md"}},
+		{"Keep last 3", Policy{KeepLast: 3}, []string{"notes.md", "report-06-16.md", "report-06-23.md", "report-06-30.md"}},
+		{"Keep last 1", Policy{KeepLast: 1}, []string{"notes.md", "report-06-30.md"}},
+		{"Keep 10 days", Policy{KeepDays: 10}, []string{"notes.md", "report-06-23.md", "report-06-30.md"}},
+		{"Both limits", Policy{KeepLast: 4, KeepDays: 20}, []string{"notes.md", "report-06-16.md", "report-06-23.md", "report-06-30.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeOutputs(t, dir, now, map[string]int{
+				"report-06-02.md": 28,
+				"report-06-09.md": 21,
+				"report-06-16.md": 14,
+				"report-06-23.md": 7,
+				"report-06-30.md": 0,
+				"notes.md":        60,
+			})
+
+			current := filepath.Join(dir, "report-06-30.md")
+			removed, err := Prune(filepath.Join(dir, "report-*.md"), current, tt.policy, now)
+			if err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+
+			if got := remaining(t, dir); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("remaining files = %v, want %v", got, tt.want)
+			}
+			if len(removed) != 6-len(tt.want) {
+				t.Errorf("removed = %v, want %d files", removed, 6-len(tt.want))
+			}
+		})
+	}
+}
+
+func TestPrune_KeepsCurrentFile(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	// The current file is old, e.g. because it was copied with its time kept
+	writeOutputs(t, dir, now, map[string]int{"report-a.md": 40, "report-b.md": 1})
+
+	removed, err := Prune(filepath.Join(dir, "report-*.md"), filepath.Join(dir, "report-a.md"), Policy{KeepLast: 1, KeepDays: 7}, now)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if want := []string{filepath.Join(dir, "report-b.md")}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+}
+
+func TestPrune_RemovesChecksumAndSignature(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	writeOutputs(t, dir, now, map[string]int{
+		"report-a.md":         10,
+		"report-a.md.sha256":  10,
+		"report-a.md.minisig": 10,
+		"report-b.md":         0,
+		"report-b.md.sha256":  0,
+	})
+
+	if _, err := Prune(filepath.Join(dir, "report-*.md"), filepath.Join(dir, "report-b.md"), Policy{KeepLast: 1}, now); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if got, want := remaining(t, dir), []string{"report-b.md", "report-b.md.sha256"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("remaining files = %v, want %v", got, want)
+	}
+}
+
+func TestPrune_InvalidPattern(t *testing.T) {
+	if _, err := Prune("[", "", Policy{KeepLast: 1}, time.Now()); err == nil {
+		t.Error("Prune() should fail for an invalid pattern")
+	}
+}
@@ -0,0 +1,226 @@
+// Package schema generates JSON Schemas for the JSON outputs from the Go
+// types behind them, so the published schemas can't drift from the output.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema version the schemas follow
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema, or part of one, ready to be encoded as JSON
+type Schema map[string]interface{}
+
+// Shaped is implemented by types with their own MarshalJSON. JSONShape
+// returns a value of the type they are encoded as.
+type Shaped interface {
+	JSONShape() interface{}
+}
+
+// Section is one kind of section in a document: the value of the section's
+// "type" field and the results its "data" field may hold
+type Section struct {
+	Type    string
+	Results []interface{}
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	shapedType    = reflect.TypeOf((*Shaped)(nil)).Elem()
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// generator builds schemas for Go types, collecting structs in $defs so
+// shared and recursive types are described once
+type generator struct {
+	defs  map[string]Schema
+	names map[reflect.Type]string
+}
+
+func newGenerator() *generator {
+	return &generator{defs: make(map[string]Schema), names: make(map[reflect.Type]string)}
+}
+
+// For returns the schema of the JSON encoding of v's type
+func For(title string, v interface{}) (Schema, error) {
+	g := newGenerator()
+	root, err := g.schema(reflect.TypeOf(v))
+	if err != nil {
+		return nil, err
+	}
+	return g.document(title, root), nil
+}
+
+// ForDocument returns the schema of a document with sections. section is the
+// type of the document's sections, whose definition is replaced by one
+// alternative per kind of section, each with the results it may hold.
+func ForDocument(title string, doc, section interface{}, sections []Section) (Schema, error) {
+	g := newGenerator()
+	root, err := g.schema(reflect.TypeOf(doc))
+	if err != nil {
+		return nil, err
+	}
+
+	name, ok := g.names[reflect.TypeOf(section)]
+	if !ok {
+		return nil, fmt.Errorf("%T has no %T sections", doc, section)
+	}
+	var alternatives []Schema
+	for _, s := range sections {
+		var results []Schema
+		for _, result := range s.Results {
+			schema, err := g.schema(reflect.TypeOf(result))
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, schema)
+		}
+		data := results[0]
+		if len(results) > 1 {
+			data = Schema{"oneOf": results}
+		}
+		alternatives = append(alternatives, Schema{
+			"type": "object",
+			"properties": Schema{
+				"type": Schema{"const": s.Type},
+				"data": data,
+			},
+			"required":             []string{"type", "data"},
+			"additionalProperties": false,
+		})
+	}
+	g.defs[name] = Schema{"oneOf": alternatives}
+
+	return g.document(title, root), nil
+}
+
+// document wraps the root schema with the draft, title and definitions
+func (g *generator) document(title string, root Schema) Schema {
+	doc := Schema{"$schema": Draft, "title": title}
+	for key, value := range root {
+		doc[key] = value
+	}
+	if len(g.defs) > 0 {
+		doc["$defs"] = g.defs
+	}
+	return doc
+}
+
+// schema returns the schema of a type, as encoding/json writes it
+func (g *generator) schema(t reflect.Type) (Schema, error) {
+	if t.Kind() == reflect.Pointer {
+		elem, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(elem), nil
+	}
+
+	switch {
+	case t == timeType:
+		return Schema{"type": "string", "format": "date-time"}, nil
+	case t.Implements(shapedType):
+		return g.structRef(t, reflect.TypeOf(reflect.Zero(t).Interface().(Shaped).JSONShape()))
+	case t.Implements(marshalerType):
+		return nil, fmt.Errorf("%s has its own MarshalJSON but no JSONShape", t)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}, nil
+	case reflect.String:
+		return Schema{"type": "string"}, nil
+	case reflect.Interface:
+		return Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		// Nil slices are written as null
+		return Schema{"type": []string{"array", "null"}, "items": items}, nil
+	case reflect.Map:
+		values, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Schema{"type": []string{"object", "null"}, "additionalProperties": values}, nil
+	case reflect.Struct:
+		return g.structRef(t, t)
+	}
+	return nil, fmt.Errorf("no JSON schema for %s", t)
+}
+
+// structRef defines the struct named by t with the fields of shape, and
+// returns a reference to the definition
+func (g *generator) structRef(t, shape reflect.Type) (Schema, error) {
+	name, defined := g.names[t]
+	if !defined {
+		name = t.Name()
+		if _, taken := g.defs[name]; taken || name == "" {
+			name = strings.ReplaceAll(t.String(), ".", "_")
+		}
+		g.names[t] = name
+		g.defs[name] = Schema{} // Placeholder, so recursive types end
+
+		properties := Schema{}
+		required := []string{}
+		if err := g.fields(shape, properties, &required); err != nil {
+			return nil, err
+		}
+		g.defs[name] = Schema{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	}
+	return Schema{"$ref": "#/$defs/" + name}, nil
+}
+
+// fields adds the JSON fields of a struct, including promoted fields of
+// embedded structs, to properties. Fields without omitempty are required.
+func (g *generator) fields(t reflect.Type, properties Schema, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := g.fields(field.Type, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema, err := g.schema(field.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		properties[name] = schema
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+	return nil
+}
+
+// nullable allows null in place of a value, as written for nil pointers
+func nullable(schema Schema) Schema {
+	return Schema{"anyOf": []Schema{schema, {"type": "null"}}}
+}
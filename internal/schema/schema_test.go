@@ -0,0 +1,140 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type node struct {
+	Name     string    `json:"name"`
+	Count    int       `json:"count,omitempty"`
+	Share    *float64  `json:"share"`
+	When     time.Time `json:"when"`
+	Children []node    `json:"children"`
+	Ignored  string    `json:"-"`
+	hidden   string
+}
+
+type embedding struct {
+	node
+	Extra bool `json:"extra"`
+}
+
+type custom struct{}
+
+func (custom) MarshalJSON() ([]byte, error) { return []byte(`"custom"`), nil }
+
+type shaped struct{}
+
+func (shaped) MarshalJSON() ([]byte, error) { return []byte(`{"label":"x"}`), nil }
+func (shaped) JSONShape() interface{} {
+	return struct {
+		Label string `json:"label"`
+	}{}
+}
+
+func TestFor(t *testing.T) {
+	s, err := For("Nodes", []node{})
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+
+	if s["$schema"] != Draft || s["title"] != "Nodes" {
+		t.Errorf("schema = %v, want the draft and title", s)
+	}
+	def := s["$defs"].(map[string]Schema)["node"]
+	properties := def["properties"].(Schema)
+	if len(properties) != 5 {
+		t.Errorf("properties = %v, want name, count, share, when and children", properties)
+	}
+	if got := def["required"].([]string); !reflect.DeepEqual(got, []string{"name", "share", "when", "children"}) {
+		t.Errorf("required = %v, want every field without omitempty", got)
+	}
+	if got := properties["children"].(Schema)["items"]; !reflect.DeepEqual(got, Schema{"$ref": "#/$defs/node"}) {
+		t.Errorf("children items = %v, want a reference back to node", got)
+	}
+	if _, ok := properties["share"].(Schema)["anyOf"]; !ok {
+		t.Errorf("share = %v, want a pointer to allow null", properties["share"])
+	}
+}
+
+func TestFor_EmbeddedAndShaped(t *testing.T) {
+	s, err := For("Embedding", embedding{})
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+	properties := s["$defs"].(map[string]Schema)["embedding"]["properties"].(Schema)
+	if _, ok := properties["name"]; !ok {
+		t.Errorf("properties = %v, want the embedded struct's fields promoted", properties)
+	}
+
+	s, err = For("Shaped", shaped{})
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+	if err := Validate(s, []byte(`{"label":"x"}`)); err != nil {
+		t.Errorf("Validate() error = %v, want the shape's fields", err)
+	}
+
+	if _, err := For("Custom", custom{}); err == nil || !strings.Contains(err.Error(), "no JSONShape") {
+		t.Errorf("For() error = %v, want an error for MarshalJSON without JSONShape", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s, err := ForDocument("Document", struct {
+		Sections []section `json:"sections"`
+	}{}, section{}, []Section{
+		{Type: "count", Results: []interface{}{0}},
+		{Type: "names", Results: []interface{}{[]string{}, ""}},
+	})
+	if err != nil {
+		t.Fatalf("ForDocument() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"Valid", `{"sections":[{"type":"count","data":3},{"type":"names","data":["a"]},{"type":"names","data":"a"}]}`, ""},
+		{"Wrong data for the type", `{"sections":[{"type":"count","data":"3"}]}`, "$.sections[0]"},
+		{"Unknown section type", `{"sections":[{"type":"other","data":3}]}`, "matches 0 alternatives"},
+		{"Missing field", `{}`, `missing required field "sections"`},
+		{"Unexpected field", `{"sections":[],"extra":1}`, "$.extra: unexpected field"},
+		{"Fraction for an integer", `{"sections":[{"type":"count","data":1.5}]}`, "$.sections[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(s, []byte(tt.doc))
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+type section struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+func TestSchema_EncodesAsJSON(t *testing.T) {
+	s, err := For("Nodes", node{})
+	if err != nil {
+		t.Fatalf("For() error = %v", err)
+	}
+	if _, err := json.Marshal(s); err != nil {
+		t.Errorf("json.Marshal() error = %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Validate checks a JSON document against a schema from this package. It
+// supports the keywords these schemas use, not all of JSON Schema.
+func Validate(s Schema, data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	defs, _ := s["$defs"].(map[string]Schema)
+	return validator{defs}.check(s, value, "$")
+}
+
+// validator checks values against schemas, resolving references to defs
+type validator struct {
+	defs map[string]Schema
+}
+
+// check reports the first place value doesn't match s, by its JSON path
+func (v validator) check(s Schema, value interface{}, path string) error {
+	if ref, ok := s["$ref"].(string); ok {
+		def, found := v.defs[strings.TrimPrefix(ref, "#/$defs/")]
+		if !found {
+			return fmt.Errorf("%s: unknown reference %s", path, ref)
+		}
+		return v.check(def, value, path)
+	}
+	if alternatives, ok := s["anyOf"].([]Schema); ok {
+		if v.matches(alternatives, value, path) == 0 {
+			return fmt.Errorf("%s: matches none of the alternatives", path)
+		}
+		return nil
+	}
+	if alternatives, ok := s["oneOf"].([]Schema); ok {
+		if n := v.matches(alternatives, value, path); n != 1 {
+			return fmt.Errorf("%s: matches %d alternatives, want exactly 1", path, n)
+		}
+		return nil
+	}
+	if want, ok := s["const"]; ok && value != want {
+		return fmt.Errorf("%s: is %v, want %v", path, value, want)
+	}
+	if err := checkType(s["type"], value, path); err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return v.checkObject(s, value, path)
+	case []interface{}:
+		if items, ok := s["items"].(Schema); ok {
+			for i, item := range value {
+				if err := v.check(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkObject checks the required fields and each field of an object
+func (v validator) checkObject(s Schema, value map[string]interface{}, path string) error {
+	if required, ok := s["required"].([]string); ok {
+		for _, name := range required {
+			if _, present := value[name]; !present {
+				return fmt.Errorf("%s: missing required field %q", path, name)
+			}
+		}
+	}
+
+	properties, _ := s["properties"].(Schema)
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fieldPath := path + "." + name
+		if property, ok := properties[name].(Schema); ok {
+			if err := v.check(property, value[name], fieldPath); err != nil {
+				return err
+			}
+			continue
+		}
+		switch additional := s["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return fmt.Errorf("%s: unexpected field", fieldPath)
+			}
+		case Schema:
+			if err := v.check(additional, value[name], fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// matches counts the alternatives value matches
+func (v validator) matches(alternatives []Schema, value interface{}, path string) int {
+	n := 0
+	for _, alternative := range alternatives {
+		if v.check(alternative, value, path) == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// checkType checks value against a "type" keyword of one type or a list
+func checkType(want interface{}, value interface{}, path string) error {
+	var types []string
+	switch want := want.(type) {
+	case nil:
+		return nil
+	case string:
+		types = []string{want}
+	case []string:
+		types = want
+	}
+
+	got := jsonType(value)
+	for _, t := range types {
+		if t == got || (t == "number" && got == "integer") {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: is %s, want %s", path, got, strings.Join(types, " or "))
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
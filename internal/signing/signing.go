@@ -0,0 +1,65 @@
+package signing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumExtension is appended to the output path for the checksum file
+const ChecksumExtension = ".sha256"
+
+// SignatureExtension is appended to the output path for the minisign signature
+const SignatureExtension = ".minisig"
+
+// minisignCommand is the minisign executable, replaced in tests
+var minisignCommand = "minisign"
+
+// WriteChecksum writes the SHA-256 checksum of the file next to it, in the
+// format of sha256sum so it can be verified with "sha256sum -c", and returns
+// the checksum file's path
+func WriteChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening %s for checksum: %v", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("error reading %s for checksum: %v", path, err)
+	}
+
+	// The file is named relative to the checksum file, which sits next to it
+	checksumPath := path + ChecksumExtension
+	line := fmt.Sprintf("%s  %s\n", hex.EncodeToString(hash.Sum(nil)), filepath.Base(path))
+	if err := os.WriteFile(checksumPath, []byte(line), 0644); err != nil {
+		return "", fmt.Errorf("error writing checksum: %v", err)
+	}
+	return checksumPath, nil
+}
+
+// Sign signs the file with minisign using the secret key, writing the
+// signature next to it, and returns the signature file's path. The key's
+// password is prompted for by minisign unless the key has none.
+func Sign(path, keyPath string) (string, error) {
+	signaturePath := path + SignatureExtension
+	cmd := exec.Command(minisignCommand, "-S", "-s", keyPath, "-m", path, "-x", signaturePath)
+	cmd.Stdin = os.Stdin
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("minisign is not installed; install it to sign outputs with --minisign-key")
+		}
+		return "", fmt.Errorf("error signing %s with minisign: %v %s", path, err, strings.TrimSpace(stderr.String()))
+	}
+	return signaturePath, nil
+}
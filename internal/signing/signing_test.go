@@ -0,0 +1,51 @@
+package signing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteChecksum(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.txt")
+	if err := os.WriteFile(path, []byte("hello\n"), 0644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+
+	checksumPath, err := WriteChecksum(path)
+	if err != nil {
+		t.Fatalf("WriteChecksum() error = %v", err)
+	}
+	if checksumPath != path+".sha256" {
+		t.Errorf("WriteChecksum() path = %q, want %q", checksumPath, path+".sha256")
+	}
+
+	content, err := os.ReadFile(checksumPath)
+	if err != nil {
+		t.Fatalf("Failed to read checksum: %v", err)
+	}
+	// sha256 of "hello\n"
+	want := "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03  report.txt\n"
+	if string(content) != want {
+		t.Errorf("checksum file = %q, want %q", content, want)
+	}
+}
+
+func TestWriteChecksum_MissingFile(t *testing.T) {
+	if _, err := WriteChecksum(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("WriteChecksum() should fail for a missing file")
+	}
+}
+
+func TestSign_MinisignNotInstalled(t *testing.T) {
+	original := minisignCommand
+	minisignCommand = "kanban-reports-no-such-minisign"
+	defer func() { minisignCommand = original }()
+
+	_, err := Sign(filepath.Join(t.TempDir(), "report.txt"), "key")
+	if err == nil || !strings.Contains(err.Error(), "minisign is not installed") {
+		t.Errorf("Sign() error = %v, want a not installed error", err)
+	}
+}
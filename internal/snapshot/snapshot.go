@@ -0,0 +1,222 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+	"github.com/hannasdev/kanban-reports/internal/schema"
+)
+
+// Kind is what changed about an item between two exports
+type Kind string
+
+// Kinds of changes, in the order they are reported
+const (
+	KindAdded       Kind = "added"       // In the current export only
+	KindRemoved     Kind = "removed"     // In the baseline only, e.g. deleted or archived and left out of the export
+	KindCompleted   Kind = "completed"   // Not completed in the baseline, completed now
+	KindReopened    Kind = "reopened"    // Completed in the baseline, not completed now
+	KindReestimated Kind = "reestimated" // The estimate changed
+	KindMovedEpic   Kind = "moved-epic"  // The item moved to another epic
+	KindMovedTeam   Kind = "moved-team"  // The item moved to another team
+)
+
+// Kinds lists every kind of change in report order
+var Kinds = []Kind{KindAdded, KindRemoved, KindCompleted, KindReopened, KindReestimated, KindMovedEpic, KindMovedTeam}
+
+// kindTitles are the section titles of the kinds, with the headers of the
+// values before and after the change
+var kindTitles = map[Kind]struct{ title, from, to string }{
+	KindAdded:       {"Added", "", "State"},
+	KindRemoved:     {"Removed", "State", ""},
+	KindCompleted:   {"Completed", "State", "Completed"},
+	KindReopened:    {"Re-opened", "Completed", "State"},
+	KindReestimated: {"Re-estimated", "Before", "After"},
+	KindMovedEpic:   {"Moved Between Epics", "From", "To"},
+	KindMovedTeam:   {"Moved Between Teams", "From", "To"},
+}
+
+// Change is one change to an item. From and To hold the value before and
+// after, such as the old and new estimate; an added item has no From and a
+// removed item no To.
+type Change struct {
+	Kind Kind   `json:"kind"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Team string `json:"team,omitempty"` // Team in the current export, or in the baseline for removed items
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// Result is the difference between a baseline export and the current one
+type Result struct {
+	BaselineItems int      `json:"baseline_items"`
+	CurrentItems  int      `json:"current_items"`
+	ChangedItems  int      `json:"changed_items"` // Items with at least one change
+	Changes       []Change `json:"changes"`       // By kind, then by item ID
+}
+
+// Count returns the number of changes of a kind
+func (r Result) Count(kind Kind) int {
+	count := 0
+	for _, change := range r.Changes {
+		if change.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Diff compares the items of two exports of the same board, matching them by
+// ID. An item can change in several ways at once, such as being completed
+// and re-estimated.
+func Diff(baseline, current []models.KanbanItem) Result {
+	result := Result{BaselineItems: len(baseline), CurrentItems: len(current), Changes: []Change{}}
+
+	before := make(map[string]models.KanbanItem, len(baseline))
+	for _, item := range baseline {
+		before[item.ID] = item
+	}
+	changed := make(map[string]bool)
+	add := func(change Change) {
+		result.Changes = append(result.Changes, change)
+		changed[change.ID] = true
+	}
+
+	seen := make(map[string]bool, len(current))
+	for _, item := range current {
+		seen[item.ID] = true
+		old, ok := before[item.ID]
+		if !ok {
+			add(Change{Kind: KindAdded, ID: item.ID, Name: item.Name, Team: item.Team, To: item.State})
+			continue
+		}
+		switch {
+		case !old.IsCompleted && item.IsCompleted:
+			add(Change{Kind: KindCompleted, ID: item.ID, Name: item.Name, Team: item.Team, From: old.State, To: formatDate(item)})
+		case old.IsCompleted && !item.IsCompleted:
+			add(Change{Kind: KindReopened, ID: item.ID, Name: item.Name, Team: item.Team, From: formatDate(old), To: item.State})
+		}
+		if old.Estimate != item.Estimate {
+			add(Change{Kind: KindReestimated, ID: item.ID, Name: item.Name, Team: item.Team, From: formatEstimate(old.Estimate), To: formatEstimate(item.Estimate)})
+		}
+		if old.Epic != item.Epic {
+			add(Change{Kind: KindMovedEpic, ID: item.ID, Name: item.Name, Team: item.Team, From: old.Epic, To: item.Epic})
+		}
+		if old.Team != item.Team {
+			add(Change{Kind: KindMovedTeam, ID: item.ID, Name: item.Name, Team: item.Team, From: old.Team, To: item.Team})
+		}
+	}
+	for _, item := range baseline {
+		if !seen[item.ID] {
+			add(Change{Kind: KindRemoved, ID: item.ID, Name: item.Name, Team: item.Team, From: item.State})
+			seen[item.ID] = true
+		}
+	}
+
+	order := make(map[Kind]int, len(Kinds))
+	for i, kind := range Kinds {
+		order[kind] = i
+	}
+	sort.SliceStable(result.Changes, func(i, j int) bool {
+		a, b := result.Changes[i], result.Changes[j]
+		if a.Kind != b.Kind {
+			return order[a.Kind] < order[b.Kind]
+		}
+		return a.ID < b.ID
+	})
+	result.ChangedItems = len(changed)
+	return result
+}
+
+// formatDate returns the completion date of an item, if it has one
+func formatDate(item models.KanbanItem) string {
+	if item.CompletedAt.IsZero() {
+		return ""
+	}
+	return item.CompletedAt.Format("2006-01-02")
+}
+
+// formatEstimate formats an estimate without needless decimals
+func formatEstimate(estimate float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", estimate), ".0")
+}
+
+// Blocks lays out the changes for documents: a summary, then a table of the
+// items of each kind of change found
+func Blocks(result Result) []render.Block {
+	blocks := []render.Block{
+		render.Heading{Level: 1, Text: "Changes Since Baseline"},
+		render.Paragraph{Lines: []string{
+			fmt.Sprintf("%d items in the baseline, %d now; %d items changed.", result.BaselineItems, result.CurrentItems, result.ChangedItems),
+		}},
+	}
+	if len(result.Changes) == 0 {
+		return append(blocks, render.Paragraph{Lines: []string{"No changes found."}})
+	}
+
+	var counts []string
+	for _, kind := range Kinds {
+		if count := result.Count(kind); count > 0 {
+			counts = append(counts, fmt.Sprintf("%s: %d", kindTitles[kind].title, count))
+		}
+	}
+	blocks = append(blocks, render.List{Items: counts})
+
+	for _, kind := range Kinds {
+		titles := kindTitles[kind]
+		headers := []string{"ID", "Name", "Team"}
+		if titles.from != "" {
+			headers = append(headers, titles.from)
+		}
+		if titles.to != "" {
+			headers = append(headers, titles.to)
+		}
+		table := render.Table{Headers: headers}
+		for _, change := range result.Changes {
+			if change.Kind != kind {
+				continue
+			}
+			row := []string{change.ID, change.Name, change.Team}
+			if titles.from != "" {
+				row = append(row, orNone(change.From))
+			}
+			if titles.to != "" {
+				row = append(row, orNone(change.To))
+			}
+			table.Rows = append(table.Rows, row)
+		}
+		if len(table.Rows) > 0 {
+			blocks = append(blocks, render.Heading{Level: 2, Text: fmt.Sprintf("%s (%d)", titles.title, len(table.Rows))}, table)
+		}
+	}
+	return blocks
+}
+
+// orNone shows an empty value, such as an item without an epic, as "-"
+func orNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// JSONSchema describes the JSON document written by WriteJSON
+func JSONSchema() (schema.Schema, error) {
+	return schema.For("Kanban changes since a baseline export", Result{})
+}
+
+// WriteJSON writes the changes as a JSON document for tools that track them
+func WriteJSON(w io.Writer, result Result) error {
+	if result.Changes == nil {
+		result.Changes = []Change{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
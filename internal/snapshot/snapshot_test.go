@@ -0,0 +1,115 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/render"
+)
+
+func snapshotItems() (baseline, current []models.KanbanItem) {
+	done := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	baseline = []models.KanbanItem{
+		{ID: "1", Name: "Login", Team: "Payments", Epic: "Auth", State: "In Progress", Estimate: 3},
+		{ID: "2", Name: "Logout", Team: "Payments", Epic: "Auth", State: "Done", IsCompleted: true, CompletedAt: done, Estimate: 1},
+		{ID: "3", Name: "Invoices", Team: "Payments", Epic: "Billing", State: "Ready", Estimate: 2},
+		{ID: "4", Name: "Old spike", Team: "Core", State: "Ready"},
+		{ID: "5", Name: "Unchanged", Team: "Core", Epic: "Billing", State: "Ready", Estimate: 5},
+	}
+	current = []models.KanbanItem{
+		{ID: "1", Name: "Login", Team: "Payments", Epic: "Auth", State: "Done", IsCompleted: true, CompletedAt: done, Estimate: 5},
+		{ID: "2", Name: "Logout", Team: "Payments", Epic: "Auth", State: "In Progress", CompletedAt: done, Estimate: 1},
+		{ID: "3", Name: "Invoices", Team: "Core", Epic: "", State: "Ready", Estimate: 2},
+		{ID: "5", Name: "Unchanged", Team: "Core", Epic: "Billing", State: "Ready", Estimate: 5},
+		{ID: "6", Name: "Refunds", Team: "Payments", Epic: "Billing", State: "Ready", Estimate: 2.5},
+	}
+	return baseline, current
+}
+
+func TestDiff(t *testing.T) {
+	result := Diff(snapshotItems())
+
+	want := []Change{
+		{Kind: KindAdded, ID: "6", Name: "Refunds", Team: "Payments", To: "Ready"},
+		{Kind: KindRemoved, ID: "4", Name: "Old spike", Team: "Core", From: "Ready"},
+		{Kind: KindCompleted, ID: "1", Name: "Login", Team: "Payments", From: "In Progress", To: "2024-05-10"},
+		{Kind: KindReopened, ID: "2", Name: "Logout", Team: "Payments", From: "2024-05-10", To: "In Progress"},
+		{Kind: KindReestimated, ID: "1", Name: "Login", Team: "Payments", From: "3", To: "5"},
+		{Kind: KindMovedEpic, ID: "3", Name: "Invoices", Team: "Core", From: "Billing"},
+		{Kind: KindMovedTeam, ID: "3", Name: "Invoices", Team: "Core", From: "Payments", To: "Core"},
+	}
+	if len(result.Changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(want), len(result.Changes), result.Changes)
+	}
+	for i, change := range want {
+		if result.Changes[i] != change {
+			t.Errorf("Change %d = %+v, want %+v", i, result.Changes[i], change)
+		}
+	}
+	if result.BaselineItems != 5 || result.CurrentItems != 5 || result.ChangedItems != 5 {
+		t.Errorf("Expected 5 baseline, 5 current and 5 changed items, got %d, %d and %d",
+			result.BaselineItems, result.CurrentItems, result.ChangedItems)
+	}
+	if got := result.Count(KindMovedTeam); got != 1 {
+		t.Errorf("Count(moved-team) = %d, want 1", got)
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	baseline, _ := snapshotItems()
+	result := Diff(baseline, baseline)
+	if len(result.Changes) != 0 || result.ChangedItems != 0 {
+		t.Errorf("Expected no changes, got %+v", result.Changes)
+	}
+
+	text := render.TextRenderer{}.Render(render.ReportDocument{Sections: []render.Section{{Blocks: Blocks(result)}}})
+	if !strings.Contains(text, "No changes found.") {
+		t.Errorf("Expected no-changes message, got:\n%s", text)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	text := render.TextRenderer{}.Render(render.ReportDocument{Sections: []render.Section{{Blocks: Blocks(Diff(snapshotItems()))}}})
+
+	expected := []string{
+		"# Changes Since Baseline",
+		"5 items in the baseline, 5 now; 5 items changed.",
+		"- Added: 1",
+		"- Moved Between Teams: 1",
+		"## Added (1)",
+		"ID | Name    | Team     | State",
+		" 6 | Refunds | Payments | Ready",
+		"## Re-estimated (1)",
+		"ID | Name  | Team     | Before | After",
+		" 1 | Login | Payments |      3 |     5",
+		"## Moved Between Epics (1)",
+		" 3 | Invoices | Core | Billing | -",
+	}
+	for _, str := range expected {
+		if !strings.Contains(text, str) {
+			t.Errorf("Output doesn't contain expected string: %q\nGot:\n%s", str, text)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, Diff(nil, nil)); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, buf.String())
+	}
+	if changes, ok := decoded["changes"].([]interface{}); !ok || len(changes) != 0 {
+		t.Errorf("Expected an empty changes array, got %v", decoded["changes"])
+	}
+
+	if _, err := JSONSchema(); err != nil {
+		t.Errorf("JSONSchema() error = %v", err)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/hannasdev/kanban-reports/pkg/terminal"
 )
 
 // CSVPathError represents different types of CSV path validation errors
@@ -54,7 +56,7 @@ func ValidateCSVPath(path string) error {
 		return CSVPathError{
 			Path:    cleanPath,
 			Type:    "is_directory",
-			Message: fmt.Sprintf("'%s' is a directory, not a file. Please specify a CSV file, e.g., '%s/data.csv'", cleanPath, cleanPath),
+			Message: fmt.Sprintf("'%s' is a directory, not a file. Please specify a CSV file, e.g., '%s'", cleanPath, filepath.Join(cleanPath, "data.csv")),
 		}
 	}
 
@@ -73,7 +75,7 @@ func ValidateCSVPath(path string) error {
 	ext := strings.ToLower(filepath.Ext(cleanPath))
 	if ext != ".csv" && ext != ".txt" {
 		// This is just a warning, not an error
-		fmt.Printf("⚠️  Warning: File '%s' doesn't have a .csv or .txt extension. Proceeding anyway...\n", cleanPath)
+		fmt.Fprintf(terminal.Stdout(), "⚠️  Warning: File '%s' doesn't have a .csv or .txt extension. Proceeding anyway...\n", cleanPath)
 	}
 
 	// Check file size (warn if empty)
@@ -142,13 +144,23 @@ func validateCSVFormat(path string) error {
 		}
 		
 		if foundColumns == 0 {
-			fmt.Printf("⚠️  Warning: File doesn't contain expected kanban columns (id, name, estimate, etc.). Proceeding anyway...\n")
+			fmt.Fprintf(terminal.Stdout(), "⚠️  Warning: File doesn't contain expected kanban columns (id, name, estimate, etc.). Proceeding anyway...\n")
 		}
 	}
 
 	return nil
 }
 
+// CleanPathInput trims the spaces and quotes around a typed or pasted path,
+// such as the quotes Windows Explorer's "Copy as path" adds
+func CleanPathInput(path string) string {
+	path = strings.TrimSpace(path)
+	if len(path) >= 2 && (path[0] == '"' || path[0] == '\'') && path[len(path)-1] == path[0] {
+		path = strings.TrimSpace(path[1 : len(path)-1])
+	}
+	return path
+}
+
 // SuggestCSVFiles suggests CSV files in a directory if user provided a directory
 func SuggestCSVFiles(dirPath string) []string {
 	var suggestions []string
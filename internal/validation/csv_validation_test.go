@@ -154,6 +154,26 @@ func TestSuggestCSVFiles(t *testing.T) {
 	}
 }
 
+func TestCleanPathInput(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"data.csv", "data.csv"},
+		{"  data.csv \n", "data.csv"},
+		{`"C:\Users\me\My Exports\data.csv"`, `C:\Users\me\My Exports\data.csv`},
+		{"'exports/data.csv'", "exports/data.csv"},
+		{`"data.csv'`, `"data.csv'`}, // Unmatched quotes are kept
+		{`"`, `"`},
+	}
+
+	for _, tt := range tests {
+		if got := CleanPathInput(tt.input); got != tt.want {
+			t.Errorf("CleanPathInput(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
 func TestCSVPathError(t *testing.T) {
 	err := CSVPathError{
 		Path:    "/test/path",
@@ -0,0 +1,47 @@
+//go:build windows
+
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateCSVPath_Windows(t *testing.T) {
+	// UNC paths keep their leading backslashes when cleaned
+	err := ValidateCSVPath(`\\fileserver\exports\missing.csv`)
+	csvErr, ok := err.(CSVPathError)
+	if !ok || csvErr.Type != "not_found" {
+		t.Fatalf("ValidateCSVPath() error = %v, want not_found", err)
+	}
+	if csvErr.Path != `\\fileserver\exports\missing.csv` {
+		t.Errorf("Path = %q, want the UNC path unchanged", csvErr.Path)
+	}
+
+	// Forward slashes are accepted and the suggestion uses backslashes
+	dir := t.TempDir()
+	err = ValidateCSVPath(filepath.ToSlash(dir))
+	if csvErr, ok := err.(CSVPathError); !ok || csvErr.Type != "is_directory" {
+		t.Fatalf("ValidateCSVPath() error = %v, want is_directory", err)
+	}
+	if !strings.Contains(err.Error(), dir+`\data.csv`) {
+		t.Errorf("Error should suggest a path with backslashes: %v", err)
+	}
+}
+
+func TestSuggestCSVFiles_Windows(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"Export.CSV", "notes.TXT", "board.xlsx"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("id,name\r\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	suggestions := SuggestCSVFiles(dir + `\`)
+	want := []string{dir + `\Export.CSV`, dir + `\notes.TXT`}
+	if strings.Join(suggestions, "|") != strings.Join(want, "|") {
+		t.Errorf("SuggestCSVFiles() = %v, want %v", suggestions, want)
+	}
+}
@@ -0,0 +1,138 @@
+package dateutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Absence is a date range in which part of the team's capacity was unavailable
+type Absence struct {
+	Start    time.Time
+	End      time.Time // Inclusive
+	Fraction float64   // Share of capacity lost on each working day, from 0 to 1
+}
+
+// Absences is a list of absence periods
+type Absences []Absence
+
+// ParseAbsences reads one absence per line in the form "START[..END] PERCENT",
+// e.g. "2024-07-01..2024-07-31 50%" when half the team was away in July.
+// The percentage defaults to 100%. Blank lines and lines starting with # are ignored.
+func ParseAbsences(r io.Reader) (Absences, error) {
+	var absences Absences
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		absence, err := parseAbsenceRange(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		absence.Fraction = 1
+		if len(fields) > 1 {
+			percent, err := strconv.ParseFloat(strings.TrimSuffix(fields[1], "%"), 64)
+			if err != nil || percent <= 0 || percent > 100 {
+				return nil, fmt.Errorf("line %d: invalid absence percentage %q (expected 1-100%%)", lineNumber, fields[1])
+			}
+			absence.Fraction = percent / 100
+		}
+
+		absences = append(absences, absence)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return absences, nil
+}
+
+// parseAbsenceRange parses "YYYY-MM-DD" or "YYYY-MM-DD..YYYY-MM-DD"
+func parseAbsenceRange(s string) (Absence, error) {
+	startStr, endStr, isRange := strings.Cut(s, "..")
+	if !isRange {
+		endStr = startStr
+	}
+
+	start, err := time.Parse(HolidayDateFormat, startStr)
+	if err != nil {
+		return Absence{}, fmt.Errorf("invalid absence date %q (expected YYYY-MM-DD)", startStr)
+	}
+	end, err := time.Parse(HolidayDateFormat, endStr)
+	if err != nil {
+		return Absence{}, fmt.Errorf("invalid absence date %q (expected YYYY-MM-DD)", endStr)
+	}
+	if end.Before(start) {
+		return Absence{}, fmt.Errorf("absence ends before it starts: %s", s)
+	}
+
+	return Absence{Start: start, End: end}, nil
+}
+
+// LoadAbsences reads an absences file from disk
+func LoadAbsences(path string) (Absences, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening absences file: %w", err)
+	}
+	defer file.Close()
+
+	absences, err := ParseAbsences(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading absences file '%s': %w", path, err)
+	}
+	return absences, nil
+}
+
+// lostOn returns the share of capacity lost on the calendar date of t, capped at 1
+func (a Absences) lostOn(t time.Time) float64 {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	lost := 0.0
+	for _, absence := range a {
+		if !date.Before(absence.Start) && !date.After(absence.End) {
+			lost += absence.Fraction
+		}
+	}
+	if lost > 1 {
+		lost = 1
+	}
+	return lost
+}
+
+// Availability returns the average share of capacity available on the working
+// days from start to end (both inclusive, by calendar date). It is 1 when no
+// absences fall in the range or the range has no working days.
+func (a Absences) Availability(start, end time.Time, holidays Holidays) float64 {
+	if len(a) == 0 {
+		return 1
+	}
+
+	workingDays := 0
+	available := 0.0
+	current := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	for !current.After(last) {
+		if IsWorkingDay(current, holidays) {
+			workingDays++
+			available += 1 - a.lostOn(current)
+		}
+		current = current.AddDate(0, 0, 1)
+	}
+
+	if workingDays == 0 {
+		return 1
+	}
+	return available / float64(workingDays)
+}
@@ -0,0 +1,92 @@
+package dateutil
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAbsences(t *testing.T) {
+	input := `# Summer vacations
+2024-07-01..2024-07-31 50%
+2024-12-24
+
+2024-12-27 25`
+
+	absences, err := ParseAbsences(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseAbsences() error = %v", err)
+	}
+	if len(absences) != 3 {
+		t.Fatalf("Expected 3 absences, got %d", len(absences))
+	}
+
+	if absences[0].Fraction != 0.5 || absences[0].End.Format(HolidayDateFormat) != "2024-07-31" {
+		t.Errorf("First absence = %+v, want July at 50%%", absences[0])
+	}
+	if absences[1].Fraction != 1 || !absences[1].Start.Equal(absences[1].End) {
+		t.Errorf("Single-day absence = %+v, want one day at 100%%", absences[1])
+	}
+	if absences[2].Fraction != 0.25 {
+		t.Errorf("Percentage without %% sign = %v, want 0.25", absences[2].Fraction)
+	}
+}
+
+func TestParseAbsences_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		errorMsg string
+	}{
+		{"Invalid date", "2024-13-01", "invalid absence date"},
+		{"Reversed range", "2024-07-31..2024-07-01", "ends before it starts"},
+		{"Invalid percentage", "2024-07-01 150%", "invalid absence percentage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAbsences(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("ParseAbsences() error = %v, want %q", err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestAbsences_Availability(t *testing.T) {
+	absences := Absences{
+		{Start: date(2024, 7, 1), End: date(2024, 7, 5), Fraction: 0.5},
+		{Start: date(2024, 7, 5), End: date(2024, 7, 5), Fraction: 1},
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected float64
+	}{
+		// Mon-Thu at 50%, Friday fully absent (capped at 100%)
+		{"Absent week", date(2024, 7, 1), date(2024, 7, 7), (4 * 0.5) / 5},
+		{"Unaffected week", date(2024, 7, 8), date(2024, 7, 14), 1},
+		{"Weekend only", date(2024, 7, 6), date(2024, 7, 7), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := absences.Availability(tt.start, tt.end, nil)
+			if math.Abs(got-tt.expected) > 0.0001 {
+				t.Errorf("Availability() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	if got := Absences(nil).Availability(date(2024, 7, 1), date(2024, 7, 31), nil); got != 1 {
+		t.Errorf("Availability() without absences = %v, want 1", got)
+	}
+}
+
+// date returns midnight UTC on the given day
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
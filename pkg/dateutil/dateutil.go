@@ -1,8 +1,9 @@
 package dateutil
 
 import (
-	"fmt"
 	"time"
+
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
 // GetStartOfPeriod returns the start date of a period (week or month)
@@ -19,12 +20,11 @@ func GetStartOfPeriod(date time.Time, periodType string) time.Time {
 
 // FormatPeriod formats a date according to period type (week or month)
 func FormatPeriod(date time.Time, periodType string) string {
-    if periodType == "week" {
-        // Format as ISO week: 2024-W02
-        year, week := date.ISOWeek()
-        return fmt.Sprintf("%d-W%02d", year, week)
-    } else {
-        // Format as year-month: 2024-01
-        return date.Format("2006-01")
-    }
+    return FormatPeriodWith(date, periodType, types.WeekNumberingISO)
+}
+
+// FormatPeriodWith formats a date according to period type, numbering weeks
+// the given way
+func FormatPeriodWith(date time.Time, periodType string, numbering types.WeekNumbering) string {
+    return PeriodOf(date, periodType, numbering).Format(periodType)
 }
\ No newline at end of file
@@ -3,6 +3,8 @@ package dateutil
 import (
 	"testing"
 	"time"
+
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
 func TestGetStartOfPeriod(t *testing.T) {
@@ -209,6 +211,29 @@ func TestFormatPeriod_WeekEdgeCases(t *testing.T) {
 	}
 }
 
+func TestFormatPeriodWith_US(t *testing.T) {
+	tests := []struct {
+		name       string
+		date       time.Time
+		periodType string
+		expected   string
+	}{
+		{"Sunday starts a new week", time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC), "week", "2024-W21"},
+		{"Saturday ends the week", time.Date(2024, 5, 18, 0, 0, 0, 0, time.UTC), "week", "2024-W20"},
+		{"Week spanning New Year belongs to the new year", time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), "week", "2024-W01"},
+		{"Month is unaffected", time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC), "month", "2024-05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatPeriodWith(tt.date, tt.periodType, types.WeekNumberingUS)
+			if got != tt.expected {
+				t.Errorf("FormatPeriodWith() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestGetStartOfPeriod_Timezone(t *testing.T) {
 	// Test with different timezones
 	est, _ := time.LoadLocation("America/New_York")
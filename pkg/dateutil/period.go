@@ -0,0 +1,32 @@
+package dateutil
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// Period identifies the week or month containing a date as year*100 plus the
+// week or month number, so periods can be used as map keys and sorted as
+// integers, and are only formatted once per period rather than once per item
+type Period int
+
+// PeriodOf returns the week or month containing date, numbering weeks the
+// given way
+func PeriodOf(date time.Time, periodType string, numbering types.WeekNumbering) Period {
+	if periodType == "week" {
+		year, week := numbering.Week(date)
+		return Period(year*100 + week)
+	}
+	return Period(date.Year()*100 + int(date.Month()))
+}
+
+// Format returns the label of the period: 2024-W02 for a week, 2024-01 for a
+// month. Labels sort in the same order as the periods.
+func (p Period) Format(periodType string) string {
+	if periodType == "week" {
+		return fmt.Sprintf("%d-W%02d", int(p)/100, int(p)%100)
+	}
+	return fmt.Sprintf("%d-%02d", int(p)/100, int(p)%100)
+}
@@ -0,0 +1,71 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestPeriodOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		date       time.Time
+		periodType string
+		numbering  types.WeekNumbering
+		expected   string
+	}{
+		{"Month", time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC), "month", types.WeekNumberingISO, "2024-05"},
+		{"December", time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), "month", types.WeekNumberingISO, "2024-12"},
+		{"ISO week", time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), "week", types.WeekNumberingISO, "2024-W20"},
+		{"ISO week of the next year", time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), "week", types.WeekNumberingISO, "2025-W01"},
+		{"ISO week of the previous year", time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), "week", types.WeekNumberingISO, "2020-W53"},
+		{"US week spanning New Year", time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), "week", types.WeekNumberingUS, "2025-W01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period := PeriodOf(tt.date, tt.periodType, tt.numbering)
+			if got := period.Format(tt.periodType); got != tt.expected {
+				t.Errorf("PeriodOf(%v).Format() = %q, want %q", tt.date, got, tt.expected)
+			}
+			if got := FormatPeriodWith(tt.date, tt.periodType, tt.numbering); got != tt.expected {
+				t.Errorf("FormatPeriodWith(%v) = %q, want %q", tt.date, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPeriodOrder(t *testing.T) {
+	// Periods sort like their labels, across year boundaries
+	for _, periodType := range []string{"week", "month"} {
+		date := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+		previous := PeriodOf(date, periodType, types.WeekNumberingISO)
+		for i := 0; i < 120; i++ {
+			date = date.AddDate(0, 0, 7)
+			period := PeriodOf(date, periodType, types.WeekNumberingISO)
+			if period < previous || (period > previous) != (period.Format(periodType) > previous.Format(periodType)) {
+				t.Fatalf("%s %s follows %s", periodType, period.Format(periodType), previous.Format(periodType))
+			}
+			previous = period
+		}
+	}
+}
+
+// The string labels of periods were once the keys items were grouped by;
+// these compare that with grouping by period index
+func BenchmarkFormatPeriodWith(b *testing.B) {
+	date := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		FormatPeriodWith(date, "week", types.WeekNumberingISO)
+	}
+}
+
+func BenchmarkPeriodOf(b *testing.B) {
+	date := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PeriodOf(date, "week", types.WeekNumberingISO)
+	}
+}
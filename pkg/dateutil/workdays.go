@@ -0,0 +1,101 @@
+package dateutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// HolidayDateFormat is the date format used in holiday files
+const HolidayDateFormat = "2006-01-02"
+
+// Holidays is a set of non-working dates keyed by YYYY-MM-DD
+type Holidays map[string]bool
+
+// Contains reports whether the calendar date of t is a holiday
+func (h Holidays) Contains(t time.Time) bool {
+	if h == nil {
+		return false
+	}
+	return h[t.Format(HolidayDateFormat)]
+}
+
+// ParseHolidays reads one YYYY-MM-DD date per line; blank lines and lines starting with # are ignored.
+// Anything after the date on a line is treated as a description.
+func ParseHolidays(r io.Reader) (Holidays, error) {
+	holidays := make(Holidays)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ','
+		})
+		date, err := time.Parse(HolidayDateFormat, fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid holiday date %q (expected YYYY-MM-DD)", lineNumber, fields[0])
+		}
+		holidays[date.Format(HolidayDateFormat)] = true
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// LoadHolidays reads a holidays file from disk
+func LoadHolidays(path string) (Holidays, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening holidays file: %w", err)
+	}
+	defer file.Close()
+
+	holidays, err := ParseHolidays(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading holidays file '%s': %w", path, err)
+	}
+	return holidays, nil
+}
+
+// IsWorkingDay reports whether the date is a weekday that is not a holiday
+func IsWorkingDay(date time.Time, holidays Holidays) bool {
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !holidays.Contains(date)
+}
+
+// WorkingDaysBetween returns the elapsed time between start and end in days,
+// counting only the portions that fall on working days
+func WorkingDaysBetween(start, end time.Time, holidays Holidays) float64 {
+	if !end.After(start) {
+		return 0
+	}
+
+	total := 0.0
+	current := start
+	for current.Before(end) {
+		next := time.Date(current.Year(), current.Month(), current.Day()+1, 0, 0, 0, 0, current.Location())
+		if next.After(end) {
+			next = end
+		}
+		if IsWorkingDay(current, holidays) {
+			total += next.Sub(current).Hours() / 24
+		}
+		current = next
+	}
+
+	return total
+}
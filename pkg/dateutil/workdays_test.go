@@ -0,0 +1,128 @@
+package dateutil
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsWorkingDay(t *testing.T) {
+	holidays := Holidays{"2024-05-20": true}
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected bool
+	}{
+		{"Friday", time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC), true},
+		{"Saturday", time.Date(2024, 5, 18, 12, 0, 0, 0, time.UTC), false},
+		{"Sunday", time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC), false},
+		{"Holiday Monday", time.Date(2024, 5, 20, 12, 0, 0, 0, time.UTC), false},
+		{"Tuesday", time.Date(2024, 5, 21, 12, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsWorkingDay(tt.date, holidays); got != tt.expected {
+				t.Errorf("IsWorkingDay() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWorkingDaysBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		holidays Holidays
+		expected float64
+	}{
+		{
+			name:     "Full week excludes weekend",
+			start:    time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), // Monday
+			end:      time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), // next Monday
+			expected: 5,
+		},
+		{
+			name:     "Friday noon to Monday noon",
+			start:    time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC),
+			end:      time.Date(2024, 5, 20, 12, 0, 0, 0, time.UTC),
+			expected: 1,
+		},
+		{
+			name:     "Holiday excluded",
+			start:    time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC),
+			holidays: Holidays{"2024-05-15": true},
+			expected: 4,
+		},
+		{
+			name:     "Within a single weekday",
+			start:    time.Date(2024, 5, 14, 9, 0, 0, 0, time.UTC),
+			end:      time.Date(2024, 5, 14, 15, 0, 0, 0, time.UTC),
+			expected: 0.25,
+		},
+		{
+			name:     "End before start",
+			start:    time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC),
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WorkingDaysBetween(tt.start, tt.end, tt.holidays)
+			if math.Abs(got-tt.expected) > 0.0001 {
+				t.Errorf("WorkingDaysBetween() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseHolidays(t *testing.T) {
+	input := `# Company holidays
+2024-12-25 Christmas Day
+2024-12-26,Boxing Day
+
+2025-01-01
+`
+	holidays, err := ParseHolidays(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseHolidays() error = %v", err)
+	}
+
+	if len(holidays) != 3 {
+		t.Errorf("ParseHolidays() returned %d holidays, want 3", len(holidays))
+	}
+	if !holidays.Contains(time.Date(2024, 12, 26, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2024-12-26 to be a holiday")
+	}
+
+	if _, err := ParseHolidays(strings.NewReader("25/12/2024\n")); err == nil {
+		t.Errorf("ParseHolidays() should fail on malformed dates")
+	}
+}
+
+func TestLoadHolidays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	if err := os.WriteFile(path, []byte("2024-05-20\n"), 0644); err != nil {
+		t.Fatalf("Failed to write holidays file: %v", err)
+	}
+
+	holidays, err := LoadHolidays(path)
+	if err != nil {
+		t.Fatalf("LoadHolidays() error = %v", err)
+	}
+	if !holidays["2024-05-20"] {
+		t.Errorf("Expected 2024-05-20 to be loaded")
+	}
+
+	if _, err := LoadHolidays(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("LoadHolidays() should fail for a missing file")
+	}
+}
@@ -0,0 +1,106 @@
+package filtering
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+)
+
+// DefaultAdHocLabel is the item label that marks ad-hoc requests by default
+const DefaultAdHocLabel = "ad-hoc-request"
+
+// AdHocRules decide which items count as ad-hoc requests. An item matches
+// when any of its labels, its epic's labels or its type is listed; values
+// are compared case-insensitively.
+type AdHocRules struct {
+	Labels     []string // Item labels that mark ad-hoc work
+	EpicLabels []string // Epic labels that mark all items in the epic as ad-hoc
+	Types      []string // Item types that are always ad-hoc, e.g. "support"
+}
+
+// DefaultAdHocRules returns the rules used when none are configured: items
+// labeled "ad-hoc-request"
+func DefaultAdHocRules() AdHocRules {
+	return AdHocRules{Labels: []string{DefaultAdHocLabel}}
+}
+
+// ParseAdHocRules parses comma-separated rules such as
+// "label=urgent,epic-label=support,type=chore". Item labels are only replaced
+// when a label rule is given, so the default "ad-hoc-request" label keeps
+// working alongside epic label and type rules.
+func ParseAdHocRules(s string) (AdHocRules, error) {
+	rules := AdHocRules{}
+	if strings.TrimSpace(s) == "" {
+		return DefaultAdHocRules(), nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		kind, value, ok := strings.Cut(part, "=")
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return AdHocRules{}, fmt.Errorf("invalid ad-hoc rule: %s (must be label=VALUE, epic-label=VALUE or type=VALUE)", part)
+		}
+
+		switch kind {
+		case "label":
+			rules.Labels = append(rules.Labels, value)
+		case "epic-label":
+			rules.EpicLabels = append(rules.EpicLabels, value)
+		case "type":
+			rules.Types = append(rules.Types, value)
+		default:
+			return AdHocRules{}, fmt.Errorf("invalid ad-hoc rule: %s (must be label=VALUE, epic-label=VALUE or type=VALUE)", part)
+		}
+	}
+
+	if len(rules.Labels) == 0 {
+		rules.Labels = DefaultAdHocRules().Labels
+	}
+	return rules, nil
+}
+
+// IsEmpty reports whether no rules are set
+func (r AdHocRules) IsEmpty() bool {
+	return len(r.Labels) == 0 && len(r.EpicLabels) == 0 && len(r.Types) == 0
+}
+
+// Matches reports whether the item is an ad-hoc request under these rules
+func (r AdHocRules) Matches(item models.KanbanItem) bool {
+	return containsFold(r.Labels, item.Labels...) ||
+		containsFold(r.EpicLabels, item.EpicLabels...) ||
+		containsFold(r.Types, item.Type)
+}
+
+// String formats the rules in the form accepted by ParseAdHocRules
+func (r AdHocRules) String() string {
+	var parts []string
+	for _, label := range r.Labels {
+		parts = append(parts, "label="+label)
+	}
+	for _, label := range r.EpicLabels {
+		parts = append(parts, "epic-label="+label)
+	}
+	for _, itemType := range r.Types {
+		parts = append(parts, "type="+itemType)
+	}
+	return strings.Join(parts, ",")
+}
+
+// containsFold reports whether any of values is in list, ignoring case
+func containsFold(list []string, values ...string) bool {
+	for _, value := range values {
+		for _, candidate := range list {
+			if strings.EqualFold(strings.TrimSpace(value), candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
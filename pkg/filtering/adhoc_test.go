@@ -0,0 +1,113 @@
+package filtering
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestParseAdHocRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    AdHocRules
+		wantErr bool
+	}{
+		{
+			name:  "Empty uses defaults",
+			input: "",
+			want:  DefaultAdHocRules(),
+		},
+		{
+			name:  "Epic labels and types keep the default label",
+			input: "epic-label=Support, type=chore",
+			want: AdHocRules{
+				Labels:     []string{"ad-hoc-request"},
+				EpicLabels: []string{"Support"},
+				Types:      []string{"chore"},
+			},
+		},
+		{
+			name:  "Label rules replace the default label",
+			input: "label=urgent,LABEL=interrupt",
+			want:  AdHocRules{Labels: []string{"urgent", "interrupt"}},
+		},
+		{name: "Unknown kind", input: "owner=alice", wantErr: true},
+		{name: "Missing value", input: "type=", wantErr: true},
+		{name: "Missing equals", input: "support", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAdHocRules(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAdHocRules(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAdHocRules(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdHocRules_Matches(t *testing.T) {
+	rules, err := ParseAdHocRules("epic-label=support,type=Chore")
+	if err != nil {
+		t.Fatalf("ParseAdHocRules() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		item     models.KanbanItem
+		expected bool
+	}{
+		{"Default label", models.KanbanItem{Labels: []string{"Ad-Hoc-Request"}}, true},
+		{"Epic label", models.KanbanItem{EpicLabels: []string{"platform", "Support"}}, true},
+		{"Item type", models.KanbanItem{Type: "chore"}, true},
+		{"Epic label used as item label", models.KanbanItem{Labels: []string{"support"}}, false},
+		{"No match", models.KanbanItem{Type: "feature", Labels: []string{"backend"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.Matches(tt.item); got != tt.expected {
+				t.Errorf("Matches() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAdHocRules_String(t *testing.T) {
+	rules := AdHocRules{Labels: []string{"urgent"}, EpicLabels: []string{"support"}, Types: []string{"chore"}}
+	if got := rules.String(); got != "label=urgent,epic-label=support,type=chore" {
+		t.Errorf("String() = %q", got)
+	}
+
+	parsed, err := ParseAdHocRules(rules.String())
+	if err != nil || !reflect.DeepEqual(parsed, rules) {
+		t.Errorf("ParseAdHocRules(String()) = %+v, %v; want %+v", parsed, err, rules)
+	}
+}
+
+func TestFilterItemsByDateRangeWithRules(t *testing.T) {
+	completed := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	items := []models.KanbanItem{
+		{ID: "1", IsCompleted: true, CompletedAt: completed, EpicLabels: []string{"support"}},
+		{ID: "2", IsCompleted: true, CompletedAt: completed, Labels: []string{"ad-hoc-request"}},
+		{ID: "3", IsCompleted: true, CompletedAt: completed},
+	}
+	rules := AdHocRules{Labels: []string{"ad-hoc-request"}, EpicLabels: []string{"support"}}
+
+	only := FilterItemsByDateRangeWithRules(items, time.Time{}, time.Time{}, models.FilterFieldCompletedAt, types.AdHocFilterOnly, rules)
+	if len(only) != 2 {
+		t.Errorf("ad-hoc only returned %d items, expected 2", len(only))
+	}
+
+	excluded := FilterItemsByDateRangeWithRules(items, time.Time{}, time.Time{}, models.FilterFieldCompletedAt, types.AdHocFilterExclude, rules)
+	if len(excluded) != 1 || excluded[0].ID != "3" {
+		t.Errorf("ad-hoc exclude returned %v, expected only item 3", excluded)
+	}
+}
@@ -1,21 +1,15 @@
 package filtering
 
 import (
-	"strings"
 	"time"
 
 	"github.com/hannasdev/kanban-reports/internal/models"
-	"github.com/hannasdev/kanban-reports/pkg/types" // Use types instead of reports
+	"github.com/hannasdev/kanban-reports/pkg/types"
 )
 
 // IsAdHocRequest checks if an item is an ad-hoc request (has "ad-hoc-request" label)
 func IsAdHocRequest(item models.KanbanItem) bool {
-	for _, label := range item.Labels {
-		if strings.ToLower(label) == "ad-hoc-request" {
-			return true
-		}
-	}
-	return false
+	return DefaultAdHocRules().Matches(item)
 }
 
 // FilterItemsByDateRange returns items filtered by the given date range and filter criteria
@@ -23,7 +17,19 @@ func FilterItemsByDateRange(
 	items []models.KanbanItem, 
 	startDate, endDate time.Time, 
 	filterField models.FilterField, 
-	adHocFilter types.AdHocFilterType, // Updated type
+	adHocFilter types.AdHocFilterType,
+) []models.KanbanItem {
+	return FilterItemsByDateRangeWithRules(items, startDate, endDate, filterField, adHocFilter, DefaultAdHocRules())
+}
+
+// FilterItemsByDateRangeWithRules is FilterItemsByDateRange with custom rules
+// for recognizing ad-hoc requests
+func FilterItemsByDateRangeWithRules(
+	items []models.KanbanItem,
+	startDate, endDate time.Time,
+	filterField models.FilterField,
+	adHocFilter types.AdHocFilterType,
+	adHocRules AdHocRules,
 ) []models.KanbanItem {
 	var filtered []models.KanbanItem
 	
@@ -41,16 +47,16 @@ func FilterItemsByDateRange(
 		   (endDate.IsZero() || !itemDate.After(endDate)) {
 			
 			// Apply ad-hoc request filter
-			isAdHoc := IsAdHocRequest(item)
+			isAdHoc := adHocRules.Matches(item)
 			
 			switch adHocFilter {
-			case types.AdHocFilterInclude: // Updated constant
+			case types.AdHocFilterInclude:
 				filtered = append(filtered, item)
-			case types.AdHocFilterExclude: // Updated constant
+			case types.AdHocFilterExclude:
 				if !isAdHoc {
 					filtered = append(filtered, item)
 				}
-			case types.AdHocFilterOnly: // Updated constant
+			case types.AdHocFilterOnly:
 				if isAdHoc {
 					filtered = append(filtered, item)
 				}
@@ -0,0 +1,62 @@
+package filtering
+
+import (
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// IsReopened checks if an item was completed and then reopened (has completed_at but is_completed is false)
+func IsReopened(item models.KanbanItem) bool {
+	return !item.IsCompleted && !item.CompletedAt.IsZero()
+}
+
+// ApplyReopenedPolicy returns the items with reopened items adjusted according to the policy,
+// along with the number of reopened items found
+func ApplyReopenedPolicy(items []models.KanbanItem, policy types.ReopenedPolicy) ([]models.KanbanItem, int) {
+	result := make([]models.KanbanItem, len(items))
+	reopened := 0
+
+	for i, item := range items {
+		if IsReopened(item) {
+			reopened++
+
+			switch policy {
+			case types.ReopenedCountFirstCompletion:
+				item.IsCompleted = true
+			case types.ReopenedCountLast:
+				item.IsCompleted = true
+			}
+		}
+		// Items completed again after reopening count on their last completion
+		if policy == types.ReopenedCountLast && item.IsCompleted {
+			if last := lastCompletion(item); last.After(item.CompletedAt) {
+				item.CompletedAt = last
+			}
+		}
+		result[i] = item
+	}
+
+	return result, reopened
+}
+
+// lastCompletion returns when the item last moved into the state it was in
+// when first completed, from its state history, or the zero time without one
+func lastCompletion(item models.KanbanItem) time.Time {
+	if !item.HasHistory() || item.CompletedAt.IsZero() {
+		return time.Time{}
+	}
+	done := item.StateAt(item.CompletedAt)
+	if done == "" {
+		return time.Time{}
+	}
+
+	var last time.Time
+	for _, transition := range item.History {
+		if transition.To == done && transition.From != done {
+			last = transition.At
+		}
+	}
+	return last
+}
@@ -0,0 +1,90 @@
+package filtering
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+func TestApplyReopenedPolicy(t *testing.T) {
+	completedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	movedAt := time.Date(2024, 5, 14, 12, 0, 0, 0, time.UTC)
+
+	items := []models.KanbanItem{
+		{ID: "1", Name: "Done", IsCompleted: true, CompletedAt: completedAt},
+		{ID: "2", Name: "Reopened", IsCompleted: false, CompletedAt: completedAt, MovedAt: movedAt},
+		{ID: "3", Name: "Open", IsCompleted: false},
+	}
+
+	tests := []struct {
+		name            string
+		policy          types.ReopenedPolicy
+		expectCompleted bool
+		expectDate      time.Time
+	}{
+		{"Exclude", types.ReopenedExclude, false, completedAt},
+		{"Count first completion", types.ReopenedCountFirstCompletion, true, completedAt},
+		{"Count last without a history", types.ReopenedCountLast, true, completedAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, reopened := ApplyReopenedPolicy(items, tt.policy)
+
+			if reopened != 1 {
+				t.Errorf("ApplyReopenedPolicy() reopened = %d, want 1", reopened)
+			}
+			if result[1].IsCompleted != tt.expectCompleted {
+				t.Errorf("Reopened item IsCompleted = %v, want %v", result[1].IsCompleted, tt.expectCompleted)
+			}
+			if !result[1].CompletedAt.Equal(tt.expectDate) {
+				t.Errorf("Reopened item CompletedAt = %v, want %v", result[1].CompletedAt, tt.expectDate)
+			}
+			if result[2].IsCompleted {
+				t.Errorf("Open item should not be marked completed")
+			}
+		})
+	}
+
+	// The input slice is not modified
+	if items[1].IsCompleted {
+		t.Errorf("ApplyReopenedPolicy() should not modify the input items")
+	}
+}
+
+func TestApplyReopenedPolicy_CountLastWithHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 12, 0, 0, 0, time.UTC) }
+	history := []models.StateTransition{
+		{From: "", To: "In Progress", At: day(2)},
+		{From: "In Progress", To: "Done", At: day(10)},
+		{From: "Done", To: "In Progress", At: day(14)}, // Reopened
+		{From: "In Progress", To: "Done", At: day(20)},
+	}
+
+	items := []models.KanbanItem{
+		// Reopened, then completed again after the export's is_completed was read
+		{ID: "1", IsCompleted: false, CompletedAt: day(10), MovedAt: day(14), History: history},
+		// Reopened and completed again, with completed_at kept at the first completion
+		{ID: "2", IsCompleted: true, CompletedAt: day(10), MovedAt: day(20), History: history},
+		// Reopened and still open
+		{ID: "3", IsCompleted: false, CompletedAt: day(10), MovedAt: day(14), History: history[:3]},
+	}
+
+	result, reopened := ApplyReopenedPolicy(items, types.ReopenedCountLast)
+	if reopened != 2 {
+		t.Errorf("ApplyReopenedPolicy() reopened = %d, want 2", reopened)
+	}
+	for i, want := range []time.Time{day(20), day(20), day(10)} {
+		if !result[i].IsCompleted || !result[i].CompletedAt.Equal(want) {
+			t.Errorf("Item %s = completed %v on %v, want completed on %v", result[i].ID, result[i].IsCompleted, result[i].CompletedAt, want)
+		}
+	}
+
+	// Counting the first completion ignores the history
+	first, _ := ApplyReopenedPolicy(items, types.ReopenedCountFirstCompletion)
+	if !first[1].CompletedAt.Equal(day(10)) {
+		t.Errorf("Count first completion CompletedAt = %v, want %v", first[1].CompletedAt, day(10))
+	}
+}
@@ -0,0 +1,36 @@
+package kanban_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/pkg/kanban"
+)
+
+func ExampleReporter_Age() {
+	started := time.Now().AddDate(0, 0, -20)
+	items := []kanban.Item{
+		{ID: "1", Name: "Checkout redesign", State: "Review", StartedAt: started, Source: kanban.Source{File: "board.csv", Row: 1, Line: 2}},
+		{ID: "2", Name: "Search filters", State: "Review", StartedAt: started.AddDate(0, 0, 10)},
+		{ID: "3", Name: "Refund form", State: "In Progress", StartedAt: started.AddDate(0, 0, 15)},
+	}
+
+	// Open items have no completion date, so select them by when they started
+	age, err := kanban.NewReporter(items).WithFilterField(kanban.FilterStartedAt).Age()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, state := range age.States {
+		var calendar kanban.StatsSummary = state.Calendar
+		var oldest kanban.AgedItem = state.Oldest[0]
+		fmt.Printf("%s: %d items, oldest %s", state.Name, calendar.Count, oldest.Name)
+		if oldest.Source != nil {
+			fmt.Printf(" (%s)", oldest.Source)
+		}
+		fmt.Println()
+	}
+	// Output:
+	// In Progress: 1 items, oldest Refund form
+	// Review: 2 items, oldest Checkout redesign (board.csv:2)
+}
@@ -0,0 +1,469 @@
+// Package kanban loads kanban CSV exports and computes the reports and
+// metrics of kanban-reports as typed results, for Go programs that embed the
+// analysis instead of running the command line tool
+package kanban
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/metrics"
+	"github.com/hannasdev/kanban-reports/internal/models"
+	"github.com/hannasdev/kanban-reports/internal/parser"
+	"github.com/hannasdev/kanban-reports/internal/reports"
+	"github.com/hannasdev/kanban-reports/pkg/filtering"
+	"github.com/hannasdev/kanban-reports/pkg/types"
+)
+
+// Item is one story, task or bug of an export
+type Item = models.KanbanItem
+
+// Parts of an item
+type (
+	Source          = models.Source
+	StateTransition = models.StateTransition
+)
+
+// Period groups results over time
+type Period = metrics.PeriodType
+
+// Periods of the throughput and workflow results
+const (
+	PeriodWeek  = metrics.PeriodTypeWeek
+	PeriodMonth = metrics.PeriodTypeMonth
+)
+
+// FilterField is the date of an item that date ranges apply to
+type FilterField = models.FilterField
+
+// Dates that date ranges can apply to
+const (
+	FilterCompletedAt = models.FilterFieldCompletedAt
+	FilterCreatedAt   = models.FilterFieldCreatedAt
+	FilterStartedAt   = models.FilterFieldStartedAt
+)
+
+// Results of the reports, the same as the data of their sections in the JSON
+// output of the command line tool
+type (
+	TotalsResult          = reports.TotalsResult
+	GroupTotal            = reports.GroupTotal
+	WorkloadResult        = reports.WorkloadResult
+	OwnerLoad             = reports.OwnerLoad
+	ContentionResult      = reports.ContentionResult
+	EpicContributorResult = reports.EpicContributorResult
+	TeamMonthResult       = reports.TeamMonthResult
+	DeliveryResult        = reports.DeliveryResult
+	DeliveryGroup         = reports.DeliveryGroup
+	StateResult           = reports.StateResult
+	PivotResult           = reports.PivotResult
+	PivotRow              = reports.PivotRow
+	EpicProgressResult    = reports.EpicProgressResult
+	EpicProgress          = reports.EpicProgress
+	AllocationResult      = reports.AllocationResult
+	OwnerAllocation       = reports.OwnerAllocation
+	AllocationRow         = reports.AllocationRow
+
+	// Parts of the report results
+	ItemDetail           = reports.ItemDetail
+	SizeMix              = reports.SizeMix
+	ContendedContributor = reports.ContendedContributor
+	EpicPair             = reports.EpicPair
+	EpicContributors     = reports.EpicContributors
+	TeamMonths           = reports.TeamMonths
+)
+
+// Results of the metrics
+type (
+	LeadTimeResult    = metrics.LeadTimeResult
+	GroupStats        = metrics.GroupStats
+	ThroughputResult  = metrics.ThroughputResult
+	FlowResult        = metrics.FlowResult
+	EstimationResult  = metrics.EstimationResult
+	AgeResult         = metrics.AgeResult
+	AgingWIPResult    = metrics.AgingWIPResult
+	ImprovementResult = metrics.ImprovementResult
+	WorkflowResult    = metrics.WorkflowResult
+	ForecastResult    = metrics.ForecastResult
+	ForecastSettings  = metrics.ForecastSettings
+
+	// Parts of the metrics results
+	StatsSummary           = metrics.StatsSummary
+	HistogramBucket        = metrics.HistogramBucket
+	CycleTimePercentile    = metrics.CycleTimePercentile
+	ThroughputPeriod       = metrics.ThroughputPeriod
+	ThroughputDistribution = metrics.ThroughputDistribution
+	PeriodAnnotation       = metrics.PeriodAnnotation
+	CapacityAdjustment     = metrics.CapacityAdjustment
+	FlowState              = metrics.FlowState
+	AgeState               = metrics.AgeState
+	AgeThreshold           = metrics.AgeThreshold
+	AgedItem               = metrics.AgedItem
+	AgingBand              = metrics.AgingBand
+	SLAStatus              = metrics.SLAStatus
+	WIPViolation           = metrics.WIPViolation
+	ImprovementMonth       = metrics.ImprovementMonth
+	WorkflowStats          = metrics.WorkflowStats
+	ForecastInterval       = metrics.ForecastInterval
+	DateForecast           = metrics.DateForecast
+	DeliveryLevel          = metrics.DeliveryLevel
+	CompletionForecast     = metrics.CompletionForecast
+	CompletionLevel        = metrics.CompletionLevel
+)
+
+// How an item's age compares with the SLA of its state
+const (
+	SLAGreen  = metrics.SLAGreen
+	SLAYellow = metrics.SLAYellow
+	SLARed    = metrics.SLARed
+)
+
+// DefaultForecastSettings returns the settings the command line tool forecasts with
+func DefaultForecastSettings() ForecastSettings {
+	return metrics.DefaultForecastSettings()
+}
+
+// Load reads the items of one or more CSV exports, detecting each file's
+// delimiter. Like the command line tool, items of several files are merged to
+// one per ID, the most recently updated, and reopened items are left out.
+func Load(paths ...string) ([]Item, error) {
+	return LoadContext(context.Background(), paths...)
+}
+
+// LoadContext reads the exports like Load, stopping with the context's error
+// when it is cancelled or times out
+func LoadContext(ctx context.Context, paths ...string) ([]Item, error) {
+	files := make([]File, len(paths))
+	for i, path := range paths {
+		files[i] = File{Path: path}
+	}
+	return LoadFiles(ctx, files...)
+}
+
+// File is a CSV export to load, with settings for exports whose delimiter or
+// header names differ from the others
+type File struct {
+	Path      string
+	Delimiter string            // comma, tab or semicolon; detected when empty
+	ColumnMap map[string]string // Export column to expected column, e.g. "Summary" to "name"
+}
+
+// LoadFiles reads the exports like LoadContext, parsing each with its own
+// delimiter and column renames
+func LoadFiles(ctx context.Context, files ...File) ([]Item, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no CSV files to load")
+	}
+
+	sources := make([]parser.FileSource, len(files))
+	for i, file := range files {
+		sources[i] = parser.FileSource{Path: file.Path, ColumnMap: models.ColumnMap(file.ColumnMap)}
+		if file.Delimiter != "" {
+			delimiter, err := models.ParseDelimiter(file.Delimiter)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", file.Path, err)
+			}
+			sources[i].Delimiter = delimiter
+		}
+	}
+
+	csvParser := parser.NewCSVParser(files[0].Path).
+		WithDelimiter(models.DelimiterAuto).
+		WithOutput(io.Discard)
+
+	items, err := csvParser.ParseSourcesContext(ctx, sources)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) > 1 {
+		items, _ = parser.MergeDuplicates(items)
+	}
+
+	items, _ = filtering.ApplyReopenedPolicy(items, types.ReopenedExclude)
+	return items, nil
+}
+
+// Reporter computes reports and metrics over a set of items. Its settings
+// default to those of the command line tool: all dates, completed_at, ad-hoc
+// requests included, story points and monthly periods.
+type Reporter struct {
+	items       []Item
+	ctx         context.Context
+	start       time.Time
+	end         time.Time
+	filterField FilterField
+	adHocFilter types.AdHocFilterType
+	unit        types.EstimateUnit
+	period      Period
+	labelPrefix string
+	groupBy     reports.GroupField
+	pivotRows   reports.GroupField
+	pivotCols   reports.GroupField
+	allocateBy  reports.GroupField
+}
+
+// NewReporter creates a reporter over the given items
+func NewReporter(items []Item) *Reporter {
+	return &Reporter{
+		items:       items,
+		ctx:         context.Background(),
+		filterField: FilterCompletedAt,
+		adHocFilter: types.AdHocFilterInclude,
+		unit:        types.UnitPoints,
+		period:      PeriodMonth,
+	}
+}
+
+// WithContext stops the metrics with the context's error when it is cancelled
+func (r *Reporter) WithContext(ctx context.Context) *Reporter {
+	r.ctx = ctx
+	return r
+}
+
+// WithDateRange limits the results to items whose filter field falls between
+// start and end, inclusive; a zero time leaves that side open
+func (r *Reporter) WithDateRange(start, end time.Time) *Reporter {
+	r.start, r.end = start, end
+	return r
+}
+
+// WithFilterField sets the date of an item that the date range applies to
+func (r *Reporter) WithFilterField(field FilterField) *Reporter {
+	r.filterField = field
+	return r
+}
+
+// WithAdHocFilter includes, excludes or keeps only ad-hoc requests
+func (r *Reporter) WithAdHocFilter(filter types.AdHocFilterType) *Reporter {
+	r.adHocFilter = filter
+	return r
+}
+
+// WithUnit sets how estimates are aggregated
+func (r *Reporter) WithUnit(unit types.EstimateUnit) *Reporter {
+	r.unit = unit
+	return r
+}
+
+// WithPeriod sets the period the throughput is grouped by
+func (r *Reporter) WithPeriod(period Period) *Reporter {
+	r.period = period
+	return r
+}
+
+// WithLabelPrefix limits the labels results to labels starting with the
+// prefix, such as "component:"
+func (r *Reporter) WithLabelPrefix(prefix string) *Reporter {
+	r.labelPrefix = prefix
+	return r
+}
+
+// Contributors totals the completed work per contributor
+func (r *Reporter) Contributors() (TotalsResult, error) {
+	return reportResult[TotalsResult](r, reports.ReportTypeContributor)
+}
+
+// Epics totals the completed work per epic
+func (r *Reporter) Epics() (TotalsResult, error) {
+	return reportResult[TotalsResult](r, reports.ReportTypeEpic)
+}
+
+// ProductAreas totals the completed work per product area
+func (r *Reporter) ProductAreas() (TotalsResult, error) {
+	return reportResult[TotalsResult](r, reports.ReportTypeProductArea)
+}
+
+// Teams totals the completed work per team
+func (r *Reporter) Teams() (TotalsResult, error) {
+	return reportResult[TotalsResult](r, reports.ReportTypeTeam)
+}
+
+// Labels totals the completed work per label, counting items toward each of
+// their labels
+func (r *Reporter) Labels() (TotalsResult, error) {
+	return reportResult[TotalsResult](r, reports.ReportTypeLabel)
+}
+
+// Groups totals the completed work by the values of a column, such as
+// "priority", or of a custom field given as "custom:KEY"
+func (r *Reporter) Groups(field string) (TotalsResult, error) {
+	groupBy, err := reports.ParseGroupField(field)
+	if err != nil {
+		return TotalsResult{}, err
+	}
+	return reportResult[TotalsResult](r.withGroupBy(groupBy), reports.ReportTypeGroup)
+}
+
+// Pivot totals the completed work as a cross-tab of the values of two fields,
+// given like the field of Groups, with "month" for the month of completion
+func (r *Reporter) Pivot(rows, cols string) (PivotResult, error) {
+	rowField, err := reports.ParseGroupField(rows)
+	if err != nil {
+		return PivotResult{}, err
+	}
+	colField, err := reports.ParseGroupField(cols)
+	if err != nil {
+		return PivotResult{}, err
+	}
+	return reportResult[PivotResult](r.withPivot(rowField, colField), reports.ReportTypePivot)
+}
+
+// Allocation estimates how each owner's time was divided between the values
+// of a field, given like the field of Groups, such as "epic" or
+// "product_area", as their share of the work they completed in each month
+func (r *Reporter) Allocation(field string) (AllocationResult, error) {
+	allocateBy, err := reports.ParseGroupField(field)
+	if err != nil {
+		return AllocationResult{}, err
+	}
+	return reportResult[AllocationResult](r.withAllocateBy(allocateBy), reports.ReportTypeAllocation)
+}
+
+// Milestones totals the completed work per milestone, against its due date
+func (r *Reporter) Milestones() (DeliveryResult, error) {
+	return reportResult[DeliveryResult](r, reports.ReportTypeMilestone)
+}
+
+// Iterations totals the completed work per iteration
+func (r *Reporter) Iterations() (DeliveryResult, error) {
+	return reportResult[DeliveryResult](r, reports.ReportTypeIteration)
+}
+
+// States totals the open work per workflow state as of now, and the completed
+// work per state it ended in
+func (r *Reporter) States() (StateResult, error) {
+	return reportResult[StateResult](r, reports.ReportTypeState)
+}
+
+// EpicProgress compares each epic's completed work with all of its work as of
+// now, regardless of the date range, and projects when it will finish
+func (r *Reporter) EpicProgress() (EpicProgressResult, error) {
+	return reportResult[EpicProgressResult](r, reports.ReportTypeEpicProgress)
+}
+
+// Workload lists the open work of each owner as of now
+func (r *Reporter) Workload() (WorkloadResult, error) {
+	return reportResult[WorkloadResult](r, reports.ReportTypeWorkload)
+}
+
+// Contention finds people spread over several epics at once
+func (r *Reporter) Contention() (ContentionResult, error) {
+	return reportResult[ContentionResult](r, reports.ReportTypeContention)
+}
+
+// EpicContributors breaks each epic down by who delivered it
+func (r *Reporter) EpicContributors() (EpicContributorResult, error) {
+	return reportResult[EpicContributorResult](r, reports.ReportTypeEpicContributor)
+}
+
+// TeamMonths totals the completed work per team and month
+func (r *Reporter) TeamMonths() (TeamMonthResult, error) {
+	return reportResult[TeamMonthResult](r, reports.ReportTypeTeamMonth)
+}
+
+// LeadTime measures lead and cycle times per estimate size
+func (r *Reporter) LeadTime() (LeadTimeResult, error) {
+	return metricsResult[LeadTimeResult](r, r.generator(), metrics.MetricsTypeLeadTime)
+}
+
+// Throughput counts the completed items per period
+func (r *Reporter) Throughput() (ThroughputResult, error) {
+	return metricsResult[ThroughputResult](r, r.generator(), metrics.MetricsTypeThroughput)
+}
+
+// Flow measures the share of time items were actively worked on
+func (r *Reporter) Flow() (FlowResult, error) {
+	return metricsResult[FlowResult](r, r.generator(), metrics.MetricsTypeFlow)
+}
+
+// Estimation compares estimates to the time items took
+func (r *Reporter) Estimation() (EstimationResult, error) {
+	return metricsResult[EstimationResult](r, r.generator(), metrics.MetricsTypeEstimation)
+}
+
+// Age measures how long the open items have been in progress
+func (r *Reporter) Age() (AgeResult, error) {
+	return metricsResult[AgeResult](r, r.generator(), metrics.MetricsTypeAge)
+}
+
+// AgingWIP compares the age of the open items with the cycle time
+// percentiles of the completed ones
+func (r *Reporter) AgingWIP() (AgingWIPResult, error) {
+	return metricsResult[AgingWIPResult](r, r.generator().WithAgeMode(metrics.AgeModeAgingWIP), metrics.MetricsTypeAge)
+}
+
+// Improvement follows lead time and throughput month over month
+func (r *Reporter) Improvement() (ImprovementResult, error) {
+	return metricsResult[ImprovementResult](r, r.generator(), metrics.MetricsTypeImprovement)
+}
+
+// Workflows compares the workflows of the items side by side
+func (r *Reporter) Workflows() (WorkflowResult, error) {
+	return metricsResult[WorkflowResult](r, r.generator(), metrics.MetricsTypeWorkflow)
+}
+
+// Forecast runs the Monte Carlo forecast of the weekly throughput up to the
+// end of the date range, or today
+func (r *Reporter) Forecast(settings ForecastSettings) (ForecastResult, error) {
+	return metricsResult[ForecastResult](r, r.generator().WithForecast(settings), metrics.MetricsTypeForecast)
+}
+
+// withGroupBy returns a copy of the reporter grouping by the field
+func (r *Reporter) withGroupBy(field reports.GroupField) *Reporter {
+	grouped := *r
+	grouped.groupBy = field
+	return &grouped
+}
+
+// withPivot returns a copy of the reporter with the pivot fields
+func (r *Reporter) withPivot(rows, cols reports.GroupField) *Reporter {
+	pivot := *r
+	pivot.pivotRows = rows
+	pivot.pivotCols = cols
+	return &pivot
+}
+
+// withAllocateBy returns a copy of the reporter with the allocation field
+func (r *Reporter) withAllocateBy(field reports.GroupField) *Reporter {
+	allocated := *r
+	allocated.allocateBy = field
+	return &allocated
+}
+
+// generator creates a metrics generator with the reporter's settings
+func (r *Reporter) generator() *metrics.Generator {
+	return metrics.NewGenerator(r.items).
+		WithContext(r.ctx).
+		WithAdHocFilter(r.adHocFilter).
+		WithUnit(r.unit)
+}
+
+// reportResult computes a report and returns its typed result
+func reportResult[T any](r *Reporter, reportType reports.ReportType) (T, error) {
+	var result T
+	reporter := reports.NewReporter(r.items).
+		WithAdHocFilter(r.adHocFilter).
+		WithUnit(r.unit).
+		WithLabelPrefix(r.labelPrefix).
+		WithGroupBy(r.groupBy).
+		WithPivot(r.pivotRows, r.pivotCols).
+		WithAllocateBy(r.allocateBy)
+	data, err := reporter.Result(reportType, r.start, r.end, r.filterField)
+	if err != nil {
+		return result, err
+	}
+	return data.(T), nil
+}
+
+// metricsResult computes a metrics type with the generator and returns its
+// typed result
+func metricsResult[T any](r *Reporter, generator *metrics.Generator, metricsType metrics.MetricsType) (T, error) {
+	var result T
+	data, err := generator.Result(metricsType, r.period, r.start, r.end, r.filterField)
+	if err != nil {
+		return result, err
+	}
+	return data.(T), nil
+}
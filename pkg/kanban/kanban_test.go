@@ -0,0 +1,179 @@
+package kanban
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hannasdev/kanban-reports/internal/demo"
+)
+
+// loadDemo loads the demo dataset, moved so its latest dates fall last week
+func loadDemo(t *testing.T) ([]Item, string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := demo.WriteFiles(dir, time.Now()); err != nil {
+		t.Fatalf("WriteFiles() error = %v", err)
+	}
+	path := filepath.Join(dir, demo.DatasetFile)
+	items, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return items, path
+}
+
+func TestLoad(t *testing.T) {
+	items, path := loadDemo(t)
+	if len(items) == 0 {
+		t.Fatal("Load() returned no items")
+	}
+
+	// The same export twice merges back to one item per ID
+	merged, err := Load(path, path)
+	if err != nil {
+		t.Fatalf("Load() of two files error = %v", err)
+	}
+	if len(merged) != len(items) {
+		t.Errorf("Load() of the same file twice = %d items, want %d", len(merged), len(items))
+	}
+
+	if _, err := Load(); err == nil {
+		t.Error("Load() without paths should return an error")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("Load() of a missing file should return an error")
+	}
+}
+
+func TestReporter(t *testing.T) {
+	items, _ := loadDemo(t)
+
+	teams, err := NewReporter(items).Teams()
+	if err != nil {
+		t.Fatalf("Teams() error = %v", err)
+	}
+	if len(teams.Groups) != 2 {
+		t.Errorf("Teams() = %d groups, want the 2 teams of the demo", len(teams.Groups))
+	}
+	var amount float64
+	for _, group := range teams.Groups {
+		amount += group.Amount
+	}
+	if amount != teams.Amount || amount == 0 {
+		t.Errorf("Teams() groups add up to %v, total %v", amount, teams.Amount)
+	}
+
+	future, err := NewReporter(items).WithDateRange(time.Now().AddDate(1, 0, 0), time.Time{}).Teams()
+	if err != nil {
+		t.Fatalf("Teams() in the future error = %v", err)
+	}
+	if future.Items != 0 {
+		t.Errorf("Teams() in the future = %d items, want 0", future.Items)
+	}
+
+	leadTime, err := NewReporter(items).LeadTime()
+	if err != nil {
+		t.Fatalf("LeadTime() error = %v", err)
+	}
+	if len(leadTime.LeadTime) == 0 {
+		t.Error("LeadTime() returned no groups")
+	}
+
+	throughput, err := NewReporter(items).WithPeriod(PeriodWeek).Throughput()
+	if err != nil {
+		t.Fatalf("Throughput() error = %v", err)
+	}
+	if len(throughput.Periods) == 0 {
+		t.Error("Throughput() returned no periods")
+	}
+
+	if _, err := NewReporter(items).AgingWIP(); err != nil {
+		t.Errorf("AgingWIP() error = %v", err)
+	}
+}
+
+func TestLoadFiles(t *testing.T) {
+	_, path := loadDemo(t)
+	jira := filepath.Join(t.TempDir(), "jira.csv")
+	content := "id;Summary;estimate;is_completed;completed_at\n" +
+		"JIRA-1;Import;3;true;2024/05/20 10:00:00\n"
+	if err := os.WriteFile(jira, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := LoadFiles(context.Background(), File{Path: path}, File{Path: jira, Delimiter: "semicolon", ColumnMap: map[string]string{"Summary": "name"}})
+	if err != nil {
+		t.Fatalf("LoadFiles() error = %v", err)
+	}
+	last := items[len(items)-1]
+	if last.ID != "JIRA-1" || last.Name != "Import" || last.Estimate != 3 {
+		t.Errorf("LoadFiles() last item = %+v, want JIRA-1 named Import", last)
+	}
+
+	if _, err := LoadFiles(context.Background(), File{Path: jira, Delimiter: "pipe"}); err == nil {
+		t.Error("LoadFiles() with an unknown delimiter should return an error")
+	}
+}
+
+// TestResultTypesArePublic checks that every type reachable from the results
+// has an alias in this package, so programs using it can name all of them
+func TestResultTypesArePublic(t *testing.T) {
+	public := make(map[reflect.Type]bool)
+	for _, value := range []any{
+		Item{}, Source{}, StateTransition{}, FilterField(""), Period(""), File{},
+		TotalsResult{}, GroupTotal{}, WorkloadResult{}, OwnerLoad{}, ContentionResult{}, EpicContributorResult{},
+		TeamMonthResult{}, DeliveryResult{}, DeliveryGroup{}, StateResult{}, PivotResult{}, PivotRow{},
+		EpicProgressResult{}, EpicProgress{}, AllocationResult{}, OwnerAllocation{}, AllocationRow{},
+		ItemDetail{}, SizeMix{}, ContendedContributor{}, EpicPair{}, EpicContributors{}, TeamMonths{},
+		LeadTimeResult{}, GroupStats{}, ThroughputResult{}, FlowResult{}, EstimationResult{}, AgeResult{},
+		AgingWIPResult{}, ImprovementResult{}, WorkflowResult{}, ForecastResult{}, ForecastSettings{},
+		StatsSummary{}, HistogramBucket{}, CycleTimePercentile{}, ThroughputPeriod{}, ThroughputDistribution{},
+		PeriodAnnotation{}, CapacityAdjustment{}, FlowState{}, AgeState{}, AgeThreshold{}, AgedItem{}, AgingBand{},
+		SLAStatus(""), WIPViolation{}, ImprovementMonth{}, WorkflowStats{}, ForecastInterval{}, DateForecast{},
+		DeliveryLevel{}, CompletionForecast{}, CompletionLevel{},
+	} {
+		public[reflect.TypeOf(value)] = true
+	}
+
+	seen := make(map[reflect.Type]bool)
+	var check func(typ reflect.Type)
+	check = func(typ reflect.Type) {
+		if seen[typ] {
+			return
+		}
+		seen[typ] = true
+		if strings.Contains(typ.PkgPath(), "/internal/") && !public[typ] {
+			t.Errorf("%s is part of the results but has no alias in package kanban", typ)
+		}
+		switch typ.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			check(typ.Elem())
+		case reflect.Map:
+			check(typ.Key())
+			check(typ.Elem())
+		case reflect.Struct:
+			for i := 0; i < typ.NumField(); i++ {
+				if typ.Field(i).IsExported() {
+					check(typ.Field(i).Type)
+				}
+			}
+		}
+	}
+
+	reporter := reflect.TypeOf(&Reporter{})
+	for i := 0; i < reporter.NumMethod(); i++ {
+		method := reporter.Method(i).Type
+		for j := 1; j < method.NumIn(); j++ {
+			check(method.In(j))
+		}
+		for j := 0; j < method.NumOut(); j++ {
+			check(method.Out(j))
+		}
+	}
+	check(reflect.TypeOf(Item{}))
+}
@@ -0,0 +1,142 @@
+package terminal
+
+import (
+	"flag"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// ascii reports whether output is restricted to plain ASCII markers
+var ascii bool
+
+// asciiMarkers maps symbols that carry meaning to ASCII equivalents;
+// other emoji are decorative and removed
+var asciiMarkers = strings.NewReplacer(
+	"❌", "[x]",
+	"✅", "[ok]",
+	"⚠️", "[!]",
+	"⚠", "[!]",
+	"🔴", "[red]",
+	"🟡", "[yellow]",
+	"🟢", "[green]",
+	"🔺", "[up]",
+	"🔻", "[down]",
+	"✓", "[v]",
+	"✔", "[v]",
+	"✗", "[x]",
+	"✘", "[x]",
+	"→", "->",
+	"•", "-",
+	"Δ", "+/-",
+	"×", "x",
+	"–", "-",
+)
+
+// SetASCII enables or disables plain ASCII output
+func SetASCII(enabled bool) {
+	ascii = enabled
+}
+
+// ASCII reports whether plain ASCII output is enabled
+func ASCII() bool {
+	return ascii
+}
+
+// ASCIIPreferred reports whether the environment asks for plain output,
+// either through NO_COLOR (see https://no-color.org) or TERM=dumb, or shows
+// output on the legacy Windows console
+func ASCIIPreferred() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return true
+	}
+	return os.Getenv("TERM") == "dumb" || legacyConsole()
+}
+
+// ASCIIMode returns whether to use plain ASCII output: the value of the
+// --ascii flag of fs when it was given, so --ascii=false keeps emoji, and
+// otherwise what the environment prefers
+func ASCIIMode(fs *flag.FlagSet, ascii bool) bool {
+	given := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "ascii" {
+			given = true
+		}
+	})
+	if given {
+		return ascii
+	}
+	return ASCIIPreferred()
+}
+
+// Plain replaces emoji and symbols in s with ASCII markers. Decorative emoji
+// are removed along with the spaces that follow them; other text, such as
+// accented names from the data, is left alone.
+func Plain(s string) string {
+	s = asciiMarkers.Replace(s)
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	skipSpace := false
+	for _, r := range s {
+		if isEmoji(r) {
+			skipSpace = true
+			continue
+		}
+		if skipSpace && r == ' ' {
+			continue
+		}
+		skipSpace = false
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// isEmoji reports whether r is a pictographic symbol or emoji modifier
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // Pictographs, emoticons, transport, symbols
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // Miscellaneous technical (⏰, ⏱)
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // Miscellaneous symbols and arrows
+		return true
+	case r == 0xFE0F || r == 0x200D: // Variation selector and zero width joiner
+		return true
+	}
+	return false
+}
+
+// asciiWriter replaces emoji with ASCII markers in everything written to it
+type asciiWriter struct {
+	w io.Writer
+}
+
+// NewASCIIWriter wraps w so output written to it uses plain ASCII markers.
+// Each Write should hold whole characters, as fmt's print functions do.
+func NewASCIIWriter(w io.Writer) io.Writer {
+	return &asciiWriter{w: w}
+}
+
+// Write converts p to plain markers and writes it to the wrapped writer
+func (a *asciiWriter) Write(p []byte) (int, error) {
+	if utf8.Valid(p) {
+		if _, err := io.WriteString(a.w, Plain(string(p))); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	return a.w.Write(p)
+}
+
+// Stdout returns standard output, converted to ASCII markers when plain
+// ASCII output is enabled
+func Stdout() io.Writer {
+	if ascii {
+		return NewASCIIWriter(os.Stdout)
+	}
+	return os.Stdout
+}
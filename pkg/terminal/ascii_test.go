@@ -0,0 +1,102 @@
+package terminal
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPlain(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"❌ Error: file not found", "[x] Error: file not found"},
+		{"✅ Loaded 5 kanban items", "[ok] Loaded 5 kanban items"},
+		{"⚠️  Warning: odd file", "[!]  Warning: odd file"},
+		{"   📅 Date Range: last 30 days", "   Date Range: last 30 days"},
+		{"1. ⏱️  Lead Time", "1. Lead Time"},
+		{"🔴 Stuck item (12.0 days)", "[red] Stuck item (12.0 days)"},
+		{"Project → Epic → Item", "Project -> Epic -> Item"},
+		{"   • Try --last 30", "   - Try --last 30"},
+		{"Lead Time Δ", "Lead Time +/-"},
+		{"José Müller", "José Müller"},
+	}
+
+	for _, tt := range tests {
+		if got := Plain(tt.input); got != tt.want {
+			t.Errorf("Plain(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestASCIIWriter(t *testing.T) {
+	var sb strings.Builder
+	w := NewASCIIWriter(&sb)
+
+	n, err := w.Write([]byte("🎉 Done ✅\n"))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if n != len("🎉 Done ✅\n") {
+		t.Errorf("Write() = %d, want the length of the input", n)
+	}
+	if got := sb.String(); got != "Done [ok]\n" {
+		t.Errorf("written = %q, want %q", got, "Done [ok]\n")
+	}
+}
+
+func TestASCIIMode(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{"flag left out follows the environment", nil, true},
+		{"flag given", []string{"--ascii"}, true},
+		{"flag turned off overrides the environment", []string{"--ascii=false"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			ascii := fs.Bool("ascii", false, "")
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if got := ASCIIMode(fs, *ascii); got != tt.want {
+				t.Errorf("ASCIIMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestASCIIPreferred(t *testing.T) {
+	tests := []struct {
+		name    string
+		noColor bool
+		term    string
+		want    bool
+	}{
+		{"plain terminal", false, "xterm-256color", false},
+		{"NO_COLOR set", true, "xterm-256color", true},
+		{"dumb terminal", false, "dumb", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TERM", tt.term)
+			// Setenv restores the original value; unset it for this case
+			t.Setenv("NO_COLOR", "")
+			if !tt.noColor {
+				os.Unsetenv("NO_COLOR")
+			}
+			if got := ASCIIPreferred(); got != tt.want {
+				t.Errorf("ASCIIPreferred() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
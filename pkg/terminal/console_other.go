@@ -0,0 +1,9 @@
+//go:build !windows
+
+package terminal
+
+// legacyConsole reports whether stdout is a console that can't show emoji,
+// which is only the case on Windows
+func legacyConsole() bool {
+	return false
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package terminal
+
+import (
+	"os"
+	"syscall"
+)
+
+// legacyConsole reports whether stdout is the Windows console host, which
+// shows emoji as boxes or question marks. Windows Terminal, ConEmu and the
+// terminals of editors render them.
+func legacyConsole() bool {
+	var mode uint32
+	if err := syscall.GetConsoleMode(syscall.Handle(os.Stdout.Fd()), &mode); err != nil {
+		return false // Redirected to a file or pipe
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("TERM_PROGRAM") != "" || os.Getenv("ConEmuANSI") == "ON" {
+		return false
+	}
+	return true
+}
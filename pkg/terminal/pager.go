@@ -0,0 +1,79 @@
+package terminal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Height returns the height in rows of the terminal attached to stdout, or 0
+// when stdout is not a terminal, e.g. when output is piped or redirected
+func Height() int {
+	return stdoutHeight()
+}
+
+// Pager shows long text a screen at a time on a terminal
+type Pager struct {
+	scanner *bufio.Scanner
+	writer  io.Writer
+	height  int
+}
+
+// NewPager creates a pager for a terminal of the given height, reading
+// keypresses, one per line, from reader
+func NewPager(reader io.Reader, writer io.Writer, height int) *Pager {
+	return &Pager{
+		scanner: bufio.NewScanner(reader),
+		writer:  writer,
+		height:  height,
+	}
+}
+
+// Lines returns the number of lines text takes up
+func (p *Pager) Lines(text string) int {
+	return len(pageLines(text))
+}
+
+// Fits reports whether text fits on one screen, so it needs no paging
+func (p *Pager) Fits(text string) bool {
+	return p.Lines(text) < p.height
+}
+
+// Page writes text a screen at a time. The last row of each screen is a
+// prompt: Enter shows the next screen, "a" the rest of the text, and "q"
+// stops. When the input ends the rest of the text is shown.
+func (p *Pager) Page(text string) {
+	lines := pageLines(text)
+	screen := max(p.height-1, 1)
+
+	for shown := 0; shown < len(lines); {
+		end := min(shown+screen, len(lines))
+		for _, line := range lines[shown:end] {
+			fmt.Fprintln(p.writer, line)
+		}
+		shown = end
+		if shown == len(lines) {
+			return
+		}
+
+		fmt.Fprintf(p.writer, "-- %d of %d lines -- Enter: next page, a: all, q: quit ", shown, len(lines))
+		if !p.scanner.Scan() {
+			fmt.Fprintln(p.writer)
+			screen = len(lines)
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(p.scanner.Text())) {
+		case "q":
+			fmt.Fprintf(p.writer, "[%d more lines not shown]\n", len(lines)-shown)
+			return
+		case "a":
+			screen = len(lines)
+		}
+	}
+}
+
+// pageLines splits text into the lines it shows on screen
+func pageLines(text string) []string {
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,64 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numberedLines returns n lines of text, "line 01" to "line n"
+func numberedLines(n int) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "line %02d\n", i)
+	}
+	return b.String()
+}
+
+func TestPager_Fits(t *testing.T) {
+	pager := NewPager(strings.NewReader(""), &strings.Builder{}, 10)
+	if !pager.Fits(numberedLines(9)) {
+		t.Error("9 lines should fit on a 10-row screen")
+	}
+	if pager.Fits(numberedLines(10)) {
+		t.Error("10 lines shouldn't fit on a 10-row screen, which needs a row for the prompt")
+	}
+	if got := pager.Lines(numberedLines(25)); got != 25 {
+		t.Errorf("Lines() = %d, want 25", got)
+	}
+}
+
+func TestPager_Page(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     []string
+		notShown string
+		prompts  int
+	}{
+		{"Screen by screen", "\n\n", []string{"line 01", "line 25"}, "", 2},
+		{"All after the first screen", "a\n", []string{"line 25"}, "", 1},
+		{"Quit after the first screen", "q\n", []string{"line 11", "[14 more lines not shown]"}, "line 12", 1},
+		{"Input ends", "", []string{"line 25"}, "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			NewPager(strings.NewReader(tt.input), &out, 12).Page(numberedLines(25))
+
+			output := out.String()
+			for _, str := range tt.want {
+				if !strings.Contains(output, str) {
+					t.Errorf("Output doesn't contain expected string: %q\nGot:\n%s", str, output)
+				}
+			}
+			if tt.notShown != "" && strings.Contains(output, tt.notShown) {
+				t.Errorf("Output shouldn't contain %q\nGot:\n%s", tt.notShown, output)
+			}
+			if got := strings.Count(output, "Enter: next page"); got != tt.prompts {
+				t.Errorf("Pager prompted %d times, want %d\nGot:\n%s", got, tt.prompts, output)
+			}
+		})
+	}
+}
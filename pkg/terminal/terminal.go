@@ -0,0 +1,34 @@
+// Package terminal provides information about the terminal output is written to
+package terminal
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Width returns the width in columns of the terminal attached to stdout.
+// When stdout is not a terminal the COLUMNS environment variable is used,
+// and 0 is returned when the width is unknown.
+func Width() int {
+	if width := stdoutWidth(); width > 0 {
+		return width
+	}
+	return columnsEnv()
+}
+
+// columnsEnv returns the width set in the COLUMNS environment variable
+func columnsEnv() int {
+	width, err := strconv.Atoi(strings.TrimSpace(os.Getenv("COLUMNS")))
+	if err != nil || width < 0 {
+		return 0
+	}
+	return width
+}
+
+// StdinIsTerminal reports whether stdin is a terminal someone can answer
+// questions on, rather than a pipe or a file
+func StdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,30 @@
+package terminal
+
+import "testing"
+
+func TestColumnsEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"120", 120},
+		{" 80 ", 80},
+		{"", 0},
+		{"wide", 0},
+		{"-5", 0},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("COLUMNS", tt.value)
+		if got := columnsEnv(); got != tt.want {
+			t.Errorf("columnsEnv() with COLUMNS=%q = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWidth_NotNegative(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	if got := Width(); got < 0 {
+		t.Errorf("Width() = %d, want 0 or more", got)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package terminal
+
+// stdoutWidth is not supported on this platform; COLUMNS is used instead
+func stdoutWidth() int {
+	return 0
+}
+
+// stdoutHeight is not supported on this platform, so output is never paged
+func stdoutHeight() int {
+	return 0
+}
@@ -0,0 +1,40 @@
+//go:build linux || darwin
+
+package terminal
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize mirrors the kernel structure filled by TIOCGWINSZ
+type winsize struct {
+	Rows    uint16
+	Cols    uint16
+	XPixels uint16
+	YPixels uint16
+}
+
+// stdoutSize asks the kernel for the size of the terminal on stdout
+func stdoutSize() (cols, rows int) {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdout.Fd(),
+		uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0, 0
+	}
+	return int(ws.Cols), int(ws.Rows)
+}
+
+// stdoutWidth asks the kernel for the width of the terminal on stdout
+func stdoutWidth() int {
+	cols, _ := stdoutSize()
+	return cols
+}
+
+// stdoutHeight asks the kernel for the height of the terminal on stdout
+func stdoutHeight() int {
+	_, rows := stdoutSize()
+	return rows
+}
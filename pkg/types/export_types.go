@@ -0,0 +1,29 @@
+package types
+
+import "fmt"
+
+// ExportType defines a raw data export written instead of reports and metrics
+type ExportType string
+
+const (
+	// ExportItemsJSON writes one JSON object per item with its computed measures
+	ExportItemsJSON ExportType = "items-json"
+)
+
+// IsValid checks if an ExportType is valid
+func (e ExportType) IsValid() bool {
+	switch e {
+	case ExportItemsJSON:
+		return true
+	}
+	return false
+}
+
+// ParseExportType converts a string to an ExportType with validation
+func ParseExportType(s string) (ExportType, error) {
+	e := ExportType(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid export: %s (must be one of: items-json)", s)
+	}
+	return e, nil
+}
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseExportType(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  ExportType
+		expectErr bool
+	}{
+		{"Valid items-json", "items-json", ExportItemsJSON, false},
+		{"Invalid export", "items-csv", ExportType(""), true},
+		{"Empty", "", ExportType(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExportType(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ParseExportType() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("ParseExportType() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -2,7 +2,8 @@ package types
 
 import "fmt"
 
-// AdHocFilterType defines how to handle ad-hoc requests
+// AdHocFilterType defines how to handle ad-hoc requests. It is the one type
+// for the filter across the reports, metrics and filtering packages.
 type AdHocFilterType string
 
 const (
@@ -0,0 +1,39 @@
+package types
+
+import "fmt"
+
+// OutputFormat defines how reports and metrics are rendered
+type OutputFormat string
+
+const (
+	// FormatText renders plain text tables (default)
+	FormatText OutputFormat = "text"
+	// FormatJSON renders structured JSON for other tools
+	FormatJSON OutputFormat = "json"
+	// FormatMarkdown renders a Markdown document with tables
+	FormatMarkdown OutputFormat = "markdown"
+	// FormatHTML renders a styled HTML page to share with stakeholders
+	FormatHTML OutputFormat = "html"
+	// FormatProblems lists the data-quality findings as file:line: message for editors
+	FormatProblems OutputFormat = "problems"
+	// FormatSVG draws the cycle time scatterplot as a standalone SVG image
+	FormatSVG OutputFormat = "svg"
+)
+
+// IsValid checks if an OutputFormat is valid
+func (f OutputFormat) IsValid() bool {
+	switch f {
+	case FormatText, FormatJSON, FormatMarkdown, FormatHTML, FormatProblems, FormatSVG:
+		return true
+	}
+	return false
+}
+
+// ParseOutputFormat converts a string to an OutputFormat with validation
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	f := OutputFormat(s)
+	if !f.IsValid() {
+		return "", fmt.Errorf("invalid output format: %s (must be one of: text, json, markdown, html, problems, svg)", s)
+	}
+	return f, nil
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  OutputFormat
+		expectErr bool
+	}{
+		{"Valid text", "text", FormatText, false},
+		{"Valid json", "json", FormatJSON, false},
+		{"Valid markdown", "markdown", FormatMarkdown, false},
+		{"Valid html", "html", FormatHTML, false},
+		{"Valid problems", "problems", FormatProblems, false},
+		{"Valid svg", "svg", FormatSVG, false},
+		{"Invalid format", "xml", OutputFormat(""), true},
+		{"Case sensitive", "JSON", OutputFormat(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOutputFormat(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ParseOutputFormat() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("ParseOutputFormat() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
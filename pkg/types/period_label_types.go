@@ -0,0 +1,50 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// PeriodLabels defines how the weeks and months of trend tables are labeled
+type PeriodLabels string
+
+const (
+	// PeriodLabelsISO labels periods as 2024-05 and 2024-W20, which sort in order (default)
+	PeriodLabelsISO PeriodLabels = "iso"
+	// PeriodLabelsFriendly labels periods as May 2024 and Week 20, 2024
+	PeriodLabelsFriendly PeriodLabels = "friendly"
+)
+
+// IsValid checks if a PeriodLabels is valid
+func (l PeriodLabels) IsValid() bool {
+	switch l {
+	case PeriodLabelsISO, PeriodLabelsFriendly:
+		return true
+	}
+	return false
+}
+
+// ParsePeriodLabels converts a string to a PeriodLabels with validation
+func ParsePeriodLabels(s string) (PeriodLabels, error) {
+	l := PeriodLabels(s)
+	if !l.IsValid() {
+		return "", fmt.Errorf("invalid period labels: %s (must be one of: friendly, iso)", s)
+	}
+	return l, nil
+}
+
+// Label returns the label of a period given as 2024-05 or 2024-W20. Labels
+// that are neither are returned unchanged.
+func (l PeriodLabels) Label(period string) string {
+	if l != PeriodLabelsFriendly {
+		return period
+	}
+	var year, week int
+	if n, err := fmt.Sscanf(period, "%4d-W%2d", &year, &week); err == nil && n == 2 {
+		return fmt.Sprintf("Week %d, %d", week, year)
+	}
+	if month, err := time.Parse("2006-01", period); err == nil {
+		return month.Format("January 2006")
+	}
+	return period
+}
@@ -0,0 +1,52 @@
+package types
+
+import "testing"
+
+func TestParsePeriodLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  PeriodLabels
+		expectErr bool
+	}{
+		{"Valid iso", "iso", PeriodLabelsISO, false},
+		{"Valid friendly", "friendly", PeriodLabelsFriendly, false},
+		{"Invalid labels", "long", PeriodLabels(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePeriodLabels(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ParsePeriodLabels() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("ParsePeriodLabels() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPeriodLabels_Label(t *testing.T) {
+	tests := []struct {
+		labels   PeriodLabels
+		period   string
+		expected string
+	}{
+		{PeriodLabelsFriendly, "2024-05", "May 2024"},
+		{PeriodLabelsFriendly, "2024-W20", "Week 20, 2024"},
+		{PeriodLabelsFriendly, "2025-W01", "Week 1, 2025"},
+		{PeriodLabelsFriendly, "Unspecified", "Unspecified"},
+		{PeriodLabelsISO, "2024-05", "2024-05"},
+		{PeriodLabels(""), "2024-W20", "2024-W20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.labels)+" "+tt.period, func(t *testing.T) {
+			if got := tt.labels.Label(tt.period); got != tt.expected {
+				t.Errorf("Label(%q) = %q, want %q", tt.period, got, tt.expected)
+			}
+		})
+	}
+}
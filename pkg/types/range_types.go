@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateRangePreset names a calendar date range relative to today
+type DateRangePreset string
+
+const (
+	// RangeThisWeek runs from the start of the current week to today
+	RangeThisWeek DateRangePreset = "this-week"
+	// RangeLastWeek is the whole week before the current one
+	RangeLastWeek DateRangePreset = "last-week"
+	// RangeThisMonth runs from the first of the current month to today
+	RangeThisMonth DateRangePreset = "this-month"
+	// RangeLastMonth is the whole month before the current one
+	RangeLastMonth DateRangePreset = "last-month"
+	// RangeThisQuarter runs from the start of the current quarter to today
+	RangeThisQuarter DateRangePreset = "this-quarter"
+	// RangeLastQuarter is the whole quarter before the current one
+	RangeLastQuarter DateRangePreset = "last-quarter"
+	// RangeYearToDate runs from January 1 to today
+	RangeYearToDate DateRangePreset = "ytd"
+)
+
+// IsValid checks if a DateRangePreset is valid
+func (p DateRangePreset) IsValid() bool {
+	switch p {
+	case RangeThisWeek, RangeLastWeek, RangeThisMonth, RangeLastMonth, RangeThisQuarter, RangeLastQuarter, RangeYearToDate:
+		return true
+	}
+	return false
+}
+
+// ParseDateRangePreset converts a string to a DateRangePreset with validation
+func ParseDateRangePreset(s string) (DateRangePreset, error) {
+	p := DateRangePreset(s)
+	if !p.IsValid() {
+		return "", fmt.Errorf("invalid date range: %s (must be one of: this-week, last-week, this-month, last-month, this-quarter, last-quarter, ytd)", s)
+	}
+	return p, nil
+}
+
+// Resolve returns the first and last day of the range, both at midnight, for
+// the given day. Weeks start the way numbering says.
+func (p DateRangePreset) Resolve(today time.Time, numbering WeekNumbering) (first, last time.Time) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	month := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	quarter := month.AddDate(0, -int(today.Month()-1)%3, 0)
+
+	switch p {
+	case RangeThisWeek:
+		return numbering.StartOfWeek(today), today
+	case RangeLastWeek:
+		start := numbering.StartOfWeek(today).AddDate(0, 0, -7)
+		return start, start.AddDate(0, 0, 6)
+	case RangeThisMonth:
+		return month, today
+	case RangeLastMonth:
+		return month.AddDate(0, -1, 0), month.AddDate(0, 0, -1)
+	case RangeThisQuarter:
+		return quarter, today
+	case RangeLastQuarter:
+		return quarter.AddDate(0, -3, 0), quarter.AddDate(0, 0, -1)
+	default: // RangeYearToDate
+		return time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, today.Location()), today
+	}
+}
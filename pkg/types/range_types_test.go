@@ -0,0 +1,89 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateRangePreset(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  DateRangePreset
+		expectErr bool
+	}{
+		{"Valid this-week", "this-week", RangeThisWeek, false},
+		{"Valid last-quarter", "last-quarter", RangeLastQuarter, false},
+		{"Valid ytd", "ytd", RangeYearToDate, false},
+		{"Invalid preset", "last-year", DateRangePreset(""), true},
+		{"Empty preset", "", DateRangePreset(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateRangePreset(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ParseDateRangePreset() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("ParseDateRangePreset() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDateRangePreset_Resolve(t *testing.T) {
+	// Wednesday, May 15, 2024, in the second quarter
+	today := time.Date(2024, 5, 15, 16, 45, 0, 0, time.UTC)
+	day := func(month time.Month, d int) string {
+		return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	}
+
+	tests := []struct {
+		preset    DateRangePreset
+		numbering WeekNumbering
+		wantFirst string
+		wantLast  string
+	}{
+		{RangeThisWeek, WeekNumberingISO, day(5, 13), day(5, 15)},
+		{RangeThisWeek, WeekNumberingUS, day(5, 12), day(5, 15)},
+		{RangeLastWeek, WeekNumberingISO, day(5, 6), day(5, 12)},
+		{RangeLastWeek, WeekNumberingUS, day(5, 5), day(5, 11)},
+		{RangeThisMonth, WeekNumberingISO, day(5, 1), day(5, 15)},
+		{RangeLastMonth, WeekNumberingISO, day(4, 1), day(4, 30)},
+		{RangeThisQuarter, WeekNumberingISO, day(4, 1), day(5, 15)},
+		{RangeLastQuarter, WeekNumberingISO, day(1, 1), day(3, 31)},
+		{RangeYearToDate, WeekNumberingISO, day(1, 1), day(5, 15)},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.preset)+"/"+string(tt.numbering), func(t *testing.T) {
+			first, last := tt.preset.Resolve(today, tt.numbering)
+			if got := first.Format("2006-01-02"); got != tt.wantFirst {
+				t.Errorf("first = %s, want %s", got, tt.wantFirst)
+			}
+			if got := last.Format("2006-01-02"); got != tt.wantLast {
+				t.Errorf("last = %s, want %s", got, tt.wantLast)
+			}
+			if first.Hour() != 0 || last.Hour() != 0 {
+				t.Errorf("Resolve() = %v, %v, want midnight", first, last)
+			}
+		})
+	}
+}
+
+func TestDateRangePreset_ResolveAcrossYears(t *testing.T) {
+	// January 10, 2025: the previous month and quarter are in 2024
+	today := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	first, last := RangeLastMonth.Resolve(today, WeekNumberingISO)
+	if first.Format("2006-01-02") != "2024-12-01" || last.Format("2006-01-02") != "2024-12-31" {
+		t.Errorf("last-month = %s..%s, want 2024-12-01..2024-12-31", first.Format("2006-01-02"), last.Format("2006-01-02"))
+	}
+
+	first, last = RangeLastQuarter.Resolve(today, WeekNumberingISO)
+	if first.Format("2006-01-02") != "2024-10-01" || last.Format("2006-01-02") != "2024-12-31" {
+		t.Errorf("last-quarter = %s..%s, want 2024-10-01..2024-12-31", first.Format("2006-01-02"), last.Format("2006-01-02"))
+	}
+}
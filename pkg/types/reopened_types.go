@@ -0,0 +1,34 @@
+package types
+
+import "fmt"
+
+// ReopenedPolicy defines how items with a completed_at date that are no longer completed are counted
+type ReopenedPolicy string
+
+const (
+	// ReopenedExclude leaves reopened items out of completion-based reports (default)
+	ReopenedExclude ReopenedPolicy = "exclude"
+	// ReopenedCountFirstCompletion counts reopened items as completed on their recorded completed_at date
+	ReopenedCountFirstCompletion ReopenedPolicy = "count-first-completion"
+	// ReopenedCountLast counts reopened items as completed, on their last move
+	// into the done state when a state history shows they were completed again
+	ReopenedCountLast ReopenedPolicy = "count-last"
+)
+
+// IsValid checks if a ReopenedPolicy is valid
+func (rp ReopenedPolicy) IsValid() bool {
+	switch rp {
+	case ReopenedExclude, ReopenedCountFirstCompletion, ReopenedCountLast:
+		return true
+	}
+	return false
+}
+
+// ParseReopenedPolicy converts a string to a ReopenedPolicy with validation
+func ParseReopenedPolicy(s string) (ReopenedPolicy, error) {
+	rp := ReopenedPolicy(s)
+	if !rp.IsValid() {
+		return "", fmt.Errorf("invalid reopened policy: %s (must be one of: exclude, count-first-completion, count-last)", s)
+	}
+	return rp, nil
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseReopenedPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  ReopenedPolicy
+		expectErr bool
+	}{
+		{"Valid exclude", "exclude", ReopenedExclude, false},
+		{"Valid count-first-completion", "count-first-completion", ReopenedCountFirstCompletion, false},
+		{"Valid count-last", "count-last", ReopenedCountLast, false},
+		{"Invalid policy", "include", ReopenedPolicy(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseReopenedPolicy(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ParseReopenedPolicy() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("ParseReopenedPolicy() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
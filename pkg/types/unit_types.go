@@ -0,0 +1,101 @@
+package types
+
+import "fmt"
+
+// EstimateUnit defines what the estimate column measures
+type EstimateUnit string
+
+const (
+	// UnitPoints treats estimates as story points (default)
+	UnitPoints EstimateUnit = "points"
+	// UnitHours treats estimates as hours of effort
+	UnitHours EstimateUnit = "hours"
+	// UnitItems ignores estimates and counts every item as 1
+	UnitItems EstimateUnit = "items"
+)
+
+// IsValid checks if an EstimateUnit is valid
+func (u EstimateUnit) IsValid() bool {
+	switch u {
+	case UnitPoints, UnitHours, UnitItems:
+		return true
+	}
+	return false
+}
+
+// ParseEstimateUnit converts a string to an EstimateUnit with validation
+func ParseEstimateUnit(s string) (EstimateUnit, error) {
+	u := EstimateUnit(s)
+	if !u.IsValid() {
+		return "", fmt.Errorf("invalid unit: %s (must be one of: points, hours, items)", s)
+	}
+	return u, nil
+}
+
+// Value returns the amount an item contributes to totals for this unit
+func (u EstimateUnit) Value(estimate float64) float64 {
+	if u == UnitItems {
+		return 1
+	}
+	return estimate
+}
+
+// Label returns the lower-case plural used after amounts, e.g. "5.0 points"
+func (u EstimateUnit) Label() string {
+	switch u {
+	case UnitHours:
+		return "hours"
+	case UnitItems:
+		return "items"
+	}
+	return "points"
+}
+
+// Title returns the heading used for totals, e.g. "Story Points by Team"
+func (u EstimateUnit) Title() string {
+	switch u {
+	case UnitHours:
+		return "Hours"
+	case UnitItems:
+		return "Items"
+	}
+	return "Story Points"
+}
+
+// ColumnTitle returns the short capitalised name used in table columns, e.g. "Avg Points/Item"
+func (u EstimateUnit) ColumnTitle() string {
+	switch u {
+	case UnitHours:
+		return "Hours"
+	case UnitItems:
+		return "Items"
+	}
+	return "Points"
+}
+
+// SizeLabel returns the label for tables keyed by estimate size
+func (u EstimateUnit) SizeLabel() string {
+	switch u {
+	case UnitHours:
+		return "Hours"
+	case UnitItems:
+		return "Estimate"
+	}
+	return "Story points"
+}
+
+// Abbrev returns the abbreviation used in rates, e.g. "Days/SP"
+func (u EstimateUnit) Abbrev() string {
+	switch u {
+	case UnitHours:
+		return "h"
+	case UnitItems:
+		return "item"
+	}
+	return "SP"
+}
+
+// CountsItems reports whether totals are plain item counts, making separate estimate columns redundant
+func (u EstimateUnit) CountsItems() bool {
+	return u == UnitItems
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseEstimateUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  EstimateUnit
+		expectErr bool
+	}{
+		{"Valid points", "points", UnitPoints, false},
+		{"Valid hours", "hours", UnitHours, false},
+		{"Valid items", "items", UnitItems, false},
+		{"Invalid unit", "days", EstimateUnit(""), true},
+		{"Empty string", "", EstimateUnit(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEstimateUnit(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ParseEstimateUnit() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("ParseEstimateUnit() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEstimateUnit_Value(t *testing.T) {
+	if got := UnitPoints.Value(5); got != 5 {
+		t.Errorf("UnitPoints.Value(5) = %v, want 5", got)
+	}
+	if got := UnitHours.Value(4.5); got != 4.5 {
+		t.Errorf("UnitHours.Value(4.5) = %v, want 4.5", got)
+	}
+	if got := UnitItems.Value(8); got != 1 {
+		t.Errorf("UnitItems.Value(8) = %v, want 1", got)
+	}
+}
+
+func TestEstimateUnit_Labels(t *testing.T) {
+	tests := []struct {
+		unit   EstimateUnit
+		label  string
+		title  string
+		abbrev string
+	}{
+		{UnitPoints, "points", "Story Points", "SP"},
+		{UnitHours, "hours", "Hours", "h"},
+		{UnitItems, "items", "Items", "item"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.unit.Label(); got != tt.label {
+			t.Errorf("%s.Label() = %q, want %q", tt.unit, got, tt.label)
+		}
+		if got := tt.unit.Title(); got != tt.title {
+			t.Errorf("%s.Title() = %q, want %q", tt.unit, got, tt.title)
+		}
+		if got := tt.unit.Abbrev(); got != tt.abbrev {
+			t.Errorf("%s.Abbrev() = %q, want %q", tt.unit, got, tt.abbrev)
+		}
+	}
+}
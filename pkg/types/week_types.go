@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// WeekNumbering defines where weeks start and how they are numbered
+type WeekNumbering string
+
+const (
+	// WeekNumberingISO starts weeks on Monday; week 1 holds the year's first Thursday (default)
+	WeekNumberingISO WeekNumbering = "iso"
+	// WeekNumberingUS starts weeks on Sunday; week 1 holds January 1
+	WeekNumberingUS WeekNumbering = "us"
+)
+
+// IsValid checks if a WeekNumbering is valid
+func (n WeekNumbering) IsValid() bool {
+	switch n {
+	case WeekNumberingISO, WeekNumberingUS:
+		return true
+	}
+	return false
+}
+
+// ParseWeekNumbering converts a string to a WeekNumbering with validation
+func ParseWeekNumbering(s string) (WeekNumbering, error) {
+	n := WeekNumbering(s)
+	if !n.IsValid() {
+		return "", fmt.Errorf("invalid week numbering: %s (must be one of: iso, us)", s)
+	}
+	return n, nil
+}
+
+// StartOfWeek returns midnight on the first day of the week containing date
+func (n WeekNumbering) StartOfWeek(date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	if n == WeekNumberingUS {
+		return day.AddDate(0, 0, -int(day.Weekday()))
+	}
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// Week returns the year and number of the week containing date. A US week
+// spanning New Year belongs to the new year, so every week has one label.
+func (n WeekNumbering) Week(date time.Time) (year, week int) {
+	if n != WeekNumberingUS {
+		return date.ISOWeek()
+	}
+	start := n.StartOfWeek(date)
+	year = start.AddDate(0, 0, 6).Year()
+	firstWeek := n.StartOfWeek(time.Date(year, time.January, 1, 0, 0, 0, 0, date.Location()))
+	// Days between midnights, rounded in case of a daylight saving change
+	days := int(start.Sub(firstWeek).Hours()/24 + 0.5)
+	return year, days/7 + 1
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWeekNumbering(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  WeekNumbering
+		expectErr bool
+	}{
+		{"Valid iso", "iso", WeekNumberingISO, false},
+		{"Valid us", "us", WeekNumberingUS, false},
+		{"Invalid numbering", "uk", WeekNumbering(""), true},
+		{"Case sensitive", "ISO", WeekNumbering(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWeekNumbering(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ParseWeekNumbering() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("ParseWeekNumbering() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWeekNumbering_StartOfWeek(t *testing.T) {
+	// Wednesday, May 15, 2024
+	date := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+
+	if got, want := WeekNumberingISO.StartOfWeek(date), time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ISO StartOfWeek() = %v, want Monday %v", got, want)
+	}
+	if got, want := WeekNumberingUS.StartOfWeek(date), time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("US StartOfWeek() = %v, want Sunday %v", got, want)
+	}
+
+	// A Sunday ends an ISO week but starts a US one
+	sunday := time.Date(2024, 5, 19, 9, 0, 0, 0, time.UTC)
+	if got, want := WeekNumberingISO.StartOfWeek(sunday), time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ISO StartOfWeek(Sunday) = %v, want %v", got, want)
+	}
+	if got, want := WeekNumberingUS.StartOfWeek(sunday), time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("US StartOfWeek(Sunday) = %v, want %v", got, want)
+	}
+}
+
+func TestWeekNumbering_Week(t *testing.T) {
+	tests := []struct {
+		name      string
+		numbering WeekNumbering
+		date      time.Time
+		wantYear  int
+		wantWeek  int
+	}{
+		{"ISO mid year", WeekNumberingISO, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), 2024, 20},
+		{"ISO New Year's Eve in next year's week 1", WeekNumberingISO, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), 2025, 1},
+		{"ISO January 1 in last year's week 53", WeekNumberingISO, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 2020, 53},
+		{"US mid year", WeekNumberingUS, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), 2024, 20},
+		{"US Sunday starts the next week", WeekNumberingUS, time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC), 2024, 21},
+		{"US January 1 is week 1", WeekNumberingUS, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 2021, 1},
+		{"US first Sunday starts week 2", WeekNumberingUS, time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC), 2021, 2},
+		{"US week spanning New Year belongs to the new year", WeekNumberingUS, time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), 2025, 1},
+		{"US last full week of the year", WeekNumberingUS, time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC), 2024, 52},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			year, week := tt.numbering.Week(tt.date)
+			if year != tt.wantYear || week != tt.wantWeek {
+				t.Errorf("Week() = %d-W%02d, want %d-W%02d", year, week, tt.wantYear, tt.wantWeek)
+			}
+		})
+	}
+}